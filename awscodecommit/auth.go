@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend, i.e. the Git endpoint
+	// of CodeCommit in the us-east-1 region.
+	DefaultDomain = "git-codecommit.us-east-1.amazonaws.com"
+
+	// tokenProviderName is the name of the credentials providers created from tokens.
+	tokenProviderName = "GitProviderToken"
+	// tokenExpiryWindow is how long before their expiry credentials from a token source are refreshed.
+	tokenExpiryWindow = 10 * time.Second
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		HostHints: []string{"codecommit"},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// The token consists of the access key ID and secret access key given as user info
+	var token string
+	if u.User != nil {
+		token = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			token += ":" + password
+		}
+	}
+	return NewClient(token, append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for AWS CodeCommit API endpoints.
+//
+// CodeCommit authenticates API calls using IAM credentials, which are given as token of the form
+// "<access key ID>:<secret access key>", optionally followed by ":<session token>" for temporary
+// credentials. Passing an empty token uses the default credential chain of the AWS SDK instead,
+// i.e. the AWS_* environment variables, the shared configuration files, and the IAM role of the
+// EC2 instance or ECS task. Use NewClientWithTokenSource for credentials that are rotated.
+//
+// The region is derived from the domain, which is the Git endpoint of CodeCommit in that region,
+// e.g. "git-codecommit.eu-west-1.amazonaws.com". The domain defaults to the us-east-1 region.
+//
+// CodeCommit repositories belong to an AWS account, which is used as organization, and as user,
+// with the account ID as name. The authentication options, e.g. WithOAuth2Token and
+// WithTokenSource, fail with ErrInvalidClientOptions, as they would replace the signature of the
+// requests with a bearer token.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Use the default credential chain of the session, if no token is given
+	var creds *credentials.Credentials
+	if token != "" {
+		value, err := parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewStaticCredentialsFromCreds(value)
+	}
+	return newClientWithCredentials(creds, optFns...)
+}
+
+// NewClientWithTokenSource creates a new gitprovider.Client instance for AWS CodeCommit API
+// endpoints like NewClient, using the IAM credentials returned by source. The returned tokens
+// must have the same format as the token passed to NewClient, and are cached until shortly
+// before their expiry.
+func NewClientWithTokenSource(source gitprovider.TokenSourceFunc, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Don't allow an empty value
+	if source == nil {
+		return nil, fmt.Errorf("source cannot be nil: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	return newClientWithCredentials(credentials.NewCredentials(&tokenSourceProvider{source: source}), optFns...)
+}
+
+func newClientWithCredentials(creds *credentials.Credentials, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	// The requests are signed with the IAM credentials
+	if opts.HasAuthentication() {
+		return nil, fmt.Errorf("the authentication options aren't supported, pass the IAM credentials as token: %w", gitprovider.ErrInvalidClientOptions)
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = *opts.Domain
+	}
+	region, err := regionFromDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			Region:      aws.String(region),
+			Credentials: creds,
+			HTTPClient:  httpClient,
+		},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an AWS session: %v: %w", err, gitprovider.ErrInvalidClientOptions)
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(codecommit.New(sess), sts.New(sess), domain, destructiveActions), nil
+}
+
+// regionFromDomain returns the region of the CodeCommit Git endpoint domain, e.g. "eu-west-1" for
+// "git-codecommit.eu-west-1.amazonaws.com".
+func regionFromDomain(domain string) (string, error) {
+	if host := strings.TrimPrefix(domain, "git-codecommit."); host != domain {
+		for _, suffix := range []string{".amazonaws.com", ".amazonaws.com.cn"} {
+			if region := strings.TrimSuffix(host, suffix); region != host && region != "" && !strings.Contains(region, ".") {
+				return region, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("domain %q isn't a codecommit git endpoint, e.g. %q: %w", domain, DefaultDomain, gitprovider.ErrInvalidClientOptions)
+}
+
+// parseToken parses IAM credentials of the form "<access key ID>:<secret access key>[:<session token>]".
+func parseToken(token string) (credentials.Value, error) {
+	parts := strings.SplitN(token, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return credentials.Value{}, fmt.Errorf("token must be of the form <access key ID>:<secret access key>[:<session token>]: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	value := credentials.Value{
+		AccessKeyID:     parts[0],
+		SecretAccessKey: parts[1],
+		ProviderName:    tokenProviderName,
+	}
+	if len(parts) == 3 {
+		value.SessionToken = parts[2]
+	}
+	return value, nil
+}
+
+// tokenSourceProvider is a credentials.Provider returning the IAM credentials of a token source.
+type tokenSourceProvider struct {
+	credentials.Expiry
+
+	source gitprovider.TokenSourceFunc
+}
+
+// tokenSourceProvider implements credentials.ProviderWithContext.
+var _ credentials.ProviderWithContext = &tokenSourceProvider{}
+
+// Retrieve returns the credentials of the token source.
+func (p *tokenSourceProvider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithContext(context.Background())
+}
+
+// RetrieveWithContext returns the credentials of the token source. A zero expiry means the
+// credentials aren't cached, as the Expiry is already expired in that case.
+func (p *tokenSourceProvider) RetrieveWithContext(ctx credentials.Context) (credentials.Value, error) {
+	token, expiry, err := p.source(ctx)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("failed to get a token from the token source: %w", err)
+	}
+	value, err := parseToken(token)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	p.SetExpiration(expiry, tokenExpiryWindow)
+	return value, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestNewClient_authenticationOptions(t *testing.T) {
+	// The AWS session fails to load a custom CA bundle with the transport chain
+	t.Setenv("AWS_CA_BUNDLE", "")
+	source := func(context.Context) (string, time.Time, error) {
+		return "token", time.Time{}, nil
+	}
+	tests := []struct {
+		name      string
+		newClient func() (gitprovider.Client, error)
+	}{
+		{
+			name: "WithOAuth2Token",
+			newClient: func() (gitprovider.Client, error) {
+				return NewClient("AKID:SECRET", gitprovider.WithOAuth2Token("token"))
+			},
+		},
+		{
+			name: "WithTokenSource",
+			newClient: func() (gitprovider.Client, error) {
+				return NewClient("", gitprovider.WithTokenSource(source))
+			},
+		},
+		{
+			name: "WithOAuth2Token and a token source",
+			newClient: func() (gitprovider.Client, error) {
+				return NewClientWithTokenSource(source, gitprovider.WithOAuth2Token("token"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.newClient(); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+				t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+			}
+		})
+	}
+}
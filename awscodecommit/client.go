@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for AWS CodeCommit.
+const ProviderID = gitprovider.ProviderID("awscodecommit")
+
+func newClient(ccClient codecommitiface.CodeCommitAPI, stsClient stsiface.STSAPI, domain string, destructiveActions bool) *Client {
+	c := &codeCommitClientImpl{c: ccClient, sts: stsClient, destructiveActions: destructiveActions}
+	ctx := &clientContext{c, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  codeCommitClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "git-codecommit.us-east-1.amazonaws.com".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "awscodecommit".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the codecommitiface.CodeCommitAPI used under the hood for accessing CodeCommit.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeOrganization if owner is the ID of the AWS account of the
+// credentials. The account can also be used as user, but is reported as organization, as it
+// isn't a person.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// sts:GetCallerIdentity
+	if err := validateAccount(ctx, c.c, owner); err != nil {
+		return "", err
+	}
+	return gitprovider.OwnerTypeOrganization, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as CodeCommit doesn't support starring repositories.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as CodeCommit has no license templates.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as CodeCommit has no .gitignore templates.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as the permissions of IAM credentials
+// are given by policies, which can't be mapped to token permissions.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as IAM credentials have no scopes.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as AWS CodeCommit is accessed using IAM
+// credentials instead of a token.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
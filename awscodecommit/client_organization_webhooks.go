@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationWebhookClient implements the gitprovider.OrganizationWebhookClient interface.
+var _ gitprovider.OrganizationWebhookClient = &OrganizationWebhookClient{}
+
+// OrganizationWebhookClient handles webhooks, which are not available in CodeCommit.
+type OrganizationWebhookClient struct{}
+
+// Get always returns ErrNoProviderSupport, as CodeCommit has no webhooks.
+func (c *OrganizationWebhookClient) Get(_ context.Context, _ string) (gitprovider.OrganizationWebhook, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as CodeCommit has no webhooks.
+func (c *OrganizationWebhookClient) List(_ context.Context) ([]gitprovider.OrganizationWebhook, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as CodeCommit has no webhooks.
+func (c *OrganizationWebhookClient) Create(_ context.Context, _ gitprovider.WebhookInfo) (gitprovider.OrganizationWebhook, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as CodeCommit has no webhooks.
+func (c *OrganizationWebhookClient) Reconcile(_ context.Context, _ gitprovider.WebhookInfo, _ ...gitprovider.WebhookReconcileOption) (gitprovider.OrganizationWebhook, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the AWS account of the credentials, which is the only
+// organization CodeCommit repositories can be accessed in.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get returns the AWS account with the ID in ref.Organization.
+// This can't refer to a sub-organization, as AWS accounts can't be nested.
+//
+// ErrNotFound is returned if the account isn't the account of the credentials.
+func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// sts:GetCallerIdentity
+	if err := validateAccount(ctx, c.c, ref.Organization); err != nil {
+		return nil, err
+	}
+
+	return newOrganization(c.clientContext, &Account{ID: ref.Organization}, ref), nil
+}
+
+// List returns the AWS account of the credentials.
+func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organization, error) {
+	// sts:GetCallerIdentity
+	accountID, err := c.c.GetAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []gitprovider.Organization{
+		newOrganization(c.clientContext, &Account{ID: accountID}, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: accountID,
+		}),
+	}, nil
+}
+
+// Children always returns ErrNoProviderSupport, as AWS accounts can't be nested.
+func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as access to CodeCommit
+// repositories is given by IAM policies.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as access to CodeCommit
+// repositories is given by IAM policies.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as access to
+// CodeCommit repositories is given by IAM policies.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
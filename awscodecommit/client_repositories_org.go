@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient operates on the repositories in the AWS account of the credentials.
+// The Organization of the references is the ID of the account.
+type OrgRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	// Make sure the OrgRepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	// sts:GetCallerIdentity
+	if err := validateAccount(ctx, c.c, ref.Organization); err != nil {
+		return nil, err
+	}
+	// codecommit:GetRepository
+	apiObj, err := c.c.GetRepo(ctx, ref.RepositoryName)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, ref), nil
+}
+
+// List all repositories in the given AWS account.
+//
+// List returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given AWS account like List, and calls
+// progress after each page. CodeCommit doesn't tell the total, which is hence only estimated
+// once the last page has been listed.
+//
+// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	// sts:GetCallerIdentity
+	if err := validateAccount(ctx, c.c, ref.Organization); err != nil {
+		return nil, err
+	}
+
+	// codecommit:ListRepositories
+	apiObjs, err := c.c.ListRepos(ctx, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListRepos
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  *apiObj.RepositoryName,
+		}))
+	}
+	return repos, nil
+}
+
+// SearchPage always returns ErrNoProviderSupport, as CodeCommit can't search repositories.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create creates a repository in the given AWS account, with the data and options.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.c, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repo := newOrgRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+// Transfer always returns ErrNoProviderSupport, as CodeCommit repositories can't be moved
+// between AWS accounts.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// createRepository creates the repository, and returns it together with the ID of the initial
+// commit if the AutoInit option is set. CodeCommit always creates empty repositories, hence
+// the initial commit adding a README.md is created afterwards, which also sets the default
+// branch.
+func createRepository(ctx context.Context, c codeCommitClient, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*codecommit.RepositoryMetadata, *string, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.LicenseTemplate != nil {
+		return nil, nil, fmt.Errorf("aws codecommit has no license templates: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.VerifyVisibility != nil {
+		return nil, nil, fmt.Errorf("option VerifyVisibility isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if _, isOrg := ref.(gitprovider.OrgRepositoryRef); isOrg && o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		return nil, nil, fmt.Errorf("option CheckOrgMembership isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	// sts:GetCallerIdentity
+	if err := validateAccount(ctx, c, ref.GetIdentity()); err != nil {
+		return nil, nil, err
+	}
+
+	apiObj := &codecommit.RepositoryMetadata{}
+	if err := repositoryInfoToAPIObj(&req, apiObj); err != nil {
+		return nil, nil, err
+	}
+	// codecommit:CreateRepository
+	apiObj, err = c.CreateRepo(ctx, ref.GetRepository(), apiObj.RepositoryDescription)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil, nil
+	}
+
+	// codecommit:CreateCommit
+	files := map[string]string{"README.md": fmt.Sprintf("# %s\n", ref.GetRepository())}
+	commitID, err := c.CreateCommit(ctx, ref.GetRepository(), *req.DefaultBranch, "Initial commit", files)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The first commit sets the default branch
+	apiObj.DefaultBranch = aws.String(*req.DefaultBranch)
+	return apiObj, &commitID, nil
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchClient implements the gitprovider.BranchClient interface.
+var _ gitprovider.BranchClient = &BranchClient{}
+
+// BranchClient operates on the branches of a specific repository.
+type BranchClient struct{}
+
+// Create always returns ErrNoProviderSupport, as creating branches isn't implemented yet.
+func (c *BranchClient) Create(_ context.Context, _, _ string) error {
+	return errNotImplemented("creating branches")
+}
+
+// List always returns ErrNoProviderSupport, as listing branches isn't implemented yet.
+func (c *BranchClient) List(_ context.Context) ([]gitprovider.BranchInfo, error) {
+	return nil, errNotImplemented("listing branches")
+}
+
+// Delete always returns ErrNoProviderSupport, as deleting branches isn't implemented yet.
+func (c *BranchClient) Delete(_ context.Context, _ string) error {
+	return errNotImplemented("deleting branches")
+}
+
+// Rename always returns ErrNoProviderSupport, as renaming branches isn't implemented yet.
+func (c *BranchClient) Rename(_ context.Context, _, _ string) error {
+	return errNotImplemented("renaming branches")
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"io"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient handles releases, which are not available in CodeCommit.
+type ReleaseClient struct{}
+
+// Get always returns ErrNoProviderSupport, as CodeCommit has no releases.
+func (c *ReleaseClient) Get(_ context.Context, _ string) (gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as CodeCommit has no releases.
+func (c *ReleaseClient) List(_ context.Context) ([]gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// DownloadAsset always returns ErrNoProviderSupport, as CodeCommit has no releases.
+func (c *ReleaseClient) DownloadAsset(_ context.Context, _ gitprovider.ReleaseAsset) (io.ReadCloser, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
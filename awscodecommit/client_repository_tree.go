@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TreeClient implements the gitprovider.TreeClient interface.
+var _ gitprovider.TreeClient = &TreeClient{}
+
+// TreeClient operates on the trees of a specific repository.
+type TreeClient struct{}
+
+// Get always returns ErrNoProviderSupport, as reading trees isn't implemented yet.
+func (c *TreeClient) Get(_ context.Context, _ string, _ bool) (*gitprovider.TreeInfo, error) {
+	return nil, errNotImplemented("reading trees")
+}
+
+// List always returns ErrNoProviderSupport, as reading trees isn't implemented yet.
+func (c *TreeClient) List(_ context.Context, _ string, _ string, _ bool) ([]*gitprovider.TreeEntry, error) {
+	return nil, errNotImplemented("reading trees")
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// batchGetRepositoriesLimit is the maximum number of repositories BatchGetRepositories accepts.
+const batchGetRepositoriesLimit = 100
+
+// codeCommitClient is a wrapper around codecommitiface.CodeCommitAPI, which implements
+// higher-level methods, operating on the CodeCommit structs. Pagination is implemented for all
+// List* methods, all returned objects are validated, and errors are handled/wrapped using
+// handleHTTPError. This interface is also fakeable, in order to unit-test the client.
+type codeCommitClient interface {
+	// Client returns the underlying codecommitiface.CodeCommitAPI
+	Client() codecommitiface.CodeCommitAPI
+
+	// GetAccountID is a wrapper for "sts:GetCallerIdentity", returning the ID of the AWS account
+	// the credentials belong to. The account ID is cached after the first successful call.
+	// This function handles HTTP error wrapping.
+	GetAccountID(ctx context.Context) (string, error)
+
+	// GetRepo is a wrapper for "codecommit:GetRepository".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, repo string) (*codecommit.RepositoryMetadata, error)
+	// ListRepos is a wrapper for "codecommit:ListRepositories", followed by
+	// "codecommit:BatchGetRepositories" for the metadata of the listed repositories.
+	// progress is called after each page, if non-nil.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepos(ctx context.Context, progress gitprovider.ListProgressFunc) ([]*codecommit.RepositoryMetadata, error)
+	// CreateRepo is a wrapper for "codecommit:CreateRepository".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, repo string, description *string) (*codecommit.RepositoryMetadata, error)
+	// UpdateRepo is a wrapper for "codecommit:UpdateRepositoryDescription", and
+	// "codecommit:UpdateDefaultBranch" if defaultBranch is non-nil and differs from the current
+	// one. Empty repositories have no default branch, CodeCommit uses the first pushed branch
+	// instead, hence defaultBranch is ignored for them. The updated repository is returned
+	// using "codecommit:GetRepository".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, repo string, description, defaultBranch *string) (*codecommit.RepositoryMetadata, error)
+	// DeleteRepo is a wrapper for "codecommit:DeleteRepository".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, repo string) error
+
+	// CreateCommit is a wrapper for "codecommit:CreateCommit", committing files, a map of paths
+	// to contents, to the given branch. The ID of the commit is returned.
+	// This function handles HTTP error wrapping.
+	CreateCommit(ctx context.Context, repo, branch, message string, files map[string]string) (string, error)
+}
+
+// codeCommitClientImpl is a wrapper around codecommitiface.CodeCommitAPI, which implements
+// higher-level methods, operating on the CodeCommit structs.
+type codeCommitClientImpl struct {
+	c                  codecommitiface.CodeCommitAPI
+	sts                stsiface.STSAPI
+	destructiveActions bool
+
+	// accountIDMu guards accountID, which is cached by GetAccountID.
+	accountIDMu sync.Mutex
+	accountID   string
+}
+
+// codeCommitClientImpl implements codeCommitClient.
+var _ codeCommitClient = &codeCommitClientImpl{}
+
+func (c *codeCommitClientImpl) Client() codecommitiface.CodeCommitAPI {
+	return c.c
+}
+
+func (c *codeCommitClientImpl) GetAccountID(ctx context.Context) (string, error) {
+	c.accountIDMu.Lock()
+	defer c.accountIDMu.Unlock()
+
+	if c.accountID != "" {
+		return c.accountID, nil
+	}
+	// sts:GetCallerIdentity
+	identity, err := c.sts.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	if aws.StringValue(identity.Account) == "" {
+		return "", fmt.Errorf("caller identity without account: %w", gitprovider.ErrInvalidServerData)
+	}
+	c.accountID = *identity.Account
+	return c.accountID, nil
+}
+
+func (c *codeCommitClientImpl) GetRepo(ctx context.Context, repo string) (*codecommit.RepositoryMetadata, error) {
+	// codecommit:GetRepository
+	out, err := c.c.GetRepositoryWithContext(ctx, &codecommit.GetRepositoryInput{
+		RepositoryName: aws.String(repo),
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateRepositoryAPI(out.RepositoryMetadata); err != nil {
+		return nil, err
+	}
+	return out.RepositoryMetadata, nil
+}
+
+func (c *codeCommitClientImpl) ListRepos(ctx context.Context, progress gitprovider.ListProgressFunc) ([]*codecommit.RepositoryMetadata, error) {
+	names := []*string{}
+	// codecommit:ListRepositories
+	err := c.c.ListRepositoriesPagesWithContext(ctx, &codecommit.ListRepositoriesInput{
+		SortBy: aws.String(codecommit.SortByEnumRepositoryName),
+	}, func(page *codecommit.ListRepositoriesOutput, lastPage bool) bool {
+		for _, repo := range page.Repositories {
+			names = append(names, repo.RepositoryName)
+		}
+		if progress != nil {
+			progress(listProgress(len(names), lastPage))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	apiObjs := make([]*codecommit.RepositoryMetadata, 0, len(names))
+	for start := 0; start < len(names); start += batchGetRepositoriesLimit {
+		end := start + batchGetRepositoriesLimit
+		if end > len(names) {
+			end = len(names)
+		}
+		// codecommit:BatchGetRepositories
+		out, err := c.c.BatchGetRepositoriesWithContext(ctx, &codecommit.BatchGetRepositoriesInput{
+			RepositoryNames: names[start:end],
+		})
+		if err != nil {
+			return nil, handleHTTPError(err)
+		}
+		// Repositories deleted after they were listed are returned as not found, and skipped
+		apiObjs = append(apiObjs, sortRepositories(out.Repositories, names[start:end])...)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *codeCommitClientImpl) CreateRepo(ctx context.Context, repo string, description *string) (*codecommit.RepositoryMetadata, error) {
+	// codecommit:CreateRepository
+	out, err := c.c.CreateRepositoryWithContext(ctx, &codecommit.CreateRepositoryInput{
+		RepositoryName:        aws.String(repo),
+		RepositoryDescription: description,
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateRepositoryAPI(out.RepositoryMetadata); err != nil {
+		return nil, err
+	}
+	return out.RepositoryMetadata, nil
+}
+
+func (c *codeCommitClientImpl) UpdateRepo(ctx context.Context, repo string, description, defaultBranch *string) (*codecommit.RepositoryMetadata, error) {
+	// codecommit:UpdateRepositoryDescription
+	if _, err := c.c.UpdateRepositoryDescriptionWithContext(ctx, &codecommit.UpdateRepositoryDescriptionInput{
+		RepositoryName:        aws.String(repo),
+		RepositoryDescription: description,
+	}); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// codecommit:GetRepository
+	apiObj, err := c.GetRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if defaultBranch != nil && apiObj.DefaultBranch != nil && *apiObj.DefaultBranch != *defaultBranch {
+		// codecommit:UpdateDefaultBranch
+		if _, err := c.c.UpdateDefaultBranchWithContext(ctx, &codecommit.UpdateDefaultBranchInput{
+			RepositoryName:    aws.String(repo),
+			DefaultBranchName: defaultBranch,
+		}); err != nil {
+			return nil, handleHTTPError(err)
+		}
+		// codecommit:GetRepository
+		return c.GetRepo(ctx, repo)
+	}
+	return apiObj, nil
+}
+
+func (c *codeCommitClientImpl) DeleteRepo(ctx context.Context, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// codecommit:DeleteRepository
+	_, err := c.c.DeleteRepositoryWithContext(ctx, &codecommit.DeleteRepositoryInput{
+		RepositoryName: aws.String(repo),
+	})
+	return handleHTTPError(err)
+}
+
+func (c *codeCommitClientImpl) CreateCommit(ctx context.Context, repo, branch, message string, files map[string]string) (string, error) {
+	putFiles := make([]*codecommit.PutFileEntry, 0, len(files))
+	for path, content := range files {
+		putFiles = append(putFiles, &codecommit.PutFileEntry{
+			FilePath:    aws.String(path),
+			FileContent: []byte(content),
+		})
+	}
+	// codecommit:CreateCommit
+	out, err := c.c.CreateCommitWithContext(ctx, &codecommit.CreateCommitInput{
+		RepositoryName: aws.String(repo),
+		BranchName:     aws.String(branch),
+		CommitMessage:  aws.String(message),
+		PutFiles:       putFiles,
+	})
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	if aws.StringValue(out.CommitId) == "" {
+		return "", fmt.Errorf("created commit without ID: %w", gitprovider.ErrInvalidServerData)
+	}
+	return *out.CommitId, nil
+}
+
+// sortRepositories returns the repositories in the order of names, as BatchGetRepositories
+// doesn't keep the order.
+func sortRepositories(repos []*codecommit.RepositoryMetadata, names []*string) []*codecommit.RepositoryMetadata {
+	byName := make(map[string]*codecommit.RepositoryMetadata, len(repos))
+	for _, repo := range repos {
+		byName[aws.StringValue(repo.RepositoryName)] = repo
+	}
+	sorted := make([]*codecommit.RepositoryMetadata, 0, len(repos))
+	for _, name := range names {
+		if repo, ok := byName[aws.StringValue(name)]; ok {
+			sorted = append(sorted, repo)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_codeCommitClientImpl_ListRepos(t *testing.T) {
+	// List more repositories than BatchGetRepositories accepts at once, over two pages
+	names := []string{}
+	for i := 0; i < batchGetRepositoriesLimit+2; i++ {
+		names = append(names, fmt.Sprintf("repo-%03d", i))
+	}
+	const deleted = "repo-001"
+	firstPage := names[:batchGetRepositoriesLimit/2]
+
+	batches := [][]string{}
+	c := newTestClient(t, map[string]codeCommitHandler{
+		"ListRepositories": func(body map[string]interface{}) (int, interface{}) {
+			if body["sortBy"] != "repositoryName" {
+				t.Errorf("sortBy = %v, want repositoryName", body["sortBy"])
+			}
+			page, nextToken := firstPage, "page-2"
+			if body["nextToken"] == "page-2" {
+				page, nextToken = names[len(firstPage):], ""
+			}
+			repos := []map[string]string{}
+			for _, name := range page {
+				repos = append(repos, map[string]string{"repositoryName": name})
+			}
+			resp := map[string]interface{}{"repositories": repos}
+			if nextToken != "" {
+				resp["nextToken"] = nextToken
+			}
+			return http.StatusOK, resp
+		},
+		"BatchGetRepositories": func(body map[string]interface{}) (int, interface{}) {
+			requested, _ := body["repositoryNames"].([]interface{})
+			batch := []string{}
+			repos := []interface{}{}
+			notFound := []string{}
+			// Return the repositories in reverse order, which must not affect the result
+			for i := len(requested) - 1; i >= 0; i-- {
+				name := requested[i].(string)
+				batch = append([]string{name}, batch...)
+				// Repositories deleted since they were listed are reported as not found
+				if name == deleted {
+					notFound = append(notFound, name)
+					continue
+				}
+				repos = append(repos, repositoryMetadata(name))
+			}
+			batches = append(batches, batch)
+			return http.StatusOK, map[string]interface{}{"repositories": repos, "repositoriesNotFound": notFound}
+		},
+	})
+
+	progress := []gitprovider.ListProgress{}
+	repos, err := c.c.ListRepos(context.Background(), func(p gitprovider.ListProgress) { progress = append(progress, p) })
+	if err != nil {
+		t.Fatalf("ListRepos() error = %v", err)
+	}
+
+	got := []string{}
+	for _, repo := range repos {
+		got = append(got, *repo.RepositoryName)
+	}
+	// All repositories but the deleted one, in the listed order
+	want := append(append([]string{}, names[:1]...), names[2:]...)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListRepos() = %v, want %v", got, want)
+	}
+	wantBatches := [][]string{names[:batchGetRepositoriesLimit], names[batchGetRepositoriesLimit:]}
+	if !reflect.DeepEqual(batches, wantBatches) {
+		t.Errorf("BatchGetRepositories requests = %v, want %v", batches, wantBatches)
+	}
+	// CodeCommit doesn't tell the total, which is hence only known after the last page
+	wantProgress := []gitprovider.ListProgress{{Count: len(firstPage)}, {Count: len(names), EstimatedTotal: len(names)}}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("progress = %v, want %v", progress, wantProgress)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Account is the AWS account of the credentials, which is exposed as organization.
+type Account struct {
+	// ID is the 12-digit ID of the account.
+	ID string
+}
+
+func newOrganization(ctx *clientContext, apiObj *Account, ref gitprovider.OrganizationRef) *organization {
+	return &organization{
+		clientContext:     ctx,
+		a:                 *apiObj,
+		ref:               ref,
+		teams:             &TeamsClient{},
+		runners:           &RunnersClient{},
+		appAuthorizations: &AppAuthorizationsClient{},
+		projectBoards:     &ProjectBoardsClient{},
+		webhooks:          &OrganizationWebhookClient{},
+	}
+}
+
+var _ gitprovider.Organization = &organization{}
+
+type organization struct {
+	*clientContext
+
+	a   Account
+	ref gitprovider.OrganizationRef
+
+	teams             *TeamsClient
+	runners           *RunnersClient
+	appAuthorizations *AppAuthorizationsClient
+	projectBoards     *ProjectBoardsClient
+	webhooks          *OrganizationWebhookClient
+}
+
+func (o *organization) Get() gitprovider.OrganizationInfo {
+	return organizationFromAPI(&o.a)
+}
+
+func (o *organization) APIObject() interface{} {
+	return &o.a
+}
+
+func (o *organization) Organization() gitprovider.OrganizationRef {
+	return o.ref
+}
+
+func (o *organization) Teams() gitprovider.TeamsClient {
+	return o.teams
+}
+
+func (o *organization) Runners() gitprovider.RunnersClient {
+	return o.runners
+}
+
+func (o *organization) AppAuthorizations() gitprovider.AppAuthorizationsClient {
+	return o.appAuthorizations
+}
+
+func (o *organization) ProjectBoards() gitprovider.ProjectBoardsClient {
+	return o.projectBoards
+}
+
+func (o *organization) Webhooks() gitprovider.OrganizationWebhookClient {
+	return o.webhooks
+}
+
+func organizationFromAPI(apiObj *Account) gitprovider.OrganizationInfo {
+	return gitprovider.OrganizationInfo{
+		Name: gitprovider.StringVar(apiObj.ID),
+	}
+}
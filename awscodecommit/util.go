@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	errorsDocURL    = "https://docs.aws.amazon.com/codecommit/latest/APIReference/CommonErrors.html"
+	rateLimitDocURL = "https://docs.aws.amazon.com/codecommit/latest/userguide/limits.html"
+)
+
+// invalidCredentialsErrorCodes are the codes of the errors AWS returns for invalid or expired
+// credentials, including the one of the SDK if no credentials could be found.
+//
+//nolint:gochecknoglobals
+var invalidCredentialsErrorCodes = map[string]bool{
+	"UnrecognizedClientException": true,
+	"InvalidClientTokenId":        true,
+	"InvalidSignatureException":   true,
+	"SignatureDoesNotMatch":       true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"NoCredentialProviders":       true,
+}
+
+// rateLimitErrorCodes are the codes of the errors AWS returns when requests are throttled.
+//
+//nolint:gochecknoglobals
+var rateLimitErrorCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"TooManyRequestsException":               true,
+	"RequestLimitExceeded":                   true,
+	"ProvisionedThroughputExceededException": true,
+}
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for CodeCommit's usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for CodeCommit's usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for CodeCommit's usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for CodeCommit's usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser:
+		return nil
+	case gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("aws accounts can't be nested: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// validateAccount makes sure the given identity is the AWS account of the credentials, as
+// CodeCommit repositories can only be accessed in the account they belong to. ErrNotFound is
+// returned for other accounts.
+func validateAccount(ctx context.Context, c codeCommitClient, identity string) error {
+	// sts:GetCallerIdentity
+	accountID, err := c.GetAccountID(ctx)
+	if err != nil {
+		return err
+	}
+	if identity != accountID {
+		return fmt.Errorf("aws account %q isn't the account %q of the credentials: %w", identity, accountID, gitprovider.ErrNotFound)
+	}
+	return nil
+}
+
+// handleHTTPError checks the type of err, and returns typed variants of it.
+// However, it _always_ keeps the original error too, and just wraps it in a MultiError.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(err error) error {
+	// Short-circuit quickly if possible, allow always piping through this function
+	if err == nil {
+		return nil
+	}
+	awsErr := awserr.Error(nil)
+	if !errors.As(err, &awsErr) {
+		return err
+	}
+
+	httpErr := gitprovider.HTTPError{
+		ErrorMessage:     err.Error(),
+		Message:          awsErr.Message(),
+		DocumentationURL: errorsDocURL,
+	}
+	statusCode := 0
+	reqErr := awserr.RequestFailure(nil)
+	if errors.As(err, &reqErr) {
+		statusCode = reqErr.StatusCode()
+	}
+
+	switch code := awsErr.Code(); {
+	case code == codecommit.ErrCodeRepositoryDoesNotExistException, statusCode == http.StatusNotFound:
+		return validation.NewMultiError(err, &httpErr, gitprovider.ErrNotFound)
+	case code == codecommit.ErrCodeRepositoryNameExistsException:
+		return validation.NewMultiError(err, &httpErr, gitprovider.ErrAlreadyExists)
+	case invalidCredentialsErrorCodes[code], statusCode == http.StatusUnauthorized:
+		// Check for invalid credentials, and return a typed error in that case
+		return validation.NewMultiError(err, &gitprovider.InvalidCredentialsError{HTTPError: httpErr})
+	case code == "AccessDeniedException", code == "AccessDenied", statusCode == http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(err, &gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case rateLimitErrorCodes[code], statusCode == http.StatusTooManyRequests:
+		// AWS doesn't tell the limits, only that they have been exceeded
+		httpErr.DocumentationURL = rateLimitDocURL
+		return validation.NewMultiError(err, &gitprovider.RateLimitError{HTTPError: httpErr})
+	}
+	// Otherwise, return a generic *HTTPError
+	return validation.NewMultiError(err, &httpErr)
+}
+
+// listProgress returns the progress after count repositories have been listed. CodeCommit
+// doesn't tell the total, which is hence only known once the last page has been listed.
+func listProgress(count int, lastPage bool) gitprovider.ListProgress {
+	if lastPage {
+		return gitprovider.ListProgress{Count: count, EstimatedTotal: count}
+	}
+	return gitprovider.ListProgress{Count: count}
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that CodeCommit has, but which aren't
+// implemented by this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for aws codecommit yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
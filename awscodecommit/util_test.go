@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awscodecommit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const testAccountID = "123456789012"
+
+// fakeSTS returns testAccountID as the account of the credentials.
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (fakeSTS) GetCallerIdentityWithContext(_ aws.Context, _ *sts.GetCallerIdentityInput, _ ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Account: aws.String(testAccountID)}, nil
+}
+
+// codeCommitHandler handles a CodeCommit action with the decoded request body, and returns the
+// status code and response body.
+type codeCommitHandler func(body map[string]interface{}) (int, interface{})
+
+// newTestClient returns a client for a fake CodeCommit API, which dispatches the requests to
+// the handlers by their action, e.g. "GetRepository".
+func newTestClient(t *testing.T, handlers map[string]codeCommitHandler) *Client {
+	t.Helper()
+	srv := testutil.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "CodeCommit_20150413.")
+		handler, ok := handlers[action]
+		if !ok {
+			t.Errorf("unexpected action %q", action)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body := map[string]interface{}{}
+		testutil.ReadJSON(t, r, &body)
+		status, resp := handler(body)
+		testutil.WriteJSON(w, status, resp)
+	}))
+	sess := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(srv.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		HTTPClient:  srv.Client(),
+		MaxRetries:  aws.Int(0),
+	}))
+	return newClient(codecommit.New(sess), fakeSTS{}, DefaultDomain, false)
+}
+
+func repositoryMetadata(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"accountId":      testAccountID,
+		"repositoryId":   name + "-id",
+		"repositoryName": name,
+	}
+}
+
+func Test_handleHTTPError(t *testing.T) {
+	requestFailure := func(code string, status int) error {
+		return awserr.NewRequestFailure(awserr.New(code, "message", nil), status, "request-id")
+	}
+	errConnectionRefused := errors.New("dial tcp: connection refused")
+	tests := []struct {
+		name         string
+		err          error
+		expectedErrs []error
+	}{
+		{
+			name: "nil => nil",
+		},
+		{
+			name:         "non-AWS error => as is",
+			err:          errConnectionRefused,
+			expectedErrs: []error{errConnectionRefused},
+		},
+		{
+			name:         "RepositoryDoesNotExistException => ErrNotFound",
+			err:          requestFailure(codecommit.ErrCodeRepositoryDoesNotExistException, http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+		},
+		{
+			name:         "RepositoryNameExistsException => ErrAlreadyExists",
+			err:          requestFailure(codecommit.ErrCodeRepositoryNameExistsException, http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+		},
+		{
+			name:         "UnrecognizedClientException => InvalidCredentialsError",
+			err:          requestFailure("UnrecognizedClientException", http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			name:         "NoCredentialProviders without response => InvalidCredentialsError",
+			err:          awserr.New("NoCredentialProviders", "no valid providers in chain", nil),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			name:         "AccessDeniedException => InvalidCredentialsError & ErrForbidden",
+			err:          requestFailure("AccessDeniedException", http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+		},
+		{
+			name:         "ThrottlingException => RateLimitError",
+			err:          requestFailure("ThrottlingException", http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.RateLimitError{}},
+		},
+		{
+			name:         "other exception => HTTPError",
+			err:          requestFailure(codecommit.ErrCodeEncryptionKeyAccessDeniedException, http.StatusBadRequest),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.HTTPError{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleHTTPError(tt.err)
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+			// The AWS error is always kept, for the consumers to inspect its code
+			awsErr := awserr.Error(nil)
+			if tt.err != nil && errors.As(tt.err, &awsErr) && !errors.As(err, &awsErr) {
+				t.Errorf("handleHTTPError() error = %v, want the AWS error", err)
+			}
+		})
+	}
+}
+
+func Test_handleHTTPError_fromAPI(t *testing.T) {
+	c := newTestClient(t, map[string]codeCommitHandler{
+		"GetRepository": func(body map[string]interface{}) (int, interface{}) {
+			return http.StatusBadRequest, map[string]string{
+				"__type":  codecommit.ErrCodeRepositoryDoesNotExistException,
+				"message": fmt.Sprintf("%s does not exist", body["repositoryName"]),
+			}
+		},
+	})
+
+	_, err := c.c.GetRepo(context.Background(), "missing")
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("GetRepo() error = %v, want ErrNotFound", err)
+	}
+	httpErr := &gitprovider.HTTPError{}
+	if !errors.As(err, &httpErr) || httpErr.Message != "missing does not exist" {
+		t.Errorf("GetRepo() error = %v, want the message of the AWS error", err)
+	}
+}
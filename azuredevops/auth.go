@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "dev.azure.com"
+	// TokenVariable is the common name for the environment variable
+	// containing an Azure DevOps personal access token.
+	TokenVariable = "AZURE_DEVOPS_TOKEN" // #nosec G101
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// The organization is the first segment of the path, e.g. https://dev.azure.com/fabrikam
+	organization := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+	return NewClient(gitprovider.TokenFromURL(u), organization, append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for Azure DevOps API endpoints.
+//
+// The token is a personal access token, which needs the "Code" and "Project and Team" scopes,
+// and the "Tokens" scope for managing deploy keys and deploy tokens. Passing an empty token will
+// allow public read access only. The organization is the Azure DevOps organization all calls are
+// scoped to, e.g. "fabrikam" for https://dev.azure.com/fabrikam.
+//
+// Azure DevOps Server can be used if you specify the domain using WithDomain, e.g.
+// "devops.example.com/tfs", together with the name of the collection as organization.
+// The domain defaults to dev.azure.com.
+//
+// Azure Repos addresses all repositories by project. Projects are mapped to organizations,
+// as there are no repositories owned by users.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(token, organization string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	if organization == "" || strings.Contains(organization, "/") {
+		return nil, fmt.Errorf("invalid organization %q: %w", organization, gitprovider.ErrInvalidClientOptions)
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = strings.TrimRight(*opts.Domain, "/")
+	}
+	baseURL, err := url.Parse(gitprovider.GetDomainURL(domain) + "/" + url.PathEscape(organization) + "/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if baseURL.Host == "" || baseURL.RawQuery != "" || baseURL.Fragment != "" {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	// SSH keys and personal access tokens are managed by the token service, which has its own
+	// host in Azure DevOps Services, and is part of the collection in Azure DevOps Server
+	tokensURL := baseURL
+	if domain == DefaultDomain {
+		u := *baseURL
+		u.Host = "vssps." + u.Host
+		tokensURL = &u
+	}
+
+	return newClient(httpClient, baseURL, tokensURL, token, domain, destructiveActions), nil
+}
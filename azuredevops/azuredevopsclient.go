@@ -0,0 +1,441 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// apiVersion is the version of the Azure DevOps REST API, which has to be set on every request.
+	apiVersion = "7.1"
+	// tokensAPIVersion is the version of the session tokens API of the token service, which
+	// manages SSH public keys and personal access tokens, and is only available as preview.
+	tokensAPIVersion = "5.0-preview.1"
+)
+
+// azureDevOpsClient is a wrapper around the Azure DevOps REST API, which implements higher-level
+// methods, operating on the structs in types.go. All paths are relative to the organization, the
+// ones of the token service to its URL for the organization.
+// Pagination is implemented for all List* methods, all returned objects are validated, and HTTP
+// errors are handled/wrapped using handleHTTPError. This interface is also fakeable, in order to
+// unit-test the client.
+type azureDevOpsClient interface {
+	// Client returns the underlying *http.Client
+	Client() *http.Client
+
+	// GetProject is a wrapper for "GET /_apis/projects/{projectId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProject(ctx context.Context, project string) (*Project, error)
+	// ListProjects is a wrapper for "GET /_apis/projects".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjects(ctx context.Context) ([]*Project, error)
+
+	// GetRepo is a wrapper for "GET /{project}/_apis/git/repositories/{repositoryId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, project, repo string) (*GitRepository, error)
+	// ListRepos is a wrapper for "GET /{project}/_apis/git/repositories".
+	// Azure DevOps returns all repositories of a project at once.
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListRepos(ctx context.Context, project string) ([]*GitRepository, error)
+	// CreateRepo is a wrapper for "POST /{project}/_apis/git/repositories".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, project string, req *GitRepositoryCreateOptions) (*GitRepository, error)
+	// UpdateRepo is a wrapper for "PATCH /{project}/_apis/git/repositories/{repositoryId}".
+	// Only the set fields of req are changed.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, project, repo string, req *GitRepositoryUpdateOptions) (*GitRepository, error)
+	// DeleteRepo is a wrapper for "DELETE /{project}/_apis/git/repositories/{repositoryId}".
+	// repoID must be the ID of the repository, as the name isn't accepted.
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, project, repoID string) error
+
+	// CreatePush is a wrapper for "POST /{project}/_apis/git/repositories/{repositoryId}/pushes".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePush(ctx context.Context, project, repo string, req *GitPush) (*GitPush, error)
+
+	// ListPullRequests is a wrapper for "GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests",
+	// returning the pull requests matching the given search criteria, by default the active ones.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListPullRequests(ctx context.Context, project, repo string, criteria *GitPullRequestSearchCriteria) ([]*GitPullRequest, error)
+	// GetPullRequest is a wrapper for "GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetPullRequest(ctx context.Context, project, repo string, id int) (*GitPullRequest, error)
+	// CreatePullRequest is a wrapper for "POST /{project}/_apis/git/repositories/{repositoryId}/pullrequests".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePullRequest(ctx context.Context, project, repo string, req *GitPullRequest) (*GitPullRequest, error)
+	// UpdatePullRequest is a wrapper for "PATCH /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}".
+	// Only the set fields of req are changed.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdatePullRequest(ctx context.Context, project, repo string, id int, req *GitPullRequest) (*GitPullRequest, error)
+
+	// ListSessionTokens is a wrapper for "GET /_apis/token/sessiontokens" of the token service,
+	// returning the SSH public keys of the authenticated user if isPublic is true, and its
+	// personal access tokens otherwise. The token values are not part of the result.
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListSessionTokens(ctx context.Context, isPublic bool) ([]*SessionToken, error)
+	// CreateSessionToken is a wrapper for "POST /_apis/token/sessiontokens" of the token service,
+	// adding an SSH public key if req.IsPublic is true, and creating a personal access token otherwise.
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateSessionToken(ctx context.Context, req *SessionToken) (*SessionToken, error)
+	// DeleteSessionToken is a wrapper for "DELETE /_apis/token/sessiontokens/{authorizationId}" of
+	// the token service, removing an SSH public key if isPublic is true, and revoking a personal
+	// access token otherwise.
+	// This function handles HTTP error wrapping.
+	DeleteSessionToken(ctx context.Context, authorizationID string, isPublic bool) error
+}
+
+// azureDevOpsClientImpl is a wrapper around *http.Client, which implements higher-level methods,
+// operating on the structs in types.go.
+type azureDevOpsClientImpl struct {
+	c                  *http.Client
+	baseURL            *url.URL
+	tokensURL          *url.URL
+	token              string
+	destructiveActions bool
+}
+
+// azureDevOpsClientImpl implements azureDevOpsClient.
+var _ azureDevOpsClient = &azureDevOpsClientImpl{}
+
+func (c *azureDevOpsClientImpl) Client() *http.Client {
+	return c.c
+}
+
+func (c *azureDevOpsClientImpl) GetProject(ctx context.Context, project string) (*Project, error) {
+	apiObj := &Project{}
+	// GET /_apis/projects/{projectId}
+	if err := c.do(ctx, http.MethodGet, apiPath("_apis", "projects", project), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) ListProjects(ctx context.Context) ([]*Project, error) {
+	apiObjs := []*Project{}
+	// GET /_apis/projects
+	err := c.allPages(ctx, apiPath("_apis", "projects"), nil, func(values json.RawMessage) (int, error) {
+		pageObjs := []*Project{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProjectAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureDevOpsClientImpl) GetRepo(ctx context.Context, project, repo string) (*GitRepository, error) {
+	apiObj := &GitRepository{}
+	// GET /{project}/_apis/git/repositories/{repositoryId}
+	if err := c.do(ctx, http.MethodGet, apiPath(project, "_apis", "git", "repositories", repo), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) ListRepos(ctx context.Context, project string) ([]*GitRepository, error) {
+	l := &list{}
+	// GET /{project}/_apis/git/repositories
+	if err := c.do(ctx, http.MethodGet, apiPath(project, "_apis", "git", "repositories"), nil, l); err != nil {
+		return nil, err
+	}
+	apiObjs := []*GitRepository{}
+	if err := json.Unmarshal(l.Value, &apiObjs); err != nil {
+		return nil, fmt.Errorf("failed to decode the repositories of project %q: %v: %w", project, err, gitprovider.ErrInvalidServerData)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureDevOpsClientImpl) CreateRepo(ctx context.Context, project string, req *GitRepositoryCreateOptions) (*GitRepository, error) {
+	apiObj := &GitRepository{}
+	// POST /{project}/_apis/git/repositories
+	if err := c.do(ctx, http.MethodPost, apiPath(project, "_apis", "git", "repositories"), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) UpdateRepo(ctx context.Context, project, repo string, req *GitRepositoryUpdateOptions) (*GitRepository, error) {
+	apiObj := &GitRepository{}
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}
+	if err := c.do(ctx, http.MethodPatch, apiPath(project, "_apis", "git", "repositories", repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) DeleteRepo(ctx context.Context, project, repoID string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /{project}/_apis/git/repositories/{repositoryId}
+	return c.do(ctx, http.MethodDelete, apiPath(project, "_apis", "git", "repositories", repoID), nil, nil)
+}
+
+func (c *azureDevOpsClientImpl) CreatePush(ctx context.Context, project, repo string, req *GitPush) (*GitPush, error) {
+	apiObj := &GitPush{}
+	// POST /{project}/_apis/git/repositories/{repositoryId}/pushes
+	if err := c.do(ctx, http.MethodPost, apiPath(project, "_apis", "git", "repositories", repo, "pushes"), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validatePushAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) ListPullRequests(ctx context.Context, project, repo string, criteria *GitPullRequestSearchCriteria) ([]*GitPullRequest, error) {
+	query := url.Values{}
+	if criteria != nil {
+		if criteria.Status != "" {
+			query.Set("searchCriteria.status", criteria.Status)
+		}
+		if criteria.SourceRefName != "" {
+			query.Set("searchCriteria.sourceRefName", criteria.SourceRefName)
+		}
+		if criteria.TargetRefName != "" {
+			query.Set("searchCriteria.targetRefName", criteria.TargetRefName)
+		}
+	}
+	apiObjs := []*GitPullRequest{}
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	err := c.allPages(ctx, apiPath(project, "_apis", "git", "repositories", repo, "pullrequests"), query, func(values json.RawMessage) (int, error) {
+		pageObjs := []*GitPullRequest{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validatePullRequestAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureDevOpsClientImpl) GetPullRequest(ctx context.Context, project, repo string, id int) (*GitPullRequest, error) {
+	apiObj := &GitPullRequest{}
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	if err := c.do(ctx, http.MethodGet, apiPath(project, "_apis", "git", "repositories", repo, "pullrequests", strconv.Itoa(id)), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) CreatePullRequest(ctx context.Context, project, repo string, req *GitPullRequest) (*GitPullRequest, error) {
+	apiObj := &GitPullRequest{}
+	// POST /{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	if err := c.do(ctx, http.MethodPost, apiPath(project, "_apis", "git", "repositories", repo, "pullrequests"), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) UpdatePullRequest(ctx context.Context, project, repo string, id int, req *GitPullRequest) (*GitPullRequest, error) {
+	apiObj := &GitPullRequest{}
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	if err := c.do(ctx, http.MethodPatch, apiPath(project, "_apis", "git", "repositories", repo, "pullrequests", strconv.Itoa(id)), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) ListSessionTokens(ctx context.Context, isPublic bool) ([]*SessionToken, error) {
+	query := url.Values{
+		"isPublic":          {strconv.FormatBool(isPublic)},
+		"includePublicData": {"true"},
+		"api-version":       {tokensAPIVersion},
+	}
+	l := &list{}
+	// GET /_apis/token/sessiontokens
+	if err := c.doAt(ctx, c.tokensURL, http.MethodGet, apiPath("_apis", "token", "sessiontokens")+"?"+query.Encode(), nil, l); err != nil {
+		return nil, err
+	}
+	apiObjs := []*SessionToken{}
+	if err := json.Unmarshal(l.Value, &apiObjs); err != nil {
+		return nil, fmt.Errorf("failed to decode the session tokens: %v: %w", err, gitprovider.ErrInvalidServerData)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateSessionTokenAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureDevOpsClientImpl) CreateSessionToken(ctx context.Context, req *SessionToken) (*SessionToken, error) {
+	query := url.Values{"api-version": {tokensAPIVersion}}
+	apiObj := &SessionToken{}
+	// POST /_apis/token/sessiontokens
+	if err := c.doAt(ctx, c.tokensURL, http.MethodPost, apiPath("_apis", "token", "sessiontokens")+"?"+query.Encode(), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateSessionTokenAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureDevOpsClientImpl) DeleteSessionToken(ctx context.Context, authorizationID string, isPublic bool) error {
+	query := url.Values{
+		"isPublic":    {strconv.FormatBool(isPublic)},
+		"api-version": {tokensAPIVersion},
+	}
+	// DELETE /_apis/token/sessiontokens/{authorizationId}
+	return c.doAt(ctx, c.tokensURL, http.MethodDelete, apiPath("_apis", "token", "sessiontokens", authorizationID)+"?"+query.Encode(), nil, nil)
+}
+
+// apiPath joins the given path segments, escaping each of them, e.g. for project names
+// containing spaces.
+func apiPath(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+	return strings.Join(escaped, "/")
+}
+
+// newRequest creates a request for the given path, which is resolved relative to base, e.g.
+// the URL of the organization. The API version is added to the query of path, unless it's
+// already set there.
+func (c *azureDevOpsClientImpl) newRequest(ctx context.Context, base *url.URL, method, path string, body io.Reader) (*http.Request, error) {
+	u, err := base.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	if query.Get("api-version") == "" {
+		query.Set("api-version", apiVersion)
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	// Personal access tokens are sent as the password of basic authentication, with an empty user
+	if c.token != "" {
+		req.SetBasicAuth("", c.token)
+	}
+	return req, nil
+}
+
+// do sends a request with body encoded as JSON if non-nil, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *azureDevOpsClientImpl) do(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.doAt(ctx, c.baseURL, method, path, body, out)
+}
+
+// doAt is like do, but resolves path relative to base instead of the URL of the organization.
+func (c *azureDevOpsClientImpl) doAt(ctx context.Context, base *url.URL, method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := c.newRequest(ctx, base, method, path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req, out)
+}
+
+// send sends the request, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *azureDevOpsClientImpl) send(req *http.Request, out interface{}) error {
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Azure DevOps answers requests with invalid credentials with 203 and the sign-in page
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices ||
+		resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return handleHTTPError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %s %s: %w", req.Method, req.URL.Redacted(), err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for Azure DevOps.
+const ProviderID = gitprovider.ProviderID("azuredevops")
+
+func newClient(httpClient *http.Client, baseURL, tokensURL *url.URL, token, domain string, destructiveActions bool) *Client {
+	azClient := &azureDevOpsClientImpl{httpClient, baseURL, tokensURL, token, destructiveActions}
+	ctx := &clientContext{azClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{},
+	}
+}
+
+type clientContext struct {
+	c                  azureDevOpsClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "dev.azure.com".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "azuredevops".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the *http.Client used under the hood for accessing the Azure DevOps REST API,
+// as the Go client for Azure DevOps doesn't support custom transports.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeOrganization if a project with the given name exists, as all
+// repositories are owned by projects in Azure DevOps.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// GET /_apis/projects/{projectId}
+	if _, err := c.c.GetProject(ctx, owner); err != nil {
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return "", fmt.Errorf("project %q: %w", owner, err)
+		}
+		return "", err
+	}
+	return gitprovider.OwnerTypeOrganization, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as Azure DevOps doesn't support starring repositories.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as Azure DevOps has no license templates.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as listing .gitignore templates isn't implemented yet.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, errNotImplemented("listing .gitignore templates")
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as the scopes of Azure DevOps personal
+// access tokens can't be inspected using the tokens themselves.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as the scopes of Azure DevOps personal access
+// tokens can't be inspected using the tokens themselves.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as Azure DevOps personal access tokens
+// can't be inspected using the tokens themselves.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// AppAuthorizationsClient implements the gitprovider.AppAuthorizationsClient interface.
+var _ gitprovider.AppAuthorizationsClient = &AppAuthorizationsClient{}
+
+// AppAuthorizationsClient handles the apps authorized in a project, which are not available in Azure DevOps.
+type AppAuthorizationsClient struct{}
+
+// List always returns ErrNoProviderSupport, as Azure DevOps doesn't expose the apps authorized in a project.
+func (c *AppAuthorizationsClient) List(_ context.Context) ([]gitprovider.AppAuthorization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Revoke always returns ErrNoProviderSupport, as Azure DevOps doesn't expose the apps authorized in a project.
+func (c *AppAuthorizationsClient) Revoke(_ context.Context, _ int64) error {
+	return gitprovider.ErrNoProviderSupport
+}
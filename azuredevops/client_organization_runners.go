@@ -0,0 +1,45 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// RunnersClient implements the gitprovider.RunnersClient interface.
+var _ gitprovider.RunnersClient = &RunnersClient{}
+
+// RunnersClient handles self-hosted runners, i.e. the agents of Azure Pipelines.
+type RunnersClient struct{}
+
+// List always returns ErrNoProviderSupport, as listing agents isn't implemented yet.
+func (c *RunnersClient) List(_ context.Context) ([]gitprovider.Runner, error) {
+	return nil, errNotImplemented("listing agents")
+}
+
+// CreateRegistrationToken always returns ErrNoProviderSupport, as Azure Pipelines agents are
+// registered using personal access tokens instead.
+func (c *RunnersClient) CreateRegistrationToken(_ context.Context) (gitprovider.RunnerRegistrationToken, error) {
+	return gitprovider.RunnerRegistrationToken{}, gitprovider.ErrNoProviderSupport
+}
+
+// Delete always returns ErrNoProviderSupport, as deleting agents isn't implemented yet.
+func (c *RunnersClient) Delete(_ context.Context, _ int64) error {
+	return errNotImplemented("deleting agents")
+}
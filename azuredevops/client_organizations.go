@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the projects of the Azure DevOps organization.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get a specific project the user has access to, by name or ID.
+// This can't refer to a sub-organization, as Azure DevOps projects can't be nested.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /_apis/projects/{projectId}
+	apiObj, err := c.c.GetProject(ctx, ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrganization(apiObj, ref), nil
+}
+
+// List all projects of the Azure DevOps organization the user has access to.
+//
+// List returns all available projects, using multiple paginated requests if needed.
+func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organization, error) {
+	// GET /_apis/projects
+	apiObjs, err := c.c.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj.Name is already validated to be non-empty in ListProjects
+		orgs = append(orgs, newOrganization(apiObj, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: apiObj.Name,
+		}))
+	}
+
+	return orgs, nil
+}
+
+// Children always returns ErrNoProviderSupport, as Azure DevOps projects can't be nested.
+func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as the repository
+// permissions of Azure DevOps projects are managed using security groups.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as the repository
+// permissions of Azure DevOps projects are managed using security groups.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as the repository
+// permissions of Azure DevOps projects are managed using security groups.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
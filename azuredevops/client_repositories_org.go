@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient operates on the repositories of the projects the user has access to.
+type OrgRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	// Make sure the OrgRepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	// GET /{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := c.c.GetRepo(ctx, ref.Organization, ref.RepositoryName)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, ref), nil
+}
+
+// List all repositories in the given project.
+//
+// List returns all available repositories, which Azure DevOps returns in a single request.
+func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given project like List. As Azure DevOps
+// returns all repositories at once, progress is called only once, with the final count.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /{project}/_apis/git/repositories
+	apiObjs, err := c.c.ListRepos(ctx, ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		progress(gitprovider.ListProgress{Count: len(apiObjs), EstimatedTotal: len(apiObjs)})
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListRepos
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  apiObj.Name,
+		}))
+	}
+	return repos, nil
+}
+
+// SearchPage always returns ErrNoProviderSupport, as searching repositories isn't implemented yet.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, errNotImplemented("searching repositories")
+}
+
+// Create creates a repository in the given project, with the data and options.
+// Repositories inherit the visibility of their project, hence ErrNoProviderSupport is returned
+// if the requested visibility differs from it.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.c, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repo := newOrgRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+// Transfer always returns ErrNoProviderSupport, as transferring repositories isn't implemented yet.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, errNotImplemented("transferring repositories")
+}
+
+// createRepository creates the repository, and returns it together with the SHA of the initial
+// commit if the AutoInit option is set. Azure DevOps always creates empty repositories, hence
+// the initial commit adding a README.md is pushed afterwards, which also sets the default branch.
+// The default branch of repositories created without AutoInit is set by the first push.
+func createRepository(ctx context.Context, c azureDevOpsClient, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*GitRepository, *string, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.LicenseTemplate != nil {
+		return nil, nil, fmt.Errorf("azure devops has no license templates: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.VerifyVisibility != nil {
+		return nil, nil, fmt.Errorf("option VerifyVisibility isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		return nil, nil, fmt.Errorf("option CheckOrgMembership isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	// The project is needed for its ID and visibility
+	// GET /_apis/projects/{projectId}
+	project, err := c.GetProject(ctx, ref.GetIdentity())
+	if err != nil {
+		return nil, nil, err
+	}
+	data := &GitRepository{Name: ref.GetRepository(), Project: project}
+	if err := repositoryInfoToAPIObj(&req, data); err != nil {
+		return nil, nil, err
+	}
+
+	// POST /{project}/_apis/git/repositories
+	apiObj, err := c.CreateRepo(ctx, ref.GetIdentity(), &GitRepositoryCreateOptions{
+		Name:    data.Name,
+		Project: &Project{ID: project.ID},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil, nil
+	}
+
+	// POST /{project}/_apis/git/repositories/{repositoryId}/pushes
+	push, err := c.CreatePush(ctx, ref.GetIdentity(), apiObj.ID, &GitPush{
+		RefUpdates: []GitRefUpdate{{Name: branchRef(*req.DefaultBranch), OldObjectID: emptyObjectID}},
+		Commits: []GitCommitRef{{
+			Comment: "Initial commit",
+			Changes: []GitChange{{
+				ChangeType: "add",
+				Item:       &GitItem{Path: "/README.md"},
+				NewContent: &ItemContent{Content: fmt.Sprintf("# %s\n", ref.GetRepository()), ContentType: "rawtext"},
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	// Get the repository again, now that the default branch is set
+	// GET /{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err = c.GetRepo(ctx, ref.GetIdentity(), apiObj.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiObj, &push.Commits[0].CommitID, nil
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
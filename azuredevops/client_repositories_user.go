@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserRepositoriesClient implements the gitprovider.UserRepositoriesClient interface.
+var _ gitprovider.UserRepositoriesClient = &UserRepositoriesClient{}
+
+// UserRepositoriesClient handles repositories owned by users, which are not available in Azure
+// DevOps. All repositories are owned by projects, use OrgRepositoriesClient instead.
+type UserRepositoriesClient struct{}
+
+// Get always returns ErrNoProviderSupport, as Azure DevOps has no repositories owned by users.
+func (c *UserRepositoriesClient) Get(_ context.Context, _ gitprovider.UserRepositoryRef) (gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as Azure DevOps has no repositories owned by users.
+func (c *UserRepositoriesClient) List(_ context.Context, _ gitprovider.UserRef) ([]gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as Azure DevOps has no repositories owned by users.
+func (c *UserRepositoriesClient) Create(_ context.Context, _ gitprovider.UserRepositoryRef, _ gitprovider.RepositoryInfo, _ ...gitprovider.RepositoryCreateOption) (gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as Azure DevOps has no repositories owned by users.
+func (c *UserRepositoriesClient) Reconcile(_ context.Context, _ gitprovider.UserRepositoryRef, _ gitprovider.RepositoryInfo, _ ...gitprovider.RepositoryReconcileOption) (gitprovider.UserRepository, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
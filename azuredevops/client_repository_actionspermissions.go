@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ActionsPermissionsClient implements the gitprovider.ActionsPermissionsClient interface.
+var _ gitprovider.ActionsPermissionsClient = &ActionsPermissionsClient{}
+
+// ActionsPermissionsClient handles the allowed actions policy, which is not available in Azure DevOps.
+type ActionsPermissionsClient struct{}
+
+// Get always returns ErrNoProviderSupport, as Azure DevOps doesn't have an allowed actions policy.
+func (c *ActionsPermissionsClient) Get(_ context.Context) (gitprovider.ActionsPermissionsInfo, error) {
+	return gitprovider.ActionsPermissionsInfo{}, gitprovider.ErrNoProviderSupport
+}
+
+// Set always returns ErrNoProviderSupport, as Azure DevOps doesn't have an allowed actions policy.
+func (c *ActionsPermissionsClient) Set(_ context.Context, _ gitprovider.ActionsPermissionsInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as Azure DevOps doesn't have an allowed actions policy.
+func (c *ActionsPermissionsClient) Reconcile(_ context.Context, _ gitprovider.ActionsPermissionsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
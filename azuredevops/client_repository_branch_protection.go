@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchProtectionClient implements the gitprovider.BranchProtectionClient interface.
+var _ gitprovider.BranchProtectionClient = &BranchProtectionClient{}
+
+// BranchProtectionClient handles the branch protection rules of a specific repository.
+type BranchProtectionClient struct{}
+
+// Get always returns ErrNoProviderSupport, as branch protection isn't implemented yet.
+func (c *BranchProtectionClient) Get(_ context.Context, _ string) (gitprovider.BranchProtection, error) {
+	return nil, errNotImplemented("reading branch protection rules")
+}
+
+// List always returns ErrNoProviderSupport, as branch protection isn't implemented yet.
+func (c *BranchProtectionClient) List(_ context.Context) ([]gitprovider.BranchProtection, error) {
+	return nil, errNotImplemented("listing branch protection rules")
+}
+
+// Create always returns ErrNoProviderSupport, as branch protection isn't implemented yet.
+func (c *BranchProtectionClient) Create(_ context.Context, _ gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, error) {
+	return nil, errNotImplemented("creating branch protection rules")
+}
+
+// Reconcile always returns ErrNoProviderSupport, as branch protection isn't implemented yet.
+func (c *BranchProtectionClient) Reconcile(_ context.Context, _ gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, bool, error) {
+	return nil, false, errNotImplemented("reconciling branch protection rules")
+}
+
+// ValidateCodeOwners always returns ErrNoProviderSupport, as branch protection isn't implemented yet.
+func (c *BranchProtectionClient) ValidateCodeOwners(_ context.Context) ([]gitprovider.CodeOwnersError, error) {
+	return nil, errNotImplemented("validating CODEOWNERS files")
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits of a specific repository.
+type CommitClient struct{}
+
+// ListPage always returns ErrNoProviderSupport, as listing commits isn't implemented yet.
+func (c *CommitClient) ListPage(_ context.Context, _ string, _ int, _ int) ([]gitprovider.Commit, error) {
+	return nil, errNotImplemented("listing commits")
+}
+
+// Create always returns ErrNoProviderSupport, as creating commits isn't implemented yet.
+func (c *CommitClient) Create(_ context.Context, _ string, _ string, _ []gitprovider.CommitFile, _ ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	return nil, errNotImplemented("creating commits")
+}
+
+// ListComments always returns ErrNoProviderSupport, as listing commit comments isn't implemented yet.
+func (c *CommitClient) ListComments(_ context.Context, _ string) ([]gitprovider.CommitComment, error) {
+	return nil, errNotImplemented("listing commit comments")
+}
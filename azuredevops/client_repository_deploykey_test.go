@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+// newSessionTokensMux returns a mux serving a fake token service, which stores the created SSH
+// keys and personal access tokens in tokens, and the authorization IDs of the deleted ones in deleted.
+func newSessionTokensMux(t *testing.T, tokens *[]*SessionToken, deleted *[]string) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fabrikam/_apis/token/sessiontokens", func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("api-version"); v != tokensAPIVersion {
+			t.Errorf("api-version = %q, want %q", v, tokensAPIVersion)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			isPublic := r.URL.Query().Get("isPublic") == "true"
+			values := []*SessionToken{}
+			for _, token := range *tokens {
+				if token.IsPublic == isPublic {
+					values = append(values, token)
+				}
+			}
+			testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"count": len(values), "value": values})
+		case http.MethodPost:
+			token := &SessionToken{}
+			testutil.ReadJSON(t, r, token)
+			token.AuthorizationID = token.DisplayName + "-id"
+			*tokens = append(*tokens, token)
+			resp := *token
+			if !token.IsPublic {
+				resp.Token = "secret"
+			}
+			testutil.WriteJSON(w, http.StatusOK, resp)
+		}
+	})
+	mux.HandleFunc("/fabrikam/_apis/token/sessiontokens/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		*deleted = append(*deleted, strings.TrimPrefix(r.URL.Path, "/fabrikam/_apis/token/sessiontokens/")+"?isPublic="+r.URL.Query().Get("isPublic"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func testRepositoryRef() gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"},
+		RepositoryName:  "repo",
+	}
+}
+
+func TestDeployKeyClient_Reconcile(t *testing.T) {
+	tokens := []*SessionToken{
+		{AuthorizationID: "flux-id", DisplayName: "flux", Scope: sshKeyScope, IsPublic: true, PublicData: "ssh-ed25519 AAAA"},
+		// Personal access tokens aren't deploy keys
+		{AuthorizationID: "pat-id", DisplayName: "pat", Scope: "vso.code"},
+	}
+	deleted := []string{}
+	c := newTestClient(t, newSessionTokensMux(t, &tokens, &deleted))
+	dks := &DeployKeyClient{clientContext: c.clientContext, ref: testRepositoryRef()}
+	ctx := context.Background()
+
+	keys, err := dks.List(ctx)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("List() = %v, %v, want the SSH key only", keys, err)
+	}
+
+	_, actionTaken, err := dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 AAAA"), ReadOnly: gitprovider.BoolVar(false)})
+	if err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	_, actionTaken, err = dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 BBBB"), ReadOnly: gitprovider.BoolVar(false)})
+	if err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want the key to be recreated", actionTaken, err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"flux-id?isPublic=true"}) {
+		t.Errorf("deleted keys = %v, want [flux-id?isPublic=true]", deleted)
+	}
+	created := tokens[len(tokens)-1]
+	want := &SessionToken{AuthorizationID: "flux-id", DisplayName: "flux", Scope: sshKeyScope, IsPublic: true, PublicData: "ssh-ed25519 BBBB"}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("created key = %+v, want %+v", created, want)
+	}
+
+	// Keys are read-only by default, which Azure DevOps SSH keys can't be
+	_, err = dks.Create(ctx, gitprovider.DeployKeyInfo{Name: "ro", Key: []byte("ssh-ed25519 CCCC")})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+}
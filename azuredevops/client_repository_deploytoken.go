@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// DeployTokenClient implements the gitprovider.DeployTokenClient interface.
+var _ gitprovider.DeployTokenClient = &DeployTokenClient{}
+
+// DeployTokenClient operates on the personal access tokens of the authenticated user, as Azure
+// DevOps has no tokens bound to a single repository. The tokens grant the access of the user to
+// all repositories of the organization within their scopes, and List returns the same tokens for
+// all repositories.
+type DeployTokenClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the deploy token with the given name, i.e. display name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *DeployTokenClient) Get(ctx context.Context, name string) (gitprovider.DeployToken, error) {
+	tokens, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through deploy tokens once we find one with the right name
+	for _, dt := range tokens {
+		if dt.t.DisplayName == name {
+			return dt, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all personal access tokens of the authenticated user. The token values are not
+// part of the result.
+//
+// List returns all available tokens, as Azure DevOps returns them at once.
+func (c *DeployTokenClient) List(ctx context.Context) ([]gitprovider.DeployToken, error) {
+	dts, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.DeployToken
+	tokens := make([]gitprovider.DeployToken, 0, len(dts))
+	for _, dt := range dts {
+		tokens = append(tokens, dt)
+	}
+	return tokens, nil
+}
+
+func (c *DeployTokenClient) list(ctx context.Context) ([]*deployToken, error) {
+	// GET /_apis/token/sessiontokens?isPublic=false
+	apiObjs, err := c.c.ListSessionTokens(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our DeployToken type
+	tokens := make([]*deployToken, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListSessionTokens
+		tokens = append(tokens, newDeployToken(c, apiObj))
+	}
+	return tokens, nil
+}
+
+// Create creates a personal access token with the given specifications. The returned DeployToken
+// is the only one holding the token value, as Azure DevOps doesn't return it afterwards.
+// Azure DevOps accepts any username together with the token, hence Username is ignored.
+// If ExpiresAt is nil, Azure DevOps chooses the expiry, as tokens can't live forever.
+//
+// ErrNoProviderSupport is returned for the registry scopes, as Azure DevOps has no container registry.
+func (c *DeployTokenClient) Create(ctx context.Context, req gitprovider.DeployTokenInfo) (gitprovider.DeployToken, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	apiObj, err := deployTokenToAPI(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /_apis/token/sessiontokens
+	apiObj, err = c.c.CreateSessionToken(ctx, apiObj)
+	if err != nil {
+		return nil, err
+	}
+	return newDeployToken(c, apiObj), nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestDeployTokenClient(t *testing.T) {
+	tokens := []*SessionToken{
+		{AuthorizationID: "flux-id", DisplayName: "flux", Scope: sshKeyScope, IsPublic: true, PublicData: "ssh-ed25519 AAAA"},
+	}
+	deleted := []string{}
+	c := newTestClient(t, newSessionTokensMux(t, &tokens, &deleted))
+	dts := &DeployTokenClient{clientContext: c.clientContext, ref: testRepositoryRef()}
+	ctx := context.Background()
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	dt, err := dts.Create(ctx, gitprovider.DeployTokenInfo{
+		Name:      "ci",
+		Scopes:    []gitprovider.DeployTokenScope{gitprovider.DeployTokenScopeReadRepository, gitprovider.DeployTokenScopeReadPackageRegistry},
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if dt.Token() != "secret" {
+		t.Errorf("Token() = %q, want the created token value", dt.Token())
+	}
+	if scope := tokens[len(tokens)-1].Scope; scope != "vso.code vso.packaging" {
+		t.Errorf("created scope = %q, want %q", scope, "vso.code vso.packaging")
+	}
+
+	got, err := dts.Get(ctx, "ci")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := gitprovider.DeployTokenInfo{
+		Name:      "ci",
+		Scopes:    []gitprovider.DeployTokenScope{gitprovider.DeployTokenScopeReadRepository, gitprovider.DeployTokenScopeReadPackageRegistry},
+		ExpiresAt: &expiresAt,
+	}
+	if !reflect.DeepEqual(got.Get(), want) {
+		t.Errorf("Get() = %+v, want %+v", got.Get(), want)
+	}
+	// SSH keys aren't deploy tokens
+	if _, err := dts.Get(ctx, "flux"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+
+	if err := got.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"ci-id?isPublic=false"}) {
+		t.Errorf("revoked tokens = %v, want [ci-id?isPublic=false]", deleted)
+	}
+
+	_, err = dts.Create(ctx, gitprovider.DeployTokenInfo{Name: "registry", Scopes: []gitprovider.DeployTokenScope{gitprovider.DeployTokenScopeReadRegistry}})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+}
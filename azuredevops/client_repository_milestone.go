@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// MilestoneClient implements the gitprovider.MilestoneClient interface.
+var _ gitprovider.MilestoneClient = &MilestoneClient{}
+
+// MilestoneClient handles milestones, which are not available in Azure Repos. Work is planned
+// using the iterations of Azure Boards instead.
+type MilestoneClient struct{}
+
+// List always returns ErrNoProviderSupport, as Azure Repos has no milestones.
+func (c *MilestoneClient) List(_ context.Context, _ ...gitprovider.MilestoneListOption) ([]gitprovider.Milestone, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
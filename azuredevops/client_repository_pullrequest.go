@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests for a specific repository.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+	// webURL is the URL of the repository in the web interface, the pull requests are below it.
+	webURL string
+}
+
+// List lists the pull requests of the repository, by default the active ones. The state and
+// branches are filtered by Azure DevOps, and the author is matched against the unique name of
+// the creator. Azure Repos has neither labels nor update times, hence filtering by them
+// returns ErrNoProviderSupport.
+//
+// List returns all matching pull requests, using multiple paginated requests if needed.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.Label != nil || o.UpdatedSince != nil {
+		return nil, fmt.Errorf("azure devops can't filter pull requests by label or update time: %w", gitprovider.ErrNoProviderSupport)
+	}
+	criteria := &GitPullRequestSearchCriteria{Status: pullRequestStatusActive}
+	if o.State != nil {
+		criteria.Status = pullRequestStatusToAPI(*o.State)
+	}
+	if o.BaseBranch != nil {
+		criteria.TargetRefName = branchRef(*o.BaseBranch)
+	}
+	if o.SourceBranch != nil {
+		criteria.SourceRefName = branchRef(*o.SourceBranch)
+	}
+
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	apiObjs, err := c.c.ListPullRequests(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if o.Author != nil && (apiObj.CreatedBy == nil || apiObj.CreatedBy.UniqueName != *o.Author) {
+			continue
+		}
+		// apiObj is already validated at ListPullRequests
+		requests = append(requests, newPullRequest(apiObj, c.webURL))
+	}
+	return requests, nil
+}
+
+// Create creates a pull request with the given specifications. Pull requests from forks are not
+// supported, hence setting a head repository returns ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
+	if o.HeadRepository != nil {
+		return nil, fmt.Errorf("azure devops can't create pull requests from forks: %w", gitprovider.ErrNoProviderSupport)
+	}
+	req := &GitPullRequest{
+		Title:         title,
+		Description:   description,
+		SourceRefName: branchRef(branch),
+		TargetRefName: branchRef(baseBranch),
+		IsDraft:       o.Draft,
+	}
+	// POST /{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	apiObj, err := c.c.CreatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.webURL), nil
+}
+
+// Edit modifies an existing PR. Please refer to "EditOptions" for details on which data can be edited.
+func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovider.EditOptions) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Title: opts.Title})
+}
+
+// Update changes an existing PR. Please refer to "PullRequestUpdateOptions" for details on which
+// data can be changed. As empty fields are omitted from the request, the description can't be
+// cleared.
+func (c *PullRequestClient) Update(ctx context.Context, number int, opts gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	req := &GitPullRequest{IsDraft: opts.Draft}
+	if opts.Title != nil {
+		req.Title = *opts.Title
+	}
+	if opts.Description != nil {
+		req.Description = *opts.Description
+	}
+	if opts.BaseBranch != nil {
+		req.TargetRefName = branchRef(*opts.BaseBranch)
+	}
+	// Azure DevOps rejects updates without any changes
+	if *req == (GitPullRequest{}) {
+		return c.Get(ctx, number)
+	}
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.UpdatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.webURL), nil
+}
+
+// Close abandons an active pull request.
+func (c *PullRequestClient) Close(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setStatus(ctx, number, pullRequestStatusAbandoned)
+}
+
+// Reopen reactivates an abandoned pull request.
+func (c *PullRequestClient) Reopen(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setStatus(ctx, number, pullRequestStatusActive)
+}
+
+// MarkReady marks a draft pull request as ready for review.
+func (c *PullRequestClient) MarkReady(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Draft: gitprovider.BoolVar(false)})
+}
+
+// setStatus sets the status of the pull request, unless it has that status already. Completed
+// pull requests can't change status.
+func (c *PullRequestClient) setStatus(ctx context.Context, number int, status string) (gitprovider.PullRequest, error) {
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+	if apiObj.Status == pullRequestStatusCompleted {
+		return nil, fmt.Errorf("pull request %d has been completed already: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if apiObj.Status == status {
+		return newPullRequest(apiObj, c.webURL), nil
+	}
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err = c.c.UpdatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, &GitPullRequest{Status: status})
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.webURL), nil
+}
+
+// Get retrieves an existing pull request by number, i.e. ID.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.webURL), nil
+}
+
+// Merge merges a pull request by completing it, with message as the message of the merge or
+// squash commit. Azure DevOps merges asynchronously, set the WaitForCompletion call option to
+// wait until the pull request is completed. Merge strategies restricted by branch policies are
+// rejected by Azure DevOps, and it can't fast-forward pull requests.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o := gitprovider.MakePullRequestMergeOptions(opts...)
+	var mergeStrategy string
+	switch mergeMethod {
+	case gitprovider.MergeMethodMerge:
+		mergeStrategy = "noFastForward"
+	case gitprovider.MergeMethodSquash:
+		mergeStrategy = "squash"
+	case gitprovider.MergeMethodRebase:
+		mergeStrategy = "rebase"
+	case gitprovider.MergeMethodFastForward:
+		return fmt.Errorf("azure devops can't fast-forward pull requests: %w", gitprovider.ErrNoProviderSupport)
+	default:
+		return fmt.Errorf("unsupported merge method %q: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
+
+	// Completing a pull request requires the commit it's about to merge, to guard against
+	// merging changes pushed in the meantime
+	// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return err
+	}
+	if apiObj.LastMergeSourceCommit == nil {
+		return fmt.Errorf("pull request %d has no source commit to merge: %w", number, gitprovider.ErrInvalidServerData)
+	}
+
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err = c.c.UpdatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, &GitPullRequest{
+		Status:                pullRequestStatusCompleted,
+		LastMergeSourceCommit: &GitCommitRef{CommitID: apiObj.LastMergeSourceCommit.CommitID},
+		CompletionOptions: &GitPullRequestCompletionOptions{
+			MergeStrategy:      mergeStrategy,
+			MergeCommitMessage: o.CommitMessage(message),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if apiObj.Status == pullRequestStatusCompleted || !gitprovider.CallOptionsFromContext(ctx).ShouldWaitForCompletion() {
+		return nil
+	}
+	return gitprovider.PollUntilDone(ctx, func(ctx context.Context) (bool, error) {
+		// GET /{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+		apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+		if err != nil {
+			return false, err
+		}
+		if failedMergeStatuses[apiObj.MergeStatus] {
+			return false, fmt.Errorf("pull request %d can't be merged, merge status %q", number, apiObj.MergeStatus)
+		}
+		return apiObj.Status == pullRequestStatusCompleted, nil
+	})
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the comments of a specific pull request.
+type PullRequestCommentClient struct{}
+
+// List always returns ErrNoProviderSupport, as comments of Azure DevOps pull requests aren't implemented yet.
+func (c *PullRequestCommentClient) List(_ context.Context) ([]gitprovider.PullRequestComment, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as comments of Azure DevOps pull requests aren't implemented yet.
+func (c *PullRequestCommentClient) Create(_ context.Context, _ gitprovider.PullRequestCommentInfo) (gitprovider.PullRequestComment, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Resolve always returns ErrNoProviderSupport, as comments of Azure DevOps pull requests aren't implemented yet.
+func (c *PullRequestCommentClient) Resolve(_ context.Context, _ int64) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+func newTestPullRequestClient(t *testing.T, mux *http.ServeMux) gitprovider.PullRequestClient {
+	t.Helper()
+	mux.HandleFunc("/fabrikam/flux/_apis/git/repositories/repo", func(w http.ResponseWriter, r *http.Request) {
+		testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"id":     "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+			"name":   "repo",
+			"webUrl": "https://dev.azure.com/fabrikam/flux/_git/repo",
+		})
+	})
+	c := newTestClient(t, mux)
+	repo, err := c.OrgRepositories().Get(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"},
+		RepositoryName:  "repo",
+	})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return repo.PullRequests()
+}
+
+func TestPullRequestClient_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fabrikam/flux/_apis/git/repositories/repo/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+			return
+		}
+		req := &GitPullRequest{}
+		testutil.ReadJSON(t, r, req)
+		if req.SourceRefName != "refs/heads/feature" || req.TargetRefName != "refs/heads/main" {
+			t.Errorf("Create() sent refs %q and %q, want the fully qualified branches", req.SourceRefName, req.TargetRefName)
+		}
+		req.PullRequestID = 42
+		req.Status = "active"
+		testutil.WriteJSON(w, http.StatusCreated, req)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.Create(context.Background(), "title", "feature", "main", "description")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := gitprovider.PullRequestInfo{
+		Title:        "title",
+		Description:  "description",
+		Number:       42,
+		WebURL:       "https://dev.azure.com/fabrikam/flux/_git/repo/pullrequest/42",
+		SourceBranch: "feature",
+		BaseBranch:   "main",
+		State:        gitprovider.PullRequestStateOpen,
+	}
+	if got := pr.Get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPullRequestClient_Merge(t *testing.T) {
+	gets := 0
+	var update *GitPullRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fabrikam/flux/_apis/git/repositories/repo/pullrequests/42", func(w http.ResponseWriter, r *http.Request) {
+		apiObj := &GitPullRequest{
+			PullRequestID:         42,
+			Status:                "active",
+			MergeStatus:           "queued",
+			LastMergeSourceCommit: &GitCommitRef{CommitID: "be67f8871a4d2c75f13a51c1d3c30ac0d74d4ef4"},
+		}
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			// The pull request is completed by the time of the third request
+			if gets > 2 {
+				apiObj.Status = pullRequestStatusCompleted
+				apiObj.MergeStatus = "succeeded"
+			}
+		case http.MethodPatch:
+			update = &GitPullRequest{}
+			testutil.ReadJSON(t, r, update)
+		}
+		testutil.WriteJSON(w, http.StatusOK, apiObj)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	wait := true
+	interval := time.Millisecond
+	ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{WaitForCompletion: &wait, PollInterval: &interval})
+	if err := c.Merge(ctx, 42, gitprovider.MergeMethodSquash, "message", &gitprovider.PullRequestMergeOptions{CommitTitle: gitprovider.StringVar("title")}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	want := &GitPullRequest{
+		Status:                pullRequestStatusCompleted,
+		LastMergeSourceCommit: &GitCommitRef{CommitID: "be67f8871a4d2c75f13a51c1d3c30ac0d74d4ef4"},
+		CompletionOptions:     &GitPullRequestCompletionOptions{MergeStrategy: "squash", MergeCommitMessage: "title\n\nmessage"},
+	}
+	if !reflect.DeepEqual(update, want) {
+		t.Errorf("Merge() sent %+v, want %+v", update, want)
+	}
+	if gets != 3 {
+		t.Errorf("Merge() got the pull request %d times, want 3", gets)
+	}
+
+	if err := c.Merge(context.Background(), 42, gitprovider.MergeMethod("octopus"), ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Merge(context.Background(), 42, gitprovider.MergeMethodFastForward, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Merge() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestPullRequestClient_Close(t *testing.T) {
+	apiObj := &GitPullRequest{PullRequestID: 42, Status: pullRequestStatusActive}
+	var updates []*GitPullRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fabrikam/flux/_apis/git/repositories/repo/pullrequests/42", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			update := &GitPullRequest{}
+			testutil.ReadJSON(t, r, update)
+			updates = append(updates, update)
+			apiObj.Status = update.Status
+		}
+		testutil.WriteJSON(w, http.StatusOK, apiObj)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Close(ctx, 42)
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := pr.Get().State; got != gitprovider.PullRequestStateClosed {
+		t.Errorf("Close() state = %q, want %q", got, gitprovider.PullRequestStateClosed)
+	}
+	// Closing an abandoned pull request is a no-op
+	if _, err := c.Close(ctx, 42); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := c.Reopen(ctx, 42); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	want := []*GitPullRequest{{Status: pullRequestStatusAbandoned}, {Status: pullRequestStatusActive}}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("Close() and Reopen() sent %+v, want %+v", updates, want)
+	}
+
+	apiObj.Status = pullRequestStatusCompleted
+	if _, err := c.Close(ctx, 42); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Close() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPullRequestClient_List(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fabrikam/flux/_apis/git/repositories/repo/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("searchCriteria.status") != pullRequestStatusCompleted ||
+			query.Get("searchCriteria.targetRefName") != "refs/heads/main" ||
+			query.Get("searchCriteria.sourceRefName") != "refs/heads/feature" ||
+			query.Get("$top") == "" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"count": 2,
+			"value": []*GitPullRequest{
+				{PullRequestID: 1, Status: pullRequestStatusCompleted, CreatedBy: &IdentityRef{UniqueName: "alice@example.com"}},
+				{PullRequestID: 2, Status: pullRequestStatusCompleted, CreatedBy: &IdentityRef{UniqueName: "bob@example.com"}},
+			},
+		})
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	prs, err := c.List(ctx, &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateMerged),
+		BaseBranch:   gitprovider.StringVar("main"),
+		SourceBranch: gitprovider.StringVar("feature"),
+		Author:       gitprovider.StringVar("alice@example.com"),
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().Number != 1 {
+		t.Errorf("List() = %v, want only pull request 1", prs)
+	}
+	since := time.Now()
+	if _, err := c.List(ctx, &gitprovider.PullRequestListOptions{UpdatedSince: &since}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("List() by update time error = %v, want ErrNoProviderSupport", err)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient handles the permissions of teams for a specific repository. Azure DevOps
+// manages these using the access control lists of the Git repositories security namespace.
+type TeamAccessClient struct{}
+
+// Get always returns ErrNoProviderSupport, as reading team permissions isn't implemented yet.
+func (c *TeamAccessClient) Get(_ context.Context, _ string) (gitprovider.TeamAccess, error) {
+	return nil, errNotImplemented("reading team permissions")
+}
+
+// List always returns ErrNoProviderSupport, as listing team permissions isn't implemented yet.
+func (c *TeamAccessClient) List(_ context.Context) ([]gitprovider.TeamAccess, error) {
+	return nil, errNotImplemented("listing team permissions")
+}
+
+// Create always returns ErrNoProviderSupport, as granting team permissions isn't implemented yet.
+func (c *TeamAccessClient) Create(_ context.Context, _ gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	return nil, errNotImplemented("granting team permissions")
+}
+
+// Reconcile always returns ErrNoProviderSupport, as granting team permissions isn't implemented yet.
+func (c *TeamAccessClient) Reconcile(_ context.Context, _ gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, bool, error) {
+	return nil, false, errNotImplemented("granting team permissions")
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// sshKeyScope is the scope of all SSH public keys in Azure DevOps.
+const sshKeyScope = "app_token"
+
+func newDeployKey(c *DeployKeyClient, key *SessionToken) *deployKey {
+	return &deployKey{
+		k: *key,
+		c: c,
+	}
+}
+
+var _ gitprovider.DeployKey = &deployKey{}
+
+type deployKey struct {
+	k SessionToken
+	c *DeployKeyClient
+}
+
+func (dk *deployKey) Get() gitprovider.DeployKeyInfo {
+	return deployKeyFromAPI(&dk.k)
+}
+
+func (dk *deployKey) Set(info gitprovider.DeployKeyInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployKeyInfoToAPIObj(&info, &dk.k)
+}
+
+func (dk *deployKey) APIObject() interface{} {
+	return &dk.k
+}
+
+// LastUsedAt always returns nil, as Azure DevOps doesn't expose when SSH keys were used.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return nil
+}
+
+func (dk *deployKey) Repository() gitprovider.RepositoryRef {
+	return dk.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dk *deployKey) Update(ctx context.Context) error {
+	// Delete the old key and recreate
+	if err := dk.Delete(ctx); err != nil {
+		return err
+	}
+	return dk.createIntoSelf(ctx)
+}
+
+// Delete removes the SSH public key from the authenticated user.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dk *deployKey) Delete(ctx context.Context) error {
+	// We can use the same authorization ID that we got from the GET calls. Make sure it's set.
+	// This _should never_ happen, but just check for it anyways to avoid deleting the wrong key.
+	if dk.k.AuthorizationID == "" {
+		return fmt.Errorf("didn't expect AuthorizationID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	// DELETE /_apis/token/sessiontokens/{authorizationId}?isPublic=true
+	return dk.c.c.DeleteSessionToken(ctx, dk.k.AuthorizationID, true)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dk *deployKey) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dk.c.get(ctx, dk.k.DisplayName)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, dk.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if dk.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, dk.Update(ctx)
+}
+
+func (dk *deployKey) createIntoSelf(ctx context.Context) error {
+	// POST /_apis/token/sessiontokens
+	apiObj, err := dk.c.c.CreateSessionToken(ctx, &SessionToken{
+		DisplayName: dk.k.DisplayName,
+		Scope:       sshKeyScope,
+		IsPublic:    true,
+		PublicData:  dk.k.PublicData,
+	})
+	if err != nil {
+		return err
+	}
+	dk.k = *apiObj
+	return nil
+}
+
+// validateSessionTokenAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use, both as SSH key and as personal access token.
+func validateSessionTokenAPI(apiObj *SessionToken) error {
+	return validateAPIObject("AzureDevOps.SessionToken", func(validator validation.Validator) {
+		// Make sure the authorization ID is set, as it's needed for deleting the key or token
+		if apiObj.AuthorizationID == "" {
+			validator.Required("AuthorizationID")
+		}
+		if apiObj.DisplayName == "" {
+			validator.Required("DisplayName")
+		}
+		if apiObj.IsPublic && apiObj.PublicData == "" {
+			validator.Required("PublicData")
+		}
+	})
+}
+
+func deployKeyFromAPI(apiObj *SessionToken) gitprovider.DeployKeyInfo {
+	return gitprovider.DeployKeyInfo{
+		Name: apiObj.DisplayName,
+		Key:  []byte(apiObj.PublicData),
+		// SSH keys have the access of their user
+		ReadOnly: gitprovider.BoolVar(false),
+	}
+}
+
+func deployKeyToAPI(info *gitprovider.DeployKeyInfo) (*SessionToken, error) {
+	k := &SessionToken{
+		Scope:    sshKeyScope,
+		IsPublic: true,
+	}
+	if err := deployKeyInfoToAPIObj(info, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// deployKeyInfoToAPIObj applies info to apiObj. ErrNoProviderSupport is returned for read-only
+// keys, as Azure DevOps SSH keys have the access of their user.
+func deployKeyInfoToAPIObj(info *gitprovider.DeployKeyInfo, apiObj *SessionToken) error {
+	if info.ReadOnly != nil && *info.ReadOnly {
+		return fmt.Errorf("azure devops ssh keys have the access of their user, hence ReadOnly must be false: %w", gitprovider.ErrNoProviderSupport)
+	}
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.DisplayName = info.Name
+	apiObj.PublicData = strings.TrimSpace(string(info.Key))
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// tokenScopes maps the deploy token scopes to the scopes of Azure DevOps personal access tokens.
+//
+//nolint:gochecknoglobals
+var tokenScopes = map[gitprovider.DeployTokenScope]string{
+	gitprovider.DeployTokenScopeReadRepository:       "vso.code",
+	gitprovider.DeployTokenScopeReadPackageRegistry:  "vso.packaging",
+	gitprovider.DeployTokenScopeWritePackageRegistry: "vso.packaging_write",
+}
+
+func newDeployToken(c *DeployTokenClient, token *SessionToken) *deployToken {
+	return &deployToken{
+		t: *token,
+		c: c,
+	}
+}
+
+var _ gitprovider.DeployToken = &deployToken{}
+
+type deployToken struct {
+	t SessionToken
+	c *DeployTokenClient
+}
+
+func (dt *deployToken) Get() gitprovider.DeployTokenInfo {
+	return deployTokenFromAPI(&dt.t)
+}
+
+// Token returns the token value, which is only set if dt was returned by Create.
+func (dt *deployToken) Token() string {
+	return dt.t.Token
+}
+
+func (dt *deployToken) APIObject() interface{} {
+	return &dt.t
+}
+
+func (dt *deployToken) Repository() gitprovider.RepositoryRef {
+	return dt.c.ref
+}
+
+// Delete revokes the personal access token.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dt *deployToken) Delete(ctx context.Context) error {
+	// We can use the same authorization ID that we got from the GET calls. Make sure it's set.
+	// This _should never_ happen, but just check for it anyways to avoid revoking the wrong token.
+	if dt.t.AuthorizationID == "" {
+		return fmt.Errorf("didn't expect AuthorizationID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	// DELETE /_apis/token/sessiontokens/{authorizationId}?isPublic=false
+	return dt.c.c.DeleteSessionToken(ctx, dt.t.AuthorizationID, false)
+}
+
+func deployTokenFromAPI(apiObj *SessionToken) gitprovider.DeployTokenInfo {
+	// Scopes the deploy tokens have no equivalent of, e.g. "vso.work", are left out
+	scopes := []gitprovider.DeployTokenScope{}
+	for _, apiScope := range strings.Fields(apiObj.Scope) {
+		for scope, s := range tokenScopes {
+			if s == apiScope {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return gitprovider.DeployTokenInfo{
+		Name:      apiObj.DisplayName,
+		Scopes:    scopes,
+		ExpiresAt: apiObj.ValidTo,
+	}
+}
+
+// deployTokenToAPI returns the request creating a personal access token for info.
+// ErrNoProviderSupport is returned for scopes Azure DevOps has no equivalent of.
+func deployTokenToAPI(info *gitprovider.DeployTokenInfo) (*SessionToken, error) {
+	scopes := make([]string, 0, len(info.Scopes))
+	for _, scope := range info.Scopes {
+		s, ok := tokenScopes[scope]
+		if !ok {
+			return nil, fmt.Errorf("azure devops has no container registry, hence the %q scope isn't supported: %w", scope, gitprovider.ErrNoProviderSupport)
+		}
+		scopes = append(scopes, s)
+	}
+	return &SessionToken{
+		DisplayName: info.Name,
+		Scope:       strings.Join(scopes, " "),
+		ValidTo:     info.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// pullRequestStatusCompleted is the status of merged pull requests.
+	pullRequestStatusCompleted = "completed"
+	// pullRequestStatusAbandoned is the status of pull requests closed without merging.
+	pullRequestStatusAbandoned = "abandoned"
+	// pullRequestStatusActive is the status of open pull requests.
+	pullRequestStatusActive = "active"
+)
+
+// failedMergeStatuses are the merge statuses of pull requests which can't be completed.
+//
+//nolint:gochecknoglobals
+var failedMergeStatuses = map[string]bool{
+	"conflicts":        true,
+	"failure":          true,
+	"rejectedByPolicy": true,
+}
+
+func newPullRequest(apiObj *GitPullRequest, webURL string) *pullrequest {
+	return &pullrequest{
+		pr:     *apiObj,
+		webURL: webURL,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	pr GitPullRequest
+	// webURL is the URL of the repository in the web interface.
+	webURL string
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullrequestFromAPI(&pr.pr, pr.webURL)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.pr
+}
+
+// Reviews gives access to the reviews of the pull request, which aren't supported yet.
+func (pr *pullrequest) Reviews() gitprovider.ReviewClient {
+	return &ReviewClient{}
+}
+
+// Comments gives access to the comments of the pull request, which aren't supported yet.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{}
+}
+
+// Files always returns ErrNoProviderSupport, as the files of pull requests aren't implemented yet.
+func (pr *pullrequest) Files(_ context.Context) ([]gitprovider.PullRequestFile, error) {
+	return nil, errNotImplemented("listing the files of pull requests")
+}
+
+// Diff always returns ErrNoProviderSupport, as the diffs of pull requests aren't implemented yet.
+func (pr *pullrequest) Diff(_ context.Context) (string, error) {
+	return "", errNotImplemented("getting the diff of pull requests")
+}
+
+// EnableAutoMerge always returns ErrNoProviderSupport. Azure DevOps has auto-complete,
+// which isn't supported yet.
+func (pr *pullrequest) EnableAutoMerge(_ context.Context, _ gitprovider.MergeMethod) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetLabels always returns ErrNoProviderSupport, as Azure Repos has no repository labels.
+func (pr *pullrequest) SetLabels(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetAssignees always returns ErrNoProviderSupport, as Azure DevOps pull requests have reviewers, but no assignees.
+func (pr *pullrequest) SetAssignees(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetMilestone always returns ErrNoProviderSupport, as Azure Repos has no milestones.
+func (pr *pullrequest) SetMilestone(_ context.Context, _ int) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// AddReaction always returns ErrNoProviderSupport, as Azure DevOps has no reactions to pull requests.
+func (pr *pullrequest) AddReaction(_ context.Context, _ gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListReactions always returns ErrNoProviderSupport, as Azure DevOps has no reactions to pull requests.
+func (pr *pullrequest) ListReactions(_ context.Context) ([]gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func pullrequestFromAPI(apiObj *GitPullRequest, webURL string) gitprovider.PullRequestInfo {
+	return gitprovider.PullRequestInfo{
+		Title:        apiObj.Title,
+		Description:  apiObj.Description,
+		Merged:       apiObj.Status == pullRequestStatusCompleted,
+		Number:       apiObj.PullRequestID,
+		WebURL:       fmt.Sprintf("%s/pullrequest/%d", webURL, apiObj.PullRequestID),
+		SourceBranch: strings.TrimPrefix(apiObj.SourceRefName, branchRefPrefix),
+		BaseBranch:   strings.TrimPrefix(apiObj.TargetRefName, branchRefPrefix),
+		State:        pullRequestStateFromAPI(apiObj.Status),
+		Draft:        apiObj.IsDraft != nil && *apiObj.IsDraft,
+	}
+}
+
+// pullRequestStatusToAPI maps a gitprovider.PullRequestState to the status of a pull request.
+func pullRequestStatusToAPI(state gitprovider.PullRequestState) string {
+	switch state {
+	case gitprovider.PullRequestStateMerged:
+		return pullRequestStatusCompleted
+	case gitprovider.PullRequestStateClosed:
+		return pullRequestStatusAbandoned
+	}
+	return pullRequestStatusActive
+}
+
+// pullRequestStateFromAPI maps the status of a pull request to a gitprovider.PullRequestState.
+func pullRequestStateFromAPI(status string) gitprovider.PullRequestState {
+	switch status {
+	case pullRequestStatusCompleted:
+		return gitprovider.PullRequestStateMerged
+	case pullRequestStatusAbandoned:
+		return gitprovider.PullRequestStateClosed
+	}
+	return gitprovider.PullRequestStateOpen
+}
+
+// validatePullRequestAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validatePullRequestAPI(apiObj *GitPullRequest) error {
+	return validateAPIObject("AzureDevOps.GitPullRequest", func(validator validation.Validator) {
+		if apiObj.PullRequestID == 0 {
+			validator.Required("PullRequestID")
+		}
+	})
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// branchRefPrefix is the prefix of the fully qualified refs of branches.
+	branchRefPrefix = "refs/heads/"
+	// emptyObjectID is the object ID refs are updated from when they are created.
+	emptyObjectID = "0000000000000000000000000000000000000000"
+)
+
+func newOrgRepository(ctx *clientContext, apiObj *GitRepository, ref gitprovider.RepositoryRef) *orgRepository {
+	return &orgRepository{
+		clientContext: ctx,
+		r:             *apiObj,
+		ref:           ref,
+		deployKeys: &DeployKeyClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		deployTokens: &DeployTokenClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		labels:            &LabelClient{},
+		milestones:        &MilestoneClient{},
+		releases:          &ReleaseClient{},
+		webhooks:          &WebhookClient{},
+		branchProtections: &BranchProtectionClient{},
+		tagProtections:    &TagProtectionClient{},
+		commits:           &CommitClient{},
+		branches:          &BranchClient{},
+		pullRequests: &PullRequestClient{
+			clientContext: ctx,
+			ref:           ref,
+			webURL:        apiObj.WebURL,
+		},
+		files:              &FileClient{},
+		trees:              &TreeClient{},
+		actionsPermissions: &ActionsPermissionsClient{},
+		teamAccess:         &TeamAccessClient{},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	*clientContext
+
+	r   GitRepository
+	ref gitprovider.RepositoryRef
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+	teamAccess         *TeamAccessClient
+
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
+}
+
+func (r *orgRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+func (r *orgRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return repositoryInfoToAPIObj(&info, &r.r)
+}
+
+func (r *orgRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *orgRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// InitialCommitSHA returns the SHA of the initial commit, if the repository was created with AutoInit.
+func (r *orgRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
+}
+
+func (r *orgRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *orgRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *orgRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *orgRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *orgRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *orgRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *orgRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *orgRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
+func (r *orgRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *orgRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *orgRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *orgRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *orgRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *orgRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *orgRepository) Update(ctx context.Context) error {
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.r.ID, newRepositorySpec(&r.r))
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *orgRepository) Reconcile(ctx context.Context) (bool, error) {
+	// GET /{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := r.c.GetRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			req := &GitRepositoryCreateOptions{Name: r.ref.GetRepository()}
+			if r.r.Project != nil {
+				req.Project = &Project{ID: r.r.Project.ID}
+			}
+			// POST /{project}/_apis/git/repositories
+			repo, err := r.c.CreateRepo(ctx, r.ref.GetIdentity(), req)
+			if err != nil {
+				return true, err
+			}
+			r.r = *repo
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if reflect.DeepEqual(newRepositorySpec(&r.r), newRepositorySpec(apiObj)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, r.Update(ctx)
+}
+
+// SetDefaultBranch makes the given branch the default branch of the repository.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *orgRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	// PATCH /{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.r.ID, &GitRepositoryUpdateOptions{DefaultBranch: gitprovider.StringVar(branchRef(branch))})
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Delete deletes the current resource irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *orgRepository) Delete(ctx context.Context) error {
+	// DELETE /{project}/_apis/git/repositories/{repositoryId}
+	return r.c.DeleteRepo(ctx, r.ref.GetIdentity(), r.r.ID)
+}
+
+// Star always returns ErrNoProviderSupport, as Azure DevOps doesn't support starring repositories.
+func (r *orgRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as Azure DevOps doesn't support starring repositories.
+func (r *orgRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as Azure DevOps doesn't support starring repositories.
+func (r *orgRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as Azure DevOps doesn't expose repository notification settings.
+func (r *orgRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as Azure DevOps doesn't expose repository notification settings.
+func (r *orgRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as Azure Repos has no issues. Work items of
+// Azure Boards belong to projects instead of repositories.
+func (r *orgRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, gitprovider.ErrNoProviderSupport
+}
+
+// validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRepositoryAPI(apiObj *GitRepository) error {
+	return validateAPIObject("AzureDevOps.GitRepository", func(validator validation.Validator) {
+		// Make sure the ID is set, as it's needed for updating and deleting the repository
+		if apiObj.ID == "" {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// validatePushAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validatePushAPI(apiObj *GitPush) error {
+	return validateAPIObject("AzureDevOps.GitPush", func(validator validation.Validator) {
+		if len(apiObj.Commits) == 0 || apiObj.Commits[0].CommitID == "" {
+			validator.Required("Commits[0].CommitID")
+		}
+	})
+}
+
+// branchRef returns the fully qualified ref of the given branch.
+func branchRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return branchRefPrefix + branch
+}
+
+func repositoryFromAPI(apiObj *GitRepository) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{}
+	// Repositories inherit the visibility of their project
+	if apiObj.Project != nil && apiObj.Project.Visibility != "" {
+		repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility(apiObj.Project.Visibility))
+	}
+	if apiObj.DefaultBranch != nil {
+		repo.DefaultBranch = gitprovider.StringVar(strings.TrimPrefix(*apiObj.DefaultBranch, branchRefPrefix))
+	}
+	return repo
+}
+
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings Azure DevOps doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *GitRepository) error {
+	if repo.DefaultBranch != nil {
+		apiObj.DefaultBranch = gitprovider.StringVar(branchRef(*repo.DefaultBranch))
+	}
+	if repo.Description != nil {
+		return fmt.Errorf("azure devops repositories have no description: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.Visibility != nil {
+		if apiObj.Project == nil || apiObj.Project.Visibility != string(*repo.Visibility) {
+			return fmt.Errorf("azure devops repositories inherit the visibility of their project, hence %q can't be set: %w", *repo.Visibility, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	// The merge settings are chosen per pull request
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("azure devops doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("azure devops doesn't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// newRepositorySpec copies over the fields of the repository that can be set, i.e. the desired
+// spec of the repository. This allows us to separate "spec" from "status" fields.
+func newRepositorySpec(apiObj *GitRepository) *GitRepositoryUpdateOptions {
+	return &GitRepositoryUpdateOptions{
+		DefaultBranch: apiObj.DefaultBranch,
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import "time"
+
+// The types in this file are the objects of the Azure DevOps REST API 7.1, as documented in
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/. Only the fields used by this
+// package are part of them.
+
+// Project is an Azure DevOps project, which owns repositories. The visibility is either
+// "private" or "public".
+type Project struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+}
+
+// GitRepository is a Git repository in a project. DefaultBranch is a fully qualified ref, e.g.
+// "refs/heads/main", and is only set once the repository has been pushed to.
+type GitRepository struct {
+	ID            string   `json:"id,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	DefaultBranch *string  `json:"defaultBranch,omitempty"`
+	Project       *Project `json:"project,omitempty"`
+	RemoteURL     string   `json:"remoteUrl,omitempty"`
+	SSHURL        string   `json:"sshUrl,omitempty"`
+	WebURL        string   `json:"webUrl,omitempty"`
+	IsDisabled    bool     `json:"isDisabled,omitempty"`
+}
+
+// GitRepositoryCreateOptions is the request to create a repository in the given project.
+type GitRepositoryCreateOptions struct {
+	Name    string   `json:"name"`
+	Project *Project `json:"project"`
+}
+
+// GitRepositoryUpdateOptions is the request to update a repository. Only the set fields are changed.
+type GitRepositoryUpdateOptions struct {
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+}
+
+// GitRefUpdate moves a ref from OldObjectID to NewObjectID. Refs are created by using the
+// all-zero object ID as OldObjectID.
+type GitRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+// ItemContent is the content of a file, with ContentType being "rawtext" or "base64encoded".
+type ItemContent struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"`
+}
+
+// GitItem is a file or folder in a repository.
+type GitItem struct {
+	Path string `json:"path"`
+}
+
+// GitChange is a change of a single file, with ChangeType being e.g. "add" or "edit".
+type GitChange struct {
+	ChangeType string       `json:"changeType"`
+	Item       *GitItem     `json:"item"`
+	NewContent *ItemContent `json:"newContent,omitempty"`
+}
+
+// GitCommitRef is a commit, either pushed as part of a GitPush, or referred to by ID.
+type GitCommitRef struct {
+	CommitID string      `json:"commitId,omitempty"`
+	Comment  string      `json:"comment,omitempty"`
+	Changes  []GitChange `json:"changes,omitempty"`
+}
+
+// GitPush pushes commits, and moves refs to them.
+type GitPush struct {
+	PushID     int            `json:"pushId,omitempty"`
+	RefUpdates []GitRefUpdate `json:"refUpdates"`
+	Commits    []GitCommitRef `json:"commits"`
+}
+
+// GitPullRequestCompletionOptions are the options used when completing, i.e. merging, a pull
+// request. MergeStrategy is e.g. "noFastForward" or "squash".
+type GitPullRequestCompletionOptions struct {
+	MergeStrategy      string `json:"mergeStrategy,omitempty"`
+	MergeCommitMessage string `json:"mergeCommitMessage,omitempty"`
+}
+
+// IdentityRef is a user, UniqueName is the name the user signs in with, e.g. an email address.
+type IdentityRef struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+}
+
+// GitPullRequestSearchCriteria filters the listed pull requests. Status is either "active",
+// "abandoned", "completed" or "all", and the refs are fully qualified.
+type GitPullRequestSearchCriteria struct {
+	Status        string
+	SourceRefName string
+	TargetRefName string
+}
+
+// GitPullRequest is a pull request of a repository. Status is either "active", "abandoned" or
+// "completed", and the refs are fully qualified, e.g. "refs/heads/main". MergeStatus is the
+// status of the latest merge attempt, e.g. "queued", "succeeded" or "conflicts". The fields are
+// omitted if empty, in order to only send the set fields when updating the pull request, hence
+// IsDraft is a pointer.
+type GitPullRequest struct {
+	PullRequestID         int                              `json:"pullRequestId,omitempty"`
+	Status                string                           `json:"status,omitempty"`
+	MergeStatus           string                           `json:"mergeStatus,omitempty"`
+	Title                 string                           `json:"title,omitempty"`
+	Description           string                           `json:"description,omitempty"`
+	SourceRefName         string                           `json:"sourceRefName,omitempty"`
+	TargetRefName         string                           `json:"targetRefName,omitempty"`
+	IsDraft               *bool                            `json:"isDraft,omitempty"`
+	LastMergeSourceCommit *GitCommitRef                    `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *GitPullRequestCompletionOptions `json:"completionOptions,omitempty"`
+	Repository            *GitRepository                   `json:"repository,omitempty"`
+	CreatedBy             *IdentityRef                     `json:"createdBy,omitempty"`
+}
+
+// SessionToken is an SSH public key of a user if IsPublic is true, with the key in PublicData,
+// and a personal access token otherwise. Scope is a space-separated list of scopes, e.g.
+// "vso.code_write", or "app_token" for SSH keys. Token is the value of a personal access token,
+// and only returned when it is created.
+type SessionToken struct {
+	AuthorizationID string     `json:"authorizationId,omitempty"`
+	DisplayName     string     `json:"displayName,omitempty"`
+	Scope           string     `json:"scope,omitempty"`
+	ValidTo         *time.Time `json:"validTo,omitempty"`
+	IsPublic        bool       `json:"isPublic,omitempty"`
+	PublicData      string     `json:"publicData,omitempty"`
+	Token           string     `json:"token,omitempty"`
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	errorsDocURL    = "https://learn.microsoft.com/en-us/rest/api/azure/devops/"
+	rateLimitDocURL = "https://learn.microsoft.com/en-us/azure/devops/integrate/concepts/rate-limits"
+
+	// defaultPerPage is the page size used for lists, if none is given in the call options.
+	defaultPerPage = 100
+)
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Azure DevOps' usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Azure DevOps' usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization:
+		return nil
+	case gitprovider.IdentityTypeUser:
+		return fmt.Errorf("azure devops has no repositories owned by users: %w", gitprovider.ErrNoProviderSupport)
+	case gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("azure devops projects can't be nested: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// errorResponse is the body of Azure DevOps' error responses. TypeKey is the name of the
+// exception, e.g. "GitRepositoryNotFoundException".
+type errorResponse struct {
+	Message string `json:"message"`
+	TypeKey string `json:"typeKey"`
+}
+
+// handleHTTPError reads the error response resp, and returns typed variants of it.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(resp *http.Response) error {
+	// The body is only used for the message, hence reading a prefix is enough
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	apiErr := &errorResponse{}
+	message := http.StatusText(resp.StatusCode)
+	if err := json.Unmarshal(body, apiErr); err == nil && apiErr.Message != "" {
+		message = apiErr.Message
+	}
+	httpErr := gitprovider.HTTPError{
+		Response:         resp,
+		ErrorMessage:     fmt.Sprintf("%s %s: %d %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode, message),
+		Message:          message,
+		DocumentationURL: errorsDocURL,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusNonAuthoritativeInfo:
+		// Check for invalid credentials, and return a typed error in that case
+		return &gitprovider.InvalidCredentialsError{HTTPError: httpErr}
+	case http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case http.StatusNotFound:
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		httpErr.DocumentationURL = rateLimitDocURL
+		return newRateLimitError(httpErr)
+	case http.StatusConflict:
+		// Check for already exists errors, e.g. "TF400948: A Git repository with the name x already exists."
+		if strings.Contains(message, "already exists") {
+			return validation.NewMultiError(&httpErr, gitprovider.ErrAlreadyExists)
+		}
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// newRateLimitError returns a RateLimitError with the limits Azure DevOps reports in the
+// X-RateLimit-* headers, if set.
+func newRateLimitError(httpErr gitprovider.HTTPError) *gitprovider.RateLimitError {
+	rateLimitErr := &gitprovider.RateLimitError{HTTPError: httpErr}
+	header := httpErr.Response.Header
+	rateLimitErr.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	rateLimitErr.Remaining, _ = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimitErr.Reset = time.Unix(reset, 0)
+	}
+	return rateLimitErr
+}
+
+// list is a list of values, which are decoded by the caller.
+type list struct {
+	Count int             `json:"count"`
+	Value json.RawMessage `json:"value"`
+}
+
+// allPages requests the list at path with the given query page by page, using the $top and $skip
+// parameters, and calls fn with the values of each page. fn is expected to save the values to an outer variable,
+// and return how many it got. The last page is the first one with fewer values than requested.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *azureDevOpsClientImpl) allPages(ctx context.Context, path string, query url.Values, fn func(values json.RawMessage) (int, error)) error {
+	perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	for skip := 0; ; skip += perPage {
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pageQuery := url.Values{"$top": {strconv.Itoa(perPage)}, "$skip": {strconv.Itoa(skip)}}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		l := &list{}
+		if err := c.do(ctx, http.MethodGet, path+"?"+pageQuery.Encode(), nil, l); err != nil {
+			return err
+		}
+		n, err := fn(l.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decode the values of %s: %v: %w", path, err, gitprovider.ErrInvalidServerData)
+		}
+		if n < perPage {
+			return nil
+		}
+	}
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that Azure DevOps has, but which aren't
+// implemented by this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for azure devops yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
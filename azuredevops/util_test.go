@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// newTestClient returns a client for a fake Azure DevOps API of the "fabrikam" organization
+// served by mux.
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	srv := testutil.NewServer(t, mux)
+	baseURL, _ := url.Parse(srv.URL + "/fabrikam/")
+	return newClient(srv.Client(), baseURL, baseURL, "token", DefaultDomain, false)
+}
+
+func Test_handleHTTPError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedErrs []error
+	}{
+		{
+			name:         "401 => InvalidCredentialsError",
+			status:       http.StatusUnauthorized,
+			expectedErrs: []error{&gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			// Azure DevOps serves its sign-in page for requests with invalid credentials
+			name:         "203 sign-in page => InvalidCredentialsError",
+			status:       http.StatusNonAuthoritativeInfo,
+			body:         "<html><title>Azure DevOps Services | Sign In</title></html>",
+			expectedErrs: []error{&gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			name:         "403 => InvalidCredentialsError & ErrForbidden",
+			status:       http.StatusForbidden,
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+		},
+		{
+			name:         "404 => ErrNotFound",
+			status:       http.StatusNotFound,
+			body:         `{"message": "TF401019: The Git repository with name or identifier missing does not exist or you do not have permissions for the operation you are attempting.", "typeKey": "GitRepositoryNotFoundException"}`,
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+		},
+		{
+			name:         "409 for existing repository => ErrAlreadyExists",
+			status:       http.StatusConflict,
+			body:         `{"message": "TF400948: A Git repository with the name repo already exists."}`,
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+		},
+		{
+			name:         "409 for other conflicts => HTTPError",
+			status:       http.StatusConflict,
+			body:         `{"message": "TF401028: The reference 'refs/heads/main' has already been updated by another client."}`,
+			expectedErrs: []error{&gitprovider.HTTPError{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleHTTPError(newTestResponse(tt.status, tt.body))
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+		})
+	}
+}
+
+func Test_handleHTTPError_rateLimit(t *testing.T) {
+	resp := newTestResponse(http.StatusTooManyRequests, `{"message": "Request was blocked due to exceeding usage of resource 'Core' in namespace 'User'."}`)
+	resp.Header = http.Header{}
+	resp.Header.Set("X-RateLimit-Limit", "200")
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", "1700000000")
+
+	err := handleHTTPError(resp)
+	rateLimitErr := &gitprovider.RateLimitError{}
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("handleHTTPError() error = %v, want a RateLimitError", err)
+	}
+	if rateLimitErr.Limit != 200 || rateLimitErr.Remaining != 0 || !rateLimitErr.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("RateLimitError = %+v, want the limits of the X-RateLimit headers", rateLimitErr)
+	}
+	if rateLimitErr.DocumentationURL != rateLimitDocURL {
+		t.Errorf("DocumentationURL = %q, want %q", rateLimitErr.DocumentationURL, rateLimitDocURL)
+	}
+}
+
+func newTestResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/fabrikam/flux/_apis/git/repositories/repo"}},
+	}
+}
+
+func Test_allPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects int
+		wantSkip []string
+	}{
+		{
+			name:     "last page not full",
+			projects: 3,
+			wantSkip: []string{"0", "2"},
+		},
+		{
+			// Azure DevOps doesn't tell whether there are more values, hence an empty page is
+			// requested after a full last page
+			name:     "last page full",
+			projects: 4,
+			wantSkip: []string{"0", "2", "4"},
+		},
+		{
+			name:     "empty list",
+			wantSkip: []string{"0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := []map[string]string{}
+			for i := 0; i < tt.projects; i++ {
+				projects = append(projects, map[string]string{"id": strconv.Itoa(i), "name": "project-" + strconv.Itoa(i)})
+			}
+			skips := []string{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/fabrikam/_apis/projects", func(w http.ResponseWriter, r *http.Request) {
+				if top := r.URL.Query().Get("$top"); top != "2" {
+					t.Errorf("$top = %q, want 2", top)
+				}
+				if got := r.URL.Query().Get("api-version"); got != apiVersion {
+					t.Errorf("api-version = %q, want %q", got, apiVersion)
+				}
+				skips = append(skips, r.URL.Query().Get("$skip"))
+				skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+				page := []map[string]string{}
+				for i := skip; i < skip+2 && i < len(projects); i++ {
+					page = append(page, projects[i])
+				}
+				testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"count": len(page), "value": page})
+			})
+			c := newTestClient(t, mux)
+
+			perPage := 2
+			ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{PerPage: &perPage})
+			orgs, err := c.Organizations().List(ctx)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(orgs) != tt.projects {
+				t.Errorf("List() returned %d projects, want %d", len(orgs), tt.projects)
+			}
+			if !reflect.DeepEqual(skips, tt.wantSkip) {
+				t.Errorf("requested $skip %v, want %v", skips, tt.wantSkip)
+			}
+		})
+	}
+}
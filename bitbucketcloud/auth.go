@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "bitbucket.org"
+	// TokenVariable is the common name for the environment variable
+	// containing a Bitbucket Cloud authentication token.
+	TokenVariable = "BITBUCKET_TOKEN" // #nosec G101
+
+	// defaultBaseURL is the base URL of the Bitbucket Cloud REST API 2.0.
+	defaultBaseURL = "https://api.bitbucket.org/2.0/"
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	if token := gitprovider.TokenFromURL(u); token != "" {
+		opts = append(opts, gitprovider.WithOAuth2Token(token))
+	}
+	return NewClient(append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for Bitbucket Cloud API endpoints.
+//
+// Using WithOAuth2Token you can specify authentication credentials, e.g. a workspace or
+// repository access token, or an OAuth 2.0 access token. Passing no such ClientOption will
+// allow public read access only. Use WithTokenSource instead for credentials that are rotated.
+//
+// Bitbucket Cloud is only available at bitbucket.org, hence WithDomain can't be set to any
+// other domain. Use the stash package for Bitbucket Server and Data Center instead.
+//
+// Bitbucket Cloud addresses all repositories by workspace. Workspaces are mapped to
+// organizations, and personal workspaces can be used as users.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Domain != nil && *opts.Domain != DefaultDomain {
+		return nil, fmt.Errorf("bitbucket cloud is only available at %s, got %q: %w", DefaultDomain, *opts.Domain, gitprovider.ErrDomainUnsupported)
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := url.Parse(defaultBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(httpClient, baseURL, DefaultDomain, destructiveActions), nil
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// bitbucketClient is a wrapper around the Bitbucket Cloud REST API 2.0, which implements
+// higher-level methods, operating on the structs in types.go. Pagination is implemented for all
+// List* methods, all returned objects are validated, and HTTP errors are handled/wrapped using
+// handleHTTPError. This interface is also fakeable, in order to unit-test the client.
+type bitbucketClient interface {
+	// Client returns the underlying *http.Client
+	Client() *http.Client
+
+	// GetWorkspace is a wrapper for "GET /workspaces/{workspace}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetWorkspace(ctx context.Context, workspace string) (*Workspace, error)
+	// ListWorkspaces is a wrapper for "GET /user/permissions/workspaces", returning the
+	// workspaces the authenticated user is a member of.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListWorkspaces(ctx context.Context) ([]*Workspace, error)
+
+	// GetRepo is a wrapper for "GET /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, workspace, repo string) (*Repository, error)
+	// ListRepos is a wrapper for "GET /repositories/{workspace}".
+	// progress is called after each page, if non-nil.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepos(ctx context.Context, workspace string, progress gitprovider.ListProgressFunc) ([]*Repository, error)
+	// CreateRepo is a wrapper for "POST /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error)
+	// UpdateRepo is a wrapper for "PUT /repositories/{workspace}/{repo_slug}".
+	// Only the set fields of req are changed.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error)
+	// DeleteRepo is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, workspace, repo string) error
+
+	// CreateFiles is a wrapper for "POST /repositories/{workspace}/{repo_slug}/src", committing
+	// files, a map of paths to contents, to the given branch.
+	// This function handles HTTP error wrapping.
+	CreateFiles(ctx context.Context, workspace, repo, branch, message string, files map[string]string) error
+	// GetBranch is a wrapper for "GET /repositories/{workspace}/{repo_slug}/refs/branches/{name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, workspace, repo, branch string) (*Branch, error)
+
+	// ListKeys is a wrapper for "GET /repositories/{workspace}/{repo_slug}/deploy-keys".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListKeys(ctx context.Context, workspace, repo string) ([]*DeployKey, error)
+	// CreateKey is a wrapper for "POST /repositories/{workspace}/{repo_slug}/deploy-keys".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateKey(ctx context.Context, workspace, repo string, req *DeployKey) (*DeployKey, error)
+	// DeleteKey is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/deploy-keys/{key_id}".
+	// This function handles HTTP error wrapping.
+	DeleteKey(ctx context.Context, workspace, repo string, id int) error
+
+	// ListGroupPermissions is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/groups".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupPermissions(ctx context.Context, workspace, repo string) ([]*GroupPermission, error)
+	// GetGroupPermission is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetGroupPermission(ctx context.Context, workspace, repo, group string) (*GroupPermission, error)
+	// SetGroupPermission is a wrapper for "PUT /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping.
+	SetGroupPermission(ctx context.Context, workspace, repo, group, permission string) error
+	// DeleteGroupPermission is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping.
+	DeleteGroupPermission(ctx context.Context, workspace, repo, group string) error
+}
+
+// bitbucketClientImpl is a wrapper around *http.Client, which implements higher-level methods,
+// operating on the structs in types.go.
+type bitbucketClientImpl struct {
+	c                  *http.Client
+	baseURL            *url.URL
+	destructiveActions bool
+}
+
+// bitbucketClientImpl implements bitbucketClient.
+var _ bitbucketClient = &bitbucketClientImpl{}
+
+func (c *bitbucketClientImpl) Client() *http.Client {
+	return c.c
+}
+
+func (c *bitbucketClientImpl) GetWorkspace(ctx context.Context, workspace string) (*Workspace, error) {
+	apiObj := &Workspace{}
+	// GET /workspaces/{workspace}
+	if err := c.do(ctx, http.MethodGet, apiPath("workspaces", workspace), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateWorkspaceAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListWorkspaces(ctx context.Context) ([]*Workspace, error) {
+	apiObjs := []*Workspace{}
+	// GET /user/permissions/workspaces
+	err := c.allPages(ctx, apiPath("user", "permissions", "workspaces"), nil, func(values json.RawMessage) (int, error) {
+		pageObjs := []*WorkspacePermission{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		for _, pageObj := range pageObjs {
+			if pageObj.Workspace == nil {
+				return 0, fmt.Errorf("workspace permission without workspace: %w", gitprovider.ErrInvalidServerData)
+			}
+			apiObjs = append(apiObjs, pageObj.Workspace)
+		}
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateWorkspaceAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) GetRepo(ctx context.Context, workspace, repo string) (*Repository, error) {
+	apiObj := &Repository{}
+	// GET /repositories/{workspace}/{repo_slug}
+	if err := c.do(ctx, http.MethodGet, apiPath("repositories", workspace, repo), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListRepos(ctx context.Context, workspace string, progress gitprovider.ListProgressFunc) ([]*Repository, error) {
+	apiObjs := []*Repository{}
+	// GET /repositories/{workspace}
+	err := c.allPages(ctx, apiPath("repositories", workspace), progress, func(values json.RawMessage) (int, error) {
+		pageObjs := []*Repository{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) CreateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// POST /repositories/{workspace}/{repo_slug}
+	if err := c.do(ctx, http.MethodPost, apiPath("repositories", workspace, repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) UpdateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// PUT /repositories/{workspace}/{repo_slug}
+	if err := c.do(ctx, http.MethodPut, apiPath("repositories", workspace, repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteRepo(ctx context.Context, workspace, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repositories/{workspace}/{repo_slug}
+	return c.do(ctx, http.MethodDelete, apiPath("repositories", workspace, repo), nil, nil)
+}
+
+func (c *bitbucketClientImpl) CreateFiles(ctx context.Context, workspace, repo, branch, message string, files map[string]string) error {
+	// The files are sent as form fields named after their paths
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fields := map[string]string{"branch": branch, "message": message}
+	for path, content := range files {
+		fields[path] = content
+	}
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	// POST /repositories/{workspace}/{repo_slug}/src
+	req, err := c.newRequest(ctx, http.MethodPost, apiPath("repositories", workspace, repo, "src"), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return c.send(req, nil)
+}
+
+func (c *bitbucketClientImpl) GetBranch(ctx context.Context, workspace, repo, branch string) (*Branch, error) {
+	apiObj := &Branch{}
+	// GET /repositories/{workspace}/{repo_slug}/refs/branches/{name}
+	if err := c.do(ctx, http.MethodGet, apiPath("repositories", workspace, repo, "refs", "branches", branch), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListKeys(ctx context.Context, workspace, repo string) ([]*DeployKey, error) {
+	apiObjs := []*DeployKey{}
+	// GET /repositories/{workspace}/{repo_slug}/deploy-keys
+	err := c.allPages(ctx, apiPath("repositories", workspace, repo, "deploy-keys"), nil, func(values json.RawMessage) (int, error) {
+		pageObjs := []*DeployKey{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) CreateKey(ctx context.Context, workspace, repo string, req *DeployKey) (*DeployKey, error) {
+	apiObj := &DeployKey{}
+	// POST /repositories/{workspace}/{repo_slug}/deploy-keys
+	if err := c.do(ctx, http.MethodPost, apiPath("repositories", workspace, repo, "deploy-keys"), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateDeployKeyAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteKey(ctx context.Context, workspace, repo string, id int) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/deploy-keys/{key_id}
+	return c.do(ctx, http.MethodDelete, apiPath("repositories", workspace, repo, "deploy-keys", strconv.Itoa(id)), nil, nil)
+}
+
+func (c *bitbucketClientImpl) ListGroupPermissions(ctx context.Context, workspace, repo string) ([]*GroupPermission, error) {
+	apiObjs := []*GroupPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups
+	err := c.allPages(ctx, apiPath("repositories", workspace, repo, "permissions-config", "groups"), nil, func(values json.RawMessage) (int, error) {
+		pageObjs := []*GroupPermission{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return 0, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return len(pageObjs), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateGroupPermissionAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) GetGroupPermission(ctx context.Context, workspace, repo, group string) (*GroupPermission, error) {
+	apiObj := &GroupPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	if err := c.do(ctx, http.MethodGet, apiPath("repositories", workspace, repo, "permissions-config", "groups", group), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateGroupPermissionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) SetGroupPermission(ctx context.Context, workspace, repo, group, permission string) error {
+	// PUT /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	return c.do(ctx, http.MethodPut, apiPath("repositories", workspace, repo, "permissions-config", "groups", group),
+		&GroupPermission{Permission: permission}, nil)
+}
+
+func (c *bitbucketClientImpl) DeleteGroupPermission(ctx context.Context, workspace, repo, group string) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	return c.do(ctx, http.MethodDelete, apiPath("repositories", workspace, repo, "permissions-config", "groups", group), nil, nil)
+}
+
+// apiPath joins the given path segments, escaping each of them, e.g. for repository slugs
+// containing dots.
+func apiPath(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+	return strings.Join(escaped, "/")
+}
+
+// newRequest creates a request for the given path, which is resolved relative to the base URL.
+// Absolute URLs, e.g. the links to the next page, are used as-is.
+func (c *bitbucketClientImpl) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// do sends a request with body encoded as JSON if non-nil, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *bitbucketClientImpl) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := c.newRequest(ctx, method, path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req, out)
+}
+
+// send sends the request, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *bitbucketClientImpl) send(req *http.Request, out interface{}) error {
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return handleHTTPError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %s %s: %w", req.Method, req.URL.Redacted(), err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for Bitbucket Cloud.
+const ProviderID = gitprovider.ProviderID("bitbucketcloud")
+
+func newClient(httpClient *http.Client, baseURL *url.URL, domain string, destructiveActions bool) *Client {
+	bbClient := &bitbucketClientImpl{httpClient, baseURL, destructiveActions}
+	ctx := &clientContext{bbClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  bitbucketClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, i.e. "bitbucket.org".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "bitbucketcloud".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the *http.Client used under the hood for accessing the Bitbucket Cloud REST API,
+// as there is no Go client for Bitbucket Cloud supporting contexts.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeOrganization if a workspace with the given slug exists. Bitbucket
+// Cloud addresses all repositories by workspace, and doesn't tell personal workspaces apart,
+// hence those are reported as organizations too.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// GET /workspaces/{workspace}
+	if _, err := c.c.GetWorkspace(ctx, owner); err != nil {
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return "", fmt.Errorf("workspace %q: %w", owner, err)
+		}
+		return "", err
+	}
+	return gitprovider.OwnerTypeOrganization, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't support starring repositories.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as Bitbucket Cloud has no license templates.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as Bitbucket Cloud has no .gitignore templates.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as the permissions of Bitbucket Cloud
+// access tokens can't be inspected.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as the scopes of Bitbucket Cloud access tokens
+// can't be inspected.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as Bitbucket Cloud access tokens can't be
+// inspected.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the workspaces the user has access to.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get a specific workspace the user has access to.
+// This can't refer to a sub-organization, as Bitbucket Cloud workspaces can't be nested.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /workspaces/{workspace}
+	apiObj, err := c.c.GetWorkspace(ctx, ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// List all workspaces the specific user is a member of.
+//
+// List returns all available workspaces, using multiple paginated requests if needed.
+func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organization, error) {
+	// GET /user/permissions/workspaces
+	apiObjs, err := c.c.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj.Slug is already validated to be non-empty in ListWorkspaces
+		orgs = append(orgs, newOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: apiObj.Slug,
+		}))
+	}
+
+	return orgs, nil
+}
+
+// Children always returns ErrNoProviderSupport, as Bitbucket Cloud workspaces can't be nested.
+func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as Bitbucket Cloud workspaces
+// have no default repository permission.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as Bitbucket Cloud workspaces
+// have no default repository permission.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as Bitbucket Cloud
+// workspaces have no default repository permission.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
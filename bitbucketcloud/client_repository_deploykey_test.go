@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+func TestDeployKeyClient_Reconcile(t *testing.T) {
+	keys := []*DeployKey{{ID: 1, Label: "flux", Key: "ssh-ed25519 AAAA", Comment: "flux@cluster"}}
+	deleted := []int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/repositories/flux/repo/deploy-keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"values": keys})
+		case http.MethodPost:
+			key := &DeployKey{}
+			testutil.ReadJSON(t, r, key)
+			key.ID = len(keys) + 1
+			keys = append(keys, key)
+			testutil.WriteJSON(w, http.StatusOK, key)
+		}
+	})
+	mux.HandleFunc("/2.0/repositories/flux/repo/deploy-keys/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Path, "/2.0/repositories/flux/repo/deploy-keys/%d", &id); err != nil || r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		deleted = append(deleted, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux)
+	dks := &DeployKeyClient{
+		clientContext: c.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+
+	// The comment Bitbucket Cloud splits off is part of the key again
+	_, actionTaken, err := dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 AAAA flux@cluster")})
+	if err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	_, actionTaken, err = dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 BBBB")})
+	if err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want the key to be recreated", actionTaken, err)
+	}
+	if !reflect.DeepEqual(deleted, []int{1}) {
+		t.Errorf("deleted keys = %v, want [1]", deleted)
+	}
+
+	_, err = dks.Create(ctx, gitprovider.DeployKeyInfo{Name: "rw", Key: []byte("ssh-ed25519 CCCC"), ReadOnly: gitprovider.BoolVar(false)})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// LabelClient implements the gitprovider.LabelClient interface.
+var _ gitprovider.LabelClient = &LabelClient{}
+
+// LabelClient handles labels, which are not available in Bitbucket Cloud.
+type LabelClient struct{}
+
+// Get always returns ErrNoProviderSupport, as Bitbucket Cloud has no labels.
+func (c *LabelClient) Get(_ context.Context, _ string) (gitprovider.Label, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as Bitbucket Cloud has no labels.
+func (c *LabelClient) List(_ context.Context) ([]gitprovider.Label, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as Bitbucket Cloud has no labels.
+func (c *LabelClient) Create(_ context.Context, _ gitprovider.LabelInfo) (gitprovider.Label, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as Bitbucket Cloud has no labels.
+func (c *LabelClient) Reconcile(_ context.Context, _ gitprovider.LabelInfo, _ ...gitprovider.LabelReconcileOption) (gitprovider.Label, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
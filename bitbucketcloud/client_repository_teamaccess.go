@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient operates on the permissions of workspace groups for a specific repository.
+// Teams are referred to by the slug of the group.
+type TeamAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get the permission of the group with the given slug on this repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamAccessClient) Get(ctx context.Context, name string) (gitprovider.TeamAccess, error) {
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	apiObj, err := c.c.GetGroupPermission(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return nil, err
+	}
+	return newTeamAccess(c, teamAccessFromAPI(apiObj)), nil
+}
+
+// List lists the group permissions of this repository.
+//
+// List returns all available team access lists, using multiple paginated requests if needed.
+func (c *TeamAccessClient) List(ctx context.Context) ([]gitprovider.TeamAccess, error) {
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups
+	apiObjs, err := c.c.ListGroupPermissions(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	teamAccess := make([]gitprovider.TeamAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListGroupPermissions
+		teamAccess = append(teamAccess, newTeamAccess(c, teamAccessFromAPI(apiObj)))
+	}
+
+	return teamAccess, nil
+}
+
+// Create grants the given group access to the repository.
+// ErrNoProviderSupport is returned for the triage and maintain permissions, as Bitbucket Cloud
+// only has read, write and admin permissions.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamAccessClient) Create(ctx context.Context, req gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	permission, err := permissionToAPI(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	// PUT /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	if err := c.c.SetGroupPermission(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Name, permission); err != nil {
+		return nil, err
+	}
+
+	return newTeamAccess(c, req), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.TeamAccessInfo,
+) (gitprovider.TeamAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+func TestTeamAccessClient_Reconcile(t *testing.T) {
+	permissions := map[string]string{"devs": "read"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/repositories/flux/repo/permissions-config/groups/", func(w http.ResponseWriter, r *http.Request) {
+		group := r.URL.Path[len("/2.0/repositories/flux/repo/permissions-config/groups/"):]
+		switch r.Method {
+		case http.MethodGet:
+			permission, ok := permissions[group]
+			if !ok {
+				testutil.WriteJSON(w, http.StatusNotFound, map[string]interface{}{"type": "error"})
+				return
+			}
+			testutil.WriteJSON(w, http.StatusOK, GroupPermission{Permission: permission, Group: &Group{Slug: group}})
+		case http.MethodPut:
+			req := &GroupPermission{}
+			testutil.ReadJSON(t, r, req)
+			permissions[group] = req.Permission
+			testutil.WriteJSON(w, http.StatusOK, GroupPermission{Permission: req.Permission, Group: &Group{Slug: group}})
+		}
+	})
+	c := newTestClient(t, mux)
+	tas := &TeamAccessClient{
+		clientContext: c.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		req             gitprovider.TeamAccessInfo
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name: "unchanged",
+			req:  gitprovider.TeamAccessInfo{Name: "devs"},
+		},
+		{
+			name:            "updated",
+			req:             gitprovider.TeamAccessInfo{Name: "devs", Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionAdmin)},
+			wantActionTaken: true,
+		},
+		{
+			name:            "created",
+			req:             gitprovider.TeamAccessInfo{Name: "ops", Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush)},
+			wantActionTaken: true,
+		},
+		{
+			name:            "unsupported permission",
+			req:             gitprovider.TeamAccessInfo{Name: "qa", Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionTriage)},
+			wantActionTaken: true,
+			wantErr:         gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, actionTaken, err := tas.Reconcile(ctx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+		})
+	}
+	want := map[string]string{"devs": "admin", "ops": "write"}
+	if !reflect.DeepEqual(permissions, want) {
+		t.Errorf("permissions = %v, want %v", permissions, want)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// WebhookClient implements the gitprovider.WebhookClient interface.
+var _ gitprovider.WebhookClient = &WebhookClient{}
+
+// WebhookClient handles the webhooks of a specific repository.
+type WebhookClient struct{}
+
+// Get always returns ErrNoProviderSupport, as webhooks aren't implemented yet.
+func (c *WebhookClient) Get(_ context.Context, _ string) (gitprovider.Webhook, error) {
+	return nil, errNotImplemented("reading webhooks")
+}
+
+// List always returns ErrNoProviderSupport, as webhooks aren't implemented yet.
+func (c *WebhookClient) List(_ context.Context) ([]gitprovider.Webhook, error) {
+	return nil, errNotImplemented("listing webhooks")
+}
+
+// Create always returns ErrNoProviderSupport, as webhooks aren't implemented yet.
+func (c *WebhookClient) Create(_ context.Context, _ gitprovider.WebhookInfo) (gitprovider.Webhook, error) {
+	return nil, errNotImplemented("creating webhooks")
+}
+
+// Reconcile always returns ErrNoProviderSupport, as webhooks aren't implemented yet.
+func (c *WebhookClient) Reconcile(_ context.Context, _ gitprovider.WebhookInfo, _ ...gitprovider.WebhookReconcileOption) (gitprovider.Webhook, bool, error) {
+	return nil, false, errNotImplemented("reconciling webhooks")
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.RepositoryRef) *userRepository {
+	return &userRepository{
+		clientContext: ctx,
+		r:             *apiObj,
+		ref:           ref,
+		deployKeys: &DeployKeyClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		deployTokens:       &DeployTokenClient{},
+		labels:             &LabelClient{},
+		milestones:         &MilestoneClient{},
+		releases:           &ReleaseClient{},
+		webhooks:           &WebhookClient{},
+		branchProtections:  &BranchProtectionClient{},
+		tagProtections:     &TagProtectionClient{},
+		commits:            &CommitClient{},
+		branches:           &BranchClient{},
+		pullRequests:       &PullRequestClient{},
+		files:              &FileClient{},
+		trees:              &TreeClient{},
+		actionsPermissions: &ActionsPermissionsClient{},
+	}
+}
+
+var _ gitprovider.UserRepository = &userRepository{}
+
+type userRepository struct {
+	*clientContext
+
+	r   Repository
+	ref gitprovider.RepositoryRef
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
+}
+
+func (r *userRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return repositoryInfoToAPIObj(&info, &r.r)
+}
+
+func (r *userRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *userRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// InitialCommitSHA returns the SHA of the initial commit, if the repository was created with AutoInit.
+func (r *userRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
+}
+
+func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *userRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *userRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *userRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
+func (r *userRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *userRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *userRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *userRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *userRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *userRepository) Update(ctx context.Context) error {
+	// PUT /repositories/{workspace}/{repo_slug}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), newRepositorySpec(&r.r))
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
+	// GET /repositories/{workspace}/{repo_slug}
+	apiObj, err := r.c.GetRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /repositories/{workspace}/{repo_slug}
+			repo, err := r.c.CreateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), newRepositorySpec(&r.r))
+			if err != nil {
+				return true, err
+			}
+			r.r = *repo
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if reflect.DeepEqual(newRepositorySpec(&r.r), newRepositorySpec(apiObj)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, r.Update(ctx)
+}
+
+// SetDefaultBranch makes the given branch the main branch of the repository.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *userRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	// PUT /repositories/{workspace}/{repo_slug}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &Repository{MainBranch: &Branch{Name: branch}})
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Delete deletes the current resource irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *userRepository) Delete(ctx context.Context) error {
+	// DELETE /repositories/{workspace}/{repo_slug}
+	return r.c.DeleteRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+}
+
+// Star always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't support starring repositories.
+func (r *userRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't support starring repositories.
+func (r *userRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't support starring repositories.
+func (r *userRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't expose repository notification settings.
+func (r *userRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as Bitbucket Cloud doesn't expose repository notification settings.
+func (r *userRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as counting issues isn't implemented yet.
+func (r *userRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, errNotImplemented("counting issues")
+}
+
+func newOrgRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.RepositoryRef) *orgRepository {
+	return &orgRepository{
+		userRepository: *newUserRepository(ctx, apiObj, ref),
+		teamAccess: &TeamAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	userRepository
+
+	teamAccess *TeamAccessClient
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRepositoryAPI(apiObj *Repository) error {
+	return validateAPIObject("Bitbucket.Repository", func(validator validation.Validator) {
+		// Make sure slug is set, as it's used to reference the repository
+		if apiObj.Slug == "" {
+			validator.Required("Slug")
+		}
+	})
+}
+
+// validateBranchAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateBranchAPI(apiObj *Branch) error {
+	return validateAPIObject("Bitbucket.Branch", func(validator validation.Validator) {
+		if apiObj.Target == nil || apiObj.Target.Hash == "" {
+			validator.Required("Target.Hash")
+		}
+	})
+}
+
+func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{
+		Description: apiObj.Description,
+		Visibility:  gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic),
+	}
+	if apiObj.IsPrivate != nil && *apiObj.IsPrivate {
+		repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate)
+	}
+	if apiObj.MainBranch != nil {
+		repo.DefaultBranch = gitprovider.StringVar(apiObj.MainBranch.Name)
+	}
+	return repo
+}
+
+func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) (*Repository, error) {
+	apiObj := &Repository{
+		Name: ref.GetRepository(),
+		SCM:  "git",
+	}
+	if err := repositoryInfoToAPIObj(repo, apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings Bitbucket Cloud doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) error {
+	if repo.Description != nil {
+		apiObj.Description = repo.Description
+	}
+	if repo.DefaultBranch != nil {
+		apiObj.MainBranch = &Branch{Name: *repo.DefaultBranch}
+	}
+	if repo.Visibility != nil {
+		switch *repo.Visibility {
+		case gitprovider.RepositoryVisibilityPrivate:
+			apiObj.IsPrivate = gitprovider.BoolVar(true)
+		case gitprovider.RepositoryVisibilityPublic:
+			apiObj.IsPrivate = gitprovider.BoolVar(false)
+		default:
+			return fmt.Errorf("bitbucket cloud doesn't support the %q visibility: %w", *repo.Visibility, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	// The merge settings are chosen per pull request
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("bitbucket cloud doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("bitbucket cloud doesn't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// newRepositorySpec copies over the fields of the repository that can be set, i.e. the desired
+// spec of the repository. This allows us to separate "spec" from "status" fields.
+func newRepositorySpec(apiObj *Repository) *Repository {
+	spec := &Repository{
+		Description: apiObj.Description,
+		IsPrivate:   apiObj.IsPrivate,
+	}
+	if apiObj.MainBranch != nil {
+		spec.MainBranch = &Branch{Name: apiObj.MainBranch.Name}
+	}
+	return spec
+}
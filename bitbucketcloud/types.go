@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import "time"
+
+// The types in this file are the objects of the Bitbucket Cloud REST API 2.0, as documented in
+// https://developer.atlassian.com/cloud/bitbucket/rest/intro/. Only the fields used by this
+// package are part of them.
+
+// Link is a hypermedia link to a related resource.
+type Link struct {
+	Href string `json:"href,omitempty"`
+}
+
+// Links holds the links of a resource.
+type Links struct {
+	HTML *Link `json:"html,omitempty"`
+}
+
+// Workspace is a Bitbucket Cloud workspace, which owns repositories.
+type Workspace struct {
+	UUID      string `json:"uuid,omitempty"`
+	Slug      string `json:"slug,omitempty"`
+	Name      string `json:"name,omitempty"`
+	IsPrivate bool   `json:"is_private,omitempty"`
+	Links     *Links `json:"links,omitempty"`
+}
+
+// WorkspacePermission is the permission of the authenticated user in a workspace.
+type WorkspacePermission struct {
+	Permission string     `json:"permission,omitempty"`
+	Workspace  *Workspace `json:"workspace,omitempty"`
+}
+
+// Project is a project in a workspace, grouping repositories.
+type Project struct {
+	Key string `json:"key,omitempty"`
+}
+
+// BranchTarget is the commit a branch points to.
+type BranchTarget struct {
+	Hash string `json:"hash,omitempty"`
+}
+
+// Branch is a branch of a repository.
+type Branch struct {
+	Name   string        `json:"name,omitempty"`
+	Target *BranchTarget `json:"target,omitempty"`
+}
+
+// Repository is a Bitbucket Cloud repository. The fields that can be changed are pointers, in
+// order to only send the set fields when updating the repository.
+type Repository struct {
+	UUID        string   `json:"uuid,omitempty"`
+	Slug        string   `json:"slug,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	FullName    string   `json:"full_name,omitempty"`
+	SCM         string   `json:"scm,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	IsPrivate   *bool    `json:"is_private,omitempty"`
+	MainBranch  *Branch  `json:"mainbranch,omitempty"`
+	Project     *Project `json:"project,omitempty"`
+	Links       *Links   `json:"links,omitempty"`
+}
+
+// DeployKey is an SSH key with read-only access to a repository. Bitbucket Cloud stores the
+// comment of the key separately from the key itself.
+type DeployKey struct {
+	ID        int        `json:"id,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	Label     string     `json:"label,omitempty"`
+	Comment   string     `json:"comment,omitempty"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// Group is a group of users in a workspace.
+type Group struct {
+	Slug string `json:"slug,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// GroupPermission is the permission of a group on a repository, i.e. "read", "write" or "admin".
+type GroupPermission struct {
+	Permission string `json:"permission,omitempty"`
+	Group      *Group `json:"group,omitempty"`
+}
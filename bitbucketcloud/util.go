@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	errorsDocURL    = "https://developer.atlassian.com/cloud/bitbucket/rest/intro/#standardized-error-responses"
+	rateLimitDocURL = "https://support.atlassian.com/bitbucket-cloud/docs/api-request-limits/"
+)
+
+// alreadyExistsMagicStrings are part of the messages Bitbucket Cloud returns when creating a
+// repository or deploy key that already exists.
+//
+//nolint:gochecknoglobals
+var alreadyExistsMagicStrings = []string{"already exists", "already added"}
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for Bitbucket Cloud's usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Bitbucket Cloud's usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Bitbucket Cloud's usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for Bitbucket Cloud's usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser:
+		return nil
+	case gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("bitbucket cloud doesn't support sub-workspaces: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// errorResponse is the body of Bitbucket Cloud's error responses.
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// handleHTTPError reads the error response resp, and returns typed variants of it.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(resp *http.Response) error {
+	// The body is only used for the message, hence reading a prefix is enough
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	apiErr := &errorResponse{}
+	message := http.StatusText(resp.StatusCode)
+	if err := json.Unmarshal(body, apiErr); err == nil && apiErr.Error.Message != "" {
+		message = apiErr.Error.Message
+	}
+	httpErr := gitprovider.HTTPError{
+		Response:         resp,
+		ErrorMessage:     fmt.Sprintf("%s %s: %d %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode, message),
+		Message:          message,
+		DocumentationURL: errorsDocURL,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		// Check for invalid credentials, and return a typed error in that case
+		return &gitprovider.InvalidCredentialsError{HTTPError: httpErr}
+	case http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case http.StatusNotFound:
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		// Bitbucket Cloud doesn't tell the limits, only that they have been exceeded
+		httpErr.DocumentationURL = rateLimitDocURL
+		return &gitprovider.RateLimitError{HTTPError: httpErr}
+	case http.StatusBadRequest:
+		// Check for already exists errors
+		for _, s := range alreadyExistsMagicStrings {
+			if strings.Contains(message, s) {
+				return validation.NewMultiError(&httpErr, gitprovider.ErrAlreadyExists)
+			}
+		}
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// page is a page of a paginated list. The values are decoded by the caller.
+type page struct {
+	// Size is the total number of values, which Bitbucket Cloud doesn't return for all lists.
+	Size   int             `json:"size"`
+	Next   string          `json:"next"`
+	Values json.RawMessage `json:"values"`
+}
+
+// allPages requests the list at path and all following pages, and calls fn with the values of each
+// page. fn is expected to save the values to an outer variable, and return how many it got.
+// progress is called after each page, if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *bitbucketClientImpl) allPages(ctx context.Context, path string, progress gitprovider.ListProgressFunc, fn func(values json.RawMessage) (int, error)) error {
+	next := path
+	if perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0); perPage > 0 {
+		next += "?" + url.Values{"pagelen": {strconv.Itoa(perPage)}}.Encode()
+	}
+	count := 0
+	for next != "" {
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p := &page{}
+		if err := c.do(ctx, http.MethodGet, next, nil, p); err != nil {
+			return err
+		}
+		n, err := fn(p.Values)
+		if err != nil {
+			return fmt.Errorf("failed to decode the values of %s: %v: %w", path, err, gitprovider.ErrInvalidServerData)
+		}
+		count += n
+		if progress != nil {
+			progress(listProgress(p, count))
+		}
+		next = p.Next
+	}
+	return nil
+}
+
+// listProgress returns the progress after listing count values, the last page being p.
+func listProgress(p *page, count int) gitprovider.ListProgress {
+	if p.Next == "" {
+		return gitprovider.ListProgress{Count: count, EstimatedTotal: count}
+	}
+	return gitprovider.ListProgress{Count: count, EstimatedTotal: p.Size}
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that Bitbucket Cloud has, but which aren't
+// implemented by this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for bitbucket cloud yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucketcloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// newTestClient returns a client for a fake Bitbucket Cloud API served by mux.
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	srv := testutil.NewServer(t, mux)
+	baseURL, _ := url.Parse(srv.URL + "/2.0/")
+	return newClient(srv.Client(), baseURL, DefaultDomain, false)
+}
+
+func Test_handleHTTPError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedErrs []error
+		wantMessage  string
+	}{
+		{
+			name:         "401 => InvalidCredentialsError",
+			status:       http.StatusUnauthorized,
+			expectedErrs: []error{&gitprovider.InvalidCredentialsError{}},
+			wantMessage:  "Unauthorized",
+		},
+		{
+			name:         "403 => InvalidCredentialsError & ErrForbidden",
+			status:       http.StatusForbidden,
+			body:         `{"type": "error", "error": {"message": "Your credentials lack one or more required privilege scopes."}}`,
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+			wantMessage:  "Your credentials lack one or more required privilege scopes.",
+		},
+		{
+			name:         "404 => ErrNotFound",
+			status:       http.StatusNotFound,
+			body:         `{"type": "error", "error": {"message": "Repository flux/missing not found"}}`,
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+			wantMessage:  "Repository flux/missing not found",
+		},
+		{
+			name:         "429 => RateLimitError",
+			status:       http.StatusTooManyRequests,
+			expectedErrs: []error{&gitprovider.RateLimitError{}},
+			wantMessage:  "Too Many Requests",
+		},
+		{
+			name:         "400 for existing repository => ErrAlreadyExists",
+			status:       http.StatusBadRequest,
+			body:         `{"type": "error", "error": {"message": "Repository with this Slug and Owner already exists."}}`,
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+			wantMessage:  "Repository with this Slug and Owner already exists.",
+		},
+		{
+			name:         "400 with non-JSON body => HTTPError",
+			status:       http.StatusBadRequest,
+			body:         "<html>Bad Request</html>",
+			expectedErrs: []error{&gitprovider.HTTPError{}},
+			wantMessage:  "Bad Request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+				Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/2.0/repositories/flux/missing"}},
+			}
+			err := handleHTTPError(resp)
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("handleHTTPError() error = %v, want the message %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func Test_allPages(t *testing.T) {
+	var srvURL string
+	requests := []string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/repositories/flux", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		// Bitbucket Cloud links to the next page, which must be followed as is
+		switch r.URL.Query().Get("page") {
+		case "":
+			testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{
+				"size":   3,
+				"next":   srvURL + "/2.0/repositories/flux?pagelen=2&page=cursor-2",
+				"values": []map[string]string{{"slug": "a"}, {"slug": "b"}},
+			})
+		case "cursor-2":
+			testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{
+				"size":   3,
+				"values": []map[string]string{{"slug": "c"}},
+			})
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	srv := testutil.NewServer(t, mux)
+	srvURL = srv.URL
+	baseURL, _ := url.Parse(srv.URL + "/2.0/")
+	c := newClient(srv.Client(), baseURL, DefaultDomain, false)
+
+	perPage := 2
+	ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{PerPage: &perPage})
+	progress := []gitprovider.ListProgress{}
+	repos, err := c.OrgRepositories().ListWithProgress(ctx,
+		gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"},
+		func(p gitprovider.ListProgress) { progress = append(progress, p) })
+	if err != nil {
+		t.Fatalf("ListWithProgress() error = %v", err)
+	}
+	names := []string{}
+	for _, repo := range repos {
+		names = append(names, repo.Repository().GetRepository())
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListWithProgress() = %v, want %v", names, want)
+	}
+	if want := []string{"pagelen=2", "pagelen=2&page=cursor-2"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requested pages %v, want %v", requests, want)
+	}
+	// The size is the total of the list, which is exact once the last page has been listed
+	wantProgress := []gitprovider.ListProgress{{Count: 2, EstimatedTotal: 3}, {Count: 3, EstimatedTotal: 3}}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("progress = %v, want %v", progress, wantProgress)
+	}
+}
+
+func Test_listProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		page  *page
+		count int
+		want  gitprovider.ListProgress
+	}{
+		{
+			name:  "size reported",
+			page:  &page{Size: 30, Next: "next"},
+			count: 10,
+			want:  gitprovider.ListProgress{Count: 10, EstimatedTotal: 30},
+		},
+		{
+			name:  "size not reported",
+			page:  &page{Next: "next"},
+			count: 10,
+			want:  gitprovider.ListProgress{Count: 10},
+		},
+		{
+			name:  "last page",
+			page:  &page{Size: 30},
+			count: 25,
+			want:  gitprovider.ListProgress{Count: 25, EstimatedTotal: 25},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listProgress(tt.page, tt.count); got != tt.want {
+				t.Errorf("listProgress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
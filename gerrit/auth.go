@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	var username, password string
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	return NewClient(username, password, append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for Gerrit REST API endpoints.
+//
+// Gerrit is always self-hosted, hence the domain must be given using WithDomain, e.g.
+// "gerrit.example.com" or "https://example.com/gerrit" for servers served under a sub-path.
+//
+// The username and password are the HTTP credentials of a Gerrit account, i.e. the generated
+// HTTP password, not the password used to sign in. Passing an empty username will allow
+// anonymous read access only.
+//
+// Gerrit projects are addressed by their full name, e.g. "org/sub/repo", which is mapped to
+// the repository "repo" of the organization "org" with the sub-organization "sub". The
+// organizations are namespaces, which exist as long as there are projects in them. Changes
+// are mapped to pull requests.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(username, password string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Domain == nil {
+		return nil, fmt.Errorf("the domain of the gerrit server must be given: %w", gitprovider.ErrInvalidClientOptions)
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := strings.TrimRight(*opts.Domain, "/")
+	// Authenticated requests are sent to the REST API below "/a/"
+	restPath := "/"
+	if username != "" {
+		restPath = "/a/"
+	}
+	baseURL, err := url.Parse(gitprovider.GetDomainURL(domain) + restPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if baseURL.Host == "" || baseURL.RawQuery != "" || baseURL.Fragment != "" {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(httpClient, baseURL, username, password, domain, destructiveActions), nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProjectBoardsClient implements the gitprovider.ProjectBoardsClient interface.
+var _ gitprovider.ProjectBoardsClient = &ProjectBoardsClient{}
+
+// ProjectBoardsClient handles organization-wide project boards, which are not available in Gerrit.
+type ProjectBoardsClient struct{}
+
+// List always returns ErrNoProviderSupport, as Gerrit has no project boards.
+func (c *ProjectBoardsClient) List(_ context.Context) ([]gitprovider.ProjectBoard, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
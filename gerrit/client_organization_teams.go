@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamsClient implements the gitprovider.TeamsClient interface.
+var _ gitprovider.TeamsClient = &TeamsClient{}
+
+// TeamsClient handles teams, i.e. the groups of Gerrit, which aren't scoped to a namespace.
+type TeamsClient struct{}
+
+// Get always returns ErrNoProviderSupport, as reading groups isn't implemented yet.
+func (c *TeamsClient) Get(_ context.Context, _ string) (gitprovider.Team, error) {
+	return nil, errNotImplemented("reading groups")
+}
+
+// List always returns ErrNoProviderSupport, as listing groups isn't implemented yet.
+func (c *TeamsClient) List(_ context.Context, _ ...gitprovider.TeamListOption) ([]gitprovider.Team, error) {
+	return nil, errNotImplemented("listing groups")
+}
+
+// SetParent always returns ErrNoProviderSupport, as setting the parent of groups isn't implemented yet.
+func (c *TeamsClient) SetParent(_ context.Context, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the namespaces of the Gerrit projects, i.e. the prefixes of
+// their names.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get a specific namespace, which can also refer to a sub-organization, e.g. "org/sub".
+//
+// ErrNotFound is returned if there are no projects in the namespace.
+func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /projects/
+	ok, err := c.c.HasProjects(ctx, ref.GetIdentity()+"/")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no projects in namespace %q: %w", ref.GetIdentity(), gitprovider.ErrNotFound)
+	}
+
+	return newOrganization(&Namespace{Path: ref.GetIdentity()}, ref), nil
+}
+
+// List all top-level namespaces of the projects the user has access to.
+//
+// List returns all available namespaces, using multiple paginated requests if needed.
+func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organization, error) {
+	// GET /projects/
+	apiObjs, err := c.c.ListProjects(ctx, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := childNamespaces("", apiObjs)
+	orgs := make([]gitprovider.Organization, 0, len(names))
+	for _, name := range names {
+		orgs = append(orgs, newOrganization(&Namespace{Path: name}, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: name,
+		}))
+	}
+	return orgs, nil
+}
+
+// Children returns the namespaces directly below the given namespace.
+//
+// Children returns all available namespaces, using multiple paginated requests if needed.
+func (c *OrganizationsClient) Children(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /projects/
+	prefix := ref.GetIdentity() + "/"
+	apiObjs, err := c.c.ListProjects(ctx, prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := childNamespaces(prefix, apiObjs)
+	orgs := make([]gitprovider.Organization, 0, len(names))
+	for _, name := range names {
+		childRef := gitprovider.OrganizationRef{
+			Domain:           c.domain,
+			Organization:     ref.Organization,
+			SubOrganizations: append(append([]string{}, ref.SubOrganizations...), name),
+		}
+		orgs = append(orgs, newOrganization(&Namespace{Path: childRef.GetIdentity()}, childRef))
+	}
+	return orgs, nil
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as the access rights of
+// Gerrit projects are inherited from their parent projects, not their namespaces.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as the access rights of
+// Gerrit projects are inherited from their parent projects, not their namespaces.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as the access
+// rights of Gerrit projects are inherited from their parent projects, not their namespaces.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
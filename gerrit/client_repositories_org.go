@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient operates on the projects in the namespaces the user has access to.
+type OrgRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	// Make sure the OrgRepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	// GET /projects/{project-name}
+	apiObj, head, err := getProject(ctx, c.c, projectName(ref))
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, head, ref), nil
+}
+
+// List all repositories directly in the given namespace.
+//
+// List returns all available repositories, using multiple paginated requests if needed.
+// The default branches of the listed repositories are unknown, as Gerrit doesn't list them;
+// use Get for them.
+func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories directly in the given namespace like List, and calls
+// progress after each page. Gerrit doesn't tell the total, which is hence only estimated once
+// the last page has been listed. The count includes the projects in child namespaces, as
+// Gerrit lists all projects with the prefix of the namespace.
+//
+// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /projects/
+	prefix := ref.GetIdentity() + "/"
+	apiObjs, err := c.c.ListProjects(ctx, prefix, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListProjects
+		name := strings.TrimPrefix(apiObj.Name, prefix)
+		// Skip the projects in child namespaces
+		if strings.Contains(name, "/") {
+			continue
+		}
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, "", gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  name,
+		}))
+	}
+	return repos, nil
+}
+
+// SearchPage always returns ErrNoProviderSupport, as searching repositories isn't implemented yet.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, errNotImplemented("searching repositories")
+}
+
+// Create creates a repository in the given namespace, with the data and options.
+// The project inherits the access rights of the default parent project.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, head, initialCommitSHA, err := createRepository(ctx, c.c, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repo := newOrgRepository(c.clientContext, apiObj, head, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+// Transfer always returns ErrNoProviderSupport, as Gerrit projects can't be renamed.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// createRepository creates the project with the default branch as HEAD, and returns it
+// together with HEAD, and the SHA of the initial commit if the AutoInit option is set. Gerrit
+// creates an empty initial commit in that case.
+func createRepository(ctx context.Context, c gerritClient, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*ProjectInfo, string, *string, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, "", nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if o.LicenseTemplate != nil {
+		return nil, "", nil, fmt.Errorf("gerrit has no license templates: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.VerifyVisibility != nil {
+		return nil, "", nil, fmt.Errorf("option VerifyVisibility isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		return nil, "", nil, fmt.Errorf("option CheckOrgMembership isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	apiObj, head := &ProjectInfo{}, ""
+	if err := repositoryInfoToAPIObj(&req, apiObj, &head); err != nil {
+		return nil, "", nil, err
+	}
+	name := projectName(ref)
+	data := &ProjectInput{
+		Description:       apiObj.Description,
+		Branches:          []string{head},
+		CreateEmptyCommit: o.AutoInit != nil && *o.AutoInit,
+	}
+	// PUT /projects/{project-name}
+	apiObj, err = c.CreateProject(ctx, name, data)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if !data.CreateEmptyCommit {
+		return apiObj, head, nil, nil
+	}
+
+	// GET /projects/{project-name}/branches/{branch-id}
+	branch, err := c.GetBranch(ctx, name, head)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return apiObj, head, &branch.Revision, nil
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
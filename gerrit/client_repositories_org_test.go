@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_List_childNamespaces(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]map[string]string{
+			"flux/a":     {"id": "flux%2Fa"},
+			"flux/sub/b": {"id": "flux%2Fsub%2Fb"},
+		})
+	})
+
+	repos, err := c.OrgRepositories().List(context.Background(), gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	names := []string{}
+	for _, repo := range repos {
+		names = append(names, repo.Repository().GetRepository())
+	}
+	// The project in the child namespace isn't a repository of the organization
+	if want := []string{"a"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("List() = %v, want %v", names, want)
+	}
+}
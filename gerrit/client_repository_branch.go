@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchClient implements the gitprovider.BranchClient interface.
+var _ gitprovider.BranchClient = &BranchClient{}
+
+// BranchClient operates on the branches of a specific repository.
+type BranchClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Create creates a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch already exists.
+func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
+	// PUT /projects/{project-name}/branches/{branch-id}
+	_, err := c.c.CreateBranch(ctx, projectName(c.ref), branch, sha)
+	return err
+}
+
+// List lists all branches of the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.BranchInfo, error) {
+	// GET /projects/{project-name}/branches/
+	apiObjs, err := c.c.ListBranches(ctx, projectName(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.BranchInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// Skip HEAD and refs/meta/config, which Gerrit lists as well
+		if !strings.HasPrefix(apiObj.Ref, branchRefPrefix) {
+			continue
+		}
+		branches = append(branches, gitprovider.BranchInfo{
+			Name: strings.TrimPrefix(apiObj.Ref, branchRefPrefix),
+			Sha:  apiObj.Revision,
+		})
+	}
+	return branches, nil
+}
+
+// Delete deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// DELETE /projects/{project-name}/branches/{branch-id}
+	return c.c.DeleteBranch(ctx, projectName(c.ref), branch)
+}
+
+// Rename renames the given branch to newName. Gerrit can't rename branches, hence the branch
+// is created under newName, HEAD is moved to it if it pointed to the old branch, and the old
+// branch is deleted.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Rename(ctx context.Context, branch, newName string) error {
+	project := projectName(c.ref)
+	// GET /projects/{project-name}/branches/{branch-id}
+	apiObj, err := c.c.GetBranch(ctx, project, branch)
+	if err != nil {
+		return err
+	}
+	// PUT /projects/{project-name}/branches/{branch-id}
+	if _, err := c.c.CreateBranch(ctx, project, newName, apiObj.Revision); err != nil {
+		return err
+	}
+	// GET /projects/{project-name}/HEAD
+	head, err := c.c.GetHead(ctx, project)
+	if err != nil {
+		return err
+	}
+	if head == branchRef(branch) {
+		// PUT /projects/{project-name}/HEAD
+		if err := c.c.SetHead(ctx, project, branchRef(newName)); err != nil {
+			return err
+		}
+	}
+	// DELETE /projects/{project-name}/branches/{branch-id}
+	return c.c.DeleteBranch(ctx, project, branch)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// DeployKeyClient implements the gitprovider.DeployKeyClient interface.
+var _ gitprovider.DeployKeyClient = &DeployKeyClient{}
+
+// DeployKeyClient handles deploy keys, which are not available in Gerrit. SSH keys always
+// belong to an account, e.g. a service user, which is given access using the access rights of
+// the projects.
+type DeployKeyClient struct{}
+
+// Get always returns ErrNoProviderSupport, as Gerrit has no deploy keys.
+func (c *DeployKeyClient) Get(_ context.Context, _ string) (gitprovider.DeployKey, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as Gerrit has no deploy keys.
+func (c *DeployKeyClient) List(_ context.Context, _ ...gitprovider.DeployKeyListOption) ([]gitprovider.DeployKey, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as Gerrit has no deploy keys.
+func (c *DeployKeyClient) Create(_ context.Context, _ gitprovider.DeployKeyInfo) (gitprovider.DeployKey, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as Gerrit has no deploy keys.
+func (c *DeployKeyClient) Reconcile(_ context.Context, _ gitprovider.DeployKeyInfo) (gitprovider.DeployKey, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
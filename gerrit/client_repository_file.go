@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"io"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the files of a specific repository.
+type FileClient struct{}
+
+// Get always returns ErrNoProviderSupport, as reading files isn't implemented yet.
+func (c *FileClient) Get(_ context.Context, _, _ string, _ ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	return nil, errNotImplemented("reading files")
+}
+
+// GetReader always returns ErrNoProviderSupport, as reading files isn't implemented yet.
+func (c *FileClient) GetReader(_ context.Context, _, _ string) (io.ReadCloser, error) {
+	return nil, errNotImplemented("reading files")
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the changes of a specific repository, which are the pull
+// requests of Gerrit. A pull request of a branch is a change of a merge commit, which merges the
+// branch into the base branch. The branch is recorded as the topic of the change.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists the changes of the repository, by default the open ones. The filters are applied
+// by Gerrit, with the source branch matching the topic of the change. Gerrit has no labels on
+// changes, hence filtering by label returns ErrNoProviderSupport.
+//
+// List returns all matching changes, using multiple paginated requests if needed.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.Label != nil {
+		return nil, fmt.Errorf("gerrit can't filter changes by label: %w", gitprovider.ErrNoProviderSupport)
+	}
+	operators := []string{"status:open"}
+	if o.State != nil {
+		operators[0] = changeStatusOperator(*o.State)
+	}
+	if o.BaseBranch != nil {
+		operators = append(operators, fmt.Sprintf("branch:%q", *o.BaseBranch))
+	}
+	if o.SourceBranch != nil {
+		operators = append(operators, fmt.Sprintf("topic:%q", *o.SourceBranch))
+	}
+	if o.Author != nil {
+		operators = append(operators, fmt.Sprintf("owner:%q", *o.Author))
+	}
+	if o.UpdatedSince != nil {
+		operators = append(operators, fmt.Sprintf("after:%q", o.UpdatedSince.Format(searchTimeLayout)))
+	}
+
+	// GET /changes/
+	apiObjs, err := c.c.ListChanges(ctx, projectName(c.ref), operators...)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListChanges
+		requests = append(requests, newPullRequest(apiObj, c.domain))
+	}
+	return requests, nil
+}
+
+// Create creates a change merging branch into baseBranch, with title as the subject and
+// description as the body of the commit message. Draft changes are created as work in progress.
+// Gerrit has no forks, hence setting a head repository returns ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
+	if o.HeadRepository != nil {
+		return nil, fmt.Errorf("gerrit can't create changes from other repositories: %w", gitprovider.ErrNoProviderSupport)
+	}
+	message := title
+	if description != "" {
+		message += "\n\n" + description
+	}
+	req := &ChangeInput{
+		Project: projectName(c.ref),
+		Branch:  baseBranch,
+		Subject: message,
+		Topic:   branch,
+		Merge:   &MergeInput{Source: branch},
+	}
+	if o.Draft != nil {
+		req.WorkInProgress = *o.Draft
+	}
+	// POST /changes/
+	apiObj, err := c.c.CreateChange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.domain), nil
+}
+
+// Edit modifies an existing change. Please refer to "EditOptions" for details on which data can
+// be edited. Changing the title creates a new patch set with the new subject.
+func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovider.EditOptions) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Title: opts.Title})
+}
+
+// Update changes an existing change. Please refer to "PullRequestUpdateOptions" for details on
+// which data can be changed. Changing the title or description creates a new patch set with the
+// new commit message, changing the base branch moves the change to that branch, and draft
+// changes are marked as work in progress.
+func (c *PullRequestClient) Update(ctx context.Context, number int, opts gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	// GET /changes/{change-id}
+	apiObj, err := c.c.GetChange(ctx, projectName(c.ref), number)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+
+	message := commitMessage(apiObj)
+	if opts.Title != nil && *opts.Title != apiObj.Subject {
+		message = replaceSubject(message, *opts.Title)
+	}
+	if opts.Description != nil && *opts.Description != descriptionFromMessage(message) {
+		message = replaceDescription(message, *opts.Description)
+	}
+	if message != commitMessage(apiObj) {
+		// PUT /changes/{change-id}/message
+		if err := c.c.SetCommitMessage(ctx, projectName(c.ref), number, message); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if opts.BaseBranch != nil && *opts.BaseBranch != apiObj.Branch {
+		// POST /changes/{change-id}/move
+		if err := c.c.MoveChange(ctx, projectName(c.ref), number, *opts.BaseBranch); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if opts.Draft != nil && *opts.Draft != apiObj.WorkInProgress {
+		action := "ready"
+		if *opts.Draft {
+			action = "wip"
+		}
+		// POST /changes/{change-id}/wip or /ready
+		if err := c.c.SetChangeState(ctx, projectName(c.ref), number, action); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return newPullRequest(apiObj, c.domain), nil
+	}
+	return c.Get(ctx, number)
+}
+
+// Close abandons an open change.
+func (c *PullRequestClient) Close(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setStatus(ctx, number, changeStatusAbandoned, "abandon")
+}
+
+// Reopen restores an abandoned change.
+func (c *PullRequestClient) Reopen(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setStatus(ctx, number, changeStatusNew, "restore")
+}
+
+// MarkReady marks a work in progress change as ready for review.
+func (c *PullRequestClient) MarkReady(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Draft: gitprovider.BoolVar(false)})
+}
+
+// setStatus runs the given action to move the change into status, unless it has that status
+// already. Merged changes can't change status.
+func (c *PullRequestClient) setStatus(ctx context.Context, number int, status, action string) (gitprovider.PullRequest, error) {
+	// GET /changes/{change-id}
+	apiObj, err := c.c.GetChange(ctx, projectName(c.ref), number)
+	if err != nil {
+		return nil, err
+	}
+	if apiObj.Status == changeStatusMerged {
+		return nil, fmt.Errorf("change %d has been merged already: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if apiObj.Status == status {
+		return newPullRequest(apiObj, c.domain), nil
+	}
+	// POST /changes/{change-id}/abandon or /restore
+	if err := c.c.SetChangeState(ctx, projectName(c.ref), number, action); err != nil {
+		return nil, err
+	}
+	return c.Get(ctx, number)
+}
+
+// Get retrieves an existing change by number.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	// GET /changes/{change-id}
+	apiObj, err := c.c.GetChange(ctx, projectName(c.ref), number)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(apiObj, c.domain), nil
+}
+
+// Merge submits a change, which requires it to be approved according to the submit
+// requirements of the project. Only MergeMethodMerge is supported, as the changes are merge
+// commits already, and how they're submitted is up to the submit type of the project. message
+// and the commit title are ignored, as the commit message is reviewed as part of the change;
+// use Edit to change it before.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, _ string, _ ...gitprovider.PullRequestMergeOption) error {
+	switch mergeMethod {
+	case gitprovider.MergeMethodMerge:
+	case gitprovider.MergeMethodSquash, gitprovider.MergeMethodRebase, gitprovider.MergeMethodFastForward:
+		return fmt.Errorf("gerrit can only submit changes according to the submit type of the project: %w", gitprovider.ErrNoProviderSupport)
+	default:
+		return fmt.Errorf("unsupported merge method %q: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
+
+	// POST /changes/{change-id}/submit
+	_, err := c.c.SubmitChange(ctx, projectName(c.ref), number)
+	return err
+}
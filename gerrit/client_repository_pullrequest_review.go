@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReviewClient implements the gitprovider.ReviewClient interface.
+var _ gitprovider.ReviewClient = &ReviewClient{}
+
+// ReviewClient operates on the reviews of a specific pull request.
+type ReviewClient struct{}
+
+// RequestReviewers always returns ErrNoProviderSupport, as reviews of Gerrit changes aren't implemented yet.
+func (c *ReviewClient) RequestReviewers(_ context.Context, _ ...string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as reviews of Gerrit changes aren't implemented yet.
+func (c *ReviewClient) List(_ context.Context) ([]gitprovider.Review, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Approve always returns ErrNoProviderSupport, as reviews of Gerrit changes aren't implemented yet.
+func (c *ReviewClient) Approve(_ context.Context, _ string) (gitprovider.Review, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Dismiss always returns ErrNoProviderSupport, as reviews of Gerrit changes aren't implemented yet.
+func (c *ReviewClient) Dismiss(_ context.Context, _ int64, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
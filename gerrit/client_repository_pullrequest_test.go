@@ -0,0 +1,303 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const testChangeID = "I8473b95934b5732ac55d26311a706c9c2bde9940"
+
+// newTestPullRequestClient returns a client for the changes of the "flux/repo" project.
+func newTestPullRequestClient(t *testing.T, handler http.HandlerFunc) gitprovider.PullRequestClient {
+	t.Helper()
+	c := newTestClient(t, handler)
+	return &PullRequestClient{
+		clientContext: c.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+// testChange returns an open change of the "flux/repo" project with the given commit message,
+// including the Change-Id footer.
+func testChange(number int, message string) *ChangeInfo {
+	subject, _, _ := strings.Cut(message, "\n")
+	return &ChangeInfo{
+		Project:         "flux/repo",
+		Branch:          "main",
+		Topic:           "feature",
+		ChangeID:        testChangeID,
+		Subject:         subject,
+		Status:          "NEW",
+		Number:          number,
+		CurrentRevision: "be67f8871a4d2c75f13a51c1d3c30ac0d74d4ef4",
+		Revisions: map[string]*RevisionInfo{
+			"be67f8871a4d2c75f13a51c1d3c30ac0d74d4ef4": {
+				Number: 1,
+				Commit: &CommitInfo{Subject: subject, Message: message + "\n\nChange-Id: " + testChangeID + "\n"},
+			},
+		},
+	}
+}
+
+func TestPullRequestClient_Create(t *testing.T) {
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requestKey(r) != "POST /changes/" {
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		req := &ChangeInput{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Error(err)
+		}
+		want := &ChangeInput{
+			Project: "flux/repo",
+			Branch:  "main",
+			Subject: "title\n\ndescription",
+			Topic:   "feature",
+			Merge:   &MergeInput{Source: "feature"},
+		}
+		if !reflect.DeepEqual(req, want) {
+			t.Errorf("Create() sent %+v, want %+v", req, want)
+		}
+		writeJSON(w, http.StatusCreated, testChange(42, req.Subject))
+	})
+
+	pr, err := c.Create(context.Background(), "title", "feature", "main", "description")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := gitprovider.PullRequestInfo{
+		Title:        "title",
+		Description:  "description",
+		Number:       42,
+		WebURL:       "https://gerrit.example.com/c/flux/repo/+/42",
+		SourceBranch: "feature",
+		BaseBranch:   "main",
+		State:        gitprovider.PullRequestStateOpen,
+	}
+	if got := pr.Get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPullRequestClient_Edit(t *testing.T) {
+	message := "title\n\ndescription"
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch requestKey(r) {
+		case "GET /changes/flux%2Frepo~42":
+			writeJSON(w, http.StatusOK, testChange(42, message))
+		case "PUT /changes/flux%2Frepo~42/message":
+			req := &CommitMessageInput{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			// The body and the Change-Id footer must be kept
+			if want := "new title\n\ndescription\n\nChange-Id: " + testChangeID + "\n"; req.Message != want {
+				t.Errorf("Edit() sent message %q, want %q", req.Message, want)
+			}
+			message = "new title\n\ndescription"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	})
+
+	pr, err := c.Edit(context.Background(), 42, gitprovider.EditOptions{Title: gitprovider.StringVar("new title")})
+	if err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+	if got := pr.Get(); got.Title != "new title" || got.Description != "description" {
+		t.Errorf("Edit() = %+v, want the new title and the description", got)
+	}
+}
+
+func TestPullRequestClient_Update(t *testing.T) {
+	change := testChange(42, "title\n\ndescription")
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch requestKey(r) {
+		case "GET /changes/flux%2Frepo~42":
+			writeJSON(w, http.StatusOK, change)
+		case "PUT /changes/flux%2Frepo~42/message":
+			req := &CommitMessageInput{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			// The subject and the Change-Id footer must be kept
+			if want := "title\n\nnew description\n\nChange-Id: " + testChangeID + "\n"; req.Message != want {
+				t.Errorf("Update() sent message %q, want %q", req.Message, want)
+			}
+			change.Revisions[change.CurrentRevision].Commit.Message = req.Message
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /changes/flux%2Frepo~42/move":
+			req := &MoveInput{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			change.Branch = req.DestinationBranch
+			w.WriteHeader(http.StatusOK)
+		case "POST /changes/flux%2Frepo~42/wip":
+			change.WorkInProgress = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	})
+
+	pr, err := c.Update(context.Background(), 42, gitprovider.PullRequestUpdateOptions{
+		Description: gitprovider.StringVar("new description"),
+		BaseBranch:  gitprovider.StringVar("release"),
+		Draft:       gitprovider.BoolVar(true),
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got := pr.Get()
+	if got.Title != "title" || got.Description != "new description" || got.BaseBranch != "release" || !got.Draft {
+		t.Errorf("Update() = %+v, want the new description, base branch and draft state", got)
+	}
+}
+
+func TestPullRequestClient_CloseReopen(t *testing.T) {
+	change := testChange(42, "title")
+	var actions []string
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch requestKey(r) {
+		case "GET /changes/flux%2Frepo~42":
+			writeJSON(w, http.StatusOK, change)
+		case "POST /changes/flux%2Frepo~42/abandon":
+			actions = append(actions, "abandon")
+			change.Status = changeStatusAbandoned
+			writeJSON(w, http.StatusOK, change)
+		case "POST /changes/flux%2Frepo~42/restore":
+			actions = append(actions, "restore")
+			change.Status = changeStatusNew
+			writeJSON(w, http.StatusOK, change)
+		default:
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	})
+	ctx := context.Background()
+
+	pr, err := c.Close(ctx, 42)
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := pr.Get().State; got != gitprovider.PullRequestStateClosed {
+		t.Errorf("Close() state = %q, want %q", got, gitprovider.PullRequestStateClosed)
+	}
+	// Closing an abandoned change is a no-op
+	if _, err := c.Close(ctx, 42); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	pr, err = c.Reopen(ctx, 42)
+	if err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	if got := pr.Get().State; got != gitprovider.PullRequestStateOpen {
+		t.Errorf("Reopen() state = %q, want %q", got, gitprovider.PullRequestStateOpen)
+	}
+	if want := []string{"abandon", "restore"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+
+	change.Status = changeStatusMerged
+	if _, err := c.Close(ctx, 42); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Close() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPullRequestClient_Merge(t *testing.T) {
+	submitted := false
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requestKey(r) != "POST /changes/flux%2Frepo~42/submit" {
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		submitted = true
+		change := testChange(42, "title")
+		change.Status = changeStatusMerged
+		writeJSON(w, http.StatusOK, change)
+	})
+
+	if err := c.Merge(context.Background(), 42, gitprovider.MergeMethodMerge, ""); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if !submitted {
+		t.Error("Merge() didn't submit the change")
+	}
+
+	if err := c.Merge(context.Background(), 42, gitprovider.MergeMethodSquash, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Merge() error = %v, want ErrNoProviderSupport", err)
+	}
+	if err := c.Merge(context.Background(), 42, gitprovider.MergeMethod("octopus"), ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPullRequestClient_List(t *testing.T) {
+	c := newTestPullRequestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requestKey(r) != "GET /changes/" {
+			t.Errorf("unexpected %s", requestKey(r))
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		want := `project:"flux/repo" status:merged branch:"main" topic:"feature" owner:"alice" after:"2023-01-01 00:00:00 +0000"`
+		if got := r.URL.Query().Get("q"); got != want {
+			t.Errorf("List() queried %q, want %q", got, want)
+		}
+		change := testChange(42, "title")
+		change.Status = "MERGED"
+		writeJSON(w, http.StatusOK, []*ChangeInfo{change})
+	})
+	ctx := context.Background()
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	prs, err := c.List(ctx, &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateMerged),
+		BaseBranch:   gitprovider.StringVar("main"),
+		SourceBranch: gitprovider.StringVar("feature"),
+		Author:       gitprovider.StringVar("alice"),
+		UpdatedSince: &since,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().State != gitprovider.PullRequestStateMerged {
+		t.Errorf("List() = %v, want the merged change", prs)
+	}
+	if _, err := c.List(ctx, &gitprovider.PullRequestListOptions{Label: gitprovider.StringVar("release")}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("List() by label error = %v, want ErrNoProviderSupport", err)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagProtectionClient implements the gitprovider.TagProtectionClient interface.
+var _ gitprovider.TagProtectionClient = &TagProtectionClient{}
+
+// TagProtectionClient handles the tag protection rules of a specific repository.
+type TagProtectionClient struct{}
+
+// Get always returns ErrNoProviderSupport, as tag protection isn't implemented yet.
+func (c *TagProtectionClient) Get(_ context.Context, _ string) (gitprovider.TagProtection, error) {
+	return nil, errNotImplemented("reading tag protection rules")
+}
+
+// List always returns ErrNoProviderSupport, as tag protection isn't implemented yet.
+func (c *TagProtectionClient) List(_ context.Context) ([]gitprovider.TagProtection, error) {
+	return nil, errNotImplemented("listing tag protection rules")
+}
+
+// Create always returns ErrNoProviderSupport, as tag protection isn't implemented yet.
+func (c *TagProtectionClient) Create(_ context.Context, _ gitprovider.TagProtectionInfo) (gitprovider.TagProtection, error) {
+	return nil, errNotImplemented("creating tag protection rules")
+}
+
+// Reconcile always returns ErrNoProviderSupport, as tag protection isn't implemented yet.
+func (c *TagProtectionClient) Reconcile(_ context.Context, _ gitprovider.TagProtectionInfo) (gitprovider.TagProtection, bool, error) {
+	return nil, false, errNotImplemented("reconciling tag protection rules")
+}
@@ -0,0 +1,500 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// xssiPrefix is the prefix of all JSON responses of Gerrit, guarding against cross-site script inclusion.
+const xssiPrefix = ")]}'"
+
+// changeOptions are the options to request changes with, in order to get the commit message of
+// the current revision, which holds the description.
+//
+//nolint:gochecknoglobals
+var changeOptions = url.Values{"o": {"CURRENT_REVISION", "CURRENT_COMMIT"}}
+
+// gerritClient is a wrapper around the Gerrit REST API, which implements higher-level methods,
+// operating on the structs in types.go. Projects are given by their full name, e.g. "org/repo",
+// and changes by the project and their number. Pagination is implemented for all List* methods,
+// all returned objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+// This interface is also fakeable, in order to unit-test the client.
+type gerritClient interface {
+	// Client returns the underlying *http.Client
+	Client() *http.Client
+
+	// GetProject is a wrapper for "GET /projects/{project-name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProject(ctx context.Context, project string) (*ProjectInfo, error)
+	// ListProjects is a wrapper for "GET /projects/", listing the code projects whose name starts
+	// with prefix, sorted by name. progress is called after each page, if non-nil.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjects(ctx context.Context, prefix string, progress gitprovider.ListProgressFunc) ([]*ProjectInfo, error)
+	// HasProjects is a wrapper for "GET /projects/", returning whether there is any code project
+	// whose name starts with prefix.
+	// This function handles HTTP error wrapping.
+	HasProjects(ctx context.Context, prefix string) (bool, error)
+	// CreateProject is a wrapper for "PUT /projects/{project-name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateProject(ctx context.Context, project string, req *ProjectInput) (*ProjectInfo, error)
+	// SetProjectDescription is a wrapper for "PUT /projects/{project-name}/description".
+	// An empty description deletes the description.
+	// This function handles HTTP error wrapping.
+	SetProjectDescription(ctx context.Context, project, description string) error
+	// GetHead is a wrapper for "GET /projects/{project-name}/HEAD", returning the ref HEAD
+	// points to, e.g. "refs/heads/main".
+	// This function handles HTTP error wrapping.
+	GetHead(ctx context.Context, project string) (string, error)
+	// SetHead is a wrapper for "PUT /projects/{project-name}/HEAD".
+	// This function handles HTTP error wrapping.
+	SetHead(ctx context.Context, project, ref string) error
+	// DeleteProject is a wrapper for "POST /projects/{project-name}/delete-project~delete",
+	// which requires the delete-project plugin to be installed on the server.
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteProject(ctx context.Context, project string) error
+
+	// GetBranch is a wrapper for "GET /projects/{project-name}/branches/{branch-id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, project, branch string) (*BranchInfo, error)
+	// CreateBranch is a wrapper for "PUT /projects/{project-name}/branches/{branch-id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateBranch(ctx context.Context, project, branch, revision string) (*BranchInfo, error)
+	// ListBranches is a wrapper for "GET /projects/{project-name}/branches/", which also lists
+	// HEAD and refs/meta/config besides the branches.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, project string) ([]*BranchInfo, error)
+	// DeleteBranch is a wrapper for "DELETE /projects/{project-name}/branches/{branch-id}".
+	// This function handles HTTP error wrapping.
+	DeleteBranch(ctx context.Context, project, branch string) error
+
+	// ListChanges is a wrapper for "GET /changes/", returning the changes of the project which
+	// match all the given search operators, e.g. "status:open".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListChanges(ctx context.Context, project string, operators ...string) ([]*ChangeInfo, error)
+	// GetChange is a wrapper for "GET /changes/{change-id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetChange(ctx context.Context, project string, number int) (*ChangeInfo, error)
+	// CreateChange is a wrapper for "POST /changes/".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateChange(ctx context.Context, req *ChangeInput) (*ChangeInfo, error)
+	// SetCommitMessage is a wrapper for "PUT /changes/{change-id}/message", which creates a new
+	// patch set with the given commit message.
+	// This function handles HTTP error wrapping.
+	SetCommitMessage(ctx context.Context, project string, number int, message string) error
+	// SubmitChange is a wrapper for "POST /changes/{change-id}/submit".
+	// This function handles HTTP error wrapping, and validates the server result.
+	SubmitChange(ctx context.Context, project string, number int) (*ChangeInfo, error)
+	// SetChangeState is a wrapper for "POST /changes/{change-id}/{action}", where action is one
+	// of "abandon", "restore", "wip" or "ready".
+	// This function handles HTTP error wrapping.
+	SetChangeState(ctx context.Context, project string, number int, action string) error
+	// MoveChange is a wrapper for "POST /changes/{change-id}/move".
+	// This function handles HTTP error wrapping.
+	MoveChange(ctx context.Context, project string, number int, branch string) error
+}
+
+// gerritClientImpl is a wrapper around *http.Client, which implements higher-level methods,
+// operating on the structs in types.go.
+type gerritClientImpl struct {
+	c                  *http.Client
+	baseURL            *url.URL
+	username           string
+	password           string
+	destructiveActions bool
+}
+
+// gerritClientImpl implements gerritClient.
+var _ gerritClient = &gerritClientImpl{}
+
+func (c *gerritClientImpl) Client() *http.Client {
+	return c.c
+}
+
+func (c *gerritClientImpl) GetProject(ctx context.Context, project string) (*ProjectInfo, error) {
+	apiObj := &ProjectInfo{}
+	// GET /projects/{project-name}
+	if err := c.do(ctx, http.MethodGet, apiPath("projects", project), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) ListProjects(ctx context.Context, prefix string, progress gitprovider.ListProgressFunc) ([]*ProjectInfo, error) {
+	perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	apiObjs := []*ProjectInfo{}
+	for skip := 0; ; skip += perPage {
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// GET /projects/
+		pageObjs, err := c.listProjects(ctx, prefix, perPage, skip)
+		if err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		lastPage := len(pageObjs) < perPage
+		if progress != nil {
+			progress(listProgress(len(apiObjs), lastPage))
+		}
+		if lastPage {
+			break
+		}
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProjectAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gerritClientImpl) HasProjects(ctx context.Context, prefix string) (bool, error) {
+	// GET /projects/
+	apiObjs, err := c.listProjects(ctx, prefix, 1, 0)
+	if err != nil {
+		return false, err
+	}
+	return len(apiObjs) > 0, nil
+}
+
+// listProjects lists a page of the code projects whose name starts with prefix, sorted by name.
+func (c *gerritClientImpl) listProjects(ctx context.Context, prefix string, limit, skip int) ([]*ProjectInfo, error) {
+	query := url.Values{
+		"p":    {prefix},
+		"type": {"CODE"},
+		"n":    {strconv.Itoa(limit)},
+		"S":    {strconv.Itoa(skip)},
+	}
+	// Gerrit returns the projects as map keyed by their names
+	projects := map[string]*ProjectInfo{}
+	// GET /projects/
+	if err := c.do(ctx, http.MethodGet, "projects/?d&"+query.Encode(), nil, &projects); err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*ProjectInfo, 0, len(projects))
+	for name, apiObj := range projects {
+		if apiObj == nil {
+			apiObj = &ProjectInfo{}
+		}
+		apiObj.Name = name
+		apiObjs = append(apiObjs, apiObj)
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+func (c *gerritClientImpl) CreateProject(ctx context.Context, project string, req *ProjectInput) (*ProjectInfo, error) {
+	apiObj := &ProjectInfo{}
+	// PUT /projects/{project-name}
+	if err := c.do(ctx, http.MethodPut, apiPath("projects", project), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) SetProjectDescription(ctx context.Context, project, description string) error {
+	req := &ProjectDescriptionInput{
+		Description:   description,
+		CommitMessage: "Update description",
+	}
+	// PUT /projects/{project-name}/description
+	return c.do(ctx, http.MethodPut, apiPath("projects", project, "description"), req, nil)
+}
+
+func (c *gerritClientImpl) GetHead(ctx context.Context, project string) (string, error) {
+	var ref string
+	// GET /projects/{project-name}/HEAD
+	if err := c.do(ctx, http.MethodGet, apiPath("projects", project, "HEAD"), nil, &ref); err != nil {
+		return "", err
+	}
+	return ref, nil
+}
+
+func (c *gerritClientImpl) SetHead(ctx context.Context, project, ref string) error {
+	// PUT /projects/{project-name}/HEAD
+	return c.do(ctx, http.MethodPut, apiPath("projects", project, "HEAD"), &HeadInput{Ref: ref}, nil)
+}
+
+func (c *gerritClientImpl) DeleteProject(ctx context.Context, project string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// POST /projects/{project-name}/delete-project~delete
+	return c.do(ctx, http.MethodPost, apiPath("projects", project, "delete-project~delete"), struct{}{}, nil)
+}
+
+func (c *gerritClientImpl) GetBranch(ctx context.Context, project, branch string) (*BranchInfo, error) {
+	apiObj := &BranchInfo{}
+	// GET /projects/{project-name}/branches/{branch-id}
+	if err := c.do(ctx, http.MethodGet, apiPath("projects", project, "branches", branch), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) CreateBranch(ctx context.Context, project, branch, revision string) (*BranchInfo, error) {
+	apiObj := &BranchInfo{}
+	// PUT /projects/{project-name}/branches/{branch-id}
+	if err := c.do(ctx, http.MethodPut, apiPath("projects", project, "branches", branch), &BranchInput{Revision: revision}, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) ListBranches(ctx context.Context, project string) ([]*BranchInfo, error) {
+	perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	apiObjs := []*BranchInfo{}
+	for skip := 0; ; skip += perPage {
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		query := url.Values{
+			"n": {strconv.Itoa(perPage)},
+			"S": {strconv.Itoa(skip)},
+		}
+		pageObjs := []*BranchInfo{}
+		// GET /projects/{project-name}/branches/
+		if err := c.do(ctx, http.MethodGet, apiPath("projects", project, "branches")+"/?"+query.Encode(), nil, &pageObjs); err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		if len(pageObjs) < perPage {
+			break
+		}
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gerritClientImpl) DeleteBranch(ctx context.Context, project, branch string) error {
+	// DELETE /projects/{project-name}/branches/{branch-id}
+	return c.do(ctx, http.MethodDelete, apiPath("projects", project, "branches", branch), nil, nil)
+}
+
+func (c *gerritClientImpl) ListChanges(ctx context.Context, project string, operators ...string) ([]*ChangeInfo, error) {
+	perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	q := strings.Join(append([]string{fmt.Sprintf("project:%q", project)}, operators...), " ")
+	apiObjs := []*ChangeInfo{}
+	for skip := 0; ; skip += perPage {
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		query := url.Values{
+			"q": {q},
+			"n": {strconv.Itoa(perPage)},
+			"S": {strconv.Itoa(skip)},
+		}
+		for key, values := range changeOptions {
+			query[key] = values
+		}
+		pageObjs := []*ChangeInfo{}
+		// GET /changes/
+		if err := c.do(ctx, http.MethodGet, "changes/?"+query.Encode(), nil, &pageObjs); err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		// Gerrit marks the last change of a page, if there are more changes
+		if len(pageObjs) == 0 || !pageObjs[len(pageObjs)-1].MoreChanges {
+			break
+		}
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateChangeAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gerritClientImpl) GetChange(ctx context.Context, project string, number int) (*ChangeInfo, error) {
+	apiObj := &ChangeInfo{}
+	// GET /changes/{change-id}
+	if err := c.do(ctx, http.MethodGet, changePath(project, number)+"?"+changeOptions.Encode(), nil, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateChangeAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) CreateChange(ctx context.Context, req *ChangeInput) (*ChangeInfo, error) {
+	apiObj := &ChangeInfo{}
+	// POST /changes/
+	if err := c.do(ctx, http.MethodPost, "changes/?"+changeOptions.Encode(), req, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateChangeAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) SetCommitMessage(ctx context.Context, project string, number int, message string) error {
+	// PUT /changes/{change-id}/message
+	return c.do(ctx, http.MethodPut, changePath(project, number)+"/message", &CommitMessageInput{Message: message}, nil)
+}
+
+func (c *gerritClientImpl) SubmitChange(ctx context.Context, project string, number int) (*ChangeInfo, error) {
+	apiObj := &ChangeInfo{}
+	// POST /changes/{change-id}/submit
+	if err := c.do(ctx, http.MethodPost, changePath(project, number)+"/submit", struct{}{}, apiObj); err != nil {
+		return nil, err
+	}
+	if err := validateChangeAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gerritClientImpl) SetChangeState(ctx context.Context, project string, number int, action string) error {
+	// POST /changes/{change-id}/{action}
+	return c.do(ctx, http.MethodPost, changePath(project, number)+"/"+action, struct{}{}, nil)
+}
+
+func (c *gerritClientImpl) MoveChange(ctx context.Context, project string, number int, branch string) error {
+	// POST /changes/{change-id}/move
+	return c.do(ctx, http.MethodPost, changePath(project, number)+"/move", &MoveInput{DestinationBranch: branch}, nil)
+}
+
+// apiPath joins the given path segments, escaping each of them, e.g. the slashes of project names.
+func apiPath(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+	return strings.Join(escaped, "/")
+}
+
+// changePath returns the path of the change with the given number, which is identified
+// together with the project, e.g. "changes/org%2Frepo~42".
+func changePath(project string, number int) string {
+	return apiPath("changes", project+"~"+strconv.Itoa(number))
+}
+
+// newRequest creates a request for the given path, which is resolved relative to the base URL,
+// i.e. the URL of the REST API, which is below "/a/" for authenticated requests.
+func (c *gerritClientImpl) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	return req, nil
+}
+
+// do sends a request with body encoded as JSON if non-nil, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *gerritClientImpl) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := c.newRequest(ctx, method, path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req, out)
+}
+
+// send sends the request, and decodes the response into out if non-nil.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *gerritClientImpl) send(req *http.Request, out interface{}) error {
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return handleHTTPError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Strip the XSSI guard, which makes the response invalid JSON
+	data = bytes.TrimPrefix(data, []byte(xssiPrefix))
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode the response of %s %s: %w", req.Method, req.URL.Redacted(), err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_gerritClientImpl_ListProjects(t *testing.T) {
+	// Gerrit returns the projects of a page as map keyed by their names, hence unordered
+	pages := map[string]map[string]map[string]string{
+		"0": {"flux/b": {"id": "flux%2Fb"}, "flux/a": {"id": "flux%2Fa"}},
+		"2": {"flux/sub/c": {"id": "flux%2Fsub%2Fc"}, "flux/c": {"id": "flux%2Fc"}},
+		"4": {},
+	}
+	skips := []string{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if requestKey(r) != "GET /projects/" || query.Get("p") != "flux/" || query.Get("type") != "CODE" || query.Get("n") != "2" {
+			t.Errorf("unexpected %s?%s", requestKey(r), r.URL.RawQuery)
+		}
+		skips = append(skips, query.Get("S"))
+		writeJSON(w, http.StatusOK, pages[query.Get("S")])
+	})
+
+	perPage := 2
+	ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{PerPage: &perPage})
+	progress := []gitprovider.ListProgress{}
+	projects, err := c.c.ListProjects(ctx, "flux/", func(p gitprovider.ListProgress) { progress = append(progress, p) })
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	names := []string{}
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	if want := []string{"flux/a", "flux/b", "flux/c", "flux/sub/c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListProjects() = %v, want %v", names, want)
+	}
+	// Gerrit doesn't tell whether there are more projects, hence an empty page is requested after
+	// a full last page
+	if want := []string{"0", "2", "4"}; !reflect.DeepEqual(skips, want) {
+		t.Errorf("requested S %v, want %v", skips, want)
+	}
+	wantProgress := []gitprovider.ListProgress{{Count: 2}, {Count: 4}, {Count: 4, EstimatedTotal: 4}}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("progress = %v, want %v", progress, wantProgress)
+	}
+}
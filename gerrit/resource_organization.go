@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Namespace is a prefix of the names of Gerrit projects, e.g. "org/sub" for the project
+// "org/sub/repo". Gerrit has no namespace entities, they exist as long as there are projects
+// in them.
+type Namespace struct {
+	Path string
+}
+
+func newOrganization(apiObj *Namespace, ref gitprovider.OrganizationRef) *organization {
+	return &organization{
+		n:                 *apiObj,
+		ref:               ref,
+		teams:             &TeamsClient{},
+		runners:           &RunnersClient{},
+		appAuthorizations: &AppAuthorizationsClient{},
+		projectBoards:     &ProjectBoardsClient{},
+		webhooks:          &OrganizationWebhookClient{},
+	}
+}
+
+var _ gitprovider.Organization = &organization{}
+
+type organization struct {
+	n   Namespace
+	ref gitprovider.OrganizationRef
+
+	teams             *TeamsClient
+	runners           *RunnersClient
+	appAuthorizations *AppAuthorizationsClient
+	projectBoards     *ProjectBoardsClient
+	webhooks          *OrganizationWebhookClient
+}
+
+func (o *organization) Get() gitprovider.OrganizationInfo {
+	return organizationFromAPI(&o.n)
+}
+
+func (o *organization) APIObject() interface{} {
+	return &o.n
+}
+
+func (o *organization) Organization() gitprovider.OrganizationRef {
+	return o.ref
+}
+
+func (o *organization) Teams() gitprovider.TeamsClient {
+	return o.teams
+}
+
+func (o *organization) Runners() gitprovider.RunnersClient {
+	return o.runners
+}
+
+func (o *organization) AppAuthorizations() gitprovider.AppAuthorizationsClient {
+	return o.appAuthorizations
+}
+
+func (o *organization) ProjectBoards() gitprovider.ProjectBoardsClient {
+	return o.projectBoards
+}
+
+func (o *organization) Webhooks() gitprovider.OrganizationWebhookClient {
+	return o.webhooks
+}
+
+func organizationFromAPI(apiObj *Namespace) gitprovider.OrganizationInfo {
+	return gitprovider.OrganizationInfo{
+		Name: gitprovider.StringVar(apiObj.Path),
+	}
+}
+
+// childNamespaces returns the distinct names of the namespaces directly below prefix, which
+// contain the given projects. Projects directly in prefix aren't in a child namespace.
+func childNamespaces(prefix string, apiObjs []*ProjectInfo) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, apiObj := range apiObjs {
+		name, _, ok := strings.Cut(strings.TrimPrefix(apiObj.Name, prefix), "/")
+		if !ok || name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// changeStatusMerged is the status of submitted changes.
+	changeStatusMerged = "MERGED"
+	// changeStatusAbandoned is the status of changes closed without submitting them.
+	changeStatusAbandoned = "ABANDONED"
+	// changeStatusNew is the status of open changes.
+	changeStatusNew = "NEW"
+)
+
+// changeIDFooter is the prefix of the footer line identifying the change in its commit message.
+const changeIDFooter = "Change-Id:"
+
+// searchTimeLayout is the layout of the times in search operators, e.g. "after:".
+const searchTimeLayout = "2006-01-02 15:04:05 -0700"
+
+func newPullRequest(apiObj *ChangeInfo, domain string) *pullrequest {
+	return &pullrequest{
+		c:      *apiObj,
+		domain: domain,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	c      ChangeInfo
+	domain string
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullrequestFromAPI(&pr.c, pr.domain)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.c
+}
+
+// Reviews gives access to the reviews of the change, which aren't supported yet.
+func (pr *pullrequest) Reviews() gitprovider.ReviewClient {
+	return &ReviewClient{}
+}
+
+// Comments gives access to the comments of the pull request, which aren't supported yet.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{}
+}
+
+// Files always returns ErrNoProviderSupport, as the files of changes aren't implemented yet.
+func (pr *pullrequest) Files(_ context.Context) ([]gitprovider.PullRequestFile, error) {
+	return nil, errNotImplemented("listing the files of changes")
+}
+
+// Diff always returns ErrNoProviderSupport, as the diffs of changes aren't implemented yet.
+func (pr *pullrequest) Diff(_ context.Context) (string, error) {
+	return "", errNotImplemented("getting the diff of changes")
+}
+
+// EnableAutoMerge always returns ErrNoProviderSupport, as Gerrit has no auto-submit for changes.
+func (pr *pullrequest) EnableAutoMerge(_ context.Context, _ gitprovider.MergeMethod) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetLabels always returns ErrNoProviderSupport, as Gerrit has no labels on changes, only hashtags and review labels.
+func (pr *pullrequest) SetLabels(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetAssignees always returns ErrNoProviderSupport, as Gerrit changes have reviewers, but no assignees.
+func (pr *pullrequest) SetAssignees(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetMilestone always returns ErrNoProviderSupport, as Gerrit has no milestones.
+func (pr *pullrequest) SetMilestone(_ context.Context, _ int) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// AddReaction always returns ErrNoProviderSupport, as Gerrit has no reactions to changes.
+func (pr *pullrequest) AddReaction(_ context.Context, _ gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListReactions always returns ErrNoProviderSupport, as Gerrit has no reactions to changes.
+func (pr *pullrequest) ListReactions(_ context.Context) ([]gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func pullrequestFromAPI(apiObj *ChangeInfo, domain string) gitprovider.PullRequestInfo {
+	return gitprovider.PullRequestInfo{
+		Title:        apiObj.Subject,
+		Description:  descriptionFromMessage(commitMessage(apiObj)),
+		Merged:       apiObj.Status == changeStatusMerged,
+		Number:       apiObj.Number,
+		WebURL:       fmt.Sprintf("%s/c/%s/+/%d", gitprovider.GetDomainURL(domain), (&url.URL{Path: apiObj.Project}).EscapedPath(), apiObj.Number),
+		SourceBranch: apiObj.Topic,
+		BaseBranch:   apiObj.Branch,
+		State:        pullRequestStateFromAPI(apiObj.Status),
+		Draft:        apiObj.WorkInProgress,
+	}
+}
+
+// changeStatusOperator maps a gitprovider.PullRequestState to the search operator of the changes in that state.
+func changeStatusOperator(state gitprovider.PullRequestState) string {
+	switch state {
+	case gitprovider.PullRequestStateMerged:
+		return "status:merged"
+	case gitprovider.PullRequestStateClosed:
+		return "status:abandoned"
+	}
+	return "status:open"
+}
+
+// pullRequestStateFromAPI maps the status of a change to a gitprovider.PullRequestState.
+func pullRequestStateFromAPI(status string) gitprovider.PullRequestState {
+	switch status {
+	case changeStatusMerged:
+		return gitprovider.PullRequestStateMerged
+	case changeStatusAbandoned:
+		return gitprovider.PullRequestStateClosed
+	}
+	return gitprovider.PullRequestStateOpen
+}
+
+// commitMessage returns the commit message of the current revision of the change, or the
+// subject if the commit isn't included.
+func commitMessage(apiObj *ChangeInfo) string {
+	revision, ok := apiObj.Revisions[apiObj.CurrentRevision]
+	if !ok || revision == nil || revision.Commit == nil {
+		return apiObj.Subject
+	}
+	return revision.Commit.Message
+}
+
+// descriptionFromMessage returns the body of the commit message, i.e. everything after the
+// subject paragraph, without the Change-Id footer.
+func descriptionFromMessage(message string) string {
+	_, body, found := strings.Cut(message, "\n\n")
+	if !found {
+		return ""
+	}
+	lines := strings.Split(body, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, changeIDFooter) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// replaceSubject replaces the subject paragraph of the commit message with subject, keeping the
+// body and footers, e.g. the Change-Id footer.
+func replaceSubject(message, subject string) string {
+	_, rest, found := strings.Cut(message, "\n\n")
+	if !found {
+		return subject + "\n"
+	}
+	return subject + "\n\n" + rest
+}
+
+// replaceDescription replaces the body of the commit message with description, keeping the
+// subject and the Change-Id footer.
+func replaceDescription(message, description string) string {
+	subject, _, _ := strings.Cut(message, "\n\n")
+	var footers []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, changeIDFooter) {
+			footers = append(footers, line)
+		}
+	}
+	paragraphs := []string{subject}
+	if description = strings.TrimSpace(description); description != "" {
+		paragraphs = append(paragraphs, description)
+	}
+	if len(footers) != 0 {
+		paragraphs = append(paragraphs, strings.Join(footers, "\n"))
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n"
+}
+
+// validateChangeAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateChangeAPI(apiObj *ChangeInfo) error {
+	return validateAPIObject("Gerrit.ChangeInfo", func(validator validation.Validator) {
+		if apiObj.Number == 0 {
+			validator.Required("Number")
+		}
+	})
+}
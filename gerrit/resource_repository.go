@@ -0,0 +1,412 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// branchRefPrefix is the prefix of the fully qualified refs of branches.
+const branchRefPrefix = "refs/heads/"
+
+func newOrgRepository(ctx *clientContext, apiObj *ProjectInfo, head string, ref gitprovider.RepositoryRef) *orgRepository {
+	return &orgRepository{
+		clientContext:     ctx,
+		p:                 *apiObj,
+		head:              head,
+		ref:               ref,
+		deployKeys:        &DeployKeyClient{},
+		deployTokens:      &DeployTokenClient{},
+		labels:            &LabelClient{},
+		milestones:        &MilestoneClient{},
+		releases:          &ReleaseClient{},
+		webhooks:          &WebhookClient{},
+		branchProtections: &BranchProtectionClient{},
+		tagProtections:    &TagProtectionClient{},
+		commits:           &CommitClient{},
+		branches: &BranchClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		pullRequests: &PullRequestClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		files:              &FileClient{},
+		trees:              &TreeClient{},
+		actionsPermissions: &ActionsPermissionsClient{},
+		teamAccess:         &TeamAccessClient{},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	*clientContext
+
+	p ProjectInfo
+	// head is the ref HEAD points to, e.g. "refs/heads/main", which isn't part of ProjectInfo.
+	// It's empty if unknown, e.g. for listed repositories.
+	head string
+	ref  gitprovider.RepositoryRef
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+	teamAccess         *TeamAccessClient
+
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
+}
+
+func (r *orgRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.p, r.head)
+}
+
+func (r *orgRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return repositoryInfoToAPIObj(&info, &r.p, &r.head)
+}
+
+func (r *orgRepository) APIObject() interface{} {
+	return &r.p
+}
+
+func (r *orgRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// InitialCommitSHA returns the SHA of the initial commit, if the repository was created with AutoInit.
+func (r *orgRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
+}
+
+func (r *orgRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *orgRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *orgRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *orgRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *orgRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *orgRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *orgRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *orgRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
+func (r *orgRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *orgRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *orgRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *orgRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *orgRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *orgRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *orgRepository) Update(ctx context.Context) error {
+	name := projectName(r.ref)
+	// GET /projects/{project-name}
+	apiObj, head, err := getProject(ctx, r.c, name)
+	if err != nil {
+		return err
+	}
+	// Every change of a project is a commit to its configuration, hence only change what differs
+	if apiObj.Description != r.p.Description {
+		// PUT /projects/{project-name}/description
+		if err := r.c.SetProjectDescription(ctx, name, r.p.Description); err != nil {
+			return err
+		}
+	}
+	if r.head != "" && head != r.head {
+		// PUT /projects/{project-name}/HEAD
+		if err := r.c.SetHead(ctx, name, r.head); err != nil {
+			return err
+		}
+	}
+
+	// GET /projects/{project-name}
+	apiObj, head, err = getProject(ctx, r.c, name)
+	if err != nil {
+		return err
+	}
+	r.p, r.head = *apiObj, head
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *orgRepository) Reconcile(ctx context.Context) (bool, error) {
+	name := projectName(r.ref)
+	// GET /projects/{project-name}
+	apiObj, head, err := getProject(ctx, r.c, name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			req := &ProjectInput{Description: r.p.Description}
+			if r.head != "" {
+				req.Branches = []string{r.head}
+			}
+			// PUT /projects/{project-name}
+			if _, err := r.c.CreateProject(ctx, name, req); err != nil {
+				return true, err
+			}
+			// GET /projects/{project-name}
+			apiObj, head, err := getProject(ctx, r.c, name)
+			if err != nil {
+				return true, err
+			}
+			r.p, r.head = *apiObj, head
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	desired := newRepositorySpec(&r.p, r.head)
+	if r.head == "" {
+		desired.Head = head
+	}
+	if reflect.DeepEqual(desired, newRepositorySpec(apiObj, head)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, r.Update(ctx)
+}
+
+// SetDefaultBranch points HEAD of the project to the given branch.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *orgRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	// PUT /projects/{project-name}/HEAD
+	if err := r.c.SetHead(ctx, projectName(r.ref), branchRef(branch)); err != nil {
+		return err
+	}
+	r.head = branchRef(branch)
+	return nil
+}
+
+// Delete deletes the current resource irreversibly. This requires the delete-project plugin
+// to be installed on the Gerrit server.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *orgRepository) Delete(ctx context.Context) error {
+	// POST /projects/{project-name}/delete-project~delete
+	return r.c.DeleteProject(ctx, projectName(r.ref))
+}
+
+// Star always returns ErrNoProviderSupport, as Gerrit doesn't support starring repositories.
+func (r *orgRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as Gerrit doesn't support starring repositories.
+func (r *orgRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as Gerrit doesn't support starring repositories.
+func (r *orgRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as Gerrit's project watches are
+// configured using queries, which don't map to subscriptions.
+func (r *orgRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as Gerrit's project watches are
+// configured using queries, which don't map to subscriptions.
+func (r *orgRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as Gerrit has no issues.
+func (r *orgRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, gitprovider.ErrNoProviderSupport
+}
+
+// getProject returns the project together with the ref HEAD points to.
+func getProject(ctx context.Context, c gerritClient, name string) (*ProjectInfo, string, error) {
+	// GET /projects/{project-name}
+	apiObj, err := c.GetProject(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	// GET /projects/{project-name}/HEAD
+	head, err := c.GetHead(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return apiObj, head, nil
+}
+
+// validateProjectAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateProjectAPI(apiObj *ProjectInfo) error {
+	return validateAPIObject("Gerrit.ProjectInfo", func(validator validation.Validator) {
+		// Make sure the name is set, as it's used to reference the project
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// validateBranchAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateBranchAPI(apiObj *BranchInfo) error {
+	return validateAPIObject("Gerrit.BranchInfo", func(validator validation.Validator) {
+		if apiObj.Revision == "" {
+			validator.Required("Revision")
+		}
+	})
+}
+
+// branchRef returns the fully qualified ref of the given branch.
+func branchRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return branchRefPrefix + branch
+}
+
+// repositoryFromAPI returns the RepositoryInfo of the project, with head being the ref HEAD
+// points to, if known. Read access to Gerrit projects is given by access rights, which are
+// usually inherited from the parent projects, hence all repositories are reported as private.
+func repositoryFromAPI(apiObj *ProjectInfo, head string) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{
+		Visibility: gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+	if apiObj.Description != "" {
+		repo.Description = gitprovider.StringVar(apiObj.Description)
+	}
+	if head != "" {
+		repo.DefaultBranch = gitprovider.StringVar(strings.TrimPrefix(head, branchRefPrefix))
+	}
+	return repo
+}
+
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj and head. ErrNoProviderSupport
+// is returned for settings Gerrit doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *ProjectInfo, head *string) error {
+	if repo.Description != nil {
+		apiObj.Description = *repo.Description
+	}
+	if repo.DefaultBranch != nil {
+		*head = branchRef(*repo.DefaultBranch)
+	}
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityPrivate {
+		return fmt.Errorf("gerrit projects get their visibility from access rights, hence %q can't be set: %w", *repo.Visibility, gitprovider.ErrNoProviderSupport)
+	}
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("gerrit doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("gerrit doesn't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// repositorySpec is the part of a project that can be set.
+type repositorySpec struct {
+	Description string
+	Head        string
+}
+
+// newRepositorySpec copies over the fields of the repository that can be set, i.e. the desired
+// spec of the repository. This allows us to separate "spec" from "status" fields.
+func newRepositorySpec(apiObj *ProjectInfo, head string) *repositorySpec {
+	return &repositorySpec{
+		Description: apiObj.Description,
+		Head:        head,
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+// The types in this file are the entities of the Gerrit REST API, as documented in
+// https://gerrit-review.googlesource.com/Documentation/rest-api.html. Only the fields used by
+// this package are part of them.
+
+// ProjectInfo is a Gerrit project, i.e. a repository. Name isn't set by the server if the
+// project is part of a map keyed by the name, it's filled in from the key by this package.
+type ProjectInfo struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+// ProjectInput is the request to create a project. The first of Branches becomes HEAD, and
+// CreateEmptyCommit creates an empty initial commit on all of them.
+type ProjectInput struct {
+	Description       string   `json:"description,omitempty"`
+	Branches          []string `json:"branches,omitempty"`
+	CreateEmptyCommit bool     `json:"create_empty_commit,omitempty"`
+}
+
+// ProjectDescriptionInput is the request to set the description of a project.
+type ProjectDescriptionInput struct {
+	Description   string `json:"description,omitempty"`
+	CommitMessage string `json:"commit_message,omitempty"`
+}
+
+// HeadInput is the request to set HEAD of a project, with Ref being a branch name or ref.
+type HeadInput struct {
+	Ref string `json:"ref"`
+}
+
+// BranchInfo is a branch of a project, with Ref being the fully qualified ref, e.g.
+// "refs/heads/main", and Revision the ID of the commit it points to.
+type BranchInfo struct {
+	Ref      string `json:"ref"`
+	Revision string `json:"revision"`
+}
+
+// BranchInput is the request to create a branch at Revision.
+type BranchInput struct {
+	Revision string `json:"revision,omitempty"`
+}
+
+// ChangeInfo is a change, i.e. a commit under review. Status is either "NEW", "MERGED" or
+// "ABANDONED". Revisions only contains the current revision, with its commit, if requested.
+// MoreChanges is set on the last change of a list, if there are more changes to list.
+type ChangeInfo struct {
+	ID              string                   `json:"id"`
+	Project         string                   `json:"project"`
+	Branch          string                   `json:"branch"`
+	Topic           string                   `json:"topic,omitempty"`
+	ChangeID        string                   `json:"change_id"`
+	Subject         string                   `json:"subject"`
+	Status          string                   `json:"status"`
+	WorkInProgress  bool                     `json:"work_in_progress,omitempty"`
+	Number          int                      `json:"_number"`
+	CurrentRevision string                   `json:"current_revision,omitempty"`
+	Revisions       map[string]*RevisionInfo `json:"revisions,omitempty"`
+	MoreChanges     bool                     `json:"_more_changes,omitempty"`
+}
+
+// RevisionInfo is a patch set of a change.
+type RevisionInfo struct {
+	Number int         `json:"_number"`
+	Commit *CommitInfo `json:"commit,omitempty"`
+}
+
+// CommitInfo is a commit, with Message being the full commit message, including the subject
+// and the Change-Id footer.
+type CommitInfo struct {
+	Commit  string `json:"commit,omitempty"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// ChangeInput is the request to create a change. Subject is the full commit message of the
+// change. If Merge is set, the change is a merge commit of Merge.Source into Branch. If
+// WorkInProgress is set, the change isn't ready for review yet.
+type ChangeInput struct {
+	Project        string      `json:"project"`
+	Branch         string      `json:"branch"`
+	Subject        string      `json:"subject"`
+	Topic          string      `json:"topic,omitempty"`
+	Merge          *MergeInput `json:"merge,omitempty"`
+	WorkInProgress bool        `json:"work_in_progress,omitempty"`
+}
+
+// MergeInput is the source of a merge commit, e.g. a branch name or commit ID.
+type MergeInput struct {
+	Source string `json:"source"`
+}
+
+// CommitMessageInput is the request to change the commit message of a change.
+type CommitMessageInput struct {
+	Message string `json:"message"`
+}
+
+// MoveInput is the request to move a change to another destination branch.
+type MoveInput struct {
+	DestinationBranch string `json:"destination_branch"`
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	errorsDocURL = "https://gerrit-review.googlesource.com/Documentation/rest-api.html#response-codes"
+
+	// defaultPerPage is the page size used for lists, if none is given in the call options.
+	defaultPerPage = 100
+)
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Gerrit's usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Gerrit's usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeSuborganization:
+		return nil
+	case gitprovider.IdentityTypeUser:
+		return fmt.Errorf("gerrit has no repositories owned by users: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// projectName returns the name of the Gerrit project of the repository, which is the path of
+// the organization joined with the repository name, e.g. "org/sub/repo".
+func projectName(ref gitprovider.RepositoryRef) string {
+	return ref.GetIdentity() + "/" + ref.GetRepository()
+}
+
+// handleHTTPError reads the error response resp, and returns typed variants of it.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(resp *http.Response) error {
+	// Gerrit's error responses are plain text, which is only used for the message,
+	// hence reading a prefix is enough
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	httpErr := gitprovider.HTTPError{
+		Response:         resp,
+		ErrorMessage:     fmt.Sprintf("%s %s: %d %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode, message),
+		Message:          message,
+		DocumentationURL: errorsDocURL,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		// Check for invalid credentials, and return a typed error in that case
+		return &gitprovider.InvalidCredentialsError{HTTPError: httpErr}
+	case http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case http.StatusNotFound:
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		// Gerrit doesn't tell the limits of its quota, only that they have been exceeded
+		return &gitprovider.RateLimitError{HTTPError: httpErr}
+	case http.StatusConflict:
+		// Check for already exists errors, e.g. "Project already exists"
+		if strings.Contains(message, "already exists") {
+			return validation.NewMultiError(&httpErr, gitprovider.ErrAlreadyExists)
+		}
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// listProgress returns the progress after count objects have been listed. Gerrit doesn't tell
+// the total, which is hence only known once the last page has been listed.
+func listProgress(count int, lastPage bool) gitprovider.ListProgress {
+	if lastPage {
+		return gitprovider.ListProgress{Count: count, EstimatedTotal: count}
+	}
+	return gitprovider.ListProgress{Count: count}
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that Gerrit has, but which aren't
+// implemented by this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for gerrit yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
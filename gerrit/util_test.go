@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const testDomain = "gerrit.example.com"
+
+// newTestClient returns a client for a fake Gerrit REST API served by handler, which gets the
+// requests below "/a/".
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := testutil.NewServer(t, http.StripPrefix("/a", handler))
+	baseURL, _ := url.Parse(srv.URL + "/a/")
+	return newClient(srv.Client(), baseURL, "user", "password", testDomain, false)
+}
+
+// writeJSON writes v like Gerrit, i.e. prefixed with the XSSI guard.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintln(w, xssiPrefix)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the plain-text error message like Gerrit.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintln(w, message)
+}
+
+// requestKey returns the method and the escaped path of the request, e.g.
+// "GET /projects/flux%2Frepo", as project names are escaped.
+func requestKey(r *http.Request) string {
+	return r.Method + " " + r.URL.EscapedPath()
+}
+
+func Test_handleHTTPError(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedErrs  []error
+		unexpectedErr error
+		wantMessage   string
+	}{
+		{
+			name:         "401 => InvalidCredentialsError",
+			status:       http.StatusUnauthorized,
+			expectedErrs: []error{&gitprovider.InvalidCredentialsError{}},
+			wantMessage:  "Unauthorized",
+		},
+		{
+			name:         "403 => InvalidCredentialsError & ErrForbidden",
+			status:       http.StatusForbidden,
+			body:         "not permitted: create on refs/heads/main\n",
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+			wantMessage:  "not permitted: create on refs/heads/main",
+		},
+		{
+			name:         "404 => ErrNotFound",
+			status:       http.StatusNotFound,
+			body:         "Not found: flux/missing\n",
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+			wantMessage:  "Not found: flux/missing",
+		},
+		{
+			name:         "429 => RateLimitError",
+			status:       http.StatusTooManyRequests,
+			expectedErrs: []error{&gitprovider.RateLimitError{}},
+			wantMessage:  "Too Many Requests",
+		},
+		{
+			name:         "409 for existing project => ErrAlreadyExists",
+			status:       http.StatusConflict,
+			body:         "Project already exists\n",
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+			wantMessage:  "Project already exists",
+		},
+		{
+			name:          "409 for other conflicts => HTTPError",
+			status:        http.StatusConflict,
+			body:          "change is closed\n",
+			expectedErrs:  []error{&gitprovider.HTTPError{}},
+			unexpectedErr: gitprovider.ErrAlreadyExists,
+			wantMessage:   "change is closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+				Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/a/projects/flux%2Fmissing"}},
+			}
+			err := handleHTTPError(resp)
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+			if tt.unexpectedErr != nil && errors.Is(err, tt.unexpectedErr) {
+				t.Errorf("handleHTTPError() error = %v, didn't want %v", err, tt.unexpectedErr)
+			}
+			// The plain-text body is the message, or the status text if it's empty
+			if want := fmt.Sprintf(": %d %s", tt.status, tt.wantMessage); !strings.Contains(err.Error(), want) {
+				t.Errorf("handleHTTPError() error = %q, want it to contain %q", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/giteaapi"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "gitea.com"
+	// TokenVariable is the common name for the environment variable
+	// containing a Gitea authentication token.
+	TokenVariable = "GITEA_TOKEN" // #nosec G101
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain, "codeberg.org"},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	return NewClient(gitprovider.TokenFromURL(u), append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for Gitea API endpoints.
+//
+// The token is an access token of a Gitea user, passing an empty token will allow public read
+// access only.
+//
+// A self-hosted Gitea instance can be used if you specify the domain using WithDomain, e.g.
+// "gitea.example.com" or "https://example.com/gitea" for instances served under a sub-path.
+// The domain defaults to gitea.com.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		if domain, err = giteaapi.NormalizeDomain(*opts.Domain); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := giteaapi.NewClientFromOptions(flavor, token, domain, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/giteaapi"
+)
+
+// ProviderID is the provider ID for Gitea.
+const ProviderID = gitprovider.ProviderID("gitea")
+
+// flavor describes the Gitea API, which serves everything the shared clients implement.
+//
+//nolint:gochecknoglobals
+var flavor = &giteaapi.Flavor{
+	ProviderID:         ProviderID,
+	APIDocURL:          "https://docs.gitea.com/development/api-usage",
+	EditRepositories:   true,
+	TeamAccess:         true,
+	WritableDeployKeys: true,
+	LicenseTemplates: map[gitprovider.LicenseTemplate]string{
+		gitprovider.LicenseTemplateApache2: "Apache-2.0",
+		gitprovider.LicenseTemplateMIT:     "MIT",
+		gitprovider.LicenseTemplateGPL3:    "GPL-3.0",
+	},
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// appJWTLifetime is how long the JSON Web Tokens authenticating as the GitHub App are valid.
+	// GitHub allows at most 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockDrift is how far the issue time of the JSON Web Tokens is backdated, to allow
+	// for clock drift between the client and GitHub.
+	appJWTClockDrift = 60 * time.Second
+)
+
+// WithGitHubApp initializes a Client which authenticates as an installation of the GitHub App,
+// instead of using a long-lived personal access token. The installation tokens are minted using
+// the private key of the app, and refreshed shortly before they expire (after an hour).
+//
+// privateKey is the PEM encoded RSA private key generated for the app. This option is only
+// supported by the GitHub client, the clients of other providers fail with
+// ErrInvalidClientOptions. It can't be combined with the other authentication options, e.g.
+// WithOAuth2Token.
+func WithGitHubApp(appID, installationID int64, privateKey []byte) gitprovider.ClientOption {
+	if appID <= 0 {
+		return &appOption{err: fmt.Errorf("appID must be positive: %w", gitprovider.ErrInvalidClientOptions)}
+	}
+	if installationID <= 0 {
+		return &appOption{err: fmt.Errorf("installationID must be positive: %w", gitprovider.ErrInvalidClientOptions)}
+	}
+	key, err := parseAppPrivateKey(privateKey)
+	if err != nil {
+		return &appOption{err: err}
+	}
+	return &appOption{app: &gitHubApp{appID: appID, installationID: installationID, key: key}}
+}
+
+// appOption carries the GitHub App to NewClient, which needs the domain to know where to mint
+// the installation tokens.
+type appOption struct {
+	app *gitHubApp
+	err error
+}
+
+// ApplyToClientOptions fails, as NewClient takes the option out using appFromOptions before
+// applying the others. It's only called if the option is given to a client of another provider.
+func (o *appOption) ApplyToClientOptions(_ *gitprovider.ClientOptions) error {
+	if o.err != nil {
+		return o.err
+	}
+	return fmt.Errorf("WithGitHubApp is only supported by the GitHub client: %w", gitprovider.ErrInvalidClientOptions)
+}
+
+// appFromOptions returns the GitHub App given using WithGitHubApp, if any, and the other options.
+func appFromOptions(optFns []gitprovider.ClientOption) (*gitHubApp, []gitprovider.ClientOption, error) {
+	var app *gitHubApp
+	others := make([]gitprovider.ClientOption, 0, len(optFns))
+	for _, opt := range optFns {
+		o, ok := opt.(*appOption)
+		if !ok {
+			others = append(others, opt)
+			continue
+		}
+		if o.err != nil {
+			return nil, nil, o.err
+		}
+		// Make sure the user didn't specify the GitHub App twice
+		if app != nil {
+			return nil, nil, fmt.Errorf("option WithGitHubApp already configured: %w", gitprovider.ErrInvalidClientOptions)
+		}
+		app = o.app
+	}
+	return app, others, nil
+}
+
+// parseAppPrivateKey parses the PEM encoded RSA private key of a GitHub App, which is in
+// PKCS #1 form when downloaded from GitHub, or PKCS #8 form after conversion.
+func parseAppPrivateKey(privateKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, fmt.Errorf("privateKey must be PEM encoded: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid privateKey: %v: %w", err, gitprovider.ErrInvalidClientOptions)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("privateKey must be an RSA key: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	return rsaKey, nil
+}
+
+// gitHubApp mints the installation tokens of a GitHub App.
+type gitHubApp struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+}
+
+// tokenSource returns a token source minting installation tokens using gh, whose requests are
+// authenticated as the app.
+func (a *gitHubApp) tokenSource(gh *github.Client) gitprovider.TokenSourceFunc {
+	return func(ctx context.Context) (string, time.Time, error) {
+		// POST /app/installations/{installation_id}/access_tokens
+		apiObj, _, err := gh.Apps.CreateInstallationToken(ctx, a.installationID, nil)
+		if err != nil {
+			return "", time.Time{}, handleHTTPError(err)
+		}
+		if apiObj.GetToken() == "" {
+			return "", time.Time{}, fmt.Errorf("no installation token returned: %w", gitprovider.ErrInvalidServerData)
+		}
+		return apiObj.GetToken(), apiObj.GetExpiresAt(), nil
+	}
+}
+
+// jwt returns a JSON Web Token authenticating as the app, signed with its private key.
+func (a *gitHubApp) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// transport returns a transport authenticating the requests as the app, on top of next.
+func (a *gitHubApp) transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &appTransport{next: next, app: a}
+}
+
+// appTransport sets the Authorization header of requests to a JSON Web Token of the app.
+type appTransport struct {
+	next http.RoundTripper
+	app  *gitHubApp
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.app.jwt(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the JSON Web Token of the GitHub App: %w", err)
+	}
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.next.RoundTrip(req)
+}
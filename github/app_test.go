@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newTestAppKey returns a PKCS #1 PEM encoded RSA key, like the ones GitHub generates for apps.
+func newTestAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyAppJWT verifies the signature of the JSON Web Token, and returns its claims.
+func verifyAppJWT(t *testing.T, key *rsa.PublicKey, token string) map[string]interface{} {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token %q isn't a JSON Web Token", token)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestWithGitHubApp(t *testing.T) {
+	key, privateKey := newTestAppKey(t)
+	mints := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		claims := verifyAppJWT(t, &key.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if claims["iss"] != "7" {
+			t.Errorf("iss = %v, want 7", claims["iss"])
+		}
+		mints++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_installation",
+			"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+		})
+	})
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer ghs_installation" {
+			t.Errorf("Authorization = %q, want the installation token", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"login": "app[bot]"})
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	c, err := NewClient(
+		WithGitHubApp(7, 42, privateKey),
+		gitprovider.WithDomain(strings.TrimPrefix(srv.URL, "https://")),
+		gitprovider.WithCustomCAPostChainTransportHook(caBundle),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	gh := c.Raw().(*github.Client)
+	for i := 0; i < 2; i++ {
+		if _, _, err := gh.Users.Get(context.Background(), ""); err != nil {
+			t.Fatalf("Users.Get() error = %v", err)
+		}
+	}
+	// The installation token is cached until it expires
+	if mints != 1 {
+		t.Errorf("minted %d installation tokens, want 1", mints)
+	}
+}
+
+func TestWithGitHubApp_InvalidOptions(t *testing.T) {
+	_, privateKey := newTestAppKey(t)
+	tests := []struct {
+		name string
+		opts []gitprovider.ClientOption
+	}{
+		{
+			name: "invalid app ID",
+			opts: []gitprovider.ClientOption{WithGitHubApp(0, 42, privateKey)},
+		},
+		{
+			name: "invalid installation ID",
+			opts: []gitprovider.ClientOption{WithGitHubApp(7, 0, privateKey)},
+		},
+		{
+			name: "invalid private key",
+			opts: []gitprovider.ClientOption{WithGitHubApp(7, 42, []byte("not a key"))},
+		},
+		{
+			name: "combined with a personal access token",
+			opts: []gitprovider.ClientOption{WithGitHubApp(7, 42, privateKey), gitprovider.WithOAuth2Token("token")},
+		},
+		{
+			name: "given twice",
+			opts: []gitprovider.ClientOption{WithGitHubApp(7, 42, privateKey), WithGitHubApp(8, 43, privateKey)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient(tt.opts...); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+				t.Errorf("NewClient() error = %v, want ErrInvalidClientOptions", err)
+			}
+		})
+	}
+}
+
+func TestWithGitHubApp_otherProviders(t *testing.T) {
+	_, privateKey := newTestAppKey(t)
+	// The clients of the other providers apply all options using MakeClientOptions
+	if _, err := gitprovider.MakeClientOptions(WithGitHubApp(7, 42, privateKey)); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("MakeClientOptions() error = %v, want ErrInvalidClientOptions", err)
+	}
+}
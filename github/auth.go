@@ -17,7 +17,10 @@ limitations under the License.
 package github
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/google/go-github/v49/github"
 
@@ -32,10 +35,29 @@ const (
 	TokenVariable = "GITHUB_TOKEN" // #nosec G101
 )
 
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	if token := gitprovider.TokenFromURL(u); token != "" {
+		opts = append(opts, gitprovider.WithOAuth2Token(token))
+	}
+	return NewClient(append(opts, gitprovider.WithDomain(domain))...)
+}
+
 // NewClient creates a new gitprovider.Client instance for GitHub API endpoints.
 //
 // Using WithOAuth2Token you can specify authentication
 // credentials, passing no such ClientOption will allow public read access only.
+// Use WithGitHubApp to authenticate as an installation of a GitHub App, WithOAuth2TokenSource
+// for OAuth2 access tokens that are refreshed, or WithTokenSource for other credentials that are
+// rotated.
 //
 // Password-based authentication is not supported because it is deprecated by GitHub, see
 // https://developer.github.com/changes/2020-02-14-deprecating-password-auth/
@@ -48,37 +70,51 @@ const (
 // The chain of transports looks like this:
 // github.com API <-> "Post Chain" <-> Authentication <-> Cache <-> "Pre Chain" <-> *github.Client.
 func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// The GitHub App is configured below, as it needs the domain
+	app, optFns, err := appFromOptions(optFns)
+	if err != nil {
+		return nil, err
+	}
+
 	// Complete the options struct
 	opts, err := gitprovider.MakeClientOptions(optFns...)
 	if err != nil {
 		return nil, err
 	}
 
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = *opts.Domain
+	}
+
 	// Create a *http.Client using the transport chain
-	httpClient, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	httpClient, err := opts.BuildHTTPClient()
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the GitHub client either for the default github.com domain, or
-	// a custom enterprise domain if opts.Domain is set to something other than
-	// the default.
-	var gh *github.Client
-	var domain string
-
-	if opts.Domain == nil || *opts.Domain == DefaultDomain {
-		// No domain or the default github.com used
-		domain = DefaultDomain
-		gh = github.NewClient(httpClient)
-	} else {
-		// GitHub Enterprise is used
-		domain = *opts.Domain
-		baseURL := fmt.Sprintf("https://%s/api/v3/", domain)
-		uploadURL := fmt.Sprintf("https://%s/api/uploads/", domain)
-
-		if gh, err = github.NewEnterpriseClient(baseURL, uploadURL, httpClient); err != nil {
+	// When authenticating as a GitHub App, mint the installation tokens through the API of the
+	// domain, and authenticate the client with them
+	var installationPermissions func(ctx context.Context) (map[string]string, error)
+	if app != nil {
+		appClient, err := newGitHubClient(&http.Client{Transport: app.transport(httpClient.Transport)}, domain)
+		if err != nil {
+			return nil, err
+		}
+		installationPermissions = app.installationPermissions(appClient)
+		// Extend the options instead of making new ones, so that e.g. the concurrency limit stays
+		// shared between all HTTP clients of the client
+		if err := gitprovider.WithTokenSource(app.tokenSource(appClient)).ApplyToClientOptions(opts); err != nil {
 			return nil, err
 		}
+		if httpClient, err = opts.BuildHTTPClient(); err != nil {
+			return nil, err
+		}
+	}
+
+	gh, err := newGitHubClient(httpClient, domain)
+	if err != nil {
+		return nil, err
 	}
 	// By default, turn destructive actions off. But allow overrides.
 	destructiveActions := false
@@ -86,5 +122,32 @@ func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(gh, domain, destructiveActions), nil
+	// Follow the redirects of release asset downloads with the transport chain, but without
+	// the credentials of the client
+	downloadClient, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c := newClient(gh, domain, destructiveActions)
+	c.c.(*githubClientImpl).downloadClient = downloadClient
+	c.installationPermissions = installationPermissions
+	return c, nil
+}
+
+// newGitHubClient creates the GitHub client either for the default github.com domain, or a
+// custom enterprise domain.
+func newGitHubClient(httpClient *http.Client, domain string) (*github.Client, error) {
+	if domain == DefaultDomain {
+		return github.NewClient(httpClient), nil
+	}
+	// GitHub Enterprise is used
+	baseURL := fmt.Sprintf("https://%s/api/v3/", domain)
+	gh, err := github.NewEnterpriseClient(baseURL, baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	// Uploads are served from a different path than the API
+	gh.UploadURL = uploadURLFromBaseURL(gh.BaseURL)
+	return gh, nil
 }
@@ -17,8 +17,17 @@ limitations under the License.
 package github
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/google/go-github/v49/github"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
@@ -66,3 +75,36 @@ func assertEqual(t *testing.T, a interface{}, b interface{}) {
 		t.Fatalf("%s != %s", a, b)
 	}
 }
+
+func TestNewClient_Anonymous(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "repo"})
+	})
+	mux.HandleFunc("/api/v3/orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	c, err := NewClient(
+		gitprovider.WithAnonymous(),
+		gitprovider.WithDomain(strings.TrimPrefix(srv.URL, "https://")),
+		gitprovider.WithCustomCAPostChainTransportHook(caBundle),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	gh := c.Raw().(*github.Client)
+	if _, _, err := gh.Repositories.Get(context.Background(), "org", "repo"); err != nil {
+		t.Errorf("Repositories.Get() error = %v", err)
+	}
+	_, _, err = gh.Repositories.Create(context.Background(), "org", &github.Repository{Name: github.String("new")})
+	if !errors.Is(err, gitprovider.ErrCredentialsRequired) {
+		t.Errorf("Repositories.Create() error = %v, want ErrCredentialsRequired", err)
+	}
+}
@@ -18,7 +18,12 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v49/github"
 
@@ -29,7 +34,7 @@ import (
 const ProviderID = gitprovider.ProviderID("github")
 
 func newClient(c *github.Client, domain string, destructiveActions bool) *Client {
-	ghClient := &githubClientImpl{c, destructiveActions}
+	ghClient := &githubClientImpl{c: c, destructiveActions: destructiveActions}
 	ctx := &clientContext{ghClient, domain, destructiveActions}
 	return &Client{
 		clientContext: ctx,
@@ -61,6 +66,15 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+
+	// gitignoreTemplates caches the result of ListGitignoreTemplates, as the templates of the
+	// provider don't change during the lifetime of the client.
+	gitignoreTemplatesMu sync.Mutex
+	gitignoreTemplates   []string
+
+	// installationPermissions returns the permissions granted to the GitHub App installation the
+	// client authenticates as, nil if it doesn't.
+	installationPermissions func(ctx context.Context) (map[string]string, error)
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "github.com", "enterprise.github.com" or
@@ -98,6 +112,84 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 	return c.userRepos
 }
 
+// GetOwnerType returns whether the given owner is a user or an organization, based on the
+// type GitHub reports for the account. Other account types (e.g. bots) result in ErrUnknownOwnerType.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// GET /users/{username}
+	apiObj, err := c.c.GetUser(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	switch apiObj.GetType() {
+	case "User":
+		return gitprovider.OwnerTypeUser, nil
+	case "Organization":
+		return gitprovider.OwnerTypeOrganization, nil
+	}
+	return "", fmt.Errorf("account %q has type %q: %w", owner, apiObj.GetType(), gitprovider.ErrUnknownOwnerType)
+}
+
+// ListStarred returns references to the repositories the given user has starred. Repositories
+// owned by organizations are returned as OrgRepositoryRefs, all others as UserRepositoryRefs.
+func (c *Client) ListStarred(ctx context.Context, username string) ([]gitprovider.RepositoryRef, error) {
+	// GET /users/{username}/starred
+	apiObjs, err := c.c.ListStarredRepos(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]gitprovider.RepositoryRef, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		owner := apiObj.GetOwner()
+		if owner.GetType() == "Organization" {
+			refs = append(refs, gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: owner.GetLogin()},
+				RepositoryName:  apiObj.GetName(),
+			})
+			continue
+		}
+		refs = append(refs, gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: c.domain, UserLogin: owner.GetLogin()},
+			RepositoryName: apiObj.GetName(),
+		})
+	}
+	return refs, nil
+}
+
+// ListLicenseTemplates returns the license templates GitHub offers when creating repositories.
+func (c *Client) ListLicenseTemplates(ctx context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	// GET /licenses
+	apiObjs, err := c.c.ListLicenses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	licenses := make([]gitprovider.LicenseTemplateInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		licenses = append(licenses, gitprovider.LicenseTemplateInfo{
+			Key:  gitprovider.LicenseTemplate(apiObj.GetKey()),
+			Name: apiObj.GetName(),
+		})
+	}
+	return licenses, nil
+}
+
+// ListGitignoreTemplates returns the names of the .gitignore templates GitHub offers when
+// creating repositories. The list is cached for the lifetime of the client.
+func (c *Client) ListGitignoreTemplates(ctx context.Context) ([]string, error) {
+	c.gitignoreTemplatesMu.Lock()
+	defer c.gitignoreTemplatesMu.Unlock()
+	if c.gitignoreTemplates == nil {
+		// GET /gitignore/templates
+		names, err := c.c.ListGitignoreTemplates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.gitignoreTemplates = append([]string{}, names...)
+	}
+	// Don't allow callers to modify the cache
+	return append([]string{}, c.gitignoreTemplates...), nil
+}
+
 //nolint:gochecknoglobals
 var permissionScopes = map[gitprovider.TokenPermission]string{
 	gitprovider.TokenPermissionRWRepository: "repo",
@@ -110,19 +202,11 @@ func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.
 		return false, gitprovider.ErrNoProviderSupport
 	}
 
-	// The X-OAuth-Scopes header is returned for any API calls, using Meta here to keep things simple.
-	_, res, err := c.c.Client().APIMeta(ctx)
+	scopes, err := c.tokenScopes(ctx)
 	if err != nil {
 		return false, err
 	}
-
-	scopes := res.Header.Get("X-OAuth-Scopes")
-	if scopes == "" {
-		return false, gitprovider.ErrMissingHeader
-	}
-
-	for _, s := range strings.Split(scopes, ",") {
-		scope := strings.TrimSpace(s)
+	for _, scope := range scopes {
 		if scope == requestedScope {
 			return true, nil
 		}
@@ -130,3 +214,97 @@ func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.
 
 	return false, nil
 }
+
+// impliedScopes maps OAuth scopes to the narrower scopes they include.
+// See: https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+//
+//nolint:gochecknoglobals
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"admin:org":        {"write:org", "read:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"project":          {"read:project"},
+}
+
+// CheckScopes reports which of the required OAuth scopes the token lacks, based on the
+// X-OAuth-Scopes header. Fine-grained personal access tokens and GitHub App tokens don't have
+// OAuth scopes, hence ErrNoProviderSupport is returned for them.
+func (c *Client) CheckScopes(ctx context.Context, requiredScopes []string) (*gitprovider.ScopeCheckResult, error) {
+	scopes, err := c.tokenScopes(ctx)
+	if errors.Is(err, gitprovider.ErrMissingHeader) {
+		return nil, fmt.Errorf("the token has no OAuth scopes: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gitprovider.NewScopeCheckResult(scopes, requiredScopes, impliedScopes), nil
+}
+
+// tokenExpirationHeader is the header GitHub reports the expiry of expiring tokens in.
+const tokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+
+// tokenExpirationLayouts are the time layouts GitHub uses for the tokenExpirationHeader.
+//
+//nolint:gochecknoglobals
+var tokenExpirationLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"}
+
+// TokenInfo returns the OAuth scopes and expiry of the token, based on the X-OAuth-Scopes and
+// GitHub-Authentication-Token-Expiration headers. Scopes is nil for fine-grained personal access
+// tokens and GitHub App tokens, which don't have OAuth scopes.
+func (c *Client) TokenInfo(ctx context.Context) (*gitprovider.TokenInfo, error) {
+	// The headers are returned for any API calls, using Meta here to keep things simple.
+	_, res, err := c.c.Client().APIMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &gitprovider.TokenInfo{}
+	// Classic tokens without any scopes get an empty header
+	if values, ok := res.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok && len(values) != 0 {
+		info.Scopes = []string{}
+		for _, s := range strings.Split(values[0], ",") {
+			if scope := strings.TrimSpace(s); scope != "" {
+				info.Scopes = append(info.Scopes, scope)
+			}
+		}
+	}
+	if value := res.Header.Get(tokenExpirationHeader); value != "" {
+		expiresAt, err := parseTokenExpiration(value)
+		if err != nil {
+			return nil, err
+		}
+		info.ExpiresAt = &expiresAt
+	}
+	return info, nil
+}
+
+// parseTokenExpiration parses the value of the tokenExpirationHeader.
+func parseTokenExpiration(value string) (time.Time, error) {
+	for _, layout := range tokenExpirationLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid %s header %q: %w", tokenExpirationHeader, value, gitprovider.ErrInvalidServerData)
+}
+
+// tokenScopes returns the OAuth scopes granted to the token. ErrMissingHeader is returned if
+// GitHub doesn't report scopes at all, e.g. for fine-grained tokens.
+func (c *Client) tokenScopes(ctx context.Context) ([]string, error) {
+	info, err := c.TokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if info.Scopes == nil {
+		return nil, gitprovider.ErrMissingHeader
+	}
+	return info.Scopes, nil
+}
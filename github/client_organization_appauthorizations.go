@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// AppAuthorizationsClient implements the gitprovider.AppAuthorizationsClient interface.
+var _ gitprovider.AppAuthorizationsClient = &AppAuthorizationsClient{}
+
+// AppAuthorizationsClient handles the GitHub App installations of an organization.
+type AppAuthorizationsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List all GitHub App installations of the specific organization.
+// This requires the admin:org scope.
+//
+// ErrForbidden is returned if the credentials lack the permissions to list installations.
+//
+// List returns all available installations, using multiple paginated requests if needed.
+func (c *AppAuthorizationsClient) List(ctx context.Context) ([]gitprovider.AppAuthorization, error) {
+	// GET /orgs/{org}/installations
+	apiObjs, err := c.c.ListOrgInstallations(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := make([]gitprovider.AppAuthorization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		authorizations = append(authorizations, newAppAuthorization(apiObj, c.ref))
+	}
+	return authorizations, nil
+}
+
+// Revoke uninstalls the GitHub App installation with the given ID.
+// Note that GitHub only allows an app to uninstall itself, hence this requires the client to be
+// authenticated as the GitHub App owning the installation.
+//
+// ErrNotFound is returned if the installation does not exist.
+// ErrForbidden is returned if the credentials lack the permissions to uninstall the app.
+func (c *AppAuthorizationsClient) Revoke(ctx context.Context, id int64) error {
+	// DELETE /app/installations/{installation_id}
+	return c.c.DeleteInstallation(ctx, id)
+}
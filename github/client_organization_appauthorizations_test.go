@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestAppAuthorizationsClient_List(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []gitprovider.AppAuthorizationInfo
+		wantErr error
+	}{
+		{
+			name:   "installations",
+			status: http.StatusOK,
+			body:   `{"total_count":1,"installations":[{"id":1,"app_slug":"renovate","permissions":{"contents":"write","metadata":"read"}}]}`,
+			want: []gitprovider.AppAuthorizationInfo{{
+				ID:          1,
+				AppName:     "renovate",
+				Permissions: map[string]string{"contents": "write", "metadata": "read"},
+			}},
+		},
+		{
+			name:    "not an admin",
+			status:  http.StatusForbidden,
+			body:    `{"message":"Must have admin rights to Repository."}`,
+			wantErr: gitprovider.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/orgs/org/installations" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &AppAuthorizationsClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+				ref:           gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+			}
+
+			authorizations, err := c.List(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []gitprovider.AppAuthorizationInfo
+			for _, a := range authorizations {
+				got = append(got, a.Get())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProjectBoardsClient implements the gitprovider.ProjectBoardsClient interface.
+var _ gitprovider.ProjectBoardsClient = &ProjectBoardsClient{}
+
+// ProjectBoardsClient handles the Projects (v2) of an organization.
+type ProjectBoardsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List all Projects (v2) of the specific organization, including closed ones.
+// This requires the read:project scope.
+//
+// ErrNotFound is returned if the organization doesn't exist.
+// ErrForbidden is returned if the credentials lack the permissions to list projects.
+//
+// List returns all available projects, using multiple paginated requests if needed.
+func (c *ProjectBoardsClient) List(ctx context.Context) ([]gitprovider.ProjectBoard, error) {
+	// query organization.projectsV2
+	apiObjs, err := c.c.ListOrgProjectsV2(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]gitprovider.ProjectBoard, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		boards = append(boards, newProjectBoard(apiObj, c.ref))
+	}
+	return boards, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestProjectBoardsClient_List(t *testing.T) {
+	page := func(id, title string, hasNextPage bool, endCursor string) string {
+		return fmt.Sprintf(`{"data":{"organization":{"projectsV2":{"nodes":[{"id":%q,"number":1,"title":%q,"url":"https://github.com/orgs/org/projects/1"}],"pageInfo":{"hasNextPage":%t,"endCursor":%q}}}}}`,
+			id, title, hasNextPage, endCursor)
+	}
+	tests := []struct {
+		name    string
+		baseURL string
+		pages   map[string]string
+		want    []gitprovider.ProjectBoardInfo
+		wantErr error
+	}{
+		{
+			name:    "paginated",
+			baseURL: "/",
+			pages: map[string]string{
+				"":   page("PVT_1", "Roadmap", true, "c1"),
+				"c1": page("PVT_2", "Bugs", false, ""),
+			},
+			want: []gitprovider.ProjectBoardInfo{
+				{ID: "PVT_1", Title: "Roadmap", URL: "https://github.com/orgs/org/projects/1"},
+				{ID: "PVT_2", Title: "Bugs", URL: "https://github.com/orgs/org/projects/1"},
+			},
+		},
+		{
+			name:    "enterprise",
+			baseURL: "/api/v3/",
+			pages: map[string]string{
+				"": page("PVT_1", "Roadmap", false, ""),
+			},
+			want: []gitprovider.ProjectBoardInfo{
+				{ID: "PVT_1", Title: "Roadmap", URL: "https://github.com/orgs/org/projects/1"},
+			},
+		},
+		{
+			name:    "organization not found",
+			baseURL: "/",
+			pages: map[string]string{
+				"": `{"data":{"organization":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to an Organization with the login of 'org'."}]}`,
+			},
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				wantPath := "/graphql"
+				if tt.baseURL != "/" {
+					wantPath = "/api/graphql"
+				}
+				if r.Method != http.MethodPost || r.URL.Path != wantPath {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				req := graphQLRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req.Variables["login"] != "org" {
+					t.Errorf("unexpected login %v", req.Variables["login"])
+				}
+				after, _ := req.Variables["after"].(string)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, tt.pages[after])
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + tt.baseURL)
+			c := &ProjectBoardsClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+				ref:           gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+			}
+
+			boards, err := c.List(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []gitprovider.ProjectBoardInfo
+			for _, b := range boards {
+				got = append(got, b.Get())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// RunnersClient implements the gitprovider.RunnersClient interface.
+var _ gitprovider.RunnersClient = &RunnersClient{}
+
+// RunnersClient handles self-hosted GitHub Actions runners organization-wide.
+type RunnersClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List all self-hosted runners registered for the specific organization.
+// This requires the admin:org scope.
+//
+// ErrForbidden is returned if the credentials lack the permissions to list runners.
+//
+// List returns all available runners, using multiple paginated requests if needed.
+func (c *RunnersClient) List(ctx context.Context) ([]gitprovider.Runner, error) {
+	// GET /orgs/{org}/actions/runners
+	apiObjs, err := c.c.ListOrgRunners(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	runners := make([]gitprovider.Runner, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		runners = append(runners, newRunner(apiObj, c.ref))
+	}
+	return runners, nil
+}
+
+// CreateRegistrationToken generates a token that can be used to register a new self-hosted
+// runner in the specific organization. The token expires after one hour.
+// This requires the admin:org scope. The returned token is a secret, and must not be logged.
+//
+// ErrForbidden is returned if the credentials lack the permissions to create the token.
+func (c *RunnersClient) CreateRegistrationToken(ctx context.Context) (gitprovider.RunnerRegistrationToken, error) {
+	// POST /orgs/{org}/actions/runners/registration-token
+	apiObj, err := c.c.CreateOrgRunnerRegistrationToken(ctx, c.ref.Organization)
+	if err != nil {
+		return gitprovider.RunnerRegistrationToken{}, err
+	}
+
+	var expiresAt *time.Time
+	if apiObj.ExpiresAt != nil {
+		expiresAt = &apiObj.ExpiresAt.Time
+	}
+	return gitprovider.NewRunnerRegistrationToken(*apiObj.Token, expiresAt), nil
+}
+
+// Delete removes the self-hosted runner with the given ID from the specific organization.
+// This requires the admin:org scope.
+//
+// ErrNotFound is returned if the runner does not exist.
+// ErrForbidden is returned if the credentials lack the permissions to delete the runner.
+func (c *RunnersClient) Delete(ctx context.Context, id int64) error {
+	// DELETE /orgs/{org}/actions/runners/{runner_id}
+	return c.c.DeleteOrgRunner(ctx, c.ref.Organization, id)
+}
@@ -18,6 +18,7 @@ package github
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/go-github/v49/github"
 
@@ -40,45 +41,35 @@ type TeamsClient struct {
 //
 // ErrNotFound is returned if the resource does not exist.
 func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Team, error) {
-	// GET /orgs/{org}/teams/{team_slug}/members
-	apiObjs, err := c.c.ListOrgTeamMembers(ctx, c.ref.Organization, teamName)
+	// GET /orgs/{org}/teams/{team_slug}
+	apiObj, err := c.c.GetOrgTeam(ctx, c.ref.Organization, teamName)
 	if err != nil {
 		return nil, err
 	}
-
-	// Collect a list of the members' names. Login is validated to be non-nil in ListOrgTeamMembers.
-	logins := make([]string, 0, len(apiObjs))
-	for _, apiObj := range apiObjs {
-		// Login is validated to be non-nil in ListOrgTeamMembers
-		logins = append(logins, *apiObj.Login)
-	}
-
-	return &team{
-		users: apiObjs,
-		info: gitprovider.TeamInfo{
-			Name:    teamName,
-			Members: logins,
-		},
-		ref: c.ref,
-	}, nil
+	return c.newTeam(ctx, apiObj)
 }
 
 // List all teams (recursively, in terms of subgroups) within the specific organization.
+// Only the top-level teams are returned if the TopLevelOnly option is set.
 //
 // List returns all available organizations, using multiple paginated requests if needed.
-func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
+func (c *TeamsClient) List(ctx context.Context, opts ...gitprovider.TeamListOption) ([]gitprovider.Team, error) {
+	o := gitprovider.MakeTeamListOptions(opts...)
+
 	// GET /orgs/{org}/teams
 	apiObjs, err := c.c.ListOrgTeams(ctx, c.ref.Organization)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use .Get() to get detailed information about each member
+	// Get detailed information about each team (including members)
 	teams := make([]gitprovider.Team, 0, len(apiObjs))
 	for _, apiObj := range apiObjs {
-		// Get detailed information about individual teams (including members).
-		// Slug is validated to be non-nil in ListOrgTeams.
-		team, err := c.Get(ctx, *apiObj.Slug)
+		if o.TopLevelOnly != nil && *o.TopLevelOnly && apiObj.Parent != nil {
+			continue
+		}
+
+		team, err := c.newTeam(ctx, apiObj)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +80,74 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 	return teams, nil
 }
 
+// SetParent moves the team with the given name below the parent team with the given name.
+// If parentName is an empty string, the team is moved to the top level of the organization.
+//
+// ErrNotFound is returned if either team does not exist.
+// ErrInvalidArgument is returned if the move would make the team its own ancestor.
+// ErrForbidden is returned if the credentials lack the permissions to move the team.
+func (c *TeamsClient) SetParent(ctx context.Context, teamName, parentName string) error {
+	// GET /orgs/{org}/teams/{team_slug}
+	apiObj, err := c.c.GetOrgTeam(ctx, c.ref.Organization, teamName)
+	if err != nil {
+		return err
+	}
+	// The team name is required by the API, even though it isn't changed
+	req := &github.NewTeam{Name: apiObj.GetName()}
+
+	if parentName == "" {
+		// PATCH /orgs/{org}/teams/{team_slug}
+		_, err = c.c.EditOrgTeam(ctx, c.ref.Organization, teamName, req, true)
+		return err
+	}
+
+	// Walk up from the new parent, to make sure the team doesn't become its own ancestor.
+	// The parent of a team is only returned one level deep, hence every ancestor is fetched.
+	parent, err := c.c.GetOrgTeam(ctx, c.ref.Organization, parentName)
+	if err != nil {
+		return err
+	}
+	for ancestor := parent; ; {
+		if ancestor.GetID() == apiObj.GetID() {
+			return fmt.Errorf("cannot move team %q below %q, as it would be its own ancestor: %w", teamName, parentName, gitprovider.ErrInvalidArgument)
+		}
+		if ancestor.Parent == nil {
+			break
+		}
+		// GET /orgs/{org}/teams/{team_slug}
+		if ancestor, err = c.c.GetOrgTeam(ctx, c.ref.Organization, ancestor.Parent.GetSlug()); err != nil {
+			return err
+		}
+	}
+
+	req.ParentTeamID = parent.ID
+	// PATCH /orgs/{org}/teams/{team_slug}
+	_, err = c.c.EditOrgTeam(ctx, c.ref.Organization, teamName, req, false)
+	return err
+}
+
+// newTeam fetches the members of the given team, and returns the team with its parent set.
+func (c *TeamsClient) newTeam(ctx context.Context, apiObj *github.Team) (*team, error) {
+	// GET /orgs/{org}/teams/{team_slug}/members
+	// Slug is validated to be non-nil in ListOrgTeams and GetOrgTeam.
+	users, err := c.c.ListOrgTeamMembers(ctx, c.ref.Organization, *apiObj.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect a list of the members' names. Login is validated to be non-nil in ListOrgTeamMembers.
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, *user.Login)
+	}
+
+	return &team{
+		users: users,
+		info:  teamFromAPI(apiObj, logins),
+		ref:   c.ref,
+	}, nil
+}
+
 var _ gitprovider.Team = &team{}
 
 type team struct {
@@ -108,3 +167,14 @@ func (t *team) APIObject() interface{} {
 func (t *team) Organization() gitprovider.OrganizationRef {
 	return t.ref
 }
+
+func teamFromAPI(apiObj *github.Team, members []string) gitprovider.TeamInfo {
+	info := gitprovider.TeamInfo{
+		Name:    apiObj.GetSlug(),
+		Members: members,
+	}
+	if apiObj.Parent != nil {
+		info.Parent = apiObj.Parent.Slug
+	}
+	return info
+}
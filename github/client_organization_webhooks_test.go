@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET /orgs/org/hooks"},
+		},
+		{
+			name: "change events",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventRelease},
+			},
+			wantRequests: []string{"GET /orgs/org/hooks", "PATCH /orgs/org/hooks/1"},
+			wantBody: map[string]interface{}{
+				"config": map[string]interface{}{"url": hookURL, "content_type": "json"},
+				"events": []interface{}{"push", "release"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET /orgs/org/hooks", "POST /orgs/org/hooks"},
+			wantBody: map[string]interface{}{
+				"name":   "web",
+				"active": true,
+				"config": map[string]interface{}{"url": "https://example.com/other", "content_type": "json", "secret": "s3cr3t"},
+				"events": []interface{}{"pull_request", "create", "delete"},
+			},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `[{"id":1,"name":"web","events":["push"],"config":{"url":%q,"content_type":"json","secret":"********"}}]`, hookURL)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 1}
+					for key, value := range body {
+						resp[key] = value
+					}
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &OrganizationWebhookClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref:           gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package github
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/google/go-github/v49/github"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -80,3 +83,80 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
 	return nil, gitprovider.ErrNoProviderSupport
 }
+
+// defaultRepoPermissions maps the default repository permissions of organizations to the
+// values used by GitHub.
+//
+//nolint:gochecknoglobals
+var defaultRepoPermissions = map[gitprovider.RepositoryPermission]string{
+	gitprovider.RepositoryPermissionNone:  "none",
+	gitprovider.RepositoryPermissionPull:  "read",
+	gitprovider.RepositoryPermissionPush:  "write",
+	gitprovider.RepositoryPermissionAdmin: "admin",
+}
+
+// GetDefaultRepositoryPermission returns the base permission of the organization's members on
+// its repositories.
+//
+// ErrForbidden is returned if the caller isn't an owner of the organization.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return "", err
+	}
+
+	// GET /orgs/{org}
+	apiObj, err := c.c.GetOrg(ctx, ref.Organization)
+	if err != nil {
+		return "", err
+	}
+	// GitHub only returns the base permission to owners of the organization
+	if apiObj.DefaultRepoPermission == nil {
+		return "", fmt.Errorf("default repository permission of organization %q isn't visible: %w", ref.Organization, gitprovider.ErrForbidden)
+	}
+	for permission, value := range defaultRepoPermissions {
+		if value == *apiObj.DefaultRepoPermission {
+			return permission, nil
+		}
+	}
+	return "", fmt.Errorf("unknown default repository permission %q: %w", *apiObj.DefaultRepoPermission, gitprovider.ErrInvalidServerData)
+}
+
+// SetDefaultRepositoryPermission sets the base permission of the organization's members on
+// its repositories.
+//
+// ErrForbidden is returned if the caller isn't an owner of the organization.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef, permission gitprovider.RepositoryPermission) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return err
+	}
+	if err := gitprovider.ValidateDefaultRepositoryPermission(permission); err != nil {
+		return fmt.Errorf("invalid default repository permission %q: %w", permission, err)
+	}
+
+	// PATCH /orgs/{org}
+	return c.c.EditOrg(ctx, ref.Organization, &github.Organization{
+		DefaultRepoPermission: github.String(defaultRepoPermissions[permission]),
+	})
+}
+
+// ReconcileDefaultRepositoryPermission makes sure the base permission of the organization's
+// members on its repositories is the given one.
+//
+// If the permission differs from the actual one, it is set (actionTaken == true).
+// If the permission is already the actual one, this is a no-op (actionTaken == false).
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef, permission gitprovider.RepositoryPermission) (bool, error) {
+	if err := gitprovider.ValidateDefaultRepositoryPermission(permission); err != nil {
+		return false, fmt.Errorf("invalid default repository permission %q: %w", permission, err)
+	}
+
+	actual, err := c.GetDefaultRepositoryPermission(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+	if actual == permission {
+		return false, nil
+	}
+	return true, c.SetDefaultRepositoryPermission(ctx, ref, permission)
+}
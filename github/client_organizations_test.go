@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationsClient_DefaultRepositoryPermission(t *testing.T) {
+	tests := []struct {
+		value      string
+		permission gitprovider.RepositoryPermission
+	}{
+		{value: "none", permission: gitprovider.RepositoryPermissionNone},
+		{value: "read", permission: gitprovider.RepositoryPermissionPull},
+		{value: "write", permission: gitprovider.RepositoryPermissionPush},
+		{value: "admin", permission: gitprovider.RepositoryPermissionAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				if r.Method == http.MethodPatch {
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+				}
+				// Report another permission than the wanted one, unless it's read
+				value := "read"
+				if tt.value == "read" {
+					value = "write"
+				}
+				_, _ = fmt.Fprintf(w, `{"login":"org","default_repository_permission":%q}`, value)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &OrganizationsClient{clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"}}
+			ref := gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"}
+
+			actionTaken, err := c.ReconcileDefaultRepositoryPermission(context.Background(), ref, tt.permission)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actionTaken {
+				t.Error("ReconcileDefaultRepositoryPermission() actionTaken = false, want true")
+			}
+			if want := []string{"GET /orgs/org", "PATCH /orgs/org"}; !reflect.DeepEqual(requests, want) {
+				t.Errorf("ReconcileDefaultRepositoryPermission() requests = %v, want %v", requests, want)
+			}
+			if want := map[string]interface{}{"default_repository_permission": tt.value}; !reflect.DeepEqual(body, want) {
+				t.Errorf("ReconcileDefaultRepositoryPermission() body = %v, want %v", body, want)
+			}
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run("get "+tt.value, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"login":"org","default_repository_permission":%q}`, tt.value)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &OrganizationsClient{clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"}}
+			got, err := c.GetDefaultRepositoryPermission(context.Background(), gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.permission {
+				t.Errorf("GetDefaultRepositoryPermission() = %q, want %q", got, tt.permission)
+			}
+		})
+	}
+}
+
+func TestOrganizationsClient_GetDefaultRepositoryPermission_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{
+			name:    "hidden from non-owners",
+			body:    `{"login":"org"}`,
+			wantErr: gitprovider.ErrForbidden,
+		},
+		{
+			name:    "unknown value",
+			body:    `{"login":"org","default_repository_permission":"maintain"}`,
+			wantErr: gitprovider.ErrInvalidServerData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &OrganizationsClient{clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"}}
+			ref := gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"}
+
+			if _, err := c.GetDefaultRepositoryPermission(context.Background(), ref); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetDefaultRepositoryPermission() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := c.ReconcileDefaultRepositoryPermission(context.Background(), ref, gitprovider.RepositoryPermissionPull); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReconcileDefaultRepositoryPermission() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package github
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v49/github"
 
@@ -53,13 +55,21 @@ func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepo
 //
 // List returns all available repositories, using multiple paginated requests if needed.
 func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given organization like List, and calls
+// progress after each page. The total is estimated from the last page in the Link header.
+//
+// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
 	// Make sure the OrganizationRef is valid
 	if err := validateOrganizationRef(ref, c.domain); err != nil {
 		return nil, err
 	}
 
 	// GET /orgs/{org}/repos
-	apiObjs, err := c.c.ListOrgRepos(ctx, ref.Organization)
+	apiObjs, err := c.c.ListOrgRepos(ctx, ref.Organization, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +86,56 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// SearchPage returns the given page of repositories in the given organization, that match
+// all filters set in opts. Pages are numbered from 1, and hold at most perPage repositories.
+//
+// validation.ErrFieldRequired is returned if a filter is set, but empty.
+func (c *OrgRepositoriesClient) SearchPage(ctx context.Context, ref gitprovider.OrganizationRef, perPage, page int, opts ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	o, err := gitprovider.MakeRepositorySearchOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /search/repositories
+	apiObjs, err := c.c.SearchReposPage(ctx, repositorySearchQuery(ref.Organization, o), perPage, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at SearchReposPage
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  *apiObj.Name,
+		}))
+	}
+	return repos, nil
+}
+
+// repositorySearchQuery builds the search query for repositories in the given organization,
+// matching the given filters. Values containing whitespace are quoted.
+func repositorySearchQuery(org string, o gitprovider.RepositorySearchOptions) string {
+	qualifiers := []string{searchQualifier("org", org)}
+	if o.Language != nil {
+		qualifiers = append(qualifiers, searchQualifier("language", *o.Language))
+	}
+	return strings.Join(qualifiers, " ")
+}
+
+func searchQualifier(key, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = strconv.Quote(value)
+	}
+	return key + ":" + value
+}
+
 // Create creates a repository for the given organization, with the data and options.
 //
 // ErrAlreadyExists will be returned if the resource already exists.
@@ -85,11 +145,13 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgR
 		return nil, err
 	}
 
-	apiObj, err := createRepository(ctx, c.c, ref, ref.Organization, req, opts...)
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.c, ref, ref.Organization, req, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return newOrgRepository(c.clientContext, apiObj, ref), nil
+	repo := newOrgRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -120,24 +182,114 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 	return actual, actionTaken, err
 }
 
-func createRepository(ctx context.Context, c githubClient, ref gitprovider.RepositoryRef, orgName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*github.Repository, error) {
+// Transfer transfers the repository to the organization newOwner, keeping its name. GitHub
+// transfers repositories asynchronously, hence Transfer waits until the repository is visible
+// in newOwner, before re-applying opts.TeamAccess.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Transfer(ctx context.Context, ref gitprovider.OrgRepositoryRef, newOwner gitprovider.OrganizationRef, opts ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	// Make sure the references are valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	if err := validateOrganizationRef(newOwner, c.domain); err != nil {
+		return nil, err
+	}
+	o, err := gitprovider.MakeRepositoryTransferOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/transfer
+	if err := c.c.TransferRepo(ctx, ref.Organization, ref.RepositoryName, newOwner.Organization); err != nil {
+		return nil, err
+	}
+	newRef := gitprovider.OrgRepositoryRef{OrganizationRef: newOwner, RepositoryName: ref.RepositoryName}
+	return gitprovider.CompleteRepositoryTransfer(ctx, c, newRef, o)
+}
+
+// createRepository creates the repository, and returns it together with the SHA of the initial
+// commit if the AutoInit option is set. GitHub doesn't return the initial commit when creating the
+// repository, hence it's looked up from the default branch. If the lookup fails, e.g. as the
+// commit isn't visible yet, nil is returned for the SHA.
+func createRepository(ctx context.Context, c githubClient, ref gitprovider.RepositoryRef, orgName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*github.Repository, *string, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
 	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Assemble the options struct based on the given options
 	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Only check the membership if creating in an organization
+	if orgName != "" && o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		if err := checkOrgMembership(ctx, c, orgName); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Convert to the API object and apply the options
 	data := repositoryToAPI(&req, ref)
 	applyRepoCreateOptions(&data, o)
 
-	return c.CreateRepo(ctx, orgName, &data)
+	apiObj, err := c.CreateRepo(ctx, orgName, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	gitprovider.VerifyCreatedRepositoryVisibility(ctx, o.VerifyVisibility, ref, req.Visibility, func(ctx context.Context) (*gitprovider.RepositoryVisibility, error) {
+		// GET /repos/{owner}/{repo}
+		actual, err := c.GetRepo(ctx, ref.GetIdentity(), ref.GetRepository())
+		if err != nil {
+			return nil, err
+		}
+		return repositoryFromAPI(actual).Visibility, nil
+	})
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil, nil
+	}
+
+	// The repository exists at this point, hence don't fail if the lookup does
+	// GET /repos/{owner}/{repo}/git/ref/heads/{branch}
+	headRef, err := c.GetRef(ctx, ref.GetIdentity(), ref.GetRepository(), "heads/"+apiObj.GetDefaultBranch())
+	if err != nil {
+		return apiObj, nil, nil
+	}
+	return apiObj, headRef.Object.SHA, nil
+}
+
+// checkOrgMembership makes sure the authenticated user is allowed to create repositories in the
+// organization. Admins always are, members only if the organization allows it.
+func checkOrgMembership(ctx context.Context, c githubClient, orgName string) error {
+	// GET /user/memberships/orgs/{org}
+	membership, err := c.GetOrgMembership(ctx, orgName)
+	if err != nil && !errors.Is(err, gitprovider.ErrNotFound) {
+		return err
+	}
+	role := ""
+	// Pending invitations don't grant any access yet
+	if err == nil && membership.GetState() == "active" {
+		role = membership.GetRole()
+	}
+
+	requiredRole := "member"
+	if role == "member" {
+		// GET /orgs/{org}
+		org, err := c.GetOrg(ctx, orgName)
+		if err != nil {
+			return err
+		}
+		if org.MembersCanCreateRepos != nil && !*org.MembersCanCreateRepos {
+			requiredRole = "admin"
+		}
+	}
+	if role == "admin" || role == requiredRole {
+		return nil
+	}
+	return &gitprovider.InsufficientMembershipError{Organization: orgName, Role: role, RequiredRole: requiredRole}
 }
 
 func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
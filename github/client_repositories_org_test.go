@@ -0,0 +1,485 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_repositorySearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts gitprovider.RepositorySearchOptions
+		want string
+	}{
+		{
+			name: "no filters",
+			want: "org:fluxcd",
+		},
+		{
+			name: "language",
+			opts: gitprovider.RepositorySearchOptions{Language: gitprovider.StringVar("Go")},
+			want: "org:fluxcd language:Go",
+		},
+		{
+			name: "language with whitespace",
+			opts: gitprovider.RepositorySearchOptions{Language: gitprovider.StringVar("Visual Basic")},
+			want: `org:fluxcd language:"Visual Basic"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repositorySearchQuery("fluxcd", tt.opts); got != tt.want {
+				t.Errorf("repositorySearchQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_subOrganization(t *testing.T) {
+	c := &OrgRepositoriesClient{clientContext: &clientContext{domain: "github.com"}}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{
+			Domain:           "github.com",
+			Organization:     "org",
+			SubOrganizations: []string{"sub"},
+		},
+		RepositoryName: "repo",
+	}
+	if _, err := c.Create(context.Background(), ref, gitprovider.RepositoryInfo{}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestOrgRepositoriesClient_Transfer(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/transfer", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["new_owner"] != "neworg" {
+			t.Errorf("new_owner = %v, want neworg", body["new_owner"])
+		}
+		// The transfer is done in the background
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"name":"repo"}`)
+	})
+	mux.HandleFunc("/repos/neworg/repo", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if gets == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"name":"repo","owner":{"login":"neworg"}}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &OrgRepositoriesClient{clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"}}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	newOwner := gitprovider.OrganizationRef{Domain: "github.com", Organization: "neworg"}
+	interval := time.Millisecond
+
+	res, err := c.Transfer(context.Background(), ref, newOwner, &gitprovider.RepositoryTransferOptions{PollInterval: &interval})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 {
+		t.Errorf("expected 2 lookups, got %d", gets)
+	}
+	if got := res.Repository.Repository().GetIdentity(); got != "neworg" {
+		t.Errorf("repository is owned by %q, want neworg", got)
+	}
+}
+
+func TestOrgRepositoriesClient_Get_emptyResponse(t *testing.T) {
+	// Simulate a proxy answering with an empty 200 response
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	opts, err := gitprovider.MakeClientOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gh := github.NewClient(httpClient)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := newClient(gh, DefaultDomain, false)
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	_, err = c.OrgRepositories().Get(context.Background(), ref)
+	emptyErr := &gitprovider.EmptyResponseError{}
+	if !errors.As(err, &emptyErr) {
+		t.Fatalf("Get() error = %v, want *EmptyResponseError", err)
+	}
+	if want := srv.URL + "/repos/org/repo"; emptyErr.URL != want {
+		t.Errorf("EmptyResponseError.URL = %q, want %q", emptyErr.URL, want)
+	}
+}
+
+func TestEmptyResponseCheck_rawBodies(t *testing.T) {
+	// Empty files, assets and diffs are valid, unlike empty JSON documents
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	opts, err := gitprovider.MakeClientOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gh := github.NewClient(httpClient)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	ctx := &clientContext{c: &githubClientImpl{c: gh}, domain: DefaultDomain}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+		RepositoryName:  "repo",
+	}
+
+	readAll := func(rc io.ReadCloser, err error) (string, error) {
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		return string(b), err
+	}
+	tests := []struct {
+		name string
+		get  func() (string, error)
+	}{
+		{
+			name: "empty file",
+			get: func() (string, error) {
+				return readAll((&FileClient{clientContext: ctx, ref: ref}).GetReader(context.Background(), "empty.txt", "main"))
+			},
+		},
+		{
+			name: "empty asset",
+			get: func() (string, error) {
+				return readAll((&ReleaseClient{clientContext: ctx, ref: ref}).DownloadAsset(context.Background(), gitprovider.ReleaseAsset{ID: 1}))
+			},
+		},
+		{
+			name: "empty diff",
+			get: func() (string, error) {
+				return newPullRequest(ctx, &github.PullRequest{Number: github.Int(1)}, ref).Diff(context.Background())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != "" {
+				t.Errorf("got %q, want an empty body", got)
+			}
+		})
+	}
+}
+
+func TestFileClient_GetReader_size(t *testing.T) {
+	content := strings.Repeat("x", 64)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		opts    []gitprovider.ClientOption
+		wantErr error
+	}{
+		{
+			name: "larger than the max response size",
+			opts: []gitprovider.ClientOption{gitprovider.WithMaxResponseSize(8)},
+		},
+		{
+			name:    "larger than the max download size",
+			opts:    []gitprovider.ClientOption{gitprovider.WithMaxDownloadSize(8)},
+			wantErr: gitprovider.ErrResponseTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := gitprovider.MakeClientOptions(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			httpClient, err := opts.BuildHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			gh := github.NewClient(httpClient)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &FileClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: DefaultDomain},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			rc, err := c.GetReader(context.Background(), "file.txt", "main")
+			var body []byte
+			if err == nil {
+				body, err = io.ReadAll(rc)
+				rc.Close()
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && string(body) != content {
+				t.Errorf("GetReader() = %q, want %q", body, content)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_checkOrgMembership(t *testing.T) {
+	tests := []struct {
+		name       string
+		membership string
+		org        string
+		wantErr    *gitprovider.InsufficientMembershipError
+	}{
+		{
+			name:    "not a member",
+			wantErr: &gitprovider.InsufficientMembershipError{Organization: "org", RequiredRole: "member"},
+		},
+		{
+			name:       "pending invitation",
+			membership: `{"state":"pending","role":"member"}`,
+			wantErr:    &gitprovider.InsufficientMembershipError{Organization: "org", RequiredRole: "member"},
+		},
+		{
+			name:       "member of restricted organization",
+			membership: `{"state":"active","role":"member"}`,
+			org:        `{"login":"org","members_can_create_repositories":false}`,
+			wantErr:    &gitprovider.InsufficientMembershipError{Organization: "org", Role: "member", RequiredRole: "admin"},
+		},
+		{
+			name:       "admin",
+			membership: `{"state":"active","role":"admin"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("/user/memberships/orgs/org", func(w http.ResponseWriter, r *http.Request) {
+				if tt.membership == "" {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, tt.membership)
+			})
+			mux.HandleFunc("/orgs/org", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, tt.org)
+			})
+			mux.HandleFunc("/orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+				created = true
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprint(w, `{"id":1,"name":"repo","full_name":"org/repo","visibility":"private","default_branch":"main"}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+				RepositoryName:  "repo",
+			}
+			_, err := c.OrgRepositories().Create(context.Background(), ref, gitprovider.RepositoryInfo{},
+				&gitprovider.RepositoryCreateOptions{CheckOrgMembership: gitprovider.BoolVar(true)})
+			if tt.wantErr == nil {
+				if err != nil || !created {
+					t.Fatalf("Create() error = %v, created = %v", err, created)
+				}
+				return
+			}
+			if !errors.Is(err, gitprovider.ErrForbidden) {
+				t.Fatalf("Create() error = %v, want ErrForbidden", err)
+			}
+			gotErr := &gitprovider.InsufficientMembershipError{}
+			if !errors.As(err, &gotErr) || *gotErr != *tt.wantErr {
+				t.Errorf("Create() error = %#v, want %#v", err, tt.wantErr)
+			}
+			if created {
+				t.Errorf("repository was created")
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_initialCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantSHA *string
+	}{
+		{
+			name:    "initial commit",
+			status:  http.StatusOK,
+			wantSHA: gitprovider.StringVar("abc123"),
+		},
+		{
+			name:   "initial commit not visible yet",
+			status: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprint(w, `{"id":1,"name":"repo","full_name":"org/repo","visibility":"private","default_branch":"main"}`)
+			})
+			mux.HandleFunc("/repos/org/repo/git/ref/heads/main", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusOK {
+					_, _ = fmt.Fprint(w, `{"message":"Git Repository is empty."}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"ref":"refs/heads/main","object":{"type":"commit","sha":"abc123"}}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+				RepositoryName:  "repo",
+			}
+			repo, err := c.OrgRepositories().Create(context.Background(), ref, gitprovider.RepositoryInfo{},
+				&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantSHA, repo.InitialCommitSHA()); d != "" {
+				t.Errorf("InitialCommitSHA() returned diff (want -> got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_ListWithProgress(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page != "3" {
+			next := map[string]string{"1": "2", "2": "3"}[page]
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/org/repos?page=%s>; rel="next", <%s/orgs/org/repos?page=3>; rel="last"`, srvURL, next, srvURL))
+		}
+		repos := []map[string]interface{}{}
+		n := 2
+		if page == "3" {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			repos = append(repos, map[string]interface{}{"id": 1, "name": fmt.Sprintf("repo-%s-%d", page, i), "full_name": "org/repo", "visibility": "public", "default_branch": "main"})
+		}
+		_ = json.NewEncoder(w).Encode(repos)
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := newClient(gh, DefaultDomain, false)
+	ref := gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"}
+
+	t.Run("progress", func(t *testing.T) {
+		var got []gitprovider.ListProgress
+		repos, err := c.OrgRepositories().ListWithProgress(context.Background(), ref, func(p gitprovider.ListProgress) {
+			got = append(got, p)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(repos) != 5 {
+			t.Errorf("got %d repositories, want 5", len(repos))
+		}
+		want := []gitprovider.ListProgress{{Count: 2, EstimatedTotal: 6}, {Count: 4, EstimatedTotal: 6}, {Count: 5, EstimatedTotal: 5}}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("progress = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("cancelled mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := 0
+		_, err := c.OrgRepositories().ListWithProgress(ctx, ref, func(p gitprovider.ListProgress) {
+			calls++
+			cancel()
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ListWithProgress() error = %v, want context.Canceled", err)
+		}
+		if calls != 1 {
+			t.Errorf("progress was called %d times, want 1", calls)
+		}
+	})
+}
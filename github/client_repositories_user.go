@@ -87,11 +87,13 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 		return nil, err
 	}
 
-	apiObj, err := createRepository(ctx, c.c, ref, "", req, opts...)
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.c, ref, "", req, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return newUserRepository(c.clientContext, apiObj, ref), nil
+	repo := newUserRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
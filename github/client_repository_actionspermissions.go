@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ActionsPermissionsClient implements the gitprovider.ActionsPermissionsClient interface.
+var _ gitprovider.ActionsPermissionsClient = &ActionsPermissionsClient{}
+
+// ActionsPermissionsClient operates on the GitHub Actions permissions of a specific repository.
+type ActionsPermissionsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the actions permissions policy of the repository.
+// If GitHub Actions are disabled for the repository, AllowedActions is empty.
+func (c *ActionsPermissionsClient) Get(ctx context.Context) (gitprovider.ActionsPermissionsInfo, error) {
+	// GET /repos/{owner}/{repo}/actions/permissions
+	apiObj, err := c.c.GetRepoActionsPermissions(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.ActionsPermissionsInfo{}, err
+	}
+
+	info := gitprovider.ActionsPermissionsInfo{
+		AllowedActions: gitprovider.AllowedActions(apiObj.GetAllowedActions()),
+	}
+	if info.AllowedActions != gitprovider.AllowedActionsSelected {
+		return info, nil
+	}
+
+	// GET /repos/{owner}/{repo}/actions/permissions/selected-actions
+	allowed, err := c.c.GetRepoActionsAllowed(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.ActionsPermissionsInfo{}, err
+	}
+	info.Patterns = allowed.PatternsAllowed
+	return info, nil
+}
+
+// Set applies the given actions permissions policy to the repository, enabling GitHub Actions
+// if needed. Only the patterns of the selected-actions policy are changed; whether actions
+// created by GitHub or verified creators are allowed is left as-is.
+func (c *ActionsPermissionsClient) Set(ctx context.Context, req gitprovider.ActionsPermissionsInfo) error {
+	// Make sure the request is valid
+	if err := req.ValidateInfo(); err != nil {
+		return err
+	}
+
+	// PUT /repos/{owner}/{repo}/actions/permissions
+	err := c.c.EditRepoActionsPermissions(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), github.ActionsPermissionsRepository{
+		Enabled:        gitprovider.BoolVar(true),
+		AllowedActions: github.String(string(req.AllowedActions)),
+	})
+	if err != nil || req.AllowedActions != gitprovider.AllowedActionsSelected {
+		return err
+	}
+
+	// PUT /repos/{owner}/{repo}/actions/permissions/selected-actions
+	return c.c.EditRepoActionsAllowed(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), github.ActionsAllowed{
+		PatternsAllowed: req.Patterns,
+	})
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't equal the actual state, the policy will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *ActionsPermissionsClient) Reconcile(ctx context.Context, req gitprovider.ActionsPermissionsInfo) (bool, error) {
+	// Make sure the request is valid
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	actual, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	// If the mode and patterns match, there is nothing to do
+	if req.Equals(actual) {
+		return false, nil
+	}
+	return true, c.Set(ctx, req)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/google/go-github/v49/github"
 )
 
@@ -50,3 +51,51 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 
 	return nil
 }
+
+// List lists all branches of the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.BranchInfo, error) {
+	// GET /repos/{owner}/{repo}/branches
+	apiObjs, err := c.c.ListBranches(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.BranchInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListBranches
+		branches = append(branches, gitprovider.BranchInfo{
+			Name: apiObj.GetName(),
+			Sha:  apiObj.GetCommit().GetSHA(),
+		})
+	}
+	return branches, nil
+}
+
+// Delete deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// DELETE /repos/{owner}/{repo}/git/refs/heads/{branch}
+	return c.c.DeleteRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), "heads/"+branch)
+}
+
+// Rename renames the given branch to newName. GitHub moves the default branch along with it.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Rename(ctx context.Context, branch, newName string) error {
+	// POST /repos/{owner}/{repo}/branches/{branch}/rename
+	return c.c.RenameBranch(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, newName)
+}
+
+func validateBranchAPI(apiObj *github.Branch) error {
+	return validateAPIObject("GitHub.Branch", func(validator validation.Validator) {
+		if apiObj.Name == nil {
+			validator.Required("Name")
+		}
+		if apiObj.GetCommit().SHA == nil {
+			validator.Required("Commit.SHA")
+		}
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchProtectionClient implements the gitprovider.BranchProtectionClient interface.
+var _ gitprovider.BranchProtectionClient = &BranchProtectionClient{}
+
+// BranchProtectionClient operates on the branch protection rules of a specific repository.
+type BranchProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protection rule of the given branch.
+//
+// ErrNotFound is returned if the branch isn't protected.
+func (c *BranchProtectionClient) Get(ctx context.Context, branch string) (gitprovider.BranchProtection, error) {
+	bp, err := c.get(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+func (c *BranchProtectionClient) get(ctx context.Context, branch string) (*branchProtection, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, err := c.c.GetBranchProtection(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if err != nil {
+		return nil, err
+	}
+	return newBranchProtection(c, branch, apiObj), nil
+}
+
+// List lists the protection rules of all protected branches of the repository.
+//
+// List returns all available protection rules, using multiple paginated requests if needed.
+func (c *BranchProtectionClient) List(ctx context.Context) ([]gitprovider.BranchProtection, error) {
+	// GET /repos/{owner}/{repo}/branches?protected=true
+	apiObjs, err := c.c.ListProtectedBranches(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// The branches only tell whether they are protected, hence get the rule of each of them
+	protections := make([]gitprovider.BranchProtection, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		bp, err := c.get(ctx, apiObj.GetName())
+		if err != nil {
+			return nil, err
+		}
+		protections = append(protections, bp)
+	}
+	return protections, nil
+}
+
+// Create protects a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch is already protected.
+func (c *BranchProtectionClient) Create(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateBranchProtectionSupport(req); err != nil {
+		return nil, err
+	}
+
+	// GitHub replaces existing rules, hence make sure the branch isn't protected yet
+	if _, err := c.get(ctx, req.Branch); err == nil {
+		return nil, fmt.Errorf("branch %q: %w", req.Branch, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	return c.create(ctx, req)
+}
+
+func (c *BranchProtectionClient) create(ctx context.Context, req gitprovider.BranchProtectionInfo) (*branchProtection, error) {
+	bp := newBranchProtection(c, req.Branch, nil)
+	if err := bp.Set(req); err != nil {
+		return nil, err
+	}
+	// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+	if err := bp.Update(ctx); err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing protection rule is matched by its branch.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *BranchProtectionClient) Reconcile(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+	if err := validateBranchProtectionSupport(req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.get(ctx, req.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.create(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// ValidateCodeOwners returns the syntax errors of the CODEOWNERS file on the default branch.
+//
+// ErrNotFound is returned if the repository has no CODEOWNERS file.
+func (c *BranchProtectionClient) ValidateCodeOwners(ctx context.Context) ([]gitprovider.CodeOwnersError, error) {
+	// GET /repos/{owner}/{repo}/codeowners/errors
+	apiObjs, err := c.c.GetCodeownersErrors(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	codeOwnersErrors := make([]gitprovider.CodeOwnersError, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		codeOwnersErrors = append(codeOwnersErrors, gitprovider.CodeOwnersError{
+			Path:    apiObj.Path,
+			Line:    apiObj.Line,
+			Column:  apiObj.Column,
+			Kind:    apiObj.Kind,
+			Message: apiObj.Message,
+		})
+	}
+	return codeOwnersErrors, nil
+}
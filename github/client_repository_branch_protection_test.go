@@ -0,0 +1,311 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchProtectionClient_Reconcile(t *testing.T) {
+	const mainProtection = `{
+		"required_status_checks": {"strict": true, "contexts": ["ci"], "checks": [{"context": "ci", "app_id": 15368}]},
+		"required_pull_request_reviews": {"dismiss_stale_reviews": true, "required_approving_review_count": 1},
+		"enforce_admins": {"enabled": true},
+		"restrictions": {"users": [{"login": "alice"}], "teams": [{"slug": "maintainers"}], "apps": []},
+		"required_linear_history": {"enabled": true},
+		"allow_force_pushes": {"enabled": false},
+		"allow_deletions": {"enabled": false},
+		"required_conversation_resolution": {"enabled": false}
+	}`
+	// Only alice may push to release
+	const releaseProtection = `{
+		"enforce_admins": {"enabled": false},
+		"restrictions": {"users": [{"login": "alice"}], "teams": [], "apps": []}
+	}`
+	tests := []struct {
+		name            string
+		req             gitprovider.BranchProtectionInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.BranchProtectionInfo{
+				Branch:               "main",
+				RequiredApprovals:    gitprovider.IntVar(1),
+				RequiredStatusChecks: gitprovider.StatusChecks("ci"),
+				EnforceAdmins:        gitprovider.BoolVar(true),
+				AllowedPushers:       []string{"Alice"},
+			},
+			wantRequests: []string{"GET /repos/org/repo/branches/main/protection"},
+		},
+		{
+			name: "change approvals, checks and pushers, keeping the other settings",
+			req: gitprovider.BranchProtectionInfo{
+				Branch:               "main",
+				RequiredApprovals:    gitprovider.IntVar(2),
+				RequiredStatusChecks: []gitprovider.StatusCheck{{Context: "ci"}, {Context: "lint", AppID: gitprovider.Int64Var(42)}},
+				EnforceAdmins:        gitprovider.BoolVar(true),
+				AllowedPushers:       []string{"alice", "bob"},
+			},
+			wantRequests: []string{"GET /repos/org/repo/branches/main/protection", "PUT /repos/org/repo/branches/main/protection"},
+			wantBody: map[string]interface{}{
+				"required_status_checks": map[string]interface{}{
+					"strict": true,
+					"checks": []interface{}{
+						map[string]interface{}{"context": "ci", "app_id": float64(15368)},
+						map[string]interface{}{"context": "lint", "app_id": float64(42)},
+					},
+				},
+				"required_pull_request_reviews": map[string]interface{}{
+					"dismiss_stale_reviews":           true,
+					"require_code_owner_reviews":      false,
+					"required_approving_review_count": float64(2),
+				},
+				"enforce_admins":                   true,
+				"restrictions":                     map[string]interface{}{"users": []interface{}{"alice", "bob"}, "teams": []interface{}{"maintainers"}, "apps": nil},
+				"required_linear_history":          true,
+				"allow_force_pushes":               false,
+				"allow_deletions":                  false,
+				"required_conversation_resolution": false,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name:         "remove the pushers",
+			req:          gitprovider.BranchProtectionInfo{Branch: "release"},
+			wantRequests: []string{"GET /repos/org/repo/branches/release/protection", "PUT /repos/org/repo/branches/release/protection"},
+			wantBody: map[string]interface{}{
+				"required_status_checks":        nil,
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                false,
+				"restrictions":                  nil,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name:         "require code owner reviews without approvals",
+			req:          gitprovider.BranchProtectionInfo{Branch: "dev", RequireCodeOwnerReviews: gitprovider.BoolVar(true)},
+			wantRequests: []string{"GET /repos/org/repo/branches/dev/protection", "PUT /repos/org/repo/branches/dev/protection"},
+			wantBody: map[string]interface{}{
+				"required_status_checks": nil,
+				"required_pull_request_reviews": map[string]interface{}{
+					"dismiss_stale_reviews":           false,
+					"require_code_owner_reviews":      true,
+					"required_approving_review_count": float64(0),
+				},
+				"enforce_admins": false,
+				"restrictions":   nil,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name:         "protect an unprotected branch",
+			req:          gitprovider.BranchProtectionInfo{Branch: "dev"},
+			wantRequests: []string{"GET /repos/org/repo/branches/dev/protection", "PUT /repos/org/repo/branches/dev/protection"},
+			wantBody: map[string]interface{}{
+				"required_status_checks":        nil,
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                false,
+				"restrictions":                  nil,
+			},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch {
+				case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/main/"):
+					_, _ = fmt.Fprint(w, mainProtection)
+				case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/release/"):
+					_, _ = fmt.Fprint(w, releaseProtection)
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"Branch not protected"}`)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					_ = json.NewEncoder(w).Encode(protectionResponse(body))
+				}
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &BranchProtectionClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionClient_Reconcile_pattern(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &BranchProtectionClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	_, _, err := c.Reconcile(context.Background(), gitprovider.BranchProtectionInfo{Branch: "release/*"})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Reconcile() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if len(requests) != 0 {
+		t.Errorf("Reconcile() requests = %v, want none", requests)
+	}
+}
+
+func TestBranchProtectionClient_ValidateCodeOwners(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    []gitprovider.CodeOwnersError
+		wantErr error
+	}{
+		{
+			name: "valid",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"errors":[]}`)
+			},
+			want: []gitprovider.CodeOwnersError{},
+		},
+		{
+			name: "unknown owner",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"errors":[{"line":3,"column":7,"kind":"Unknown owner","source":"*.go @ghost","message":"Unknown owner on line 3","path":".github/CODEOWNERS"}]}`)
+			},
+			want: []gitprovider.CodeOwnersError{
+				{Path: ".github/CODEOWNERS", Line: 3, Column: 7, Kind: "Unknown owner", Message: "Unknown owner on line 3"},
+			},
+		},
+		{
+			name: "no CODEOWNERS file",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
+			},
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				tt.handler(w, r)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &BranchProtectionClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			got, err := c.ValidateCodeOwners(context.Background())
+			if wantRequests := []string{"GET /repos/org/repo/codeowners/errors"}; !reflect.DeepEqual(requests, wantRequests) {
+				t.Errorf("ValidateCodeOwners() requests = %v, want %v", requests, wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ValidateCodeOwners() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateCodeOwners() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// protectionResponse maps the body of a request setting the branch protection to the
+// protection GitHub responds with.
+func protectionResponse(req map[string]interface{}) map[string]interface{} {
+	resp := map[string]interface{}{
+		"required_status_checks":        req["required_status_checks"],
+		"required_pull_request_reviews": req["required_pull_request_reviews"],
+		"enforce_admins":                map[string]interface{}{"enabled": req["enforce_admins"]},
+	}
+	if restrictions, ok := req["restrictions"].(map[string]interface{}); ok {
+		users := []interface{}{}
+		for _, login := range restrictions["users"].([]interface{}) {
+			users = append(users, map[string]interface{}{"login": login})
+		}
+		resp["restrictions"] = map[string]interface{}{"users": users}
+	}
+	return resp
+}
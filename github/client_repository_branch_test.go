@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchClient_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		wantErr error
+	}{
+		{
+			name:   "existing branch",
+			branch: "dev",
+		},
+		{
+			name:    "missing branch",
+			branch:  "missing",
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != fmt.Sprintf("/repos/org/repo/git/refs/heads/%s", tt.branch) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if tt.branch == "missing" {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = fmt.Fprint(w, `{"message":"Reference does not exist"}`)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &BranchClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			err := c.Delete(context.Background(), tt.branch)
+			if tt.wantErr == nil && err != nil {
+				t.Fatal(err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
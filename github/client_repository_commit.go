@@ -69,7 +69,20 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+//
+// GitHub signs commits created through the API itself if no custom author or committer is given,
+// and the client is authenticated as a GitHub App. As no committer is set here, the
+// ProviderSigned option is accepted, and the outcome is reported in the Verification field of
+// the returned commit. Note that setting the Author option prevents GitHub from signing the commit.
+//
+// If the Author option is set, it is recorded as the author of the commit, while the
+// authenticated user remains the committer.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	o, err := gitprovider.MakeCommitCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	author := commitAuthorToAPI(o.Author)
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
@@ -89,6 +102,9 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 	if err != nil {
 		return nil, err
 	}
+	if len(commits) == 0 {
+		return c.createInitial(ctx, branch, message, author, treeEntries)
+	}
 
 	latestCommitTreeSHA := commits[0].Get().TreeSha
 
@@ -101,6 +117,7 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 	nCommit, _, err := c.c.Client().Git.CreateCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Commit{
 		Message: &message,
 		Tree:    tree,
+		Author:  author,
 		Parents: []*github.Commit{
 			{
 				SHA: &latestCommitSHA,
@@ -125,3 +142,87 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 
 	return newCommit(c, nCommit), nil
 }
+
+// createInitial creates the first commit of an empty repository, which also creates the branch.
+//
+// GitHub's Git database API doesn't work on empty repositories, hence the first file is created
+// through the contents API, bootstrapping the branch. A root commit with all files then replaces
+// the bootstrap commit, so the branch ends up with a single commit.
+func (c *CommitClient) createInitial(ctx context.Context, branch, message string, author *github.CommitAuthor, treeEntries []*github.TreeEntry) (gitprovider.Commit, error) {
+	// Files can't be deleted from an empty repository
+	entries := make([]*github.TreeEntry, 0, len(treeEntries))
+	for _, entry := range treeEntries {
+		if entry.Content != nil {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	// PUT /repos/{owner}/{repo}/contents/{path}
+	if _, _, err := c.c.Client().Repositories.CreateFile(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *entries[0].Path, &github.RepositoryContentFileOptions{
+		Message: &message,
+		Content: []byte(*entries[0].Content),
+		Branch:  &branch,
+		Author:  author,
+	}); err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	// POST /repos/{owner}/{repo}/git/trees
+	tree, _, err := c.c.Client().Git.CreateTree(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), "", entries)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	// POST /repos/{owner}/{repo}/git/commits
+	nCommit, _, err := c.c.Client().Git.CreateCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Commit{
+		Message: &message,
+		Tree:    tree,
+		Author:  author,
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	// PATCH /repos/{owner}/{repo}/git/refs/heads/{branch}
+	ref := "refs/heads/" + branch
+	if _, _, err := c.c.Client().Git.UpdateRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Reference{
+		Ref:    &ref,
+		Object: &github.GitObject{SHA: nCommit.SHA},
+	}, true); err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	return newCommit(c, nCommit), nil
+}
+
+// ListComments lists all comments made on the commit with the given sha, including inline comments.
+//
+// ListComments returns all available comments, using multiple paginated requests if needed.
+func (c *CommitClient) ListComments(ctx context.Context, sha string) ([]gitprovider.CommitComment, error) {
+	// GET /repos/{owner}/{repo}/commits/{commit_sha}/comments
+	apiObjs, err := c.c.ListCommitComments(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]gitprovider.CommitComment, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		comments = append(comments, newCommitComment(c, apiObj))
+	}
+	return comments, nil
+}
+
+// commitAuthorToAPI converts the requested author to the API object, leaving it nil if no
+// author was requested, so that GitHub defaults to the authenticated user.
+func commitAuthorToAPI(author *gitprovider.CommitAuthor) *github.CommitAuthor {
+	if author == nil {
+		return nil
+	}
+	return &github.CommitAuthor{
+		Name:  &author.Name,
+		Email: &author.Email,
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestCommitClient_Create_Author(t *testing.T) {
+	var author *github.CommitAuthor
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "GET /repos/org/repo/commits":
+			_, _ = fmt.Fprint(w, `[{"sha":"parent","commit":{"tree":{"sha":"parent-tree"},"author":{"name":"user","date":"2023-01-01T00:00:00Z"},"message":"first"},"html_url":"https://example.com"}]`)
+		case "POST /repos/org/repo/git/trees":
+			_, _ = fmt.Fprint(w, `{"sha":"tree"}`)
+		case "POST /repos/org/repo/git/commits":
+			var body struct {
+				Author *github.CommitAuthor `json:"author"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			author = body.Author
+			_, _ = fmt.Fprint(w, `{"sha":"commit","tree":{"sha":"tree"},"author":{"name":"Flux","email":"flux@example.com","date":"2023-01-01T00:00:00Z"},"message":"second","url":"https://example.com"}`)
+		case "PATCH /repos/org/repo/git/refs/heads/main":
+			_, _ = fmt.Fprint(w, `{"ref":"refs/heads/main","object":{"sha":"commit"}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &CommitClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	files := []gitprovider.CommitFile{{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("hello")}}
+	commit, err := c.Create(context.Background(), "main", "second", files, &gitprovider.CommitCreateOptions{
+		Author: &gitprovider.CommitAuthor{Name: "Flux", Email: "flux@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if author.GetName() != "Flux" || author.GetEmail() != "flux@example.com" {
+		t.Errorf("requested author = %q <%s>, want %q <%s>", author.GetName(), author.GetEmail(), "Flux", "flux@example.com")
+	}
+	if got := commit.Get(); got.Author != "Flux" || got.AuthorEmail != "flux@example.com" {
+		t.Errorf("commit author = %q <%s>, want %q <%s>", got.Author, got.AuthorEmail, "Flux", "flux@example.com")
+	}
+}
+
+func TestCommitClient_ListComments_perPage(t *testing.T) {
+	var perPages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/commits/abc/comments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		perPages = append(perPages, r.URL.Query().Get("per_page"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=1>; rel="next"`, "http://"+r.Host, r.URL.Path))
+			_, _ = fmt.Fprint(w, `[{"id":1,"body":"first","path":"main.go","line":3}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id":2,"body":"second"}]`)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &CommitClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	perPage := 1
+	ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{PerPage: &perPage})
+	comments, err := c.ListComments(ctx, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("ListComments() returned %d comments, want 2", len(comments))
+	}
+	if got := comments[0].Get(); got.Path != "main.go" || got.Line != 3 {
+		t.Errorf("first comment = %+v, want it on main.go:3", got)
+	}
+	if len(perPages) != 2 || perPages[0] != "1" || perPages[1] != "1" {
+		t.Errorf("per_page of the requests = %v, want [1 1]", perPages)
+	}
+}
@@ -59,7 +59,7 @@ func (c *DeployKeyClient) get(ctx context.Context, name string) (*deployKey, err
 //
 // List returns all available repository deploy keys for the given type,
 // using multiple paginated requests if needed.
-func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, error) {
+func (c *DeployKeyClient) List(ctx context.Context, opts ...gitprovider.DeployKeyListOption) ([]gitprovider.DeployKey, error) {
 	dks, err := c.list(ctx)
 	if err != nil {
 		return nil, err
@@ -69,6 +69,7 @@ func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, er
 	for _, dk := range dks {
 		keys = append(keys, dk)
 	}
+	gitprovider.SortDeployKeys(keys, gitprovider.MakeDeployKeyListOptions(opts...))
 	return keys, nil
 }
 
@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// DeployTokenClient implements the gitprovider.DeployTokenClient interface.
+var _ gitprovider.DeployTokenClient = &DeployTokenClient{}
+
+// DeployTokenClient handles deploy tokens, which are not available in GitHub.
+type DeployTokenClient struct{}
+
+// Get always returns ErrNoProviderSupport, as GitHub has no deploy tokens (the closest equivalent is a fine-grained personal access token).
+func (c *DeployTokenClient) Get(_ context.Context, _ string) (gitprovider.DeployToken, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as GitHub has no deploy tokens (the closest equivalent is a fine-grained personal access token).
+func (c *DeployTokenClient) List(_ context.Context) ([]gitprovider.DeployToken, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as GitHub has no deploy tokens (the closest equivalent is a fine-grained personal access token).
+func (c *DeployTokenClient) Create(_ context.Context, _ gitprovider.DeployTokenInfo) (gitprovider.DeployToken, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
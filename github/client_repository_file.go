@@ -81,3 +81,10 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 
 	return files, nil
 }
+
+// GetReader streams the raw contents of the file at path on the given ref.
+// The caller is responsible for closing the returned reader. The size of the file isn't limited
+// by WithMaxResponseSize, but by WithMaxDownloadSize, if given.
+func (c *FileClient) GetReader(ctx context.Context, path, ref string) (io.ReadCloser, error) {
+	return c.c.GetRawFile(gitprovider.WithDownload(ctx), c.ref.GetIdentity(), c.ref.GetRepository(), path, ref)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// LabelClient implements the gitprovider.LabelClient interface.
+var _ gitprovider.LabelClient = &LabelClient{}
+
+// LabelClient operates on the labels of a specific repository.
+type LabelClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the label with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *LabelClient) Get(ctx context.Context, name string) (gitprovider.Label, error) {
+	labels, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		if l.l.GetName() == name {
+			return l, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all labels of the repository.
+//
+// List returns all available labels, using multiple paginated requests if needed.
+func (c *LabelClient) List(ctx context.Context) ([]gitprovider.Label, error) {
+	ls, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.Label
+	labels := make([]gitprovider.Label, 0, len(ls))
+	for _, l := range ls {
+		labels = append(labels, l)
+	}
+	return labels, nil
+}
+
+func (c *LabelClient) list(ctx context.Context) ([]*label, error) {
+	// GET /repos/{owner}/{repo}/labels
+	apiObjs, err := c.c.ListLabels(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Label type
+	labels := make([]*label, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListLabels
+		labels = append(labels, newLabel(c, apiObj))
+	}
+	return labels, nil
+}
+
+// Create creates a label with the given specifications.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *LabelClient) Create(ctx context.Context, req gitprovider.LabelInfo) (gitprovider.Label, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/labels
+	apiObj, err := c.c.CreateLabel(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), labelToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newLabel(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing label is found as described in gitprovider.LabelReconcileOptions.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *LabelClient) Reconcile(ctx context.Context, req gitprovider.LabelInfo, opts ...gitprovider.LabelReconcileOption) (gitprovider.Label, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	labels, err := c.List(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	actual := gitprovider.MatchLabel(labels, req.Name, gitprovider.MakeLabelReconcileOptions(opts...))
+	if actual == nil {
+		resp, err := c.Create(ctx, req)
+		return resp, true, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
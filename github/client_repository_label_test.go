@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestLabelClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.LabelInfo
+		opts            []gitprovider.LabelReconcileOption
+		wantRequests    []string
+		wantBody        map[string]string
+		wantActionTaken bool
+	}{
+		{
+			name:         "up to date",
+			req:          gitprovider.LabelInfo{Name: "bug", Color: gitprovider.StringVar("FF0000")},
+			wantRequests: []string{"GET /repos/org/repo/labels"},
+		},
+		{
+			name:            "recolor",
+			req:             gitprovider.LabelInfo{Name: "bug", Color: gitprovider.StringVar("00ff00")},
+			wantRequests:    []string{"GET /repos/org/repo/labels", "PATCH /repos/org/repo/labels/bug"},
+			wantBody:        map[string]string{"new_name": "bug", "color": "00ff00", "description": "Something isn't working"},
+			wantActionTaken: true,
+		},
+		{
+			name:            "rename by previous name",
+			req:             gitprovider.LabelInfo{Name: "kind/bug"},
+			opts:            []gitprovider.LabelReconcileOption{&gitprovider.LabelReconcileOptions{PreviousName: gitprovider.StringVar("bug")}},
+			wantRequests:    []string{"GET /repos/org/repo/labels", "PATCH /repos/org/repo/labels/bug"},
+			wantBody:        map[string]string{"new_name": "kind/bug", "color": "ff0000", "description": "Something isn't working"},
+			wantActionTaken: true,
+		},
+		{
+			name:            "rename and recolor by ID",
+			req:             gitprovider.LabelInfo{Name: "kind/bug", Color: gitprovider.StringVar("00ff00")},
+			opts:            []gitprovider.LabelReconcileOption{&gitprovider.LabelReconcileOptions{ID: gitprovider.StringVar("1")}},
+			wantRequests:    []string{"GET /repos/org/repo/labels", "PATCH /repos/org/repo/labels/bug"},
+			wantBody:        map[string]string{"new_name": "kind/bug", "color": "00ff00", "description": "Something isn't working"},
+			wantActionTaken: true,
+		},
+		{
+			name:            "create without a match",
+			req:             gitprovider.LabelInfo{Name: "kind/bug"},
+			wantRequests:    []string{"GET /repos/org/repo/labels", "POST /repos/org/repo/labels"},
+			wantBody:        map[string]string{"name": "kind/bug"},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprint(w, `[{"id":1,"name":"bug","color":"ff0000","description":"Something isn't working"},{"id":2,"name":"docs","color":"0000ff"}]`)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					name := body["name"]
+					if name == "" {
+						name = body["new_name"]
+					}
+					_, _ = fmt.Fprintf(w, `{"id":1,"name":%q,"color":%q}`, name, body["color"])
+				}
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &LabelClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if resp.Get().Name != tt.req.Name {
+				t.Errorf("Reconcile() name = %q, want %q", resp.Get().Name, tt.req.Name)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package github
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v49/github"
@@ -32,30 +33,84 @@ type PullRequestClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// List lists all pull requests in the repository
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
-	prs, _, err := c.c.Client().PullRequests.List(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), nil)
+// List lists the pull requests in the repository, by default the open ones.
+//
+// The state, base and source branch are filtered by GitHub, the other filters are applied to the
+// returned pull requests. The source branch only matches pull requests from the repository itself,
+// not from forks.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
 	if err != nil {
 		return nil, err
 	}
+	listOpts := &github.PullRequestListOptions{State: "open"}
+	if o.State != nil && *o.State != gitprovider.PullRequestStateOpen {
+		// merged pull requests are closed as well, and told apart below
+		listOpts.State = "closed"
+	}
+	if o.BaseBranch != nil {
+		listOpts.Base = *o.BaseBranch
+	}
+	if o.SourceBranch != nil {
+		listOpts.Head = c.ref.GetIdentity() + ":" + *o.SourceBranch
+	}
 
-	requests := make([]gitprovider.PullRequest, len(prs))
+	// GET /repos/{owner}/{repo}/pulls
+	prs, err := c.c.ListPullRequests(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), listOpts)
+	if err != nil {
+		return nil, err
+	}
 
-	for idx, pr := range prs {
-		requests[idx] = newPullRequest(c.clientContext, pr)
+	requests := make([]gitprovider.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if matchesListOptions(pr, o) {
+			requests = append(requests, newPullRequest(c.clientContext, pr, c.ref))
+		}
 	}
 
 	return requests, nil
 }
 
-// Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// matchesListOptions applies the filters of opts, which GitHub can't apply when listing pull requests.
+func matchesListOptions(apiObj *github.PullRequest, opts gitprovider.PullRequestListOptions) bool {
+	info := pullrequestFromAPI(apiObj)
+	if opts.State != nil && info.State != *opts.State {
+		return false
+	}
+	if opts.Author != nil && apiObj.GetUser().GetLogin() != *opts.Author {
+		return false
+	}
+	if opts.UpdatedSince != nil && apiObj.GetUpdatedAt().Before(*opts.UpdatedSince) {
+		return false
+	}
+	if opts.Label == nil {
+		return true
+	}
+	for _, label := range info.Labels {
+		if label == *opts.Label {
+			return true
+		}
+	}
+	return false
+}
+
+// Create creates a pull request with the given specifications. If a head repository is set, the
+// head branch is qualified with the owner of that repository, i.e. "owner:branch", which requires
+// it to be a fork within the network of the repository.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
+
+	head := branch
+	if o.HeadRepository != nil {
+		head = fmt.Sprintf("%s:%s", o.HeadRepository.GetIdentity(), branch)
+	}
 
 	prOpts := &github.NewPullRequest{
 		Title: &title,
-		Head:  &branch,
+		Head:  &head,
 		Base:  &baseBranch,
 		Body:  &description,
+		Draft: o.Draft,
 	}
 
 	pr, _, err := c.c.Client().PullRequests.Create(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), prOpts)
@@ -63,18 +118,87 @@ func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranc
 		return nil, err
 	}
 
-	return newPullRequest(c.clientContext, pr), nil
+	return newPullRequest(c.clientContext, pr, c.ref), nil
 }
 
 // Edit modifies an existing PR. Please refer to "EditOptions" for details on which data can be edited.
 func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovider.EditOptions) (gitprovider.PullRequest, error) {
-	editPR := &github.PullRequest{}
-	editPR.Title = opts.Title
-	editedPR, _, err := c.c.Client().PullRequests.Edit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, editPR)
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Title: opts.Title})
+}
+
+// Update changes an existing pull request. Please refer to "PullRequestUpdateOptions" for
+// details on which data can be changed.
+//
+// The REST API doesn't allow changing the draft state of a pull request, hence that is done
+// through the GraphQL API, which requires an extra request.
+func (c *PullRequestClient) Update(ctx context.Context, number int, opts gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	editPR := &github.PullRequest{
+		Title: opts.Title,
+		Body:  opts.Description,
+	}
+	if opts.BaseBranch != nil {
+		editPR.Base = &github.PullRequestBranch{Ref: opts.BaseBranch}
+	}
+	// PATCH /repos/{owner}/{repo}/pulls/{pull_number}
+	pr, _, err := c.c.Client().PullRequests.Edit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, editPR)
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
-	return newPullRequest(c.clientContext, editedPR), nil
+	if opts.Draft != nil && *opts.Draft != pr.GetDraft() {
+		if err := c.setDraft(ctx, pr.GetNodeID(), *opts.Draft); err != nil {
+			return nil, err
+		}
+		pr.Draft = opts.Draft
+	}
+	return newPullRequest(c.clientContext, pr, c.ref), nil
+}
+
+// Close closes an open pull request without merging it.
+func (c *PullRequestClient) Close(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, "closed")
+}
+
+// Reopen reopens a closed pull request.
+func (c *PullRequestClient) Reopen(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, "open")
+}
+
+// MarkReady marks a draft pull request as ready for review.
+func (c *PullRequestClient) MarkReady(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Draft: gitprovider.BoolVar(false)})
+}
+
+// setState sets the state of the pull request to either "open" or "closed". Merged pull
+// requests can't change state, and pull requests already in the given state are left as-is.
+func (c *PullRequestClient) setState(ctx context.Context, number int, state string) (gitprovider.PullRequest, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}
+	pr, _, err := c.c.Client().PullRequests.Get(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if pr.GetMerged() {
+		return nil, fmt.Errorf("pull request %d has been merged already: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if pr.GetState() == state {
+		return newPullRequest(c.clientContext, pr, c.ref), nil
+	}
+	// PATCH /repos/{owner}/{repo}/pulls/{pull_number}
+	pr, _, err = c.c.Client().PullRequests.Edit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, &github.PullRequest{State: &state})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return newPullRequest(c.clientContext, pr, c.ref), nil
+}
+
+// setDraft converts the pull request with the given GraphQL node ID to a draft, or marks it as
+// ready for review.
+func (c *PullRequestClient) setDraft(ctx context.Context, nodeID string, draft bool) error {
+	mutation := `mutation($id: ID!) { markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId } }`
+	if draft {
+		mutation = `mutation($id: ID!) { convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId } }`
+	}
+	// POST /graphql
+	return graphQLQuery(ctx, c.c.Client(), mutation, map[string]interface{}{"id": nodeID}, &struct{}{})
 }
 
 // Get retrieves an existing pull request by number
@@ -85,17 +209,24 @@ func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.Pu
 		return nil, err
 	}
 
-	return newPullRequest(c.clientContext, pr), nil
+	return newPullRequest(c.clientContext, pr, c.ref), nil
 }
 
-// Merge merges a pull request with the given specifications.
-func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string) error {
+// Merge merges a pull request with the given specifications. The merge method must be allowed
+// in the settings of the repository, GitHub can't fast-forward pull requests.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err := c.validateMergeMethod(ctx, mergeMethod); err != nil {
+		return err
+	}
 
 	prOpts := &github.PullRequestOptions{
-		CommitTitle: "",
 		SHA:         "",
 		MergeMethod: string(mergeMethod),
 	}
+	if o.CommitTitle != nil {
+		prOpts.CommitTitle = *o.CommitTitle
+	}
 
 	_, _, err := c.c.Client().PullRequests.Merge(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, message, prOpts)
 	if err != nil {
@@ -104,3 +235,31 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod g
 
 	return nil
 }
+
+// validateMergeMethod checks whether the repository allows mergeMethod. The settings are only
+// reported to users with push access, otherwise GitHub is left to reject the merge.
+func (c *PullRequestClient) validateMergeMethod(ctx context.Context, mergeMethod gitprovider.MergeMethod) error {
+	var allowed func(*github.Repository) *bool
+	switch mergeMethod {
+	case gitprovider.MergeMethodMerge:
+		allowed = func(repo *github.Repository) *bool { return repo.AllowMergeCommit }
+	case gitprovider.MergeMethodSquash:
+		allowed = func(repo *github.Repository) *bool { return repo.AllowSquashMerge }
+	case gitprovider.MergeMethodRebase:
+		allowed = func(repo *github.Repository) *bool { return repo.AllowRebaseMerge }
+	case gitprovider.MergeMethodFastForward:
+		return fmt.Errorf("github can't fast-forward pull requests: %w", gitprovider.ErrNoProviderSupport)
+	default:
+		return fmt.Errorf("unsupported merge method %q: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
+
+	// GET /repos/{owner}/{repo}
+	repo, err := c.c.GetRepo(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return err
+	}
+	if a := allowed(repo); a != nil && !*a {
+		return fmt.Errorf("merge method %q isn't allowed for repository %s: %w", mergeMethod, c.ref.GetRepository(), gitprovider.ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the comments of a specific pull request. General comments
+// are issue comments in GitHub, whereas inline comments are review comments.
+type PullRequestCommentClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+	// headSHA is the latest commit of the pull request, which inline comments are anchored to.
+	headSHA string
+}
+
+// List lists the general and inline comments of the pull request, oldest first. The review
+// threads are requested through the GraphQL API, in order to tell whether they're resolved.
+//
+// List returns all available comments, using multiple paginated requests if needed.
+func (c *PullRequestCommentClient) List(ctx context.Context) ([]gitprovider.PullRequestComment, error) {
+	// GET /repos/{owner}/{repo}/issues/{issue_number}/comments
+	issueComments, err := c.c.ListIssueComments(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number)
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}/comments
+	reviewComments, err := c.c.ListReviewComments(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number)
+	if err != nil {
+		return nil, err
+	}
+	var threads []*reviewThread
+	if len(reviewComments) != 0 {
+		// POST /graphql
+		threads, err = c.c.ListReviewThreads(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	comments := make([]gitprovider.PullRequestComment, 0, len(issueComments)+len(reviewComments))
+	for _, apiObj := range issueComments {
+		comments = append(comments, newIssueComment(apiObj))
+	}
+	for _, apiObj := range reviewComments {
+		thread := findReviewThread(threads, apiObj.GetID())
+		comments = append(comments, newReviewComment(apiObj, thread != nil && thread.IsResolved))
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Get().CreatedAt.Before(comments[j].Get().CreatedAt)
+	})
+	return comments, nil
+}
+
+// Create creates a general comment, an inline comment on the latest commit of the pull request,
+// or a reply to an inline comment.
+func (c *PullRequestCommentClient) Create(ctx context.Context, req gitprovider.PullRequestCommentInfo) (gitprovider.PullRequestComment, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	if req.Path == "" && req.InReplyTo == 0 {
+		// POST /repos/{owner}/{repo}/issues/{issue_number}/comments
+		apiObj, err := c.c.CreateIssueComment(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number, req.Body)
+		if err != nil {
+			return nil, err
+		}
+		return newIssueComment(apiObj), nil
+	}
+
+	comment := &github.PullRequestComment{Body: &req.Body}
+	if req.InReplyTo != 0 {
+		comment.InReplyTo = &req.InReplyTo
+	} else {
+		comment.CommitID = &c.headSHA
+		comment.Path = &req.Path
+		comment.Line = &req.Line
+		comment.Side = github.String("RIGHT")
+	}
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/comments
+	apiObj, err := c.c.CreateReviewComment(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number, comment)
+	if err != nil {
+		return nil, err
+	}
+	return newReviewComment(apiObj, false), nil
+}
+
+// Resolve resolves the review thread of the inline comment with the given ID. General comments
+// have no threads on GitHub, hence can't be resolved.
+func (c *PullRequestCommentClient) Resolve(ctx context.Context, id int64) error {
+	// POST /graphql
+	threads, err := c.c.ListReviewThreads(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number)
+	if err != nil {
+		return err
+	}
+	thread := findReviewThread(threads, id)
+	if thread == nil {
+		// GET /repos/{owner}/{repo}/issues/comments/{comment_id}
+		_, err := c.c.GetIssueComment(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), id)
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return fmt.Errorf("comment %d: %w", id, gitprovider.ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("general comment %d has no thread to resolve: %w", id, gitprovider.ErrInvalidArgument)
+	}
+	if thread.IsResolved {
+		return nil
+	}
+	// POST /graphql
+	return c.c.ResolveReviewThread(ctx, thread.ID)
+}
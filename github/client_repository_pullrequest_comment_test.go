@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func TestPullRequestCommentClient(t *testing.T) {
+	var posted []map[string]interface{}
+	var resolved []interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, `[{"id":1,"user":{"login":"alice"},"body":"general","created_at":"2024-01-01T00:00:00Z"}]`)
+		case http.MethodPost:
+			_, _ = fmt.Fprint(w, `{"id":4,"user":{"login":"carol"},"body":"thanks"}`)
+		}
+	})
+	mux.HandleFunc("/repos/org/repo/issues/comments/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":1}`)
+	})
+	mux.HandleFunc("/repos/org/repo/issues/comments/9", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, `[
+				{"id":2,"user":{"login":"bob"},"body":"nit","path":"main.go","line":3,"created_at":"2024-01-03T00:00:00Z"},
+				{"id":3,"user":{"login":"alice"},"body":"done","path":"main.go","line":3,"in_reply_to_id":2,"created_at":"2024-01-02T00:00:00Z"}
+			]`)
+		case http.MethodPost:
+			req := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+			posted = append(posted, req)
+			_, _ = fmt.Fprintf(w, `{"id":%d,"body":%q}`, 4+len(posted), req["body"])
+		}
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		req := graphQLRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if strings.Contains(req.Query, "resolveReviewThread") {
+			resolved = append(resolved, req.Variables["id"])
+			_, _ = fmt.Fprint(w, `{"data":{"resolveReviewThread":{"thread":{"id":"T_2"}}}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"reviewThreads":{
+			"nodes":[{"id":"T_2","isResolved":false,"comments":{"nodes":[{"databaseId":2},{"databaseId":3}]}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"c"}}}}}}`)
+	})
+	prs := newTestPullRequestClient(t, mux)
+	apiObj := &github.PullRequest{Number: github.Int(1), Head: &github.PullRequestBranch{SHA: github.String("abc")}}
+	c := newPullRequest(prs.clientContext, apiObj, prs.ref).Comments()
+	ctx := context.Background()
+
+	comments, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var ids []int64
+	for _, comment := range comments {
+		ids = append(ids, comment.Get().ID)
+	}
+	// sorted by creation time
+	if fmt.Sprint(ids) != "[1 3 2]" {
+		t.Errorf("List() returned comments %v, want [1 3 2]", ids)
+	}
+	if got := comments[1].Get(); got.Path != "main.go" || got.Line != 3 || got.InReplyTo != 2 || got.Resolved {
+		t.Errorf("List()[1] = %+v, want the unresolved reply to comment 2", got)
+	}
+
+	if _, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "thanks"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	comment, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "typo", Path: "main.go", Line: 7})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if inline := posted[0]; inline["commit_id"] != "abc" || inline["path"] != "main.go" || inline["line"] != float64(7) || inline["side"] != "RIGHT" {
+		t.Errorf("Create() sent %v, want an inline comment on the head commit", inline)
+	}
+	if got := comment.Get(); got.ID != 5 || got.Body != "typo" {
+		t.Errorf("Create() = %+v, want the inline comment", got)
+	}
+	if _, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "fixed", InReplyTo: 2}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if reply := posted[1]; reply["in_reply_to"] != float64(2) || reply["body"] != "fixed" {
+		t.Errorf("Create() sent %v, want the reply to comment 2", reply)
+	}
+	if _, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Path: "main.go", Line: 7}); !errors.Is(err, validation.ErrFieldRequired) {
+		t.Errorf("Create() error = %v, want ErrFieldRequired", err)
+	}
+
+	if err := c.Resolve(ctx, 3); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(resolved) != 1 || resolved[0] != "T_2" {
+		t.Errorf("Resolve() resolved threads %v, want [T_2]", resolved)
+	}
+	if err := c.Resolve(ctx, 1); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Resolve() of a general comment error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Resolve(ctx, 9); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Resolve() of a missing comment error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReviewClient implements the gitprovider.ReviewClient interface.
+var _ gitprovider.ReviewClient = &ReviewClient{}
+
+// ReviewClient operates on the reviews of a specific pull request.
+type ReviewClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// RequestReviewers requests reviews from the users with the given logins.
+func (c *ReviewClient) RequestReviewers(ctx context.Context, logins ...string) error {
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers
+	return c.c.RequestReviewers(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number, logins)
+}
+
+// List lists the submitted and pending reviews of the pull request.
+//
+// List returns all available reviews, using multiple paginated requests if needed.
+func (c *ReviewClient) List(ctx context.Context) ([]gitprovider.Review, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObjs, err := c.c.ListReviews(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number)
+	if err != nil {
+		return nil, err
+	}
+	reviews := make([]gitprovider.Review, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		reviews = append(reviews, newReview(apiObj))
+	}
+	return reviews, nil
+}
+
+// Approve submits an approving review with the given body.
+func (c *ReviewClient) Approve(ctx context.Context, body string) (gitprovider.Review, error) {
+	req := &github.PullRequestReviewRequest{
+		Event: github.String("APPROVE"),
+	}
+	if body != "" {
+		req.Body = &body
+	}
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObj, err := c.c.CreateReview(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number, req)
+	if err != nil {
+		return nil, err
+	}
+	return newReview(apiObj), nil
+}
+
+// Dismiss dismisses the review with the given ID. GitHub requires a message for dismissals.
+func (c *ReviewClient) Dismiss(ctx context.Context, id int64, message string) error {
+	// PUT /repos/{owner}/{repo}/pulls/{pull_number}/reviews/{review_id}/dismissals
+	return c.c.DismissReview(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), c.number, id, message)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestReviewClient(t *testing.T) {
+	var requested github.ReviewersRequest
+	var created, dismissal map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/1/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&requested); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"number":1}`)
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, `[{"id":10,"user":{"login":"alice"},"state":"CHANGES_REQUESTED","body":"please fix","submitted_at":"2024-01-02T03:04:05Z"},{"id":11,"user":{"login":"bob"},"state":"APPROVED"}]`)
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Error(err)
+			}
+			_, _ = fmt.Fprint(w, `{"id":12,"user":{"login":"carol"},"state":"APPROVED","body":"LGTM"}`)
+		}
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1/reviews/10/dismissals", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&dismissal); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"id":10,"state":"DISMISSED"}`)
+	})
+	prs := newTestPullRequestClient(t, mux)
+	c := newPullRequest(prs.clientContext, &github.PullRequest{Number: github.Int(1)}, prs.ref).Reviews()
+	ctx := context.Background()
+
+	if err := c.RequestReviewers(ctx, "alice", "bob"); err != nil {
+		t.Fatalf("RequestReviewers() error = %v", err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(requested.Reviewers, want) {
+		t.Errorf("RequestReviewers() sent %v, want %v", requested.Reviewers, want)
+	}
+
+	reviews, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("List() returned %d reviews, want 2", len(reviews))
+	}
+	if got := reviews[0].Get(); got.ID != 10 || got.Reviewer != "alice" || got.State != gitprovider.ReviewStateChangesRequested || got.SubmittedAt == nil {
+		t.Errorf("List()[0] = %+v, want the changes requested review of alice", got)
+	}
+	if got := reviews[1].Get().State; got != gitprovider.ReviewStateApproved {
+		t.Errorf("List()[1] state = %q, want %q", got, gitprovider.ReviewStateApproved)
+	}
+
+	review, err := c.Approve(ctx, "LGTM")
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if created["event"] != "APPROVE" || created["body"] != "LGTM" {
+		t.Errorf("Approve() sent %v, want an approving review", created)
+	}
+	if got := review.Get(); got.ID != 12 || got.State != gitprovider.ReviewStateApproved {
+		t.Errorf("Approve() = %+v, want the approving review", got)
+	}
+
+	if err := c.Dismiss(ctx, 10, "outdated"); err != nil {
+		t.Fatalf("Dismiss() error = %v", err)
+	}
+	if dismissal["message"] != "outdated" {
+		t.Errorf("Dismiss() sent %v, want the message", dismissal)
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestPullRequestClient(t *testing.T, mux *http.ServeMux) *PullRequestClient {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &PullRequestClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestPullRequestClient_CreateDraft(t *testing.T) {
+	var create map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"number":1,"state":"open","draft":true}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.Create(context.Background(), "title", "feature", "main", "description",
+		&gitprovider.PullRequestCreateOptions{Draft: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if create["draft"] != true {
+		t.Errorf("Create() sent %v, want a draft pull request", create)
+	}
+	if !pr.Get().Draft {
+		t.Errorf("Create() = %+v, want a draft", pr.Get())
+	}
+}
+
+func TestPullRequestClient_CreateFromFork(t *testing.T) {
+	var create map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"number":1,"state":"open"}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	fork := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: "github.com", UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	_, err := c.Create(context.Background(), "title", "feature", "main", "description",
+		&gitprovider.PullRequestCreateOptions{HeadRepository: fork})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if create["head"] != "user:feature" {
+		t.Errorf("Create() sent head %v, want %q", create["head"], "user:feature")
+	}
+}
+
+func TestPullRequestClient_MarkReady(t *testing.T) {
+	var mutation string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number":1,"node_id":"PR_1","state":"open","draft":true}`)
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		req := graphQLRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		mutation = req.Query
+		_, _ = fmt.Fprint(w, `{"data":{"markPullRequestReadyForReview":{"clientMutationId":null}}}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.MarkReady(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("MarkReady() error = %v", err)
+	}
+	if !strings.Contains(mutation, "markPullRequestReadyForReview") {
+		t.Errorf("MarkReady() sent mutation %q, want markPullRequestReadyForReview", mutation)
+	}
+	if pr.Get().Draft {
+		t.Errorf("MarkReady() = %+v, want the pull request to be ready", pr.Get())
+	}
+}
+
+func TestPullRequest_EnableAutoMerge(t *testing.T) {
+	var req graphQLRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name":"repo","allow_merge_commit":false,"allow_squash_merge":true}`)
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number":1,"node_id":"PR_1","state":"open"}`)
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"data":{"enablePullRequestAutoMerge":{"pullRequest":{"id":"PR_1"}}}}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := pr.EnableAutoMerge(ctx, gitprovider.MergeMethodSquash); err != nil {
+		t.Fatalf("EnableAutoMerge() error = %v", err)
+	}
+	if !strings.Contains(req.Query, "enablePullRequestAutoMerge") || req.Variables["id"] != "PR_1" || req.Variables["method"] != "SQUASH" {
+		t.Errorf("EnableAutoMerge() sent %+v, want a squash auto-merge of PR_1", req)
+	}
+	if err := pr.EnableAutoMerge(ctx, gitprovider.MergeMethodMerge); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("EnableAutoMerge() with disallowed merge commits error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPullRequest_Triage(t *testing.T) {
+	var edits []map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number":1,"state":"open","labels":[{"name":"bug"}],"assignees":[{"login":"alice"}],"milestone":{"number":3}}`)
+	})
+	mux.HandleFunc("/repos/org/repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		edit := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+			t.Error(err)
+		}
+		edits = append(edits, edit)
+		_, _ = fmt.Fprint(w, `{"number":1,"labels":[{"name":"release"}],"assignees":[{"login":"bob"}]}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	info := pr.Get()
+	if !reflect.DeepEqual(info.Labels, []string{"bug"}) || !reflect.DeepEqual(info.Assignees, []string{"alice"}) || info.Milestone == nil || *info.Milestone != 3 {
+		t.Errorf("Get() = %+v, want the labels, assignees and milestone of the pull request", info)
+	}
+	if err := pr.SetLabels(ctx, []string{"release"}); err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	if err := pr.SetAssignees(ctx, nil); err != nil {
+		t.Fatalf("SetAssignees() error = %v", err)
+	}
+	if err := pr.SetMilestone(ctx, 0); err != nil {
+		t.Fatalf("SetMilestone() error = %v", err)
+	}
+	want := []map[string]interface{}{
+		{"labels": []interface{}{"release"}},
+		{"assignees": []interface{}{}},
+		{"milestone": nil},
+	}
+	if !reflect.DeepEqual(edits, want) {
+		t.Errorf("sent %v, want %v", edits, want)
+	}
+	info = pr.Get()
+	if !reflect.DeepEqual(info.Labels, []string{"release"}) || !reflect.DeepEqual(info.Assignees, []string{"bob"}) || info.Milestone != nil {
+		t.Errorf("Get() = %+v, want the labels, assignees and milestone to be updated", info)
+	}
+}
+
+func TestPullRequestClient_List(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != "closed" || query.Get("base") != "main" || query.Get("head") != "org:feature" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		_, _ = fmt.Fprint(w, `[
+			{"number":1,"state":"closed","merged_at":"2023-01-02T00:00:00Z","updated_at":"2023-01-02T00:00:00Z","user":{"login":"alice"},"labels":[{"name":"release"}]},
+			{"number":2,"state":"closed","updated_at":"2023-01-02T00:00:00Z","user":{"login":"alice"},"labels":[{"name":"release"}]},
+			{"number":3,"state":"closed","merged_at":"2023-01-02T00:00:00Z","updated_at":"2023-01-02T00:00:00Z","user":{"login":"bob"},"labels":[{"name":"release"}]},
+			{"number":4,"state":"closed","merged_at":"2022-12-01T00:00:00Z","updated_at":"2022-12-01T00:00:00Z","user":{"login":"alice"},"labels":[{"name":"release"}]},
+			{"number":5,"state":"closed","merged_at":"2023-01-02T00:00:00Z","updated_at":"2023-01-02T00:00:00Z","user":{"login":"alice"}}
+		]`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	prs, err := c.List(context.Background(), &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateMerged),
+		BaseBranch:   gitprovider.StringVar("main"),
+		SourceBranch: gitprovider.StringVar("feature"),
+		Author:       gitprovider.StringVar("alice"),
+		Label:        gitprovider.StringVar("release"),
+		UpdatedSince: &since,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().Number != 1 {
+		t.Errorf("List() = %v, want only the merged pull request 1", prs)
+	}
+}
+
+func TestPullRequest_FilesAndDiff(t *testing.T) {
+	const diff = "diff --git a/README.md b/README.md\n"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/vnd.github.v3.diff" {
+			_, _ = fmt.Fprint(w, diff)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"number":1,"state":"open"}`)
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[
+			{"filename":"README.md","status":"modified","additions":2,"deletions":1,"patch":"@@ -1 +1,2 @@"},
+			{"filename":"docs/new.md","previous_filename":"docs/old.md","status":"renamed"}
+		]`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	files, err := pr.Files(ctx)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	want := []gitprovider.PullRequestFile{
+		{Path: "README.md", ChangeType: gitprovider.FileChangeTypeModified, Additions: 2, Deletions: 1, Patch: "@@ -1 +1,2 @@"},
+		{Path: "docs/new.md", PreviousPath: "docs/old.md", ChangeType: gitprovider.FileChangeTypeRenamed},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Files() = %+v, want %+v", files, want)
+	}
+	got, err := pr.Diff(ctx)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if got != diff {
+		t.Errorf("Diff() = %q, want %q", got, diff)
+	}
+}
+
+func TestPullRequestClient_Update(t *testing.T) {
+	var edit map[string]interface{}
+	var mutation string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprintf(w, `{"number":1,"node_id":"PR_1","state":"open","body":%q,"base":{"ref":%q},"draft":false}`,
+			edit["body"], edit["base"])
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		req := graphQLRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if req.Variables["id"] != "PR_1" {
+			t.Errorf("unexpected pull request ID %v", req.Variables["id"])
+		}
+		mutation = req.Query
+		_, _ = fmt.Fprint(w, `{"data":{"convertPullRequestToDraft":{"clientMutationId":null}}}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.Update(context.Background(), 1, gitprovider.PullRequestUpdateOptions{
+		Description: gitprovider.StringVar("description"),
+		BaseBranch:  gitprovider.StringVar("release"),
+		Draft:       gitprovider.BoolVar(true),
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, ok := edit["title"]; ok {
+		t.Errorf("Update() sent %v, want only the set fields", edit)
+	}
+	if !strings.Contains(mutation, "convertPullRequestToDraft") {
+		t.Errorf("Update() sent mutation %q, want convertPullRequestToDraft", mutation)
+	}
+	got := pr.Get()
+	if got.Description != "description" || got.BaseBranch != "release" || !got.Draft || got.State != gitprovider.PullRequestStateOpen {
+		t.Errorf("Update() = %+v, want the new description, base branch and draft state", got)
+	}
+}
+
+func TestPullRequestClient_Close(t *testing.T) {
+	tests := []struct {
+		name      string
+		pr        string
+		wantEdit  bool
+		wantState gitprovider.PullRequestState
+		wantErr   error
+	}{
+		{
+			name:      "open",
+			pr:        `{"number":1,"state":"open"}`,
+			wantEdit:  true,
+			wantState: gitprovider.PullRequestStateClosed,
+		},
+		{
+			name:      "closed already",
+			pr:        `{"number":1,"state":"closed"}`,
+			wantState: gitprovider.PullRequestStateClosed,
+		},
+		{
+			name:    "merged",
+			pr:      `{"number":1,"state":"closed","merged":true}`,
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/org/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					edited = true
+					edit := &github.PullRequest{}
+					if err := json.NewDecoder(r.Body).Decode(edit); err != nil {
+						t.Error(err)
+					}
+					_, _ = fmt.Fprintf(w, `{"number":1,"state":%q}`, edit.GetState())
+					return
+				}
+				_, _ = fmt.Fprint(w, tt.pr)
+			})
+			c := newTestPullRequestClient(t, mux)
+
+			pr, err := c.Close(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Close() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if edited != tt.wantEdit {
+				t.Errorf("Close() edited = %v, want %v", edited, tt.wantEdit)
+			}
+			if err == nil && pr.Get().State != tt.wantState {
+				t.Errorf("Close() state = %q, want %q", pr.Get().State, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_MergeMethods(t *testing.T) {
+	var merge map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name":"repo","allow_merge_commit":false,"allow_squash_merge":true,"allow_rebase_merge":true}`)
+	})
+	mux.HandleFunc("/repos/org/repo/pulls/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&merge); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"merged":true}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodSquash, "message", &gitprovider.PullRequestMergeOptions{CommitTitle: gitprovider.StringVar("title")}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merge["merge_method"] != "squash" || merge["commit_title"] != "title" || merge["commit_message"] != "message" {
+		t.Errorf("Merge() sent %v, want a squash merge with the given title and message", merge)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodMerge, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() with disallowed merge commits error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodFastForward, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Merge() error = %v, want ErrNoProviderSupport", err)
+	}
+}
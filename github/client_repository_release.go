@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"io"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the release created from the given tag, including its assets.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.Release, error) {
+	// GET /repos/{owner}/{repo}/releases/tags/{tag}
+	apiObj, err := c.c.GetReleaseByTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), tagName)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// List lists all releases of the repository, including their assets, most recent first.
+//
+// List returns all available releases, using multiple paginated requests if needed.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.Release, error) {
+	// GET /repos/{owner}/{repo}/releases
+	apiObjs, err := c.c.ListReleases(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Release type
+	releases := make([]gitprovider.Release, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListReleases
+		releases = append(releases, newRelease(c, apiObj))
+	}
+	return releases, nil
+}
+
+// DownloadAsset returns a reader streaming the contents of the given release asset.
+// The caller must close the returned reader. The size of the asset isn't limited by
+// WithMaxResponseSize, but by WithMaxDownloadSize, if given.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) DownloadAsset(ctx context.Context, asset gitprovider.ReleaseAsset) (io.ReadCloser, error) {
+	// GET /repos/{owner}/{repo}/releases/assets/{asset_id}
+	return c.c.DownloadReleaseAsset(gitprovider.WithDownload(ctx), c.ref.GetIdentity(), c.ref.GetRepository(), asset.ID)
+}
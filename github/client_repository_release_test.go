@@ -0,0 +1,251 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestRelease_UploadAsset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":5,"tag_name":"v1.0.0","assets":[{"id":1,"name":"existing.tar.gz"}]}`)
+	})
+	mux.HandleFunc("/api/uploads/repos/org/repo/releases/5/assets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if got := r.URL.Query().Get("name"); got != "app.tar.gz" {
+			t.Errorf("name = %q, want %q", got, "app.tar.gz")
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/gzip" {
+			t.Errorf("Content-Type = %q, want %q", got, "application/gzip")
+		}
+		if r.ContentLength != 7 {
+			t.Errorf("Content-Length = %d, want 7", r.ContentLength)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "content" {
+			t.Errorf("body = %q, want %q", body, "content")
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id":2,"name":"app.tar.gz","size":7,"browser_download_url":"https://github.com/org/repo/releases/download/v1.0.0/app.tar.gz"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	gh.UploadURL = uploadURLFromBaseURL(gh.BaseURL)
+	c := &ReleaseClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	ctx := context.Background()
+	rel, err := c.Get(ctx, "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The content is read into memory, as its size is unknown
+	content := io.MultiReader(strings.NewReader("content"))
+	asset, err := rel.UploadAsset(ctx, "app.tar.gz", "application/gzip", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gitprovider.ReleaseAsset{ID: 2, Name: "app.tar.gz", Size: 7, DownloadURL: "https://github.com/org/repo/releases/download/v1.0.0/app.tar.gz"}
+	if asset != want {
+		t.Errorf("UploadAsset() = %+v, want %+v", asset, want)
+	}
+	if got := len(rel.Get().Assets); got != 2 {
+		t.Errorf("release has %d assets, want 2", got)
+	}
+
+	_, err = rel.UploadAsset(ctx, "existing.tar.gz", "", strings.NewReader("content"))
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("UploadAsset() error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+// hookTransport marks the requests passing through it.
+type hookTransport struct {
+	next http.RoundTripper
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Hook", "applied")
+	if t.next == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
+
+func TestRelease_DownloadAsset(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("credentials sent to the storage backend: %q", got)
+		}
+		if got := r.Header.Get("X-Hook"); got != "applied" {
+			t.Errorf("transport hook not applied to the storage backend request")
+		}
+		_, _ = fmt.Fprint(w, "content")
+	}))
+	defer storage.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+		http.Redirect(w, r, storage.URL+"/app.tar.gz", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	opts, err := gitprovider.MakeClientOptions(
+		gitprovider.WithOAuth2Token("token"),
+		gitprovider.WithPreChainTransportHook(func(next http.RoundTripper) http.RoundTripper {
+			return &hookTransport{next: next}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloadClient, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gh := github.NewClient(httpClient)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &ReleaseClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh, downloadClient: downloadClient}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	rc, err := c.DownloadAsset(context.Background(), gitprovider.ReleaseAsset{ID: 1, Name: "app.tar.gz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "content" {
+		t.Errorf("DownloadAsset() = %q, want %q", body, "content")
+	}
+}
+
+func TestRelease_DownloadAsset_size(t *testing.T) {
+	content := strings.Repeat("x", 64)
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, content)
+	}))
+	defer storage.Close()
+
+	mux := http.NewServeMux()
+	// Served by the API itself
+	mux.HandleFunc("/repos/org/repo/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, content)
+	})
+	// Redirected to the storage backend
+	mux.HandleFunc("/repos/org/repo/releases/assets/2", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, storage.URL+"/app.tar.gz", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		opts    []gitprovider.ClientOption
+		wantErr error
+	}{
+		{
+			name: "larger than the max response size",
+			opts: []gitprovider.ClientOption{gitprovider.WithMaxResponseSize(8)},
+		},
+		{
+			name:    "larger than the max download size",
+			opts:    []gitprovider.ClientOption{gitprovider.WithMaxDownloadSize(8)},
+			wantErr: gitprovider.ErrResponseTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		for _, id := range []int64{1, 2} {
+			t.Run(fmt.Sprintf("%s/asset %d", tt.name, id), func(t *testing.T) {
+				opts, err := gitprovider.MakeClientOptions(tt.opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				httpClient, err := opts.BuildHTTPClient()
+				if err != nil {
+					t.Fatal(err)
+				}
+				downloadClient, err := opts.BuildDownloadHTTPClient()
+				if err != nil {
+					t.Fatal(err)
+				}
+				gh := github.NewClient(httpClient)
+				gh.BaseURL, _ = url.Parse(srv.URL + "/")
+				c := &ReleaseClient{
+					clientContext: &clientContext{c: &githubClientImpl{c: gh, downloadClient: downloadClient}},
+					ref: gitprovider.OrgRepositoryRef{
+						OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+						RepositoryName:  "repo",
+					},
+				}
+
+				rc, err := c.DownloadAsset(context.Background(), gitprovider.ReleaseAsset{ID: id, Name: "app.tar.gz"})
+				var body []byte
+				if err == nil {
+					body, err = io.ReadAll(rc)
+					rc.Close()
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if tt.wantErr == nil && string(body) != content {
+					t.Errorf("DownloadAsset() = %q, want %q", body, content)
+				}
+			})
+		}
+	}
+}
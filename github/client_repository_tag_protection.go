@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagProtectionClient implements the gitprovider.TagProtectionClient interface.
+var _ gitprovider.TagProtectionClient = &TagProtectionClient{}
+
+// TagProtectionClient operates on the tag protection rules of a specific repository.
+type TagProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the rule with the given pattern.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagProtectionClient) Get(ctx context.Context, pattern string) (gitprovider.TagProtection, error) {
+	rules, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.t.GetPattern() == pattern {
+			return rule, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all tag protection rules of the repository.
+//
+// List returns all available rules, which GitHub returns in a single request.
+func (c *TagProtectionClient) List(ctx context.Context) ([]gitprovider.TagProtection, error) {
+	tps, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.TagProtection
+	rules := make([]gitprovider.TagProtection, 0, len(tps))
+	for _, tp := range tps {
+		rules = append(rules, tp)
+	}
+	return rules, nil
+}
+
+func (c *TagProtectionClient) list(ctx context.Context) ([]*tagProtection, error) {
+	// GET /repos/{owner}/{repo}/tags/protection
+	apiObjs, err := c.c.ListTagProtection(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our TagProtection type
+	rules := make([]*tagProtection, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListTagProtection
+		rules = append(rules, newTagProtection(c, apiObj))
+	}
+	return rules, nil
+}
+
+// Create creates a tag protection rule with the given pattern.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TagProtectionClient) Create(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// GitHub allows duplicate patterns, hence make sure the rule doesn't exist yet
+	if _, err := c.Get(ctx, req.Pattern); err == nil {
+		return nil, fmt.Errorf("tag protection %q: %w", req.Pattern, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+	return c.create(ctx, req)
+}
+
+func (c *TagProtectionClient) create(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, error) {
+	// POST /repos/{owner}/{repo}/tags/protection
+	apiObj, err := c.c.CreateTagProtection(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	return newTagProtection(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing rule is matched by its pattern.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req already exists, this is a no-op (actionTaken == false).
+func (c *TagProtectionClient) Reconcile(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Pattern)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.create(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	return actual, false, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTagProtectionClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.TagProtectionInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+	}{
+		{
+			name:         "already protected",
+			req:          gitprovider.TagProtectionInfo{Pattern: "v*"},
+			wantRequests: []string{"GET /repos/org/repo/tags/protection"},
+		},
+		{
+			name:            "protect a new pattern",
+			req:             gitprovider.TagProtectionInfo{Pattern: "release-*"},
+			wantRequests:    []string{"GET /repos/org/repo/tags/protection", "POST /repos/org/repo/tags/protection"},
+			wantBody:        map[string]interface{}{"pattern": "release-*"},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				if r.Method == http.MethodGet {
+					_, _ = fmt.Fprint(w, `[{"id":1,"pattern":"v*"}]`)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 2, "pattern": body["pattern"]})
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &TagProtectionClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if got := resp.Get(); !tt.req.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, tt.req)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// WebhookClient implements the gitprovider.WebhookClient interface.
+var _ gitprovider.WebhookClient = &WebhookClient{}
+
+// WebhookClient operates on the webhooks of a specific repository.
+type WebhookClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *WebhookClient) Get(ctx context.Context, url string) (gitprovider.Webhook, error) {
+	wh, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
+func (c *WebhookClient) get(ctx context.Context, url string) (*webhook, error) {
+	webhooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, wh := range webhooks {
+		if webhookURL(&wh.h) == url {
+			return wh, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the repository.
+//
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *WebhookClient) List(ctx context.Context) ([]gitprovider.Webhook, error) {
+	whs, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.Webhook
+	webhooks := make([]gitprovider.Webhook, 0, len(whs))
+	for _, wh := range whs {
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+func (c *WebhookClient) list(ctx context.Context) ([]*webhook, error) {
+	// GET /repos/{owner}/{repo}/hooks
+	apiObjs, err := c.c.ListHooks(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Webhook type
+	webhooks := make([]*webhook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListHooks
+		webhooks = append(webhooks, newWebhook(c, apiObj))
+	}
+	return webhooks, nil
+}
+
+// Create creates a webhook with the given specifications.
+func (c *WebhookClient) Create(ctx context.Context, req gitprovider.WebhookInfo) (gitprovider.Webhook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, err := c.c.CreateHook(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), webhookToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newWebhook(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing webhook is matched by its URL.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+// WebhookReconcileOptions.ForceSecretRotation deletes and recreates the webhook (actionTaken == true).
+func (c *WebhookClient) Reconcile(ctx context.Context, req gitprovider.WebhookInfo, opts ...gitprovider.WebhookReconcileOption) (gitprovider.Webhook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+	rotateSecret, err := gitprovider.RotatesWebhookSecret(req, gitprovider.MakeWebhookReconcileOptions(opts...))
+	if err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// The secret can't be compared, hence recreate the webhook to make sure it's used
+	if rotateSecret {
+		if err := actual.Delete(ctx); err != nil {
+			return nil, false, err
+		}
+		resp, err := c.Create(ctx, req)
+		return resp, true, err
+	}
+
+	// If the desired matches the actual state, just return the actual state. GitHub only tells
+	// whether a secret is set, hence that's all that can be compared.
+	if req.Equals(actual.Get()) && !secretDrifted(req, actual.hasSecret) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		opts            []gitprovider.WebhookReconcileOption
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			wantRequests: []string{"GET /repos/org/repo/hooks"},
+		},
+		{
+			name: "change events, keeping the secret and other settings",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventComment, gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET /repos/org/repo/hooks", "PATCH /repos/org/repo/hooks/1"},
+			wantBody: map[string]interface{}{
+				"config": map[string]interface{}{"url": hookURL, "content_type": "json", "insecure_ssl": "0"},
+				"events": []interface{}{"push", "issue_comment", "pull_request_review_comment"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "up to date with a secret",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			wantRequests: []string{"GET /repos/org/repo/hooks"},
+		},
+		{
+			name: "remove the secret",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Secret: gitprovider.StringVar(""),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			wantRequests: []string{"GET /repos/org/repo/hooks", "PATCH /repos/org/repo/hooks/1"},
+			wantBody: map[string]interface{}{
+				"config": map[string]interface{}{"url": hookURL, "content_type": "json", "insecure_ssl": "0", "secret": ""},
+				"events": []interface{}{"push", "pull_request"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "force secret rotation",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Secret: gitprovider.StringVar("n3w"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			opts:         []gitprovider.WebhookReconcileOption{&gitprovider.WebhookReconcileOptions{ForceSecretRotation: gitprovider.BoolVar(true)}},
+			wantRequests: []string{"GET /repos/org/repo/hooks", "DELETE /repos/org/repo/hooks/1", "POST /repos/org/repo/hooks"},
+			wantBody: map[string]interface{}{
+				"name":   "web",
+				"active": true,
+				"config": map[string]interface{}{"url": hookURL, "content_type": "json", "secret": "n3w"},
+				"events": []interface{}{"push", "pull_request"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET /repos/org/repo/hooks", "POST /repos/org/repo/hooks"},
+			wantBody: map[string]interface{}{
+				"name":   "web",
+				"active": true,
+				"config": map[string]interface{}{"url": "https://example.com/other", "content_type": "json", "secret": "s3cr3t"},
+				"events": []interface{}{"push"},
+			},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `[{"id":1,"name":"web","events":["push","pull_request"],"config":{"url":%q,"content_type":"json","insecure_ssl":"0","secret":"********"}}]`, hookURL)
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 1}
+					for key, value := range body {
+						resp[key] = value
+					}
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := &WebhookClient{
+				clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestWebhook_Deliveries(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/hooks/1/deliveries", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery))
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<https://api.github.com/repos/org/repo/hooks/1/deliveries?cursor=abc>; rel="next"`)
+			_, _ = fmt.Fprint(w, `[{"id":2,"event":"push","delivered_at":"2022-01-02T10:00:00Z","status_code":200,"duration":0.5}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id":3,"event":"ping","delivered_at":"2022-01-01T10:00:00Z","status_code":502,"redelivery":true}]`)
+	})
+	mux.HandleFunc("/repos/org/repo/hooks/1/deliveries/2/attempts", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"id":4}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	wh := newWebhook(&WebhookClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}, &github.Hook{ID: github.Int64(1)})
+
+	deliveries, err := wh.Deliveries(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gitprovider.WebhookDelivery{
+		{ID: "2", Event: "push", DeliveredAt: time.Date(2022, 1, 2, 10, 0, 0, 0, time.UTC), StatusCode: 200, Duration: 500 * time.Millisecond},
+		{ID: "3", Event: "ping", DeliveredAt: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC), StatusCode: 502, Redelivery: true},
+	}
+	if !reflect.DeepEqual(deliveries, want) {
+		t.Errorf("Deliveries() = %+v, want %+v", deliveries, want)
+	}
+
+	if err := wh.Redeliver(context.Background(), "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wh.Redeliver(context.Background(), "latest"); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Redeliver() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+
+	wantRequests := []string{
+		"GET /repos/org/repo/hooks/1/deliveries?",
+		"GET /repos/org/repo/hooks/1/deliveries?cursor=abc",
+		"POST /repos/org/repo/hooks/1/deliveries/2/attempts",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+}
+
+func TestWebhook_Ping(t *testing.T) {
+	var requests []string
+	pinged := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/hooks/1/deliveries", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		if !pinged {
+			_, _ = fmt.Fprint(w, `[{"id":3,"event":"push","status_code":200},{"id":2,"event":"ping","status_code":200}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id":4,"event":"ping","status":"Invalid HTTP Response: 503","status_code":503},{"id":3,"event":"push","status_code":200},{"id":2,"event":"ping","status_code":200}]`)
+	})
+	mux.HandleFunc("/repos/org/repo/hooks/1/pings", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		pinged = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	wh := newWebhook(&WebhookClient{
+		clientContext: &clientContext{c: &githubClientImpl{c: gh}, domain: "github.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}, &github.Hook{ID: github.Int64(1)})
+
+	got, err := wh.Ping(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gitprovider.WebhookPingResult{StatusCode: 503, Message: "Invalid HTTP Response: 503"}
+	if got != want {
+		t.Errorf("Ping() = %+v, want %+v", got, want)
+	}
+	wantRequests := []string{
+		"GET /repos/org/repo/hooks/1/deliveries",
+		"POST /repos/org/repo/hooks/1/pings",
+		"GET /repos/org/repo/hooks/1/deliveries",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestClient_CheckScopes(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      []string
+		wantMissing []string
+		wantErr     error
+	}{
+		{
+			name:        "implied scopes",
+			header:      []string{"repo, admin:org"},
+			wantMissing: []string{},
+		},
+		{
+			name:        "missing scopes",
+			header:      []string{"public_repo, read:org"},
+			wantMissing: []string{"repo", "write:org"},
+		},
+		{
+			name:        "no scopes",
+			header:      []string{""},
+			wantMissing: []string{"read:org", "repo", "write:org"},
+		},
+		{
+			name:    "fine-grained token",
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != nil {
+					w.Header()["X-Oauth-Scopes"] = tt.header
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+
+			res, err := c.CheckScopes(context.Background(), []string{"repo", "read:org", "write:org"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckScopes() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(res.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %v, want %v", res.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestClient_TokenInfo(t *testing.T) {
+	expiresAt := time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    *gitprovider.TokenInfo
+		wantErr error
+	}{
+		{
+			name:    "classic token",
+			headers: map[string]string{"X-Oauth-Scopes": "repo, admin:org"},
+			want:    &gitprovider.TokenInfo{Scopes: []string{"repo", "admin:org"}},
+		},
+		{
+			name:    "expiring classic token",
+			headers: map[string]string{"X-Oauth-Scopes": "", tokenExpirationHeader: "2023-03-06 12:00:00 UTC"},
+			want:    &gitprovider.TokenInfo{Scopes: []string{}, ExpiresAt: &expiresAt},
+		},
+		{
+			name:    "fine-grained token",
+			headers: map[string]string{tokenExpirationHeader: "2023-03-06 13:00:00 +0100"},
+			want:    &gitprovider.TokenInfo{ExpiresAt: &expiresAt},
+		},
+		{
+			name:    "invalid expiry",
+			headers: map[string]string{tokenExpirationHeader: "tomorrow"},
+			wantErr: gitprovider.ErrInvalidServerData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+
+			got, err := c.TokenInfo(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TokenInfo() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Scopes, tt.want.Scopes) {
+				t.Errorf("Scopes = %#v, want %#v", got.Scopes, tt.want.Scopes)
+			}
+			if (got.ExpiresAt == nil) != (tt.want.ExpiresAt == nil) ||
+				(got.ExpiresAt != nil && !got.ExpiresAt.Equal(*tt.want.ExpiresAt)) {
+				t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, tt.want.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestClient_ListStarred(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []gitprovider.RepositoryRef
+		wantErr error
+	}{
+		{
+			name:   "stars",
+			status: http.StatusOK,
+			body:   `[{"repo":{"name":"flux2","owner":{"login":"fluxcd","type":"Organization"}}},{"repo":{"name":"dotfiles","owner":{"login":"octocat","type":"User"}}}]`,
+			want: []gitprovider.RepositoryRef{
+				gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "flux2",
+				},
+				gitprovider.UserRepositoryRef{
+					UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "octocat"},
+					RepositoryName: "dotfiles",
+				},
+			},
+		},
+		{
+			name:   "no stars",
+			status: http.StatusOK,
+			body:   `[]`,
+			want:   []gitprovider.RepositoryRef{},
+		},
+		{
+			name:    "missing user",
+			status:  http.StatusNotFound,
+			body:    `{"message":"Not Found"}`,
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/users/octocat/starred" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+
+			got, err := c.ListStarred(context.Background(), "octocat")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListStarred() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListStarred() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListLicenseTemplates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/licenses" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"key":"apache-2.0","name":"Apache License 2.0"},{"key":"unlicense","name":"The Unlicense"}]`))
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := newClient(gh, DefaultDomain, false)
+
+	got, err := c.ListLicenseTemplates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gitprovider.LicenseTemplateInfo{
+		{Key: gitprovider.LicenseTemplateApache2, Name: "Apache License 2.0"},
+		{Key: gitprovider.LicenseTemplate("unlicense"), Name: "The Unlicense"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListLicenseTemplates() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_ListGitignoreTemplates(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gitignore/templates" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`["Go","Node"]`))
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := newClient(gh, DefaultDomain, false)
+
+	for i := 0; i < 2; i++ {
+		got, err := c.ListGitignoreTemplates(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"Go", "Node"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ListGitignoreTemplates() = %v, want %v", got, want)
+		}
+		// Modifying the result must not affect the cache
+		got[0] = "modified"
+	}
+	if requests != 1 {
+		t.Errorf("expected the templates to be fetched once, got %d requests", requests)
+	}
+}
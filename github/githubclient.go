@@ -18,12 +18,20 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v49/github"
 )
 
+// rawMediaType makes the contents API return the raw file instead of a JSON object.
+const rawMediaType = "application/vnd.github.raw"
+
 // githubClientImpl is a wrapper around *github.Client, which implements higher-level methods,
 // operating on the go-github structs. Pagination is implemented for all List* methods, all returned
 // objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
@@ -35,6 +43,16 @@ type githubClient interface {
 	// GetOrg is a wrapper for "GET /orgs/{org}".
 	// This function HTTP error wrapping, and validates the server result.
 	GetOrg(ctx context.Context, orgName string) (*github.Organization, error)
+	// GetOrgMembership is a wrapper for "GET /user/memberships/orgs/{org}", returning the
+	// membership of the authenticated user.
+	// This function handles HTTP error wrapping.
+	GetOrgMembership(ctx context.Context, orgName string) (*github.Membership, error)
+	// EditOrg is a wrapper for "PATCH /orgs/{org}".
+	// This function handles HTTP error wrapping.
+	EditOrg(ctx context.Context, orgName string, req *github.Organization) error
+	// GetUser is a wrapper for "GET /users/{username}".
+	// This function HTTP error wrapping.
+	GetUser(ctx context.Context, login string) (*github.User, error)
 	// ListOrgs is a wrapper for "GET /user/orgs".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgs(ctx context.Context) ([]*github.Organization, error)
@@ -45,16 +63,66 @@ type githubClient interface {
 	// ListOrgTeams is a wrapper for "GET /orgs/{org}/teams".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgTeams(ctx context.Context, orgName string) ([]*github.Team, error)
+	// GetOrgTeam is a wrapper for "GET /orgs/{org}/teams/{team_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetOrgTeam(ctx context.Context, orgName, teamName string) (*github.Team, error)
+	// EditOrgTeam is a wrapper for "PATCH /orgs/{org}/teams/{team_slug}".
+	// If removeParent is true, the parent of the team is removed, i.e. it becomes a top-level team.
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditOrgTeam(ctx context.Context, orgName, teamName string, req *github.NewTeam, removeParent bool) (*github.Team, error)
+
+	// ListOrgRunners is a wrapper for "GET /orgs/{org}/actions/runners".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgRunners(ctx context.Context, orgName string) ([]*github.Runner, error)
+	// CreateOrgRunnerRegistrationToken is a wrapper for "POST /orgs/{org}/actions/runners/registration-token".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateOrgRunnerRegistrationToken(ctx context.Context, orgName string) (*github.RegistrationToken, error)
+	// DeleteOrgRunner is a wrapper for "DELETE /orgs/{org}/actions/runners/{runner_id}".
+	// This function handles HTTP error wrapping.
+	DeleteOrgRunner(ctx context.Context, orgName string, id int64) error
+	// ListOrgInstallations is a wrapper for "GET /orgs/{org}/installations".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgInstallations(ctx context.Context, orgName string) ([]*github.Installation, error)
+	// DeleteInstallation is a wrapper for "DELETE /app/installations/{installation_id}".
+	// This function handles HTTP error wrapping.
+	DeleteInstallation(ctx context.Context, id int64) error
+	// ListOrgProjectsV2 is a wrapper for the "organization.projectsV2" GraphQL query.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgProjectsV2(ctx context.Context, orgName string) ([]*projectV2, error)
+	// ListOrgHooks is a wrapper for "GET /orgs/{org}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgHooks(ctx context.Context, orgName string) ([]*github.Hook, error)
+	// CreateOrgHook is a wrapper for "POST /orgs/{org}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateOrgHook(ctx context.Context, orgName string, req *github.Hook) (*github.Hook, error)
+	// EditOrgHook is a wrapper for "PATCH /orgs/{org}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditOrgHook(ctx context.Context, orgName string, id int64, req *github.Hook) (*github.Hook, error)
+	// DeleteOrgHook is a wrapper for "DELETE /orgs/{org}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteOrgHook(ctx context.Context, orgName string, id int64) error
 
 	// GetRepo is a wrapper for "GET /repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error)
 	// ListOrgRepos is a wrapper for "GET /orgs/{org}/repos".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
-	ListOrgRepos(ctx context.Context, org string) ([]*github.Repository, error)
+	ListOrgRepos(ctx context.Context, org string, progress gitprovider.ListProgressFunc) ([]*github.Repository, error)
+	// SearchReposPage is a wrapper for "GET /search/repositories".
+	// This function handles HTTP error wrapping, and validates the server result.
+	SearchReposPage(ctx context.Context, query string, perPage, page int) ([]*github.Repository, error)
 	// ListUserRepos is a wrapper for "GET /users/{username}/repos".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListUserRepos(ctx context.Context, username string) ([]*github.Repository, error)
+	// ListStarredRepos is a wrapper for "GET /users/{username}/starred".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListStarredRepos(ctx context.Context, username string) ([]*github.Repository, error)
+	// ListLicenses is a wrapper for "GET /licenses".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListLicenses(ctx context.Context) ([]*github.License, error)
+	// ListGitignoreTemplates is a wrapper for "GET /gitignore/templates".
+	// This function handles HTTP error wrapping.
+	ListGitignoreTemplates(ctx context.Context) ([]string, error)
 	// CreateRepo is a wrapper for "POST /user/repos" (if orgName == "")
 	// or "POST /orgs/{org}/repos" (if orgName != "").
 	// This function handles HTTP error wrapping, and validates the server result.
@@ -66,13 +134,209 @@ type githubClient interface {
 	// This function handles HTTP error wrapping.
 	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
 	DeleteRepo(ctx context.Context, owner, repo string) error
+	// StarRepo is a wrapper for "PUT /user/starred/{owner}/{repo}".
+	// This function handles HTTP error wrapping.
+	StarRepo(ctx context.Context, owner, repo string) error
+	// UnstarRepo is a wrapper for "DELETE /user/starred/{owner}/{repo}".
+	// This function handles HTTP error wrapping.
+	UnstarRepo(ctx context.Context, owner, repo string) error
+	// IsRepoStarred is a wrapper for "GET /user/starred/{owner}/{repo}".
+	// This function handles HTTP error wrapping.
+	IsRepoStarred(ctx context.Context, owner, repo string) (bool, error)
+	// GetRepoSubscription is a wrapper for "GET /repos/{owner}/{repo}/subscription".
+	// This function handles HTTP error wrapping. nil is returned if there's no subscription.
+	GetRepoSubscription(ctx context.Context, owner, repo string) (*github.Subscription, error)
+	// SetRepoSubscription is a wrapper for "PUT /repos/{owner}/{repo}/subscription".
+	// This function handles HTTP error wrapping.
+	SetRepoSubscription(ctx context.Context, owner, repo string, req *github.Subscription) error
+	// DeleteRepoSubscription is a wrapper for "DELETE /repos/{owner}/{repo}/subscription".
+	// This function handles HTTP error wrapping.
+	DeleteRepoSubscription(ctx context.Context, owner, repo string) error
+	// CountIssues is a wrapper for "GET /search/issues", returning the total count of issues
+	// matching query without fetching them.
+	// This function handles HTTP error wrapping.
+	CountIssues(ctx context.Context, query string) (int, error)
+	// TransferRepo is a wrapper for "POST /repos/{owner}/{repo}/transfer".
+	// This function handles HTTP error wrapping. GitHub transfers the repository asynchronously.
+	TransferRepo(ctx context.Context, owner, repo, newOwner string) error
+	// GetRef is a wrapper for "GET /repos/{owner}/{repo}/git/ref/{ref}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, error)
+	// DeleteRef is a wrapper for "DELETE /repos/{owner}/{repo}/git/refs/{ref}".
+	// This function handles HTTP error wrapping.
+	DeleteRef(ctx context.Context, owner, repo, ref string) error
+	// ListBranches is a wrapper for "GET /repos/{owner}/{repo}/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error)
+	// RenameBranch is a wrapper for "POST /repos/{owner}/{repo}/branches/{branch}/rename".
+	// This function handles HTTP error wrapping. GitHub moves the default branch, the branch
+	// protection and the open pull requests along with the branch.
+	RenameBranch(ctx context.Context, owner, repo, branch, newName string) error
+	// GetRawFile is a wrapper for "GET /repos/{owner}/{repo}/contents/{path}" using the raw media type.
+	// This function handles HTTP error wrapping. The caller must close the returned body.
+	GetRawFile(ctx context.Context, owner, repo, path, ref string) (io.ReadCloser, error)
+
+	// ListLabels is a wrapper for "GET /repos/{owner}/{repo}/labels".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListLabels(ctx context.Context, owner, repo string) ([]*github.Label, error)
+	// CreateLabel is a wrapper for "POST /repos/{owner}/{repo}/labels".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateLabel(ctx context.Context, owner, repo string, req *github.Label) (*github.Label, error)
+	// EditLabel is a wrapper for "PATCH /repos/{owner}/{repo}/labels/{name}".
+	// The label is renamed to req.Name using the "new_name" parameter.
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditLabel(ctx context.Context, owner, repo, name string, req *github.Label) (*github.Label, error)
+	// DeleteLabel is a wrapper for "DELETE /repos/{owner}/{repo}/labels/{name}".
+	// This function handles HTTP error wrapping.
+	DeleteLabel(ctx context.Context, owner, repo, name string) error
+
+	// ListHooks is a wrapper for "GET /repos/{owner}/{repo}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error)
+	// CreateHook is a wrapper for "POST /repos/{owner}/{repo}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error)
+	// EditHook is a wrapper for "PATCH /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error)
+	// DeleteHook is a wrapper for "DELETE /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteHook(ctx context.Context, owner, repo string, id int64) error
+	// ListHookDeliveries is a wrapper for "GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListHookDeliveries(ctx context.Context, owner, repo string, id int64) ([]*github.HookDelivery, error)
+	// RedeliverHookDelivery is a wrapper for
+	// "POST /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id}/attempts".
+	// This function handles HTTP error wrapping.
+	RedeliverHookDelivery(ctx context.Context, owner, repo string, hookID, deliveryID int64) error
+	// ListRecentHookDeliveries is a wrapper for "GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries".
+	// This function only returns the first page, i.e. the most recent deliveries, handles HTTP
+	// error wrapping, and validates the server result.
+	ListRecentHookDeliveries(ctx context.Context, owner, repo string, id int64) ([]*github.HookDelivery, error)
+	// PingHook is a wrapper for "POST /repos/{owner}/{repo}/hooks/{hook_id}/pings".
+	// This function handles HTTP error wrapping.
+	PingHook(ctx context.Context, owner, repo string, id int64) error
+
+	// ListProtectedBranches is a wrapper for "GET /repos/{owner}/{repo}/branches?protected=true".
+	// This function handles pagination, and HTTP error wrapping.
+	ListProtectedBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error)
+	// GetBranchProtection is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping, and returns ErrNotFound if the branch isn't protected.
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error)
+	// UpdateBranchProtection is a wrapper for "PUT /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping.
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, req *github.ProtectionRequest) (*github.Protection, error)
+	// RemoveBranchProtection is a wrapper for "DELETE /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping.
+	RemoveBranchProtection(ctx context.Context, owner, repo, branch string) error
+	// GetCodeownersErrors is a wrapper for "GET /repos/{owner}/{repo}/codeowners/errors".
+	// This function handles HTTP error wrapping.
+	GetCodeownersErrors(ctx context.Context, owner, repo string) ([]*github.CodeownersError, error)
+
+	// ListTagProtection is a wrapper for "GET /repos/{owner}/{repo}/tags/protection".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListTagProtection(ctx context.Context, owner, repo string) ([]*github.TagProtection, error)
+	// CreateTagProtection is a wrapper for "POST /repos/{owner}/{repo}/tags/protection".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateTagProtection(ctx context.Context, owner, repo, pattern string) (*github.TagProtection, error)
+	// DeleteTagProtection is a wrapper for "DELETE /repos/{owner}/{repo}/tags/protection/{tag_protection_id}".
+	// This function handles HTTP error wrapping.
+	DeleteTagProtection(ctx context.Context, owner, repo string, id int64) error
+
+	// ListMilestones is a wrapper for "GET /repos/{owner}/{repo}/milestones".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListMilestones(ctx context.Context, owner, repo, state string) ([]*github.Milestone, error)
+
+	// GetReleaseByTag is a wrapper for "GET /repos/{owner}/{repo}/releases/tags/{tag}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error)
+	// ListReleases is a wrapper for "GET /repos/{owner}/{repo}/releases".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error)
+	// DownloadReleaseAsset is a wrapper for "GET /repos/{owner}/{repo}/releases/assets/{asset_id}".
+	// This function handles HTTP error wrapping. The caller must close the returned body.
+	DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64) (io.ReadCloser, error)
+	// UploadReleaseAsset is a wrapper for "POST /repos/{owner}/{repo}/releases/{release_id}/assets"
+	// at the uploads API.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, name, contentType string, content io.Reader) (*github.ReleaseAsset, error)
 
 	// ListKeys is a wrapper for "GET /repos/{owner}/{repo}/keys".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, error)
 	// ListCommitsPage is a wrapper for "GET /repos/{owner}/{repo}/commits".
+	// No commits are returned if the repository is empty.
 	// This function handles pagination, HTTP error wrapping.
 	ListCommitsPage(ctx context.Context, owner, repo, branch string, perPage int, page int) ([]*github.Commit, error)
+	// ListCommitComments is a wrapper for "GET /repos/{owner}/{repo}/commits/{commit_sha}/comments".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCommitComments(ctx context.Context, owner, repo, sha string) ([]*commitCommentAPI, error)
+	// ListCommitCommentReactions is a wrapper for "GET /repos/{owner}/{repo}/comments/{comment_id}/reactions".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCommitCommentReactions(ctx context.Context, owner, repo string, id int64) ([]*github.Reaction, error)
+	// CreateCommitCommentReaction is a wrapper for "POST /repos/{owner}/{repo}/comments/{comment_id}/reactions".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateCommitCommentReaction(ctx context.Context, owner, repo string, id int64, content string) (*github.Reaction, error)
+	// ListIssueReactions is a wrapper for "GET /repos/{owner}/{repo}/issues/{issue_number}/reactions".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListIssueReactions(ctx context.Context, owner, repo string, number int) ([]*github.Reaction, error)
+	// CreateIssueReaction is a wrapper for "POST /repos/{owner}/{repo}/issues/{issue_number}/reactions".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateIssueReaction(ctx context.Context, owner, repo string, number int, content string) (*github.Reaction, error)
+	// ListReviews is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error)
+	// CreateReview is a wrapper for "POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateReview(ctx context.Context, owner, repo string, number int, req *github.PullRequestReviewRequest) (*github.PullRequestReview, error)
+	// DismissReview is a wrapper for "PUT /repos/{owner}/{repo}/pulls/{pull_number}/reviews/{review_id}/dismissals".
+	// This function handles HTTP error wrapping.
+	DismissReview(ctx context.Context, owner, repo string, number int, id int64, message string) error
+	// RequestReviewers is a wrapper for "POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers".
+	// This function handles HTTP error wrapping.
+	RequestReviewers(ctx context.Context, owner, repo string, number int, logins []string) error
+	// ListIssueComments is a wrapper for "GET /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles pagination, HTTP error wrapping.
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error)
+	// GetIssueComment is a wrapper for "GET /repos/{owner}/{repo}/issues/comments/{comment_id}".
+	// This function handles HTTP error wrapping.
+	GetIssueComment(ctx context.Context, owner, repo string, id int64) (*github.IssueComment, error)
+	// ListPullRequests is a wrapper for "GET /repos/{owner}/{repo}/pulls".
+	// This function handles pagination, HTTP error wrapping.
+	ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error)
+	// ListPullRequestFiles is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/files".
+	// This function handles pagination, HTTP error wrapping.
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*github.CommitFile, error)
+	// GetPullRequestDiff is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}", requesting the diff media type.
+	// This function handles HTTP error wrapping.
+	GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error)
+	// EditIssue is a wrapper for "PATCH /repos/{owner}/{repo}/issues/{issue_number}".
+	// This function handles HTTP error wrapping.
+	EditIssue(ctx context.Context, owner, repo string, number int, req *github.IssueRequest) (*github.Issue, error)
+	// RemoveIssueMilestone is a wrapper for "PATCH /repos/{owner}/{repo}/issues/{issue_number}",
+	// removing the issue from its milestone.
+	// This function handles HTTP error wrapping.
+	RemoveIssueMilestone(ctx context.Context, owner, repo string, number int) (*github.Issue, error)
+	// CreateIssueComment is a wrapper for "POST /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles HTTP error wrapping.
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error)
+	// ListReviewComments is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/comments".
+	// This function handles pagination, HTTP error wrapping.
+	ListReviewComments(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error)
+	// CreateReviewComment is a wrapper for "POST /repos/{owner}/{repo}/pulls/{pull_number}/comments".
+	// If req.InReplyTo is set, the comment is created as a reply to that comment.
+	// This function handles HTTP error wrapping.
+	CreateReviewComment(ctx context.Context, owner, repo string, number int, req *github.PullRequestComment) (*github.PullRequestComment, error)
+	// ListReviewThreads is a wrapper for the "repository.pullRequest.reviewThreads" GraphQL query.
+	// This function handles pagination, HTTP error wrapping.
+	ListReviewThreads(ctx context.Context, owner, repo string, number int) ([]*reviewThread, error)
+	// ResolveReviewThread is a wrapper for the "resolveReviewThread" GraphQL mutation.
+	// This function handles HTTP error wrapping.
+	ResolveReviewThread(ctx context.Context, threadID string) error
+	// EnablePullRequestAutoMerge is a wrapper for the "enablePullRequestAutoMerge" GraphQL mutation.
+	// mergeMethod is one of MERGE, SQUASH or REBASE.
+	// This function handles HTTP error wrapping.
+	EnablePullRequestAutoMerge(ctx context.Context, pullRequestID, mergeMethod string) error
 	// CreateKey is a wrapper for "POST /repos/{owner}/{repo}/keys".
 	// This function handles HTTP error wrapping, and validates the server result.
 	CreateKey(ctx context.Context, owner, repo string, req *github.Key) (*github.Key, error)
@@ -80,6 +344,19 @@ type githubClient interface {
 	// This function handles HTTP error wrapping.
 	DeleteKey(ctx context.Context, owner, repo string, id int64) error
 
+	// GetRepoActionsPermissions is a wrapper for "GET /repos/{owner}/{repo}/actions/permissions".
+	// This function handles HTTP error wrapping.
+	GetRepoActionsPermissions(ctx context.Context, owner, repo string) (*github.ActionsPermissionsRepository, error)
+	// EditRepoActionsPermissions is a wrapper for "PUT /repos/{owner}/{repo}/actions/permissions".
+	// This function handles HTTP error wrapping.
+	EditRepoActionsPermissions(ctx context.Context, owner, repo string, req github.ActionsPermissionsRepository) error
+	// GetRepoActionsAllowed is a wrapper for "GET /repos/{owner}/{repo}/actions/permissions/selected-actions".
+	// This function handles HTTP error wrapping.
+	GetRepoActionsAllowed(ctx context.Context, owner, repo string) (*github.ActionsAllowed, error)
+	// EditRepoActionsAllowed is a wrapper for "PUT /repos/{owner}/{repo}/actions/permissions/selected-actions".
+	// This function handles HTTP error wrapping.
+	EditRepoActionsAllowed(ctx context.Context, owner, repo string, req github.ActionsAllowed) error
+
 	// GetTeamPermissions is a wrapper for "GET /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetTeamPermissions(ctx context.Context, orgName, repo, teamName string) (map[string]bool, error)
@@ -101,6 +378,9 @@ type githubClient interface {
 type githubClientImpl struct {
 	c                  *github.Client
 	destructiveActions bool
+	// downloadClient requests the release assets from the storage backend, without the
+	// credentials of c. http.DefaultClient is used if nil.
+	downloadClient *http.Client
 }
 
 // githubClientImpl implements githubClient.
@@ -110,6 +390,15 @@ func (c *githubClientImpl) Client() *github.Client {
 	return c.c
 }
 
+func (c *githubClientImpl) GetOrgMembership(ctx context.Context, orgName string) (*github.Membership, error) {
+	// GET /user/memberships/orgs/{org}
+	apiObj, _, err := c.c.Organizations.GetOrgMembership(ctx, "", orgName)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *githubClientImpl) GetOrg(ctx context.Context, orgName string) (*github.Organization, error) {
 	// GET /orgs/{org}
 	apiObj, _, err := c.c.Organizations.Get(ctx, orgName)
@@ -123,10 +412,25 @@ func (c *githubClientImpl) GetOrg(ctx context.Context, orgName string) (*github.
 	return apiObj, nil
 }
 
+func (c *githubClientImpl) EditOrg(ctx context.Context, orgName string, req *github.Organization) error {
+	// PATCH /orgs/{org}
+	_, _, err := c.c.Organizations.Edit(ctx, orgName, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetUser(ctx context.Context, login string) (*github.User, error) {
+	// GET /users/{username}
+	apiObj, _, err := c.c.Users.Get(ctx, login)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *githubClientImpl) ListOrgs(ctx context.Context) ([]*github.Organization, error) {
 	apiObjs := []*github.Organization{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func() (*github.Response, error) {
 		// GET /user/orgs
 		pageObjs, resp, listErr := c.c.Organizations.List(ctx, "", opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -148,7 +452,7 @@ func (c *githubClientImpl) ListOrgs(ctx context.Context) ([]*github.Organization
 func (c *githubClientImpl) ListOrgTeamMembers(ctx context.Context, orgName, teamName string) ([]*github.User, error) {
 	apiObjs := []*github.User{}
 	opts := &github.TeamListTeamMembersOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
 		// GET /orgs/{org}/teams/{team_slug}/members
 		pageObjs, resp, listErr := c.c.Teams.ListTeamMembersBySlug(ctx, orgName, teamName, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -172,7 +476,7 @@ func (c *githubClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]
 	// List all teams, using pagination. This does not contain information about the members
 	apiObjs := []*github.Team{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func() (*github.Response, error) {
 		// GET /orgs/{org}/teams
 		pageObjs, resp, listErr := c.c.Teams.ListTeams(ctx, orgName, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -191,6 +495,188 @@ func (c *githubClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]
 	return apiObjs, nil
 }
 
+func (c *githubClientImpl) GetOrgTeam(ctx context.Context, orgName, teamName string) (*github.Team, error) {
+	// GET /orgs/{org}/teams/{team_slug}
+	apiObj, _, err := c.c.Teams.GetTeamBySlug(ctx, orgName, teamName)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the Slug field is set.
+	if apiObj.Slug == nil {
+		return nil, fmt.Errorf("didn't expect slug to be nil for team: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditOrgTeam(ctx context.Context, orgName, teamName string, req *github.NewTeam, removeParent bool) (*github.Team, error) {
+	// PATCH /orgs/{org}/teams/{team_slug}
+	apiObj, _, err := c.c.Teams.EditTeamBySlug(ctx, orgName, teamName, *req, removeParent)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the Slug field is set.
+	if apiObj.Slug == nil {
+		return nil, fmt.Errorf("didn't expect slug to be nil for team: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListOrgRunners(ctx context.Context, orgName string) ([]*github.Runner, error) {
+	apiObjs := []*github.Runner{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /orgs/{org}/actions/runners
+		page, resp, listErr := c.c.Actions.ListOrganizationRunners(ctx, orgName, opts)
+		if page != nil {
+			apiObjs = append(apiObjs, page.Runners...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateRunnerAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateOrgRunnerRegistrationToken(ctx context.Context, orgName string) (*github.RegistrationToken, error) {
+	// POST /orgs/{org}/actions/runners/registration-token
+	apiObj, _, err := c.c.Actions.CreateOrganizationRegistrationToken(ctx, orgName)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the token is set. Don't include apiObj in the error, as it's a secret.
+	if apiObj.Token == nil {
+		return nil, fmt.Errorf("didn't expect registration token to be nil: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteOrgRunner(ctx context.Context, orgName string, id int64) error {
+	// DELETE /orgs/{org}/actions/runners/{runner_id}
+	_, err := c.c.Actions.RemoveOrganizationRunner(ctx, orgName, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListOrgInstallations(ctx context.Context, orgName string) ([]*github.Installation, error) {
+	apiObjs := []*github.Installation{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /orgs/{org}/installations
+		page, resp, listErr := c.c.Organizations.ListInstallations(ctx, orgName, opts)
+		if page != nil {
+			apiObjs = append(apiObjs, page.Installations...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateAppAuthorizationAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) DeleteInstallation(ctx context.Context, id int64) error {
+	// DELETE /app/installations/{installation_id}
+	_, err := c.c.Apps.DeleteInstallation(ctx, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListOrgProjectsV2(ctx context.Context, orgName string) ([]*projectV2, error) {
+	// GraphQL connections return at most 100 nodes per page
+	perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0)
+	if perPage <= 0 || perPage > maxProjectsV2PerPage {
+		perPage = maxProjectsV2PerPage
+	}
+
+	apiObjs := []*projectV2{}
+	variables := map[string]interface{}{"login": orgName, "first": perPage, "after": nil}
+	for {
+		// POST /graphql
+		result := &orgProjectsV2Query{}
+		if err := graphQLQuery(ctx, c.c, orgProjectsV2QueryString, variables, result); err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, result.Organization.ProjectsV2.Nodes...)
+		pageInfo := result.Organization.ProjectsV2.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = pageInfo.EndCursor
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateProjectV2API(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListOrgHooks(ctx context.Context, orgName string) ([]*github.Hook, error) {
+	apiObjs := []*github.Hook{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /orgs/{org}/hooks
+		pageObjs, resp, listErr := c.c.Organizations.ListHooks(ctx, orgName, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateOrgHook(ctx context.Context, orgName string, req *github.Hook) (*github.Hook, error) {
+	// POST /orgs/{org}/hooks
+	apiObj, _, err := c.c.Organizations.CreateHook(ctx, orgName, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditOrgHook(ctx context.Context, orgName string, id int64, req *github.Hook) (*github.Hook, error) {
+	// PATCH /orgs/{org}/hooks/{hook_id}
+	apiObj, _, err := c.c.Organizations.EditHook(ctx, orgName, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteOrgHook(ctx context.Context, orgName string, id int64) error {
+	// DELETE /orgs/{org}/hooks/{hook_id}
+	_, err := c.c.Organizations.DeleteHook(ctx, orgName, id)
+	return handleHTTPError(err)
+}
+
 func (c *githubClientImpl) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error) {
 	// GET /repos/{owner}/{repo}
 	apiObj, _, err := c.c.Repositories.Get(ctx, owner, repo)
@@ -209,13 +695,21 @@ func validateRepositoryAPIResp(apiObj *github.Repository, err error) (*github.Re
 	return apiObj, nil
 }
 
-func (c *githubClientImpl) ListOrgRepos(ctx context.Context, org string) ([]*github.Repository, error) {
+func (c *githubClientImpl) ListOrgRepos(ctx context.Context, org string, progress gitprovider.ListProgressFunc) ([]*github.Repository, error) {
 	var apiObjs []*github.Repository
 	opts := &github.RepositoryListByOrgOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	pageSize := 0
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
 		// GET /orgs/{org}/repos
 		pageObjs, resp, listErr := c.c.Repositories.ListByOrg(ctx, org, opts)
 		apiObjs = append(apiObjs, pageObjs...)
+		if listErr == nil && progress != nil {
+			// All pages but the last one are full, hence the first one tells the page size
+			if pageSize == 0 {
+				pageSize = len(pageObjs)
+			}
+			progress(listProgress(resp, len(apiObjs), pageSize))
+		}
 		return resp, listErr
 	})
 	if err != nil {
@@ -224,6 +718,21 @@ func (c *githubClientImpl) ListOrgRepos(ctx context.Context, org string) ([]*git
 	return validateRepositoryObjects(apiObjs)
 }
 
+func (c *githubClientImpl) SearchReposPage(ctx context.Context, query string, perPage, page int) ([]*github.Repository, error) {
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{
+			PerPage: gitprovider.CallOptionsFromContext(ctx).GetPerPage(perPage),
+			Page:    page,
+		},
+	}
+	// GET /search/repositories
+	result, _, err := c.c.Search.Repositories(ctx, query, opts)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return validateRepositoryObjects(result.Repositories)
+}
+
 func validateRepositoryObjects(apiObjs []*github.Repository) ([]*github.Repository, error) {
 	for _, apiObj := range apiObjs {
 		// Make sure apiObj is valid
@@ -237,7 +746,7 @@ func validateRepositoryObjects(apiObjs []*github.Repository) ([]*github.Reposito
 func (c *githubClientImpl) ListUserRepos(ctx context.Context, username string) ([]*github.Repository, error) {
 	var apiObjs []*github.Repository
 	opts := &github.RepositoryListOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
 		// GET /users/{username}/repos
 		pageObjs, resp, listErr := c.c.Repositories.List(ctx, username, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -249,6 +758,53 @@ func (c *githubClientImpl) ListUserRepos(ctx context.Context, username string) (
 	return validateRepositoryObjects(apiObjs)
 }
 
+func (c *githubClientImpl) ListStarredRepos(ctx context.Context, username string) ([]*github.Repository, error) {
+	var apiObjs []*github.Repository
+	opts := &github.ActivityListStarredOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /users/{username}/starred
+		pageObjs, resp, listErr := c.c.Activity.ListStarred(ctx, username, opts)
+		for _, pageObj := range pageObjs {
+			apiObjs = append(apiObjs, pageObj.Repository)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateStarredRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListLicenses(ctx context.Context) ([]*github.License, error) {
+	// GET /licenses
+	apiObjs, _, err := c.c.Licenses.List(ctx)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateLicenseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListGitignoreTemplates(ctx context.Context) ([]string, error) {
+	// GET /gitignore/templates
+	names, _, err := c.c.Gitignores.List(ctx)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return names, nil
+}
+
 func (c *githubClientImpl) CreateRepo(ctx context.Context, orgName string, req *github.Repository) (*github.Repository, error) {
 	// POST /user/repos (if orgName == "")
 	// POST /orgs/{org}/repos (if orgName != "")
@@ -266,20 +822,499 @@ func (c *githubClientImpl) UpdateRepo(ctx context.Context, owner, repo string, r
 	return validateRepositoryAPIResp(apiObj, err)
 }
 
-func (c *githubClientImpl) DeleteRepo(ctx context.Context, owner, repo string) error {
-	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
-	if !c.destructiveActions {
-		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+func (c *githubClientImpl) GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, error) {
+	// GET /repos/{owner}/{repo}/git/ref/{ref}
+	apiObj, _, err := c.c.Git.GetRef(ctx, owner, repo, ref)
+	if err != nil {
+		return nil, handleHTTPError(err)
 	}
-	// DELETE /repos/{owner}/{repo}
+	// Make sure the ref points to an object
+	if apiObj.GetObject().SHA == nil {
+		return nil, fmt.Errorf("didn't expect reference object SHA to be nil: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteRef(ctx context.Context, owner, repo, ref string) error {
+	// DELETE /repos/{owner}/{repo}/git/refs/{ref}
+	_, err := c.c.Git.DeleteRef(ctx, owner, repo, ref)
+	// GitHub responds with 422 instead of 404 for refs that don't exist
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusUnprocessableEntity && errResp.Message == refNotExistsMagicString {
+		return fmt.Errorf("ref %q: %w", ref, gitprovider.ErrNotFound)
+	}
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error) {
+	apiObjs := []*github.Branch{}
+	opts := &github.BranchListOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		pageObjs, resp, listErr := c.c.Repositories.ListBranches(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) RenameBranch(ctx context.Context, owner, repo, branch, newName string) error {
+	// POST /repos/{owner}/{repo}/branches/{branch}/rename
+	_, _, err := c.c.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetRawFile(ctx context.Context, owner, repo, path, ref string) (io.ReadCloser, error) {
+	// GET /repos/{owner}/{repo}/contents/{path}?ref={ref}
+	escapedPath := (&url.URL{Path: strings.TrimSuffix(path, "/")}).String()
+	u := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, escapedPath)
+	if ref != "" {
+		u += "?ref=" + url.QueryEscape(ref)
+	}
+	req, err := c.c.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", rawMediaType)
+	// BareDo leaves the body open on success, and closes it on error
+	resp, err := c.c.BareDo(ctx, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return resp.Body, nil
+}
+
+func (c *githubClientImpl) DeleteRepo(ctx context.Context, owner, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repos/{owner}/{repo}
 	_, err := c.c.Repositories.Delete(ctx, owner, repo)
 	return handleHTTPError(err)
 }
 
+func (c *githubClientImpl) StarRepo(ctx context.Context, owner, repo string) error {
+	// PUT /user/starred/{owner}/{repo}
+	_, err := c.c.Activity.Star(ctx, owner, repo)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) UnstarRepo(ctx context.Context, owner, repo string) error {
+	// DELETE /user/starred/{owner}/{repo}
+	_, err := c.c.Activity.Unstar(ctx, owner, repo)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) IsRepoStarred(ctx context.Context, owner, repo string) (bool, error) {
+	// GET /user/starred/{owner}/{repo}
+	// GitHub responds with 404 Not Found if the repository isn't starred, which go-github maps to false.
+	starred, _, err := c.c.Activity.IsStarred(ctx, owner, repo)
+	return starred, handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetRepoSubscription(ctx context.Context, owner, repo string) (*github.Subscription, error) {
+	// GET /repos/{owner}/{repo}/subscription
+	// GitHub responds with 404 Not Found if there's no subscription, which go-github maps to nil.
+	apiObj, _, err := c.c.Activity.GetRepositorySubscription(ctx, owner, repo)
+	return apiObj, handleHTTPError(err)
+}
+
+func (c *githubClientImpl) SetRepoSubscription(ctx context.Context, owner, repo string, req *github.Subscription) error {
+	// PUT /repos/{owner}/{repo}/subscription
+	_, _, err := c.c.Activity.SetRepositorySubscription(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DeleteRepoSubscription(ctx context.Context, owner, repo string) error {
+	// DELETE /repos/{owner}/{repo}/subscription
+	_, err := c.c.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) CountIssues(ctx context.Context, query string) (int, error) {
+	// GET /search/issues
+	// Only the total count is of interest, hence request the smallest possible page
+	res, _, err := c.c.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return 0, handleHTTPError(err)
+	}
+	return res.GetTotal(), nil
+}
+
+func (c *githubClientImpl) TransferRepo(ctx context.Context, owner, repo, newOwner string) error {
+	// POST /repos/{owner}/{repo}/transfer
+	_, _, err := c.c.Repositories.Transfer(ctx, owner, repo, github.TransferRequest{NewOwner: newOwner})
+	// 202 Accepted means that the transfer has been scheduled
+	var acceptedErr *github.AcceptedError
+	if errors.As(err, &acceptedErr) {
+		return nil
+	}
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListLabels(ctx context.Context, owner, repo string) ([]*github.Label, error) {
+	apiObjs := []*github.Label{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/labels
+		pageObjs, resp, listErr := c.c.Issues.ListLabels(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateLabelAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateLabel(ctx context.Context, owner, repo string, req *github.Label) (*github.Label, error) {
+	// POST /repos/{owner}/{repo}/labels
+	apiObj, _, err := c.c.Issues.CreateLabel(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateLabelAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+// labelEditRequest is the body of "PATCH /repos/{owner}/{repo}/labels/{name}". go-github sends
+// the new name as "name", while the API documents "new_name" for renaming the label.
+type labelEditRequest struct {
+	NewName     *string `json:"new_name,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+func (c *githubClientImpl) EditLabel(ctx context.Context, owner, repo, name string, req *github.Label) (*github.Label, error) {
+	// PATCH /repos/{owner}/{repo}/labels/{name}
+	u := fmt.Sprintf("repos/%s/%s/labels/%s", owner, repo, url.PathEscape(name))
+	httpReq, err := c.c.NewRequest(http.MethodPatch, u, &labelEditRequest{
+		NewName:     req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiObj := &github.Label{}
+	if _, err := c.c.Do(ctx, httpReq, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateLabelAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	// DELETE /repos/{owner}/{repo}/labels/{name}
+	_, err := c.c.Issues.DeleteLabel(ctx, owner, repo, name)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	apiObjs := []*github.Hook{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/hooks
+		pageObjs, resp, listErr := c.c.Repositories.ListHooks(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error) {
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, _, err := c.c.Repositories.CreateHook(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error) {
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, _, err := c.c.Repositories.EditHook(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteHook(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	_, err := c.c.Repositories.DeleteHook(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListHookDeliveries(ctx context.Context, owner, repo string, id int64) ([]*github.HookDelivery, error) {
+	apiObjs := []*github.HookDelivery{}
+	opts := &github.ListCursorOptions{}
+	err := allCursorPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries
+		pageObjs, resp, listErr := c.c.Repositories.ListHookDeliveries(ctx, owner, repo, id, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookDeliveryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) RedeliverHookDelivery(ctx context.Context, owner, repo string, hookID, deliveryID int64) error {
+	// POST /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id}/attempts
+	_, _, err := c.c.Repositories.RedeliverHookDelivery(ctx, owner, repo, hookID, deliveryID)
+	// 202 Accepted means that the redelivery has been scheduled
+	var acceptedErr *github.AcceptedError
+	if errors.As(err, &acceptedErr) {
+		return nil
+	}
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListRecentHookDeliveries(ctx context.Context, owner, repo string, id int64) ([]*github.HookDelivery, error) {
+	// GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries
+	apiObjs, _, err := c.c.Repositories.ListHookDeliveries(ctx, owner, repo, id, &github.ListCursorOptions{})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookDeliveryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) PingHook(ctx context.Context, owner, repo string, id int64) error {
+	// POST /repos/{owner}/{repo}/hooks/{hook_id}/pings
+	_, err := c.c.Repositories.PingHook(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListProtectedBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error) {
+	apiObjs := []*github.Branch{}
+	opts := &github.BranchListOptions{Protected: github.Bool(true)}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/branches?protected=true
+		pageObjs, resp, listErr := c.c.Repositories.ListBranches(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, _, err := c.c.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return nil, fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, req *github.ProtectionRequest) (*github.Protection, error) {
+	// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, _, err := c.c.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) RemoveBranchProtection(ctx context.Context, owner, repo, branch string) error {
+	// DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+	_, err := c.c.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetCodeownersErrors(ctx context.Context, owner, repo string) ([]*github.CodeownersError, error) {
+	// GET /repos/{owner}/{repo}/codeowners/errors
+	apiObj, _, err := c.c.Repositories.GetCodeownersErrors(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj.Errors, nil
+}
+
+func (c *githubClientImpl) ListTagProtection(ctx context.Context, owner, repo string) ([]*github.TagProtection, error) {
+	// GET /repos/{owner}/{repo}/tags/protection
+	apiObjs, _, err := c.c.Repositories.ListTagProtection(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateTagProtectionAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateTagProtection(ctx context.Context, owner, repo, pattern string) (*github.TagProtection, error) {
+	// POST /repos/{owner}/{repo}/tags/protection
+	apiObj, _, err := c.c.Repositories.CreateTagProtection(ctx, owner, repo, pattern)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateTagProtectionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteTagProtection(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/tags/protection/{tag_protection_id}
+	_, err := c.c.Repositories.DeleteTagProtection(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListMilestones(ctx context.Context, owner, repo, state string) ([]*github.Milestone, error) {
+	apiObjs := []*github.Milestone{}
+	opts := &github.MilestoneListOptions{State: state}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/milestones
+		pageObjs, resp, listErr := c.c.Issues.ListMilestones(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateMilestoneAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error) {
+	// GET /repos/{owner}/{repo}/releases/tags/{tag}
+	apiObj, _, err := c.c.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error) {
+	apiObjs := []*github.RepositoryRelease{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/releases
+		pageObjs, resp, listErr := c.c.Repositories.ListReleases(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReleaseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64) (io.ReadCloser, error) {
+	// GET /repos/{owner}/{repo}/releases/assets/{asset_id}
+	// GitHub redirects to a pre-signed URL of the storage backend, which must be requested
+	// without the credentials of the client.
+	followRedirectsClient := c.downloadClient
+	if followRedirectsClient == nil {
+		followRedirectsClient = http.DefaultClient
+	}
+	body, _, err := c.c.Repositories.DownloadReleaseAsset(ctx, owner, repo, id, followRedirectsClient)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return body, nil
+}
+
+func (c *githubClientImpl) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, name, contentType string, content io.Reader) (*github.ReleaseAsset, error) {
+	// go-github only uploads from an *os.File, hence build the request by hand. GitHub requires
+	// the size of the asset up front.
+	content, size, err := contentSize(content)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?name=%s", owner, repo, releaseID, url.QueryEscape(name))
+	req, err := c.c.NewUploadRequest(u, content, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/releases/{release_id}/assets
+	apiObj := &github.ReleaseAsset{}
+	if _, err := c.c.Do(ctx, req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAssetAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
 func (c *githubClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, error) {
 	apiObjs := []*github.Key{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func() (*github.Response, error) {
 		// GET /repos/{owner}/{repo}/keys
 		pageObjs, resp, listErr := c.c.Repositories.ListKeys(ctx, owner, repo, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -301,7 +1336,7 @@ func (c *githubClientImpl) ListCommitsPage(ctx context.Context, owner, repo, bra
 	apiObjs := make([]*github.Commit, 0)
 	lcOpts := &github.CommitsListOptions{
 		ListOptions: github.ListOptions{
-			PerPage: perPage,
+			PerPage: gitprovider.CallOptionsFromContext(ctx).GetPerPage(perPage),
 			Page:    page,
 		},
 		SHA: branch,
@@ -315,14 +1350,317 @@ func (c *githubClientImpl) ListCommitsPage(ctx context.Context, owner, repo, bra
 			Tree: &github.Tree{
 				SHA: c.Commit.Tree.SHA,
 			},
-			Author:  c.Commit.Author,
-			Message: c.Commit.Message,
-			URL:     c.HTMLURL,
+			Author:       c.Commit.Author,
+			Committer:    c.Commit.Committer,
+			Message:      c.Commit.Message,
+			URL:          c.HTMLURL,
+			Verification: c.Commit.Verification,
 		})
 	}
 
+	// GitHub responds with 409 Conflict if the repository is empty
+	ghErrorResponse := &github.ErrorResponse{}
+	if errors.As(listErr, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusConflict {
+		return apiObjs, nil
+	}
 	if listErr != nil {
-		return nil, listErr
+		return nil, handleHTTPError(listErr)
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListCommitComments(ctx context.Context, owner, repo, sha string) ([]*commitCommentAPI, error) {
+	apiObjs := []*commitCommentAPI{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/commits/{commit_sha}/comments
+		// go-github doesn't decode the "line" field of commit comments, hence do the request manually.
+		u := url.URL{
+			Path:     fmt.Sprintf("repos/%s/%s/commits/%s/comments", owner, repo, sha),
+			RawQuery: listOptionsQuery(opts).Encode(),
+		}
+		req, err := c.c.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageObjs []*commitCommentAPI
+		resp, listErr := c.c.Do(ctx, req, &pageObjs)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateCommitCommentAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListCommitCommentReactions(ctx context.Context, owner, repo string, id int64) ([]*github.Reaction, error) {
+	apiObjs := []*github.Reaction{}
+	opts := &github.ListCommentReactionOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/comments/{comment_id}/reactions
+		pageObjs, resp, listErr := c.c.Reactions.ListCommentReactions(ctx, owner, repo, id, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateReactionObjects(apiObjs)
+}
+
+func (c *githubClientImpl) CreateCommitCommentReaction(ctx context.Context, owner, repo string, id int64, content string) (*github.Reaction, error) {
+	// POST /repos/{owner}/{repo}/comments/{comment_id}/reactions
+	apiObj, _, err := c.c.Reactions.CreateCommentReaction(ctx, owner, repo, id, content)
+	return validateReactionAPIResp(apiObj, err)
+}
+
+func (c *githubClientImpl) ListIssueReactions(ctx context.Context, owner, repo string, number int) ([]*github.Reaction, error) {
+	apiObjs := []*github.Reaction{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{issue_number}/reactions
+		pageObjs, resp, listErr := c.c.Reactions.ListIssueReactions(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateReactionObjects(apiObjs)
+}
+
+func (c *githubClientImpl) CreateIssueReaction(ctx context.Context, owner, repo string, number int, content string) (*github.Reaction, error) {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/reactions
+	apiObj, _, err := c.c.Reactions.CreateIssueReaction(ctx, owner, repo, number, content)
+	return validateReactionAPIResp(apiObj, err)
+}
+
+func (c *githubClientImpl) ListReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	apiObjs := []*github.PullRequestReview{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+		pageObjs, resp, listErr := c.c.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if err := validateReviewAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateReview(ctx context.Context, owner, repo string, number int, req *github.PullRequestReviewRequest) (*github.PullRequestReview, error) {
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObj, _, err := c.c.PullRequests.CreateReview(ctx, owner, repo, number, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReviewAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DismissReview(ctx context.Context, owner, repo string, number int, id int64, message string) error {
+	// PUT /repos/{owner}/{repo}/pulls/{pull_number}/reviews/{review_id}/dismissals
+	_, _, err := c.c.PullRequests.DismissReview(ctx, owner, repo, number, id, &github.PullRequestReviewDismissalRequest{
+		Message: &message,
+	})
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) RequestReviewers(ctx context.Context, owner, repo string, number int, logins []string) error {
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers
+	_, _, err := c.c.PullRequests.RequestReviewers(ctx, owner, repo, number, github.ReviewersRequest{
+		Reviewers: logins,
+	})
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	apiObjs := []*github.IssueComment{}
+	opts := &github.IssueListCommentsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{issue_number}/comments
+		pageObjs, resp, listErr := c.c.Issues.ListComments(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetIssueComment(ctx context.Context, owner, repo string, id int64) (*github.IssueComment, error) {
+	// GET /repos/{owner}/{repo}/issues/comments/{comment_id}
+	apiObj, _, err := c.c.Issues.GetComment(ctx, owner, repo, id)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	apiObjs := []*github.PullRequest{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls
+		pageObjs, resp, listErr := c.c.PullRequests.List(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*github.CommitFile, error) {
+	apiObjs := []*github.CommitFile{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/files
+		pageObjs, resp, listErr := c.c.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}
+	diff, _, err := c.c.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	return diff, nil
+}
+
+func (c *githubClientImpl) EditIssue(ctx context.Context, owner, repo string, number int, req *github.IssueRequest) (*github.Issue, error) {
+	// PATCH /repos/{owner}/{repo}/issues/{issue_number}
+	apiObj, _, err := c.c.Issues.Edit(ctx, owner, repo, number, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) RemoveIssueMilestone(ctx context.Context, owner, repo string, number int) (*github.Issue, error) {
+	// PATCH /repos/{owner}/{repo}/issues/{issue_number}
+	apiObj, _, err := c.c.Issues.RemoveMilestone(ctx, owner, repo, number)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error) {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/comments
+	apiObj, _, err := c.c.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListReviewComments(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error) {
+	apiObjs := []*github.PullRequestComment{}
+	opts := &github.PullRequestListCommentsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/comments
+		pageObjs, resp, listErr := c.c.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateReviewComment(ctx context.Context, owner, repo string, number int, req *github.PullRequestComment) (*github.PullRequestComment, error) {
+	var apiObj *github.PullRequestComment
+	var err error
+	if req.InReplyTo != nil {
+		// POST /repos/{owner}/{repo}/pulls/{pull_number}/comments
+		apiObj, _, err = c.c.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, number, req.GetBody(), req.GetInReplyTo())
+	} else {
+		// POST /repos/{owner}/{repo}/pulls/{pull_number}/comments
+		apiObj, _, err = c.c.PullRequests.CreateComment(ctx, owner, repo, number, req)
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListReviewThreads(ctx context.Context, owner, repo string, number int) ([]*reviewThread, error) {
+	apiObjs := []*reviewThread{}
+	variables := map[string]interface{}{"owner": owner, "repo": repo, "number": number, "after": nil}
+	for {
+		// POST /graphql
+		result := &reviewThreadsQuery{}
+		if err := graphQLQuery(ctx, c.c, reviewThreadsQueryString, variables, result); err != nil {
+			return nil, err
+		}
+		if result.Repository.PullRequest == nil {
+			return nil, fmt.Errorf("pull request %d: %w", number, gitprovider.ErrNotFound)
+		}
+		threads := result.Repository.PullRequest.ReviewThreads
+		apiObjs = append(apiObjs, threads.Nodes...)
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = threads.PageInfo.EndCursor
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ResolveReviewThread(ctx context.Context, threadID string) error {
+	// POST /graphql
+	return graphQLQuery(ctx, c.c, resolveReviewThreadMutation, map[string]interface{}{"id": threadID}, &struct{}{})
+}
+
+func (c *githubClientImpl) EnablePullRequestAutoMerge(ctx context.Context, pullRequestID, mergeMethod string) error {
+	variables := map[string]interface{}{"id": pullRequestID, "method": mergeMethod}
+	// POST /graphql
+	return graphQLQuery(ctx, c.c, enableAutoMergeMutation, variables, &struct{}{})
+}
+
+func validateReactionAPIResp(apiObj *github.Reaction, err error) (*github.Reaction, error) {
+	// If the response contained an error, return
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure apiObj is valid
+	if err := validateReactionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func validateReactionObjects(apiObjs []*github.Reaction) ([]*github.Reaction, error) {
+	for _, apiObj := range apiObjs {
+		// Make sure apiObj is valid
+		if err := validateReactionAPI(apiObj); err != nil {
+			return nil, err
+		}
 	}
 	return apiObjs, nil
 }
@@ -345,6 +1683,36 @@ func (c *githubClientImpl) DeleteKey(ctx context.Context, owner, repo string, id
 	return handleHTTPError(err)
 }
 
+func (c *githubClientImpl) GetRepoActionsPermissions(ctx context.Context, owner, repo string) (*github.ActionsPermissionsRepository, error) {
+	// GET /repos/{owner}/{repo}/actions/permissions
+	apiObj, _, err := c.c.Repositories.GetActionsPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRepoActionsPermissions(ctx context.Context, owner, repo string, req github.ActionsPermissionsRepository) error {
+	// PUT /repos/{owner}/{repo}/actions/permissions
+	_, _, err := c.c.Repositories.EditActionsPermissions(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetRepoActionsAllowed(ctx context.Context, owner, repo string) (*github.ActionsAllowed, error) {
+	// GET /repos/{owner}/{repo}/actions/permissions/selected-actions
+	apiObj, _, err := c.c.Repositories.GetActionsAllowed(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRepoActionsAllowed(ctx context.Context, owner, repo string, req github.ActionsAllowed) error {
+	// PUT /repos/{owner}/{repo}/actions/permissions/selected-actions
+	_, _, err := c.c.Repositories.EditActionsAllowed(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
 func (c *githubClientImpl) GetTeamPermissions(ctx context.Context, orgName, repo, teamName string) (map[string]bool, error) {
 	// GET /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}
 	apiObj, _, err := c.c.Teams.IsTeamRepoBySlug(ctx, orgName, teamName, orgName, repo)
@@ -362,7 +1730,7 @@ func (c *githubClientImpl) GetTeamPermissions(ctx context.Context, orgName, repo
 func (c *githubClientImpl) ListRepoTeams(ctx context.Context, orgName, repo string) ([]*github.Team, error) {
 	apiObjs := []*github.Team{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func() (*github.Response, error) {
 		// GET /repos/{owner}/{repo}/teams
 		pageObjs, resp, listErr := c.c.Repositories.ListTeams(ctx, orgName, repo, opts)
 		apiObjs = append(apiObjs, pageObjs...)
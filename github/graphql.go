@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// graphQLPath is the path of the GraphQL endpoint relative to the REST API base URL. On
+// GitHub.com the REST API is served from the root ("/graphql"), whereas GitHub Enterprise
+// serves it from "/api/v3/" and the GraphQL API from "/api/graphql".
+const graphQLPath = "../graphql"
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLError is an error reported in the "errors" array of a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphQLResponse is the body of a GraphQL response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// graphQLQuery runs the given GraphQL query against the GitHub GraphQL API using the HTTP
+// client, authentication and base URL of c, and decodes the "data" field of the response into
+// result. Errors in the response are translated into ErrNotFound and ErrForbidden where possible.
+func graphQLQuery(ctx context.Context, c *github.Client, query string, variables map[string]interface{}, result interface{}) error {
+	req, err := c.NewRequest(http.MethodPost, graphQLPath, &graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	resp := &graphQLResponse{}
+	if _, err := c.Do(ctx, req, resp); err != nil {
+		return handleHTTPError(err)
+	}
+	if len(resp.Errors) != 0 {
+		return graphQLErrors(resp.Errors)
+	}
+	return json.Unmarshal(resp.Data, result)
+}
+
+// graphQLErrors combines the errors reported in a GraphQL response into one error, marking it
+// as ErrNotFound or ErrForbidden if the first error is of such type.
+func graphQLErrors(errs []graphQLError) error {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Message)
+	}
+	err := fmt.Errorf("GraphQL request failed: %s", strings.Join(msgs, "; "))
+	switch errs[0].Type {
+	case "NOT_FOUND":
+		return validation.NewMultiError(err, gitprovider.ErrNotFound)
+	case "FORBIDDEN", "INSUFFICIENT_SCOPES":
+		return validation.NewMultiError(err, gitprovider.ErrForbidden)
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// acceptedPermissionsHeader lists the fine-grained permissions accepted for a request, e.g.
+// "contents=read; issues=write,pull_requests=write". It's returned to fine-grained personal access
+// tokens and GitHub App tokens; alternative sets of permissions are separated by ";".
+const acceptedPermissionsHeader = "X-Accepted-GitHub-Permissions"
+
+// permissionLevels orders the access levels of fine-grained permissions.
+//
+//nolint:gochecknoglobals
+var permissionLevels = map[string]int{"read": 1, "write": 2, "admin": 3}
+
+// classicPermissionScopes maps fine-grained permissions to the OAuth scopes of classic tokens
+// granting them, for read and write access respectively.
+//
+//nolint:gochecknoglobals
+var classicPermissionScopes = map[string][2]string{
+	"actions":                     {"repo", "repo"},
+	"administration":              {"repo", "repo"},
+	"contents":                    {"repo", "repo"},
+	"deployments":                 {"repo_deployment", "repo_deployment"},
+	"issues":                      {"repo", "repo"},
+	"members":                     {"read:org", "write:org"},
+	"metadata":                    {"", ""},
+	"organization_administration": {"read:org", "admin:org"},
+	"organization_projects":       {"read:project", "project"},
+	"packages":                    {"read:packages", "write:packages"},
+	"pull_requests":               {"repo", "repo"},
+	"repository_hooks":            {"read:repo_hook", "write:repo_hook"},
+	"repository_projects":         {"repo", "repo"},
+	"secrets":                     {"repo", "repo"},
+	"statuses":                    {"repo:status", "repo:status"},
+	"workflows":                   {"workflow", "workflow"},
+}
+
+// parseAcceptedPermissions parses the value of the acceptedPermissionsHeader into the sets of
+// permissions allowing the request, each of the form "name=level[,name=level...]".
+func parseAcceptedPermissions(value string) []string {
+	sets := []string{}
+	for _, set := range strings.Split(value, ";") {
+		permissions := []string{}
+		for _, permission := range strings.Split(set, ",") {
+			if permission = strings.TrimSpace(permission); permission != "" {
+				permissions = append(permissions, permission)
+			}
+		}
+		if len(permissions) != 0 {
+			sets = append(sets, strings.Join(permissions, ","))
+		}
+	}
+	return sets
+}
+
+// missingPermissionError returns a *MissingTokenPermissionError if GitHub told which
+// fine-grained permissions a request answered with 403 Forbidden accepts.
+func missingPermissionError(httpErr gitprovider.HTTPError, header http.Header) (*gitprovider.MissingTokenPermissionError, bool) {
+	sets := parseAcceptedPermissions(header.Get(acceptedPermissionsHeader))
+	if len(sets) == 0 {
+		return nil, false
+	}
+	return &gitprovider.MissingTokenPermissionError{HTTPError: httpErr, Permission: sets[0], Alternatives: sets[1:]}, true
+}
+
+// parsePermission splits a permission of the form "name=level" into its name and access level.
+// The level defaults to "read".
+func parsePermission(permission string) (string, string, error) {
+	name, level, found := strings.Cut(permission, "=")
+	if !found {
+		level = "read"
+	}
+	if _, ok := permissionLevels[level]; name == "" || !ok {
+		return "", "", fmt.Errorf("invalid permission %q, expected e.g. \"contents=write\": %w", permission, gitprovider.ErrInvalidArgument)
+	}
+	return name, level, nil
+}
+
+// ValidatePermissions checks upfront that the token has the given fine-grained permissions,
+// e.g. "administration=write" or "contents=read", so callers can fail fast with an actionable
+// error instead of failing halfway through. A *MissingTokenPermissionError is returned for the
+// first permission the token lacks.
+//
+// For GitHub App installations, the permissions granted to the installation are checked. For
+// classic personal access tokens, the permissions are mapped to the OAuth scopes granting them.
+// The permissions of fine-grained personal access tokens can't be inspected, hence
+// ErrNoProviderSupport is returned for them; failing calls return a
+// *MissingTokenPermissionError then.
+func (c *Client) ValidatePermissions(ctx context.Context, required ...string) error {
+	type permission struct{ name, level string }
+	permissions := make([]permission, 0, len(required))
+	for _, p := range required {
+		name, level, err := parsePermission(p)
+		if err != nil {
+			return err
+		}
+		permissions = append(permissions, permission{name, level})
+	}
+
+	missing := func(name, level string) error {
+		p := name + "=" + level
+		msg := fmt.Sprintf("the token lacks the %q permission", p)
+		return &gitprovider.MissingTokenPermissionError{
+			HTTPError:  gitprovider.HTTPError{ErrorMessage: msg, Message: msg},
+			Permission: p,
+		}
+	}
+
+	if c.installationPermissions != nil {
+		granted, err := c.installationPermissions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range permissions {
+			if permissionLevels[granted[p.name]] < permissionLevels[p.level] {
+				return missing(p.name, p.level)
+			}
+		}
+		return nil
+	}
+
+	scopes, err := c.tokenScopes(ctx)
+	if err != nil {
+		if errors.Is(err, gitprovider.ErrMissingHeader) {
+			return fmt.Errorf("the permissions of fine-grained tokens can't be inspected: %w", gitprovider.ErrNoProviderSupport)
+		}
+		return err
+	}
+	for _, p := range permissions {
+		classic, ok := classicPermissionScopes[p.name]
+		if !ok {
+			return fmt.Errorf("no OAuth scope known for permission %q: %w", p.name, gitprovider.ErrNoProviderSupport)
+		}
+		scope := classic[0]
+		if p.level != "read" {
+			scope = classic[1]
+		}
+		if scope == "" {
+			continue
+		}
+		if res := gitprovider.NewScopeCheckResult(scopes, []string{scope}, impliedScopes); len(res.Missing) != 0 {
+			return missing(p.name, p.level)
+		}
+	}
+	return nil
+}
+
+// installationPermissions returns a function getting the permissions granted to the
+// installation of the GitHub App, using gh whose requests are authenticated as the app.
+func (a *gitHubApp) installationPermissions(gh *github.Client) func(ctx context.Context) (map[string]string, error) {
+	return func(ctx context.Context) (map[string]string, error) {
+		// GET /app/installations/{installation_id}
+		apiObj, _, err := gh.Apps.GetInstallation(ctx, a.installationID)
+		if err != nil {
+			return nil, handleHTTPError(err)
+		}
+		// The permissions are a struct of optional fields, named like the permissions
+		granted := map[string]string{}
+		data, err := json.Marshal(apiObj.GetPermissions())
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &granted); err != nil {
+			return nil, err
+		}
+		return granted, nil
+	}
+}
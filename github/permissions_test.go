@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_handleHTTPError_MissingTokenPermission(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(acceptedPermissionsHeader, "administration=write; contents=write, pull_requests=write")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `{"message":"Resource not accessible by personal access token"}`)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &githubClientImpl{c: gh}
+
+	_, err := c.GetOrg(context.Background(), "foo")
+	if !errors.Is(err, gitprovider.ErrForbidden) {
+		t.Errorf("GetOrg() error = %v, want ErrForbidden", err)
+	}
+	permErr := &gitprovider.MissingTokenPermissionError{}
+	if !errors.As(err, &permErr) {
+		t.Fatalf("GetOrg() error = %v, want MissingTokenPermissionError", err)
+	}
+	if permErr.Permission != "administration=write" {
+		t.Errorf("Permission = %q, want %q", permErr.Permission, "administration=write")
+	}
+	if want := []string{"contents=write,pull_requests=write"}; !reflect.DeepEqual(permErr.Alternatives, want) {
+		t.Errorf("Alternatives = %q, want %q", permErr.Alternatives, want)
+	}
+}
+
+func TestClient_ValidatePermissions(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         []string
+		installation   map[string]string
+		required       []string
+		wantPermission string
+		wantErr        error
+	}{
+		{
+			name:     "classic token",
+			scopes:   []string{"repo, read:org"},
+			required: []string{"contents=write", "administration=write", "members", "metadata=read"},
+		},
+		{
+			name:           "classic token, missing scope",
+			scopes:         []string{"repo, read:org"},
+			required:       []string{"contents=write", "members=write"},
+			wantPermission: "members=write",
+			wantErr:        gitprovider.ErrMissingTokenPermission,
+		},
+		{
+			name:     "fine-grained token",
+			required: []string{"contents=write"},
+			wantErr:  gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:         "app installation",
+			installation: map[string]string{"contents": "write", "metadata": "read"},
+			required:     []string{"contents=read", "metadata"},
+		},
+		{
+			name:           "app installation, insufficient level",
+			installation:   map[string]string{"contents": "read", "metadata": "read"},
+			required:       []string{"metadata", "contents=write"},
+			wantPermission: "contents=write",
+			wantErr:        gitprovider.ErrMissingTokenPermission,
+		},
+		{
+			name:     "invalid level",
+			required: []string{"contents=delete"},
+			wantErr:  gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.scopes != nil {
+					w.Header()["X-Oauth-Scopes"] = tt.scopes
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			c := newClient(gh, DefaultDomain, false)
+			if tt.installation != nil {
+				c.installationPermissions = func(context.Context) (map[string]string, error) {
+					return tt.installation, nil
+				}
+			}
+
+			err := c.ValidatePermissions(context.Background(), tt.required...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidatePermissions() error = %v, want %v", err, tt.wantErr)
+			}
+			permErr := &gitprovider.MissingTokenPermissionError{}
+			if errors.As(err, &permErr) && permErr.Permission != tt.wantPermission {
+				t.Errorf("Permission = %q, want %q", permErr.Permission, tt.wantPermission)
+			}
+		})
+	}
+}
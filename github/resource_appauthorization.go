@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newAppAuthorization(apiObj *github.Installation, ref gitprovider.OrganizationRef) *appAuthorization {
+	return &appAuthorization{
+		i:   *apiObj,
+		ref: ref,
+	}
+}
+
+var _ gitprovider.AppAuthorization = &appAuthorization{}
+
+type appAuthorization struct {
+	i   github.Installation
+	ref gitprovider.OrganizationRef
+}
+
+func (a *appAuthorization) Get() gitprovider.AppAuthorizationInfo {
+	return appAuthorizationFromAPI(&a.i)
+}
+
+func (a *appAuthorization) APIObject() interface{} {
+	return &a.i
+}
+
+func (a *appAuthorization) Organization() gitprovider.OrganizationRef {
+	return a.ref
+}
+
+func appAuthorizationFromAPI(apiObj *github.Installation) gitprovider.AppAuthorizationInfo {
+	// GitHub doesn't report who installed the app, so Installer is left empty.
+	return gitprovider.AppAuthorizationInfo{
+		ID:          apiObj.GetID(),
+		AppName:     apiObj.GetAppSlug(),
+		Permissions: installationPermissionsFromAPI(apiObj.Permissions),
+	}
+}
+
+// installationPermissionsFromAPI flattens the set permissions of an installation into a map
+// keyed by the permission name used by the GitHub API, e.g. "contents" -> "read".
+func installationPermissionsFromAPI(apiObj *github.InstallationPermissions) map[string]string {
+	permissions := map[string]string{}
+	if apiObj == nil {
+		return permissions
+	}
+	// InstallationPermissions only consists of *string fields with omitempty JSON tags,
+	// hence a JSON round-trip yields exactly the permissions that are set.
+	b, err := json.Marshal(apiObj)
+	if err != nil {
+		return permissions
+	}
+	_ = json.Unmarshal(b, &permissions)
+	return permissions
+}
+
+// validateAppAuthorizationAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateAppAuthorizationAPI(apiObj *github.Installation) error {
+	return validateAPIObject("GitHub.Installation", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.AppSlug == nil {
+			validator.Required("AppSlug")
+		}
+	})
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranchProtection(c *BranchProtectionClient, branch string, apiObj *github.Protection) *branchProtection {
+	bp := &branchProtection{
+		branch: branch,
+		c:      c,
+	}
+	if apiObj != nil {
+		bp.p = *apiObj
+	}
+	return bp
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	// branch is the name of the protected branch, as GitHub doesn't return it.
+	branch string
+	p      github.Protection
+	c      *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	return branchProtectionFromAPI(bp.branch, &bp.p)
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Branch != bp.branch {
+		return fmt.Errorf("can't move the protection rule of branch %q to %q: %w", bp.branch, info.Branch, gitprovider.ErrInvalidArgument)
+	}
+	if err := validateBranchProtectionSupport(info); err != nil {
+		return err
+	}
+	branchProtectionInfoToAPIObj(&info, &bp.p)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server. GitHub replaces the whole
+// rule, hence the settings that can't be expressed using BranchProtectionInfo are sent as they
+// were returned.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, err := bp.c.c.UpdateBranchProtection(ctx, bp.c.ref.GetIdentity(), bp.c.ref.GetRepository(), bp.branch, branchProtectionToRequest(&bp.p))
+	if err != nil {
+		return err
+	}
+	bp.p = *apiObj
+	return nil
+}
+
+// Delete removes the protection of the branch.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+	return bp.c.c.RemoveBranchProtection(ctx, bp.c.ref.GetIdentity(), bp.c.ref.GetRepository(), bp.branch)
+}
+
+// validateBranchProtectionSupport returns ErrNoProviderSupport for the rules that the GitHub
+// branch protection API can't manage. Rules of wildcard patterns are only available through the
+// GraphQL API.
+func validateBranchProtectionSupport(info gitprovider.BranchProtectionInfo) error {
+	if gitprovider.IsBranchPattern(info.Branch) {
+		return fmt.Errorf("github branch protection can't protect the branch pattern %q: %w", info.Branch, gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// enforcesAdmins returns whether the protection applies to administrators too.
+func enforcesAdmins(apiObj *github.Protection) bool {
+	return apiObj.EnforceAdmins != nil && apiObj.EnforceAdmins.Enabled
+}
+
+// statusChecksFromAPI returns the required status checks.
+func statusChecksFromAPI(apiObj *github.RequiredStatusChecks) []gitprovider.StatusCheck {
+	if apiObj == nil {
+		return nil
+	}
+	// Older GitHub Enterprise versions only return the deprecated contexts
+	if len(apiObj.Checks) == 0 {
+		return gitprovider.StatusChecks(apiObj.Contexts...)
+	}
+	checks := make([]gitprovider.StatusCheck, 0, len(apiObj.Checks))
+	for _, check := range apiObj.Checks {
+		checks = append(checks, gitprovider.StatusCheck{Context: check.Context, AppID: check.AppID})
+	}
+	return checks
+}
+
+func branchProtectionFromAPI(branch string, apiObj *github.Protection) gitprovider.BranchProtectionInfo {
+	info := gitprovider.BranchProtectionInfo{
+		Branch:                  branch,
+		RequiredApprovals:       gitprovider.IntVar(0),
+		RequireCodeOwnerReviews: gitprovider.BoolVar(false),
+		RequiredStatusChecks:    statusChecksFromAPI(apiObj.RequiredStatusChecks),
+		EnforceAdmins:           gitprovider.BoolVar(enforcesAdmins(apiObj)),
+	}
+	if reviews := apiObj.RequiredPullRequestReviews; reviews != nil {
+		info.RequiredApprovals = gitprovider.IntVar(reviews.RequiredApprovingReviewCount)
+		info.RequireCodeOwnerReviews = gitprovider.BoolVar(reviews.RequireCodeOwnerReviews)
+	}
+	if restrictions := apiObj.Restrictions; restrictions != nil {
+		for _, user := range restrictions.Users {
+			info.AllowedPushers = append(info.AllowedPushers, user.GetLogin())
+		}
+	}
+	return info
+}
+
+func branchProtectionInfoToAPIObj(info *gitprovider.BranchProtectionInfo, apiObj *github.Protection) {
+	// Reviews are only required if approvals or the reviews of code owners are
+	reviews := apiObj.RequiredPullRequestReviews
+	if reviews == nil {
+		reviews = &github.PullRequestReviewsEnforcement{}
+	}
+	if info.RequiredApprovals != nil {
+		reviews.RequiredApprovingReviewCount = *info.RequiredApprovals
+	}
+	if info.RequireCodeOwnerReviews != nil {
+		reviews.RequireCodeOwnerReviews = *info.RequireCodeOwnerReviews
+	}
+	if reviews.RequiredApprovingReviewCount == 0 && !reviews.RequireCodeOwnerReviews {
+		reviews = nil
+	}
+	apiObj.RequiredPullRequestReviews = reviews
+
+	if len(info.RequiredStatusChecks) == 0 {
+		apiObj.RequiredStatusChecks = nil
+	} else {
+		// Keep the app that must provide the existing checks, unless another one is desired
+		existing := map[string]*github.RequiredStatusCheck{}
+		if apiObj.RequiredStatusChecks != nil {
+			for _, check := range apiObj.RequiredStatusChecks.Checks {
+				existing[check.Context] = check
+			}
+		} else {
+			apiObj.RequiredStatusChecks = &github.RequiredStatusChecks{}
+		}
+		checks := make([]*github.RequiredStatusCheck, 0, len(info.RequiredStatusChecks))
+		for _, check := range info.RequiredStatusChecks {
+			apiCheck := &github.RequiredStatusCheck{Context: check.Context, AppID: check.AppID}
+			if e, ok := existing[check.Context]; ok && check.AppID == nil {
+				apiCheck.AppID = e.AppID
+			}
+			checks = append(checks, apiCheck)
+		}
+		// Only one of the contexts and checks may be set
+		apiObj.RequiredStatusChecks.Contexts = nil
+		apiObj.RequiredStatusChecks.Checks = checks
+	}
+
+	if info.EnforceAdmins != nil {
+		apiObj.EnforceAdmins = &github.AdminEnforcement{Enabled: *info.EnforceAdmins}
+	}
+
+	users := make([]*github.User, 0, len(info.AllowedPushers))
+	for _, pusher := range info.AllowedPushers {
+		users = append(users, &github.User{Login: github.String(pusher)})
+	}
+	if apiObj.Restrictions == nil {
+		if len(users) != 0 {
+			apiObj.Restrictions = &github.BranchRestrictions{Users: users}
+		}
+	} else {
+		apiObj.Restrictions.Users = users
+		// Empty restrictions would only allow administrators to push, instead of everyone
+		if len(users) == 0 && len(apiObj.Restrictions.Teams) == 0 && len(apiObj.Restrictions.Apps) == 0 {
+			apiObj.Restrictions = nil
+		}
+	}
+}
+
+// branchProtectionToRequest converts the protection into the request that sets it.
+func branchProtectionToRequest(apiObj *github.Protection) *github.ProtectionRequest {
+	req := &github.ProtectionRequest{
+		RequiredStatusChecks: apiObj.RequiredStatusChecks,
+		EnforceAdmins:        enforcesAdmins(apiObj),
+	}
+	if reviews := apiObj.RequiredPullRequestReviews; reviews != nil {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+		}
+	}
+	if restrictions := apiObj.Restrictions; restrictions != nil {
+		// The users and teams must not be nil, but empty instead
+		req.Restrictions = &github.BranchRestrictionsRequest{
+			Users: []string{},
+			Teams: []string{},
+		}
+		for _, user := range restrictions.Users {
+			req.Restrictions.Users = append(req.Restrictions.Users, user.GetLogin())
+		}
+		for _, team := range restrictions.Teams {
+			req.Restrictions.Teams = append(req.Restrictions.Teams, team.GetSlug())
+		}
+		for _, app := range restrictions.Apps {
+			req.Restrictions.Apps = append(req.Restrictions.Apps, app.GetSlug())
+		}
+	}
+	if apiObj.RequireLinearHistory != nil {
+		req.RequireLinearHistory = github.Bool(apiObj.RequireLinearHistory.Enabled)
+	}
+	if apiObj.AllowForcePushes != nil {
+		req.AllowForcePushes = github.Bool(apiObj.AllowForcePushes.Enabled)
+	}
+	if apiObj.AllowDeletions != nil {
+		req.AllowDeletions = github.Bool(apiObj.AllowDeletions.Enabled)
+	}
+	if apiObj.RequiredConversationResolution != nil {
+		req.RequiredConversationResolution = github.Bool(apiObj.RequiredConversationResolution.Enabled)
+	}
+	return req
+}
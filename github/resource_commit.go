@@ -17,9 +17,12 @@ limitations under the License.
 package github
 
 import (
+	"context"
+
 	"github.com/google/go-github/v49/github"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 )
 
 func newCommit(c *CommitClient, commit *github.Commit) *commitType {
@@ -46,11 +49,114 @@ func (c *commitType) APIObject() interface{} {
 
 func commitFromAPI(apiObj *github.Commit) gitprovider.CommitInfo {
 	return gitprovider.CommitInfo{
-		Sha:       *apiObj.SHA,
-		TreeSha:   *apiObj.Tree.SHA,
-		Author:    *apiObj.Author.Name,
-		Message:   *apiObj.Message,
-		CreatedAt: *apiObj.Author.Date,
-		URL:       *apiObj.URL,
+		Sha:          *apiObj.SHA,
+		TreeSha:      *apiObj.Tree.SHA,
+		Author:       *apiObj.Author.Name,
+		AuthorEmail:  apiObj.Author.GetEmail(),
+		Message:      *apiObj.Message,
+		CreatedAt:    *apiObj.Author.Date,
+		URL:          *apiObj.URL,
+		Verification: commitVerificationFromAPI(apiObj),
+	}
+}
+
+// commitVerificationFromAPI maps the verification object of the commit. GitHub verifies the
+// signature against the keys of the committer, hence the committer is reported as the signer.
+func commitVerificationFromAPI(apiObj *github.Commit) *gitprovider.CommitVerification {
+	if apiObj.Verification == nil {
+		return nil
+	}
+	v := &gitprovider.CommitVerification{
+		Verified: apiObj.Verification.GetVerified(),
+		Reason:   apiObj.Verification.GetReason(),
 	}
+	if v.Verified {
+		v.Signer = apiObj.GetCommitter().GetEmail()
+	}
+	return v
+}
+
+// commitCommentAPI extends go-github's RepositoryComment with the line the comment was made on,
+// which isn't part of the go-github struct.
+type commitCommentAPI struct {
+	github.RepositoryComment
+
+	// Line is the line of the file the comment was made on.
+	Line *int `json:"line,omitempty"`
+}
+
+func newCommitComment(c *CommitClient, apiObj *commitCommentAPI) *commitComment {
+	return &commitComment{
+		k: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.CommitComment = &commitComment{}
+
+type commitComment struct {
+	k commitCommentAPI
+	c *CommitClient
+}
+
+func (c *commitComment) Get() gitprovider.CommitCommentInfo {
+	return commitCommentFromAPI(&c.k)
+}
+
+func (c *commitComment) APIObject() interface{} {
+	return &c.k
+}
+
+// AddReaction adds a reaction to the commit comment. Adding an already existing reaction is a no-op.
+func (c *commitComment) AddReaction(ctx context.Context, content gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	if err := validateReactionContent(content); err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/comments/{comment_id}/reactions
+	apiObj, err := c.c.c.CreateCommitCommentReaction(ctx, c.c.ref.GetIdentity(), c.c.ref.GetRepository(), c.k.GetID(), string(content))
+	if err != nil {
+		return nil, err
+	}
+	return newReaction(apiObj), nil
+}
+
+// ListReactions lists all reactions to the commit comment.
+//
+// ListReactions returns all available reactions, using multiple paginated requests if needed.
+func (c *commitComment) ListReactions(ctx context.Context) ([]gitprovider.Reaction, error) {
+	// GET /repos/{owner}/{repo}/comments/{comment_id}/reactions
+	apiObjs, err := c.c.c.ListCommitCommentReactions(ctx, c.c.ref.GetIdentity(), c.c.ref.GetRepository(), c.k.GetID())
+	if err != nil {
+		return nil, err
+	}
+	return newReactions(apiObjs), nil
+}
+
+func commitCommentFromAPI(apiObj *commitCommentAPI) gitprovider.CommitCommentInfo {
+	return gitprovider.CommitCommentInfo{
+		ID:        apiObj.GetID(),
+		Author:    apiObj.GetUser().GetLogin(),
+		Body:      apiObj.GetBody(),
+		Path:      apiObj.GetPath(),
+		Line:      apiObj.GetLine(),
+		CreatedAt: apiObj.GetCreatedAt(),
+	}
+}
+
+// GetLine returns the Line field if it's non-nil, zero value otherwise.
+func (c *commitCommentAPI) GetLine() int {
+	if c == nil || c.Line == nil {
+		return 0
+	}
+	return *c.Line
+}
+
+// validateCommitCommentAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateCommitCommentAPI(apiObj *commitCommentAPI) error {
+	return validateAPIObject("GitHub.RepositoryComment", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+	})
 }
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_commitCommentFromAPI(t *testing.T) {
+	data := []byte(`{"id": 42, "user": {"login": "octocat"}, "body": "nit", "path": "main.go", "position": 3, "line": 17, "created_at": "2020-01-01T00:00:00Z"}`)
+	apiObj := &commitCommentAPI{}
+	if err := json.Unmarshal(data, apiObj); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateCommitCommentAPI(apiObj); err != nil {
+		t.Fatal(err)
+	}
+
+	got := commitCommentFromAPI(apiObj)
+	want := gitprovider.CommitCommentInfo{
+		ID:        42,
+		Author:    "octocat",
+		Body:      "nit",
+		Path:      "main.go",
+		Line:      17,
+		CreatedAt: apiObj.GetCreatedAt(),
+	}
+	if got != want {
+		t.Errorf("commitCommentFromAPI() = %v, want %v", got, want)
+	}
+}
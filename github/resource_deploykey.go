@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/google/go-github/v49/github"
 
@@ -58,6 +59,11 @@ func (dk *deployKey) APIObject() interface{} {
 	return &dk.k
 }
 
+// LastUsedAt always returns nil, as GitHub doesn't expose when a deploy key was last used.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return nil
+}
+
 func (dk *deployKey) Repository() gitprovider.RepositoryRef {
 	return dk.c.ref
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newLabel(c *LabelClient, apiObj *github.Label) *label {
+	return &label{
+		l:    *apiObj,
+		name: apiObj.GetName(),
+		c:    c,
+	}
+}
+
+var _ gitprovider.Label = &label{}
+
+type label struct {
+	l github.Label
+	// name is the name of the label in GitHub, which is used to address it in Update and Delete,
+	// as Set might change l.Name.
+	name string
+	c    *LabelClient
+}
+
+func (l *label) ID() string {
+	return strconv.FormatInt(l.l.GetID(), 10)
+}
+
+func (l *label) Get() gitprovider.LabelInfo {
+	return labelFromAPI(&l.l)
+}
+
+func (l *label) Set(info gitprovider.LabelInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	labelInfoToAPIObj(&info, &l.l)
+	return nil
+}
+
+func (l *label) APIObject() interface{} {
+	return &l.l
+}
+
+func (l *label) Repository() gitprovider.RepositoryRef {
+	return l.c.ref
+}
+
+// Update will apply the desired state in this object to the server, renaming the label if
+// its name was changed using Set.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (l *label) Update(ctx context.Context) error {
+	// PATCH /repos/{owner}/{repo}/labels/{name}
+	apiObj, err := l.c.c.EditLabel(ctx, l.c.ref.GetIdentity(), l.c.ref.GetRepository(), l.name, &l.l)
+	if err != nil {
+		return err
+	}
+	l.l = *apiObj
+	l.name = apiObj.GetName()
+	return nil
+}
+
+// Delete deletes the label from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (l *label) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/labels/{name}
+	return l.c.c.DeleteLabel(ctx, l.c.ref.GetIdentity(), l.c.ref.GetRepository(), l.name)
+}
+
+func validateLabelAPI(apiObj *github.Label) error {
+	return validateAPIObject("GitHub.Label", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Name == nil {
+			validator.Required("Name")
+		}
+	})
+}
+
+func labelFromAPI(apiObj *github.Label) gitprovider.LabelInfo {
+	return gitprovider.LabelInfo{
+		Name:        apiObj.GetName(),
+		Color:       apiObj.Color,
+		Description: apiObj.Description,
+	}
+}
+
+func labelToAPI(info *gitprovider.LabelInfo) *github.Label {
+	l := &github.Label{}
+	labelInfoToAPIObj(info, l)
+	return l
+}
+
+func labelInfoToAPIObj(info *gitprovider.LabelInfo, apiObj *github.Label) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Name = &info.Name
+	// optional fields
+	if info.Color != nil {
+		apiObj.Color = info.Color
+	}
+	if info.Description != nil {
+		apiObj.Description = info.Description
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newMilestone(c *MilestoneClient, apiObj *github.Milestone) *milestone {
+	return &milestone{
+		m: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Milestone = &milestone{}
+
+type milestone struct {
+	m github.Milestone
+	c *MilestoneClient
+}
+
+func (m *milestone) Get() gitprovider.MilestoneInfo {
+	return milestoneFromAPI(&m.m)
+}
+
+func (m *milestone) APIObject() interface{} {
+	return &m.m
+}
+
+func (m *milestone) Repository() gitprovider.RepositoryRef {
+	return m.c.ref
+}
+
+func validateMilestoneAPI(apiObj *github.Milestone) error {
+	return validateAPIObject("GitHub.Milestone", func(validator validation.Validator) {
+		if apiObj.Number == nil {
+			validator.Required("Number")
+		}
+		if apiObj.Title == nil {
+			validator.Required("Title")
+		}
+		if apiObj.State == nil {
+			validator.Required("State")
+		}
+	})
+}
+
+func milestoneFromAPI(apiObj *github.Milestone) gitprovider.MilestoneInfo {
+	return gitprovider.MilestoneInfo{
+		Number:       apiObj.GetNumber(),
+		Title:        apiObj.GetTitle(),
+		Description:  apiObj.Description,
+		State:        gitprovider.MilestoneState(apiObj.GetState()),
+		DueDate:      apiObj.DueOn,
+		OpenIssues:   apiObj.GetOpenIssues(),
+		ClosedIssues: apiObj.GetClosedIssues(),
+		Progress:     gitprovider.MilestoneProgress(apiObj.GetOpenIssues(), apiObj.GetClosedIssues()),
+	}
+}
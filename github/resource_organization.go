@@ -32,6 +32,22 @@ func newOrganization(ctx *clientContext, apiObj *github.Organization, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		runners: &RunnersClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		appAuthorizations: &AppAuthorizationsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		projectBoards: &ProjectBoardsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &OrganizationWebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
 
@@ -43,7 +59,11 @@ type organization struct {
 	o   github.Organization
 	ref gitprovider.OrganizationRef
 
-	teams *TeamsClient
+	teams             *TeamsClient
+	runners           *RunnersClient
+	appAuthorizations *AppAuthorizationsClient
+	projectBoards     *ProjectBoardsClient
+	webhooks          *OrganizationWebhookClient
 }
 
 func (o *organization) Get() gitprovider.OrganizationInfo {
@@ -62,6 +82,22 @@ func (o *organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+func (o *organization) Runners() gitprovider.RunnersClient {
+	return o.runners
+}
+
+func (o *organization) AppAuthorizations() gitprovider.AppAuthorizationsClient {
+	return o.appAuthorizations
+}
+
+func (o *organization) ProjectBoards() gitprovider.ProjectBoardsClient {
+	return o.projectBoards
+}
+
+func (o *organization) Webhooks() gitprovider.OrganizationWebhookClient {
+	return o.webhooks
+}
+
 func organizationFromAPI(apiObj *github.Organization) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        apiObj.Name,
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newOrgWebhook(c *OrganizationWebhookClient, apiObj *github.Hook) *orgWebhook {
+	hasSecret := removeMaskedSecret(apiObj)
+	return &orgWebhook{
+		h:         *apiObj,
+		hasSecret: hasSecret,
+		c:         c,
+	}
+}
+
+var _ gitprovider.OrganizationWebhook = &orgWebhook{}
+
+type orgWebhook struct {
+	h github.Hook
+	// hasSecret is whether the webhook has a secret, as GitHub doesn't return the secret itself.
+	hasSecret bool
+	c         *OrganizationWebhookClient
+}
+
+func (wh *orgWebhook) ID() string {
+	return strconv.FormatInt(wh.h.GetID(), 10)
+}
+
+func (wh *orgWebhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *orgWebhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	return nil
+}
+
+func (wh *orgWebhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *orgWebhook) Organization() gitprovider.OrganizationRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set, otherwise GitHub keeps the current secret.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *orgWebhook) Update(ctx context.Context) error {
+	// PATCH /orgs/{org}/hooks/{hook_id}
+	apiObj, err := wh.c.c.EditOrgHook(ctx, wh.c.ref.Organization, wh.h.GetID(), &github.Hook{
+		Config: wh.h.Config,
+		Events: wh.h.Events,
+	})
+	if err != nil {
+		return err
+	}
+	wh.hasSecret = removeMaskedSecret(apiObj)
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the organization.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *orgWebhook) Delete(ctx context.Context) error {
+	// DELETE /orgs/{org}/hooks/{hook_id}
+	return wh.c.c.DeleteOrgHook(ctx, wh.c.ref.Organization, wh.h.GetID())
+}
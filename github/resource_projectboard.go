@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// maxProjectsV2PerPage is the maximum number of projects the GraphQL API returns per page.
+const maxProjectsV2PerPage = 100
+
+// orgProjectsV2QueryString lists the Projects (v2) of an organization, one page at a time.
+const orgProjectsV2QueryString = `query($login: String!, $first: Int!, $after: String) {
+  organization(login: $login) {
+    projectsV2(first: $first, after: $after) {
+      nodes { id number title url closed }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// projectV2 is a Projects (v2) project, as returned by the GraphQL API.
+type projectV2 struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Closed bool   `json:"closed"`
+}
+
+// orgProjectsV2Query is the "data" of the response to orgProjectsV2QueryString.
+type orgProjectsV2Query struct {
+	Organization struct {
+		ProjectsV2 struct {
+			Nodes    []*projectV2 `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"projectsV2"`
+	} `json:"organization"`
+}
+
+func newProjectBoard(apiObj *projectV2, ref gitprovider.OrganizationRef) *projectBoard {
+	return &projectBoard{
+		p:   *apiObj,
+		ref: ref,
+	}
+}
+
+var _ gitprovider.ProjectBoard = &projectBoard{}
+
+type projectBoard struct {
+	p   projectV2
+	ref gitprovider.OrganizationRef
+}
+
+func (p *projectBoard) Get() gitprovider.ProjectBoardInfo {
+	return projectBoardFromAPI(&p.p)
+}
+
+func (p *projectBoard) APIObject() interface{} {
+	return &p.p
+}
+
+func (p *projectBoard) Organization() gitprovider.OrganizationRef {
+	return p.ref
+}
+
+func projectBoardFromAPI(apiObj *projectV2) gitprovider.ProjectBoardInfo {
+	return gitprovider.ProjectBoardInfo{
+		ID:    apiObj.ID,
+		Title: apiObj.Title,
+		URL:   apiObj.URL,
+	}
+}
+
+// validateProjectV2API validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateProjectV2API(apiObj *projectV2) error {
+	return validateAPIObject("GitHub.ProjectV2", func(validator validation.Validator) {
+		if apiObj.ID == "" {
+			validator.Required("ID")
+		}
+	})
+}
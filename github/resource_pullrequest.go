@@ -17,14 +17,18 @@ limitations under the License.
 package github
 
 import (
+	"context"
+	"strings"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v49/github"
 )
 
-func newPullRequest(ctx *clientContext, apiObj *github.PullRequest) *pullrequest {
+func newPullRequest(ctx *clientContext, apiObj *github.PullRequest, ref gitprovider.RepositoryRef) *pullrequest {
 	return &pullrequest{
 		clientContext: ctx,
 		pr:            *apiObj,
+		ref:           ref,
 	}
 }
 
@@ -33,7 +37,8 @@ var _ gitprovider.PullRequest = &pullrequest{}
 type pullrequest struct {
 	*clientContext
 
-	pr github.PullRequest
+	pr  github.PullRequest
+	ref gitprovider.RepositoryRef
 }
 
 func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
@@ -44,6 +49,156 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Reviews gives access to the reviews of the pull request.
+func (pr *pullrequest) Reviews() gitprovider.ReviewClient {
+	return &ReviewClient{
+		clientContext: pr.clientContext,
+		ref:           pr.ref,
+		number:        pr.pr.GetNumber(),
+	}
+}
+
+// Comments gives access to the general and inline comments of the pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{
+		clientContext: pr.clientContext,
+		ref:           pr.ref,
+		number:        pr.pr.GetNumber(),
+		headSHA:       pr.pr.GetHead().GetSHA(),
+	}
+}
+
+// EnableAutoMerge enables auto-merge for the pull request, which requires auto-merge to be
+// allowed in the repository settings. The pull request is merged right away by GitHub if
+// it has no pending required checks.
+func (pr *pullrequest) EnableAutoMerge(ctx context.Context, mergeMethod gitprovider.MergeMethod) error {
+	c := &PullRequestClient{clientContext: pr.clientContext, ref: pr.ref}
+	if err := c.validateMergeMethod(ctx, mergeMethod); err != nil {
+		return err
+	}
+	// POST /graphql
+	return pr.c.EnablePullRequestAutoMerge(ctx, pr.pr.GetNodeID(), strings.ToUpper(string(mergeMethod)))
+}
+
+// Files lists the files changed by the pull request. GitHub lists at most 3000 files, and omits
+// the patches of large files.
+func (pr *pullrequest) Files(ctx context.Context) ([]gitprovider.PullRequestFile, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}/files
+	apiObjs, err := pr.c.ListPullRequestFiles(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	files := make([]gitprovider.PullRequestFile, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		files = append(files, gitprovider.PullRequestFile{
+			Path:         apiObj.GetFilename(),
+			PreviousPath: apiObj.GetPreviousFilename(),
+			ChangeType:   fileChangeTypeFromAPI(apiObj.GetStatus()),
+			Additions:    apiObj.GetAdditions(),
+			Deletions:    apiObj.GetDeletions(),
+			Patch:        apiObj.GetPatch(),
+		})
+	}
+	return files, nil
+}
+
+// Diff returns the unified diff of the pull request.
+func (pr *pullrequest) Diff(ctx context.Context) (string, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}
+	return pr.c.GetPullRequestDiff(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber())
+}
+
+// SetLabels replaces the labels of the pull request. As pull requests are issues in GitHub,
+// this uses the issues API.
+func (pr *pullrequest) SetLabels(ctx context.Context, labels []string) error {
+	if labels == nil {
+		labels = []string{}
+	}
+	return pr.editIssue(ctx, &github.IssueRequest{Labels: &labels})
+}
+
+// SetAssignees replaces the assignees of the pull request.
+func (pr *pullrequest) SetAssignees(ctx context.Context, assignees []string) error {
+	if assignees == nil {
+		assignees = []string{}
+	}
+	return pr.editIssue(ctx, &github.IssueRequest{Assignees: &assignees})
+}
+
+// SetMilestone adds the pull request to a milestone, or removes it from its milestone if number is 0.
+func (pr *pullrequest) SetMilestone(ctx context.Context, number int) error {
+	if number != 0 {
+		return pr.editIssue(ctx, &github.IssueRequest{Milestone: &number})
+	}
+	// PATCH /repos/{owner}/{repo}/issues/{issue_number}
+	issue, err := pr.c.RemoveIssueMilestone(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber())
+	if err != nil {
+		return err
+	}
+	pr.updateFromIssue(issue)
+	return nil
+}
+
+// editIssue edits the issue of the pull request, and updates the pull request from the result.
+func (pr *pullrequest) editIssue(ctx context.Context, req *github.IssueRequest) error {
+	// PATCH /repos/{owner}/{repo}/issues/{issue_number}
+	issue, err := pr.c.EditIssue(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber(), req)
+	if err != nil {
+		return err
+	}
+	pr.updateFromIssue(issue)
+	return nil
+}
+
+// updateFromIssue copies the labels, assignees and milestone of the issue to the pull request.
+func (pr *pullrequest) updateFromIssue(issue *github.Issue) {
+	pr.pr.Labels = issue.Labels
+	pr.pr.Assignees = issue.Assignees
+	pr.pr.Milestone = issue.Milestone
+}
+
+// AddReaction adds a reaction to the pull request. As pull requests are issues in GitHub,
+// this uses the issue reactions API. Adding an already existing reaction is a no-op.
+func (pr *pullrequest) AddReaction(ctx context.Context, content gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	if err := validateReactionContent(content); err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/reactions
+	apiObj, err := pr.c.CreateIssueReaction(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber(), string(content))
+	if err != nil {
+		return nil, err
+	}
+	return newReaction(apiObj), nil
+}
+
+// ListReactions lists all reactions to the pull request.
+//
+// ListReactions returns all available reactions, using multiple paginated requests if needed.
+func (pr *pullrequest) ListReactions(ctx context.Context) ([]gitprovider.Reaction, error) {
+	// GET /repos/{owner}/{repo}/issues/{issue_number}/reactions
+	apiObjs, err := pr.c.ListIssueReactions(ctx, pr.ref.GetIdentity(), pr.ref.GetRepository(), pr.pr.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	return newReactions(apiObjs), nil
+}
+
+// fileChangeTypeFromAPI maps the status of a changed file to a gitprovider.FileChangeType. Files
+// with only a changed mode ("changed") are reported as modified.
+func fileChangeTypeFromAPI(status string) gitprovider.FileChangeType {
+	switch status {
+	case "added":
+		return gitprovider.FileChangeTypeAdded
+	case "removed":
+		return gitprovider.FileChangeTypeRemoved
+	case "renamed":
+		return gitprovider.FileChangeTypeRenamed
+	case "copied":
+		return gitprovider.FileChangeTypeCopied
+	}
+	return gitprovider.FileChangeTypeModified
+}
+
 func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo {
 	var sourceBranch string
 	head := apiObj.Head
@@ -52,6 +207,25 @@ func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo
 			sourceBranch = *head.Ref
 		}
 	}
+	state := gitprovider.PullRequestStateOpen
+	switch {
+	case apiObj.GetMerged() || apiObj.MergedAt != nil:
+		state = gitprovider.PullRequestStateMerged
+	case apiObj.GetState() == "closed":
+		state = gitprovider.PullRequestStateClosed
+	}
+	labels := make([]string, 0, len(apiObj.Labels))
+	for _, label := range apiObj.Labels {
+		labels = append(labels, label.GetName())
+	}
+	assignees := make([]string, 0, len(apiObj.Assignees))
+	for _, assignee := range apiObj.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+	var milestone *int
+	if apiObj.Milestone != nil {
+		milestone = apiObj.Milestone.Number
+	}
 	return gitprovider.PullRequestInfo{
 		Title:        apiObj.GetTitle(),
 		Description:  apiObj.GetBody(),
@@ -59,5 +233,11 @@ func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo
 		Number:       apiObj.GetNumber(),
 		WebURL:       apiObj.GetHTMLURL(),
 		SourceBranch: sourceBranch,
+		BaseBranch:   apiObj.GetBase().GetRef(),
+		State:        state,
+		Draft:        apiObj.GetDraft(),
+		Labels:       labels,
+		Assignees:    assignees,
+		Milestone:    milestone,
 	}
 }
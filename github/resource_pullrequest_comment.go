@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// reviewThreadsQueryString lists the review threads of a pull request, one page at a time. The
+// REST API doesn't expose threads, which are needed to tell and change whether they're resolved.
+const reviewThreadsQueryString = `query($owner: String!, $repo: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $after) {
+        nodes { id isResolved comments(first: 100) { nodes { databaseId } } }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// resolveReviewThreadMutation resolves the review thread with the given node ID.
+const resolveReviewThreadMutation = `mutation($id: ID!) { resolveReviewThread(input: {threadId: $id}) { thread { id } } }`
+
+// enableAutoMergeMutation enables auto-merge for the pull request with the given node ID. This
+// isn't available in the REST API.
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { pullRequest { id } }
+}`
+
+// reviewThread is a thread of inline comments, as returned by the GraphQL API.
+type reviewThread struct {
+	ID         string `json:"id"`
+	IsResolved bool   `json:"isResolved"`
+	Comments   struct {
+		Nodes []struct {
+			DatabaseID int64 `json:"databaseId"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+// reviewThreadsQuery is the "data" of the response to reviewThreadsQueryString.
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest *struct {
+			ReviewThreads struct {
+				Nodes    []*reviewThread `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// findReviewThread returns the thread containing the comment with the given ID, or nil.
+func findReviewThread(threads []*reviewThread, id int64) *reviewThread {
+	for _, thread := range threads {
+		for _, comment := range thread.Comments.Nodes {
+			if comment.DatabaseID == id {
+				return thread
+			}
+		}
+	}
+	return nil
+}
+
+// newIssueComment wraps a general comment of a pull request, which GitHub handles as issue comment.
+func newIssueComment(apiObj *github.IssueComment) *pullRequestComment {
+	info := gitprovider.PullRequestCommentInfo{
+		ID:     apiObj.GetID(),
+		Author: apiObj.GetUser().GetLogin(),
+		Body:   apiObj.GetBody(),
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	return &pullRequestComment{info: info, apiObj: apiObj}
+}
+
+// newReviewComment wraps an inline comment of a pull request, resolved being the state of its thread.
+func newReviewComment(apiObj *github.PullRequestComment, resolved bool) *pullRequestComment {
+	info := gitprovider.PullRequestCommentInfo{
+		ID:        apiObj.GetID(),
+		Author:    apiObj.GetUser().GetLogin(),
+		Body:      apiObj.GetBody(),
+		Path:      apiObj.GetPath(),
+		Line:      apiObj.GetLine(),
+		InReplyTo: apiObj.GetInReplyTo(),
+		Resolved:  resolved,
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	return &pullRequestComment{info: info, apiObj: apiObj}
+}
+
+var _ gitprovider.PullRequestComment = &pullRequestComment{}
+
+// pullRequestComment is either a general or an inline comment, apiObj is the
+// *github.IssueComment or *github.PullRequestComment respectively.
+type pullRequestComment struct {
+	info   gitprovider.PullRequestCommentInfo
+	apiObj interface{}
+}
+
+func (c *pullRequestComment) Get() gitprovider.PullRequestCommentInfo {
+	return c.info
+}
+
+func (c *pullRequestComment) APIObject() interface{} {
+	return c.apiObj
+}
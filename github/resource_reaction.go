@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newReaction(apiObj *github.Reaction) *reaction {
+	return &reaction{
+		r: *apiObj,
+	}
+}
+
+// newReactions wraps the API objects in the generic []gitprovider.Reaction.
+func newReactions(apiObjs []*github.Reaction) []gitprovider.Reaction {
+	reactions := make([]gitprovider.Reaction, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		reactions = append(reactions, newReaction(apiObj))
+	}
+	return reactions
+}
+
+var _ gitprovider.Reaction = &reaction{}
+
+type reaction struct {
+	r github.Reaction
+}
+
+func (r *reaction) Get() gitprovider.ReactionInfo {
+	return reactionFromAPI(&r.r)
+}
+
+func (r *reaction) APIObject() interface{} {
+	return &r.r
+}
+
+func reactionFromAPI(apiObj *github.Reaction) gitprovider.ReactionInfo {
+	return gitprovider.ReactionInfo{
+		ID:      apiObj.GetID(),
+		Content: gitprovider.ReactionContent(apiObj.GetContent()),
+		User:    apiObj.GetUser().GetLogin(),
+	}
+}
+
+// validateReactionAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateReactionAPI(apiObj *github.Reaction) error {
+	return validateAPIObject("GitHub.Reaction", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Content == nil {
+			validator.Required("Content")
+		}
+	})
+}
+
+// validateReactionContent makes sure the requested reaction is known to GitHub.
+func validateReactionContent(content gitprovider.ReactionContent) error {
+	validator := validation.New("Reaction")
+	validator.Append(gitprovider.ValidateReactionContent(content), content, "Content")
+	return validator.Error()
+}
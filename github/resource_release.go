@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRelease(c *ReleaseClient, apiObj *github.RepositoryRelease) *release {
+	return &release{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Release = &release{}
+
+type release struct {
+	r github.RepositoryRelease
+	c *ReleaseClient
+}
+
+func (r *release) Get() gitprovider.ReleaseInfo {
+	return releaseFromAPI(&r.r)
+}
+
+// UploadAsset uploads content as a new asset of the release, using the uploads API.
+//
+// ErrAlreadyExists is returned if the release already has an asset with the given name.
+func (r *release) UploadAsset(ctx context.Context, name, contentType string, content io.Reader) (gitprovider.ReleaseAsset, error) {
+	if err := r.Get().ValidateNewAsset(name); err != nil {
+		return gitprovider.ReleaseAsset{}, err
+	}
+	// POST /repos/{owner}/{repo}/releases/{release_id}/assets
+	apiObj, err := r.c.c.UploadReleaseAsset(ctx, r.c.ref.GetIdentity(), r.c.ref.GetRepository(), r.r.GetID(), name, contentType, content)
+	if err != nil {
+		return gitprovider.ReleaseAsset{}, err
+	}
+	r.r.Assets = append(r.r.Assets, apiObj)
+	return releaseAssetFromAPI(apiObj), nil
+}
+
+func (r *release) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *release) Repository() gitprovider.RepositoryRef {
+	return r.c.ref
+}
+
+func validateReleaseAPI(apiObj *github.RepositoryRelease) error {
+	return validateAPIObject("GitHub.Release", func(validator validation.Validator) {
+		if apiObj.TagName == nil {
+			validator.Required("TagName")
+		}
+		for _, asset := range apiObj.Assets {
+			if asset.ID == nil {
+				validator.Required("Assets.ID")
+			}
+		}
+	})
+}
+
+func validateReleaseAssetAPI(apiObj *github.ReleaseAsset) error {
+	return validateAPIObject("GitHub.ReleaseAsset", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+	})
+}
+
+func releaseFromAPI(apiObj *github.RepositoryRelease) gitprovider.ReleaseInfo {
+	info := gitprovider.ReleaseInfo{
+		TagName:     apiObj.GetTagName(),
+		Name:        apiObj.GetName(),
+		Description: apiObj.GetBody(),
+		CreatedAt:   apiObj.GetCreatedAt().Time,
+		Assets:      make([]gitprovider.ReleaseAsset, 0, len(apiObj.Assets)),
+	}
+	for _, asset := range apiObj.Assets {
+		info.Assets = append(info.Assets, releaseAssetFromAPI(asset))
+	}
+	return info
+}
+
+func releaseAssetFromAPI(apiObj *github.ReleaseAsset) gitprovider.ReleaseAsset {
+	return gitprovider.ReleaseAsset{
+		ID:          apiObj.GetID(),
+		Name:        apiObj.GetName(),
+		Size:        int64(apiObj.GetSize()),
+		DownloadURL: apiObj.GetBrowserDownloadURL(),
+	}
+}
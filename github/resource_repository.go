@@ -19,6 +19,7 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/google/go-github/v49/github"
@@ -47,6 +48,7 @@ var githubRepositoryKnownFields = map[string]struct{}{
 	"GitignoreTemplate": {},
 	"LicenseTemplate":   {},
 	// Generic
+	"AllowAutoMerge":      {},
 	"AllowSquashMerge":    {},
 	"AllowMergeCommit":    {},
 	"AllowRebaseMerge":    {},
@@ -62,6 +64,31 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		deployTokens: &DeployTokenClient{},
+		labels: &LabelClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		milestones: &MilestoneClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		tagProtections: &TagProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -82,6 +109,10 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		actionsPermissions: &ActionsPermissionsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
 
@@ -94,12 +125,23 @@ type userRepository struct {
 	topUpdate *github.Repository
 	ref       gitprovider.RepositoryRef
 
-	deployKeys   *DeployKeyClient
-	commits      *CommitClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	files        *FileClient
-	trees        *TreeClient
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
 }
 
 func (r *userRepository) Get() gitprovider.RepositoryInfo {
@@ -117,6 +159,10 @@ func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
 	return nil
 }
 
+func (r *userRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
+}
+
 func (r *userRepository) APIObject() interface{} {
 	return &r.r
 }
@@ -129,6 +175,34 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+func (r *userRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *userRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *userRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -149,6 +223,10 @@ func (r *userRepository) Trees() gitprovider.TreeClient {
 	return r.trees
 }
 
+func (r *userRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
 // Update will apply the desired state in this object to the server.
 // Only set fields will be respected (i.e. PATCH behaviour).
 // In order to apply changes to this object, use the .Set({Resource}Info) error
@@ -211,6 +289,19 @@ func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
 	return true, r.Update(ctx)
 }
 
+// SetDefaultBranch makes the given branch the default branch of the repository.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *userRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	// PATCH /repos/{owner}/{repo}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &github.Repository{DefaultBranch: &branch})
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
 // Delete deletes the current resource irreversibly.
 //
 // ErrNotFound is returned if the resource doesn't exist anymore.
@@ -218,6 +309,98 @@ func (r *userRepository) Delete(ctx context.Context) error {
 	return r.c.DeleteRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
 }
 
+// Star stars the repository as the authenticated user. GitHub treats starring an already
+// starred repository as a success.
+//
+// ErrNotFound is returned if the repository doesn't exist.
+func (r *userRepository) Star(ctx context.Context) error {
+	// PUT /user/starred/{owner}/{repo}
+	return r.c.StarRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+}
+
+// Unstar removes the star of the authenticated user from the repository. GitHub treats
+// unstarring a repository that isn't starred as a success.
+//
+// ErrNotFound is returned if the repository doesn't exist.
+func (r *userRepository) Unstar(ctx context.Context) error {
+	// DELETE /user/starred/{owner}/{repo}
+	return r.c.UnstarRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+}
+
+// IsStarred returns whether the authenticated user has starred the repository. GitHub doesn't
+// tell a missing repository apart from one that isn't starred, hence false is returned for both.
+func (r *userRepository) IsStarred(ctx context.Context) (bool, error) {
+	// GET /user/starred/{owner}/{repo}
+	return r.c.IsRepoStarred(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+}
+
+// GetSubscription returns which notifications the authenticated user receives for the repository.
+// GitHub doesn't expose custom subscriptions through the API, hence they are never returned.
+func (r *userRepository) GetSubscription(ctx context.Context) (gitprovider.RepositorySubscription, error) {
+	// GET /repos/{owner}/{repo}/subscription
+	apiObj, err := r.c.GetRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case apiObj.GetIgnored():
+		return gitprovider.RepositorySubscriptionIgnore, nil
+	case apiObj.GetSubscribed():
+		return gitprovider.RepositorySubscriptionWatch, nil
+	}
+	return gitprovider.RepositorySubscriptionDefault, nil
+}
+
+// SetSubscription sets which notifications the authenticated user receives for the repository.
+// GitHub only notifies about participating activity by default, and doesn't allow setting that
+// or custom subscriptions explicitly, hence ErrNoProviderSupport is returned for those.
+//
+// ErrNotFound is returned if the repository doesn't exist.
+func (r *userRepository) SetSubscription(ctx context.Context, subscription gitprovider.RepositorySubscription) error {
+	if err := gitprovider.ValidateRepositorySubscription(subscription); err != nil {
+		return fmt.Errorf("invalid repository subscription %q: %w", subscription, err)
+	}
+
+	switch subscription {
+	case gitprovider.RepositorySubscriptionDefault:
+		// DELETE /repos/{owner}/{repo}/subscription
+		return r.c.DeleteRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	case gitprovider.RepositorySubscriptionWatch:
+		// PUT /repos/{owner}/{repo}/subscription
+		return r.c.SetRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &github.Subscription{
+			Subscribed: gitprovider.BoolVar(true),
+			Ignored:    gitprovider.BoolVar(false),
+		})
+	case gitprovider.RepositorySubscriptionIgnore:
+		// PUT /repos/{owner}/{repo}/subscription
+		return r.c.SetRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &github.Subscription{
+			Subscribed: gitprovider.BoolVar(false),
+			Ignored:    gitprovider.BoolVar(true),
+		})
+	default:
+		return fmt.Errorf("github can't set the %q subscription: %w", subscription, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+// IssueCounts returns the number of open and closed issues of the repository. GitHub's
+// repository fields count pull requests as issues, hence the issues are counted with one search
+// per state, which is subject to the lower rate limit of the search API. GitHub reports a
+// missing repository as a validation error of the search query.
+func (r *userRepository) IssueCounts(ctx context.Context) (gitprovider.IssueCounts, error) {
+	query := fmt.Sprintf("repo:%s/%s is:issue", r.ref.GetIdentity(), r.ref.GetRepository())
+	// GET /search/issues
+	open, err := r.c.CountIssues(ctx, query+" state:open")
+	if err != nil {
+		return gitprovider.IssueCounts{}, err
+	}
+	// GET /search/issues
+	closed, err := r.c.CountIssues(ctx, query+" state:closed")
+	if err != nil {
+		return gitprovider.IssueCounts{}, err
+	}
+	return gitprovider.IssueCounts{Open: open, Closed: closed}, nil
+}
+
 func newOrgRepository(ctx *clientContext, apiObj *github.Repository, ref gitprovider.RepositoryRef) *orgRepository {
 	return &orgRepository{
 		userRepository: *newUserRepository(ctx, apiObj, ref),
@@ -256,10 +439,25 @@ func validateRepositoryAPI(apiObj *github.Repository) error {
 	})
 }
 
+// validateStarredRepositoryAPI validates a starred repository received from the server, to make
+// sure that it can be referenced.
+func validateStarredRepositoryAPI(apiObj *github.Repository) error {
+	return validateAPIObject("GitHub.Repository", func(validator validation.Validator) {
+		if apiObj.Name == nil {
+			validator.Required("Name")
+		}
+		if apiObj.GetOwner().GetLogin() == "" {
+			validator.Required("Owner.Login")
+		}
+	})
+}
+
 func repositoryFromAPI(apiObj *github.Repository) gitprovider.RepositoryInfo {
 	repo := gitprovider.RepositoryInfo{
-		Description:   apiObj.Description,
-		DefaultBranch: apiObj.DefaultBranch,
+		Description:         apiObj.Description,
+		DefaultBranch:       apiObj.DefaultBranch,
+		AllowAutoMerge:      apiObj.AllowAutoMerge,
+		DeleteBranchOnMerge: apiObj.DeleteBranchOnMerge,
 	}
 	if apiObj.Visibility != nil {
 		repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility(*apiObj.Visibility))
@@ -285,6 +483,12 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *github.Rep
 	if repo.Visibility != nil {
 		apiObj.Visibility = gitprovider.StringVar(string(*repo.Visibility))
 	}
+	if repo.AllowAutoMerge != nil {
+		apiObj.AllowAutoMerge = repo.AllowAutoMerge
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		apiObj.DeleteBranchOnMerge = repo.DeleteBranchOnMerge
+	}
 }
 
 func updateApiObjWithRepositoryInfo(repo *gitprovider.RepositoryInfo, apiObj *github.Repository) *github.Repository {
@@ -300,6 +504,12 @@ func updateApiObjWithRepositoryInfo(repo *gitprovider.RepositoryInfo, apiObj *gi
 	if repo.Visibility != nil {
 		desired.Visibility = gitprovider.StringVar(string(*repo.Visibility))
 	}
+	if repo.AllowAutoMerge != nil {
+		desired.AllowAutoMerge = repo.AllowAutoMerge
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		desired.DeleteBranchOnMerge = repo.DeleteBranchOnMerge
+	}
 
 	// create the update repository
 	return updateGithubRepository(desired, actual)
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_updateApiObjWithRepositoryInfo_mergeSettings(t *testing.T) {
+	tests := []struct {
+		name                    string
+		info                    gitprovider.RepositoryInfo
+		wantAllowAutoMerge      *bool
+		wantDeleteBranchOnMerge *bool
+	}{
+		{
+			name:                    "unset keeps the actual settings",
+			wantAllowAutoMerge:      gitprovider.BoolVar(false),
+			wantDeleteBranchOnMerge: gitprovider.BoolVar(false),
+		},
+		{
+			name:                    "enable auto-merge",
+			info:                    gitprovider.RepositoryInfo{AllowAutoMerge: gitprovider.BoolVar(true)},
+			wantAllowAutoMerge:      gitprovider.BoolVar(true),
+			wantDeleteBranchOnMerge: gitprovider.BoolVar(false),
+		},
+		{
+			name:                    "enable branch deletion",
+			info:                    gitprovider.RepositoryInfo{DeleteBranchOnMerge: gitprovider.BoolVar(true)},
+			wantAllowAutoMerge:      gitprovider.BoolVar(false),
+			wantDeleteBranchOnMerge: gitprovider.BoolVar(true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := &github.Repository{
+				Name:                gitprovider.StringVar("repo"),
+				AllowAutoMerge:      gitprovider.BoolVar(false),
+				DeleteBranchOnMerge: gitprovider.BoolVar(false),
+			}
+			got := updateApiObjWithRepositoryInfo(&tt.info, actual)
+			if !reflect.DeepEqual(got.AllowAutoMerge, tt.wantAllowAutoMerge) {
+				t.Errorf("AllowAutoMerge = %v, want %v", got.GetAllowAutoMerge(), *tt.wantAllowAutoMerge)
+			}
+			if !reflect.DeepEqual(got.DeleteBranchOnMerge, tt.wantDeleteBranchOnMerge) {
+				t.Errorf("DeleteBranchOnMerge = %v, want %v", got.GetDeleteBranchOnMerge(), *tt.wantDeleteBranchOnMerge)
+			}
+
+			// The settings round-trip through the high-level type
+			info := repositoryFromAPI(got)
+			if !reflect.DeepEqual(info.AllowAutoMerge, tt.wantAllowAutoMerge) || !reflect.DeepEqual(info.DeleteBranchOnMerge, tt.wantDeleteBranchOnMerge) {
+				t.Errorf("repositoryFromAPI() = %+v", info)
+			}
+		})
+	}
+}
+
+func TestUserRepository_Subscription(t *testing.T) {
+	// The server keeps the subscription state, like GitHub does
+	var subscription *github.Subscription
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/subscription" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if subscription == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
+				return
+			}
+		case http.MethodPut:
+			subscription = &github.Subscription{}
+			if err := json.NewDecoder(r.Body).Decode(subscription); err != nil {
+				t.Error(err)
+			}
+		case http.MethodDelete:
+			subscription = nil
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(subscription)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	r := newUserRepository(&clientContext{c: &githubClientImpl{c: gh}}, &github.Repository{}, ref)
+	ctx := context.Background()
+
+	for _, want := range []gitprovider.RepositorySubscription{
+		gitprovider.RepositorySubscriptionWatch,
+		gitprovider.RepositorySubscriptionIgnore,
+		gitprovider.RepositorySubscriptionDefault,
+	} {
+		if err := r.SetSubscription(ctx, want); err != nil {
+			t.Fatalf("SetSubscription(%q) error = %v", want, err)
+		}
+		got, err := r.GetSubscription(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("GetSubscription() = %q, want %q", got, want)
+		}
+	}
+
+	if err := r.SetSubscription(ctx, gitprovider.RepositorySubscriptionCustom); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("SetSubscription(custom) error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestUserRepository_IssueCounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/issues" || r.URL.Query().Get("per_page") != "1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		totals := map[string]int{
+			"repo:org/repo is:issue state:open":   3,
+			"repo:org/repo is:issue state:closed": 42,
+		}
+		total, ok := totals[r.URL.Query().Get("q")]
+		if !ok {
+			t.Errorf("unexpected query %q", r.URL.Query().Get("q"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"total_count":%d,"incomplete_results":false,"items":[]}`, total)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	r := newUserRepository(&clientContext{c: &githubClientImpl{c: gh}}, &github.Repository{}, ref)
+
+	got, err := r.IssueCounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (gitprovider.IssueCounts{Open: 3, Closed: 42}); got != want {
+		t.Errorf("IssueCounts() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newReview(apiObj *github.PullRequestReview) *review {
+	return &review{
+		r: *apiObj,
+	}
+}
+
+var _ gitprovider.Review = &review{}
+
+type review struct {
+	r github.PullRequestReview
+}
+
+func (r *review) Get() gitprovider.ReviewInfo {
+	return reviewFromAPI(&r.r)
+}
+
+func (r *review) APIObject() interface{} {
+	return &r.r
+}
+
+func reviewFromAPI(apiObj *github.PullRequestReview) gitprovider.ReviewInfo {
+	info := gitprovider.ReviewInfo{
+		ID:       apiObj.GetID(),
+		Reviewer: apiObj.GetUser().GetLogin(),
+		// GitHub reports the states in upper case, e.g. "CHANGES_REQUESTED"
+		State: gitprovider.ReviewState(strings.ToLower(apiObj.GetState())),
+		Body:  apiObj.GetBody(),
+	}
+	if apiObj.SubmittedAt != nil {
+		submittedAt := *apiObj.SubmittedAt
+		info.SubmittedAt = &submittedAt
+	}
+	return info
+}
+
+// validateReviewAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateReviewAPI(apiObj *github.PullRequestReview) error {
+	return validateAPIObject("GitHub.PullRequestReview", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.State == nil {
+			validator.Required("State")
+		}
+	})
+}
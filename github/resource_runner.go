@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRunner(apiObj *github.Runner, ref gitprovider.OrganizationRef) *runner {
+	return &runner{
+		r:   *apiObj,
+		ref: ref,
+	}
+}
+
+var _ gitprovider.Runner = &runner{}
+
+type runner struct {
+	r   github.Runner
+	ref gitprovider.OrganizationRef
+}
+
+func (r *runner) Get() gitprovider.RunnerInfo {
+	return runnerFromAPI(&r.r)
+}
+
+func (r *runner) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *runner) Organization() gitprovider.OrganizationRef {
+	return r.ref
+}
+
+func runnerFromAPI(apiObj *github.Runner) gitprovider.RunnerInfo {
+	labels := make([]string, 0, len(apiObj.Labels))
+	for _, label := range apiObj.Labels {
+		labels = append(labels, label.GetName())
+	}
+	return gitprovider.RunnerInfo{
+		ID:     apiObj.GetID(),
+		Name:   apiObj.GetName(),
+		Status: gitprovider.RunnerStatus(apiObj.GetStatus()),
+		Labels: labels,
+	}
+}
+
+// validateRunnerAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRunnerAPI(apiObj *github.Runner) error {
+	return validateAPIObject("GitHub.Runner", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Status == nil {
+			validator.Required("Status")
+		} else {
+			s := gitprovider.RunnerStatus(*apiObj.Status)
+			validator.Append(gitprovider.ValidateRunnerStatus(s), s, "Status")
+		}
+	})
+}
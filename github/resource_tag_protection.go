@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newTagProtection(c *TagProtectionClient, apiObj *github.TagProtection) *tagProtection {
+	return &tagProtection{
+		t: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.TagProtection = &tagProtection{}
+
+type tagProtection struct {
+	t github.TagProtection
+	c *TagProtectionClient
+}
+
+func (tp *tagProtection) Get() gitprovider.TagProtectionInfo {
+	return gitprovider.TagProtectionInfo{
+		Pattern: tp.t.GetPattern(),
+	}
+}
+
+func (tp *tagProtection) APIObject() interface{} {
+	return &tp.t
+}
+
+func (tp *tagProtection) Repository() gitprovider.RepositoryRef {
+	return tp.c.ref
+}
+
+// Delete deletes the rule, which unprotects the matching tags.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (tp *tagProtection) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/tags/protection/{tag_protection_id}
+	return tp.c.c.DeleteTagProtection(ctx, tp.c.ref.GetIdentity(), tp.c.ref.GetRepository(), tp.t.GetID())
+}
+
+func validateTagProtectionAPI(apiObj *github.TagProtection) error {
+	return validateAPIObject("GitHub.TagProtection", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Pattern == nil {
+			validator.Required("Pattern")
+		}
+	})
+}
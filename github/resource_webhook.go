@@ -0,0 +1,365 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// webhookName is the name of all webhooks that send payloads to a URL.
+	webhookName = "web"
+
+	webhookConfigURL         = "url"
+	webhookConfigContentType = "content_type"
+	webhookConfigSecret      = "secret"
+
+	// webhookPingEvent is the event of the payload sent by Ping.
+	webhookPingEvent = "ping"
+	// webhookPingPollInterval is the interval at which the deliveries are polled until the
+	// delivery of the ping shows up.
+	webhookPingPollInterval = time.Second
+	// webhookPingTimeout bounds how long Ping waits for the delivery of the ping.
+	webhookPingTimeout = 30 * time.Second
+)
+
+// webhookEvents maps the webhook events to the GitHub events, in the order of the enum.
+//
+//nolint:gochecknoglobals
+var webhookEvents = []struct {
+	event     gitprovider.WebhookEvent
+	apiEvents []string
+}{
+	{gitprovider.WebhookEventPush, []string{"push"}},
+	{gitprovider.WebhookEventPullRequest, []string{"pull_request"}},
+	{gitprovider.WebhookEventTag, []string{"create", "delete"}},
+	{gitprovider.WebhookEventIssues, []string{"issues"}},
+	{gitprovider.WebhookEventComment, []string{"issue_comment", "pull_request_review_comment"}},
+	{gitprovider.WebhookEventRelease, []string{"release"}},
+}
+
+// WebhookEvents returns the webhook events GitHub supports for repository and organization webhooks, mapped to the
+// GitHub events they subscribe to.
+func WebhookEvents() gitprovider.WebhookEventMap {
+	events := make(gitprovider.WebhookEventMap, len(webhookEvents))
+	for _, e := range webhookEvents {
+		events[e.event] = append([]string{}, e.apiEvents...)
+	}
+	return events
+}
+
+func newWebhook(c *WebhookClient, apiObj *github.Hook) *webhook {
+	hasSecret := removeMaskedSecret(apiObj)
+	return &webhook{
+		h:         *apiObj,
+		hasSecret: hasSecret,
+		c:         c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+type webhook struct {
+	h github.Hook
+	// hasSecret is whether the webhook has a secret, as GitHub doesn't return the secret itself.
+	hasSecret bool
+	c         *WebhookClient
+}
+
+func (wh *webhook) ID() string {
+	return strconv.FormatInt(wh.h.GetID(), 10)
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set, otherwise GitHub keeps the current secret.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, err := wh.c.c.EditHook(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), wh.h.GetID(), &github.Hook{
+		Config: wh.h.Config,
+		Events: wh.h.Events,
+	})
+	if err != nil {
+		return err
+	}
+	wh.hasSecret = removeMaskedSecret(apiObj)
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	return wh.c.c.DeleteHook(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), wh.h.GetID())
+}
+
+// Deliveries lists the deliveries of the webhook, which GitHub keeps for 3 days.
+//
+// Deliveries returns all available deliveries, using multiple paginated requests if needed.
+func (wh *webhook) Deliveries(ctx context.Context) ([]gitprovider.WebhookDelivery, error) {
+	// GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries
+	apiObjs, err := wh.c.c.ListHookDeliveries(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), wh.h.GetID())
+	if err != nil {
+		return nil, err
+	}
+	return webhookDeliveriesFromAPI(apiObjs), nil
+}
+
+// Redeliver sends the payload of the given delivery again.
+//
+// ErrNotFound is returned if the delivery does not exist.
+func (wh *webhook) Redeliver(ctx context.Context, deliveryID string) error {
+	id, err := parseDeliveryID(deliveryID)
+	if err != nil {
+		return err
+	}
+	// POST /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id}/attempts
+	return wh.c.c.RedeliverHookDelivery(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), wh.h.GetID(), id)
+}
+
+// Ping makes GitHub send a ping event to the webhook. As GitHub sends it asynchronously, the
+// recent deliveries are polled until the delivery of the ping shows up.
+func (wh *webhook) Ping(ctx context.Context) (gitprovider.WebhookPingResult, error) {
+	owner, repo, id := wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), wh.h.GetID()
+	// Remember the latest ping, in order to tell the new one apart
+	// GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries
+	apiObjs, err := wh.c.c.ListRecentHookDeliveries(ctx, owner, repo, id)
+	if err != nil {
+		return gitprovider.WebhookPingResult{}, err
+	}
+	previousID := latestPingDelivery(apiObjs).GetID()
+
+	// POST /repos/{owner}/{repo}/hooks/{hook_id}/pings
+	if err := wh.c.c.PingHook(ctx, owner, repo, id); err != nil {
+		return gitprovider.WebhookPingResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookPingTimeout)
+	defer cancel()
+	for {
+		// GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries
+		apiObjs, err := wh.c.c.ListRecentHookDeliveries(ctx, owner, repo, id)
+		if err != nil {
+			return gitprovider.WebhookPingResult{}, err
+		}
+		if delivery := latestPingDelivery(apiObjs); delivery.GetID() > previousID {
+			return gitprovider.WebhookPingResult{
+				Successful: delivery.GetStatusCode() >= 200 && delivery.GetStatusCode() < 300,
+				StatusCode: delivery.GetStatusCode(),
+				Message:    delivery.GetStatus(),
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return gitprovider.WebhookPingResult{}, fmt.Errorf("the delivery of the ping didn't show up: %w", ctx.Err())
+		case <-time.After(webhookPingPollInterval):
+		}
+	}
+}
+
+func validateWebhookAPI(apiObj *github.Hook) error {
+	return validateAPIObject("GitHub.Hook", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if webhookURL(apiObj) == "" {
+			validator.Required("Config.URL")
+		}
+	})
+}
+
+func validateHookDeliveryAPI(apiObj *github.HookDelivery) error {
+	return validateAPIObject("GitHub.HookDelivery", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+	})
+}
+
+// webhookURL returns the URL the webhook sends the payloads to.
+func webhookURL(apiObj *github.Hook) string {
+	return webhookConfigString(apiObj, webhookConfigURL)
+}
+
+func webhookConfigString(apiObj *github.Hook, key string) string {
+	value, _ := apiObj.Config[key].(string)
+	return value
+}
+
+// removeMaskedSecret removes the secret from the config returned by GitHub, which is masked, in
+// order not to send the masked secret back on Update. It returns whether a secret was set.
+func removeMaskedSecret(apiObj *github.Hook) bool {
+	hasSecret := webhookConfigString(apiObj, webhookConfigSecret) != ""
+	delete(apiObj.Config, webhookConfigSecret)
+	return hasSecret
+}
+
+// secretDrifted returns whether the webhook has a secret, while the desired state has none, or
+// the other way around. Without a desired secret, the current secret is kept.
+func secretDrifted(req gitprovider.WebhookInfo, hasSecret bool) bool {
+	return req.Secret != nil && (*req.Secret != "") != hasSecret
+}
+
+func webhookFromAPI(apiObj *github.Hook) gitprovider.WebhookInfo {
+	info := gitprovider.WebhookInfo{
+		URL:    webhookURL(apiObj),
+		Events: webhookEventsFromAPI(apiObj.Events),
+	}
+	// GitHub defaults to form encoded payloads
+	info.ContentType = gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm)
+	if contentType := webhookConfigString(apiObj, webhookConfigContentType); contentType != "" {
+		info.ContentType = gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentType(contentType))
+	}
+	return info
+}
+
+func webhookToAPI(info *gitprovider.WebhookInfo) *github.Hook {
+	h := &github.Hook{
+		Name:   gitprovider.StringVar(webhookName),
+		Active: gitprovider.BoolVar(true),
+	}
+	webhookInfoToAPIObj(info, h)
+	return h
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *github.Hook) {
+	// Keep the other settings, e.g. "insecure_ssl", as GitHub replaces the whole config
+	config := make(map[string]interface{}, len(apiObj.Config)+1)
+	for key, value := range apiObj.Config {
+		config[key] = value
+	}
+	// Required fields, we assume info is validated, and hence these are set
+	config[webhookConfigURL] = info.URL
+	apiObj.Events = webhookEventsToAPI(info.Events)
+	// optional fields
+	if info.ContentType != nil {
+		config[webhookConfigContentType] = string(*info.ContentType)
+	}
+	if info.Secret != nil {
+		config[webhookConfigSecret] = *info.Secret
+	}
+	apiObj.Config = config
+}
+
+func webhookEventsToAPI(events []gitprovider.WebhookEvent) []string {
+	apiEvents := make([]string, 0, len(events))
+	for _, e := range webhookEvents {
+		for _, event := range events {
+			if event == e.event {
+				apiEvents = append(apiEvents, e.apiEvents...)
+			}
+		}
+	}
+	return apiEvents
+}
+
+// webhookEventsFromAPI maps the GitHub events to webhook events. GitHub events without
+// corresponding webhook event are left out, and the "*" wildcard maps to all webhook events.
+func webhookEventsFromAPI(apiEvents []string) []gitprovider.WebhookEvent {
+	events := []gitprovider.WebhookEvent{}
+	all := containsAny(apiEvents, []string{"*"})
+	for _, e := range webhookEvents {
+		if all || containsAny(apiEvents, e.apiEvents) {
+			events = append(events, e.event)
+		}
+	}
+	return events
+}
+
+// containsAny returns whether any of values is in list.
+func containsAny(list, values []string) bool {
+	for _, l := range list {
+		for _, v := range values {
+			if l == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func webhookDeliveriesFromAPI(apiObjs []*github.HookDelivery) []gitprovider.WebhookDelivery {
+	deliveries := make([]gitprovider.WebhookDelivery, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		delivery := gitprovider.WebhookDelivery{
+			ID:          strconv.FormatInt(apiObj.GetID(), 10),
+			Event:       apiObj.GetEvent(),
+			DeliveredAt: apiObj.GetDeliveredAt().Time,
+			StatusCode:  apiObj.GetStatusCode(),
+			Redelivery:  apiObj.GetRedelivery(),
+		}
+		// GitHub returns the duration in seconds
+		if apiObj.Duration != nil {
+			delivery.Duration = time.Duration(*apiObj.Duration * float64(time.Second))
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+// parseDeliveryID parses the numeric ID of a webhook delivery.
+func parseDeliveryID(deliveryID string) (int64, error) {
+	id, err := strconv.ParseInt(deliveryID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook delivery ID %q: %w", deliveryID, gitprovider.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+// latestPingDelivery returns the most recent delivery of a ping event, or nil if there's none.
+func latestPingDelivery(apiObjs []*github.HookDelivery) *github.HookDelivery {
+	var latest *github.HookDelivery
+	for _, apiObj := range apiObjs {
+		if apiObj.GetEvent() == webhookPingEvent && apiObj.GetID() > latest.GetID() {
+			latest = apiObj
+		}
+	}
+	return latest
+}
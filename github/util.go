@@ -17,9 +17,16 @@ limitations under the License.
 package github
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v49/github"
 
@@ -29,7 +36,11 @@ import (
 
 const (
 	alreadyExistsMagicString = "name already exists on this account"
+	refNotExistsMagicString  = "Reference does not exist"
 	rateLimitDocURL          = "https://developer.github.com/v3/#rate-limiting"
+	// ssoHeader is set to e.g. "required; url=https://github.com/orgs/foo/sso?authorization_request=..."
+	// if the organization enforces SAML single sign-on, and the credentials aren't authorized for it.
+	ssoHeader = "X-GitHub-SSO"
 )
 
 // TODO: Guard better against nil pointer dereference panics in this package, also
@@ -121,9 +132,30 @@ func handleHTTPError(err error) error {
 			Message:          ghErrorResponse.Message,
 			DocumentationURL: ghErrorResponse.DocumentationURL,
 		}
+		// Check for credentials that aren't authorized for the organization's SAML single sign-on
+		if ghErrorResponse.Response.StatusCode == http.StatusForbidden {
+			if authURL, ok := parseSSOHeader(ghErrorResponse.Response.Header.Get(ssoHeader)); ok {
+				return validation.NewMultiError(err,
+					&gitprovider.SAMLAuthorizationRequiredError{HTTPError: httpErr, AuthorizationURL: authURL},
+					gitprovider.ErrSAMLAuthorizationRequired,
+				)
+			}
+		}
+		// Check for fine-grained permissions the token lacks, still marking the error as ErrForbidden
+		if ghErrorResponse.Response.StatusCode == http.StatusForbidden {
+			if permErr, ok := missingPermissionError(httpErr, ghErrorResponse.Response.Header); ok {
+				return validation.NewMultiError(err, permErr, gitprovider.ErrForbidden)
+			}
+		}
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		if ghErrorResponse.Response.StatusCode == http.StatusForbidden {
+			return validation.NewMultiError(err,
+				&gitprovider.InvalidCredentialsError{HTTPError: httpErr},
+				gitprovider.ErrForbidden,
+			)
+		}
 		// Check for invalid credentials, and return a typed error in that case
-		if ghErrorResponse.Response.StatusCode == http.StatusForbidden ||
-			ghErrorResponse.Response.StatusCode == http.StatusUnauthorized {
+		if ghErrorResponse.Response.StatusCode == http.StatusUnauthorized {
 			return validation.NewMultiError(err,
 				&gitprovider.InvalidCredentialsError{HTTPError: httpErr},
 			)
@@ -145,11 +177,28 @@ func handleHTTPError(err error) error {
 	return err
 }
 
+// parseSSOHeader parses the value of the X-GitHub-SSO header, and returns the authorization URL
+// if SAML single sign-on authorization is required. The URL is empty if the header contains none.
+func parseSSOHeader(value string) (string, bool) {
+	parts := strings.Split(value, ";")
+	if strings.TrimSpace(parts[0]) != "required" {
+		return "", false
+	}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "url=") {
+			return strings.TrimPrefix(part, "url="), true
+		}
+	}
+	return "", true
+}
+
 // allPages runs fn for each page, expecting a HTTP request to be made and returned during that call.
 // allPages expects that the data is saved in fn to an outer variable.
 // allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
 // There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
-func allPages(opts *github.ListOptions, fn func() (*github.Response, error)) error {
+func allPages(ctx context.Context, opts *github.ListOptions, fn func() (*github.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 
 		resp, err := fn()
@@ -159,10 +208,92 @@ func allPages(opts *github.ListOptions, fn func() (*github.Response, error)) err
 		if resp.NextPage == 0 {
 			return nil
 		}
+		// Stop early if the caller gave up in the meantime
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		opts.Page = resp.NextPage
 	}
 }
 
+// listOptionsQuery returns the query parameters of opts, for requests go-github can't make.
+func listOptionsQuery(opts *github.ListOptions) url.Values {
+	q := url.Values{}
+	if opts.Page != 0 {
+		q.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage != 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	return q
+}
+
+// allCursorPages runs fn for each page of a cursor-paginated list, e.g. of webhook deliveries,
+// which doesn't return page numbers.
+func allCursorPages(ctx context.Context, opts *github.ListCursorOptions, fn func() (*github.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.Cursor == "" {
+			return nil
+		}
+		// Stop early if the caller gave up in the meantime
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opts.Cursor = resp.Cursor
+	}
+}
+
+// listProgress returns the progress after count items have been listed, with resp being the
+// response to the latest page. The total is estimated using the last page from the Link header.
+func listProgress(resp *github.Response, count, pageSize int) gitprovider.ListProgress {
+	progress := gitprovider.ListProgress{Count: count}
+	if resp.NextPage == 0 {
+		progress.EstimatedTotal = count
+	} else if resp.LastPage > 0 {
+		progress.EstimatedTotal = resp.LastPage * pageSize
+	}
+	return progress
+}
+
+// contentSize returns the size of content, which GitHub requires when uploading. If the size
+// can't be determined up front, content is read into memory. The returned reader must be used
+// instead of content.
+func contentSize(content io.Reader) (io.Reader, int64, error) {
+	switch r := content.(type) {
+	case interface{ Len() int }:
+		// e.g. *bytes.Buffer, *bytes.Reader and *strings.Reader
+		return content, int64(r.Len()), nil
+	case *os.File:
+		if fi, err := r.Stat(); err == nil && fi.Mode().IsRegular() {
+			return content, fi.Size(), nil
+		}
+	}
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(b), int64(len(b)), nil
+}
+
+// uploadURLFromBaseURL derives the URL of the uploads API from the URL of the REST API.
+// github.com serves uploads from a separate host, while GitHub Enterprise serves them from
+// the "/api/uploads/" path of the same host.
+func uploadURLFromBaseURL(baseURL *url.URL) *url.URL {
+	u := *baseURL
+	if u.Host == "api.github.com" {
+		u.Host = "uploads.github.com"
+		u.Path = "/"
+		return &u
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v3") + "/api/uploads/"
+	return &u
+}
+
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
 // depending on if any error was registered with it; either returns nil, or a MultiError
 // with both the validation error and ErrInvalidServerData, to mark that the server data
@@ -176,3 +307,11 @@ func validateAPIObject(name string, fn func(validation.Validator)) error {
 	}
 	return nil
 }
+
+func validateLicenseAPI(apiObj *github.License) error {
+	return validateAPIObject("GitHub.License", func(validator validation.Validator) {
+		if apiObj.GetKey() == "" {
+			validator.Required("Key")
+		}
+	})
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package github
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
@@ -119,7 +123,7 @@ func Test_allPages(t *testing.T) {
 			// the page index are 1-based, and omitting page is the same as page=1
 			// set page=1 here just to be able to test more easily
 			tt.opts.Page = 1
-			err := allPages(tt.opts, func() (*github.Response, error) {
+			err := allPages(context.Background(), tt.opts, func() (*github.Response, error) {
 				i++
 				if tt.opts.Page != i {
 					t.Fatalf("page number is unexpected: got = %d want = %d", tt.opts.Page, i)
@@ -133,3 +137,133 @@ func Test_allPages(t *testing.T) {
 		})
 	}
 }
+
+func Test_handleHTTPError(t *testing.T) {
+	withStatus := func(code int) *github.ErrorResponse {
+		e := newGHError()
+		e.Response.StatusCode = code
+		return e
+	}
+	tests := []struct {
+		name         string
+		err          error
+		expectedErrs []error
+	}{
+		{
+			name: "nil => nil",
+		},
+		{
+			name:         "404 => ErrNotFound",
+			err:          withStatus(http.StatusNotFound),
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+		},
+		{
+			name:         "401 => InvalidCredentialsError",
+			err:          withStatus(http.StatusUnauthorized),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			name:         "403 => InvalidCredentialsError & ErrForbidden",
+			err:          withStatus(http.StatusForbidden),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+		},
+		{
+			name: "403 with SSO header => SAMLAuthorizationRequiredError & ErrSAMLAuthorizationRequired",
+			err: func() error {
+				e := withStatus(http.StatusForbidden)
+				e.Response.Header = http.Header{}
+				e.Response.Header.Set(ssoHeader, "required; url=https://github.com/orgs/foo/sso")
+				return e
+			}(),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.SAMLAuthorizationRequiredError{}, gitprovider.ErrSAMLAuthorizationRequired},
+		},
+		{
+			name: "403 with accepted permissions header => MissingTokenPermissionError & ErrForbidden",
+			err: func() error {
+				e := withStatus(http.StatusForbidden)
+				e.Response.Header = http.Header{}
+				e.Response.Header.Set(acceptedPermissionsHeader, "administration=write")
+				return e
+			}(),
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.MissingTokenPermissionError{}, gitprovider.ErrMissingTokenPermission, gitprovider.ErrForbidden},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleHTTPError(tt.err)
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+		})
+	}
+}
+
+func Test_handleHTTPError_SAMLAuthorizationRequired(t *testing.T) {
+	const authURL = "https://github.com/orgs/foo/sso?authorization_request=abc"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(ssoHeader, "required; url="+authURL)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `{"message":"Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization."}`)
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	c := &githubClientImpl{c: gh}
+
+	_, err := c.GetOrg(context.Background(), "foo")
+	if !errors.Is(err, gitprovider.ErrSAMLAuthorizationRequired) {
+		t.Fatalf("GetOrg() error = %v, want ErrSAMLAuthorizationRequired", err)
+	}
+	if errors.Is(err, gitprovider.ErrForbidden) {
+		t.Errorf("GetOrg() error = %v, didn't want ErrForbidden", err)
+	}
+	samlErr := &gitprovider.SAMLAuthorizationRequiredError{}
+	if !errors.As(err, &samlErr) {
+		t.Fatalf("GetOrg() error = %v, want SAMLAuthorizationRequiredError", err)
+	}
+	if samlErr.AuthorizationURL != authURL {
+		t.Errorf("AuthorizationURL = %q, want %q", samlErr.AuthorizationURL, authURL)
+	}
+}
+
+func Test_parseSSOHeader(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantURL string
+		wantOK  bool
+	}{
+		{value: ""},
+		{value: "partial-results; organizations=21955855,20582480"},
+		{value: "required; url=https://github.com/orgs/foo/sso", wantURL: "https://github.com/orgs/foo/sso", wantOK: true},
+		{value: "required", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			gotURL, gotOK := parseSSOHeader(tt.value)
+			if gotURL != tt.wantURL || gotOK != tt.wantOK {
+				t.Errorf("parseSSOHeader() = (%q, %v), want (%q, %v)", gotURL, gotOK, tt.wantURL, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_uploadURLFromBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{baseURL: "https://api.github.com/", want: "https://uploads.github.com/"},
+		{baseURL: "https://ghe.example.com/api/v3/", want: "https://ghe.example.com/api/uploads/"},
+		{baseURL: "https://ghe.example.com/api/v3", want: "https://ghe.example.com/api/uploads/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			baseURL, err := url.Parse(tt.baseURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := uploadURLFromBaseURL(baseURL).String(); got != tt.want {
+				t.Errorf("uploadURLFromBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package gitlab
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	gogitlab "github.com/xanzy/go-gitlab"
 )
@@ -24,9 +28,32 @@ import (
 const (
 	// DefaultDomain specifies the default domain used as the backend.
 	DefaultDomain = "gitlab.com"
+
+	// apiPathSuffix is the path go-gitlab appends to the base URL of an instance.
+	apiPathSuffix = "/api/v4"
 )
 
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	return NewClient(gitprovider.TokenFromURL(u), "", append(opts, gitprovider.WithDomain(domain))...)
+}
+
 // NewClient creates a new gitlab.Client instance for GitLab API endpoints.
+//
+// The token is a personal access token, an OAuth2 access token if tokenType is "oauth2", or a
+// CI/CD job token if tokenType is "job"; see NewJobTokenClient for the latter. Pass an empty token
+// when authenticating with WithOAuth2TokenSource or WithTokenSource, which refresh the OAuth2
+// access tokens.
+//
+// Instances behind Kerberos can be used with gitprovider.WithSPNEGO, passing an empty token.
 func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
 	var gl *gogitlab.Client
 	var domain, sshDomain string
@@ -38,41 +65,44 @@ func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOptio
 	}
 
 	// Create a *http.Client using the transport chain
-	httpClient, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	httpClient, err := opts.BuildHTTPClient()
 	if err != nil {
 		return nil, err
 	}
+	// Send the Sudo header for contexts returned by WithSudo
+	httpClient.Transport = &sudoTransport{next: httpClient.Transport}
 
-	if tokenType == "oauth2" {
-		if opts.Domain == nil || *opts.Domain == DefaultDomain {
-			// No domain set or the default gitlab.com used
-			domain = DefaultDomain
-			gl, err = gogitlab.NewOAuthClient(token, gogitlab.WithHTTPClient(httpClient))
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			domain = *opts.Domain
-			gl, err = gogitlab.NewOAuthClient(token, gogitlab.WithHTTPClient(httpClient), gogitlab.WithBaseURL(domain))
-			if err != nil {
-				return nil, err
-			}
+	if tokenType == jobTokenType {
+		if token == "" {
+			return nil, fmt.Errorf("job token cannot be empty: %w", gitprovider.ErrInvalidClientOptions)
 		}
+		// Fail the calls job tokens aren't allowed to make, before sending them
+		httpClient.Transport = &jobTokenTransport{next: httpClient.Transport}
+	}
+
+	glOpts := []gogitlab.ClientOptionFunc{gogitlab.WithHTTPClient(httpClient)}
+	if opts.Domain == nil || *opts.Domain == DefaultDomain {
+		// No domain set or the default gitlab.com used
+		domain = DefaultDomain
 	} else {
-		if opts.Domain == nil || *opts.Domain == DefaultDomain {
-			// No domain set or the default gitlab.com used
-			domain = DefaultDomain
-			gl, err = gogitlab.NewClient(token, gogitlab.WithHTTPClient(httpClient))
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			domain = *opts.Domain
-			gl, err = gogitlab.NewClient(token, gogitlab.WithHTTPClient(httpClient), gogitlab.WithBaseURL(domain))
-			if err != nil {
-				return nil, err
-			}
+		domain, err = normalizeDomain(*opts.Domain)
+		if err != nil {
+			return nil, err
 		}
+		glOpts = append(glOpts, gogitlab.WithBaseURL(gitprovider.GetDomainURL(domain)))
+	}
+
+	switch {
+	case tokenType == jobTokenType:
+		gl, err = gogitlab.NewJobClient(token, glOpts...)
+	case tokenType == "oauth2" || (token == "" && opts.HasAuthentication()):
+		// The tokens of the transport chain are OAuth2 access tokens, sent in the Authorization header
+		gl, err = gogitlab.NewOAuthClient(token, glOpts...)
+	default:
+		gl, err = gogitlab.NewClient(token, glOpts...)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// By default, turn destructive actions off. But allow overrides.
@@ -81,5 +111,33 @@ func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOptio
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(gl, domain, sshDomain, destructiveActions), nil
+	// Download the release links of external hosts with the transport chain, but without the
+	// credentials of the client
+	downloadClient, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c := newClient(gl, domain, sshDomain, destructiveActions)
+	c.c.(*gitlabClientImpl).downloadClient = downloadClient
+	return c, nil
+}
+
+// normalizeDomain validates the given domain and strips any trailing slashes or "/api/v4" suffix
+// from it, so that instances served under a relative URL root (e.g. "https://example.com/gitlab")
+// resolve to the right API endpoint and repository URLs.
+func normalizeDomain(domain string) (string, error) {
+	u, err := url.Parse(gitprovider.GetDomainURL(domain))
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("domain %q has no host: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("domain %q must not contain a query or fragment: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	domain = strings.TrimRight(domain, "/")
+	domain = strings.TrimSuffix(domain, apiPathSuffix)
+	return strings.TrimRight(domain, "/"), nil
 }
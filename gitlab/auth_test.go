@@ -17,8 +17,17 @@ limitations under the License.
 package gitlab
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	gogitlab "github.com/xanzy/go-gitlab"
+	"golang.org/x/oauth2"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
@@ -61,6 +70,134 @@ func TestSupportedDomain(t *testing.T) {
 	}
 }
 
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "plain host",
+			domain: "my-gitlab.dev.com",
+			want:   "my-gitlab.dev.com",
+		},
+		{
+			name:   "subpath with trailing slash",
+			domain: "https://my-gitlab.dev.com/gitlab/",
+			want:   "https://my-gitlab.dev.com/gitlab",
+		},
+		{
+			name:   "subpath without protocol",
+			domain: "my-gitlab.dev.com:8443/gitlab",
+			want:   "my-gitlab.dev.com:8443/gitlab",
+		},
+		{
+			name:   "subpath including api path",
+			domain: "https://my-gitlab.dev.com/gitlab/api/v4/",
+			want:   "https://my-gitlab.dev.com/gitlab",
+		},
+		{
+			name:    "query string",
+			domain:  "https://my-gitlab.dev.com/gitlab?foo=bar",
+			wantErr: gitprovider.ErrInvalidClientOptions,
+		},
+		{
+			name:    "missing host",
+			domain:  "https:///gitlab",
+			wantErr: gitprovider.ErrInvalidClientOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeDomain(tt.domain)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("normalizeDomain() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeDomain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_Subpath(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitlab/api/v4/users/jdoe/starred_projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"id":1,"name":"Flux","path":"flux","namespace":{"kind":"group","full_path":"fluxcd"}}]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := NewClient("token", "pat", gitprovider.WithDomain(srv.URL+"/gitlab/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Raw().(*gogitlab.Client).BaseURL().Path; got != "/gitlab/api/v4/" {
+		t.Errorf("BaseURL().Path = %q, want %q", got, "/gitlab/api/v4/")
+	}
+	assertEqual(t, srv.URL+"/gitlab", c.SupportedDomain())
+
+	refs, err := c.ListStarred(context.Background(), "jdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 1 {
+		t.Fatalf("ListStarred() returned %d refs, want 1", len(refs))
+	}
+	assertEqual(t, srv.URL+"/gitlab/fluxcd/flux.git", gitprovider.GetCloneURL(refs[0], gitprovider.TransportTypeHTTPS))
+	assertEqual(t, "ssh://git@"+srv.Listener.Addr().String()+"/fluxcd/flux", gitprovider.GetCloneURL(refs[0], gitprovider.TransportTypeSSH))
+}
+
+func TestNewClient_OAuth2TokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users/jdoe/starred_projects", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer access-token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer access-token")
+		}
+		if got := r.Header.Values("PRIVATE-TOKEN"); len(got) != 0 {
+			t.Errorf("PRIVATE-TOKEN = %q, want none", got)
+		}
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token"})
+	c, err := NewClient("", "", gitprovider.WithDomain(srv.URL), gitprovider.WithOAuth2TokenSource(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListStarred(context.Background(), "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClient_SPNEGO(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users/jdoe/starred_projects", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Negotiate "+base64.StdEncoding.EncodeToString([]byte("HTTP/127.0.0.1")); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		if got := r.Header.Values("PRIVATE-TOKEN"); len(got) != 0 {
+			t.Errorf("PRIVATE-TOKEN = %q, want none", got)
+		}
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// The fake token is the service principal name
+	tokenFn := func(_ context.Context, spn string) ([]byte, error) { return []byte(spn), nil }
+	c, err := NewClient("", "", gitprovider.WithDomain(srv.URL), gitprovider.WithSPNEGO(tokenFn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListStarred(context.Background(), "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func assertEqual(t *testing.T, a interface{}, b interface{}) {
 	if a != b {
 		t.Fatalf("%s != %s", a, b)
@@ -18,8 +18,12 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
@@ -29,8 +33,13 @@ import (
 const ProviderID = gitprovider.ProviderID("gitlab")
 
 func newClient(c *gitlab.Client, domain string, sshDomain string, destructiveActions bool) *Client {
-	glClient := &gitlabClientImpl{c, destructiveActions}
-	ctx := &clientContext{glClient, domain, sshDomain, destructiveActions}
+	glClient := &gitlabClientImpl{c: c, destructiveActions: destructiveActions}
+	ctx := &clientContext{
+		c:                  glClient,
+		domain:             domain,
+		sshDomain:          sshDomain,
+		destructiveActions: destructiveActions,
+	}
 	return &Client{
 		clientContext: ctx,
 		orgs: &OrganizationsClient{
@@ -42,6 +51,9 @@ func newClient(c *gitlab.Client, domain string, sshDomain string, destructiveAct
 		userRepos: &UserRepositoriesClient{
 			clientContext: ctx,
 		},
+		systemWebhooks: &SystemWebhookClient{
+			clientContext: ctx,
+		},
 	}
 }
 
@@ -50,6 +62,42 @@ type clientContext struct {
 	domain             string
 	sshDomain          string
 	destructiveActions bool
+
+	// instanceDefaultBranch caches the default branch name configured for the GitLab instance,
+	// see DefaultInfo.
+	instanceDefaultBranchMu       sync.Mutex
+	instanceDefaultBranch         string
+	instanceDefaultBranchResolved bool
+}
+
+// clientContext implements gitprovider.InfoDefaulter.
+var _ gitprovider.InfoDefaulter = &clientContext{}
+
+// DefaultInfo sets the GitLab-specific defaults of info: New projects default to the default
+// branch name configured for the GitLab instance. Reading the instance settings requires
+// administrator access; if they can't be read, the provider-agnostic default of
+// gitprovider.RepositoryInfo.Default() is used.
+func (c *clientContext) DefaultInfo(ctx context.Context, info gitprovider.DefaultedInfoRequest) error {
+	repo, ok := info.(*gitprovider.RepositoryInfo)
+	if !ok || repo.DefaultBranch != nil {
+		return nil
+	}
+
+	c.instanceDefaultBranchMu.Lock()
+	defer c.instanceDefaultBranchMu.Unlock()
+	if !c.instanceDefaultBranchResolved {
+		// GET /application/settings
+		branch, err := c.c.GetInstanceDefaultBranch(ctx)
+		// Retry on transient errors next time, but not if the token isn't allowed to read the settings
+		if err == nil || errors.Is(err, gitprovider.ErrForbidden) {
+			c.instanceDefaultBranch = branch
+			c.instanceDefaultBranchResolved = true
+		}
+	}
+	if c.instanceDefaultBranch != "" {
+		repo.DefaultBranch = gitprovider.StringVar(c.instanceDefaultBranch)
+	}
+	return nil
 }
 
 // Client implements the gitprovider.Client interface.
@@ -59,9 +107,15 @@ var _ gitprovider.Client = &Client{}
 type Client struct {
 	*clientContext
 
-	orgs      *OrganizationsClient
-	orgRepos  *OrgRepositoriesClient
-	userRepos *UserRepositoriesClient
+	orgs           *OrganizationsClient
+	orgRepos       *OrgRepositoriesClient
+	userRepos      *UserRepositoriesClient
+	systemWebhooks *SystemWebhookClient
+
+	// gitignoreTemplates caches the result of ListGitignoreTemplates, as the templates of the
+	// provider don't change during the lifetime of the client.
+	gitignoreTemplatesMu sync.Mutex
+	gitignoreTemplates   []string
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "gitlab.com" or
@@ -111,7 +165,146 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 	return c.userRepos
 }
 
+// Client implements the gitprovider.SystemWebhookProvider interface.
+var _ gitprovider.SystemWebhookProvider = &Client{}
+
+// SystemWebhooks returns the SystemWebhookClient handling the system hooks of the GitLab instance.
+func (c *Client) SystemWebhooks() gitprovider.SystemWebhookClient {
+	return c.systemWebhooks
+}
+
+// GetOwnerType returns whether the given owner is a user or an organization, based on the kind
+// of the namespace with the given path. Groups and subgroups (e.g. "group/subgroup") are organizations.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// GET /namespaces/{namespace}
+	apiObj, err := c.c.GetNamespace(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	switch apiObj.Kind {
+	case "user":
+		return gitprovider.OwnerTypeUser, nil
+	case "group":
+		return gitprovider.OwnerTypeOrganization, nil
+	}
+	return "", fmt.Errorf("namespace %q has kind %q: %w", owner, apiObj.Kind, gitprovider.ErrUnknownOwnerType)
+}
+
+// ListStarred returns references to the projects the given user has starred. Projects in user
+// namespaces are returned as UserRepositoryRefs, projects in (sub)groups as OrgRepositoryRefs.
+func (c *Client) ListStarred(ctx context.Context, username string) ([]gitprovider.RepositoryRef, error) {
+	// GET /users/{username}/starred_projects
+	apiObjs, err := c.c.ListUserStarredProjects(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]gitprovider.RepositoryRef, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if apiObj.Namespace.Kind == "user" {
+			refs = append(refs, gitprovider.UserRepositoryRef{
+				UserRef:        gitprovider.UserRef{Domain: c.domain, UserLogin: apiObj.Namespace.FullPath},
+				RepositoryName: apiObj.Path,
+			})
+			continue
+		}
+		groups := strings.Split(apiObj.Namespace.FullPath, "/")
+		refs = append(refs, gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{
+				Domain:           c.domain,
+				Organization:     groups[0],
+				SubOrganizations: groups[1:],
+			},
+			RepositoryName: apiObj.Path,
+		})
+	}
+	return refs, nil
+}
+
+// ListLicenseTemplates returns the license templates GitLab offers when creating projects.
+func (c *Client) ListLicenseTemplates(ctx context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	// GET /templates/licenses
+	apiObjs, err := c.c.ListLicenseTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	licenses := make([]gitprovider.LicenseTemplateInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		licenses = append(licenses, gitprovider.LicenseTemplateInfo{
+			Key:  gitprovider.LicenseTemplate(apiObj.Key),
+			Name: apiObj.Name,
+		})
+	}
+	return licenses, nil
+}
+
+// ListGitignoreTemplates returns the names of the .gitignore templates GitLab offers. The list is
+// cached for the lifetime of the client.
+func (c *Client) ListGitignoreTemplates(ctx context.Context) ([]string, error) {
+	c.gitignoreTemplatesMu.Lock()
+	defer c.gitignoreTemplatesMu.Unlock()
+	if c.gitignoreTemplates == nil {
+		// GET /templates/gitignores
+		apiObjs, err := c.c.ListGitignoreTemplates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(apiObjs))
+		for _, apiObj := range apiObjs {
+			names = append(names, apiObj.Key)
+		}
+		c.gitignoreTemplates = names
+	}
+	// Don't allow callers to modify the cache
+	return append([]string{}, c.gitignoreTemplates...), nil
+}
+
 // HasTokenPermission returns true if the given token has the given permissions.
 func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
 	return false, gitprovider.ErrNoProviderSupport
 }
+
+// impliedScopes maps personal access token scopes to the narrower scopes they include.
+// See: https://docs.gitlab.com/ee/user/profile/personal_access_tokens.html#personal-access-token-scopes
+//
+//nolint:gochecknoglobals
+var impliedScopes = map[string][]string{
+	"api":              {"read_api", "read_user", "read_repository", "write_repository"},
+	"write_repository": {"read_repository"},
+	"write_registry":   {"read_registry"},
+}
+
+// CheckScopes reports which of the required scopes the personal access token lacks, as told by
+// the token information endpoint. That endpoint requires GitLab 15.5 or newer, and isn't
+// available for other kinds of tokens, in which case ErrNoProviderSupport is returned.
+func (c *Client) CheckScopes(ctx context.Context, requiredScopes []string) (*gitprovider.ScopeCheckResult, error) {
+	info, err := c.TokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return gitprovider.NewScopeCheckResult(info.Scopes, requiredScopes, impliedScopes), nil
+}
+
+// TokenInfo returns the name, scopes and expiry of the personal access token, as told by the
+// token information endpoint. Like for CheckScopes, ErrNoProviderSupport is returned if the
+// endpoint isn't available.
+func (c *Client) TokenInfo(ctx context.Context) (*gitprovider.TokenInfo, error) {
+	// GET /personal_access_tokens/self
+	token, err := c.c.GetCurrentPersonalAccessToken(ctx)
+	if errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, fmt.Errorf("the token information can't be read: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &gitprovider.TokenInfo{Name: token.Name, Scopes: token.Scopes}
+	if info.Scopes == nil {
+		info.Scopes = []string{}
+	}
+	if token.ExpiresAt != nil {
+		expiresAt := time.Time(*token.ExpiresAt)
+		info.ExpiresAt = &expiresAt
+	}
+	return info, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// RunnersClient implements the gitprovider.RunnersClient interface.
+var _ gitprovider.RunnersClient = &RunnersClient{}
+
+// RunnersClient handles GitLab runners group-wide.
+type RunnersClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List all runners available to the specific group.
+// This requires owner permissions in the group.
+//
+// The tags of the runners are only fetched if the RunnerLabels call option is set, as that
+// costs an extra request per runner. Otherwise the Labels of the runners are nil.
+//
+// ErrForbidden is returned if the credentials lack the permissions to list runners.
+//
+// List returns all available runners, using multiple paginated requests if needed.
+func (c *RunnersClient) List(ctx context.Context) ([]gitprovider.Runner, error) {
+	// GET /groups/{group}/runners
+	apiObjs, err := c.c.ListGroupRunners(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	runners := make([]gitprovider.Runner, 0, len(apiObjs))
+	fetchLabels := gitprovider.CallOptionsFromContext(ctx).ShouldFetchRunnerLabels()
+	for _, apiObj := range apiObjs {
+		if !fetchLabels {
+			runners = append(runners, newRunner(runnerDetailsFromList(apiObj), c.ref))
+			continue
+		}
+		// The tags of a runner are only part of the detailed runner information.
+		// GET /runners/{id}
+		details, err := c.c.GetRunner(ctx, apiObj.ID)
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, newRunner(details, c.ref))
+	}
+	return runners, nil
+}
+
+// CreateRegistrationToken resets the registration token of the specific group, and returns the
+// new token that can be used to register a new runner. Note that the previous registration token
+// of the group is invalidated by this call: registering runners with it fails afterwards, while
+// the runners already registered with it keep working.
+// This requires owner permissions in the group. The returned token is a secret, and must not be logged.
+//
+// ErrForbidden is returned if the credentials lack the permissions to reset the token.
+func (c *RunnersClient) CreateRegistrationToken(ctx context.Context) (gitprovider.RunnerRegistrationToken, error) {
+	// POST /groups/{group}/runners/reset_registration_token
+	apiObj, err := c.c.ResetGroupRunnerRegistrationToken(ctx, c.ref.Organization)
+	if err != nil {
+		return gitprovider.RunnerRegistrationToken{}, err
+	}
+	return gitprovider.NewRunnerRegistrationToken(*apiObj.Token, apiObj.TokenExpiresAt), nil
+}
+
+// Delete removes the runner with the given ID.
+// This requires owner permissions in all groups and projects the runner is assigned to.
+//
+// ErrNotFound is returned if the runner does not exist.
+// ErrForbidden is returned if the credentials lack the permissions to delete the runner.
+func (c *RunnersClient) Delete(ctx context.Context, id int64) error {
+	// DELETE /runners/{id}
+	return c.c.DeleteRunner(ctx, int(id))
+}
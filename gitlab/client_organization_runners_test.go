@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestRunnersClient_List(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        gitprovider.CallOptions
+		wantDetails int
+		wantLabels  []string
+	}{
+		{
+			name: "without labels",
+		},
+		{
+			name:        "with labels",
+			opts:        gitprovider.CallOptions{RunnerLabels: gitprovider.BoolVar(true)},
+			wantDetails: 2,
+			wantLabels:  []string{"docker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/groups/org/runners", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `[{"id":1,"description":"first","online":true},{"id":2,"name":"second"}]`)
+			})
+			mux.HandleFunc("/api/v4/runners/", func(w http.ResponseWriter, r *http.Request) {
+				details++
+				_, _ = fmt.Fprintf(w, `{"id":%s,"tag_list":["docker"]}`, r.URL.Path[len("/api/v4/runners/"):])
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &RunnersClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref:           gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			}
+
+			runners, err := c.List(gitprovider.WithOptions(context.Background(), tt.opts))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details != tt.wantDetails {
+				t.Errorf("runner detail requests = %d, want %d", details, tt.wantDetails)
+			}
+			if len(runners) != 2 {
+				t.Fatalf("got %d runners, want 2", len(runners))
+			}
+			for _, runner := range runners {
+				if got := runner.Get().Labels; !reflect.DeepEqual(got, tt.wantLabels) {
+					t.Errorf("Labels = %v, want %v", got, tt.wantLabels)
+				}
+			}
+			if tt.wantDetails == 0 {
+				want := gitprovider.RunnerInfo{ID: 1, Name: "first", Status: gitprovider.RunnerStatusOnline}
+				if got := runners[0].Get(); !reflect.DeepEqual(got, want) {
+					t.Errorf("Get() = %+v, want %+v", got, want)
+				}
+			}
+		})
+	}
+}
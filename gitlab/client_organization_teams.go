@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
@@ -39,6 +40,10 @@ type TeamsClient struct {
 //
 // ErrNotFound is returned if the resource does not exist.
 func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Team, error) {
+	return c.get(ctx, teamName)
+}
+
+func (c *TeamsClient) get(ctx context.Context, teamName string) (*team, error) {
 	apiObjs, err := c.c.ListGroupMembers(ctx, c.ref.Organization)
 	if err != nil {
 		return nil, err
@@ -62,20 +67,42 @@ func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Tea
 }
 
 // List all teams (recursively, in terms of subgroups) within the specific organization.
+// The subgroups form the team hierarchy, subgroups directly below the organization are
+// top-level teams. Only those are returned if the TopLevelOnly option is set.
+// Teams are named by the path of their subgroup relative to the organization, e.g.
+// "parent/child", as the names of subgroups aren't unique.
 //
 // List returns all available organizations, using multiple paginated requests if needed.
-func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
-	subgroups, err := c.c.ListSubgroups(ctx, c.ref.Organization)
+func (c *TeamsClient) List(ctx context.Context, opts ...gitprovider.TeamListOption) ([]gitprovider.Team, error) {
+	o := gitprovider.MakeTeamListOptions(opts...)
+
+	// GET /groups/{group}/descendant_groups
+	subgroups, err := c.c.ListDescendantGroups(ctx, c.ref.Organization)
 	if err != nil {
 		return nil, err
 	}
 
+	// Parents not among the descendants are the organization itself
+	byID := make(map[int]*gitlab.Group, len(subgroups))
+	for _, subgroup := range subgroups {
+		byID[subgroup.ID] = subgroup
+	}
+	paths := teamPaths(byID)
+
 	teams := make([]gitprovider.Team, 0, len(subgroups))
 	for _, subgroup := range subgroups {
-		team, err := c.Get(ctx, subgroup.Name)
+		parent, hasParent := byID[subgroup.ParentID]
+		if o.TopLevelOnly != nil && *o.TopLevelOnly && hasParent {
+			continue
+		}
+
+		team, err := c.get(ctx, paths[subgroup.ID])
 		if err != nil {
 			return nil, err
 		}
+		if hasParent {
+			team.info.Parent = gitprovider.StringVar(paths[parent.ID])
+		}
 
 		teams = append(teams, team)
 	}
@@ -83,6 +110,85 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 	return teams, nil
 }
 
+// SetParent moves the team with the given name below the parent team with the given name, by
+// transferring the subgroup. If parentName is an empty string, the subgroup is transferred to
+// directly below the organization. Teams are named by the path of their subgroup relative to
+// the organization, as in List.
+//
+// ErrNotFound is returned if either team does not exist.
+// ErrInvalidArgument is returned if the move would make the team its own ancestor.
+// ErrForbidden is returned if the credentials lack the permissions to move the team.
+func (c *TeamsClient) SetParent(ctx context.Context, teamName, parentName string) error {
+	// GET /groups/{group}/descendant_groups
+	subgroups, err := c.c.ListDescendantGroups(ctx, c.ref.Organization)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*gitlab.Group, len(subgroups))
+	for _, subgroup := range subgroups {
+		byID[subgroup.ID] = subgroup
+	}
+	// Teams are identified by the relative path of the subgroup, as in List
+	byPath := make(map[string]*gitlab.Group, len(subgroups))
+	for id, path := range teamPaths(byID) {
+		byPath[path] = byID[id]
+	}
+
+	subgroup, ok := byPath[teamName]
+	if !ok {
+		return fmt.Errorf("team %q: %w", teamName, gitprovider.ErrNotFound)
+	}
+
+	var parentID int
+	if parentName == "" {
+		// GET /groups/{group}
+		org, err := c.c.GetGroup(ctx, c.ref.Organization)
+		if err != nil {
+			return err
+		}
+		parentID = org.ID
+	} else {
+		parent, ok := byPath[parentName]
+		if !ok {
+			return fmt.Errorf("team %q: %w", parentName, gitprovider.ErrNotFound)
+		}
+		// Walk up from the new parent, to make sure the team doesn't become its own ancestor
+		for ancestor := parent; ancestor != nil; ancestor = byID[ancestor.ParentID] {
+			if ancestor.ID == subgroup.ID {
+				return fmt.Errorf("cannot move team %q below %q, as it would be its own ancestor: %w", teamName, parentName, gitprovider.ErrInvalidArgument)
+			}
+		}
+		parentID = parent.ID
+	}
+
+	// POST /groups/{group}/transfer
+	_, err = c.c.TransferSubgroup(ctx, subgroup.ID, parentID)
+	return err
+}
+
+// teamPaths returns the paths of the given descendant subgroups of an organization relative to
+// it, by their ID, e.g. "parent/child". Unlike the names of subgroups, the paths are unique.
+func teamPaths(byID map[int]*gitlab.Group) map[int]string {
+	paths := make(map[int]string, len(byID))
+	var pathOf func(subgroup *gitlab.Group) string
+	pathOf = func(subgroup *gitlab.Group) string {
+		if path, ok := paths[subgroup.ID]; ok {
+			return path
+		}
+		path := subgroup.Path
+		// Parents not among the descendants are the organization itself
+		if parent, ok := byID[subgroup.ParentID]; ok {
+			path = pathOf(parent) + "/" + path
+		}
+		paths[subgroup.ID] = path
+		return path
+	}
+	for _, subgroup := range byID {
+		pathOf(subgroup)
+	}
+	return paths
+}
+
 var _ gitprovider.Team = &team{}
 
 type team struct {
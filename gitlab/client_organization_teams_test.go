@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTeamsClient_subgroupPaths(t *testing.T) {
+	var transferred []string
+	mux := http.NewServeMux()
+	// Both top-level subgroups are named "Team", only their paths are unique
+	mux.HandleFunc("/api/v4/groups/org/descendant_groups", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[`+
+			`{"id":2,"name":"Team","path":"team-a","full_path":"org/team-a","parent_id":1},`+
+			`{"id":3,"name":"Team","path":"team-b","full_path":"org/team-b","parent_id":1},`+
+			`{"id":4,"name":"Sub","path":"sub","full_path":"org/team-a/sub","parent_id":2}]`)
+	})
+	mux.HandleFunc("/api/v4/groups/org/members", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/api/v4/groups/org", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":1,"name":"org","path":"org","full_path":"org"}`)
+	})
+	for _, id := range []int{2, 3, 4} {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/api/v4/groups/%d/transfer", id), func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				GroupID int `json:"group_id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			transferred = append(transferred, fmt.Sprintf("%d -> %d", id, body.GroupID))
+			_, _ = fmt.Fprintf(w, `{"id":%d,"path":"moved"}`, id)
+		})
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &TeamsClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref:           gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+	}
+	ctx := context.Background()
+
+	teams, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, team := range teams {
+		info := team.Get()
+		got[info.Name] = ""
+		if info.Parent != nil {
+			got[info.Name] = *info.Parent
+		}
+	}
+	if want := map[string]string{"team-a": "", "team-b": "", "team-a/sub": "team-a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() teams and parents = %v, want %v", got, want)
+	}
+
+	if err := c.SetParent(ctx, "team-a/sub", "team-b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetParent(ctx, "team-b", ""); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"4 -> 3", "3 -> 1"}; !reflect.DeepEqual(transferred, want) {
+		t.Errorf("SetParent() transfers = %v, want %v", transferred, want)
+	}
+
+	if err := c.SetParent(ctx, "team-a", "team-a/sub"); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument moving a team below itself, got %v", err)
+	}
+	if err := c.SetParent(ctx, "Team", ""); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a subgroup name, got %v", err)
+	}
+}
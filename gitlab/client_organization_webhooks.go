@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationWebhookClient implements the gitprovider.OrganizationWebhookClient interface.
+var _ gitprovider.OrganizationWebhookClient = &OrganizationWebhookClient{}
+
+// OrganizationWebhookClient operates on the webhooks of a specific group. Group webhooks are
+// triggered by the events in all projects of the group and its subgroups.
+type OrganizationWebhookClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationWebhookClient) Get(ctx context.Context, url string) (gitprovider.OrganizationWebhook, error) {
+	webhooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, wh := range webhooks {
+		if wh.h.URL == url {
+			return wh, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the group.
+//
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *OrganizationWebhookClient) List(ctx context.Context) ([]gitprovider.OrganizationWebhook, error) {
+	whs, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.OrganizationWebhook
+	webhooks := make([]gitprovider.OrganizationWebhook, 0, len(whs))
+	for _, wh := range whs {
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+func (c *OrganizationWebhookClient) list(ctx context.Context) ([]*groupWebhook, error) {
+	// GET /groups/{group}/hooks
+	apiObjs, err := c.c.ListGroupHooks(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our OrganizationWebhook type
+	webhooks := make([]*groupWebhook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListGroupHooks
+		webhooks = append(webhooks, newGroupWebhook(c, apiObj))
+	}
+	return webhooks, nil
+}
+
+// Create creates a webhook with the given specifications. GitLab sends the secret as
+// "X-Gitlab-Token" header, and only supports JSON payloads.
+func (c *OrganizationWebhookClient) Create(ctx context.Context, req gitprovider.WebhookInfo) (gitprovider.OrganizationWebhook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookContentType(req); err != nil {
+		return nil, err
+	}
+
+	// POST /groups/{group}/hooks
+	apiObj, err := c.c.CreateGroupHook(ctx, c.ref.Organization, groupWebhookToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newGroupWebhook(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing webhook is matched by its URL.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+// WebhookReconcileOptions.ForceSecretRotation deletes and recreates the webhook (actionTaken == true).
+func (c *OrganizationWebhookClient) Reconcile(ctx context.Context, req gitprovider.WebhookInfo, opts ...gitprovider.WebhookReconcileOption) (gitprovider.OrganizationWebhook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+	rotateSecret, err := gitprovider.RotatesWebhookSecret(req, gitprovider.MakeWebhookReconcileOptions(opts...))
+	if err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// The secret can't be compared, hence recreate the webhook to make sure it's used
+	if rotateSecret {
+		if err := actual.Delete(ctx); err != nil {
+			return nil, false, err
+		}
+		resp, err := c.Create(ctx, req)
+		return resp, true, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET /api/v4/groups/group/hooks"},
+		},
+		{
+			name: "change events and secret",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventIssues},
+			},
+			wantRequests: []string{"GET /api/v4/groups/group/hooks", "PUT /api/v4/groups/group/hooks/1"},
+			wantBody: map[string]interface{}{
+				"url":                   hookURL,
+				"token":                 "s3cr3t",
+				"push_events":           false,
+				"tag_push_events":       false,
+				"merge_requests_events": false,
+				"issues_events":         true,
+				"note_events":           false,
+				"releases_events":       false,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET /api/v4/groups/group/hooks", "POST /api/v4/groups/group/hooks"},
+			wantBody: map[string]interface{}{
+				"url":                   "https://example.com/other",
+				"push_events":           false,
+				"tag_push_events":       true,
+				"merge_requests_events": false,
+				"issues_events":         false,
+				"note_events":           false,
+				"releases_events":       false,
+			},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/groups/", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `[{"id":1,"url":%q,"group_id":2,"push_events":true,"tag_push_events":true,"merge_requests_events":true}]`, hookURL)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 1}
+					for key, value := range body {
+						resp[key] = value
+					}
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &OrganizationWebhookClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref:           gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "group"},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -87,3 +87,18 @@ func (c *OrganizationsClient) Children(ctx context.Context, ref gitprovider.Orga
 
 	return subgroups, nil
 }
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as GitLab groups have no default permission for their members.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as GitLab groups have no default permission for their members.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as GitLab groups have no default permission for their members.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
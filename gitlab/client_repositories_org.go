@@ -19,6 +19,7 @@ package gitlab
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
@@ -41,7 +42,7 @@ func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepo
 		return nil, err
 	}
 	// GET /groups/{group}/projects
-	apiObj, err := c.c.GetGroupProject(ctx, ref.OrganizationRef.Organization, ref.RepositoryName)
+	apiObj, err := c.c.GetGroupProject(ctx, ref.OrganizationRef.GetIdentity(), ref.RepositoryName)
 	if err != nil {
 		return nil, err
 	}
@@ -52,13 +53,22 @@ func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepo
 //
 // List returns all available repositories, using multiple paginated requests if needed.
 func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given organization like List, and calls
+// progress after each page. The total is taken from the X-Total header, which GitLab omits for
+// very large groups.
+//
+// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
 	// Make sure the OrganizationRef is valid
 	if err := validateOrganizationRef(ref, c.domain); err != nil {
 		return nil, err
 	}
 
-	// GET /orgs/{org}/repos
-	apiObjs, err := c.c.ListGroupProjects(ctx, ref.Organization)
+	// GET /groups/{group}/projects
+	apiObjs, err := c.c.ListGroupProjects(ctx, ref.GetIdentity(), progress)
 	if err != nil {
 		return nil, err
 	}
@@ -75,20 +85,123 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// SearchPage returns the given page of repositories in the given organization, that match
+// all filters set in opts. Pages are numbered from 1, and hold at most perPage repositories.
+//
+// Without filters, the page is requested from GitLab as is. GitLab can't filter the projects of a
+// group by language though, hence with a Language filter the projects are listed page by page,
+// and the languages of each project are fetched to find its primary language, until the requested
+// page of matching projects is complete.
+//
+// validation.ErrFieldRequired is returned if a filter is set, but empty.
+func (c *OrgRepositoriesClient) SearchPage(ctx context.Context, ref gitprovider.OrganizationRef, perPage, page int, opts ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	o, err := gitprovider.MakeRepositorySearchOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	if o.Language == nil {
+		var apiObjs []*gitlab.Project
+		if perPage <= 0 {
+			// GET /groups/{group}/projects
+			apiObjs, err = c.c.ListGroupProjects(ctx, ref.GetIdentity(), nil)
+		} else {
+			// GET /groups/{group}/projects
+			apiObjs, _, err = c.c.ListGroupProjectsPage(ctx, ref.GetIdentity(), perPage, page)
+		}
+		if err != nil {
+			return nil, err
+		}
+		repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+		for _, apiObj := range apiObjs {
+			repos = append(repos, c.newSearchResult(ref, apiObj))
+		}
+		return repos, nil
+	}
+
+	// The matching projects on the pages before the requested one are skipped
+	skip := 0
+	if perPage > 0 {
+		skip = (page - 1) * perPage
+	}
+	repos := make([]gitprovider.OrgRepository, 0)
+	for serverPage := 1; serverPage != 0; {
+		// GET /groups/{group}/projects
+		apiObjs, nextPage, err := c.c.ListGroupProjectsPage(ctx, ref.GetIdentity(), perPage, serverPage)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range apiObjs {
+			// GET /projects/{project}/languages
+			languages, err := c.c.GetProjectLanguages(ctx, apiObj.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(primaryLanguage(languages), *o.Language) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			repos = append(repos, c.newSearchResult(ref, apiObj))
+			if len(repos) == perPage {
+				return repos, nil
+			}
+		}
+		serverPage = nextPage
+	}
+	return repos, nil
+}
+
+// newSearchResult returns the repository for the project apiObj found in the group ref.
+func (c *OrgRepositoriesClient) newSearchResult(ref gitprovider.OrganizationRef, apiObj *gitlab.Project) gitprovider.OrgRepository {
+	return newGroupProject(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+		OrganizationRef: ref,
+		RepositoryName:  apiObj.Name,
+	})
+}
+
+// primaryLanguage returns the language with the highest share, as GitHub considers the
+// language with the most code as the primary language of the repository.
+func primaryLanguage(languages map[string]float32) string {
+	primary, share := "", float32(-1)
+	for language, s := range languages {
+		// Break ties by name, for a stable result
+		if s > share || (s == share && language < primary) {
+			primary, share = language, s
+		}
+	}
+	return primary
+}
+
 // Create creates a repository for the given organization, with the data and options.
 //
-// ErrAlreadyExists will be returned if the resource already exists.
+// ErrAlreadyExists will be returned if the resource already exists. If req has settings GitLab
+// doesn't support, the repository is created with the supported ones, and returned along with
+// an error wrapping ErrNoProviderSupport.
 func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
 	// Make sure the RepositoryRef is valid
 	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
 		return nil, err
 	}
 
-	apiObj, err := createProject(ctx, c.c, ref, ref.Organization, req, opts...)
-	if err != nil {
+	// Create the project in the deepest sub-group
+	apiObj, initialCommitSHA, err := createProject(ctx, c.clientContext, ref, ref.GetIdentity(), req, opts...)
+	if err != nil && !errors.Is(err, gitprovider.ErrNoProviderSupport) {
 		return nil, err
 	}
-	return newGroupProject(c.clientContext, apiObj, ref), nil
+	repo := newGroupProject(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, err
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -99,7 +212,7 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgR
 func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
-	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+	if err := gitprovider.ValidateAndDefaultInfoWith(ctx, c.clientContext, &req); err != nil {
 		return nil, false, err
 	}
 
@@ -118,45 +231,160 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 	return actual, actionTaken, err
 }
 
+// Transfer transfers the project to the group newOwner, which may be a sub-group, keeping its
+// name. Once the project is visible in newOwner, opts.TeamAccess is re-applied.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Transfer(ctx context.Context, ref gitprovider.OrgRepositoryRef, newOwner gitprovider.OrganizationRef, opts ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	// Make sure the references are valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	if err := validateOrganizationRef(newOwner, c.domain); err != nil {
+		return nil, err
+	}
+	o, err := gitprovider.MakeRepositoryTransferOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// PUT /projects/{project}/transfer
+	if _, err := c.c.TransferProject(ctx, getRepoPath(ref), newOwner.GetIdentity()); err != nil {
+		return nil, err
+	}
+	newRef := gitprovider.OrgRepositoryRef{OrganizationRef: newOwner, RepositoryName: ref.RepositoryName}
+	return gitprovider.CompleteRepositoryTransfer(ctx, c, newRef, o)
+}
+
 // nolint
-func createProject(ctx context.Context, c gitlabClient, ref gitprovider.RepositoryRef, groupName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitlab.Project, error) {
+// createProject creates the project, and returns it together with the SHA of the initial commit
+// if the AutoInit option is set. GitLab doesn't return the initial commit when creating the
+// project, hence it's looked up from the default branch. If the lookup fails, e.g. as the commit
+// isn't visible yet, nil is returned for the SHA. If req has unsupported settings, the created
+// project is returned along with an error wrapping ErrNoProviderSupport.
+func createProject(ctx context.Context, c *clientContext, ref gitprovider.RepositoryRef, groupPath string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitlab.Project, *string, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
-	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
-		return nil, err
+	if err := gitprovider.ValidateAndDefaultInfoWith(ctx, c, &req); err != nil {
+		return nil, nil, err
 	}
 
-	// Convert to the API object and apply the options
-	data := repositoryToAPI(&req, ref)
-	if len(groupName) > 0 {
+	// Convert to the API object and apply the options. Unsupported settings are reported once
+	// the project has been created with the supported ones.
+	data, unsupportedErr := repositoryToAPI(&req, ref)
+	if unsupportedErr != nil && !errors.Is(unsupportedErr, gitprovider.ErrNoProviderSupport) {
+		return nil, nil, unsupportedErr
+	}
+	if len(groupPath) > 0 {
 		data.Namespace = &gitlab.ProjectNamespace{
-			Name: groupName,
+			FullPath: groupPath,
 		}
 	}
 
 	// Assemble the options struct based on the given options
 	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// Only check the membership if creating in a group
+	if len(groupPath) > 0 && o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		if err := checkGroupMembership(ctx, c, groupPath); err != nil {
+			return nil, nil, err
+		}
 	}
 	apiOpts := gitlab.CreateProjectOptions{
 		InitializeWithReadme: o.AutoInit,
+		// Only set if requested, to keep the instance default otherwise
+		RemoveSourceBranchAfterMerge: req.DeleteBranchOnMerge,
 	}
 
-	return c.CreateProject(ctx, &data, &apiOpts)
+	apiObj, err := c.c.CreateProject(ctx, &data, &apiOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	gitprovider.VerifyCreatedRepositoryVisibility(ctx, o.VerifyVisibility, ref, req.Visibility, func(ctx context.Context) (*gitprovider.RepositoryVisibility, error) {
+		actual, err := c.c.GetUserProject(ctx, getRepoPath(ref))
+		if err != nil {
+			return nil, err
+		}
+		return repositoryFromAPI(actual).Visibility, nil
+	})
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil, unsupportedErr
+	}
+
+	// The project exists at this point, hence don't fail if the lookup does
+	// GET /projects/{project}/repository/branches/{branch}
+	branch, err := c.c.GetBranch(ctx, apiObj.PathWithNamespace, apiObj.DefaultBranch)
+	if err != nil {
+		return apiObj, nil, unsupportedErr
+	}
+	return apiObj, &branch.Commit.ID, unsupportedErr
+}
+
+// accessLevelNames maps GitLab access levels to the names of the roles in the UI.
+var accessLevelNames = map[gitlab.AccessLevelValue]string{
+	gitlab.MinimalAccessPermissions: "minimal access",
+	gitlab.GuestPermissions:         "guest",
+	gitlab.ReporterPermissions:      "reporter",
+	gitlab.DeveloperPermissions:     "developer",
+	gitlab.MaintainerPermissions:    "maintainer",
+	gitlab.OwnerPermissions:         "owner",
+}
+
+// checkGroupMembership makes sure the authenticated user is allowed to create projects in the
+// group, taking the project creation level of the group into account.
+func checkGroupMembership(ctx context.Context, c *clientContext, groupPath string) error {
+	// GET /user
+	user, err := c.c.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	// GET /groups/{group}
+	group, err := c.c.GetGroup(ctx, groupPath)
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	requiredLevel := gitlab.DeveloperPermissions
+	if group.ProjectCreationLevel == gitlab.MaintainerProjectCreation {
+		requiredLevel = gitlab.MaintainerPermissions
+	}
+
+	// GET /groups/{group}/members/all/{user_id}
+	member, err := c.c.GetInheritedGroupMember(ctx, groupPath, user.ID)
+	if err != nil && !errors.Is(err, gitprovider.ErrNotFound) {
+		return err
+	}
+	role := ""
+	if err == nil {
+		if member.AccessLevel >= requiredLevel {
+			return nil
+		}
+		role = accessLevelNames[member.AccessLevel]
+	}
+	return &gitprovider.InsufficientMembershipError{Organization: groupPath, Role: role, RequiredRole: accessLevelNames[requiredLevel]}
 }
 
 func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// Unsupported settings can't be reconciled, they're reported once the supported ones are
+	var unsupportedErr error
+	if req.AllowAutoMerge != nil {
+		unsupportedErr = errAllowAutoMergeUnsupported
+		req.AllowAutoMerge = nil
+	}
 	// If the desired matches the actual state, just return the actual state
 	if req.Equals(actual.Get()) {
-		return false, nil
+		return false, unsupportedErr
 	}
 	// Populate the desired state to the current-actual object
 	if err := actual.Set(req); err != nil {
 		return false, err
 	}
 	// Apply the desired state by running Update
-	return true, actual.Update(ctx)
+	if err := actual.Update(ctx); err != nil {
+		return true, err
+	}
+	return true, unsupportedErr
 }
 
 func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
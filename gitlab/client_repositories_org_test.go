@@ -0,0 +1,453 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_Create_subgroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		groups        map[string]int
+		wantNamespace int
+		wantErr       error
+		wantErrGroup  string
+	}{
+		{
+			name:          "existing subgroup",
+			groups:        map[string]int{"group": 1, "group/subgroup": 2, "group/subgroup/subsubgroup": 3},
+			wantNamespace: 3,
+		},
+		{
+			name:         "missing subgroup",
+			groups:       map[string]int{"group": 1},
+			wantErr:      gitprovider.ErrGroupNotFound,
+			wantErrGroup: `"group/subgroup"`,
+		},
+		{
+			name:         "missing deepest subgroup",
+			groups:       map[string]int{"group": 1, "group/subgroup": 2},
+			wantErr:      gitprovider.ErrGroupNotFound,
+			wantErrGroup: `"group/subgroup/subsubgroup"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var namespaceID int
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/groups/", func(w http.ResponseWriter, r *http.Request) {
+				path := strings.TrimPrefix(r.URL.Path, "/api/v4/groups/")
+				id, ok := tt.groups[path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"404 Group Not Found"}`)
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"id":%d,"path":%q,"full_path":%q}`, id, path[strings.LastIndex(path, "/")+1:], path)
+			})
+			mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Name        string `json:"name"`
+					NamespaceID int    `json:"namespace_id"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				namespaceID = body.NamespaceID
+				_, _ = fmt.Fprintf(w, `{"id":10,"name":%q,"path_with_namespace":"group/subgroup/subsubgroup/%s"}`, body.Name, body.Name)
+			})
+			mux.HandleFunc("/api/v4/application/settings", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &OrgRepositoriesClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}, domain: "gitlab.com"},
+			}
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{
+					Domain:           "gitlab.com",
+					Organization:     "group",
+					SubOrganizations: []string{"subgroup", "subsubgroup"},
+				},
+				RepositoryName: "repo",
+			}
+
+			_, err = c.Create(context.Background(), ref, gitprovider.RepositoryInfo{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.wantErrGroup) {
+					t.Errorf("Create() error = %v, want it to mention %s", err, tt.wantErrGroup)
+				}
+				return
+			}
+			if namespaceID != tt.wantNamespace {
+				t.Errorf("namespace_id = %d, want %d", namespaceID, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_checkOrgMembership(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessLevel int
+		wantErr     *gitprovider.InsufficientMembershipError
+	}{
+		{
+			name:    "not a member",
+			wantErr: &gitprovider.InsufficientMembershipError{Organization: "group", RequiredRole: "maintainer"},
+		},
+		{
+			name:        "developer",
+			accessLevel: 30,
+			wantErr:     &gitprovider.InsufficientMembershipError{Organization: "group", Role: "developer", RequiredRole: "maintainer"},
+		},
+		{
+			name:        "maintainer",
+			accessLevel: 40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"id":7,"username":"user"}`)
+			})
+			mux.HandleFunc("/api/v4/groups/group", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"id":1,"path":"group","full_path":"group","project_creation_level":"maintainer"}`)
+			})
+			mux.HandleFunc("/api/v4/groups/group/members/all/7", func(w http.ResponseWriter, r *http.Request) {
+				if tt.accessLevel == 0 {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"404 Not found"}`)
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"id":7,"username":"user","access_level":%d}`, tt.accessLevel)
+			})
+			mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+				created = true
+				_, _ = fmt.Fprint(w, `{"id":10,"name":"repo","path_with_namespace":"group/repo"}`)
+			})
+			mux.HandleFunc("/api/v4/application/settings", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &OrgRepositoriesClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}, domain: "gitlab.com"},
+			}
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "group"},
+				RepositoryName:  "repo",
+			}
+
+			_, err = c.Create(context.Background(), ref, gitprovider.RepositoryInfo{},
+				&gitprovider.RepositoryCreateOptions{CheckOrgMembership: gitprovider.BoolVar(true)})
+			if tt.wantErr == nil {
+				if err != nil || !created {
+					t.Fatalf("Create() error = %v, created = %v", err, created)
+				}
+				return
+			}
+			gotErr := &gitprovider.InsufficientMembershipError{}
+			if !errors.Is(err, gitprovider.ErrForbidden) || !errors.As(err, &gotErr) || *gotErr != *tt.wantErr {
+				t.Errorf("Create() error = %#v, want %#v", err, tt.wantErr)
+			}
+			if created {
+				t.Errorf("project was created")
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_unsupportedSettings(t *testing.T) {
+	var exists bool
+	var description string
+	var updates int
+	project := func() string {
+		return fmt.Sprintf(`{"id":10,"name":"repo","path_with_namespace":"group/repo","description":%q,"visibility":"private","default_branch":"main"}`, description)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":1,"path":"group","full_path":"group"}`)
+	})
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Description string `json:"description"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		exists, description = true, body.Description
+		_, _ = fmt.Fprint(w, project())
+	})
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/group/repo":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(w, `{"message":"404 Project Not Found"}`)
+				return
+			}
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/10":
+			var body struct {
+				Description string `json:"description"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			description = body.Description
+			updates++
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, project())
+	})
+	mux.HandleFunc("/api/v4/application/settings", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &OrgRepositoriesClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}, domain: "gitlab.com"},
+	}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "group"},
+		RepositoryName:  "repo",
+	}
+	req := gitprovider.RepositoryInfo{
+		Description:    gitprovider.StringVar("created"),
+		DefaultBranch:  gitprovider.StringVar("main"),
+		AllowAutoMerge: gitprovider.BoolVar(true),
+	}
+
+	// The project is created with the supported settings
+	repo, err := c.Create(context.Background(), ref, req)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Fatalf("Create() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if repo == nil || description != "created" {
+		t.Fatalf("Create() = %v with description %q, want the created project", repo, description)
+	}
+
+	// The supported settings are reconciled
+	req.Description = gitprovider.StringVar("updated")
+	_, actionTaken, err := c.Reconcile(context.Background(), ref, req)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Fatalf("Reconcile() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if !actionTaken || updates != 1 || description != "updated" {
+		t.Errorf("Reconcile() actionTaken = %v with %d updates and description %q, want the description to be updated", actionTaken, updates, description)
+	}
+
+	// Once they match, nothing is updated, but the unsupported setting is still reported
+	_, actionTaken, err = c.Reconcile(context.Background(), ref, req)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Fatalf("Reconcile() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if actionTaken || updates != 1 {
+		t.Errorf("Reconcile() actionTaken = %v with %d updates, want no update", actionTaken, updates)
+	}
+}
+
+func TestOrgRepositoriesClient_Create_initialCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantSHA *string
+	}{
+		{
+			name:    "initial commit",
+			status:  http.StatusOK,
+			wantSHA: gitprovider.StringVar("abc123"),
+		},
+		{
+			name:   "initial commit not visible yet",
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/groups/", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"id":1,"path":"group","full_path":"group"}`)
+			})
+			mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"id":10,"name":"repo","path_with_namespace":"group/repo","default_branch":"main"}`)
+			})
+			mux.HandleFunc("/api/v4/projects/group/repo/repository/branches/main", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusOK {
+					_, _ = fmt.Fprint(w, `{"message":"404 Branch Not Found"}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"name":"main","commit":{"id":"abc123"}}`)
+			})
+			mux.HandleFunc("/api/v4/application/settings", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &OrgRepositoriesClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}, domain: "gitlab.com"},
+			}
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "group"},
+				RepositoryName:  "repo",
+			}
+
+			repo, err := c.Create(context.Background(), ref, gitprovider.RepositoryInfo{},
+				&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			got := repo.InitialCommitSHA()
+			if (got == nil) != (tt.wantSHA == nil) || (got != nil && *got != *tt.wantSHA) {
+				t.Errorf("InitialCommitSHA() = %v, want %v", got, tt.wantSHA)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_SearchPage(t *testing.T) {
+	languages := []string{"Go", "Python", "Go", "Go", "Rust"}
+	tests := []struct {
+		name          string
+		perPage, page int
+		opts          []gitprovider.RepositorySearchOption
+		want          []string
+		wantLanguages int
+	}{
+		{
+			name:    "page without filters",
+			perPage: 2,
+			page:    2,
+			want:    []string{"repo3", "repo4"},
+		},
+		{
+			name:          "page of a language",
+			perPage:       2,
+			page:          1,
+			opts:          []gitprovider.RepositorySearchOption{&gitprovider.RepositorySearchOptions{Language: gitprovider.StringVar("go")}},
+			want:          []string{"repo1", "repo3"},
+			wantLanguages: 3,
+		},
+		{
+			name:          "last page of a language",
+			perPage:       2,
+			page:          2,
+			opts:          []gitprovider.RepositorySearchOption{&gitprovider.RepositorySearchOptions{Language: gitprovider.StringVar("go")}},
+			want:          []string{"repo4"},
+			wantLanguages: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			languageRequests := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/groups/group/projects", func(w http.ResponseWriter, r *http.Request) {
+				var perPage, page int
+				_, _ = fmt.Sscan(r.URL.Query().Get("per_page"), &perPage)
+				_, _ = fmt.Sscan(r.URL.Query().Get("page"), &page)
+				start, end := (page-1)*perPage, page*perPage
+				if end >= len(languages) {
+					end = len(languages)
+				} else {
+					w.Header().Set("X-Next-Page", fmt.Sprint(page+1))
+				}
+				objs := make([]string, 0, end-start)
+				for i := start; i < end; i++ {
+					objs = append(objs, fmt.Sprintf(`{"id":%d,"name":"repo%d"}`, i+1, i+1))
+				}
+				_, _ = fmt.Fprintf(w, "[%s]", strings.Join(objs, ","))
+			})
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				var id int
+				if _, err := fmt.Sscanf(r.URL.Path, "/api/v4/projects/%d/languages", &id); err != nil {
+					t.Errorf("unexpected request %s", r.URL.Path)
+					return
+				}
+				languageRequests++
+				_, _ = fmt.Fprintf(w, `{%q:100}`, languages[id-1])
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &OrgRepositoriesClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}, domain: "gitlab.com"},
+			}
+			ref := gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "group"}
+
+			repos, err := c.SearchPage(context.Background(), ref, tt.perPage, tt.page, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(repos))
+			for _, repo := range repos {
+				got = append(got, repo.Repository().GetRepository())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SearchPage() = %v, want %v", got, tt.want)
+			}
+			if languageRequests != tt.wantLanguages {
+				t.Errorf("language requests = %d, want %d", languageRequests, tt.wantLanguages)
+			}
+		})
+	}
+}
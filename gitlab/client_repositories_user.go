@@ -76,7 +76,9 @@ func (c *UserRepositoriesClient) List(ctx context.Context, ref gitprovider.UserR
 
 // Create creates a repository for the given organization, with the data and options
 //
-// ErrAlreadyExists will be returned if the resource already exists.
+// ErrAlreadyExists will be returned if the resource already exists. If req has settings GitLab
+// doesn't support, the repository is created with the supported ones, and returned along with
+// an error wrapping ErrNoProviderSupport.
 func (c *UserRepositoriesClient) Create(ctx context.Context,
 	ref gitprovider.UserRepositoryRef,
 	req gitprovider.RepositoryInfo,
@@ -87,11 +89,13 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 		return nil, err
 	}
 
-	apiObj, err := createProject(ctx, c.c, ref, "", req, opts...)
-	if err != nil {
+	apiObj, initialCommitSHA, err := createProject(ctx, c.clientContext, ref, "", req, opts...)
+	if err != nil && !errors.Is(err, gitprovider.ErrNoProviderSupport) {
 		return nil, err
 	}
-	return newUserProject(c.clientContext, apiObj, ref), nil
+	repo := newUserProject(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, err
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -102,7 +106,7 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.UserRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.UserRepository, bool, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
-	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+	if err := gitprovider.ValidateAndDefaultInfoWith(ctx, c.clientContext, &req); err != nil {
 		return nil, false, err
 	}
 
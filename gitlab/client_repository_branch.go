@@ -20,7 +20,6 @@ import (
 	"context"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
-	"github.com/xanzy/go-gitlab"
 )
 
 // BranchClient implements the gitprovider.BranchClient interface.
@@ -33,16 +32,63 @@ type BranchClient struct {
 }
 
 // Create creates a branch with the given specifications.
-func (c *BranchClient) Create(_ context.Context, branch, sha string) error {
+func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
+	// POST /projects/{project}/repository/branches
+	_, err := c.c.CreateBranch(ctx, getRepoPath(c.ref), branch, sha)
+	return err
+}
 
-	ref := &gitlab.CreateBranchOptions{
-		Ref:    &sha,
-		Branch: &branch,
+// List lists all branches of the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.BranchInfo, error) {
+	// GET /projects/{project}/repository/branches
+	apiObjs, err := c.c.ListBranches(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
 	}
 
-	if _, _, err := c.c.Client().Branches.CreateBranch(getRepoPath(c.ref), ref); err != nil {
-		return err
+	branches := make([]gitprovider.BranchInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListBranches
+		branches = append(branches, gitprovider.BranchInfo{
+			Name: apiObj.Name,
+			Sha:  apiObj.Commit.ID,
+		})
 	}
+	return branches, nil
+}
+
+// Delete deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// DELETE /projects/{project}/repository/branches/{branch}
+	return c.c.DeleteBranch(ctx, getRepoPath(c.ref), branch)
+}
 
-	return nil
+// Rename renames the given branch to newName. GitLab can't rename branches, hence the branch
+// is created under newName, made the default branch if the old one was, and then deleted.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Rename(ctx context.Context, branch, newName string) error {
+	projectName := getRepoPath(c.ref)
+	// GET /projects/{project}/repository/branches/{branch}
+	apiObj, err := c.c.GetBranch(ctx, projectName, branch)
+	if err != nil {
+		return err
+	}
+	// POST /projects/{project}/repository/branches
+	if _, err := c.c.CreateBranch(ctx, projectName, newName, apiObj.Commit.ID); err != nil {
+		return err
+	}
+	// GitLab refuses to delete the default branch, hence move it first
+	if apiObj.Default {
+		// PUT /projects/{project}
+		if _, err := c.c.SetDefaultBranch(ctx, projectName, newName); err != nil {
+			return err
+		}
+	}
+	// DELETE /projects/{project}/repository/branches/{branch}
+	return c.c.DeleteBranch(ctx, projectName, branch)
 }
@@ -0,0 +1,319 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchProtectionClient implements the gitprovider.BranchProtectionClient interface.
+var _ gitprovider.BranchProtectionClient = &BranchProtectionClient{}
+
+// BranchProtectionClient operates on the protected branches of a specific repository.
+type BranchProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protection rule of the given branch, or wildcard pattern like "release/*". The
+// rule of a pattern is only returned for the pattern itself, not for the branches matching it.
+//
+// ErrNotFound is returned if the branch isn't protected.
+func (c *BranchProtectionClient) Get(ctx context.Context, branch string) (gitprovider.BranchProtection, error) {
+	bp, err := c.get(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+func (c *BranchProtectionClient) get(ctx context.Context, branch string) (*branchProtection, error) {
+	// GET /projects/{project}/protected_branches/{name}
+	apiObj, err := c.c.GetProtectedBranch(ctx, getRepoPath(c.ref), branch)
+	if err != nil {
+		return nil, err
+	}
+	// GET /projects/{project}
+	pipelineMustSucceed, err := c.pipelineMustSucceed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newBranchProtection(ctx, c, apiObj, pipelineMustSucceed)
+}
+
+// pipelineMustSucceed returns whether merge requests of the project can only be merged if the
+// pipeline succeeds.
+func (c *BranchProtectionClient) pipelineMustSucceed(ctx context.Context) (bool, error) {
+	// GET /projects/{project}
+	project, err := c.c.GetUserProject(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return false, err
+	}
+	return project.OnlyAllowMergeIfPipelineSucceeds, nil
+}
+
+// List lists the protection rules of all protected branches of the repository. The rules of
+// wildcard patterns are listed once by their pattern, rather than for each matching branch.
+//
+// List returns all available protection rules, using multiple paginated requests if needed.
+func (c *BranchProtectionClient) List(ctx context.Context) ([]gitprovider.BranchProtection, error) {
+	// GET /projects/{project}/protected_branches
+	apiObjs, err := c.c.ListProtectedBranches(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+	// GET /projects/{project}
+	pipelineMustSucceed, err := c.pipelineMustSucceed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our BranchProtection type
+	protections := make([]gitprovider.BranchProtection, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListProtectedBranches
+		bp, err := newBranchProtection(ctx, c, apiObj, pipelineMustSucceed)
+		if err != nil {
+			return nil, err
+		}
+		protections = append(protections, bp)
+	}
+	return protections, nil
+}
+
+// Create protects a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch is already protected.
+// ErrNoProviderSupport is returned if approvals, status checks other than StatusCheckPipeline
+// or enforcing the rule for administrators are required, as GitLab protected branches can't
+// enforce these. Requiring the pipeline to succeed applies to all branches of the project.
+func (c *BranchProtectionClient) Create(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateBranchProtectionSupport(req); err != nil {
+		return nil, err
+	}
+
+	// GET /projects/{project}/protected_branches/{name}
+	if _, err := c.c.GetProtectedBranch(ctx, getRepoPath(c.ref), req.Branch); err == nil {
+		return nil, fmt.Errorf("branch %q: %w", req.Branch, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	return c.create(ctx, req)
+}
+
+func (c *BranchProtectionClient) create(ctx context.Context, req gitprovider.BranchProtectionInfo) (*branchProtection, error) {
+	opts, err := c.protectOptions(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	// GET /projects/{project}
+	pipelineMustSucceed, err := c.pipelineMustSucceed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// POST /projects/{project}/protected_branches
+	apiObj, err := c.c.ProtectBranch(ctx, getRepoPath(c.ref), opts)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := newBranchProtection(ctx, c, apiObj, pipelineMustSucceed)
+	if err != nil {
+		return nil, err
+	}
+	bp.pipelineMustSucceed = requiresPipeline(req)
+	// PUT /projects/{project}
+	if err := bp.updatePipelineMustSucceed(ctx); err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing protection rule is matched by its branch, or its wildcard pattern.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *BranchProtectionClient) Reconcile(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.get(ctx, req.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := validateBranchProtectionSupport(req); err != nil {
+				return nil, false, err
+			}
+			resp, err := c.create(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// ValidateCodeOwners always returns ErrNoProviderSupport, as GitLab has no API validating the
+// CODEOWNERS file.
+func (c *BranchProtectionClient) ValidateCodeOwners(_ context.Context) ([]gitprovider.CodeOwnersError, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// pushAccessUpdates returns the changes to the push access levels of a protected branch, which
+// make the given users the ones allowed to push. Only if the branch switches between being
+// restricted to users and not, the push access of roles is switched between no one and
+// developers. The push access of groups and deploy keys is kept.
+func (c *BranchProtectionClient) pushAccessUpdates(ctx context.Context, access []*branchAccess, pushers []string) ([]*branchAccessUpdate, error) {
+	userIDs := make([]int, 0, len(pushers))
+	desired := make(map[int]bool, len(pushers))
+	for _, username := range pushers {
+		// GET /users?username={username}
+		user, err := c.c.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, user.ID)
+		desired[user.ID] = true
+	}
+
+	updates := []*branchAccessUpdate{}
+	destroy := func(a *branchAccess) {
+		updates = append(updates, &branchAccessUpdate{ID: gitlab.Int(a.ID), Destroy: gitlab.Bool(true)})
+	}
+	existing := map[int]bool{}
+	var roles []*branchAccess
+	for _, a := range access {
+		switch {
+		case a.UserID != 0:
+			existing[a.UserID] = true
+			if !desired[a.UserID] {
+				destroy(a)
+			}
+		case a.GroupID == 0 && a.DeployKeyID == 0:
+			roles = append(roles, a)
+		}
+	}
+	for _, id := range userIDs {
+		if !existing[id] {
+			existing[id] = true
+			updates = append(updates, &branchAccessUpdate{UserID: gitlab.Int(id)})
+		}
+	}
+
+	noOne, roleAllowed := false, false
+	for _, a := range roles {
+		noOne = noOne || a.AccessLevel == gitlab.NoPermissions
+		roleAllowed = roleAllowed || a.AccessLevel != gitlab.NoPermissions
+	}
+	wasRestricted, restricted := hasUserAccess(access), len(userIDs) != 0
+	switch {
+	case restricted && !wasRestricted:
+		// Only the given users may push
+		for _, a := range roles {
+			if a.AccessLevel != gitlab.NoPermissions {
+				destroy(a)
+			}
+		}
+		if !noOne {
+			updates = append(updates, &branchAccessUpdate{AccessLevel: gitlab.AccessLevel(gitlab.NoPermissions)})
+		}
+	case !restricted && wasRestricted:
+		// Everyone with write access can push
+		for _, a := range roles {
+			if a.AccessLevel == gitlab.NoPermissions {
+				destroy(a)
+			}
+		}
+		if !roleAllowed {
+			updates = append(updates, &branchAccessUpdate{AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions)})
+		}
+	}
+	return updates, nil
+}
+
+// hasUserAccess returns whether the push access of the protected branch is granted to users.
+func hasUserAccess(access []*branchAccess) bool {
+	for _, a := range access {
+		if a.UserID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// protectOptions maps the protection rule to the options protecting the branch, looking up
+// the IDs of the allowed pushers.
+func (c *BranchProtectionClient) protectOptions(ctx context.Context, info *gitprovider.BranchProtectionInfo) (*gitlab.ProtectRepositoryBranchesOptions, error) {
+	opts := &gitlab.ProtectRepositoryBranchesOptions{
+		Name: gitlab.String(info.Branch),
+		// Everyone with write access can merge into the branch
+		MergeAccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions),
+	}
+	// Only set if required, as the approval of code owners is a GitLab Premium feature
+	if info.RequireCodeOwnerReviews != nil && *info.RequireCodeOwnerReviews {
+		opts.CodeOwnerApprovalRequired = gitlab.Bool(true)
+	}
+	if len(info.AllowedPushers) == 0 {
+		opts.PushAccessLevel = gitlab.AccessLevel(gitlab.DeveloperPermissions)
+		return opts, nil
+	}
+
+	// Only the given users may push
+	opts.PushAccessLevel = gitlab.AccessLevel(gitlab.NoPermissions)
+	pushers := make([]*gitlab.BranchPermissionOptions, 0, len(info.AllowedPushers))
+	for _, username := range info.AllowedPushers {
+		// GET /users?username={username}
+		user, err := c.c.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		pushers = append(pushers, &gitlab.BranchPermissionOptions{UserID: gitlab.Int(user.ID)})
+	}
+	opts.AllowedToPush = &pushers
+	return opts, nil
+}
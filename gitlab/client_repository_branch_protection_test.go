@@ -0,0 +1,363 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchProtectionClient_Reconcile(t *testing.T) {
+	rules := map[string]string{
+		// Only alice may push
+		"main": `{"id":1,"name":"main","push_access_levels":[{"id":11,"access_level":40,"user_id":7},{"id":12,"access_level":0}],"merge_access_levels":[{"id":13,"access_level":30}]}`,
+		// Only maintainers may push and merge, and force pushes are allowed
+		"stable": `{"id":3,"name":"stable","push_access_levels":[{"id":31,"access_level":40}],"merge_access_levels":[{"id":32,"access_level":40}],"unprotect_access_levels":[{"id":33,"access_level":40}],"allow_force_push":true}`,
+	}
+	tests := []struct {
+		name            string
+		req             gitprovider.BranchProtectionInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantProjectBody map[string]interface{}
+		wantActionTaken bool
+		wantErr         error
+		check           func(t *testing.T, apiObj *gitlab.ProtectedBranch)
+	}{
+		{
+			name: "up to date",
+			req:  gitprovider.BranchProtectionInfo{Branch: "main", AllowedPushers: []string{"alice"}},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/projects/org/repo",
+				"GET /api/v4/users/7",
+			},
+		},
+		{
+			name: "change pushers",
+			req:  gitprovider.BranchProtectionInfo{Branch: "main", AllowedPushers: []string{"alice", "bob"}},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/projects/org/repo",
+				"GET /api/v4/users/7",
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/users",
+				"GET /api/v4/users",
+				"PATCH /api/v4/projects/org/repo/protected_branches/main",
+			},
+			wantBody: map[string]interface{}{
+				"allowed_to_push": []interface{}{map[string]interface{}{"user_id": float64(8)}},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "remove the pushers",
+			req:  gitprovider.BranchProtectionInfo{Branch: "main"},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/projects/org/repo",
+				"GET /api/v4/users/7",
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"PATCH /api/v4/projects/org/repo/protected_branches/main",
+			},
+			wantBody: map[string]interface{}{
+				"allowed_to_push": []interface{}{
+					map[string]interface{}{"id": float64(11), "_destroy": true},
+					map[string]interface{}{"id": float64(12), "_destroy": true},
+					map[string]interface{}{"access_level": float64(30)},
+				},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "keep the existing access levels",
+			req:  gitprovider.BranchProtectionInfo{Branch: "stable", RequireCodeOwnerReviews: gitprovider.BoolVar(true)},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/stable",
+				"GET /api/v4/projects/org/repo",
+				"GET /api/v4/projects/org/repo/protected_branches/stable",
+				"PATCH /api/v4/projects/org/repo/protected_branches/stable",
+			},
+			wantBody:        map[string]interface{}{"code_owner_approval_required": true},
+			wantActionTaken: true,
+			check: func(t *testing.T, apiObj *gitlab.ProtectedBranch) {
+				if !apiObj.AllowForcePush || apiObj.PushAccessLevels[0].AccessLevel != gitlab.MaintainerPermissions ||
+					apiObj.MergeAccessLevels[0].AccessLevel != gitlab.MaintainerPermissions {
+					t.Errorf("Reconcile() changed the existing access levels: %+v", apiObj)
+				}
+			},
+		},
+		{
+			name: "protect an unprotected branch",
+			req:  gitprovider.BranchProtectionInfo{Branch: "dev"},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/dev",
+				"GET /api/v4/projects/org/repo",
+				"POST /api/v4/projects/org/repo/protected_branches",
+			},
+			wantBody: map[string]interface{}{
+				"name":               "dev",
+				"push_access_level":  float64(30),
+				"merge_access_level": float64(30),
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "require code owner approval",
+			req:  gitprovider.BranchProtectionInfo{Branch: "dev", RequireCodeOwnerReviews: gitprovider.BoolVar(true)},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/dev",
+				"GET /api/v4/projects/org/repo",
+				"POST /api/v4/projects/org/repo/protected_branches",
+			},
+			wantBody: map[string]interface{}{
+				"name":                         "dev",
+				"push_access_level":            float64(30),
+				"merge_access_level":           float64(30),
+				"code_owner_approval_required": true,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "require the pipeline",
+			req: gitprovider.BranchProtectionInfo{
+				Branch:               "main",
+				RequiredStatusChecks: gitprovider.StatusChecks(gitprovider.StatusCheckPipeline),
+				AllowedPushers:       []string{"alice"},
+			},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/projects/org/repo",
+				"GET /api/v4/users/7",
+				"GET /api/v4/projects/org/repo/protected_branches/main",
+				"GET /api/v4/users",
+				"PUT /api/v4/projects/org/repo",
+			},
+			wantProjectBody: map[string]interface{}{"only_allow_merge_if_pipeline_succeeds": true},
+			wantActionTaken: true,
+		},
+		{
+			name:         "required status check",
+			req:          gitprovider.BranchProtectionInfo{Branch: "dev", RequiredStatusChecks: gitprovider.StatusChecks("ci")},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/protected_branches/dev"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:         "required approvals",
+			req:          gitprovider.BranchProtectionInfo{Branch: "dev", RequiredApprovals: gitprovider.IntVar(1)},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/protected_branches/dev"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body, projectBody map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				rule, protected := rules[path.Base(r.URL.Path)]
+				switch {
+				case r.URL.Path == "/api/v4/projects/org/repo" && r.Method == http.MethodGet:
+					_, _ = fmt.Fprint(w, `{"id":1,"name":"repo","only_allow_merge_if_pipeline_succeeds":false}`)
+				case r.URL.Path == "/api/v4/projects/org/repo":
+					if err := json.NewDecoder(r.Body).Decode(&projectBody); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 1, "name": "repo", "only_allow_merge_if_pipeline_succeeds": projectBody["only_allow_merge_if_pipeline_succeeds"]}
+					_ = json.NewEncoder(w).Encode(resp)
+				case r.Method == http.MethodGet && protected:
+					_, _ = fmt.Fprint(w, rule)
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"404 Not found"}`)
+				case r.Method == http.MethodPatch:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					// Respond with the rule, applying the changed settings
+					var resp map[string]interface{}
+					_ = json.Unmarshal([]byte(rule), &resp)
+					if v, ok := body["code_owner_approval_required"]; ok {
+						resp["code_owner_approval_required"] = v
+					}
+					_ = json.NewEncoder(w).Encode(resp)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 2, "name": body["name"], "code_owner_approval_required": body["code_owner_approval_required"]}
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			})
+			mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				ids := map[string]int{"alice": 7, "bob": 8}
+				username := r.URL.Query().Get("username")
+				_, _ = fmt.Fprintf(w, `[{"id":%d,"username":%q}]`, ids[username], username)
+			})
+			mux.HandleFunc("/api/v4/users/7", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				_, _ = fmt.Fprint(w, `{"id":7,"username":"alice"}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &BranchProtectionClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Reconcile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+			if !reflect.DeepEqual(projectBody, tt.wantProjectBody) {
+				t.Errorf("Reconcile() project body = %v, want %v", projectBody, tt.wantProjectBody)
+			}
+			if tt.check != nil {
+				tt.check(t, resp.APIObject().(*gitlab.ProtectedBranch))
+			}
+		})
+	}
+}
+
+func TestBranchProtectionClient_wildcardRules(t *testing.T) {
+	const releaseRule = `{"id":5,"name":"release/*","push_access_levels":[{"id":51,"access_level":40}],"merge_access_levels":[{"id":52,"access_level":40}]}`
+	var requests []string
+	var body map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		switch {
+		case r.URL.Path == "/api/v4/projects/org/repo":
+			_, _ = fmt.Fprint(w, `{"id":1,"name":"repo"}`)
+		case r.URL.Path == "/api/v4/projects/org/repo/protected_branches" && r.Method == http.MethodGet:
+			_, _ = fmt.Fprintf(w, `[%s,{"id":1,"name":"main"}]`, releaseRule)
+		case r.URL.Path == "/api/v4/projects/org/repo/protected_branches/release/*":
+			_, _ = fmt.Fprint(w, releaseRule)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"404 Not found"}`)
+		default:
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":6,"name":%q}`, body["name"])
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &BranchProtectionClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+
+	// The rule of the pattern is listed once, by its pattern
+	protections, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var branches []string
+	for _, bp := range protections {
+		branches = append(branches, bp.Get().Branch)
+	}
+	if want := []string{"release/*", "main"}; !reflect.DeepEqual(branches, want) {
+		t.Errorf("List() branches = %v, want %v", branches, want)
+	}
+
+	// The existing rule is matched by its pattern
+	requests = nil
+	_, actionTaken, err := c.Reconcile(ctx, gitprovider.BranchProtectionInfo{Branch: "release/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actionTaken {
+		t.Error("Reconcile() of the existing pattern took action")
+	}
+	if want := []string{
+		"GET /api/v4/projects/org/repo/protected_branches/release/*",
+		"GET /api/v4/projects/org/repo",
+	}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("Reconcile() requests = %v, want %v", requests, want)
+	}
+
+	// A new pattern is protected by its pattern
+	requests = nil
+	resp, actionTaken, err := c.Reconcile(ctx, gitprovider.BranchProtectionInfo{Branch: "hotfix-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() of a new pattern took no action")
+	}
+	if got := resp.Get().Branch; got != "hotfix-*" {
+		t.Errorf("Reconcile() branch = %q, want %q", got, "hotfix-*")
+	}
+	if got := body["name"]; got != "hotfix-*" {
+		t.Errorf("Reconcile() protected %v, want %q", got, "hotfix-*")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchClient_Rename(t *testing.T) {
+	tests := []struct {
+		name         string
+		branch       string
+		wantRequests []string
+	}{
+		{
+			name:   "default branch",
+			branch: "main",
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/repository/branches/main",
+				"POST /api/v4/projects/org/repo/repository/branches",
+				"PUT /api/v4/projects/org/repo",
+				"DELETE /api/v4/projects/org/repo/repository/branches/main",
+			},
+		},
+		{
+			name:   "other branch",
+			branch: "dev",
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/repository/branches/dev",
+				"POST /api/v4/projects/org/repo/repository/branches",
+				"DELETE /api/v4/projects/org/repo/repository/branches/dev",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			bodies := map[string]map[string]interface{}{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `{"name":%q,"default":%t,"commit":{"id":"abc"}}`, tt.branch, tt.branch == "main")
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					body := map[string]interface{}{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					bodies[r.Method] = body
+					_, _ = fmt.Fprint(w, `{"id":1,"name":"repo","commit":{"id":"abc"}}`)
+				}
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &BranchClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			if err := c.Rename(context.Background(), tt.branch, "trunk"); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Rename() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			wantBody := map[string]interface{}{"branch": "trunk", "ref": "abc"}
+			if !reflect.DeepEqual(bodies[http.MethodPost], wantBody) {
+				t.Errorf("Rename() body = %v, want %v", bodies[http.MethodPost], wantBody)
+			}
+			if put, ok := bodies[http.MethodPut]; ok && put["default_branch"] != "trunk" {
+				t.Errorf("Rename() default branch = %v, want trunk", put["default_branch"])
+			}
+		})
+	}
+}
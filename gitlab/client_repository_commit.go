@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -34,8 +35,12 @@ type CommitClient struct {
 }
 
 // ListPage lists repository commits of the given page and page size.
-func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
-	dks, err := c.listPage(branch, perPage, page)
+//
+// GitLab doesn't return the signatures when listing commits, hence the Verification of the
+// listed commits is nil, unless the CommitVerification CallOption is set. The signature of each
+// commit is then fetched with a request per commit.
+func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	dks, err := c.listPage(ctx, branch, perPage, page)
 	if err != nil {
 		return nil, err
 	}
@@ -47,24 +52,45 @@ func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page
 	return commits, nil
 }
 
-func (c *CommitClient) listPage(branch string, perPage, page int) ([]*commitType, error) {
+func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
 	// GET /repos/{owner}/{repo}/commits
-	apiObjs, err := c.c.ListCommitsPage(getRepoPath(c.ref), branch, perPage, page)
+	apiObjs, err := c.c.ListCommitsPage(ctx, getRepoPath(c.ref), branch, perPage, page)
 	if err != nil {
 		return nil, err
 	}
 
 	// Map the api object to our CommitType type
 	keys := make([]*commitType, 0, len(apiObjs))
+	verify := gitprovider.CallOptionsFromContext(ctx).ShouldFetchCommitVerification()
 	for _, apiObj := range apiObjs {
-		keys = append(keys, newCommit(c, apiObj))
+		commit := newCommit(c, apiObj)
+		if verify {
+			// GET /projects/{project}/repository/commits/{sha}/signature
+			sig, err := c.c.GetCommitGPGSignature(ctx, getRepoPath(c.ref), apiObj.ID)
+			if err != nil && !errors.Is(err, gitprovider.ErrNotFound) {
+				return nil, err
+			}
+			commit.verification = commitVerificationFromAPI(sig)
+		}
+		keys = append(keys, commit)
 	}
 
 	return keys, nil
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+//
+// GitLab can't be asked to sign the commit, hence ErrNoProviderSupport is returned if the
+// ProviderSigned option is set. If the Author option is set, it is recorded as the author of
+// the commit, while the authenticated user remains the committer.
+func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile, createOpts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	o, err := gitprovider.MakeCommitCreateOptions(createOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.ProviderSigned != nil && *o.ProviderSigned {
+		return nil, fmt.Errorf("gitlab doesn't support provider-signed commits: %w", gitprovider.ErrNoProviderSupport)
+	}
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
@@ -89,6 +115,10 @@ func (c *CommitClient) Create(_ context.Context, branch string, message string,
 		CommitMessage: &message,
 		Actions:       commitActions,
 	}
+	if o.Author != nil {
+		opts.AuthorName = &o.Author.Name
+		opts.AuthorEmail = &o.Author.Email
+	}
 
 	commit, _, err := c.c.Client().Commits.CreateCommit(getRepoPath(c.ref), opts)
 	if err != nil {
@@ -97,3 +127,27 @@ func (c *CommitClient) Create(_ context.Context, branch string, message string,
 
 	return newCommit(c, commit), nil
 }
+
+// ListComments lists all comments made on the commit with the given sha, including inline comments.
+// All notes of all discussions on the commit are returned, except for system notes.
+//
+// ListComments returns all available comments, using multiple paginated requests if needed.
+func (c *CommitClient) ListComments(ctx context.Context, sha string) ([]gitprovider.CommitComment, error) {
+	// GET /projects/{project}/repository/commits/{sha}/discussions
+	discussions, err := c.c.ListCommitDiscussions(ctx, getRepoPath(c.ref), sha)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]gitprovider.CommitComment, 0, len(discussions))
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			// System notes are generated by GitLab, not written by users
+			if note.System {
+				continue
+			}
+			comments = append(comments, newCommitComment(note))
+		}
+	}
+	return comments, nil
+}
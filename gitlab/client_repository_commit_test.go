@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestCommitClient_ListPage_verification(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           gitprovider.CallOptions
+		wantSignatures int
+		want           *gitprovider.CommitVerification
+	}{
+		{
+			name: "without verification",
+		},
+		{
+			name:           "with verification",
+			opts:           gitprovider.CallOptions{CommitVerification: gitprovider.BoolVar(true)},
+			wantSignatures: 2,
+			want:           &gitprovider.CommitVerification{Verified: true, Reason: "verified", Signer: "jane@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signatures := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v4/projects/org/repo/repository/commits":
+					_, _ = fmt.Fprint(w, `[{"id":"abc","created_at":"2020-01-01T00:00:00Z"},{"id":"def","created_at":"2020-01-01T00:00:00Z"}]`)
+				case "/api/v4/projects/org/repo/repository/commits/abc/signature", "/api/v4/projects/org/repo/repository/commits/def/signature":
+					signatures++
+					_, _ = fmt.Fprint(w, `{"verification_status":"verified","gpg_key_user_email":"jane@example.com"}`)
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &CommitClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			ctx := gitprovider.WithOptions(context.Background(), tt.opts)
+			commits, err := c.ListPage(ctx, "main", 10, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signatures != tt.wantSignatures {
+				t.Errorf("signature requests = %d, want %d", signatures, tt.wantSignatures)
+			}
+			for _, commit := range commits {
+				got := commit.Get().Verification
+				if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+					t.Errorf("Verification = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
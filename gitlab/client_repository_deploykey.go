@@ -37,12 +37,12 @@ type DeployKeyClient struct {
 // Get returns the repository at the given path.
 //
 // ErrNotFound is returned if the resource does not exist.
-func (c *DeployKeyClient) Get(_ context.Context, deployKeyName string) (gitprovider.DeployKey, error) {
-	return c.get(deployKeyName)
+func (c *DeployKeyClient) Get(ctx context.Context, deployKeyName string) (gitprovider.DeployKey, error) {
+	return c.get(ctx, deployKeyName)
 }
 
-func (c *DeployKeyClient) get(deployKeyName string) (*deployKey, error) {
-	deployKeys, err := c.list()
+func (c *DeployKeyClient) get(ctx context.Context, deployKeyName string) (*deployKey, error) {
+	deployKeys, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +59,8 @@ func (c *DeployKeyClient) get(deployKeyName string) (*deployKey, error) {
 //
 // List returns all available repository deploy keys for the given type,
 // using multiple paginated requests if needed.
-func (c *DeployKeyClient) List(_ context.Context) ([]gitprovider.DeployKey, error) {
-	dks, err := c.list()
+func (c *DeployKeyClient) List(ctx context.Context, opts ...gitprovider.DeployKeyListOption) ([]gitprovider.DeployKey, error) {
+	dks, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +69,13 @@ func (c *DeployKeyClient) List(_ context.Context) ([]gitprovider.DeployKey, erro
 	for _, dk := range dks {
 		keys = append(keys, dk)
 	}
+	gitprovider.SortDeployKeys(keys, gitprovider.MakeDeployKeyListOptions(opts...))
 	return keys, nil
 }
 
-func (c *DeployKeyClient) list() ([]*deployKey, error) {
+func (c *DeployKeyClient) list(ctx context.Context) ([]*deployKey, error) {
 	// GET /repos/{owner}/{repo}/keys
-	apiObjs, err := c.c.ListKeys(getRepoPath(c.ref))
+	apiObjs, err := c.c.ListKeys(ctx, getRepoPath(c.ref))
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +84,9 @@ func (c *DeployKeyClient) list() ([]*deployKey, error) {
 	keys := make([]*deployKey, 0, len(apiObjs))
 	for _, apiObj := range apiObjs {
 		// apiObj is already validated at ListKeys
-		keys = append(keys, newDeployKey(c, apiObj))
+		dk := newDeployKey(c, &apiObj.ProjectDeployKey)
+		dk.lastUsedAt = apiObj.LastUsedAt
+		keys = append(keys, dk)
 	}
 
 	return keys, nil
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestDeployKeyClient_List_sortByLastUsed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[`+
+			`{"id":1,"title":"recent","key":"ssh-ed25519 AAAA1","last_used_at":"2023-03-01T10:00:00Z"},`+
+			`{"id":2,"title":"old","key":"ssh-ed25519 AAAA2","last_used_at":"2022-01-01T10:00:00Z"},`+
+			`{"id":3,"title":"unused","key":"ssh-ed25519 AAAA3","last_used_at":null}]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &DeployKeyClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []gitprovider.DeployKeyListOption
+		want []string
+	}{
+		{
+			name: "provider order",
+			want: []string{"recent", "old", "unused"},
+		},
+		{
+			name: "sorted by last used",
+			opts: []gitprovider.DeployKeyListOption{&gitprovider.DeployKeyListOptions{SortByLastUsed: gitprovider.BoolVar(true)}},
+			want: []string{"unused", "old", "recent"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := c.List(context.Background(), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("List() returned %d keys, want %d", len(keys), len(tt.want))
+			}
+			for i, key := range keys {
+				if got := key.Get().Name; got != tt.want[i] {
+					t.Errorf("key %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	keys, err := c.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	if got := keys[0].LastUsedAt(); got == nil || !got.Equal(want) {
+		t.Errorf("LastUsedAt() = %v, want %v", got, want)
+	}
+	if got := keys[2].LastUsedAt(); got != nil {
+		t.Errorf("LastUsedAt() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// DeployTokenClient implements the gitprovider.DeployTokenClient interface.
+var _ gitprovider.DeployTokenClient = &DeployTokenClient{}
+
+// DeployTokenClient operates on the deploy tokens of a specific repository.
+type DeployTokenClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the deploy token with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *DeployTokenClient) Get(ctx context.Context, name string) (gitprovider.DeployToken, error) {
+	tokens, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through deploy tokens once we find one with the right name
+	for _, dt := range tokens {
+		if dt.t.Name == name {
+			return dt, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all deploy tokens of the repository. The token values are not part of the result.
+//
+// List returns all available deploy tokens, using multiple paginated requests if needed.
+func (c *DeployTokenClient) List(ctx context.Context) ([]gitprovider.DeployToken, error) {
+	dts, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.DeployToken
+	tokens := make([]gitprovider.DeployToken, 0, len(dts))
+	for _, dt := range dts {
+		tokens = append(tokens, dt)
+	}
+	return tokens, nil
+}
+
+func (c *DeployTokenClient) list(ctx context.Context) ([]*deployToken, error) {
+	// GET /projects/{project}/deploy_tokens
+	apiObjs, err := c.c.ListDeployTokens(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our DeployToken type
+	tokens := make([]*deployToken, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListDeployTokens
+		tokens = append(tokens, newDeployToken(c, apiObj))
+	}
+	return tokens, nil
+}
+
+// Create creates a deploy token with the given specifications. The returned DeployToken is the
+// only one holding the token value, as GitLab doesn't return it afterwards.
+func (c *DeployTokenClient) Create(ctx context.Context, req gitprovider.DeployTokenInfo) (gitprovider.DeployToken, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// POST /projects/{project}/deploy_tokens
+	apiObj, err := c.c.CreateDeployToken(ctx, getRepoPath(c.ref), deployTokenToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newDeployToken(c, apiObj), nil
+}
+
+func deployTokenToAPI(info *gitprovider.DeployTokenInfo) *gitlab.CreateProjectDeployTokenOptions {
+	scopes := make([]string, 0, len(info.Scopes))
+	for _, scope := range info.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return &gitlab.CreateProjectDeployTokenOptions{
+		Name:      gitlab.String(info.Name),
+		Username:  info.Username,
+		Scopes:    &scopes,
+		ExpiresAt: info.ExpiresAt,
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// MilestoneClient implements the gitprovider.MilestoneClient interface.
+var _ gitprovider.MilestoneClient = &MilestoneClient{}
+
+// MilestoneClient operates on the milestones of a specific repository.
+type MilestoneClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists the milestones of the repository, along with their progress.
+//
+// GitLab doesn't return issue counts for milestones, hence the issues statistics of every
+// milestone are requested too, which costs one extra request per milestone.
+//
+// List returns all available milestones, using multiple paginated requests if needed.
+func (c *MilestoneClient) List(ctx context.Context, opts ...gitprovider.MilestoneListOption) ([]gitprovider.Milestone, error) {
+	o, err := gitprovider.MakeMilestoneListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	var state *string
+	if o.State != nil {
+		state = milestoneStateToAPI(*o.State)
+	}
+
+	// GET /projects/{project}/milestones
+	apiObjs, err := c.c.ListMilestones(ctx, getRepoPath(c.ref), state)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Milestone type
+	milestones := make([]gitprovider.Milestone, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// GET /projects/{project}/issues_statistics
+		stats, err := c.c.GetMilestoneIssuesStatistics(ctx, getRepoPath(c.ref), apiObj.Title)
+		if err != nil {
+			return nil, err
+		}
+		// apiObj is already validated at ListMilestones
+		milestones = append(milestones, newMilestone(c, apiObj, stats))
+	}
+	return milestones, nil
+}
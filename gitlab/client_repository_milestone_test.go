@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestMilestoneClient_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []gitprovider.MilestoneListOption
+		wantState string
+		want      []gitprovider.MilestoneInfo
+	}{
+		{
+			name: "all milestones",
+			want: []gitprovider.MilestoneInfo{
+				{Number: 1, Title: "v1.0", State: gitprovider.MilestoneStateOpen, OpenIssues: 1, ClosedIssues: 3, Progress: 75},
+			},
+		},
+		{
+			name:      "open milestones",
+			opts:      []gitprovider.MilestoneListOption{&gitprovider.MilestoneListOptions{State: gitprovider.MilestoneStateVar(gitprovider.MilestoneStateOpen)}},
+			wantState: "active",
+			want: []gitprovider.MilestoneInfo{
+				{Number: 1, Title: "v1.0", State: gitprovider.MilestoneStateOpen, OpenIssues: 1, ClosedIssues: 3, Progress: 75},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/org/repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("state"); got != tt.wantState {
+					t.Errorf("state = %q, want %q", got, tt.wantState)
+				}
+				_, _ = fmt.Fprint(w, `[{"id":10,"iid":1,"title":"v1.0","state":"active"}]`)
+			})
+			mux.HandleFunc("/api/v4/projects/org/repo/issues_statistics", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("milestone"); got != "v1.0" {
+					t.Errorf("milestone = %q, want %q", got, "v1.0")
+				}
+				_, _ = fmt.Fprint(w, `{"statistics":{"counts":{"all":4,"closed":3,"opened":1}}}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &MilestoneClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			milestones, err := c.List(context.Background(), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]gitprovider.MilestoneInfo, 0, len(milestones))
+			for _, m := range milestones {
+				got = append(got, m.Get())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -37,9 +38,35 @@ type PullRequestClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// List lists all pull requests in the repository
-func (c *PullRequestClient) List(_ context.Context) ([]gitprovider.PullRequest, error) {
-	mrs, _, err := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), nil)
+// List lists the merge requests in the repository, by default the open ones. All filters
+// are applied by GitLab.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	state := openedState
+	if o.State != nil {
+		state = pullRequestStateToAPI(*o.State)
+	}
+	listOpts := &gitlab.ListProjectMergeRequestsOptions{
+		State:          &state,
+		TargetBranch:   o.BaseBranch,
+		SourceBranch:   o.SourceBranch,
+		AuthorUsername: o.Author,
+		UpdatedAfter:   o.UpdatedSince,
+	}
+	if o.Label != nil {
+		listOpts.Labels = &gitlab.Labels{*o.Label}
+	}
+
+	mrs := []*gitlab.MergeRequest{}
+	err = allMergeRequestPages(ctx, listOpts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests
+		pageObjs, resp, listErr := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), listOpts, gitlab.WithContext(ctx))
+		mrs = append(mrs, pageObjs...)
+		return resp, listErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +80,14 @@ func (c *PullRequestClient) List(_ context.Context) ([]gitprovider.PullRequest,
 	return requests, nil
 }
 
-// Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Create creates a pull request with the given specifications. Draft MRs are created by
+// prefixing the title with "Draft:". If a head repository is set, the MR is created in that
+// project, i.e. the fork, targeting the project of the client.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
+	if o.Draft != nil {
+		title = setDraftPrefix(title, *o.Draft)
+	}
 
 	prOpts := &gitlab.CreateMergeRequestOptions{
 		Title:        &title,
@@ -63,7 +96,19 @@ func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch,
 		Description:  &description,
 	}
 
-	mr, _, err := c.c.Client().MergeRequests.CreateMergeRequest(getRepoPath(c.ref), prOpts)
+	sourceRef := c.ref
+	if o.HeadRepository != nil {
+		// GET /projects/{project}
+		project, _, err := c.c.Client().Projects.GetProject(getRepoPath(c.ref), &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, handleHTTPError(err)
+		}
+		sourceRef = o.HeadRepository
+		prOpts.TargetProjectID = &project.ID
+	}
+
+	// POST /projects/{project}/merge_requests
+	mr, _, err := c.c.Client().MergeRequests.CreateMergeRequest(getRepoPath(sourceRef), prOpts, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -73,14 +118,88 @@ func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch,
 
 // Edit modifies an existing MR. Please refer to "EditOptions" for details on which data can be edited.
 func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovider.EditOptions) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Title: opts.Title})
+}
+
+// Update changes an existing MR. Please refer to "PullRequestUpdateOptions" for details on which
+// data can be changed.
+//
+// GitLab marks merge requests as draft through a "Draft:" prefix of the title. Hence, if Draft is
+// set without a new Title, the current title is retrieved first to add or remove the prefix.
+func (c *PullRequestClient) Update(ctx context.Context, number int, opts gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	title := opts.Title
+	if opts.Draft != nil {
+		if title == nil {
+			// GET /projects/{project}/merge_requests/{merge_request_iid}
+			mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(getRepoPath(c.ref), number, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+			if err != nil {
+				return nil, handleHTTPError(err)
+			}
+			title = &mr.Title
+		}
+		draftTitle := setDraftPrefix(*title, *opts.Draft)
+		title = &draftTitle
+	}
 	mrUpdate := &gitlab.UpdateMergeRequestOptions{
-		Title: opts.Title,
+		Title:        title,
+		Description:  opts.Description,
+		TargetBranch: opts.BaseBranch,
 	}
-	editedMR, _, err := c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, mrUpdate)
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	mr, _, err := c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, mrUpdate, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
+	}
+	return newPullRequest(c.clientContext, mr), nil
+}
+
+// Close closes an open MR without merging it.
+func (c *PullRequestClient) Close(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, "close", closedState)
+}
+
+// Reopen reopens a closed MR.
+func (c *PullRequestClient) Reopen(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, "reopen", openedState)
+}
+
+// MarkReady marks a draft MR as ready, by removing the "Draft:" prefix of its title.
+func (c *PullRequestClient) MarkReady(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Draft: gitprovider.BoolVar(false)})
+}
+
+// setState applies the given state event to the MR, unless the MR is in the resulting state
+// already. Merged MRs can't change state.
+func (c *PullRequestClient) setState(ctx context.Context, number int, event, state string) (gitprovider.PullRequest, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}
+	mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(getRepoPath(c.ref), number, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if mr.State == mergedState {
+		return nil, fmt.Errorf("merge request %d has been merged already: %w", number, gitprovider.ErrInvalidArgument)
 	}
-	return newPullRequest(c.clientContext, editedMR), nil
+	if mr.State == state {
+		return newPullRequest(c.clientContext, mr), nil
+	}
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	mr, _, err = c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, &gitlab.UpdateMergeRequestOptions{StateEvent: &event}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return newPullRequest(c.clientContext, mr), nil
+}
+
+// draftPrefix matches the title prefixes GitLab recognizes for marking a merge request as draft.
+var draftPrefix = regexp.MustCompile(`(?i)^\s*(\[draft\]|\(draft\)|draft:|draft\s+-|\[wip\]|wip:)\s*`)
+
+// setDraftPrefix returns title with the draft prefix added or removed.
+func setDraftPrefix(title string, draft bool) string {
+	title = draftPrefix.ReplaceAllString(title, "")
+	if draft {
+		return "Draft: " + title
+	}
+	return title
 }
 
 // Get retrieves an existing pull request by number
@@ -94,8 +213,15 @@ func (c *PullRequestClient) Get(_ context.Context, number int) (gitprovider.Pull
 	return newPullRequest(c.clientContext, mr), nil
 }
 
-// Merge merges a pull request with the given specifications.
-func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod gitprovider.MergeMethod, message string) error {
+// Merge merges a pull request with the given specifications. GitLab configures the merge
+// method per project, hence mergeMethod has to match it: MergeMethodMerge for projects with
+// merge commits, MergeMethodFastForward for projects with fast-forward merges, and
+// MergeMethodSquash unless squashing is disabled. GitLab can't rebase on merge.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err := c.validateMergeMethod(ctx, getRepoPath(c.ref), mergeMethod); err != nil {
+		return err
+	}
 	if err := c.waitForMergeRequestToBeMergeable(number); err != nil {
 		return err
 	}
@@ -105,14 +231,12 @@ func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod git
 	var mergeCommitMessage *string
 	var squashCommitMessage *string
 
-	switch mergeMethod {
-	case gitprovider.MergeMethodSquash:
+	message = o.CommitMessage(message)
+	if mergeMethod == gitprovider.MergeMethodSquash {
 		squashCommitMessage = &message
 		squash = true
-	case gitprovider.MergeMethodMerge:
+	} else {
 		mergeCommitMessage = &message
-	default:
-		return fmt.Errorf("unknown merge method: %s", mergeMethod)
 	}
 
 	amrOpts := &gitlab.AcceptMergeRequestOptions{
@@ -132,6 +256,37 @@ func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod git
 	return nil
 }
 
+// validateMergeMethod checks whether mergeMethod matches the merge method and squash option of the project.
+func (c *clientContext) validateMergeMethod(ctx context.Context, pid interface{}, mergeMethod gitprovider.MergeMethod) error {
+	switch mergeMethod {
+	case gitprovider.MergeMethodMerge, gitprovider.MergeMethodSquash, gitprovider.MergeMethodFastForward:
+	case gitprovider.MergeMethodRebase:
+		return fmt.Errorf("gitlab can't rebase merge requests on merge: %w", gitprovider.ErrNoProviderSupport)
+	default:
+		return fmt.Errorf("unknown merge method: %s: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
+
+	// GET /projects/{project}
+	project, _, err := c.c.Client().Projects.GetProject(pid, &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	var allowed bool
+	switch mergeMethod {
+	case gitprovider.MergeMethodMerge:
+		allowed = project.MergeMethod != gitlab.FastForwardMerge && project.SquashOption != gitlab.SquashOptionAlways
+	case gitprovider.MergeMethodFastForward:
+		allowed = project.MergeMethod == gitlab.FastForwardMerge && project.SquashOption != gitlab.SquashOptionAlways
+	case gitprovider.MergeMethodSquash:
+		allowed = project.SquashOption != gitlab.SquashOptionNever
+	}
+	if !allowed {
+		return fmt.Errorf("merge method %q isn't allowed for project %s (merge method %q, squash option %q): %w",
+			mergeMethod, project.PathWithNamespace, project.MergeMethod, project.SquashOption, gitprovider.ErrInvalidArgument)
+	}
+	return nil
+}
+
 func (c *PullRequestClient) waitForMergeRequestToBeMergeable(number int) error {
 	// gitlab says to poll for merge status
 	for retries := 0; retries < 10; retries++ {
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the discussions of a specific merge request.
+type PullRequestCommentClient struct {
+	*clientContext
+	projectID int
+	iid       int
+}
+
+// List lists the notes of all discussions of the merge request, oldest first. System notes,
+// e.g. about pushed commits, are left out.
+//
+// List returns all available comments, using multiple paginated requests if needed.
+func (c *PullRequestCommentClient) List(ctx context.Context) ([]gitprovider.PullRequestComment, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/discussions
+	discussions, err := c.c.ListMergeRequestDiscussions(ctx, c.projectID, c.iid)
+	if err != nil {
+		return nil, err
+	}
+	var comments []gitprovider.PullRequestComment
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if note.System {
+				continue
+			}
+			comments = append(comments, newPullRequestComment(discussion, note))
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Get().CreatedAt.Before(comments[j].Get().CreatedAt)
+	})
+	return comments, nil
+}
+
+// Create starts a new discussion, positioned on the latest diff of the merge request for inline
+// comments, or adds a note to the discussion of the comment replied to.
+func (c *PullRequestCommentClient) Create(ctx context.Context, req gitprovider.PullRequestCommentInfo) (gitprovider.PullRequestComment, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	if req.InReplyTo != 0 {
+		discussion, err := c.findDiscussion(ctx, req.InReplyTo)
+		if err != nil {
+			return nil, err
+		}
+		// POST /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}/notes
+		apiObj, err := c.c.AddMergeRequestDiscussionNote(ctx, c.projectID, c.iid, discussion.ID, req.Body)
+		if err != nil {
+			return nil, err
+		}
+		return newPullRequestComment(discussion, apiObj), nil
+	}
+
+	opts := &gitlab.CreateMergeRequestDiscussionOptions{Body: &req.Body}
+	if req.Path != "" {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}
+		mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(c.projectID, c.iid, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, handleHTTPError(err)
+		}
+		opts.Position = &gitlab.NotePosition{
+			BaseSHA:      mr.DiffRefs.BaseSha,
+			StartSHA:     mr.DiffRefs.StartSha,
+			HeadSHA:      mr.DiffRefs.HeadSha,
+			PositionType: "text",
+			NewPath:      req.Path,
+			NewLine:      req.Line,
+		}
+	}
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/discussions
+	discussion, err := c.c.CreateMergeRequestDiscussion(ctx, c.projectID, c.iid, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(discussion.Notes) == 0 {
+		return nil, fmt.Errorf("created discussion has no notes: %w", gitprovider.ErrInvalidServerData)
+	}
+	return newPullRequestComment(discussion, discussion.Notes[0]), nil
+}
+
+// Resolve resolves the discussion of the comment with the given ID. General comments aren't
+// resolvable in GitLab.
+func (c *PullRequestCommentClient) Resolve(ctx context.Context, id int64) error {
+	discussion, err := c.findDiscussion(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !discussion.Notes[0].Resolvable {
+		return fmt.Errorf("comment %d is not resolvable: %w", id, gitprovider.ErrInvalidArgument)
+	}
+	if discussion.Notes[0].Resolved {
+		return nil
+	}
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}
+	return c.c.ResolveMergeRequestDiscussion(ctx, c.projectID, c.iid, discussion.ID)
+}
+
+// findDiscussion returns the discussion containing the note with the given ID.
+func (c *PullRequestCommentClient) findDiscussion(ctx context.Context, id int64) (*gitlab.Discussion, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/discussions
+	discussions, err := c.c.ListMergeRequestDiscussions(ctx, c.projectID, c.iid)
+	if err != nil {
+		return nil, err
+	}
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if int64(note.ID) == id && !note.System {
+				return discussion, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("comment %d: %w", id, gitprovider.ErrNotFound)
+}
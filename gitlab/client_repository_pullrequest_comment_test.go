@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestPullRequestCommentClient(t *testing.T) {
+	var created *gitlab.CreateMergeRequestDiscussionOptions
+	var replies []string
+	var resolved []bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"diff_refs":{"base_sha":"base","head_sha":"head","start_sha":"start"}}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/discussions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, `[
+				{"id":"d1","individual_note":true,"notes":[{"id":1,"author":{"username":"alice"},"body":"general","created_at":"2024-01-01T00:00:00Z"}]},
+				{"id":"d2","individual_note":true,"notes":[{"id":2,"body":"added 1 commit","system":true,"created_at":"2024-01-02T00:00:00Z"}]},
+				{"id":"d3","individual_note":false,"notes":[
+					{"id":3,"author":{"username":"bob"},"body":"nit","resolvable":true,"position":{"new_path":"main.go","new_line":3},"created_at":"2024-01-03T00:00:00Z"},
+					{"id":4,"author":{"username":"alice"},"body":"done","resolvable":true,"position":{"new_path":"main.go","new_line":3},"created_at":"2024-01-04T00:00:00Z"}
+				]}
+			]`)
+		case http.MethodPost:
+			created = &gitlab.CreateMergeRequestDiscussionOptions{}
+			if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":"d4","notes":[{"id":5,"body":%q}]}`, *created.Body)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/discussions/d3/notes", func(w http.ResponseWriter, r *http.Request) {
+		replies = append(replies, "d3")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id":6,"body":"fixed"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/discussions/d3", func(w http.ResponseWriter, r *http.Request) {
+		req := &gitlab.ResolveMergeRequestDiscussionOptions{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Error(err)
+		}
+		resolved = append(resolved, req.Resolved != nil && *req.Resolved)
+		_, _ = fmt.Fprint(w, `{"id":"d3"}`)
+	})
+	prs := newTestPullRequestClient(t, mux)
+	c := newPullRequest(prs.clientContext, &gitlab.MergeRequest{ProjectID: 5, IID: 1}).Comments()
+	ctx := context.Background()
+
+	comments, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	// the system note is left out
+	if len(comments) != 3 {
+		t.Fatalf("List() returned %d comments, want 3", len(comments))
+	}
+	if got := comments[0].Get(); got.ID != 1 || got.Author != "alice" || got.Path != "" || got.InReplyTo != 0 {
+		t.Errorf("List()[0] = %+v, want the general comment", got)
+	}
+	if got := comments[2].Get(); got.ID != 4 || got.Path != "main.go" || got.Line != 3 || got.InReplyTo != 3 {
+		t.Errorf("List()[2] = %+v, want the reply to comment 3", got)
+	}
+
+	comment, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "typo", Path: "main.go", Line: 7})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if p := created.Position; p == nil || p.BaseSHA != "base" || p.HeadSHA != "head" || p.StartSHA != "start" || p.NewPath != "main.go" || p.NewLine != 7 {
+		t.Errorf("Create() sent position %+v, want a position on the latest diff", created.Position)
+	}
+	if got := comment.Get(); got.ID != 5 || got.Body != "typo" {
+		t.Errorf("Create() = %+v, want the new note", got)
+	}
+	reply, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "fixed", InReplyTo: 4})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(replies) != 1 || reply.Get().InReplyTo != 3 {
+		t.Errorf("Create() = %+v, want a note added to discussion d3", reply.Get())
+	}
+	if _, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "fixed", InReplyTo: 9}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Create() error = %v, want ErrNotFound", err)
+	}
+
+	if err := c.Resolve(ctx, 4); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(resolved) != 1 || !resolved[0] {
+		t.Errorf("Resolve() resolved %v, want discussion d3 to be resolved", resolved)
+	}
+	if err := c.Resolve(ctx, 1); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Resolve() of a general comment error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Resolve(ctx, 2); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Resolve() of a system note error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReviewClient implements the gitprovider.ReviewClient interface.
+var _ gitprovider.ReviewClient = &ReviewClient{}
+
+// ReviewClient operates on the reviewers and approvals of a specific merge request.
+type ReviewClient struct {
+	*clientContext
+	projectID int
+	iid       int
+}
+
+// RequestReviewers adds the users with the given usernames to the reviewers of the merge request.
+func (c *ReviewClient) RequestReviewers(ctx context.Context, logins ...string) error {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}
+	mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(c.projectID, c.iid, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	userIDs := make([]int, 0, len(mr.Reviewers)+len(logins))
+	requested := make(map[int]bool, len(mr.Reviewers))
+	for _, reviewer := range mr.Reviewers {
+		userIDs = append(userIDs, reviewer.ID)
+		requested[reviewer.ID] = true
+	}
+	for _, login := range logins {
+		// GET /users?username={username}
+		user, err := c.c.GetUserByUsername(ctx, login)
+		if err != nil {
+			return err
+		}
+		if !requested[user.ID] {
+			userIDs = append(userIDs, user.ID)
+			requested[user.ID] = true
+		}
+	}
+	if len(userIDs) == len(mr.Reviewers) {
+		return nil
+	}
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	return c.c.SetMergeRequestReviewers(ctx, c.projectID, c.iid, userIDs)
+}
+
+// List lists the approvals of the merge request.
+func (c *ReviewClient) List(ctx context.Context) ([]gitprovider.Review, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/approvals
+	apiObj, err := c.c.GetMergeRequestApprovals(ctx, c.projectID, c.iid)
+	if err != nil {
+		return nil, err
+	}
+	return approvalReviews(apiObj), nil
+}
+
+// Approve approves the merge request. As GitLab approvals have no comment, a non-empty body is
+// added as a note to the merge request.
+func (c *ReviewClient) Approve(ctx context.Context, body string) (gitprovider.Review, error) {
+	// GET /user
+	user, err := c.c.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/approve
+	if _, err := c.c.ApproveMergeRequest(ctx, c.projectID, c.iid); err != nil {
+		return nil, err
+	}
+	if body != "" {
+		// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+		if err := c.c.CreateMergeRequestNote(ctx, c.projectID, c.iid, body); err != nil {
+			return nil, err
+		}
+	}
+	return newReview(&gitlab.BasicUser{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		State:     user.State,
+		AvatarURL: user.AvatarURL,
+		WebURL:    user.WebURL,
+	}), nil
+}
+
+// Dismiss withdraws the approval of the authenticated user, whose user ID must be given as id.
+// message is ignored, as GitLab doesn't record a reason.
+func (c *ReviewClient) Dismiss(ctx context.Context, id int64, _ string) error {
+	// GET /user
+	user, err := c.c.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if int64(user.ID) != id {
+		return fmt.Errorf("gitlab can only withdraw the approval of the authenticated user: %w", gitprovider.ErrNoProviderSupport)
+	}
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/unapprove
+	return c.c.UnapproveMergeRequest(ctx, c.projectID, c.iid)
+}
+
+// approvalReviews returns the approvals of a merge request as reviews.
+func approvalReviews(apiObj *gitlab.MergeRequestApprovals) []gitprovider.Review {
+	reviews := make([]gitprovider.Review, 0, len(apiObj.ApprovedBy))
+	for _, approver := range apiObj.ApprovedBy {
+		if approver == nil || approver.User == nil {
+			continue
+		}
+		reviews = append(reviews, newReview(approver.User))
+	}
+	return reviews
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestReviewClient(t *testing.T) {
+	var reviewerIDs []int
+	var note string
+	approved := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":3,"username":"carol"}`)
+	})
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("username") {
+		case "alice":
+			_, _ = fmt.Fprint(w, `[{"id":1,"username":"alice"}]`)
+		case "bob":
+			_, _ = fmt.Fprint(w, `[{"id":2,"username":"bob"}]`)
+		default:
+			_, _ = fmt.Fprint(w, `[]`)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			req := &gitlab.UpdateMergeRequestOptions{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			reviewerIDs = *req.ReviewerIDs
+		}
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"reviewers":[{"id":1,"username":"alice"}]}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/approvals", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"approved_by":[{"user":{"id":1,"username":"alice"}}]}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/approve", func(w http.ResponseWriter, r *http.Request) {
+		approved = true
+		_, _ = fmt.Fprint(w, `{"iid":1,"approved_by":[{"user":{"id":3,"username":"carol"}}]}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/unapprove", func(w http.ResponseWriter, r *http.Request) {
+		approved = false
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/notes", func(w http.ResponseWriter, r *http.Request) {
+		req := &gitlab.CreateMergeRequestNoteOptions{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Error(err)
+		}
+		note = *req.Body
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id":7}`)
+	})
+	prs := newTestPullRequestClient(t, mux)
+	c := newPullRequest(prs.clientContext, &gitlab.MergeRequest{ProjectID: 5, IID: 1}).Reviews()
+	ctx := context.Background()
+
+	if err := c.RequestReviewers(ctx, "alice", "bob"); err != nil {
+		t.Fatalf("RequestReviewers() error = %v", err)
+	}
+	// alice is a reviewer already
+	if want := []int{1, 2}; !reflect.DeepEqual(reviewerIDs, want) {
+		t.Errorf("RequestReviewers() set reviewers %v, want %v", reviewerIDs, want)
+	}
+	if err := c.RequestReviewers(ctx, "mallory"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("RequestReviewers() error = %v, want ErrNotFound", err)
+	}
+
+	reviews, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].Get() != (gitprovider.ReviewInfo{ID: 1, Reviewer: "alice", State: gitprovider.ReviewStateApproved}) {
+		t.Errorf("List() = %v, want the approval of alice", reviews)
+	}
+
+	review, err := c.Approve(ctx, "LGTM")
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if !approved || note != "LGTM" {
+		t.Errorf("Approve() approved = %v and added note %q, want the approval and the note", approved, note)
+	}
+	if got := review.Get(); got.ID != 3 || got.Reviewer != "carol" {
+		t.Errorf("Approve() = %+v, want the approval of carol", got)
+	}
+
+	if err := c.Dismiss(ctx, 1, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Dismiss() error = %v, want ErrNoProviderSupport for the approval of another user", err)
+	}
+	if err := c.Dismiss(ctx, 3, ""); err != nil {
+		t.Fatalf("Dismiss() error = %v", err)
+	}
+	if approved {
+		t.Error("Dismiss() didn't withdraw the approval")
+	}
+}
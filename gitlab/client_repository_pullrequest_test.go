@@ -0,0 +1,412 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestPullRequestClient(t *testing.T, mux *http.ServeMux) *PullRequestClient {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &PullRequestClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestPullRequestClient_CreateDraft(t *testing.T) {
+	var create map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"iid":1,"title":%q,"state":"opened","draft":true}`, create["title"])
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.Create(context.Background(), "title", "feature", "main", "description",
+		&gitprovider.PullRequestCreateOptions{Draft: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if create["title"] != "Draft: title" {
+		t.Errorf("Create() sent title %q, want the draft prefix to be added", create["title"])
+	}
+	if !pr.Get().Draft {
+		t.Errorf("Create() = %+v, want a draft", pr.Get())
+	}
+}
+
+func TestPullRequestClient_CreateFromFork(t *testing.T) {
+	var create map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":5,"path_with_namespace":"org/repo"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/user/repo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"source_project_id":6,"state":"opened"}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	fork := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	_, err := c.Create(context.Background(), "title", "feature", "main", "description",
+		&gitprovider.PullRequestCreateOptions{HeadRepository: fork})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if create["source_branch"] != "feature" || create["target_project_id"] != float64(5) {
+		t.Errorf("Create() sent %v, want the fork to target project 5", create)
+	}
+}
+
+func TestPullRequestClient_Update(t *testing.T) {
+	var update map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, `{"iid":1,"title":"WIP: title","state":"opened","target_branch":"main","draft":true}`)
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Error(err)
+			}
+			_, _ = fmt.Fprintf(w, `{"iid":1,"title":%q,"description":%q,"state":"opened","target_branch":%q}`,
+				update["title"], update["description"], update["target_branch"])
+		}
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	pr, err := c.Update(context.Background(), 1, gitprovider.PullRequestUpdateOptions{
+		Description: gitprovider.StringVar("description"),
+		BaseBranch:  gitprovider.StringVar("release"),
+		Draft:       gitprovider.BoolVar(false),
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if update["title"] != "title" {
+		t.Errorf("Update() sent title %q, want the draft prefix to be removed", update["title"])
+	}
+	got := pr.Get()
+	if got.Description != "description" || got.BaseBranch != "release" || got.Draft {
+		t.Errorf("Update() = %+v, want the new description and base branch", got)
+	}
+}
+
+func TestPullRequestClient_CloseReopen(t *testing.T) {
+	state := openedState
+	var events []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			req := &gitlab.UpdateMergeRequestOptions{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			events = append(events, *req.StateEvent)
+			state = map[string]string{"close": closedState, "reopen": openedState}[*req.StateEvent]
+		}
+		_, _ = fmt.Fprintf(w, `{"iid":1,"title":"title","state":%q}`, state)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Close(ctx, 1)
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := pr.Get().State; got != gitprovider.PullRequestStateClosed {
+		t.Errorf("Close() state = %q, want %q", got, gitprovider.PullRequestStateClosed)
+	}
+	// Closing a closed merge request is a no-op
+	if _, err := c.Close(ctx, 1); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	pr, err = c.Reopen(ctx, 1)
+	if err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	if got := pr.Get().State; got != gitprovider.PullRequestStateOpen {
+		t.Errorf("Reopen() state = %q, want %q", got, gitprovider.PullRequestStateOpen)
+	}
+	if len(events) != 2 || events[0] != "close" || events[1] != "reopen" {
+		t.Errorf("state events = %v, want [close reopen]", events)
+	}
+
+	state = mergedState
+	if _, err := c.Reopen(ctx, 1); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Reopen() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func Test_setDraftPrefix(t *testing.T) {
+	tests := []struct {
+		title string
+		draft bool
+		want  string
+	}{
+		{title: "title", draft: true, want: "Draft: title"},
+		{title: "Draft: title", draft: true, want: "Draft: title"},
+		{title: "[WIP] title", draft: true, want: "Draft: title"},
+		{title: "draft - title", draft: false, want: "title"},
+		{title: "(Draft) title", draft: false, want: "title"},
+		{title: "Drafting the title", draft: false, want: "Drafting the title"},
+	}
+	for _, tt := range tests {
+		if got := setDraftPrefix(tt.title, tt.draft); got != tt.want {
+			t.Errorf("setDraftPrefix(%q, %v) = %q, want %q", tt.title, tt.draft, got, tt.want)
+		}
+	}
+}
+
+func TestPullRequestClient_MergeMethods(t *testing.T) {
+	var accept map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":5,"merge_method":"ff","squash_option":"default_off"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"state":"opened","merge_status":"can_be_merged"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&accept); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"iid":1,"state":"merged"}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodSquash, "message", &gitprovider.PullRequestMergeOptions{CommitTitle: gitprovider.StringVar("title")}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if accept["squash"] != true || accept["squash_commit_message"] != "title\n\nmessage" {
+		t.Errorf("Merge() sent %v, want a squash merge with the title prepended to the message", accept)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodFastForward, ""); err != nil {
+		t.Errorf("Merge() error = %v", err)
+	}
+	// the project only allows fast-forward merges
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodMerge, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodRebase, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Merge() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestPullRequest_EnableAutoMerge(t *testing.T) {
+	var accept map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/5", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id":5,"merge_method":"merge","squash_option":"never"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"state":"opened"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&accept); err != nil {
+			t.Error(err)
+		}
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"state":"opened","merge_when_pipeline_succeeds":true}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := pr.EnableAutoMerge(ctx, gitprovider.MergeMethodMerge); err != nil {
+		t.Fatalf("EnableAutoMerge() error = %v", err)
+	}
+	if accept["merge_when_pipeline_succeeds"] != true || accept["squash"] != false {
+		t.Errorf("EnableAutoMerge() sent %v, want a merge when the pipeline succeeds", accept)
+	}
+	// the project never squashes
+	if err := pr.EnableAutoMerge(ctx, gitprovider.MergeMethodSquash); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("EnableAutoMerge() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPullRequest_Triage(t *testing.T) {
+	var updates []map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"state":"opened","labels":["bug"],"assignees":[{"username":"alice"}],"milestone":{"id":30,"iid":3}}`)
+	})
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[{"id":7,"username":%q}]`, r.URL.Query().Get("username"))
+	})
+	mux.HandleFunc("/api/v4/projects/5/milestones", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("iids[]") != "4" {
+			t.Errorf("unexpected milestone query %q", r.URL.RawQuery)
+		}
+		_, _ = fmt.Fprint(w, `[{"id":40,"iid":4}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		update := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			t.Error(err)
+		}
+		updates = append(updates, update)
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"state":"opened","labels":["release"],"assignees":[{"username":"bob"}],"milestone":{"id":40,"iid":4}}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	info := pr.Get()
+	if !reflect.DeepEqual(info.Labels, []string{"bug"}) || !reflect.DeepEqual(info.Assignees, []string{"alice"}) || info.Milestone == nil || *info.Milestone != 3 {
+		t.Errorf("Get() = %+v, want the labels, assignees and milestone of the merge request", info)
+	}
+	if err := pr.SetLabels(ctx, []string{"release", "automated"}); err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	if err := pr.SetAssignees(ctx, []string{"bob"}); err != nil {
+		t.Fatalf("SetAssignees() error = %v", err)
+	}
+	if err := pr.SetMilestone(ctx, 4); err != nil {
+		t.Fatalf("SetMilestone() error = %v", err)
+	}
+	want := []map[string]interface{}{
+		{"labels": "release,automated"},
+		{"assignee_ids": []interface{}{float64(7)}},
+		{"milestone_id": float64(40)},
+	}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("sent %v, want %v", updates, want)
+	}
+	info = pr.Get()
+	if !reflect.DeepEqual(info.Labels, []string{"release"}) || !reflect.DeepEqual(info.Assignees, []string{"bob"}) || info.Milestone == nil || *info.Milestone != 4 {
+		t.Errorf("Get() = %+v, want the labels, assignees and milestone to be updated", info)
+	}
+}
+
+func TestPullRequestClient_List(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		want := url.Values{
+			"state":           {"merged"},
+			"target_branch":   {"main"},
+			"source_branch":   {"feature"},
+			"author_username": {"alice"},
+			"labels":          {"release"},
+			"updated_after":   {"2023-01-01T00:00:00Z"},
+		}
+		if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+			t.Errorf("List() sent %v, want %v", got, want)
+		}
+		_, _ = fmt.Fprint(w, `[{"iid":1,"state":"merged"}]`)
+	})
+	c := newTestPullRequestClient(t, mux)
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	prs, err := c.List(context.Background(), &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateMerged),
+		BaseBranch:   gitprovider.StringVar("main"),
+		SourceBranch: gitprovider.StringVar("feature"),
+		Author:       gitprovider.StringVar("alice"),
+		Label:        gitprovider.StringVar("release"),
+		UpdatedSince: &since,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().State != gitprovider.PullRequestStateMerged {
+		t.Errorf("List() = %v, want the merged merge request", prs)
+	}
+}
+
+func TestPullRequest_FilesAndDiff(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/org/repo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"state":"opened"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/5/merge_requests/1/changes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"iid":1,"project_id":5,"changes":[
+			{"old_path":"README.md","new_path":"README.md","a_mode":"100644","b_mode":"100644","diff":"@@ -1 +1,2 @@\n-old\n+new\n+line\n"},
+			{"old_path":"new.txt","new_path":"new.txt","a_mode":"0","b_mode":"100644","new_file":true,"diff":"@@ -0,0 +1 @@\n+content\n"},
+			{"old_path":"old.md","new_path":"docs/old.md","a_mode":"100644","b_mode":"100644","renamed_file":true,"diff":""}
+		]}`)
+	})
+	c := newTestPullRequestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := c.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	files, err := pr.Files(ctx)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	want := []gitprovider.PullRequestFile{
+		{Path: "README.md", ChangeType: gitprovider.FileChangeTypeModified, Additions: 2, Deletions: 1, Patch: "@@ -1 +1,2 @@\n-old\n+new\n+line\n"},
+		{Path: "new.txt", ChangeType: gitprovider.FileChangeTypeAdded, Additions: 1, Patch: "@@ -0,0 +1 @@\n+content\n"},
+		{Path: "docs/old.md", PreviousPath: "old.md", ChangeType: gitprovider.FileChangeTypeRenamed},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Files() = %+v, want %+v", files, want)
+	}
+
+	diff, err := pr.Diff(ctx)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	wantDiff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n-old\n+new\n+line\n" +
+		"diff --git a/new.txt b/new.txt\nnew file mode 100644\n--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1 @@\n+content\n" +
+		"diff --git a/old.md b/docs/old.md\nrename from old.md\nrename to docs/old.md\n"
+	if diff != wantDiff {
+		t.Errorf("Diff() = %q, want %q", diff, wantDiff)
+	}
+}
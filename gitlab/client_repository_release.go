@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the release created from the given tag, including its assets.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.Release, error) {
+	// GET /projects/{project}/releases/{tag_name}
+	apiObj, err := c.c.GetRelease(ctx, getRepoPath(c.ref), tagName)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// List lists all releases of the repository, including their assets, most recent first.
+//
+// List returns all available releases, using multiple paginated requests if needed.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.Release, error) {
+	// GET /projects/{project}/releases
+	apiObjs, err := c.c.ListReleases(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Release type
+	releases := make([]gitprovider.Release, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListReleases
+		releases = append(releases, newRelease(c, apiObj))
+	}
+	return releases, nil
+}
+
+// DownloadAsset returns a reader streaming the contents of the given release asset.
+// The caller must close the returned reader. The size of the asset isn't limited by
+// WithMaxResponseSize, but by WithMaxDownloadSize, if given.
+//
+// GitLab release assets are links, hence the asset is downloaded from its DownloadURL. The
+// credentials of the client are only sent if the link points to the GitLab instance itself.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) DownloadAsset(ctx context.Context, asset gitprovider.ReleaseAsset) (io.ReadCloser, error) {
+	if asset.DownloadURL == "" {
+		return nil, fmt.Errorf("release asset %q has no download URL: %w", asset.Name, gitprovider.ErrInvalidArgument)
+	}
+	return c.c.DownloadReleaseLink(gitprovider.WithDownload(ctx), asset.DownloadURL)
+}
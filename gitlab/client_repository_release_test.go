@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestReleaseClient_ListAndDownload(t *testing.T) {
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "" {
+			t.Errorf("token sent to external host: %q", got)
+		}
+		_, _ = fmt.Fprint(w, "external")
+	}))
+	defer external.Close()
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/api/v4/projects/org/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[{"tag_name":"v1.0.0","name":"v1","description":"first","assets":{"links":[`+
+			`{"id":1,"name":"internal.tar.gz","url":"%[1]s/org/repo/-/package_files/1/download","direct_asset_url":"%[1]s/org/repo/-/releases/v1.0.0/downloads/internal.tar.gz"},`+
+			`{"id":2,"name":"external.tar.gz","url":"%[2]s/external.tar.gz"}]}}]`, srv.URL, external.URL)
+	})
+	mux.HandleFunc("/org/repo/-/releases/v1.0.0/downloads/internal.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "token" {
+			t.Errorf("PRIVATE-TOKEN = %q, want %q", got, "token")
+		}
+		_, _ = fmt.Fprint(w, "internal")
+	})
+
+	gl, err := gitlab.NewClient("token", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &ReleaseClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	ctx := context.Background()
+	releases, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 1 {
+		t.Fatalf("got %d releases, want 1", len(releases))
+	}
+	want := gitprovider.ReleaseInfo{
+		TagName:     "v1.0.0",
+		Name:        "v1",
+		Description: "first",
+		Assets: []gitprovider.ReleaseAsset{
+			{ID: 1, Name: "internal.tar.gz", DownloadURL: srv.URL + "/org/repo/-/releases/v1.0.0/downloads/internal.tar.gz"},
+			{ID: 2, Name: "external.tar.gz", DownloadURL: external.URL + "/external.tar.gz"},
+		},
+	}
+	info := releases[0].Get()
+	if !reflect.DeepEqual(info, want) {
+		t.Fatalf("Get() = %+v, want %+v", info, want)
+	}
+
+	for i, wantBody := range []string{"internal", "external"} {
+		rc, err := c.DownloadAsset(ctx, info.Assets[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != wantBody {
+			t.Errorf("DownloadAsset(%s) = %q, want %q", info.Assets[i].Name, body, wantBody)
+		}
+	}
+}
+
+func TestRelease_UploadAsset(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/api/v4/projects/org/repo/releases/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"tag_name":"v1.0.0","assets":{"links":[{"id":1,"name":"existing.tar.gz","url":"https://example.com/existing.tar.gz"}]}}`)
+	})
+	mux.HandleFunc("/api/v4/projects/org/repo/packages/generic/repo/v1.0.0/app.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "content" {
+			t.Errorf("body = %q, want %q", body, "content")
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"message":"201 Created"}`)
+	})
+	packageURL := srv.URL + "/api/v4/projects/org%2Frepo/packages/generic/repo/v1.0.0/app.tar.gz"
+	directURL := srv.URL + "/org/repo/-/releases/v1.0.0/downloads/app.tar.gz"
+	mux.HandleFunc("/api/v4/projects/org/repo/releases/v1.0.0/assets/links", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{"name": "app.tar.gz", "url": packageURL, "filepath": "/app.tar.gz", "link_type": "package"}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":2,"name":"app.tar.gz","url":%q,"direct_asset_url":%q,"link_type":"package"}`, packageURL, directURL)
+	})
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &ReleaseClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	ctx := context.Background()
+	rel, err := c.Get(ctx, "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	asset, err := rel.UploadAsset(ctx, "app.tar.gz", "application/gzip", strings.NewReader("content"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gitprovider.ReleaseAsset{ID: 2, Name: "app.tar.gz", DownloadURL: directURL}
+	if asset != want {
+		t.Errorf("UploadAsset() = %+v, want %+v", asset, want)
+	}
+	if got := len(rel.Get().Assets); got != 2 {
+		t.Errorf("release has %d assets, want 2", got)
+	}
+
+	_, err = rel.UploadAsset(ctx, "existing.tar.gz", "", strings.NewReader("content"))
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("UploadAsset() error = %v, want ErrAlreadyExists", err)
+	}
+}
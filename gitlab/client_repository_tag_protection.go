@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagProtectionClient implements the gitprovider.TagProtectionClient interface.
+var _ gitprovider.TagProtectionClient = &TagProtectionClient{}
+
+// TagProtectionClient operates on the protected tags of a specific repository.
+type TagProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protected tag with the given pattern.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagProtectionClient) Get(ctx context.Context, pattern string) (gitprovider.TagProtection, error) {
+	// GET /projects/{project}/protected_tags/{name}
+	apiObj, err := c.c.GetProtectedTag(ctx, getRepoPath(c.ref), pattern)
+	if err != nil {
+		return nil, err
+	}
+	return newTagProtection(c, apiObj), nil
+}
+
+// List lists all protected tags of the repository.
+//
+// List returns all available rules, using multiple paginated requests if needed.
+func (c *TagProtectionClient) List(ctx context.Context) ([]gitprovider.TagProtection, error) {
+	// GET /projects/{project}/protected_tags
+	apiObjs, err := c.c.ListProtectedTags(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our TagProtection type
+	rules := make([]gitprovider.TagProtection, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListProtectedTags
+		rules = append(rules, newTagProtection(c, apiObj))
+	}
+	return rules, nil
+}
+
+// Create protects the tags matching the given pattern. Only maintainers may create the tags.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TagProtectionClient) Create(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.Get(ctx, req.Pattern); err == nil {
+		return nil, fmt.Errorf("protected tag %q: %w", req.Pattern, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+	return c.create(ctx, req)
+}
+
+func (c *TagProtectionClient) create(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, error) {
+	// POST /projects/{project}/protected_tags
+	apiObj, err := c.c.ProtectTag(ctx, getRepoPath(c.ref), &gitlab.ProtectRepositoryTagsOptions{
+		Name:              gitlab.String(req.Pattern),
+		CreateAccessLevel: gitlab.AccessLevel(gitlab.MaintainerPermissions),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTagProtection(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing rule is matched by its pattern.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req already exists, this is a no-op (actionTaken == false).
+func (c *TagProtectionClient) Reconcile(ctx context.Context, req gitprovider.TagProtectionInfo) (gitprovider.TagProtection, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Pattern)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.create(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	return actual, false, nil
+}
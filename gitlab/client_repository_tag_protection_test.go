@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTagProtectionClient_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          gitprovider.TagProtectionInfo
+		wantRequests []string
+		wantBody     map[string]interface{}
+		wantErr      error
+	}{
+		{
+			name:         "already protected",
+			req:          gitprovider.TagProtectionInfo{Pattern: "v*"},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/protected_tags/v*"},
+			wantErr:      gitprovider.ErrAlreadyExists,
+		},
+		{
+			name: "protect a new pattern",
+			req:  gitprovider.TagProtectionInfo{Pattern: "release-*"},
+			wantRequests: []string{
+				"GET /api/v4/projects/org/repo/protected_tags/release-*",
+				"POST /api/v4/projects/org/repo/protected_tags",
+			},
+			wantBody: map[string]interface{}{
+				"name":                "release-*",
+				"create_access_level": float64(40),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/v*"):
+					_, _ = fmt.Fprint(w, `{"name":"v*","create_access_levels":[{"access_level":40}]}`)
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"message":"404 Not found"}`)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": body["name"]})
+				}
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &TagProtectionClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, err := c.Create(context.Background(), tt.req)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Create() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resp.Get(); !tt.req.Equals(got) {
+				t.Errorf("Create() = %+v, want %+v", got, tt.req)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Create() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/hooks"},
+		},
+		{
+			name: "change events",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventComment},
+			},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/hooks", "PUT /api/v4/projects/org/repo/hooks/1"},
+			wantBody: map[string]interface{}{
+				"url":                   hookURL,
+				"push_events":           true,
+				"tag_push_events":       false,
+				"merge_requests_events": false,
+				"issues_events":         false,
+				"note_events":           true,
+				"releases_events":       false,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventRelease},
+			},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/hooks", "POST /api/v4/projects/org/repo/hooks"},
+			wantBody: map[string]interface{}{
+				"url":                   "https://example.com/other",
+				"token":                 "s3cr3t",
+				"push_events":           false,
+				"tag_push_events":       false,
+				"merge_requests_events": false,
+				"issues_events":         false,
+				"note_events":           false,
+				"releases_events":       true,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "form content type",
+			req: gitprovider.WebhookInfo{
+				URL:         "https://example.com/other",
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET /api/v4/projects/org/repo/hooks"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `[{"id":1,"url":%q,"push_events":true,"tag_push_events":true,"merge_requests_events":true}]`, hookURL)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 1}
+					for key, value := range body {
+						resp[key] = value
+					}
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &WebhookClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Reconcile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestWebhook_Deliveries(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Query().Get("page") == "2":
+			_, _ = fmt.Fprint(w, `[{"id":3,"trigger":"push_hooks","response_status":"internal error","execution_duration":10,"created_at":"2024-08-01T10:00:00Z"}]`)
+		default:
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = fmt.Fprint(w, `[{"id":2,"trigger":"merge_request_hooks","response_status":"200","execution_duration":0.25,"created_at":"2024-08-02T10:00:00Z"}]`)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wh := newWebhook(&WebhookClient{
+		clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+			RepositoryName:  "repo",
+		},
+	}, &gitlab.ProjectHook{ID: 1})
+
+	deliveries, err := wh.Deliveries(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gitprovider.WebhookDelivery{
+		{ID: "2", Event: "merge_request_hooks", DeliveredAt: time.Date(2024, 8, 2, 10, 0, 0, 0, time.UTC), StatusCode: 200, Duration: 250 * time.Millisecond},
+		{ID: "3", Event: "push_hooks", DeliveredAt: time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC), Duration: 10 * time.Second},
+	}
+	if !reflect.DeepEqual(deliveries, want) {
+		t.Errorf("Deliveries() = %+v, want %+v", deliveries, want)
+	}
+
+	if err := wh.Redeliver(context.Background(), "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wh.Redeliver(context.Background(), "latest"); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Redeliver() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+
+	wantRequests := []string{
+		"GET /api/v4/projects/org/repo/hooks/1/events",
+		"GET /api/v4/projects/org/repo/hooks/1/events",
+		"POST /api/v4/projects/org/repo/hooks/1/events/2/resend",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+}
+
+func TestWebhook_Ping(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       gitprovider.WebhookPingResult
+		wantErr    error
+	}{
+		{
+			name:       "successful",
+			statusCode: http.StatusCreated,
+			body:       `{"message":"201 Created"}`,
+			want:       gitprovider.WebhookPingResult{Successful: true},
+		},
+		{
+			name:       "failed delivery",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"message":"Hook execution failed: connection refused"}`,
+			want:       gitprovider.WebhookPingResult{Message: "Hook execution failed: connection refused"},
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			body:       `{"message":"404 Not Found"}`,
+			wantErr:    gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v4/projects/org/repo/hooks/1/test/push_events" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			wh := newWebhook(&WebhookClient{
+				clientContext: &clientContext{c: &gitlabClientImpl{c: gl}},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "org"},
+					RepositoryName:  "repo",
+				},
+			}, &gitlab.ProjectHook{ID: 1})
+
+			got, err := wh.Ping(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Ping() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
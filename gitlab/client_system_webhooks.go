@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// SystemWebhookClient implements the gitprovider.SystemWebhookClient interface.
+var _ gitprovider.SystemWebhookClient = &SystemWebhookClient{}
+
+// SystemWebhookClient operates on the system hooks of the GitLab instance, which are triggered
+// by the events in all projects. Managing them requires administrator access.
+type SystemWebhookClient struct {
+	*clientContext
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *SystemWebhookClient) Get(ctx context.Context, url string) (gitprovider.SystemWebhook, error) {
+	webhooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, wh := range webhooks {
+		if wh.h.URL == url {
+			return wh, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all system hooks.
+func (c *SystemWebhookClient) List(ctx context.Context) ([]gitprovider.SystemWebhook, error) {
+	whs, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.SystemWebhook
+	webhooks := make([]gitprovider.SystemWebhook, 0, len(whs))
+	for _, wh := range whs {
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+func (c *SystemWebhookClient) list(ctx context.Context) ([]*systemWebhook, error) {
+	// GET /hooks
+	apiObjs, err := c.c.ListSystemHooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our SystemWebhook type
+	webhooks := make([]*systemWebhook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListSystemHooks
+		webhooks = append(webhooks, newSystemWebhook(c, apiObj))
+	}
+	return webhooks, nil
+}
+
+// Create creates a webhook with the given specifications. GitLab sends the secret as
+// "X-Gitlab-Token" header, and only supports JSON payloads. Besides the given events, system
+// hooks are always triggered by system events, e.g. when a project or user is created.
+func (c *SystemWebhookClient) Create(ctx context.Context, req gitprovider.WebhookInfo) (gitprovider.SystemWebhook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateSystemWebhookSupport(req); err != nil {
+		return nil, err
+	}
+
+	// POST /hooks
+	apiObj, err := c.c.CreateSystemHook(ctx, systemWebhookToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newSystemWebhook(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing webhook is matched by its URL.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be recreated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+// WebhookReconcileOptions.ForceSecretRotation deletes and recreates the webhook (actionTaken == true).
+func (c *SystemWebhookClient) Reconcile(ctx context.Context, req gitprovider.WebhookInfo, opts ...gitprovider.WebhookReconcileOption) (gitprovider.SystemWebhook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+	if err := validateSystemWebhookSupport(req); err != nil {
+		return nil, false, err
+	}
+	rotateSecret, err := gitprovider.RotatesWebhookSecret(req, gitprovider.MakeWebhookReconcileOptions(opts...))
+	if err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if !rotateSecret && req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// GitLab can't update system hooks, hence recreate the webhook to apply the desired state
+	if err := actual.Delete(ctx); err != nil {
+		return nil, false, err
+	}
+	resp, err := c.Create(ctx, req)
+	return resp, true, err
+}
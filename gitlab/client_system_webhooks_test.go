@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestSystemWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		opts            []gitprovider.WebhookReconcileOption
+		wantRequests    []string
+		wantBody        map[string]interface{}
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET /api/v4/hooks"},
+		},
+		{
+			name: "change events",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest},
+			},
+			wantRequests: []string{"GET /api/v4/hooks", "DELETE /api/v4/hooks/1", "POST /api/v4/hooks"},
+			wantBody: map[string]interface{}{
+				"url":                   hookURL,
+				"push_events":           false,
+				"tag_push_events":       false,
+				"merge_requests_events": true,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "force secret rotation",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Secret: gitprovider.StringVar("n3w"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTag},
+			},
+			opts:         []gitprovider.WebhookReconcileOption{&gitprovider.WebhookReconcileOptions{ForceSecretRotation: gitprovider.BoolVar(true)}},
+			wantRequests: []string{"GET /api/v4/hooks", "DELETE /api/v4/hooks/1", "POST /api/v4/hooks"},
+			wantBody: map[string]interface{}{
+				"url":                   hookURL,
+				"token":                 "n3w",
+				"push_events":           true,
+				"tag_push_events":       true,
+				"merge_requests_events": false,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET /api/v4/hooks", "POST /api/v4/hooks"},
+			wantBody: map[string]interface{}{
+				"url":                   "https://example.com/other",
+				"push_events":           true,
+				"tag_push_events":       false,
+				"merge_requests_events": false,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "unsupported event",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventIssues},
+			},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			var body map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/hooks", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprintf(w, `[{"id":1,"url":%q,"push_events":true,"tag_push_events":true,"repository_update_events":true}]`, hookURL)
+				default:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					resp := map[string]interface{}{"id": 2}
+					for key, value := range body {
+						resp[key] = value
+					}
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(resp)
+				}
+			})
+			mux.HandleFunc("/api/v4/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+				w.WriteHeader(http.StatusNoContent)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &SystemWebhookClient{clientContext: &clientContext{c: &gitlabClientImpl{c: gl}}}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req, tt.opts...)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("Reconcile() requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Reconcile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("Reconcile() body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
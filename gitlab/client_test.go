@@ -0,0 +1,246 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestClient_CheckScopes(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMissing []string
+		wantErr     error
+	}{
+		{
+			name:        "implied scopes",
+			status:      http.StatusOK,
+			body:        `{"id":1,"scopes":["api"]}`,
+			wantMissing: []string{},
+		},
+		{
+			name:        "missing scopes",
+			status:      http.StatusOK,
+			body:        `{"id":1,"scopes":["read_repository"]}`,
+			wantMissing: []string{"api", "write_repository"},
+		},
+		{
+			name:    "endpoint not available",
+			status:  http.StatusNotFound,
+			body:    `{"message":"404 Not Found"}`,
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newClient(gl, DefaultDomain, "", false)
+
+			res, err := c.CheckScopes(context.Background(), []string{"api", "read_repository", "write_repository"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckScopes() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(res.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %v, want %v", res.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestClient_TokenInfo(t *testing.T) {
+	expiresAt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *gitprovider.TokenInfo
+		wantErr error
+	}{
+		{
+			name:   "expiring token",
+			status: http.StatusOK,
+			body:   `{"id":1,"name":"flux","scopes":["api"],"expires_at":"2024-01-31"}`,
+			want:   &gitprovider.TokenInfo{Name: "flux", Scopes: []string{"api"}, ExpiresAt: &expiresAt},
+		},
+		{
+			name:   "token without expiry",
+			status: http.StatusOK,
+			body:   `{"id":1,"name":"flux","scopes":["read_api"]}`,
+			want:   &gitprovider.TokenInfo{Name: "flux", Scopes: []string{"read_api"}},
+		},
+		{
+			name:    "endpoint not available",
+			status:  http.StatusNotFound,
+			body:    `{"message":"404 Not Found"}`,
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newClient(gl, DefaultDomain, "", false)
+
+			got, err := c.TokenInfo(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TokenInfo() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TokenInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListStarred(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []gitprovider.RepositoryRef
+		wantErr error
+	}{
+		{
+			name:   "stars",
+			status: http.StatusOK,
+			body:   `[{"id":1,"name":"Flux","path":"flux","namespace":{"kind":"group","full_path":"fluxcd/tools"}},{"id":2,"name":"Dotfiles","path":"dotfiles","namespace":{"kind":"user","full_path":"jdoe"}}]`,
+			want: []gitprovider.RepositoryRef{
+				gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd", SubOrganizations: []string{"tools"}},
+					RepositoryName:  "flux",
+				},
+				gitprovider.UserRepositoryRef{
+					UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "jdoe"},
+					RepositoryName: "dotfiles",
+				},
+			},
+		},
+		{
+			name:   "no stars",
+			status: http.StatusOK,
+			body:   `[]`,
+			want:   []gitprovider.RepositoryRef{},
+		},
+		{
+			name:    "missing user",
+			status:  http.StatusNotFound,
+			body:    `{"message":"404 User Not Found"}`,
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/users/jdoe/starred_projects", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newClient(gl, DefaultDomain, "", false)
+
+			got, err := c.ListStarred(context.Background(), "jdoe")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListStarred() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListStarred() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListLicenseTemplates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/templates/licenses", func(w http.ResponseWriter, r *http.Request) {
+		// Serve the templates on two pages
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `[{"key":"mit","name":"MIT License"}]`)
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		_, _ = fmt.Fprint(w, `[{"key":"apache-2.0","name":"Apache License 2.0"}]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(gl, DefaultDomain, "", false)
+
+	got, err := c.ListLicenseTemplates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gitprovider.LicenseTemplateInfo{
+		{Key: gitprovider.LicenseTemplateApache2, Name: "Apache License 2.0"},
+		{Key: gitprovider.LicenseTemplateMIT, Name: "MIT License"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListLicenseTemplates() = %v, want %v", got, want)
+	}
+}
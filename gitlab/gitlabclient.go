@@ -18,8 +18,14 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
@@ -33,20 +39,89 @@ type gitlabClient interface {
 	// Client returns the underlying *github.Client
 	Client() *gitlab.Client
 
+	// Token methods
+
+	// GetCurrentPersonalAccessToken is a wrapper for "GET /personal_access_tokens/self".
+	// This function handles HTTP error wrapping.
+	GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error)
+
+	// GetCurrentUser is a wrapper for "GET /user".
+	// This function handles HTTP error wrapping.
+	GetCurrentUser(ctx context.Context) (*gitlab.User, error)
+
 	// Group methods
 
 	// GetGroup is a wrapper for "GET /groups/{group}".
 	// This function HTTP error wrapping, and validates the server result.
 	GetGroup(ctx context.Context, groupID interface{}) (*gitlab.Group, error)
+	// GetInstanceDefaultBranch is a wrapper for "GET /application/settings", returning the
+	// default branch name of new projects. This requires administrator access.
+	// This function HTTP error wrapping.
+	GetInstanceDefaultBranch(ctx context.Context) (string, error)
+	// GetNamespace is a wrapper for "GET /namespaces/{namespace}".
+	// This function HTTP error wrapping.
+	GetNamespace(ctx context.Context, path string) (*gitlab.Namespace, error)
 	// ListGroups is a wrapper for "GET /groups".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroups(ctx context.Context) ([]*gitlab.Group, error)
 	// ListSubgroups is a wrapper for "GET /groups/{group}/subgroups".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListSubgroups(ctx context.Context, groupName string) ([]*gitlab.Group, error)
+	// ListDescendantGroups is a wrapper for "GET /groups/{group}/descendant_groups".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListDescendantGroups(ctx context.Context, groupName string) ([]*gitlab.Group, error)
+	// TransferSubgroup is a wrapper for "POST /groups/{group}/transfer".
+	// This function handles HTTP error wrapping, and validates the server result.
+	TransferSubgroup(ctx context.Context, groupID, parentID int) (*gitlab.Group, error)
 	// ListGroupMembers is a wrapper for "GET /groups/{group}/members".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error)
+	// GetInheritedGroupMember is a wrapper for "GET /groups/{group}/members/all/{user_id}",
+	// which includes members inherited from parent groups.
+	// This function handles HTTP error wrapping.
+	GetInheritedGroupMember(ctx context.Context, groupName string, userID int) (*gitlab.GroupMember, error)
+	// ListGroupIssueBoards is a wrapper for "GET /groups/{group}/boards".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupIssueBoards(ctx context.Context, groupName string) ([]*gitlab.GroupIssueBoard, error)
+	// ListGroupHooks is a wrapper for "GET /groups/{group}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupHooks(ctx context.Context, groupName string) ([]*gitlab.GroupHook, error)
+	// CreateGroupHook is a wrapper for "POST /groups/{group}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateGroupHook(ctx context.Context, groupName string, req *gitlab.AddGroupHookOptions) (*gitlab.GroupHook, error)
+	// UpdateGroupHook is a wrapper for "PUT /groups/{group}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateGroupHook(ctx context.Context, groupName string, hookID int, req *gitlab.EditGroupHookOptions) (*gitlab.GroupHook, error)
+	// DeleteGroupHook is a wrapper for "DELETE /groups/{group}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteGroupHook(ctx context.Context, groupName string, hookID int) error
+
+	// System hook methods
+
+	// ListSystemHooks is a wrapper for "GET /hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListSystemHooks(ctx context.Context) ([]*gitlab.Hook, error)
+	// CreateSystemHook is a wrapper for "POST /hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateSystemHook(ctx context.Context, req *gitlab.AddHookOptions) (*gitlab.Hook, error)
+	// DeleteSystemHook is a wrapper for "DELETE /hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteSystemHook(ctx context.Context, hookID int) error
+
+	// Runner methods
+
+	// ListGroupRunners is a wrapper for "GET /groups/{group}/runners".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupRunners(ctx context.Context, groupName string) ([]*gitlab.Runner, error)
+	// GetRunner is a wrapper for "GET /runners/{id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRunner(ctx context.Context, runnerID int) (*gitlab.RunnerDetails, error)
+	// ResetGroupRunnerRegistrationToken is a wrapper for "POST /groups/{group}/runners/reset_registration_token".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ResetGroupRunnerRegistrationToken(ctx context.Context, groupName string) (*gitlab.RunnerRegistrationToken, error)
+	// DeleteRunner is a wrapper for "DELETE /runners/{id}".
+	// This function handles HTTP error wrapping.
+	DeleteRunner(ctx context.Context, runnerID int) error
 
 	// Project methods
 
@@ -54,14 +129,31 @@ type gitlabClient interface {
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetGroupProject(ctx context.Context, groupName string, projectName string) (*gitlab.Project, error)
 	// ListGroupProjects is a wrapper for "GET /groups/{group}/projects".
+	// If progress is set, it is called after each page.
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
-	ListGroupProjects(ctx context.Context, groupName string) ([]*gitlab.Project, error)
+	ListGroupProjects(ctx context.Context, groupName string, progress gitprovider.ListProgressFunc) ([]*gitlab.Project, error)
+	// ListGroupProjectsPage is a wrapper for "GET /groups/{group}/projects", returning the given
+	// page along with the number of the next page, which is 0 for the last page.
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListGroupProjectsPage(ctx context.Context, groupName string, perPage, page int) ([]*gitlab.Project, int, error)
+	// GetProjectLanguages is a wrapper for "GET /projects/{project}/languages".
+	// This function handles HTTP error wrapping.
+	GetProjectLanguages(ctx context.Context, projectID int) (map[string]float32, error)
 	// GetProject is a wrapper for "GET /projects/{project}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetUserProject(ctx context.Context, projectName string) (*gitlab.Project, error)
 	// ListUserProjects is a wrapper for "GET /users/{username}/projects".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListUserProjects(ctx context.Context, username string) ([]*gitlab.Project, error)
+	// ListUserStarredProjects is a wrapper for "GET /users/{username}/starred_projects".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListUserStarredProjects(ctx context.Context, username string) ([]*gitlab.Project, error)
+	// ListLicenseTemplates is a wrapper for "GET /templates/licenses".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListLicenseTemplates(ctx context.Context) ([]*gitlab.LicenseTemplate, error)
+	// ListGitignoreTemplates is a wrapper for "GET /templates/gitignores".
+	// This function handles pagination, and HTTP error wrapping.
+	ListGitignoreTemplates(ctx context.Context) ([]*gitlab.GitIgnoreTemplateListItem, error)
 	// ListProjectUsers is a wrapper for "GET /projects/{project}/users".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListProjectUsers(ctx context.Context, projectName string) ([]*gitlab.ProjectUser, error)
@@ -73,14 +165,61 @@ type gitlabClient interface {
 	UpdateProject(ctx context.Context, req *gitlab.Project) (*gitlab.Project, error)
 	// DeleteProject is a wrapper for "DELETE /projects/{project}".
 	// This function handles HTTP error wrapping.
+	// GitLab deletes projects asynchronously, responding with 202 Accepted. If the
+	// WaitForCompletion call option is set, the project is polled until it's deleted, or marked
+	// for deletion on instances with delayed deletion.
 	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
 	DeleteProject(ctx context.Context, projectName string) error
+	// StarProject is a wrapper for "POST /projects/{project}/star".
+	// This function handles HTTP error wrapping, and treats an already starred project as success.
+	StarProject(ctx context.Context, projectName string) error
+	// UnstarProject is a wrapper for "POST /projects/{project}/unstar".
+	// This function handles HTTP error wrapping, and treats a project that isn't starred as success.
+	UnstarProject(ctx context.Context, projectName string) error
+	// IsProjectStarred is a wrapper for "GET /projects?starred=true", limited to the given project.
+	// This function handles HTTP error wrapping.
+	IsProjectStarred(ctx context.Context, projectID int) (bool, error)
+	// GetProjectNotificationSettings is a wrapper for "GET /projects/{project}/notification_settings".
+	// This function handles HTTP error wrapping.
+	GetProjectNotificationSettings(ctx context.Context, projectName string) (*gitlab.NotificationSettings, error)
+	// UpdateProjectNotificationLevel is a wrapper for "PUT /projects/{project}/notification_settings".
+	// This function handles HTTP error wrapping.
+	UpdateProjectNotificationLevel(ctx context.Context, projectName string, level gitlab.NotificationLevelValue) error
+	// GetProjectIssuesStatistics is a wrapper for "GET /projects/{project}/issues_statistics".
+	// This function handles HTTP error wrapping.
+	GetProjectIssuesStatistics(ctx context.Context, projectName string) (*gitlab.IssuesStatistics, error)
+	// TransferProject is a wrapper for "PUT /projects/{project}/transfer".
+	// This function handles HTTP error wrapping, and validates the server result.
+	TransferProject(ctx context.Context, projectName, namespace string) (*gitlab.Project, error)
+	// GetBranch is a wrapper for "GET /projects/{project}/repository/branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, projectName, branch string) (*gitlab.Branch, error)
+	// ListBranches is a wrapper for "GET /projects/{project}/repository/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, projectName string) ([]*gitlab.Branch, error)
+	// CreateBranch is a wrapper for "POST /projects/{project}/repository/branches".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateBranch(ctx context.Context, projectName, branch, ref string) (*gitlab.Branch, error)
+	// DeleteBranch is a wrapper for "DELETE /projects/{project}/repository/branches/{branch}".
+	// This function handles HTTP error wrapping.
+	DeleteBranch(ctx context.Context, projectName, branch string) error
+	// SetDefaultBranch is a wrapper for "PUT /projects/{project}", only setting the default branch.
+	// This function handles HTTP error wrapping, and validates the server result.
+	SetDefaultBranch(ctx context.Context, projectName, branch string) (*gitlab.Project, error)
+	// SetPipelineMustSucceed is a wrapper for "PUT /projects/{project}", only setting whether
+	// merge requests can only be merged if the pipeline succeeds.
+	// This function handles HTTP error wrapping, and validates the server result.
+	SetPipelineMustSucceed(ctx context.Context, projectName string, enabled bool) (*gitlab.Project, error)
+	// GetRawFile is a wrapper for "GET /projects/{project}/repository/files/{file_path}/raw".
+	// This function handles HTTP error wrapping, and streams the file instead of buffering it.
+	// The caller must close the returned reader.
+	GetRawFile(ctx context.Context, projectName, path, ref string) (io.ReadCloser, error)
 
 	// Deploy key methods
 
 	// ListKeys is a wrapper for "GET /projects/{project}/deploy_keys".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
-	ListKeys(projectName string) ([]*gitlab.ProjectDeployKey, error)
+	ListKeys(ctx context.Context, projectName string) ([]*projectDeployKey, error)
 	// CreateProjectKey is a wrapper for "POST /projects/{project}/deploy_keys".
 	// This function handles HTTP error wrapping, and validates the server result.
 	CreateKey(projectName string, req *gitlab.ProjectDeployKey) (*gitlab.ProjectDeployKey, error)
@@ -88,6 +227,126 @@ type gitlabClient interface {
 	// This function handles HTTP error wrapping.
 	DeleteKey(projectName string, keyID int) error
 
+	// Label methods
+
+	// ListLabels is a wrapper for "GET /projects/{project}/labels".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListLabels(ctx context.Context, projectName string) ([]*gitlab.Label, error)
+	// CreateLabel is a wrapper for "POST /projects/{project}/labels".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateLabel(ctx context.Context, projectName string, req *gitlab.CreateLabelOptions) (*gitlab.Label, error)
+	// UpdateLabel is a wrapper for "PUT /projects/{project}/labels".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateLabel(ctx context.Context, projectName string, req *gitlab.UpdateLabelOptions) (*gitlab.Label, error)
+	// DeleteLabel is a wrapper for "DELETE /projects/{project}/labels".
+	// This function handles HTTP error wrapping.
+	DeleteLabel(ctx context.Context, projectName, name string) error
+
+	// Webhook methods
+
+	// ListHooks is a wrapper for "GET /projects/{project}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error)
+	// CreateHook is a wrapper for "POST /projects/{project}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateHook(ctx context.Context, projectName string, req *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error)
+	// UpdateHook is a wrapper for "PUT /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateHook(ctx context.Context, projectName string, hookID int, req *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error)
+	// DeleteHook is a wrapper for "DELETE /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteHook(ctx context.Context, projectName string, hookID int) error
+	// ListHookEvents is a wrapper for "GET /projects/{project}/hooks/{hook_id}/events".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListHookEvents(ctx context.Context, projectName string, hookID int) ([]*projectHookEvent, error)
+	// ResendHookEvent is a wrapper for "POST /projects/{project}/hooks/{hook_id}/events/{hook_event_id}/resend".
+	// This function handles HTTP error wrapping.
+	ResendHookEvent(ctx context.Context, projectName string, hookID, eventID int) error
+	// TestHook is a wrapper for "POST /projects/{project}/hooks/{hook_id}/test/{trigger}".
+	// This function handles HTTP error wrapping.
+	TestHook(ctx context.Context, projectName string, hookID int, trigger string) error
+
+	// Protected branch methods
+
+	// ListProtectedBranches is a wrapper for "GET /projects/{project}/protected_branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProtectedBranches(ctx context.Context, projectName string) ([]*gitlab.ProtectedBranch, error)
+	// GetProtectedBranch is a wrapper for "GET /projects/{project}/protected_branches/{name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error)
+	// ProtectBranch is a wrapper for "POST /projects/{project}/protected_branches".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ProtectBranch(ctx context.Context, projectName string, req *gitlab.ProtectRepositoryBranchesOptions) (*gitlab.ProtectedBranch, error)
+	// GetProtectedBranchPushAccess is a wrapper for "GET /projects/{project}/protected_branches/{name}",
+	// returning the push access levels including their IDs, which go-gitlab doesn't decode.
+	// This function handles HTTP error wrapping.
+	GetProtectedBranchPushAccess(ctx context.Context, projectName, branch string) ([]*branchAccess, error)
+	// UpdateProtectedBranch is a wrapper for "PATCH /projects/{project}/protected_branches/{name}",
+	// which go-gitlab doesn't support yet. The settings which aren't given are kept.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateProtectedBranch(ctx context.Context, projectName, branch string, req *updateProtectedBranchOptions) (*gitlab.ProtectedBranch, error)
+	// UnprotectBranch is a wrapper for "DELETE /projects/{project}/protected_branches/{name}".
+	// This function handles HTTP error wrapping.
+	UnprotectBranch(ctx context.Context, projectName, branch string) error
+
+	// Protected tag methods
+
+	// ListProtectedTags is a wrapper for "GET /projects/{project}/protected_tags".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProtectedTags(ctx context.Context, projectName string) ([]*gitlab.ProtectedTag, error)
+	// GetProtectedTag is a wrapper for "GET /projects/{project}/protected_tags/{name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProtectedTag(ctx context.Context, projectName, pattern string) (*gitlab.ProtectedTag, error)
+	// ProtectTag is a wrapper for "POST /projects/{project}/protected_tags".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ProtectTag(ctx context.Context, projectName string, req *gitlab.ProtectRepositoryTagsOptions) (*gitlab.ProtectedTag, error)
+	// UnprotectTag is a wrapper for "DELETE /projects/{project}/protected_tags/{name}".
+	// This function handles HTTP error wrapping.
+	UnprotectTag(ctx context.Context, projectName, pattern string) error
+
+	// Milestone methods
+
+	// ListMilestones is a wrapper for "GET /projects/{project}/milestones".
+	// If state is nil, milestones of all states are listed.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListMilestones(ctx context.Context, projectName string, state *string) ([]*gitlab.Milestone, error)
+	// GetMilestoneIssuesStatistics is a wrapper for "GET /projects/{project}/issues_statistics",
+	// counting the issues of the milestone with the given title.
+	// This function handles HTTP error wrapping.
+	GetMilestoneIssuesStatistics(ctx context.Context, projectName, milestoneTitle string) (*gitlab.IssuesStatistics, error)
+
+	// Release methods
+
+	// GetRelease is a wrapper for "GET /projects/{project}/releases/{tag_name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRelease(ctx context.Context, projectName, tagName string) (*gitlab.Release, error)
+	// ListReleases is a wrapper for "GET /projects/{project}/releases".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReleases(ctx context.Context, projectName string) ([]*gitlab.Release, error)
+	// DownloadReleaseLink downloads the release asset at the given URL. The credentials of the
+	// client are only sent if the URL points to the GitLab instance.
+	// This function handles HTTP error wrapping. The caller must close the returned body.
+	DownloadReleaseLink(ctx context.Context, rawURL string) (io.ReadCloser, error)
+	// CreateReleaseLink is a wrapper for "POST /projects/{project}/releases/{tag_name}/assets/links".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateReleaseLink(ctx context.Context, projectName, tagName string, opts *gitlab.CreateReleaseLinkOptions) (*gitlab.ReleaseLink, error)
+	// PublishGenericPackageFile is a wrapper for
+	// "PUT /projects/{project}/packages/generic/{package_name}/{package_version}/{file_name}".
+	// This function handles HTTP error wrapping, and returns the URL of the uploaded file.
+	PublishGenericPackageFile(ctx context.Context, projectName, packageName, packageVersion, fileName string, content io.Reader) (string, error)
+
+	// Deploy token methods
+
+	// ListDeployTokens is a wrapper for "GET /projects/{project}/deploy_tokens".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListDeployTokens(ctx context.Context, projectName string) ([]*gitlab.DeployToken, error)
+	// CreateDeployToken is a wrapper for "POST /projects/{project}/deploy_tokens".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateDeployToken(ctx context.Context, projectName string, req *gitlab.CreateProjectDeployTokenOptions) (*gitlab.DeployToken, error)
+	// DeleteDeployToken is a wrapper for "DELETE /projects/{project}/deploy_tokens/{deploy_token_id}".
+	// This function handles HTTP error wrapping.
+	DeleteDeployToken(ctx context.Context, projectName string, tokenID int) error
+
 	// Team related methods
 
 	// ShareGroup is a wrapper for ""
@@ -101,7 +360,63 @@ type gitlabClient interface {
 
 	// ListCommitsPage is a wrapper for "GET /projects/{project}/repository/commits".
 	// This function handles pagination, HTTP error wrapping.
-	ListCommitsPage(projectName, branch string, perPage int, page int) ([]*gitlab.Commit, error)
+	ListCommitsPage(ctx context.Context, projectName, branch string, perPage int, page int) ([]*gitlab.Commit, error)
+	// ListCommitDiscussions is a wrapper for "GET /projects/{project}/repository/commits/{sha}/discussions".
+	// This function handles pagination, HTTP error wrapping.
+	ListCommitDiscussions(ctx context.Context, projectName, sha string) ([]*gitlab.Discussion, error)
+	// GetCommitGPGSignature is a wrapper for "GET /projects/{project}/repository/commits/{sha}/signature".
+	// This function handles HTTP error wrapping. ErrNotFound is returned if the commit isn't signed.
+	GetCommitGPGSignature(ctx context.Context, projectName, sha string) (*gitlab.GPGSignature, error)
+
+	// Award emoji methods
+
+	// ListMergeRequestAwardEmoji is a wrapper for "GET /projects/{project}/merge_requests/{merge_request_iid}/award_emoji".
+	// This function handles pagination, HTTP error wrapping.
+	ListMergeRequestAwardEmoji(ctx context.Context, projectID interface{}, mrIID int) ([]*gitlab.AwardEmoji, error)
+	// CreateMergeRequestAwardEmoji is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/award_emoji".
+	// This function handles HTTP error wrapping.
+	CreateMergeRequestAwardEmoji(ctx context.Context, projectID interface{}, mrIID int, name string) (*gitlab.AwardEmoji, error)
+
+	// Merge request review methods
+
+	// GetUserByUsername is a wrapper for "GET /users?username={username}".
+	// This function handles HTTP error wrapping, and returns ErrNotFound if there is no such user.
+	GetUserByUsername(ctx context.Context, username string) (*gitlab.User, error)
+	// GetUser is a wrapper for "GET /users/{id}".
+	// This function handles HTTP error wrapping.
+	GetUser(ctx context.Context, userID int) (*gitlab.User, error)
+	// SetMergeRequestReviewers is a wrapper for "PUT /projects/{project}/merge_requests/{merge_request_iid}",
+	// which sets the reviewers of the merge request to the users with the given IDs.
+	// This function handles HTTP error wrapping.
+	SetMergeRequestReviewers(ctx context.Context, projectID interface{}, mrIID int, userIDs []int) error
+	// GetMergeRequestApprovals is a wrapper for "GET /projects/{project}/merge_requests/{merge_request_iid}/approvals".
+	// This function handles HTTP error wrapping.
+	GetMergeRequestApprovals(ctx context.Context, projectID interface{}, mrIID int) (*gitlab.MergeRequestApprovals, error)
+	// ApproveMergeRequest is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/approve".
+	// This function handles HTTP error wrapping.
+	ApproveMergeRequest(ctx context.Context, projectID interface{}, mrIID int) (*gitlab.MergeRequestApprovals, error)
+	// UnapproveMergeRequest is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/unapprove".
+	// This function handles HTTP error wrapping.
+	UnapproveMergeRequest(ctx context.Context, projectID interface{}, mrIID int) error
+	// CreateMergeRequestNote is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/notes".
+	// This function handles HTTP error wrapping.
+	CreateMergeRequestNote(ctx context.Context, projectID interface{}, mrIID int, body string) error
+
+	// Merge request discussion methods
+
+	// ListMergeRequestDiscussions is a wrapper for "GET /projects/{project}/merge_requests/{merge_request_iid}/discussions".
+	// This function handles pagination, HTTP error wrapping.
+	ListMergeRequestDiscussions(ctx context.Context, projectID interface{}, mrIID int) ([]*gitlab.Discussion, error)
+	// CreateMergeRequestDiscussion is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/discussions".
+	// This function handles HTTP error wrapping.
+	CreateMergeRequestDiscussion(ctx context.Context, projectID interface{}, mrIID int, req *gitlab.CreateMergeRequestDiscussionOptions) (*gitlab.Discussion, error)
+	// AddMergeRequestDiscussionNote is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}/notes".
+	// This function handles HTTP error wrapping.
+	AddMergeRequestDiscussionNote(ctx context.Context, projectID interface{}, mrIID int, discussionID, body string) (*gitlab.Note, error)
+	// ResolveMergeRequestDiscussion is a wrapper for "PUT /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}",
+	// which marks the discussion as resolved.
+	// This function handles HTTP error wrapping.
+	ResolveMergeRequestDiscussion(ctx context.Context, projectID interface{}, mrIID int, discussionID string) error
 }
 
 // gitlabClientImpl is a wrapper around *gitlab.Client, which implements higher-level methods,
@@ -111,6 +426,9 @@ type gitlabClient interface {
 type gitlabClientImpl struct {
 	c                  *gitlab.Client
 	destructiveActions bool
+	// downloadClient requests the release links of external hosts, without the credentials
+	// of c. http.DefaultClient is used if nil.
+	downloadClient *http.Client
 }
 
 // gitlabClientImpl implements gitlabClient.
@@ -120,6 +438,29 @@ func (c *gitlabClientImpl) Client() *gitlab.Client {
 	return c.c
 }
 
+func (c *gitlabClientImpl) GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error) {
+	// go-gitlab doesn't support this endpoint yet
+	req, err := c.c.NewRequest(http.MethodGet, "personal_access_tokens/self", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	// GET /personal_access_tokens/self
+	apiObj := &gitlab.PersonalAccessToken{}
+	if _, err := c.c.Do(req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetCurrentUser(ctx context.Context) (*gitlab.User, error) {
+	// GET /user
+	apiObj, _, err := c.c.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *gitlabClientImpl) GetGroup(ctx context.Context, groupID interface{}) (*gitlab.Group, error) {
 	apiObj, _, err := c.c.Groups.GetGroup(groupID, nil, gitlab.WithContext(ctx))
 	if err != nil {
@@ -132,10 +473,28 @@ func (c *gitlabClientImpl) GetGroup(ctx context.Context, groupID interface{}) (*
 	return apiObj, nil
 }
 
+func (c *gitlabClientImpl) GetInstanceDefaultBranch(ctx context.Context) (string, error) {
+	// GET /application/settings
+	apiObj, _, err := c.c.Settings.GetSettings(gitlab.WithContext(ctx))
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	return apiObj.DefaultBranchName, nil
+}
+
+func (c *gitlabClientImpl) GetNamespace(ctx context.Context, path string) (*gitlab.Namespace, error) {
+	// GET /namespaces/{namespace}
+	apiObj, _, err := c.c.Namespaces.GetNamespace(path, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *gitlabClientImpl) ListGroups(ctx context.Context) ([]*gitlab.Group, error) {
 	apiObjs := []*gitlab.Group{}
 	opts := &gitlab.ListGroupsOptions{}
-	err := allGroupPages(opts, func() (*gitlab.Response, error) {
+	err := allGroupPages(ctx, opts, func() (*gitlab.Response, error) {
 		// GET /groups
 		pageObjs, resp, listErr := c.c.Groups.ListGroups(opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -156,7 +515,7 @@ func (c *gitlabClientImpl) ListGroups(ctx context.Context) ([]*gitlab.Group, err
 func (c *gitlabClientImpl) ListSubgroups(ctx context.Context, groupName string) ([]*gitlab.Group, error) {
 	var apiObjs []*gitlab.Group
 	opts := &gitlab.ListSubGroupsOptions{}
-	err := allSubgroupPages(opts, func() (*gitlab.Response, error) {
+	err := allSubgroupPages(ctx, opts, func() (*gitlab.Response, error) {
 		// GET /groups
 		pageObjs, resp, listErr := c.c.Groups.ListSubGroups(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -174,202 +533,1238 @@ func (c *gitlabClientImpl) ListSubgroups(ctx context.Context, groupName string)
 	return apiObjs, nil
 }
 
-func (c *gitlabClientImpl) GetGroupProject(ctx context.Context, groupName string, projectName string) (*gitlab.Project, error) {
-	opts := &gitlab.GetProjectOptions{}
-	apiObj, _, err := c.c.Projects.GetProject(fmt.Sprintf("%s/%s", strings.ToLower(groupName), projectName), opts, gitlab.WithContext(ctx))
-	return validateProjectAPIResp(apiObj, err)
-}
-
-func (c *gitlabClientImpl) ListGroupProjects(ctx context.Context, groupName string) ([]*gitlab.Project, error) {
-	var apiObjs []*gitlab.Project
-	opts := &gitlab.ListGroupProjectsOptions{}
-	err := allGroupProjectPages(opts, func() (*gitlab.Response, error) {
-		pageObjs, resp, listErr := c.c.Groups.ListGroupProjects(groupName, opts, gitlab.WithContext(ctx))
+func (c *gitlabClientImpl) ListDescendantGroups(ctx context.Context, groupName string) ([]*gitlab.Group, error) {
+	var apiObjs []*gitlab.Group
+	opts := &gitlab.ListDescendantGroupsOptions{}
+	err := allDescendantGroupPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /groups/{group}/descendant_groups
+		pageObjs, resp, listErr := c.c.Groups.ListDescendantGroups(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
 	})
 	if err != nil {
 		return nil, err
 	}
-	return validateProjectObjects(apiObjs)
-}
-
-func validateProjectObjects(apiObjs []*gitlab.Project) ([]*gitlab.Project, error) {
+	// Validate the API objects
 	for _, apiObj := range apiObjs {
-		// Make sure apiObj is valid
-		if err := validateProjectAPI(apiObj); err != nil {
+		if err := validateGroupAPI(apiObj); err != nil {
 			return nil, err
 		}
 	}
 	return apiObjs, nil
 }
 
-func (c *gitlabClientImpl) ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error) {
-	var apiObjs []*gitlab.GroupMember
-	opts := &gitlab.ListGroupMembersOptions{}
-	err := allGroupMemberPages(opts, func() (*gitlab.Response, error) {
-		// GET /groups/{group}/members
-		pageObjs, resp, listErr := c.c.Groups.ListGroupMembers(groupName, opts, gitlab.WithContext(ctx))
-		apiObjs = append(apiObjs, pageObjs...)
-		return resp, listErr
-	})
-	if err != nil {
-		return nil, err
-	}
-	return apiObjs, nil
-}
-
-func (c *gitlabClientImpl) GetUserProject(ctx context.Context, projectName string) (*gitlab.Project, error) {
-	opts := &gitlab.GetProjectOptions{}
-	apiObj, _, err := c.c.Projects.GetProject(projectName, opts, gitlab.WithContext(ctx))
-	return validateProjectAPIResp(apiObj, err)
-}
-
-func validateProjectAPIResp(apiObj *gitlab.Project, err error) (*gitlab.Project, error) {
-	// If the response contained an error, return
+func (c *gitlabClientImpl) TransferSubgroup(ctx context.Context, groupID, parentID int) (*gitlab.Group, error) {
+	opts := &gitlab.TransferSubGroupOptions{GroupID: &parentID}
+	// POST /groups/{group}/transfer
+	apiObj, _, err := c.c.Groups.TransferSubGroup(groupID, opts, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, handleHTTPError(err)
 	}
-	// Make sure apiObj is valid
-	if err := validateProjectAPI(apiObj); err != nil {
+	if err := validateGroupAPI(apiObj); err != nil {
 		return nil, err
 	}
 	return apiObj, nil
 }
 
-func (c *gitlabClientImpl) ListProjects(ctx context.Context) ([]*gitlab.Project, error) {
-	var apiObjs []*gitlab.Project
-	opts := &gitlab.ListProjectsOptions{}
-	err := allProjectPages(opts, func() (*gitlab.Response, error) {
-		// GET /projects
-		pageObjs, resp, listErr := c.c.Projects.ListProjects(opts, gitlab.WithContext(ctx))
-		apiObjs = append(apiObjs, pageObjs...)
-		return resp, listErr
-	})
+func (c *gitlabClientImpl) GetInheritedGroupMember(ctx context.Context, groupName string, userID int) (*gitlab.GroupMember, error) {
+	// GET /groups/{group}/members/all/{user_id}
+	// go-gitlab only supports getting direct members, hence the request is made manually
+	u := fmt.Sprintf("groups/%s/members/all/%d", gitlab.PathEscape(groupName), userID)
+	req, err := c.c.NewRequest(http.MethodGet, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
 	if err != nil {
 		return nil, err
 	}
-	return apiObjs, nil
+	apiObj := &gitlab.GroupMember{}
+	if _, err := c.c.Do(req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
 }
 
-func (c *gitlabClientImpl) ListProjectUsers(ctx context.Context, projectName string) ([]*gitlab.ProjectUser, error) {
-	var apiObjs []*gitlab.ProjectUser
-	opts := &gitlab.ListProjectUserOptions{}
-	err := allProjectUserPages(opts, func() (*gitlab.Response, error) {
-		// GET /projects/{project}/users
-		pageObjs, resp, listErr := c.c.Projects.ListProjectsUsers(projectName, opts, gitlab.WithContext(ctx))
+func (c *gitlabClientImpl) ListGroupIssueBoards(ctx context.Context, groupName string) ([]*gitlab.GroupIssueBoard, error) {
+	var apiObjs []*gitlab.GroupIssueBoard
+	opts := &gitlab.ListGroupIssueBoardsOptions{}
+	err := allGroupIssueBoardPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /groups/{group}/boards
+		pageObjs, resp, listErr := c.c.GroupIssueBoards.ListGroupIssueBoards(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
 	})
 	if err != nil {
 		return nil, err
 	}
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateGroupIssueBoardAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
 	return apiObjs, nil
 }
 
-func (c *gitlabClientImpl) ListUserProjects(ctx context.Context, username string) ([]*gitlab.Project, error) {
-	var apiObjs []*gitlab.Project
-	opts := &gitlab.ListProjectsOptions{}
-	err := allProjectPages(opts, func() (*gitlab.Response, error) {
-		// GET /projects/{project}/users
-		pageObjs, resp, listErr := c.c.Projects.ListUserProjects(username, opts, gitlab.WithContext(ctx))
+func (c *gitlabClientImpl) ListGroupHooks(ctx context.Context, groupName string) ([]*gitlab.GroupHook, error) {
+	apiObjs := []*gitlab.GroupHook{}
+	opts := &gitlab.ListGroupHooksOptions{}
+	err := allGroupHookPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /groups/{group}/hooks
+		pageObjs, resp, listErr := c.c.Groups.ListGroupHooks(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
 	})
 	if err != nil {
 		return nil, err
 	}
-	return apiObjs, nil
-}
-
-func (c *gitlabClientImpl) CreateProject(ctx context.Context, req *gitlab.Project, extraOpts *gitlab.CreateProjectOptions) (*gitlab.Project, error) {
-	var namespaceID int
-	// If the project doesn't belong to a user set its namespace ID
-	if req.Namespace != nil && req.Namespace.Kind != "user" {
-		group, err := c.GetGroup(ctx, req.Namespace.Name)
-		if err != nil {
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateGroupHookAPI(apiObj); err != nil {
 			return nil, err
 		}
-		namespaceID = group.ID
 	}
+	return apiObjs, nil
+}
 
-	opts := extraOpts
-	if opts == nil {
-		opts = &gitlab.CreateProjectOptions{}
+func (c *gitlabClientImpl) CreateGroupHook(ctx context.Context, groupName string, req *gitlab.AddGroupHookOptions) (*gitlab.GroupHook, error) {
+	// POST /groups/{group}/hooks
+	apiObj, _, err := c.c.Groups.AddGroupHook(groupName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
 	}
-	opts.Name = &req.Name
-	opts.DefaultBranch = &req.DefaultBranch
-	opts.Description = &req.Description
-	opts.Visibility = &req.Visibility
-	if namespaceID != 0 {
-		opts.NamespaceID = &namespaceID
+	if err := validateGroupHookAPI(apiObj); err != nil {
+		return nil, err
 	}
-
-	apiObj, _, err := c.c.Projects.CreateProject(opts, gitlab.WithContext(ctx))
-	return validateProjectAPIResp(apiObj, err)
+	return apiObj, nil
 }
 
-func (c *gitlabClientImpl) UpdateProject(ctx context.Context, req *gitlab.Project) (*gitlab.Project, error) {
-	opts := &gitlab.EditProjectOptions{
-		Name:        &req.Name,
-		Description: &req.Description,
-		Visibility:  &req.Visibility,
+func (c *gitlabClientImpl) UpdateGroupHook(ctx context.Context, groupName string, hookID int, req *gitlab.EditGroupHookOptions) (*gitlab.GroupHook, error) {
+	// PUT /groups/{group}/hooks/{hook_id}
+	apiObj, _, err := c.c.Groups.EditGroupHook(groupName, hookID, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
 	}
-	apiObj, _, err := c.c.Projects.EditProject(req.ID, opts, gitlab.WithContext(ctx))
-	return validateProjectAPIResp(apiObj, err)
+	if err := validateGroupHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
 }
 
-func (c *gitlabClientImpl) DeleteProject(ctx context.Context, projectName string) error {
-	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
-	if !c.destructiveActions {
-		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
-	}
-	// DELETE /projects/{project}
-	_, err := c.c.Projects.DeleteProject(projectName, gitlab.WithContext(ctx))
-	return err
+func (c *gitlabClientImpl) DeleteGroupHook(ctx context.Context, groupName string, hookID int) error {
+	// DELETE /groups/{group}/hooks/{hook_id}
+	_, err := c.c.Groups.DeleteGroupHook(groupName, hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
 }
 
-func (c *gitlabClientImpl) ListKeys(projectName string) ([]*gitlab.ProjectDeployKey, error) {
-	apiObjs := []*gitlab.ProjectDeployKey{}
-	opts := &gitlab.ListProjectDeployKeysOptions{}
-	err := allDeployKeyPages(opts, func() (*gitlab.Response, error) {
-		// GET /projects/{project}/deploy_keys
-		pageObjs, resp, listErr := c.c.DeployKeys.ListProjectDeployKeys(projectName, opts)
-		apiObjs = append(apiObjs, pageObjs...)
-		return resp, listErr
-	})
+func (c *gitlabClientImpl) ListSystemHooks(ctx context.Context) ([]*gitlab.Hook, error) {
+	// GET /hooks
+	apiObjs, _, err := c.c.SystemHooks.ListHooks(gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
-
+	// Validate the API objects
 	for _, apiObj := range apiObjs {
-		if err := validateDeployKeyAPI(apiObj); err != nil {
+		if err := validateSystemHookAPI(apiObj); err != nil {
 			return nil, err
 		}
 	}
 	return apiObjs, nil
 }
 
-func (c *gitlabClientImpl) CreateKey(projectName string, req *gitlab.ProjectDeployKey) (*gitlab.ProjectDeployKey, error) {
-	opts := &gitlab.AddDeployKeyOptions{
-		Title:   &req.Title,
-		Key:     &req.Key,
-		CanPush: &req.CanPush,
+func (c *gitlabClientImpl) CreateSystemHook(ctx context.Context, req *gitlab.AddHookOptions) (*gitlab.Hook, error) {
+	// POST /hooks
+	apiObj, _, err := c.c.SystemHooks.AddHook(req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateSystemHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteSystemHook(ctx context.Context, hookID int) error {
+	// DELETE /hooks/{hook_id}
+	_, err := c.c.SystemHooks.DeleteHook(hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListGroupRunners(ctx context.Context, groupName string) ([]*gitlab.Runner, error) {
+	var apiObjs []*gitlab.Runner
+	opts := &gitlab.ListGroupsRunnersOptions{}
+	err := allGroupRunnerPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /groups/{group}/runners
+		pageObjs, resp, listErr := c.c.Runners.ListGroupsRunners(groupName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateRunnerAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetRunner(ctx context.Context, runnerID int) (*gitlab.RunnerDetails, error) {
+	// GET /runners/{id}
+	apiObj, _, err := c.c.Runners.GetRunnerDetails(runnerID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ResetGroupRunnerRegistrationToken(ctx context.Context, groupName string) (*gitlab.RunnerRegistrationToken, error) {
+	// POST /groups/{group}/runners/reset_registration_token
+	apiObj, _, err := c.c.Runners.ResetGroupRunnerRegistrationToken(groupName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the token is set. Don't include apiObj in the error, as it's a secret.
+	if apiObj.Token == nil {
+		return nil, fmt.Errorf("didn't expect registration token to be nil: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteRunner(ctx context.Context, runnerID int) error {
+	// DELETE /runners/{id}
+	_, err := c.c.Runners.RemoveRunner(runnerID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) GetGroupProject(ctx context.Context, groupName string, projectName string) (*gitlab.Project, error) {
+	opts := &gitlab.GetProjectOptions{}
+	apiObj, _, err := c.c.Projects.GetProject(fmt.Sprintf("%s/%s", strings.ToLower(groupName), projectName), opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) ListGroupProjects(ctx context.Context, groupName string, progress gitprovider.ListProgressFunc) ([]*gitlab.Project, error) {
+	var apiObjs []*gitlab.Project
+	opts := &gitlab.ListGroupProjectsOptions{}
+	err := allGroupProjectPages(ctx, opts, func() (*gitlab.Response, error) {
+		pageObjs, resp, listErr := c.c.Groups.ListGroupProjects(groupName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		if listErr == nil && progress != nil {
+			progress(listProgress(resp, len(apiObjs)))
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateProjectObjects(apiObjs)
+}
+
+func (c *gitlabClientImpl) ListGroupProjectsPage(ctx context.Context, groupName string, perPage, page int) ([]*gitlab.Project, int, error) {
+	opts := &gitlab.ListGroupProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: gitprovider.CallOptionsFromContext(ctx).GetPerPage(perPage),
+			Page:    page,
+		},
+	}
+	// GET /groups/{group}/projects
+	apiObjs, resp, err := c.c.Groups.ListGroupProjects(groupName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, 0, handleHTTPError(err)
+	}
+	apiObjs, err = validateProjectObjects(apiObjs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return apiObjs, resp.NextPage, nil
+}
+
+func (c *gitlabClientImpl) GetProjectLanguages(ctx context.Context, projectID int) (map[string]float32, error) {
+	// GET /projects/{project}/languages
+	apiObj, _, err := c.c.Projects.GetProjectLanguages(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if apiObj == nil {
+		return nil, nil
+	}
+	return *apiObj, nil
+}
+
+func validateProjectObjects(apiObjs []*gitlab.Project) ([]*gitlab.Project, error) {
+	for _, apiObj := range apiObjs {
+		// Make sure apiObj is valid
+		if err := validateProjectAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error) {
+	var apiObjs []*gitlab.GroupMember
+	opts := &gitlab.ListGroupMembersOptions{}
+	err := allGroupMemberPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /groups/{group}/members
+		pageObjs, resp, listErr := c.c.Groups.ListGroupMembers(groupName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetUserProject(ctx context.Context, projectName string) (*gitlab.Project, error) {
+	opts := &gitlab.GetProjectOptions{}
+	apiObj, _, err := c.c.Projects.GetProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func validateProjectAPIResp(apiObj *gitlab.Project, err error) (*gitlab.Project, error) {
+	// If the response contained an error, return
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure apiObj is valid
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListProjects(ctx context.Context) ([]*gitlab.Project, error) {
+	var apiObjs []*gitlab.Project
+	opts := &gitlab.ListProjectsOptions{}
+	err := allProjectPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects
+		pageObjs, resp, listErr := c.c.Projects.ListProjects(opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListProjectUsers(ctx context.Context, projectName string) ([]*gitlab.ProjectUser, error) {
+	var apiObjs []*gitlab.ProjectUser
+	opts := &gitlab.ListProjectUserOptions{}
+	err := allProjectUserPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/users
+		pageObjs, resp, listErr := c.c.Projects.ListProjectsUsers(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListUserProjects(ctx context.Context, username string) ([]*gitlab.Project, error) {
+	var apiObjs []*gitlab.Project
+	opts := &gitlab.ListProjectsOptions{}
+	err := allProjectPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/users
+		pageObjs, resp, listErr := c.c.Projects.ListUserProjects(username, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListUserStarredProjects(ctx context.Context, username string) ([]*gitlab.Project, error) {
+	var apiObjs []*gitlab.Project
+	opts := &gitlab.ListProjectsOptions{}
+	err := allProjectPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /users/{username}/starred_projects
+		pageObjs, resp, listErr := c.c.Projects.ListUserStarredProjects(username, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateStarredProjectAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListLicenseTemplates(ctx context.Context) ([]*gitlab.LicenseTemplate, error) {
+	var apiObjs []*gitlab.LicenseTemplate
+	opts := &gitlab.ListLicenseTemplatesOptions{}
+	err := allLicenseTemplatePages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /templates/licenses
+		pageObjs, resp, listErr := c.c.LicenseTemplates.ListLicenseTemplates(opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateLicenseTemplateAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListGitignoreTemplates(ctx context.Context) ([]*gitlab.GitIgnoreTemplateListItem, error) {
+	var apiObjs []*gitlab.GitIgnoreTemplateListItem
+	opts := &gitlab.ListTemplatesOptions{}
+	err := allTemplatePages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /templates/gitignores
+		pageObjs, resp, listErr := c.c.GitIgnoreTemplates.ListTemplates(opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateProject(ctx context.Context, req *gitlab.Project, extraOpts *gitlab.CreateProjectOptions) (*gitlab.Project, error) {
+	var namespaceID int
+	// If the project doesn't belong to a user set its namespace ID
+	if req.Namespace != nil && req.Namespace.Kind != "user" {
+		group, err := c.getGroupByPath(ctx, req.Namespace.FullPath)
+		if err != nil {
+			return nil, err
+		}
+		namespaceID = group.ID
+	}
+
+	opts := extraOpts
+	if opts == nil {
+		opts = &gitlab.CreateProjectOptions{}
+	}
+	opts.Name = &req.Name
+	opts.DefaultBranch = &req.DefaultBranch
+	opts.Description = &req.Description
+	opts.Visibility = &req.Visibility
+	if namespaceID != 0 {
+		opts.NamespaceID = &namespaceID
+	}
+
+	apiObj, _, err := c.c.Projects.CreateProject(opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+// getGroupByPath returns the group with the given full path, e.g. "group/subgroup". If the group
+// doesn't exist, the error tells the first group in the path that doesn't exist.
+func (c *gitlabClientImpl) getGroupByPath(ctx context.Context, path string) (*gitlab.Group, error) {
+	// GET /groups/{group}
+	group, err := c.GetGroup(ctx, path)
+	if err == nil {
+		return group, nil
+	}
+	if err = handleHTTPError(err); !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	// Look for the first missing group, starting at the top-level group
+	segments := strings.Split(path, "/")
+	for i := range segments[:len(segments)-1] {
+		parent := strings.Join(segments[:i+1], "/")
+		// GET /groups/{group}
+		if _, parentErr := c.GetGroup(ctx, parent); parentErr != nil {
+			if parentErr = handleHTTPError(parentErr); errors.Is(parentErr, gitprovider.ErrNotFound) {
+				return nil, fmt.Errorf("group %q of %q doesn't exist: %w", parent, path, gitprovider.ErrGroupNotFound)
+			}
+			return nil, parentErr
+		}
+	}
+	return nil, fmt.Errorf("group %q doesn't exist: %w", path, gitprovider.ErrGroupNotFound)
+}
+
+func (c *gitlabClientImpl) UpdateProject(ctx context.Context, req *gitlab.Project) (*gitlab.Project, error) {
+	opts := &gitlab.EditProjectOptions{
+		Name:                         &req.Name,
+		Description:                  &req.Description,
+		Visibility:                   &req.Visibility,
+		RemoveSourceBranchAfterMerge: &req.RemoveSourceBranchAfterMerge,
+	}
+	// The default branch can only be set to an existing branch, hence don't reset it
+	if req.DefaultBranch != "" {
+		opts.DefaultBranch = &req.DefaultBranch
+	}
+	apiObj, _, err := c.c.Projects.EditProject(req.ID, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) GetBranch(ctx context.Context, projectName, branch string) (*gitlab.Branch, error) {
+	// GET /projects/{project}/repository/branches/{branch}
+	apiObj, _, err := c.c.Branches.GetBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the branch points to a commit
+	if apiObj.Commit == nil {
+		return nil, fmt.Errorf("didn't expect branch commit to be nil: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListBranches(ctx context.Context, projectName string) ([]*gitlab.Branch, error) {
+	apiObjs := []*gitlab.Branch{}
+	opts := &gitlab.ListBranchesOptions{}
+	err := allBranchPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/branches
+		pageObjs, resp, listErr := c.c.Branches.ListBranches(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		// Make sure the branch points to a commit
+		if apiObj.Commit == nil {
+			return nil, fmt.Errorf("didn't expect branch commit to be nil: %w", gitprovider.ErrInvalidServerData)
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateBranch(ctx context.Context, projectName, branch, ref string) (*gitlab.Branch, error) {
+	opts := &gitlab.CreateBranchOptions{
+		Branch: &branch,
+		Ref:    &ref,
+	}
+	// POST /projects/{project}/repository/branches
+	apiObj, _, err := c.c.Branches.CreateBranch(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the branch points to a commit
+	if apiObj.Commit == nil {
+		return nil, fmt.Errorf("didn't expect branch commit to be nil: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteBranch(ctx context.Context, projectName, branch string) error {
+	// DELETE /projects/{project}/repository/branches/{branch}
+	_, err := c.c.Branches.DeleteBranch(projectName, branch, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) SetDefaultBranch(ctx context.Context, projectName, branch string) (*gitlab.Project, error) {
+	opts := &gitlab.EditProjectOptions{
+		DefaultBranch: &branch,
+	}
+	// PUT /projects/{project}
+	apiObj, _, err := c.c.Projects.EditProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) SetPipelineMustSucceed(ctx context.Context, projectName string, enabled bool) (*gitlab.Project, error) {
+	opts := &gitlab.EditProjectOptions{
+		OnlyAllowMergeIfPipelineSucceeds: &enabled,
+	}
+	// PUT /projects/{project}
+	apiObj, _, err := c.c.Projects.EditProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) GetRawFile(ctx context.Context, projectName, path, ref string) (io.ReadCloser, error) {
+	// GET /projects/{project}/repository/files/{file_path}/raw
+	u := fmt.Sprintf("projects/%s/repository/files/%s/raw", gitlab.PathEscape(projectName), gitlab.PathEscape(path))
+	opts := &gitlab.GetRawFileOptions{}
+	if ref != "" {
+		opts.Ref = &ref
+	}
+	req, err := c.c.NewRequest(http.MethodGet, u, opts, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	return streamBody(func(w io.Writer) error {
+		_, err := c.c.Do(req, w)
+		return err
+	})
+}
+
+// streamBody returns a reader streaming the body written by do, which is expected to make an
+// HTTP request using go-gitlab. go-gitlab only streams the body into an io.Writer, so pipe it
+// through to the caller. The status is checked before anything is written, so wait for either
+// the first write or the request to finish in order to return HTTP errors synchronously.
+func streamBody(do func(w io.Writer) error) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	w := &firstWriteNotifier{w: pw, started: make(chan struct{})}
+	done := make(chan error, 1)
+	go func() {
+		err := handleHTTPError(do(w))
+		// A nil error closes the pipe with io.EOF
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	select {
+	case <-w.started:
+		return pr, nil
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		// The body was empty
+		return pr, nil
+	}
+}
+
+// firstWriteNotifier closes started right before the first write to w.
+type firstWriteNotifier struct {
+	w       io.Writer
+	once    sync.Once
+	started chan struct{}
+}
+
+func (n *firstWriteNotifier) Write(p []byte) (int, error) {
+	n.once.Do(func() { close(n.started) })
+	return n.w.Write(p)
+}
+
+func (c *gitlabClientImpl) DeleteProject(ctx context.Context, projectName string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /projects/{project}
+	resp, err := c.c.Projects.DeleteProject(projectName, gitlab.WithContext(ctx))
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	if resp.StatusCode != http.StatusAccepted || !gitprovider.CallOptionsFromContext(ctx).ShouldWaitForCompletion() {
+		return nil
+	}
+	return gitprovider.PollUntilDone(ctx, func(ctx context.Context) (bool, error) {
+		// GET /projects/{project}
+		apiObj, _, err := c.c.Projects.GetProject(projectName, &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			if err = handleHTTPError(err); errors.Is(err, gitprovider.ErrNotFound) {
+				return true, nil
+			}
+			return false, err
+		}
+		// Instances with delayed deletion keep the project around, marked for deletion
+		return apiObj.MarkedForDeletionAt != nil, nil
+	})
+}
+
+func (c *gitlabClientImpl) StarProject(ctx context.Context, projectName string) error {
+	// POST /projects/{project}/star
+	_, resp, err := c.c.Projects.StarProject(projectName, gitlab.WithContext(ctx))
+	// GitLab responds with 304 Not Modified and an empty body if the project is already starred
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) UnstarProject(ctx context.Context, projectName string) error {
+	// POST /projects/{project}/unstar
+	_, resp, err := c.c.Projects.UnstarProject(projectName, gitlab.WithContext(ctx))
+	// GitLab responds with 304 Not Modified and an empty body if the project isn't starred
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) IsProjectStarred(ctx context.Context, projectID int) (bool, error) {
+	// GET /projects?starred=true&id_after={id-1}&id_before={id+1}
+	// There is no endpoint telling whether a project is starred, hence list the starred
+	// projects of the authenticated user, narrowed down to the given project.
+	opts := &gitlab.ListProjectsOptions{
+		Starred:  gitlab.Bool(true),
+		IDAfter:  gitlab.Int(projectID - 1),
+		IDBefore: gitlab.Int(projectID + 1),
+	}
+	apiObjs, _, err := c.c.Projects.ListProjects(opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return false, handleHTTPError(err)
+	}
+	return len(apiObjs) != 0, nil
+}
+
+func (c *gitlabClientImpl) GetProjectNotificationSettings(ctx context.Context, projectName string) (*gitlab.NotificationSettings, error) {
+	// GET /projects/{project}/notification_settings
+	apiObj, _, err := c.c.NotificationSettings.GetSettingsForProject(projectName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateProjectNotificationLevel(ctx context.Context, projectName string, level gitlab.NotificationLevelValue) error {
+	// PUT /projects/{project}/notification_settings
+	opts := &gitlab.NotificationSettingsOptions{Level: &level}
+	_, _, err := c.c.NotificationSettings.UpdateSettingsForProject(projectName, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) GetProjectIssuesStatistics(ctx context.Context, projectName string) (*gitlab.IssuesStatistics, error) {
+	// GET /projects/{project}/issues_statistics
+	apiObj, _, err := c.c.IssuesStatistics.GetProjectIssuesStatistics(projectName, &gitlab.GetProjectIssuesStatisticsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) TransferProject(ctx context.Context, projectName, namespace string) (*gitlab.Project, error) {
+	opts := &gitlab.TransferProjectOptions{Namespace: namespace}
+	// PUT /projects/{project}/transfer
+	apiObj, _, err := c.c.Projects.TransferProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) ListKeys(ctx context.Context, projectName string) ([]*projectDeployKey, error) {
+	apiObjs := []*projectDeployKey{}
+	opts := &gitlab.ListProjectDeployKeysOptions{}
+	err := allDeployKeyPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/deploy_keys
+		// go-gitlab doesn't decode last_used_at, hence the request is made manually
+		u := fmt.Sprintf("projects/%s/deploy_keys", gitlab.PathEscape(projectName))
+		req, err := c.c.NewRequest(http.MethodGet, u, opts, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		var pageObjs []*projectDeployKey
+		resp, listErr := c.c.Do(req, &pageObjs)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(&apiObj.ProjectDeployKey); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateKey(projectName string, req *gitlab.ProjectDeployKey) (*gitlab.ProjectDeployKey, error) {
+	opts := &gitlab.AddDeployKeyOptions{
+		Title:   &req.Title,
+		Key:     &req.Key,
+		CanPush: &req.CanPush,
 	}
 	// POST /projects/{project}/deploy_keys
 	apiObj, _, err := c.c.DeployKeys.AddDeployKey(projectName, opts)
 	if err != nil {
 		return nil, handleHTTPError(err)
 	}
-	if err := validateDeployKeyAPI(apiObj); err != nil {
+	if err := validateDeployKeyAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteKey(projectName string, keyID int) error {
+	// DELETE /projects/{project}/deploy_keys
+	_, err := c.c.DeployKeys.DeleteDeployKey(projectName, keyID)
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListLabels(ctx context.Context, projectName string) ([]*gitlab.Label, error) {
+	apiObjs := []*gitlab.Label{}
+	opts := &gitlab.ListLabelsOptions{}
+	err := allLabelPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/labels
+		pageObjs, resp, listErr := c.c.Labels.ListLabels(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateLabelAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateLabel(ctx context.Context, projectName string, req *gitlab.CreateLabelOptions) (*gitlab.Label, error) {
+	// POST /projects/{project}/labels
+	apiObj, _, err := c.c.Labels.CreateLabel(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateLabelAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateLabel(ctx context.Context, projectName string, req *gitlab.UpdateLabelOptions) (*gitlab.Label, error) {
+	// PUT /projects/{project}/labels
+	apiObj, _, err := c.c.Labels.UpdateLabel(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateLabelAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteLabel(ctx context.Context, projectName, name string) error {
+	// DELETE /projects/{project}/labels
+	_, err := c.c.Labels.DeleteLabel(projectName, &gitlab.DeleteLabelOptions{Name: &name}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error) {
+	apiObjs := []*gitlab.ProjectHook{}
+	opts := &gitlab.ListProjectHooksOptions{}
+	err := allProjectHookPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/hooks
+		pageObjs, resp, listErr := c.c.Projects.ListProjectHooks(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateHook(ctx context.Context, projectName string, req *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// POST /projects/{project}/hooks
+	apiObj, _, err := c.c.Projects.AddProjectHook(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateHook(ctx context.Context, projectName string, hookID int, req *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, _, err := c.c.Projects.EditProjectHook(projectName, hookID, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteHook(ctx context.Context, projectName string, hookID int) error {
+	// DELETE /projects/{project}/hooks/{hook_id}
+	_, err := c.c.Projects.DeleteProjectHook(projectName, hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListHookEvents(ctx context.Context, projectName string, hookID int) ([]*projectHookEvent, error) {
+	apiObjs := []*projectHookEvent{}
+	opts := &gitlab.ListOptions{}
+	err := allHookEventPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/hooks/{hook_id}/events
+		// go-gitlab doesn't support the webhook events, hence the request is made manually
+		u := fmt.Sprintf("projects/%s/hooks/%d/events", gitlab.PathEscape(projectName), hookID)
+		req, err := c.c.NewRequest(http.MethodGet, u, opts, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		var pageObjs []*projectHookEvent
+		resp, listErr := c.c.Do(req, &pageObjs)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookEventAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ResendHookEvent(ctx context.Context, projectName string, hookID, eventID int) error {
+	// POST /projects/{project}/hooks/{hook_id}/events/{hook_event_id}/resend
+	u := fmt.Sprintf("projects/%s/hooks/%d/events/%d/resend", gitlab.PathEscape(projectName), hookID, eventID)
+	req, err := c.c.NewRequest(http.MethodPost, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	_, err = c.c.Do(req, nil)
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) TestHook(ctx context.Context, projectName string, hookID int, trigger string) error {
+	// POST /projects/{project}/hooks/{hook_id}/test/{trigger}
+	u := fmt.Sprintf("projects/%s/hooks/%d/test/%s", gitlab.PathEscape(projectName), hookID, trigger)
+	req, err := c.c.NewRequest(http.MethodPost, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	_, err = c.c.Do(req, nil)
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListProtectedBranches(ctx context.Context, projectName string) ([]*gitlab.ProtectedBranch, error) {
+	apiObjs := []*gitlab.ProtectedBranch{}
+	opts := &gitlab.ListProtectedBranchesOptions{}
+	err := allProtectedBranchPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/protected_branches
+		pageObjs, resp, listErr := c.c.ProtectedBranches.ListProtectedBranches(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProtectedBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error) {
+	// GET /projects/{project}/protected_branches/{name}
+	apiObj, _, err := c.c.ProtectedBranches.GetProtectedBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ProtectBranch(ctx context.Context, projectName string, req *gitlab.ProtectRepositoryBranchesOptions) (*gitlab.ProtectedBranch, error) {
+	// POST /projects/{project}/protected_branches
+	apiObj, _, err := c.c.ProtectedBranches.ProtectRepositoryBranches(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedBranchAPI(apiObj); err != nil {
 		return nil, err
 	}
 	return apiObj, nil
 }
 
-func (c *gitlabClientImpl) DeleteKey(projectName string, keyID int) error {
-	// DELETE /projects/{project}/deploy_keys
-	_, err := c.c.DeployKeys.DeleteDeployKey(projectName, keyID)
+func (c *gitlabClientImpl) GetProtectedBranchPushAccess(ctx context.Context, projectName, branch string) ([]*branchAccess, error) {
+	// GET /projects/{project}/protected_branches/{name}
+	// go-gitlab doesn't decode the IDs of the access levels, hence the request is made manually
+	u := fmt.Sprintf("projects/%s/protected_branches/%s", gitlab.PathEscape(projectName), url.PathEscape(branch))
+	req, err := c.c.NewRequest(http.MethodGet, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	var apiObj struct {
+		PushAccessLevels []*branchAccess `json:"push_access_levels"`
+	}
+	if _, err := c.c.Do(req, &apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj.PushAccessLevels, nil
+}
+
+func (c *gitlabClientImpl) UpdateProtectedBranch(ctx context.Context, projectName, branch string, opts *updateProtectedBranchOptions) (*gitlab.ProtectedBranch, error) {
+	// PATCH /projects/{project}/protected_branches/{name}
+	// go-gitlab would encode the options of a PATCH request into the query, hence the JSON body
+	// is set manually
+	u := fmt.Sprintf("projects/%s/protected_branches/%s", gitlab.PathEscape(projectName), url.PathEscape(branch))
+	req, err := c.c.NewRequest(http.MethodPatch, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.SetBody(body); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	apiObj := &gitlab.ProtectedBranch{}
+	if _, err := c.c.Do(req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UnprotectBranch(ctx context.Context, projectName, branch string) error {
+	// DELETE /projects/{project}/protected_branches/{name}
+	_, err := c.c.ProtectedBranches.UnprotectRepositoryBranches(projectName, branch, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListProtectedTags(ctx context.Context, projectName string) ([]*gitlab.ProtectedTag, error) {
+	apiObjs := []*gitlab.ProtectedTag{}
+	opts := &gitlab.ListProtectedTagsOptions{}
+	err := allProtectedTagPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/protected_tags
+		pageObjs, resp, listErr := c.c.ProtectedTags.ListProtectedTags(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProtectedTagAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetProtectedTag(ctx context.Context, projectName, pattern string) (*gitlab.ProtectedTag, error) {
+	// GET /projects/{project}/protected_tags/{name}
+	apiObj, _, err := c.c.ProtectedTags.GetProtectedTag(projectName, pattern, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ProtectTag(ctx context.Context, projectName string, req *gitlab.ProtectRepositoryTagsOptions) (*gitlab.ProtectedTag, error) {
+	// POST /projects/{project}/protected_tags
+	apiObj, _, err := c.c.ProtectedTags.ProtectRepositoryTags(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UnprotectTag(ctx context.Context, projectName, pattern string) error {
+	// DELETE /projects/{project}/protected_tags/{name}
+	_, err := c.c.ProtectedTags.UnprotectRepositoryTags(projectName, pattern, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListMilestones(ctx context.Context, projectName string, state *string) ([]*gitlab.Milestone, error) {
+	apiObjs := []*gitlab.Milestone{}
+	opts := &gitlab.ListMilestonesOptions{State: state}
+	err := allMilestonePages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/milestones
+		pageObjs, resp, listErr := c.c.Milestones.ListMilestones(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateMilestoneAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetMilestoneIssuesStatistics(ctx context.Context, projectName, milestoneTitle string) (*gitlab.IssuesStatistics, error) {
+	// go-gitlab's GetProjectIssuesStatisticsOptions takes a *gitlab.Milestone instead of the
+	// title of the milestone, hence build the request by hand.
+	opts := &struct {
+		Milestone string `url:"milestone"`
+	}{Milestone: milestoneTitle}
+	u := fmt.Sprintf("projects/%s/issues_statistics", gitlab.PathEscape(projectName))
+	req, err := c.c.NewRequest(http.MethodGet, u, opts, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	// GET /projects/{project}/issues_statistics
+	apiObj := &gitlab.IssuesStatistics{}
+	if _, err := c.c.Do(req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetRelease(ctx context.Context, projectName, tagName string) (*gitlab.Release, error) {
+	// GET /projects/{project}/releases/{tag_name}
+	apiObj, _, err := c.c.Releases.GetRelease(projectName, tagName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListReleases(ctx context.Context, projectName string) ([]*gitlab.Release, error) {
+	apiObjs := []*gitlab.Release{}
+	opts := &gitlab.ListReleasesOptions{}
+	err := allReleasePages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/releases
+		pageObjs, resp, listErr := c.c.Releases.ListReleases(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReleaseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) DownloadReleaseLink(ctx context.Context, rawURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release link URL %q: %w", rawURL, gitprovider.ErrInvalidServerData)
+	}
+
+	// Don't send the credentials to external hosts
+	if u.Host != c.c.BaseURL().Host {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		downloadClient := c.downloadClient
+		if downloadClient == nil {
+			downloadClient = http.DefaultClient
+		}
+		resp, err := downloadClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		// Let go-gitlab turn error responses into a *gitlab.ErrorResponse
+		if err := gitlab.CheckResponse(resp); err != nil {
+			resp.Body.Close()
+			return nil, handleHTTPError(err)
+		}
+		return resp.Body, nil
+	}
+
+	req, err := c.c.NewRequest(http.MethodGet, "", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	req.URL = u
+	// The asset isn't a JSON document, and may be empty
+	req.Header.Set("Accept", "application/octet-stream")
+	return streamBody(func(w io.Writer) error {
+		_, err := c.c.Do(req, w)
+		return err
+	})
+}
+
+func (c *gitlabClientImpl) CreateReleaseLink(ctx context.Context, projectName, tagName string, opts *gitlab.CreateReleaseLinkOptions) (*gitlab.ReleaseLink, error) {
+	// POST /projects/{project}/releases/{tag_name}/assets/links
+	apiObj, _, err := c.c.ReleaseLinks.CreateReleaseLink(projectName, tagName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseLinkAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) PublishGenericPackageFile(ctx context.Context, projectName, packageName, packageVersion, fileName string, content io.Reader) (string, error) {
+	// PUT /projects/{project}/packages/generic/{package_name}/{package_version}/{file_name}
+	_, _, err := c.c.GenericPackages.PublishPackageFile(projectName, packageName, packageVersion, fileName, content, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	// The file is downloaded from the same path it was uploaded to. Don't use FormatPackageURL,
+	// as it also escapes dots, which makes the URL needlessly hard to read.
+	u, err := c.c.BaseURL().Parse(fmt.Sprintf("projects/%s/packages/generic/%s/%s/%s",
+		url.PathEscape(projectName), url.PathEscape(packageName), url.PathEscape(packageVersion), url.PathEscape(fileName)))
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (c *gitlabClientImpl) ListDeployTokens(ctx context.Context, projectName string) ([]*gitlab.DeployToken, error) {
+	apiObjs := []*gitlab.DeployToken{}
+	opts := &gitlab.ListProjectDeployTokensOptions{}
+	err := allDeployTokenPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/deploy_tokens
+		pageObjs, resp, listErr := c.c.DeployTokens.ListProjectDeployTokens(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployTokenAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateDeployToken(ctx context.Context, projectName string, req *gitlab.CreateProjectDeployTokenOptions) (*gitlab.DeployToken, error) {
+	// POST /projects/{project}/deploy_tokens
+	apiObj, _, err := c.c.DeployTokens.CreateProjectDeployToken(projectName, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateDeployTokenAPI(apiObj); err != nil {
+		return nil, err
+	}
+	// The token value is only part of the creation response
+	if apiObj.Token == "" {
+		return nil, fmt.Errorf("didn't expect token to be empty: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteDeployToken(ctx context.Context, projectName string, tokenID int) error {
+	// DELETE /projects/{project}/deploy_tokens/{deploy_token_id}
+	_, err := c.c.DeployTokens.DeleteProjectDeployToken(projectName, tokenID, gitlab.WithContext(ctx))
 	return handleHTTPError(err)
 }
 
@@ -390,19 +1785,19 @@ func (c *gitlabClientImpl) UnshareProject(projectName string, groupID int) error
 	return handleHTTPError(err)
 }
 
-func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, perPage int, page int) ([]*gitlab.Commit, error) {
+func (c *gitlabClientImpl) ListCommitsPage(ctx context.Context, projectName string, branch string, perPage int, page int) ([]*gitlab.Commit, error) {
 	apiObjs := make([]*gitlab.Commit, 0)
 
 	opts := gitlab.ListCommitsOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: perPage,
+			PerPage: gitprovider.CallOptionsFromContext(ctx).GetPerPage(perPage),
 			Page:    page,
 		},
 		RefName: &branch,
 	}
 
 	// GET /projects/{id}/repository/commits
-	pageObjs, _, listErr := c.c.Commits.ListCommits(projectName, &opts)
+	pageObjs, _, listErr := c.c.Commits.ListCommits(projectName, &opts, gitlab.WithContext(ctx))
 	for _, c := range pageObjs {
 		apiObjs = append(apiObjs, &gitlab.Commit{
 			ID:         c.ID,
@@ -414,7 +1809,161 @@ func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, pe
 	}
 
 	if listErr != nil {
-		return nil, listErr
+		return nil, handleHTTPError(listErr)
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListCommitDiscussions(ctx context.Context, projectName, sha string) ([]*gitlab.Discussion, error) {
+	var apiObjs []*gitlab.Discussion
+	opts := &gitlab.ListCommitDiscussionsOptions{}
+	err := allCommitDiscussionPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/commits/{sha}/discussions
+		pageObjs, resp, listErr := c.c.Discussions.ListCommitDiscussions(projectName, sha, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetCommitGPGSignature(ctx context.Context, projectName, sha string) (*gitlab.GPGSignature, error) {
+	// GET /projects/{project}/repository/commits/{sha}/signature
+	apiObj, _, err := c.c.Commits.GetGPGSiganature(projectName, sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListMergeRequestAwardEmoji(ctx context.Context, projectID interface{}, mrIID int) ([]*gitlab.AwardEmoji, error) {
+	var apiObjs []*gitlab.AwardEmoji
+	opts := &gitlab.ListAwardEmojiOptions{}
+	err := allAwardEmojiPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/award_emoji
+		pageObjs, resp, listErr := c.c.AwardEmoji.ListMergeRequestAwardEmoji(projectID, mrIID, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateMergeRequestAwardEmoji(ctx context.Context, projectID interface{}, mrIID int, name string) (*gitlab.AwardEmoji, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/award_emoji
+	apiObj, _, err := c.c.AwardEmoji.CreateMergeRequestAwardEmoji(projectID, mrIID, &gitlab.CreateAwardEmojiOptions{
+		Name: name,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetUserByUsername(ctx context.Context, username string) (*gitlab.User, error) {
+	// GET /users?username={username}
+	apiObjs, _, err := c.c.Users.ListUsers(&gitlab.ListUsersOptions{Username: &username}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if len(apiObjs) == 0 {
+		return nil, fmt.Errorf("user %q: %w", username, gitprovider.ErrNotFound)
+	}
+	return apiObjs[0], nil
+}
+
+func (c *gitlabClientImpl) GetUser(ctx context.Context, userID int) (*gitlab.User, error) {
+	// GET /users/{id}
+	apiObj, _, err := c.c.Users.GetUser(userID, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) SetMergeRequestReviewers(ctx context.Context, projectID interface{}, mrIID int, userIDs []int) error {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	_, _, err := c.c.MergeRequests.UpdateMergeRequest(projectID, mrIID, &gitlab.UpdateMergeRequestOptions{
+		ReviewerIDs: &userIDs,
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) GetMergeRequestApprovals(ctx context.Context, projectID interface{}, mrIID int) (*gitlab.MergeRequestApprovals, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/approvals
+	apiObj, _, err := c.c.MergeRequestApprovals.GetConfiguration(projectID, mrIID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ApproveMergeRequest(ctx context.Context, projectID interface{}, mrIID int) (*gitlab.MergeRequestApprovals, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/approve
+	apiObj, _, err := c.c.MergeRequestApprovals.ApproveMergeRequest(projectID, mrIID, &gitlab.ApproveMergeRequestOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UnapproveMergeRequest(ctx context.Context, projectID interface{}, mrIID int) error {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/unapprove
+	_, err := c.c.MergeRequestApprovals.UnapproveMergeRequest(projectID, mrIID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) CreateMergeRequestNote(ctx context.Context, projectID interface{}, mrIID int, body string) error {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+	_, _, err := c.c.Notes.CreateMergeRequestNote(projectID, mrIID, &gitlab.CreateMergeRequestNoteOptions{
+		Body: &body,
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListMergeRequestDiscussions(ctx context.Context, projectID interface{}, mrIID int) ([]*gitlab.Discussion, error) {
+	var apiObjs []*gitlab.Discussion
+	opts := &gitlab.ListMergeRequestDiscussionsOptions{}
+	err := allMergeRequestDiscussionPages(ctx, opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/discussions
+		pageObjs, resp, listErr := c.c.Discussions.ListMergeRequestDiscussions(projectID, mrIID, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
 	}
 	return apiObjs, nil
 }
+
+func (c *gitlabClientImpl) CreateMergeRequestDiscussion(ctx context.Context, projectID interface{}, mrIID int, req *gitlab.CreateMergeRequestDiscussionOptions) (*gitlab.Discussion, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/discussions
+	apiObj, _, err := c.c.Discussions.CreateMergeRequestDiscussion(projectID, mrIID, req, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) AddMergeRequestDiscussionNote(ctx context.Context, projectID interface{}, mrIID int, discussionID, body string) (*gitlab.Note, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}/notes
+	apiObj, _, err := c.c.Discussions.AddMergeRequestDiscussionNote(projectID, mrIID, discussionID, &gitlab.AddMergeRequestDiscussionNoteOptions{
+		Body: &body,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ResolveMergeRequestDiscussion(ctx context.Context, projectID interface{}, mrIID int, discussionID string) error {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}/discussions/{discussion_id}
+	_, _, err := c.c.Discussions.ResolveMergeRequestDiscussion(projectID, mrIID, discussionID, &gitlab.ResolveMergeRequestDiscussionOptions{
+		Resolved: gitlab.Bool(true),
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_GetRawFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/org%2Frepo/repository/files/dir%2Ffile%2Etxt/raw":
+			if got := r.URL.Query().Get("ref"); got != "main" {
+				t.Errorf("ref = %q, want main", got)
+			}
+			_, _ = io.WriteString(w, "hello world")
+		case "/api/v4/projects/org%2Frepo/repository/files/empty/raw":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message":"404 File Not Found"}`)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &gitlabClientImpl{c: gl}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr error
+	}{
+		{name: "file", path: "dir/file.txt", want: "hello world"},
+		{name: "empty file", path: "empty", want: ""},
+		{name: "not found", path: "missing", wantErr: gitprovider.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.GetRawFile(ctx, "org/repo", tt.path, "main")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetRawFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetRawFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DeleteProject_async(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/org%2Frepo" {
+			t.Errorf("unexpected request to %s", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = io.WriteString(w, `{"message":"202 Accepted"}`)
+		case http.MethodGet:
+			// The project is still visible for the first lookup
+			if gets++; gets > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"message":"404 Project Not Found"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"id":1,"path":"repo"}`)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &gitlabClientImpl{c: gl, destructiveActions: true}
+
+	// Without waiting, the delete returns as soon as it's accepted
+	if err := c.DeleteProject(context.Background(), "org/repo"); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 0 {
+		t.Errorf("expected no lookups without WaitForCompletion, got %d", gets)
+	}
+
+	wait, interval := true, time.Millisecond
+	ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{WaitForCompletion: &wait, PollInterval: &interval})
+	if err := c.DeleteProject(ctx, "org/repo"); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 2 {
+		t.Errorf("expected 2 lookups until the project is gone, got %d", gets)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// JobTokenVariable is the environment variable holding the job token in GitLab CI/CD jobs.
+	JobTokenVariable = "CI_JOB_TOKEN" // #nosec G101
+	// ServerURLVariable is the environment variable holding the URL of the instance in GitLab
+	// CI/CD jobs, e.g. "https://gitlab.com".
+	ServerURLVariable = "CI_SERVER_URL"
+
+	// jobTokenType is the tokenType of NewClient for job tokens.
+	jobTokenType = "job"
+)
+
+// jobTokenEndpoints are the API endpoints job tokens can access, relative to "/api/v4", see
+// https://docs.gitlab.com/ee/ci/jobs/ci_job_token.html. Project IDs are URL-encoded, hence
+// don't contain slashes.
+//
+//nolint:gochecknoglobals
+var jobTokenEndpoints = []*regexp.Regexp{
+	regexp.MustCompile(`^/job$`),
+	regexp.MustCompile(`^/projects/[^/]+/(deployments|environments|packages|registry|releases|secure_files|terraform)(/|$)`),
+	regexp.MustCompile(`^/projects/[^/]+/jobs/([0-9]+/)?artifacts(/|$)`),
+	regexp.MustCompile(`^/projects/[^/]+/trigger/pipeline$`),
+}
+
+// NewJobTokenClient creates a new gitprovider.Client instance for code running in GitLab CI/CD,
+// which authenticates with the job token of the CI_JOB_TOKEN variable instead of a personal
+// access token. The domain defaults to the instance running the job, i.e. the CI_SERVER_URL
+// variable, and can be overridden using WithDomain.
+//
+// Job tokens can only access a few API endpoints, e.g. the releases and packages of a project.
+// Other calls, including getting the repository of the job, fail with a *JobTokenError without
+// being sent. See NewClient for the other options.
+func NewJobTokenClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	token := os.Getenv(JobTokenVariable)
+	if token == "" {
+		return nil, fmt.Errorf("%s isn't set, not running in GitLab CI/CD: %w", JobTokenVariable, gitprovider.ErrInvalidClientOptions)
+	}
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	if serverURL := os.Getenv(ServerURLVariable); opts.Domain == nil && serverURL != "" {
+		optFns = append(optFns, gitprovider.WithDomain(serverURL))
+	}
+	return NewClient(token, jobTokenType, optFns...)
+}
+
+// JobTokenError describes an API call which job tokens aren't allowed to make. It matches
+// gitprovider.ErrForbidden using errors.Is.
+type JobTokenError struct {
+	// Method is the HTTP method of the call, e.g. "GET".
+	Method string `json:"method"`
+	// Path is the API path of the call, relative to "/api/v4", e.g. "/projects/fluxcd%2Fflux".
+	Path string `json:"path"`
+}
+
+// Error implements the error interface.
+func (e *JobTokenError) Error() string {
+	return fmt.Sprintf("job tokens can't access %s %s: %v", e.Method, e.Path, gitprovider.ErrForbidden)
+}
+
+// Is makes the error match ErrForbidden.
+func (e *JobTokenError) Is(target error) bool {
+	return target == gitprovider.ErrForbidden
+}
+
+// jobTokenTransport fails the requests to API endpoints job tokens can't access.
+type jobTokenTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *jobTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Keep the URL-encoded project IDs as one segment
+	p := req.URL.EscapedPath()
+	if i := strings.Index(p, apiPathSuffix+"/"); i >= 0 {
+		p = p[i+len(apiPathSuffix):]
+	}
+	for _, endpoint := range jobTokenEndpoints {
+		if endpoint.MatchString(p) {
+			return t.next.RoundTrip(req)
+		}
+	}
+	// RoundTrippers must close the body of the request, even on errors
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return nil, &JobTokenError{Method: req.Method, Path: p}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestNewJobTokenClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/fluxcd/flux/releases", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("JOB-TOKEN"); got != "job-token" {
+			t.Errorf("JOB-TOKEN = %q, want %q", got, "job-token")
+		}
+		_, _ = fmt.Fprint(w, `[{"tag_name":"v1.0.0"}]`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Setenv(JobTokenVariable, "job-token")
+	t.Setenv(ServerURLVariable, srv.URL)
+	c, err := NewJobTokenClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, srv.URL, c.SupportedDomain())
+
+	// The releases of a project can be accessed with job tokens
+	releases, _, err := c.Raw().(*gogitlab.Client).Releases.ListReleases("fluxcd/flux", nil)
+	if err != nil {
+		t.Fatalf("ListReleases() error = %v", err)
+	}
+	if len(releases) != 1 {
+		t.Errorf("ListReleases() returned %d releases, want 1", len(releases))
+	}
+
+	// Other calls fail without being sent
+	_, err = c.OrgRepositories().Get(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: srv.URL, Organization: "fluxcd"},
+		RepositoryName:  "flux",
+	})
+	var jobTokenErr *JobTokenError
+	if !errors.As(err, &jobTokenErr) {
+		t.Fatalf("Get() error = %v, want a *JobTokenError", err)
+	}
+	assertEqual(t, "/projects/fluxcd%2Fflux", jobTokenErr.Path)
+	if !errors.Is(err, gitprovider.ErrForbidden) {
+		t.Errorf("Get() error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestNewJobTokenClient_NotInCI(t *testing.T) {
+	t.Setenv(JobTokenVariable, "")
+	if _, err := NewJobTokenClient(); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("NewJobTokenClient() error = %v, want ErrInvalidClientOptions", err)
+	}
+}
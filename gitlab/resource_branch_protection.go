@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// newBranchProtection looks up the usernames of the users allowed to push, as GitLab only
+// returns their IDs. pipelineMustSucceed is the project-wide setting of whether merge requests
+// can only be merged if the pipeline succeeds.
+func newBranchProtection(ctx context.Context, c *BranchProtectionClient, apiObj *gitlab.ProtectedBranch, pipelineMustSucceed bool) (*branchProtection, error) {
+	bp := &branchProtection{
+		p:                          *apiObj,
+		codeOwnerApprovalRequired:  apiObj.CodeOwnerApprovalRequired,
+		pipelineMustSucceed:        pipelineMustSucceed,
+		projectPipelineMustSucceed: pipelineMustSucceed,
+		c:                          c,
+	}
+	for _, level := range apiObj.PushAccessLevels {
+		if level.UserID == 0 {
+			continue
+		}
+		// GET /users/{id}
+		user, err := c.c.GetUser(ctx, level.UserID)
+		if err != nil {
+			return nil, err
+		}
+		bp.allowedPushers = append(bp.allowedPushers, user.Username)
+	}
+	return bp, nil
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	p gitlab.ProtectedBranch
+	// allowedPushers are the usernames of the users allowed to push.
+	allowedPushers []string
+	// codeOwnerApprovalRequired is whether the approval of code owners is required.
+	codeOwnerApprovalRequired bool
+	// pipelineMustSucceed is whether the StatusCheckPipeline check is required, and
+	// projectPipelineMustSucceed the setting of the project on the server.
+	pipelineMustSucceed        bool
+	projectPipelineMustSucceed bool
+	c                          *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	info := gitprovider.BranchProtectionInfo{
+		Branch:                  bp.p.Name,
+		RequiredApprovals:       gitprovider.IntVar(0),
+		RequireCodeOwnerReviews: gitprovider.BoolVar(bp.codeOwnerApprovalRequired),
+		EnforceAdmins:           gitprovider.BoolVar(false),
+		AllowedPushers:          bp.allowedPushers,
+	}
+	if bp.pipelineMustSucceed {
+		info.RequiredStatusChecks = gitprovider.StatusChecks(gitprovider.StatusCheckPipeline)
+	}
+	return info
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Branch != bp.p.Name {
+		return fmt.Errorf("can't move the protection rule of branch %q to %q: %w", bp.p.Name, info.Branch, gitprovider.ErrInvalidArgument)
+	}
+	if err := validateBranchProtectionSupport(info); err != nil {
+		return err
+	}
+	if info.RequireCodeOwnerReviews != nil {
+		bp.codeOwnerApprovalRequired = *info.RequireCodeOwnerReviews
+	}
+	bp.allowedPushers = info.AllowedPushers
+	bp.pipelineMustSucceed = requiresPipeline(info)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The protected branch is
+// updated in place, keeping e.g. the merge and unprotect access levels, force pushes and the push
+// access of groups and deploy keys. Requiring the pipeline to succeed is a project setting, which
+// affects all branches.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	opts := &updateProtectedBranchOptions{}
+	// Only send the setting if it changed, as it's a GitLab Premium feature
+	if bp.codeOwnerApprovalRequired != bp.p.CodeOwnerApprovalRequired {
+		opts.CodeOwnerApprovalRequired = gitlab.Bool(bp.codeOwnerApprovalRequired)
+	}
+	// GET /projects/{project}/protected_branches/{name}
+	access, err := bp.c.c.GetProtectedBranchPushAccess(ctx, getRepoPath(bp.c.ref), bp.p.Name)
+	if err != nil {
+		return err
+	}
+	pushers, err := bp.c.pushAccessUpdates(ctx, access, bp.allowedPushers)
+	if err != nil {
+		return err
+	}
+	if len(pushers) != 0 {
+		opts.AllowedToPush = &pushers
+	}
+	if opts.CodeOwnerApprovalRequired != nil || opts.AllowedToPush != nil {
+		// PATCH /projects/{project}/protected_branches/{name}
+		apiObj, err := bp.c.c.UpdateProtectedBranch(ctx, getRepoPath(bp.c.ref), bp.p.Name, opts)
+		if err != nil {
+			return err
+		}
+		bp.p = *apiObj
+		bp.codeOwnerApprovalRequired = apiObj.CodeOwnerApprovalRequired
+	}
+	return bp.updatePipelineMustSucceed(ctx)
+}
+
+// updatePipelineMustSucceed applies whether the pipeline must succeed to the project, if it
+// differs from the setting on the server.
+func (bp *branchProtection) updatePipelineMustSucceed(ctx context.Context) error {
+	if bp.pipelineMustSucceed == bp.projectPipelineMustSucceed {
+		return nil
+	}
+	// PUT /projects/{project}
+	project, err := bp.c.c.SetPipelineMustSucceed(ctx, getRepoPath(bp.c.ref), bp.pipelineMustSucceed)
+	if err != nil {
+		return err
+	}
+	bp.pipelineMustSucceed = project.OnlyAllowMergeIfPipelineSucceeds
+	bp.projectPipelineMustSucceed = project.OnlyAllowMergeIfPipelineSucceeds
+	return nil
+}
+
+// Delete unprotects the branch.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/protected_branches/{name}
+	return bp.c.c.UnprotectBranch(ctx, getRepoPath(bp.c.ref), bp.p.Name)
+}
+
+// branchAccess is a push access level of a protected branch, including its ID and deploy key,
+// which go-gitlab doesn't decode. The ID is needed to remove the access level in place.
+type branchAccess struct {
+	ID          int                     `json:"id"`
+	AccessLevel gitlab.AccessLevelValue `json:"access_level"`
+	UserID      int                     `json:"user_id"`
+	GroupID     int                     `json:"group_id"`
+	DeployKeyID int                     `json:"deploy_key_id"`
+}
+
+// branchAccessUpdate adds a push access level to a protected branch, or removes the one with the
+// given ID if Destroy is set.
+type branchAccessUpdate struct {
+	ID          *int                     `json:"id,omitempty"`
+	UserID      *int                     `json:"user_id,omitempty"`
+	AccessLevel *gitlab.AccessLevelValue `json:"access_level,omitempty"`
+	Destroy     *bool                    `json:"_destroy,omitempty"`
+}
+
+// updateProtectedBranchOptions are the options of
+// "PATCH /projects/{project}/protected_branches/{name}", which go-gitlab doesn't support yet.
+type updateProtectedBranchOptions struct {
+	CodeOwnerApprovalRequired *bool                  `json:"code_owner_approval_required,omitempty"`
+	AllowedToPush             *[]*branchAccessUpdate `json:"allowed_to_push,omitempty"`
+}
+
+func validateProtectedBranchAPI(apiObj *gitlab.ProtectedBranch) error {
+	return validateAPIObject("GitLab.ProtectedBranch", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// requiresPipeline returns whether the protection rule requires the pipeline to succeed.
+func requiresPipeline(info gitprovider.BranchProtectionInfo) bool {
+	return len(info.RequiredStatusChecks) == 1 && info.RequiredStatusChecks[0].Context == gitprovider.StatusCheckPipeline
+}
+
+// validateBranchProtectionSupport returns ErrNoProviderSupport for the settings that GitLab
+// protected branches can't enforce. Approval rules and external status checks are separate,
+// project-wide GitLab features, only the pipeline of the project can be required to succeed.
+func validateBranchProtectionSupport(info gitprovider.BranchProtectionInfo) error {
+	if info.RequiredApprovals != nil && *info.RequiredApprovals != 0 {
+		return fmt.Errorf("gitlab protected branches can't require approvals: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if len(info.RequiredStatusChecks) != 0 && !requiresPipeline(info) {
+		return fmt.Errorf("gitlab protected branches can only require the %q status check: %w", gitprovider.StatusCheckPipeline, gitprovider.ErrNoProviderSupport)
+	}
+	if requiresPipeline(info) && info.RequiredStatusChecks[0].AppID != nil {
+		return fmt.Errorf("gitlab protected branches can't require the app of a status check: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.EnforceAdmins != nil && *info.EnforceAdmins {
+		return fmt.Errorf("gitlab protected branches can't be enforced for administrators separately: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package gitlab
 
 import (
+	"context"
+
 	"github.com/xanzy/go-gitlab"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -34,10 +36,15 @@ var _ gitprovider.Commit = &commitType{}
 type commitType struct {
 	k gitlab.Commit
 	c *CommitClient
+
+	// verification is nil unless the signature of the commit has been fetched.
+	verification *gitprovider.CommitVerification
 }
 
 func (c *commitType) Get() gitprovider.CommitInfo {
-	return commitFromAPI(&c.k)
+	info := commitFromAPI(&c.k)
+	info.Verification = c.verification
+	return info
 }
 
 func (c *commitType) APIObject() interface{} {
@@ -46,10 +53,77 @@ func (c *commitType) APIObject() interface{} {
 
 func commitFromAPI(apiObj *gitlab.Commit) gitprovider.CommitInfo {
 	return gitprovider.CommitInfo{
-		Sha:       apiObj.ID,
-		Author:    apiObj.AuthorName,
-		Message:   apiObj.Message,
-		CreatedAt: *apiObj.CreatedAt,
-		URL:       apiObj.WebURL,
+		Sha:         apiObj.ID,
+		Author:      apiObj.AuthorName,
+		AuthorEmail: apiObj.AuthorEmail,
+		Message:     apiObj.Message,
+		CreatedAt:   *apiObj.CreatedAt,
+		URL:         apiObj.WebURL,
+	}
+}
+
+// commitVerificationFromAPI maps the GPG signature of a commit. A nil signature means the
+// commit isn't signed, which GitHub reports with the "unsigned" reason.
+func commitVerificationFromAPI(sig *gitlab.GPGSignature) *gitprovider.CommitVerification {
+	if sig == nil {
+		return &gitprovider.CommitVerification{Reason: "unsigned"}
+	}
+	v := &gitprovider.CommitVerification{
+		Verified: sig.VerificationStatus == "verified",
+		Reason:   sig.VerificationStatus,
+		Signer:   sig.KeyUserEmail,
+	}
+	if v.Signer == "" {
+		v.Signer = sig.KeyUserName
+	}
+	return v
+}
+
+func newCommitComment(note *gitlab.Note) *commitComment {
+	return &commitComment{
+		n: *note,
+	}
+}
+
+var _ gitprovider.CommitComment = &commitComment{}
+
+type commitComment struct {
+	n gitlab.Note
+}
+
+func (c *commitComment) Get() gitprovider.CommitCommentInfo {
+	return commitCommentFromAPI(&c.n)
+}
+
+func (c *commitComment) APIObject() interface{} {
+	return &c.n
+}
+
+// AddReaction is not supported, as GitLab doesn't allow awarding emojis to commit notes.
+func (c *commitComment) AddReaction(_ context.Context, _ gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListReactions is not supported, as GitLab doesn't allow awarding emojis to commit notes.
+func (c *commitComment) ListReactions(_ context.Context) ([]gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func commitCommentFromAPI(apiObj *gitlab.Note) gitprovider.CommitCommentInfo {
+	info := gitprovider.CommitCommentInfo{
+		ID:     int64(apiObj.ID),
+		Author: apiObj.Author.Username,
+		Body:   apiObj.Body,
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	// Inline comments on removed lines only have the old path and line set
+	if pos := apiObj.Position; pos != nil {
+		info.Path, info.Line = pos.NewPath, pos.NewLine
+		if info.Line == 0 {
+			info.Path, info.Line = pos.OldPath, pos.OldLine
+		}
 	}
+	return info
 }
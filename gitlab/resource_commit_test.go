@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_commitVerificationFromAPI(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  *gitlab.GPGSignature
+		want *gitprovider.CommitVerification
+	}{
+		{
+			name: "unsigned",
+			want: &gitprovider.CommitVerification{Reason: "unsigned"},
+		},
+		{
+			name: "verified",
+			sig: &gitlab.GPGSignature{
+				VerificationStatus: "verified",
+				KeyUserName:        "Jane Doe",
+				KeyUserEmail:       "jane@example.com",
+			},
+			want: &gitprovider.CommitVerification{Verified: true, Reason: "verified", Signer: "jane@example.com"},
+		},
+		{
+			name: "unknown key without e-mail",
+			sig: &gitlab.GPGSignature{
+				VerificationStatus: "unknown_key",
+				KeyUserName:        "Jane Doe",
+			},
+			want: &gitprovider.CommitVerification{Reason: "unknown_key", Signer: "Jane Doe"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitVerificationFromAPI(tt.sig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commitVerificationFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 
@@ -39,9 +40,17 @@ func newDeployKey(c *DeployKeyClient, key *gitlab.ProjectDeployKey) *deployKey {
 var _ gitprovider.DeployKey = &deployKey{}
 
 type deployKey struct {
-	k       gitlab.ProjectDeployKey
-	c       *DeployKeyClient
-	canpush bool
+	k          gitlab.ProjectDeployKey
+	c          *DeployKeyClient
+	canpush    bool
+	lastUsedAt *time.Time
+}
+
+// projectDeployKey extends gitlab.ProjectDeployKey with the time the key was last used, which
+// go-gitlab doesn't decode.
+type projectDeployKey struct {
+	gitlab.ProjectDeployKey
+	LastUsedAt *time.Time `json:"last_used_at"`
 }
 
 func (dk *deployKey) Get() gitprovider.DeployKeyInfo {
@@ -60,6 +69,12 @@ func (dk *deployKey) APIObject() interface{} {
 	return &dk.k
 }
 
+// LastUsedAt returns the time the deploy key was last used, as listed by GitLab. nil is returned
+// for keys that were never used, and for keys returned by Create.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return dk.lastUsedAt
+}
+
 func (dk *deployKey) Repository() gitprovider.RepositoryRef {
 	return dk.c.ref
 }
@@ -103,7 +118,7 @@ func (dk *deployKey) Delete(_ context.Context) error {
 //
 // The internal API object will be overridden with the received server data if actionTaken == true.
 func (dk *deployKey) Reconcile(ctx context.Context) (bool, error) {
-	actual, err := dk.c.get(dk.k.Title)
+	actual, err := dk.c.get(ctx, dk.k.Title)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newDeployToken(c *DeployTokenClient, token *gitlab.DeployToken) *deployToken {
+	return &deployToken{
+		t: *token,
+		c: c,
+	}
+}
+
+var _ gitprovider.DeployToken = &deployToken{}
+
+type deployToken struct {
+	t gitlab.DeployToken
+	c *DeployTokenClient
+}
+
+func (dt *deployToken) Get() gitprovider.DeployTokenInfo {
+	return deployTokenFromAPI(&dt.t)
+}
+
+// Token returns the token value, which is only set if dt was returned by Create.
+func (dt *deployToken) Token() string {
+	return dt.t.Token
+}
+
+func (dt *deployToken) APIObject() interface{} {
+	return &dt.t
+}
+
+func (dt *deployToken) Repository() gitprovider.RepositoryRef {
+	return dt.c.ref
+}
+
+// Delete revokes the deploy token.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dt *deployToken) Delete(ctx context.Context) error {
+	// We can use the same DeployToken ID that we got from the GET calls. Make sure it's non-nil.
+	// This _should never_ happen, but just check for it anyways to avoid panicing.
+	if dt.t.ID == 0 {
+		return fmt.Errorf("didn't expect ID to be 0: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	return dt.c.c.DeleteDeployToken(ctx, getRepoPath(dt.c.ref), dt.t.ID)
+}
+
+func validateDeployTokenAPI(apiObj *gitlab.DeployToken) error {
+	return validateAPIObject("GitLab.DeployToken", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+		if len(apiObj.Scopes) == 0 {
+			validator.Required("Scopes")
+		}
+	})
+}
+
+func deployTokenFromAPI(apiObj *gitlab.DeployToken) gitprovider.DeployTokenInfo {
+	scopes := make([]gitprovider.DeployTokenScope, 0, len(apiObj.Scopes))
+	for _, scope := range apiObj.Scopes {
+		scopes = append(scopes, gitprovider.DeployTokenScope(scope))
+	}
+	info := gitprovider.DeployTokenInfo{
+		Name:      apiObj.Name,
+		Scopes:    scopes,
+		ExpiresAt: apiObj.ExpiresAt,
+	}
+	if apiObj.Username != "" {
+		info.Username = gitprovider.StringVar(apiObj.Username)
+	}
+	return info
+}
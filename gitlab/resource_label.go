@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newLabel(c *LabelClient, apiObj *gitlab.Label) *label {
+	return &label{
+		l:    *apiObj,
+		name: apiObj.Name,
+		c:    c,
+	}
+}
+
+var _ gitprovider.Label = &label{}
+
+type label struct {
+	l gitlab.Label
+	// name is the name of the label in GitLab, which is used to address it in Update and Delete,
+	// as Set might change l.Name.
+	name string
+	c    *LabelClient
+}
+
+func (l *label) ID() string {
+	return strconv.Itoa(l.l.ID)
+}
+
+func (l *label) Get() gitprovider.LabelInfo {
+	return labelFromAPI(&l.l)
+}
+
+func (l *label) Set(info gitprovider.LabelInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	labelInfoToAPIObj(&info, &l.l)
+	return nil
+}
+
+func (l *label) APIObject() interface{} {
+	return &l.l
+}
+
+func (l *label) Repository() gitprovider.RepositoryRef {
+	return l.c.ref
+}
+
+// Update will apply the desired state in this object to the server, renaming the label if
+// its name was changed using Set.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (l *label) Update(ctx context.Context) error {
+	// PUT /projects/{project}/labels
+	apiObj, err := l.c.c.UpdateLabel(ctx, getRepoPath(l.c.ref), &gitlab.UpdateLabelOptions{
+		Name:        gitlab.String(l.name),
+		NewName:     gitlab.String(l.l.Name),
+		Color:       gitlab.String(l.l.Color),
+		Description: gitlab.String(l.l.Description),
+	})
+	if err != nil {
+		return err
+	}
+	l.l = *apiObj
+	l.name = apiObj.Name
+	return nil
+}
+
+// Delete deletes the label from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (l *label) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/labels
+	return l.c.c.DeleteLabel(ctx, getRepoPath(l.c.ref), l.name)
+}
+
+func validateLabelAPI(apiObj *gitlab.Label) error {
+	return validateAPIObject("GitLab.Label", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+func labelFromAPI(apiObj *gitlab.Label) gitprovider.LabelInfo {
+	// GitLab prefixes colors with "#"
+	color := strings.TrimPrefix(apiObj.Color, "#")
+	description := apiObj.Description
+	return gitprovider.LabelInfo{
+		Name:        apiObj.Name,
+		Color:       &color,
+		Description: &description,
+	}
+}
+
+// labelToAPI returns the options for creating the label. Note that GitLab requires a color.
+func labelToAPI(info *gitprovider.LabelInfo) *gitlab.CreateLabelOptions {
+	opts := &gitlab.CreateLabelOptions{
+		Name:        gitlab.String(info.Name),
+		Description: info.Description,
+	}
+	if info.Color != nil {
+		opts.Color = gitlab.String("#" + *info.Color)
+	}
+	return opts
+}
+
+func labelInfoToAPIObj(info *gitprovider.LabelInfo, apiObj *gitlab.Label) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Name = info.Name
+	// optional fields
+	if info.Color != nil {
+		apiObj.Color = "#" + *info.Color
+	}
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// milestoneStateActive is the GitLab name of open milestones.
+	milestoneStateActive = "active"
+)
+
+func newMilestone(c *MilestoneClient, apiObj *gitlab.Milestone, stats *gitlab.IssuesStatistics) *milestone {
+	return &milestone{
+		m:            *apiObj,
+		c:            c,
+		openIssues:   stats.Statistics.Counts.Opened,
+		closedIssues: stats.Statistics.Counts.Closed,
+	}
+}
+
+var _ gitprovider.Milestone = &milestone{}
+
+type milestone struct {
+	m gitlab.Milestone
+	c *MilestoneClient
+
+	openIssues   int
+	closedIssues int
+}
+
+func (m *milestone) Get() gitprovider.MilestoneInfo {
+	info := milestoneFromAPI(&m.m)
+	info.OpenIssues = m.openIssues
+	info.ClosedIssues = m.closedIssues
+	info.Progress = gitprovider.MilestoneProgress(m.openIssues, m.closedIssues)
+	return info
+}
+
+func (m *milestone) APIObject() interface{} {
+	return &m.m
+}
+
+func (m *milestone) Repository() gitprovider.RepositoryRef {
+	return m.c.ref
+}
+
+func validateMilestoneAPI(apiObj *gitlab.Milestone) error {
+	return validateAPIObject("GitLab.Milestone", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Title == "" {
+			validator.Required("Title")
+		}
+	})
+}
+
+func milestoneFromAPI(apiObj *gitlab.Milestone) gitprovider.MilestoneInfo {
+	info := gitprovider.MilestoneInfo{
+		Number: apiObj.IID,
+		Title:  apiObj.Title,
+		State:  gitprovider.MilestoneStateClosed,
+	}
+	if apiObj.State == milestoneStateActive {
+		info.State = gitprovider.MilestoneStateOpen
+	}
+	if apiObj.Description != "" {
+		description := apiObj.Description
+		info.Description = &description
+	}
+	if apiObj.DueDate != nil {
+		dueDate := time.Time(*apiObj.DueDate)
+		info.DueDate = &dueDate
+	}
+	return info
+}
+
+func milestoneStateToAPI(state gitprovider.MilestoneState) *string {
+	if state == gitprovider.MilestoneStateOpen {
+		return gitlab.String(milestoneStateActive)
+	}
+	return gitlab.String(string(state))
+}
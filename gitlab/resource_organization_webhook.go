@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newGroupWebhook(c *OrganizationWebhookClient, apiObj *gitlab.GroupHook) *groupWebhook {
+	return &groupWebhook{
+		h: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.OrganizationWebhook = &groupWebhook{}
+
+type groupWebhook struct {
+	h gitlab.GroupHook
+	// token is the secret set using Set, as GitLab doesn't return it.
+	token *string
+	c     *OrganizationWebhookClient
+}
+
+func (wh *groupWebhook) ID() string {
+	return strconv.Itoa(wh.h.ID)
+}
+
+func (wh *groupWebhook) Get() gitprovider.WebhookInfo {
+	return groupWebhookFromAPI(&wh.h)
+}
+
+func (wh *groupWebhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookContentType(info); err != nil {
+		return err
+	}
+	groupWebhookInfoToAPIObj(&info, &wh.h)
+	if info.Secret != nil {
+		wh.token = info.Secret
+	}
+	return nil
+}
+
+func (wh *groupWebhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *groupWebhook) Organization() gitprovider.OrganizationRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set, otherwise GitLab keeps the current secret.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *groupWebhook) Update(ctx context.Context) error {
+	// PUT /groups/{group}/hooks/{hook_id}
+	apiObj, err := wh.c.c.UpdateGroupHook(ctx, wh.c.ref.Organization, wh.h.ID, &gitlab.EditGroupHookOptions{
+		URL:                 gitlab.String(wh.h.URL),
+		Token:               wh.token,
+		PushEvents:          gitlab.Bool(wh.h.PushEvents),
+		TagPushEvents:       gitlab.Bool(wh.h.TagPushEvents),
+		MergeRequestsEvents: gitlab.Bool(wh.h.MergeRequestsEvents),
+		IssuesEvents:        gitlab.Bool(wh.h.IssuesEvents),
+		NoteEvents:          gitlab.Bool(wh.h.NoteEvents),
+		ReleasesEvents:      gitlab.Bool(wh.h.ReleasesEvents),
+	})
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	wh.token = nil
+	return nil
+}
+
+// Delete deletes the webhook from the group.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *groupWebhook) Delete(ctx context.Context) error {
+	// DELETE /groups/{group}/hooks/{hook_id}
+	return wh.c.c.DeleteGroupHook(ctx, wh.c.ref.Organization, wh.h.ID)
+}
+
+func validateGroupHookAPI(apiObj *gitlab.GroupHook) error {
+	return validateAPIObject("GitLab.GroupHook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// groupWebhookFromAPI maps the group hook through a project hook, as both have the same events.
+func groupWebhookFromAPI(apiObj *gitlab.GroupHook) gitprovider.WebhookInfo {
+	return webhookFromAPI(&gitlab.ProjectHook{
+		URL:                 apiObj.URL,
+		PushEvents:          apiObj.PushEvents,
+		TagPushEvents:       apiObj.TagPushEvents,
+		MergeRequestsEvents: apiObj.MergeRequestsEvents,
+		IssuesEvents:        apiObj.IssuesEvents,
+		NoteEvents:          apiObj.NoteEvents,
+		ReleasesEvents:      apiObj.ReleasesEvents,
+	})
+}
+
+func groupWebhookToAPI(info *gitprovider.WebhookInfo) *gitlab.AddGroupHookOptions {
+	h := &gitlab.GroupHook{}
+	groupWebhookInfoToAPIObj(info, h)
+	return &gitlab.AddGroupHookOptions{
+		URL:                 gitlab.String(h.URL),
+		Token:               info.Secret,
+		PushEvents:          gitlab.Bool(h.PushEvents),
+		TagPushEvents:       gitlab.Bool(h.TagPushEvents),
+		MergeRequestsEvents: gitlab.Bool(h.MergeRequestsEvents),
+		IssuesEvents:        gitlab.Bool(h.IssuesEvents),
+		NoteEvents:          gitlab.Bool(h.NoteEvents),
+		ReleasesEvents:      gitlab.Bool(h.ReleasesEvents),
+	}
+}
+
+func groupWebhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *gitlab.GroupHook) {
+	h := &gitlab.ProjectHook{}
+	webhookInfoToAPIObj(info, h)
+	apiObj.URL = h.URL
+	apiObj.PushEvents = h.PushEvents
+	apiObj.TagPushEvents = h.TagPushEvents
+	apiObj.MergeRequestsEvents = h.MergeRequestsEvents
+	apiObj.IssuesEvents = h.IssuesEvents
+	apiObj.NoteEvents = h.NoteEvents
+	apiObj.ReleasesEvents = h.ReleasesEvents
+}
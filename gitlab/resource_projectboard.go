@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newProjectBoard(apiObj *gitlab.GroupIssueBoard, ref gitprovider.OrganizationRef) *projectBoard {
+	return &projectBoard{
+		b:   *apiObj,
+		ref: ref,
+	}
+}
+
+var _ gitprovider.ProjectBoard = &projectBoard{}
+
+type projectBoard struct {
+	b   gitlab.GroupIssueBoard
+	ref gitprovider.OrganizationRef
+}
+
+func (p *projectBoard) Get() gitprovider.ProjectBoardInfo {
+	return projectBoardFromAPI(&p.b, p.ref)
+}
+
+func (p *projectBoard) APIObject() interface{} {
+	return &p.b
+}
+
+func (p *projectBoard) Organization() gitprovider.OrganizationRef {
+	return p.ref
+}
+
+func projectBoardFromAPI(apiObj *gitlab.GroupIssueBoard, ref gitprovider.OrganizationRef) gitprovider.ProjectBoardInfo {
+	// The API doesn't return the address of the board, but it lives below the group's web URL
+	groupURL := ref.String()
+	if apiObj.Group != nil && apiObj.Group.WebURL != "" {
+		groupURL = strings.TrimSuffix(apiObj.Group.WebURL, "/")
+	}
+	return gitprovider.ProjectBoardInfo{
+		ID:    strconv.Itoa(apiObj.ID),
+		Title: apiObj.Name,
+		URL:   fmt.Sprintf("%s/-/boards/%d", groupURL, apiObj.ID),
+	}
+}
+
+// validateGroupIssueBoardAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateGroupIssueBoardAPI(apiObj *gitlab.GroupIssueBoard) error {
+	return validateAPIObject("GitLab.GroupIssueBoard", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+	})
+}
@@ -17,12 +17,24 @@ limitations under the License.
 package gitlab
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
 )
 
-// The value of the "State" field of a gitlab merge request after it has been merged"
-const mergedState = "merged"
+// The values of the "State" field of a gitlab merge request.
+const (
+	// mergedState is the state of a merge request after it has been merged.
+	mergedState = "merged"
+	// closedState is the state of a merge request that has been closed without merging it.
+	closedState = "closed"
+	// openedState is the state of an open merge request.
+	openedState = "opened"
+)
 
 func newPullRequest(ctx *clientContext, apiObj *gitlab.MergeRequest) *pullrequest {
 	return &pullrequest{
@@ -47,7 +59,237 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Reviews gives access to the reviewers and approvals of the merge request.
+func (pr *pullrequest) Reviews() gitprovider.ReviewClient {
+	return &ReviewClient{
+		clientContext: pr.clientContext,
+		projectID:     pr.pr.ProjectID,
+		iid:           pr.pr.IID,
+	}
+}
+
+// Comments gives access to the discussions of the merge request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{
+		clientContext: pr.clientContext,
+		projectID:     pr.pr.ProjectID,
+		iid:           pr.pr.IID,
+	}
+}
+
+// EnableAutoMerge sets the merge request to be merged when its pipeline succeeds. GitLab merges
+// it right away if there is no pipeline running.
+func (pr *pullrequest) EnableAutoMerge(ctx context.Context, mergeMethod gitprovider.MergeMethod) error {
+	if err := pr.validateMergeMethod(ctx, pr.pr.ProjectID, mergeMethod); err != nil {
+		return err
+	}
+	opts := &gitlab.AcceptMergeRequestOptions{
+		MergeWhenPipelineSucceeds: gitlab.Bool(true),
+		Squash:                    gitlab.Bool(mergeMethod == gitprovider.MergeMethodSquash),
+	}
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}/merge
+	_, _, err := pr.c.Client().MergeRequests.AcceptMergeRequest(pr.pr.ProjectID, pr.pr.IID, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+// Files lists the files changed by the merge request, counting the added and deleted lines in
+// their diffs. GitLab omits the diffs of files beyond its diff limits.
+func (pr *pullrequest) Files(ctx context.Context) ([]gitprovider.PullRequestFile, error) {
+	mr, err := pr.getChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]gitprovider.PullRequestFile, 0, len(mr.Changes))
+	for _, change := range mr.Changes {
+		file := gitprovider.PullRequestFile{
+			Path:       change.NewPath,
+			ChangeType: gitprovider.FileChangeTypeModified,
+			Patch:      change.Diff,
+		}
+		switch {
+		case change.NewFile:
+			file.ChangeType = gitprovider.FileChangeTypeAdded
+		case change.DeletedFile:
+			file.ChangeType = gitprovider.FileChangeTypeRemoved
+		case change.RenamedFile:
+			file.ChangeType = gitprovider.FileChangeTypeRenamed
+			file.PreviousPath = change.OldPath
+		}
+		file.Additions, file.Deletions = countDiffLines(change.Diff)
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Diff returns the unified diff of the merge request, assembled from the diffs of the changed files.
+func (pr *pullrequest) Diff(ctx context.Context) (string, error) {
+	mr, err := pr.getChanges(ctx)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, change := range mr.Changes {
+		oldPath, newPath := "a/"+change.OldPath, "b/"+change.NewPath
+		fmt.Fprintf(&sb, "diff --git %s %s\n", oldPath, newPath)
+		switch {
+		case change.NewFile:
+			fmt.Fprintf(&sb, "new file mode %s\n", change.BMode)
+			oldPath = "/dev/null"
+		case change.DeletedFile:
+			fmt.Fprintf(&sb, "deleted file mode %s\n", change.AMode)
+			newPath = "/dev/null"
+		case change.RenamedFile:
+			fmt.Fprintf(&sb, "rename from %s\nrename to %s\n", change.OldPath, change.NewPath)
+		}
+		if change.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "--- %s\n+++ %s\n%s", oldPath, newPath, change.Diff)
+		if !strings.HasSuffix(change.Diff, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// getChanges gets the merge request including its changed files and their diffs.
+func (pr *pullrequest) getChanges(ctx context.Context) (*gitlab.MergeRequest, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/changes
+	mr, _, err := pr.c.Client().MergeRequests.GetMergeRequestChanges(pr.pr.ProjectID, pr.pr.IID, &gitlab.GetMergeRequestChangesOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return mr, nil
+}
+
+// countDiffLines counts the added and deleted lines of a diff without file header.
+func countDiffLines(diff string) (additions, deletions int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// SetLabels replaces the labels of the merge request. Labels which don't exist yet are created.
+func (pr *pullrequest) SetLabels(ctx context.Context, labels []string) error {
+	apiLabels := gitlab.Labels(labels)
+	return pr.update(ctx, &gitlab.UpdateMergeRequestOptions{Labels: &apiLabels})
+}
+
+// SetAssignees replaces the assignees of the merge request, looking up the users by username.
+func (pr *pullrequest) SetAssignees(ctx context.Context, assignees []string) error {
+	userIDs := make([]int, 0, len(assignees))
+	for _, login := range assignees {
+		// GET /users?username={username}
+		user, err := pr.c.GetUserByUsername(ctx, login)
+		if err != nil {
+			return err
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+	return pr.update(ctx, &gitlab.UpdateMergeRequestOptions{AssigneeIDs: &userIDs})
+}
+
+// SetMilestone adds the merge request to the milestone with the given number (its project
+// specific IID), or removes it from its milestone if number is 0.
+func (pr *pullrequest) SetMilestone(ctx context.Context, number int) error {
+	milestoneID := 0
+	if number != 0 {
+		// GET /projects/{project}/milestones?iids[]={number}
+		apiObjs, _, err := pr.c.Client().Milestones.ListMilestones(pr.pr.ProjectID, &gitlab.ListMilestonesOptions{IIDs: &[]int{number}}, gitlab.WithContext(ctx))
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if len(apiObjs) == 0 {
+			return fmt.Errorf("milestone %d: %w", number, gitprovider.ErrNotFound)
+		}
+		milestoneID = apiObjs[0].ID
+	}
+	return pr.update(ctx, &gitlab.UpdateMergeRequestOptions{MilestoneID: &milestoneID})
+}
+
+// update updates the merge request, and replaces it with the result.
+func (pr *pullrequest) update(ctx context.Context, opts *gitlab.UpdateMergeRequestOptions) error {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	apiObj, _, err := pr.c.Client().MergeRequests.UpdateMergeRequest(pr.pr.ProjectID, pr.pr.IID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	pr.pr = *apiObj
+	return nil
+}
+
+// AddReaction awards an emoji to the merge request. GitLab refuses to award the same emoji twice,
+// in which case the existing award emoji of the authenticated user is returned.
+func (pr *pullrequest) AddReaction(ctx context.Context, content gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	name, err := getAwardEmojiName(content)
+	if err != nil {
+		return nil, err
+	}
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/award_emoji
+	apiObj, err := pr.c.CreateMergeRequestAwardEmoji(ctx, pr.pr.ProjectID, pr.pr.IID, name)
+	if err == nil {
+		return newReaction(apiObj), nil
+	}
+	// GitLab responds with 404 Not Found if the emoji has already been awarded
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+	existing, lookupErr := pr.findOwnAwardEmoji(ctx, name)
+	if lookupErr != nil || existing == nil {
+		return nil, err
+	}
+	return newReaction(existing), nil
+}
+
+// findOwnAwardEmoji returns the award emoji with the given name of the authenticated user, or nil.
+func (pr *pullrequest) findOwnAwardEmoji(ctx context.Context, name string) (*gitlab.AwardEmoji, error) {
+	user, _, err := pr.c.Client().Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	apiObjs, err := pr.c.ListMergeRequestAwardEmoji(ctx, pr.pr.ProjectID, pr.pr.IID)
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Name == name && apiObj.User.ID == user.ID {
+			return apiObj, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListReactions lists all award emojis of the merge request.
+//
+// ListReactions returns all available reactions, using multiple paginated requests if needed.
+func (pr *pullrequest) ListReactions(ctx context.Context) ([]gitprovider.Reaction, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/award_emoji
+	apiObjs, err := pr.c.ListMergeRequestAwardEmoji(ctx, pr.pr.ProjectID, pr.pr.IID)
+	if err != nil {
+		return nil, err
+	}
+	reactions := make([]gitprovider.Reaction, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		reactions = append(reactions, newReaction(apiObj))
+	}
+	return reactions, nil
+}
+
 func pullrequestFromAPI(apiObj *gitlab.MergeRequest) gitprovider.PullRequestInfo {
+	assignees := make([]string, 0, len(apiObj.Assignees))
+	for _, assignee := range apiObj.Assignees {
+		assignees = append(assignees, assignee.Username)
+	}
+	var milestone *int
+	if apiObj.Milestone != nil {
+		milestone = &apiObj.Milestone.IID
+	}
 	return gitprovider.PullRequestInfo{
 		Title:        apiObj.Title,
 		Description:  apiObj.Description,
@@ -55,5 +297,34 @@ func pullrequestFromAPI(apiObj *gitlab.MergeRequest) gitprovider.PullRequestInfo
 		Number:       apiObj.IID,
 		WebURL:       apiObj.WebURL,
 		SourceBranch: apiObj.SourceBranch,
+		BaseBranch:   apiObj.TargetBranch,
+		State:        pullRequestStateFromAPI(apiObj.State),
+		Draft:        apiObj.Draft || apiObj.WorkInProgress,
+		Labels:       apiObj.Labels,
+		Assignees:    assignees,
+		Milestone:    milestone,
+	}
+}
+
+// pullRequestStateToAPI maps a gitprovider.PullRequestState to the state of a merge request.
+func pullRequestStateToAPI(state gitprovider.PullRequestState) string {
+	switch state {
+	case gitprovider.PullRequestStateMerged:
+		return mergedState
+	case gitprovider.PullRequestStateClosed:
+		return closedState
+	}
+	return openedState
+}
+
+// pullRequestStateFromAPI maps the state of a merge request to a gitprovider.PullRequestState.
+// Locked merge requests are about to be merged, and hence are considered open.
+func pullRequestStateFromAPI(state string) gitprovider.PullRequestState {
+	switch state {
+	case mergedState:
+		return gitprovider.PullRequestStateMerged
+	case closedState:
+		return gitprovider.PullRequestStateClosed
 	}
+	return gitprovider.PullRequestStateOpen
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newPullRequestComment wraps a note of the given merge request discussion.
+func newPullRequestComment(discussion *gitlab.Discussion, apiObj *gitlab.Note) *pullRequestComment {
+	return &pullRequestComment{
+		discussionID: discussion.ID,
+		inReplyTo:    discussionParent(discussion, apiObj),
+		n:            *apiObj,
+	}
+}
+
+// discussionParent returns the ID of the first note of the discussion if the note is a reply, or zero.
+func discussionParent(discussion *gitlab.Discussion, apiObj *gitlab.Note) int {
+	if discussion.IndividualNote || len(discussion.Notes) == 0 || discussion.Notes[0].ID == apiObj.ID {
+		return 0
+	}
+	return discussion.Notes[0].ID
+}
+
+var _ gitprovider.PullRequestComment = &pullRequestComment{}
+
+// pullRequestComment is a note of a merge request discussion. General comments are
+// discussions with a single, individual note.
+type pullRequestComment struct {
+	discussionID string
+	inReplyTo    int
+
+	n gitlab.Note
+}
+
+func (c *pullRequestComment) Get() gitprovider.PullRequestCommentInfo {
+	info := gitprovider.PullRequestCommentInfo{
+		ID:        int64(c.n.ID),
+		Author:    c.n.Author.Username,
+		Body:      c.n.Body,
+		InReplyTo: int64(c.inReplyTo),
+		Resolved:  c.n.Resolved,
+	}
+	if c.n.Position != nil {
+		info.Path = c.n.Position.NewPath
+		info.Line = c.n.Position.NewLine
+	}
+	if c.n.CreatedAt != nil {
+		info.CreatedAt = *c.n.CreatedAt
+	}
+	return info
+}
+
+func (c *pullRequestComment) APIObject() interface{} {
+	return &c.n
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// gitlabAwardEmojiNames maps the known reactions to the names of GitLab's award emojis.
+//
+//nolint:gochecknoglobals
+var gitlabAwardEmojiNames = map[gitprovider.ReactionContent]string{
+	gitprovider.ReactionContentThumbsUp:   "thumbsup",
+	gitprovider.ReactionContentThumbsDown: "thumbsdown",
+	gitprovider.ReactionContentLaugh:      "laughing",
+	gitprovider.ReactionContentConfused:   "confused",
+	gitprovider.ReactionContentHeart:      "heart",
+	gitprovider.ReactionContentHooray:     "tada",
+	gitprovider.ReactionContentRocket:     "rocket",
+	gitprovider.ReactionContentEyes:       "eyes",
+}
+
+func newReaction(apiObj *gitlab.AwardEmoji) *reaction {
+	return &reaction{
+		e: *apiObj,
+	}
+}
+
+var _ gitprovider.Reaction = &reaction{}
+
+type reaction struct {
+	e gitlab.AwardEmoji
+}
+
+func (r *reaction) Get() gitprovider.ReactionInfo {
+	return reactionFromAPI(&r.e)
+}
+
+func (r *reaction) APIObject() interface{} {
+	return &r.e
+}
+
+func reactionFromAPI(apiObj *gitlab.AwardEmoji) gitprovider.ReactionInfo {
+	return gitprovider.ReactionInfo{
+		ID:      int64(apiObj.ID),
+		Content: reactionContentFromAwardEmoji(apiObj.Name),
+		User:    apiObj.User.Username,
+	}
+}
+
+// reactionContentFromAwardEmoji returns the ReactionContent for the given award emoji name, or
+// the name itself if it isn't one of the known reactions.
+func reactionContentFromAwardEmoji(name string) gitprovider.ReactionContent {
+	for content, emoji := range gitlabAwardEmojiNames {
+		if emoji == name {
+			return content
+		}
+	}
+	return gitprovider.ReactionContent(name)
+}
+
+// getAwardEmojiName validates the requested reaction, and returns the matching award emoji name.
+func getAwardEmojiName(content gitprovider.ReactionContent) (string, error) {
+	validator := validation.New("Reaction")
+	validator.Append(gitprovider.ValidateReactionContent(content), content, "Content")
+	if err := validator.Error(); err != nil {
+		return "", err
+	}
+	return gitlabAwardEmojiNames[content], nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func Test_getAwardEmojiName(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      gitprovider.ReactionContent
+		want         string
+		expectedErrs []error
+	}{
+		{
+			name:    "thumbs up",
+			content: gitprovider.ReactionContentThumbsUp,
+			want:    "thumbsup",
+		},
+		{
+			name:    "hooray",
+			content: gitprovider.ReactionContentHooray,
+			want:    "tada",
+		},
+		{
+			name:         "unknown",
+			content:      gitprovider.ReactionContent("unicorn"),
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getAwardEmojiName(tt.content)
+			validation.TestExpectErrors(t, "getAwardEmojiName", err, tt.expectedErrs...)
+			if got != tt.want {
+				t.Errorf("getAwardEmojiName() = %q, want %q", got, tt.want)
+			}
+			// The award emoji name must map back to the same reaction
+			if err == nil && reactionContentFromAwardEmoji(got) != tt.content {
+				t.Errorf("reactionContentFromAwardEmoji(%q) = %q, want %q", got, reactionContentFromAwardEmoji(got), tt.content)
+			}
+		})
+	}
+}
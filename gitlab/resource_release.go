@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"io"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRelease(c *ReleaseClient, apiObj *gitlab.Release) *release {
+	return &release{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Release = &release{}
+
+type release struct {
+	r gitlab.Release
+	c *ReleaseClient
+}
+
+func (r *release) Get() gitprovider.ReleaseInfo {
+	return releaseFromAPI(&r.r)
+}
+
+// UploadAsset uploads content as a new asset of the release. GitLab release assets are links,
+// hence content is published to the generic package registry of the project, in a package named
+// after the repository and versioned after the tag, and linked to from the release. contentType
+// is ignored, as the package registry doesn't store it.
+//
+// ErrAlreadyExists is returned if the release already has an asset with the given name.
+func (r *release) UploadAsset(ctx context.Context, name, contentType string, content io.Reader) (gitprovider.ReleaseAsset, error) {
+	if err := r.Get().ValidateNewAsset(name); err != nil {
+		return gitprovider.ReleaseAsset{}, err
+	}
+	projectName := getRepoPath(r.c.ref)
+
+	// PUT /projects/{project}/packages/generic/{package_name}/{package_version}/{file_name}
+	packageURL, err := r.c.c.PublishGenericPackageFile(ctx, projectName, r.c.ref.GetRepository(), r.r.TagName, name, content)
+	if err != nil {
+		return gitprovider.ReleaseAsset{}, err
+	}
+
+	// POST /projects/{project}/releases/{tag_name}/assets/links
+	apiObj, err := r.c.c.CreateReleaseLink(ctx, projectName, r.r.TagName, &gitlab.CreateReleaseLinkOptions{
+		Name:     &name,
+		URL:      &packageURL,
+		FilePath: gitlab.String("/" + name),
+		LinkType: gitlab.LinkType(gitlab.PackageLinkType),
+	})
+	if err != nil {
+		return gitprovider.ReleaseAsset{}, err
+	}
+	r.r.Assets.Links = append(r.r.Assets.Links, apiObj)
+	return releaseAssetFromAPI(apiObj), nil
+}
+
+func (r *release) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *release) Repository() gitprovider.RepositoryRef {
+	return r.c.ref
+}
+
+func validateReleaseAPI(apiObj *gitlab.Release) error {
+	return validateAPIObject("GitLab.Release", func(validator validation.Validator) {
+		if apiObj.TagName == "" {
+			validator.Required("TagName")
+		}
+		for _, link := range apiObj.Assets.Links {
+			if link == nil || (link.URL == "" && link.DirectAssetURL == "") {
+				validator.Required("Assets.Links.URL")
+			}
+		}
+	})
+}
+
+func validateReleaseLinkAPI(apiObj *gitlab.ReleaseLink) error {
+	return validateAPIObject("GitLab.ReleaseLink", func(validator validation.Validator) {
+		if apiObj.URL == "" && apiObj.DirectAssetURL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+func releaseFromAPI(apiObj *gitlab.Release) gitprovider.ReleaseInfo {
+	info := gitprovider.ReleaseInfo{
+		TagName:     apiObj.TagName,
+		Name:        apiObj.Name,
+		Description: apiObj.Description,
+		Assets:      make([]gitprovider.ReleaseAsset, 0, len(apiObj.Assets.Links)),
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	for _, link := range apiObj.Assets.Links {
+		info.Assets = append(info.Assets, releaseAssetFromAPI(link))
+	}
+	return info
+}
+
+func releaseAssetFromAPI(apiObj *gitlab.ReleaseLink) gitprovider.ReleaseAsset {
+	// Prefer the permanent link, which redirects to the actual asset
+	downloadURL := apiObj.DirectAssetURL
+	if downloadURL == "" {
+		downloadURL = apiObj.URL
+	}
+	return gitprovider.ReleaseAsset{
+		ID:   int64(apiObj.ID),
+		Name: apiObj.Name,
+		// GitLab doesn't know the size of linked assets
+		DownloadURL: downloadURL,
+	}
+}
@@ -19,6 +19,7 @@ package gitlab
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/go-cmp/cmp"
 	gogitlab "github.com/xanzy/go-gitlab"
@@ -35,6 +36,34 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		deployTokens: &DeployTokenClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		labels: &LabelClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		milestones: &MilestoneClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		tagProtections: &TagProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -55,6 +84,7 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		actionsPermissions: &ActionsPermissionsClient{},
 	}
 }
 
@@ -66,12 +96,23 @@ type userProject struct {
 	p   gogitlab.Project
 	ref gitprovider.RepositoryRef
 
-	deployKeys   *DeployKeyClient
-	commits      *CommitClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	files        *FileClient
-	trees        *TreeClient
+	// initialCommitSHA is only set if the project was created with AutoInit.
+	initialCommitSHA *string
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
 }
 
 func (p *userProject) Get() gitprovider.RepositoryInfo {
@@ -82,8 +123,11 @@ func (p *userProject) Set(info gitprovider.RepositoryInfo) error {
 	if err := info.ValidateInfo(); err != nil {
 		return err
 	}
-	repositoryInfoToAPIObj(&info, &p.p)
-	return nil
+	return repositoryInfoToAPIObj(&info, &p.p)
+}
+
+func (p *userProject) InitialCommitSHA() *string {
+	return p.initialCommitSHA
 }
 
 func (p *userProject) APIObject() interface{} {
@@ -98,6 +142,34 @@ func (p *userProject) DeployKeys() gitprovider.DeployKeyClient {
 	return p.deployKeys
 }
 
+func (p *userProject) DeployTokens() gitprovider.DeployTokenClient {
+	return p.deployTokens
+}
+
+func (p *userProject) Labels() gitprovider.LabelClient {
+	return p.labels
+}
+
+func (p *userProject) Milestones() gitprovider.MilestoneClient {
+	return p.milestones
+}
+
+func (p *userProject) Releases() gitprovider.ReleaseClient {
+	return p.releases
+}
+
+func (p *userProject) Webhooks() gitprovider.WebhookClient {
+	return p.webhooks
+}
+
+func (p *userProject) BranchProtections() gitprovider.BranchProtectionClient {
+	return p.branchProtections
+}
+
+func (p *userProject) TagProtections() gitprovider.TagProtectionClient {
+	return p.tagProtections
+}
+
 func (p *userProject) Commits() gitprovider.CommitClient {
 	return p.commits
 }
@@ -118,6 +190,10 @@ func (p *userProject) Trees() gitprovider.TreeClient {
 	return p.trees
 }
 
+func (p *userProject) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return p.actionsPermissions
+}
+
 // The internal API object will be overridden with the received server data.
 func (p *userProject) Update(ctx context.Context) error {
 	// PATCH /repos/{owner}/{repo}
@@ -169,13 +245,104 @@ func (p *userProject) Reconcile(ctx context.Context) (bool, error) {
 	return true, p.Update(ctx)
 }
 
-// Delete deletes the current resource irreversibly.
+// SetDefaultBranch makes the given branch the default branch of the project.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (p *userProject) SetDefaultBranch(ctx context.Context, branch string) error {
+	// PUT /projects/{project}
+	apiObj, err := p.c.SetDefaultBranch(ctx, getRepoPath(p.ref), branch)
+	if err != nil {
+		return err
+	}
+	p.p = *apiObj
+	return nil
+}
+
+// Delete deletes the current resource irreversibly. GitLab deletes projects asynchronously,
+// set the WaitForCompletion call option to wait until the project is gone.
 //
 // ErrNotFound is returned if the resource doesn't exist anymore.
 func (p *userProject) Delete(ctx context.Context) error {
 	return p.c.DeleteProject(ctx, getRepoPath(p.ref))
 }
 
+// Star stars the project as the authenticated user.
+//
+// ErrNotFound is returned if the project doesn't exist.
+func (p *userProject) Star(ctx context.Context) error {
+	// POST /projects/{project}/star
+	return p.c.StarProject(ctx, getRepoPath(p.ref))
+}
+
+// Unstar removes the star of the authenticated user from the project.
+//
+// ErrNotFound is returned if the project doesn't exist.
+func (p *userProject) Unstar(ctx context.Context) error {
+	// POST /projects/{project}/unstar
+	return p.c.UnstarProject(ctx, getRepoPath(p.ref))
+}
+
+// IsStarred returns whether the authenticated user has starred the project.
+func (p *userProject) IsStarred(ctx context.Context) (bool, error) {
+	// GET /projects?starred=true
+	return p.c.IsProjectStarred(ctx, p.p.ID)
+}
+
+// subscriptionLevels maps repository subscriptions to GitLab notification levels.
+//
+//nolint:gochecknoglobals
+var subscriptionLevels = map[gitprovider.RepositorySubscription]gogitlab.NotificationLevelValue{
+	gitprovider.RepositorySubscriptionDefault:       gogitlab.GlobalNotificationLevel,
+	gitprovider.RepositorySubscriptionParticipating: gogitlab.ParticipatingNotificationLevel,
+	gitprovider.RepositorySubscriptionWatch:         gogitlab.WatchNotificationLevel,
+	gitprovider.RepositorySubscriptionIgnore:        gogitlab.DisabledNotificationLevel,
+	gitprovider.RepositorySubscriptionCustom:        gogitlab.CustomNotificationLevel,
+}
+
+// GetSubscription returns which notifications the authenticated user receives for the project,
+// based on its notification level. ErrNoProviderSupport is returned for the "mention" level, as
+// it has no RepositorySubscription equivalent.
+func (p *userProject) GetSubscription(ctx context.Context) (gitprovider.RepositorySubscription, error) {
+	// GET /projects/{project}/notification_settings
+	apiObj, err := p.c.GetProjectNotificationSettings(ctx, getRepoPath(p.ref))
+	if err != nil {
+		return "", err
+	}
+	for subscription, level := range subscriptionLevels {
+		if apiObj.Level == level {
+			return subscription, nil
+		}
+	}
+	return "", fmt.Errorf("notification level %q can't be represented: %w", apiObj.Level, gitprovider.ErrNoProviderSupport)
+}
+
+// SetSubscription sets which notifications the authenticated user receives for the project, by
+// setting its notification level. Setting RepositorySubscriptionCustom keeps the previously
+// selected custom events.
+//
+// ErrNotFound is returned if the project doesn't exist.
+func (p *userProject) SetSubscription(ctx context.Context, subscription gitprovider.RepositorySubscription) error {
+	if err := gitprovider.ValidateRepositorySubscription(subscription); err != nil {
+		return fmt.Errorf("invalid repository subscription %q: %w", subscription, err)
+	}
+	// PUT /projects/{project}/notification_settings
+	return p.c.UpdateProjectNotificationLevel(ctx, getRepoPath(p.ref), subscriptionLevels[subscription])
+}
+
+// IssueCounts returns the number of open and closed issues of the project, as counted by the
+// issue statistics of the project.
+//
+// ErrNotFound is returned if the project doesn't exist.
+func (p *userProject) IssueCounts(ctx context.Context) (gitprovider.IssueCounts, error) {
+	// GET /projects/{project}/issues_statistics
+	apiObj, err := p.c.GetProjectIssuesStatistics(ctx, getRepoPath(p.ref))
+	if err != nil {
+		return gitprovider.IssueCounts{}, err
+	}
+	counts := apiObj.Statistics.Counts
+	return gitprovider.IssueCounts{Open: counts.Opened, Closed: counts.Closed}, nil
+}
+
 func newGroupProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovider.RepositoryRef) *orgRepository {
 	return &orgRepository{
 		userProject: *newUserProject(ctx, apiObj, ref),
@@ -244,22 +411,25 @@ func (r *orgRepository) Reconcile(ctx context.Context) (bool, error) {
 
 func repositoryFromAPI(apiObj *gogitlab.Project) gitprovider.RepositoryInfo {
 	repo := gitprovider.RepositoryInfo{
-		Description:   &apiObj.Description,
-		DefaultBranch: &apiObj.DefaultBranch,
+		Description:         &apiObj.Description,
+		DefaultBranch:       &apiObj.DefaultBranch,
+		DeleteBranchOnMerge: &apiObj.RemoveSourceBranchAfterMerge,
 	}
 	repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility(apiObj.Visibility))
 	return repo
 }
 
-func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) gogitlab.Project {
+func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) (gogitlab.Project, error) {
 	apiObj := gogitlab.Project{
 		Name: *gitprovider.StringVar(ref.GetRepository()),
 	}
-	repositoryInfoToAPIObj(repo, &apiObj)
-	return apiObj
+	err := repositoryInfoToAPIObj(repo, &apiObj)
+	return apiObj, err
 }
 
-func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gogitlab.Project) {
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings GitLab doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gogitlab.Project) error {
 	if repo.Description != nil {
 		apiObj.Description = *repo.Description
 	}
@@ -269,8 +439,19 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gogitlab.P
 	if repo.Visibility != nil {
 		apiObj.Visibility = gitlabVisibilityMap[*repo.Visibility]
 	}
+	if repo.DeleteBranchOnMerge != nil {
+		apiObj.RemoveSourceBranchAfterMerge = *repo.DeleteBranchOnMerge
+	}
+	if repo.AllowAutoMerge != nil {
+		return errAllowAutoMergeUnsupported
+	}
+	return nil
 }
 
+// errAllowAutoMergeUnsupported is returned when AllowAutoMerge is set, as merge requests can
+// always be set to merge when the pipeline succeeds.
+var errAllowAutoMergeUnsupported = fmt.Errorf("gitlab doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+
 // This function copies over the fields that are part of create/update requests of a project
 // i.e. the desired spec of the repository. This allows us to separate "spec" from "status" fields.
 func newGitlabProjectSpec(project *gogitlab.Project) *gitlabProjectSpec {
@@ -284,6 +465,9 @@ func newGitlabProjectSpec(project *gogitlab.Project) *gitlabProjectSpec {
 
 			// Update-specific parameters
 			DefaultBranch: project.DefaultBranch,
+
+			// Merge request settings
+			RemoveSourceBranchAfterMerge: project.RemoveSourceBranchAfterMerge,
 		},
 	}
 }
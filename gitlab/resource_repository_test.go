@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_repositoryInfoToAPIObj_mergeSettings(t *testing.T) {
+	tests := []struct {
+		name                             string
+		info                             gitprovider.RepositoryInfo
+		wantRemoveSourceBranchAfterMerge bool
+		wantErr                          error
+	}{
+		{
+			name:                             "unset keeps the actual setting",
+			wantRemoveSourceBranchAfterMerge: true,
+		},
+		{
+			name:                             "disable branch deletion",
+			info:                             gitprovider.RepositoryInfo{DeleteBranchOnMerge: gitprovider.BoolVar(false)},
+			wantRemoveSourceBranchAfterMerge: false,
+		},
+		{
+			name: "auto-merge isn't supported, but branch deletion is still applied",
+			info: gitprovider.RepositoryInfo{
+				AllowAutoMerge:      gitprovider.BoolVar(true),
+				DeleteBranchOnMerge: gitprovider.BoolVar(false),
+			},
+			wantRemoveSourceBranchAfterMerge: false,
+			wantErr:                          gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiObj := &gogitlab.Project{RemoveSourceBranchAfterMerge: true}
+			err := repositoryInfoToAPIObj(&tt.info, apiObj)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("repositoryInfoToAPIObj() error = %v, want %v", err, tt.wantErr)
+			}
+			if apiObj.RemoveSourceBranchAfterMerge != tt.wantRemoveSourceBranchAfterMerge {
+				t.Errorf("RemoveSourceBranchAfterMerge = %v, want %v", apiObj.RemoveSourceBranchAfterMerge, tt.wantRemoveSourceBranchAfterMerge)
+			}
+			if got := repositoryFromAPI(apiObj).DeleteBranchOnMerge; *got != tt.wantRemoveSourceBranchAfterMerge {
+				t.Errorf("repositoryFromAPI().DeleteBranchOnMerge = %v, want %v", *got, tt.wantRemoveSourceBranchAfterMerge)
+			}
+		})
+	}
+}
+
+func TestUserProject_Star(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantErr     error
+		wantStarred bool
+	}{
+		{
+			name:        "star",
+			status:      http.StatusCreated,
+			body:        `{"id":42,"star_count":1}`,
+			wantStarred: true,
+		},
+		{
+			name:        "already starred",
+			status:      http.StatusNotModified,
+			wantStarred: true,
+		},
+		{
+			name:    "missing project",
+			status:  http.StatusNotFound,
+			body:    `{"message":"404 Project Not Found"}`,
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v4/projects/group/project/star", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			})
+			mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if q.Get("starred") != "true" || q.Get("id_after") != "41" || q.Get("id_before") != "43" {
+					t.Errorf("unexpected query %q", r.URL.RawQuery)
+				}
+				_, _ = fmt.Fprint(w, `[{"id":42}]`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			gl, err := gogitlab.NewClient("", gogitlab.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "group"},
+				RepositoryName:  "project",
+			}
+			p := newUserProject(&clientContext{c: &gitlabClientImpl{c: gl}}, &gogitlab.Project{ID: 42}, ref)
+
+			if err := p.Star(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Star() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			starred, err := p.IsStarred(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if starred != tt.wantStarred {
+				t.Errorf("IsStarred() = %v, want %v", starred, tt.wantStarred)
+			}
+		})
+	}
+}
+
+func TestUserProject_defaultBranch(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	project := `{"id":42,"name":"project","visibility":"private","default_branch":"main"}`
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		if r.Method == http.MethodPut {
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			bodies = append(bodies, body)
+			_, _ = fmt.Fprintf(w, `{"id":42,"name":"project","visibility":"private","default_branch":%q}`, body["default_branch"])
+			return
+		}
+		_, _ = fmt.Fprint(w, project)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group/project", handler)
+	mux.HandleFunc("/api/v4/projects/42", handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	gl, err := gogitlab.NewClient("", gogitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "group"},
+		RepositoryName:  "project",
+	}
+	apiObj := &gogitlab.Project{ID: 42, Name: "project", Visibility: gogitlab.PrivateVisibility, DefaultBranch: "main"}
+	p := newUserProject(&clientContext{c: &gitlabClientImpl{c: gl}}, apiObj, ref)
+
+	// A changed default branch is drift, which Reconcile applies
+	if err := p.Set(gitprovider.RepositoryInfo{DefaultBranch: gitprovider.StringVar("trunk")}); err != nil {
+		t.Fatal(err)
+	}
+	actionTaken, err := p.Reconcile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() didn't update the default branch")
+	}
+	if got := *p.Get().DefaultBranch; got != "trunk" {
+		t.Errorf("DefaultBranch = %q, want %q", got, "trunk")
+	}
+
+	if err := p.SetDefaultBranch(context.Background(), "release"); err != nil {
+		t.Fatal(err)
+	}
+	if got := *p.Get().DefaultBranch; got != "release" {
+		t.Errorf("DefaultBranch = %q, want %q", got, "release")
+	}
+
+	wantRequests := []string{
+		"GET /api/v4/projects/group/project",
+		"PUT /api/v4/projects/42",
+		"PUT /api/v4/projects/group/project",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	if len(bodies) != 2 || bodies[0]["default_branch"] != "trunk" || !reflect.DeepEqual(bodies[1], map[string]interface{}{"default_branch": "release"}) {
+		t.Errorf("bodies = %v", bodies)
+	}
+}
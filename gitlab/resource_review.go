@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newReview returns the approval of the merge request by user as a review.
+func newReview(user *gitlab.BasicUser) *review {
+	return &review{
+		u: *user,
+	}
+}
+
+var _ gitprovider.Review = &review{}
+
+// review is an approval of a merge request, GitLab has no review objects.
+type review struct {
+	u gitlab.BasicUser
+}
+
+func (r *review) Get() gitprovider.ReviewInfo {
+	return gitprovider.ReviewInfo{
+		ID:       int64(r.u.ID),
+		Reviewer: r.u.Username,
+		State:    gitprovider.ReviewStateApproved,
+	}
+}
+
+// APIObject returns the approving user.
+func (r *review) APIObject() interface{} {
+	return &r.u
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRunner(apiObj *gitlab.RunnerDetails, ref gitprovider.OrganizationRef) *runner {
+	return &runner{
+		r:   *apiObj,
+		ref: ref,
+	}
+}
+
+var _ gitprovider.Runner = &runner{}
+
+type runner struct {
+	r   gitlab.RunnerDetails
+	ref gitprovider.OrganizationRef
+}
+
+func (r *runner) Get() gitprovider.RunnerInfo {
+	return runnerFromAPI(&r.r)
+}
+
+func (r *runner) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *runner) Organization() gitprovider.OrganizationRef {
+	return r.ref
+}
+
+func runnerFromAPI(apiObj *gitlab.RunnerDetails) gitprovider.RunnerInfo {
+	// GitLab reports more fine-grained states (e.g. "stale" or "never_contacted"),
+	// all of which mean that the runner isn't able to pick up jobs.
+	status := gitprovider.RunnerStatusOffline
+	if apiObj.Online {
+		status = gitprovider.RunnerStatusOnline
+	}
+	// Runners registered without a name are identified by their description in the UI
+	name := apiObj.Name
+	if name == "" {
+		name = apiObj.Description
+	}
+	return gitprovider.RunnerInfo{
+		ID:     int64(apiObj.ID),
+		Name:   name,
+		Status: status,
+		Labels: apiObj.TagList,
+	}
+}
+
+// runnerDetailsFromList returns the details of a runner known from listing runners, i.e. without
+// the tags and the other fields only returned for a single runner.
+func runnerDetailsFromList(apiObj *gitlab.Runner) *gitlab.RunnerDetails {
+	return &gitlab.RunnerDetails{
+		ID:          apiObj.ID,
+		Name:        apiObj.Name,
+		Description: apiObj.Description,
+		Active:      apiObj.Active,
+		Paused:      apiObj.Paused,
+		IsShared:    apiObj.IsShared,
+		IPAddress:   apiObj.IPAddress,
+		RunnerType:  apiObj.RunnerType,
+		Online:      apiObj.Online,
+		Status:      apiObj.Status,
+	}
+}
+
+// validateRunnerAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRunnerAPI(apiObj *gitlab.Runner) error {
+	return validateAPIObject("GitLab.Runner", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// SystemWebhookEvents returns the webhook events GitLab supports for system hooks, mapped to the
+// settings of the hooks that enable them.
+func SystemWebhookEvents() gitprovider.WebhookEventMap {
+	return gitprovider.WebhookEventMap{
+		gitprovider.WebhookEventPush:        {"push_events"},
+		gitprovider.WebhookEventPullRequest: {"merge_requests_events"},
+		gitprovider.WebhookEventTag:         {"tag_push_events"},
+	}
+}
+
+func newSystemWebhook(c *SystemWebhookClient, apiObj *gitlab.Hook) *systemWebhook {
+	return &systemWebhook{
+		h: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.SystemWebhook = &systemWebhook{}
+
+type systemWebhook struct {
+	h gitlab.Hook
+	c *SystemWebhookClient
+}
+
+func (wh *systemWebhook) ID() string {
+	return strconv.Itoa(wh.h.ID)
+}
+
+func (wh *systemWebhook) Get() gitprovider.WebhookInfo {
+	return systemWebhookFromAPI(&wh.h)
+}
+
+func (wh *systemWebhook) APIObject() interface{} {
+	return &wh.h
+}
+
+// Delete deletes the system hook.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *systemWebhook) Delete(ctx context.Context) error {
+	// DELETE /hooks/{hook_id}
+	return wh.c.c.DeleteSystemHook(ctx, wh.h.ID)
+}
+
+func validateSystemHookAPI(apiObj *gitlab.Hook) error {
+	return validateAPIObject("GitLab.Hook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// validateSystemWebhookSupport returns ErrNoProviderSupport for any other content type than JSON,
+// and for the events system hooks aren't triggered by.
+func validateSystemWebhookSupport(info gitprovider.WebhookInfo) error {
+	if err := validateWebhookContentType(info); err != nil {
+		return err
+	}
+	for _, event := range info.Events {
+		if !SystemWebhookEvents().Supports(event) {
+			return fmt.Errorf("gitlab system hooks have no %s events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	return nil
+}
+
+// systemWebhookFromAPI maps the system hook through a project hook, as system hooks have a
+// subset of its events.
+func systemWebhookFromAPI(apiObj *gitlab.Hook) gitprovider.WebhookInfo {
+	return webhookFromAPI(&gitlab.ProjectHook{
+		URL:                 apiObj.URL,
+		PushEvents:          apiObj.PushEvents,
+		TagPushEvents:       apiObj.TagPushEvents,
+		MergeRequestsEvents: apiObj.MergeRequestsEvents,
+	})
+}
+
+func systemWebhookToAPI(info *gitprovider.WebhookInfo) *gitlab.AddHookOptions {
+	h := &gitlab.ProjectHook{}
+	webhookInfoToAPIObj(info, h)
+	return &gitlab.AddHookOptions{
+		URL:                 gitlab.String(h.URL),
+		Token:               info.Secret,
+		PushEvents:          gitlab.Bool(h.PushEvents),
+		TagPushEvents:       gitlab.Bool(h.TagPushEvents),
+		MergeRequestsEvents: gitlab.Bool(h.MergeRequestsEvents),
+	}
+}
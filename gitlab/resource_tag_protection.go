@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newTagProtection(c *TagProtectionClient, apiObj *gitlab.ProtectedTag) *tagProtection {
+	return &tagProtection{
+		t: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.TagProtection = &tagProtection{}
+
+type tagProtection struct {
+	t gitlab.ProtectedTag
+	c *TagProtectionClient
+}
+
+func (tp *tagProtection) Get() gitprovider.TagProtectionInfo {
+	return gitprovider.TagProtectionInfo{
+		Pattern: tp.t.Name,
+	}
+}
+
+func (tp *tagProtection) APIObject() interface{} {
+	return &tp.t
+}
+
+func (tp *tagProtection) Repository() gitprovider.RepositoryRef {
+	return tp.c.ref
+}
+
+// Delete unprotects the matching tags.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (tp *tagProtection) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/protected_tags/{name}
+	return tp.c.c.UnprotectTag(ctx, getRepoPath(tp.c.ref), tp.t.Name)
+}
+
+func validateProtectedTagAPI(apiObj *gitlab.ProtectedTag) error {
+	return validateAPIObject("GitLab.ProtectedTag", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
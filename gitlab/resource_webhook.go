@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newWebhook(c *WebhookClient, apiObj *gitlab.ProjectHook) *webhook {
+	return &webhook{
+		h: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+// webhookTestTrigger is the event of the payload sent by Ping.
+const webhookTestTrigger = "push_events"
+
+// WebhookEvents returns the webhook events GitLab supports for project and group hooks, mapped to
+// the settings of the hooks that enable them.
+func WebhookEvents() gitprovider.WebhookEventMap {
+	return gitprovider.WebhookEventMap{
+		gitprovider.WebhookEventPush:        {"push_events"},
+		gitprovider.WebhookEventPullRequest: {"merge_requests_events"},
+		gitprovider.WebhookEventTag:         {"tag_push_events"},
+		gitprovider.WebhookEventIssues:      {"issues_events"},
+		gitprovider.WebhookEventComment:     {"note_events"},
+		gitprovider.WebhookEventRelease:     {"releases_events"},
+	}
+}
+
+// projectHookEvent is a delivery of a project hook, which go-gitlab doesn't support yet.
+type projectHookEvent struct {
+	ID      int    `json:"id"`
+	Trigger string `json:"trigger"`
+	// ResponseStatus is the HTTP status code, or a message like "internal error" if the
+	// request failed.
+	ResponseStatus    string     `json:"response_status"`
+	ExecutionDuration float64    `json:"execution_duration"`
+	CreatedAt         *time.Time `json:"created_at"`
+}
+
+type webhook struct {
+	h gitlab.ProjectHook
+	// token is the secret set using Set, as GitLab doesn't return it.
+	token *string
+	c     *WebhookClient
+}
+
+func (wh *webhook) ID() string {
+	return strconv.Itoa(wh.h.ID)
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookContentType(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	if info.Secret != nil {
+		wh.token = info.Secret
+	}
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set, otherwise GitLab keeps the current secret.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, err := wh.c.c.UpdateHook(ctx, getRepoPath(wh.c.ref), wh.h.ID, &gitlab.EditProjectHookOptions{
+		URL:                 gitlab.String(wh.h.URL),
+		Token:               wh.token,
+		PushEvents:          gitlab.Bool(wh.h.PushEvents),
+		TagPushEvents:       gitlab.Bool(wh.h.TagPushEvents),
+		MergeRequestsEvents: gitlab.Bool(wh.h.MergeRequestsEvents),
+		IssuesEvents:        gitlab.Bool(wh.h.IssuesEvents),
+		NoteEvents:          gitlab.Bool(wh.h.NoteEvents),
+		ReleasesEvents:      gitlab.Bool(wh.h.ReleasesEvents),
+	})
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	wh.token = nil
+	return nil
+}
+
+// Delete deletes the webhook from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/hooks/{hook_id}
+	return wh.c.c.DeleteHook(ctx, getRepoPath(wh.c.ref), wh.h.ID)
+}
+
+// Deliveries lists the deliveries of the webhook, which GitLab keeps for 7 days. This requires
+// GitLab 17.3 or later.
+//
+// Deliveries returns all available deliveries, using multiple paginated requests if needed.
+func (wh *webhook) Deliveries(ctx context.Context) ([]gitprovider.WebhookDelivery, error) {
+	// GET /projects/{project}/hooks/{hook_id}/events
+	apiObjs, err := wh.c.c.ListHookEvents(ctx, getRepoPath(wh.c.ref), wh.h.ID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]gitprovider.WebhookDelivery, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		deliveries = append(deliveries, webhookDeliveryFromAPI(apiObj))
+	}
+	return deliveries, nil
+}
+
+// Redeliver sends the payload of the given delivery again. This requires GitLab 17.4 or later.
+//
+// ErrNotFound is returned if the delivery does not exist.
+func (wh *webhook) Redeliver(ctx context.Context, deliveryID string) error {
+	id, err := strconv.Atoi(deliveryID)
+	if err != nil {
+		return fmt.Errorf("invalid webhook delivery ID %q: %w", deliveryID, gitprovider.ErrInvalidArgument)
+	}
+	// POST /projects/{project}/hooks/{hook_id}/events/{hook_event_id}/resend
+	return wh.c.c.ResendHookEvent(ctx, getRepoPath(wh.c.ref), wh.h.ID, id)
+}
+
+// Ping makes GitLab send a test push event to the webhook. This requires GitLab 16.11 or later,
+// and at least one commit in the repository. GitLab only tells whether the receiver responded
+// with a 2xx status code, but not the status code itself.
+func (wh *webhook) Ping(ctx context.Context) (gitprovider.WebhookPingResult, error) {
+	// POST /projects/{project}/hooks/{hook_id}/test/{trigger}
+	err := wh.c.c.TestHook(ctx, getRepoPath(wh.c.ref), wh.h.ID, webhookTestTrigger)
+	// GitLab responds with 422 Unprocessable Entity and the reason if the delivery failed
+	glErrorResponse := &gitlab.ErrorResponse{}
+	if errors.As(err, &glErrorResponse) && glErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity {
+		var body struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(glErrorResponse.Body, &body)
+		return gitprovider.WebhookPingResult{Message: body.Message}, nil
+	}
+	if err != nil {
+		return gitprovider.WebhookPingResult{}, err
+	}
+	return gitprovider.WebhookPingResult{Successful: true}, nil
+}
+
+func validateWebhookAPI(apiObj *gitlab.ProjectHook) error {
+	return validateAPIObject("GitLab.ProjectHook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+func validateHookEventAPI(apiObj *projectHookEvent) error {
+	return validateAPIObject("GitLab.HookEvent", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+	})
+}
+
+// validateWebhookContentType returns ErrNoProviderSupport for any other content type than JSON.
+func validateWebhookContentType(info gitprovider.WebhookInfo) error {
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("gitlab only sends webhook payloads as JSON: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func webhookFromAPI(apiObj *gitlab.ProjectHook) gitprovider.WebhookInfo {
+	events := []gitprovider.WebhookEvent{}
+	if apiObj.PushEvents {
+		events = append(events, gitprovider.WebhookEventPush)
+	}
+	if apiObj.MergeRequestsEvents {
+		events = append(events, gitprovider.WebhookEventPullRequest)
+	}
+	if apiObj.TagPushEvents {
+		events = append(events, gitprovider.WebhookEventTag)
+	}
+	if apiObj.IssuesEvents {
+		events = append(events, gitprovider.WebhookEventIssues)
+	}
+	if apiObj.NoteEvents {
+		events = append(events, gitprovider.WebhookEventComment)
+	}
+	if apiObj.ReleasesEvents {
+		events = append(events, gitprovider.WebhookEventRelease)
+	}
+	return gitprovider.WebhookInfo{
+		URL:         apiObj.URL,
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Events:      events,
+	}
+}
+
+func webhookToAPI(info *gitprovider.WebhookInfo) *gitlab.AddProjectHookOptions {
+	h := &gitlab.ProjectHook{}
+	webhookInfoToAPIObj(info, h)
+	return &gitlab.AddProjectHookOptions{
+		URL:                 gitlab.String(h.URL),
+		Token:               info.Secret,
+		PushEvents:          gitlab.Bool(h.PushEvents),
+		TagPushEvents:       gitlab.Bool(h.TagPushEvents),
+		MergeRequestsEvents: gitlab.Bool(h.MergeRequestsEvents),
+		IssuesEvents:        gitlab.Bool(h.IssuesEvents),
+		NoteEvents:          gitlab.Bool(h.NoteEvents),
+		ReleasesEvents:      gitlab.Bool(h.ReleasesEvents),
+	}
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *gitlab.ProjectHook) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.URL = info.URL
+	apiObj.PushEvents = false
+	apiObj.TagPushEvents = false
+	apiObj.MergeRequestsEvents = false
+	apiObj.IssuesEvents = false
+	apiObj.NoteEvents = false
+	apiObj.ReleasesEvents = false
+	for _, event := range info.Events {
+		switch event {
+		case gitprovider.WebhookEventPush:
+			apiObj.PushEvents = true
+		case gitprovider.WebhookEventPullRequest:
+			apiObj.MergeRequestsEvents = true
+		case gitprovider.WebhookEventTag:
+			apiObj.TagPushEvents = true
+		case gitprovider.WebhookEventIssues:
+			apiObj.IssuesEvents = true
+		case gitprovider.WebhookEventComment:
+			apiObj.NoteEvents = true
+		case gitprovider.WebhookEventRelease:
+			apiObj.ReleasesEvents = true
+		}
+	}
+}
+
+func webhookDeliveryFromAPI(apiObj *projectHookEvent) gitprovider.WebhookDelivery {
+	delivery := gitprovider.WebhookDelivery{
+		ID:    strconv.Itoa(apiObj.ID),
+		Event: apiObj.Trigger,
+		// GitLab returns the duration in seconds
+		Duration: time.Duration(apiObj.ExecutionDuration * float64(time.Second)),
+	}
+	// The status is left 0 if no response was received
+	if statusCode, err := strconv.Atoi(apiObj.ResponseStatus); err == nil {
+		delivery.StatusCode = statusCode
+	}
+	if apiObj.CreatedAt != nil {
+		delivery.DeliveredAt = *apiObj.CreatedAt
+	}
+	return delivery
+}
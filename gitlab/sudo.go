@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"net/http"
+)
+
+// sudoHeader is the header making GitLab perform a request on behalf of another user.
+const sudoHeader = "Sudo"
+
+// sudoKey is the context key for the user given to WithSudo.
+type sudoKey struct{}
+
+// WithSudo returns a copy of ctx making GitLab API calls made with it act on behalf of user,
+// which is either a username or a numeric user ID, by sending the Sudo header. An empty user
+// makes calls act as the authenticated user again.
+//
+// Sudo requires an administrator token with the sudo scope, otherwise calls fail with
+// gitprovider.ErrForbidden. The header is only sent by clients created with NewClient of this
+// package, clients of other providers ignore it. As responses cached by conditional requests
+// aren't keyed by the Sudo header, don't combine WithSudo with WithConditionalRequests.
+func WithSudo(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, sudoKey{}, user)
+}
+
+// sudoFromContext returns the user given to WithSudo for ctx, or an empty string if unset.
+func sudoFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(sudoKey{}).(string)
+	return user
+}
+
+// sudoTransport sets the Sudo header of requests whose context was returned by WithSudo.
+type sudoTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sudoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	user := sudoFromContext(req.Context())
+	if user == "" {
+		return t.next.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	req.Header.Set(sudoHeader, user)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestWithSudo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Sudo") {
+		case "":
+			_, _ = fmt.Fprint(w, `[]`)
+		case "jdoe":
+			_, _ = fmt.Fprint(w, `[{"id":1,"name":"Flux","path":"flux","namespace":{"kind":"group","full_path":"fluxcd"}}]`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message":"403 Forbidden - Must be admin to use sudo"}`)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("token", "pat", gitprovider.WithDomain(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    int
+		wantErr error
+	}{
+		{name: "without sudo", ctx: context.Background(), want: 0},
+		{name: "sudo", ctx: WithSudo(context.Background(), "jdoe"), want: 1},
+		{name: "sudo reset", ctx: WithSudo(WithSudo(context.Background(), "jdoe"), ""), want: 0},
+		{name: "sudo not allowed", ctx: WithSudo(context.Background(), "admin"), wantErr: gitprovider.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.ListStarred(tt.ctx, "jdoe")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListStarred() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ListStarred() returned %d repositories, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,7 +26,8 @@ func getRepoPath(ref gitprovider.RepositoryRef) string {
 // allPages expects that the data is saved in fn to an outer variable.
 // allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
 // There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
-func allGroupPages(opts *gitlab.ListGroupsOptions, fn func() (*gitlab.Response, error)) error {
+func allGroupPages(ctx context.Context, opts *gitlab.ListGroupsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -38,7 +40,8 @@ func allGroupPages(opts *gitlab.ListGroupsOptions, fn func() (*gitlab.Response,
 	}
 }
 
-func allSubgroupPages(opts *gitlab.ListSubGroupsOptions, fn func() (*gitlab.Response, error)) error {
+func allSubgroupPages(ctx context.Context, opts *gitlab.ListSubGroupsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -51,7 +54,92 @@ func allSubgroupPages(opts *gitlab.ListSubGroupsOptions, fn func() (*gitlab.Resp
 	}
 }
 
-func allGroupProjectPages(opts *gitlab.ListGroupProjectsOptions, fn func() (*gitlab.Response, error)) error {
+func allDescendantGroupPages(ctx context.Context, opts *gitlab.ListDescendantGroupsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupRunnerPages(ctx context.Context, opts *gitlab.ListGroupsRunnersOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupIssueBoardPages(ctx context.Context, opts *gitlab.ListGroupIssueBoardsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupHookPages(ctx context.Context, opts *gitlab.ListGroupHooksOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupProjectPages(ctx context.Context, opts *gitlab.ListGroupProjectsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		// Stop early if the caller gave up in the meantime
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listProgress returns the progress after count items have been listed, with resp being the
+// response to the latest page. The total is taken from the X-Total header if present.
+func listProgress(resp *gitlab.Response, count int) gitprovider.ListProgress {
+	progress := gitprovider.ListProgress{Count: count, EstimatedTotal: resp.TotalItems}
+	if resp.NextPage == 0 {
+		progress.EstimatedTotal = count
+	}
+	return progress
+}
+
+func allGroupMemberPages(ctx context.Context, opts *gitlab.ListGroupMembersOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -64,7 +152,50 @@ func allGroupProjectPages(opts *gitlab.ListGroupProjectsOptions, fn func() (*git
 	}
 }
 
-func allGroupMemberPages(opts *gitlab.ListGroupMembersOptions, fn func() (*gitlab.Response, error)) error {
+func allProjectPages(ctx context.Context, opts *gitlab.ListProjectsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allLicenseTemplatePages(ctx context.Context, opts *gitlab.ListLicenseTemplatesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allTemplatePages(ctx context.Context, opts *gitlab.ListTemplatesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProjectUserPages(ctx context.Context, opts *gitlab.ListProjectUserOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -77,7 +208,8 @@ func allGroupMemberPages(opts *gitlab.ListGroupMembersOptions, fn func() (*gitla
 	}
 }
 
-func allProjectPages(opts *gitlab.ListProjectsOptions, fn func() (*gitlab.Response, error)) error {
+func allDeployKeyPages(ctx context.Context, opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -90,7 +222,8 @@ func allProjectPages(opts *gitlab.ListProjectsOptions, fn func() (*gitlab.Respon
 	}
 }
 
-func allProjectUserPages(opts *gitlab.ListProjectUserOptions, fn func() (*gitlab.Response, error)) error {
+func allLabelPages(ctx context.Context, opts *gitlab.ListLabelsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -103,7 +236,8 @@ func allProjectUserPages(opts *gitlab.ListProjectUserOptions, fn func() (*gitlab
 	}
 }
 
-func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gitlab.Response, error)) error {
+func allProjectHookPages(ctx context.Context, opts *gitlab.ListProjectHooksOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -116,6 +250,160 @@ func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gi
 	}
 }
 
+func allHookEventPages(ctx context.Context, opts *gitlab.ListOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProtectedBranchPages(ctx context.Context, opts *gitlab.ListProtectedBranchesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allBranchPages(ctx context.Context, opts *gitlab.ListBranchesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProtectedTagPages(ctx context.Context, opts *gitlab.ListProtectedTagsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMilestonePages(ctx context.Context, opts *gitlab.ListMilestonesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allReleasePages(ctx context.Context, opts *gitlab.ListReleasesOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allDeployTokenPages(ctx context.Context, opts *gitlab.ListProjectDeployTokensOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allCommitDiscussionPages(ctx context.Context, opts *gitlab.ListCommitDiscussionsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMergeRequestPages(ctx context.Context, opts *gitlab.ListProjectMergeRequestsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMergeRequestDiscussionPages(ctx context.Context, opts *gitlab.ListMergeRequestDiscussionsOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allAwardEmojiPages(ctx context.Context, opts *gitlab.ListAwardEmojiOptions, fn func() (*gitlab.Response, error)) error {
+	opts.PerPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PerPage)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // validateUserRepositoryRef makes sure the UserRepositoryRef is valid for GitHub's usage.
 func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
 	// Make sure the RepositoryRef fields are valid
@@ -178,6 +466,27 @@ func validateProjectAPI(apiObj *gitlab.Project) error {
 	})
 }
 
+// validateStarredProjectAPI validates a starred project received from the server, to make sure
+// that it can be referenced.
+func validateStarredProjectAPI(apiObj *gitlab.Project) error {
+	return validateAPIObject("GitLab.Project", func(validator validation.Validator) {
+		if apiObj.Path == "" {
+			validator.Required("Path")
+		}
+		if apiObj.Namespace == nil || apiObj.Namespace.FullPath == "" {
+			validator.Required("Namespace.FullPath")
+		}
+	})
+}
+
+func validateLicenseTemplateAPI(apiObj *gitlab.LicenseTemplate) error {
+	return validateAPIObject("GitLab.LicenseTemplate", func(validator validation.Validator) {
+		if apiObj.Key == "" {
+			validator.Required("Key")
+		}
+	})
+}
+
 // validateOrganizationRef makes sure the OrganizationRef is valid for GitHub's usage.
 func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
 	// Make sure the OrganizationRef fields are valid
@@ -197,10 +506,9 @@ func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string)
 	}
 	// Make sure the right type of identityref is used
 	switch ref.GetType() {
-	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser:
+	// Sub-organizations map to GitLab subgroups
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser, gitprovider.IdentityTypeSuborganization:
 		return nil
-	case gitprovider.IdentityTypeSuborganization:
-		return fmt.Errorf("gitlab doesn't support sub-organizations: %w", gitprovider.ErrNoProviderSupport)
 	}
 	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
 }
@@ -220,9 +528,15 @@ func handleHTTPError(err error) error {
 			ErrorMessage: glErrorResponse.Error(),
 			Message:      glErrorResponse.Message,
 		}
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		if glErrorResponse.Response.StatusCode == http.StatusForbidden {
+			return validation.NewMultiError(err,
+				&gitprovider.InvalidCredentialsError{HTTPError: httpErr},
+				gitprovider.ErrForbidden,
+			)
+		}
 		// Check for invalid credentials, and return a typed error in that case
-		if glErrorResponse.Response.StatusCode == http.StatusForbidden ||
-			glErrorResponse.Response.StatusCode == http.StatusUnauthorized {
+		if glErrorResponse.Response.StatusCode == http.StatusUnauthorized {
 			return validation.NewMultiError(err,
 				&gitprovider.InvalidCredentialsError{HTTPError: httpErr},
 			)
@@ -17,6 +17,7 @@ limitations under the License.
 package gitlab
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"testing"
@@ -119,7 +120,7 @@ func Test_allGroupPages(t *testing.T) {
 			// the page index are 1-based, and omitting page is the same as page=1
 			// set page=1 here just to be able to test more easily
 			tt.opts.Page = 1
-			err := allGroupPages(tt.opts, func() (*gitlab.Response, error) {
+			err := allGroupPages(context.Background(), tt.opts, func() (*gitlab.Response, error) {
 				i++
 				if tt.opts.Page != i {
 					t.Fatalf("page number is unexpected: got = %d want = %d", tt.opts.Page, i)
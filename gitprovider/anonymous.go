@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"net/http"
+)
+
+// WithAnonymous initializes a Client which doesn't send any credentials, e.g. for tools
+// inspecting public repositories which shouldn't need a token. Such a client only reads data:
+// the Get and List methods work as usual, as far as the provider allows anonymous access, while
+// all operations modifying data fail with a *CredentialsRequiredError without contacting the
+// provider. Requests made with a context carrying credentials, see WithContextCredentials, are
+// sent as usual.
+//
+// WithAnonymous can't be combined with any option configuring credentials, e.g. WithOAuth2Token;
+// pass an empty token to the NewClient functions of the providers.
+func WithAnonymous() ClientOption {
+	anonymous := true
+	return &ClientOptions{anonymous: &anonymous}
+}
+
+// readMethods are the HTTP methods anonymous clients send, as they don't modify data.
+var readMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// anonymousTransport is a ChainableRoundTripperFunc stripping the credentials of requests, and
+// failing those modifying data, unless the request context carries credentials.
+func anonymousTransport(in http.RoundTripper) http.RoundTripper {
+	if in == nil {
+		in = http.DefaultTransport
+	}
+	return &readOnlyTransport{next: in}
+}
+
+// readOnlyTransport only sends requests reading data, without credentials.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := CredentialsFromContext(req.Context()); ok {
+		return t.next.RoundTrip(req)
+	}
+
+	if !readMethods[req.Method] {
+		// RoundTrippers must always close the body
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, &CredentialsRequiredError{Method: req.Method, URL: req.URL.Redacted()}
+	}
+
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	for _, header := range authHeaders {
+		req.Header.Del(header)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithAnonymous(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.Header.Get("Authorization")+r.Header.Get("PRIVATE-TOKEN"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	opts, err := MakeClientOptions(WithAnonymous())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		method       string
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:         "read",
+			ctx:          context.Background(),
+			method:       http.MethodGet,
+			wantRequests: []string{"GET "},
+		},
+		{
+			name:    "write",
+			ctx:     context.Background(),
+			method:  http.MethodPost,
+			wantErr: true,
+		},
+		{
+			name:    "delete",
+			ctx:     context.Background(),
+			method:  http.MethodDelete,
+			wantErr: true,
+		},
+		{
+			name:         "write with context credentials",
+			ctx:          WithContextCredentials(context.Background(), Credentials{Token: "tenant-token"}),
+			method:       http.MethodPost,
+			wantRequests: []string{"POST Bearer tenant-token"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			req, err := http.NewRequestWithContext(tt.ctx, tt.method, srv.URL+"/repos", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Like e.g. the GitLab SDK, which sets the token itself
+			req.Header.Set("PRIVATE-TOKEN", "sdk-token")
+			resp, err := client.Do(req)
+			if tt.wantErr {
+				var credsErr *CredentialsRequiredError
+				if !errors.As(err, &credsErr) || !errors.Is(err, ErrCredentialsRequired) {
+					t.Fatalf("expected a *CredentialsRequiredError, got %v", err)
+				}
+				if credsErr.Method != tt.method || credsErr.URL != srv.URL+"/repos" {
+					t.Errorf("unexpected error: %v", credsErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				resp.Body.Close()
+			}
+			if strings.Join(requests, ",") != strings.Join(tt.wantRequests, ",") {
+				t.Errorf("got requests %q, want %q", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWithAnonymous_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+	}{
+		{
+			name: "duplicate",
+			opts: []ClientOption{WithAnonymous(), WithAnonymous()},
+		},
+		{
+			name: "with token",
+			opts: []ClientOption{WithAnonymous(), WithOAuth2Token("token")},
+		},
+		{
+			name: "token first",
+			opts: []ClientOption{WithOAuth2Token("token"), WithAnonymous()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MakeClientOptions(tt.opts...); !errors.Is(err, ErrInvalidClientOptions) {
+				t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+			}
+		})
+	}
+}
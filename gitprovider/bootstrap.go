@@ -0,0 +1,229 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// initialCommitMessage is the message of the commit created by CreateOrgRepositoryWithContents
+// and CreateUserRepositoryWithContents.
+const initialCommitMessage = "Initial commit"
+
+// defaultBranchWaitTimeout is how long CreateOrgRepositoryWithProtection and
+// CreateUserRepositoryWithProtection wait for the default branch to exist, if the context
+// doesn't have a deadline.
+const defaultBranchWaitTimeout = 2 * time.Minute
+
+// RepositoryProtectionResult reports what CreateOrgRepositoryWithProtection and
+// CreateUserRepositoryWithProtection created, and what was already present.
+type RepositoryProtectionResult struct {
+	// Protection is the protection rule of the default branch.
+	Protection BranchProtection
+
+	// RepositoryCreated is true if the repository was created, and false if it already existed.
+	RepositoryCreated bool
+
+	// ProtectionChanged is true if the protection rule was created or updated, and false if it
+	// already matched the requested one.
+	ProtectionChanged bool
+}
+
+// CreateOrgRepositoryWithContents creates an empty repository for the given organization, and
+// commits the given files to its default branch, which creates the branch. It returns the
+// repository along with the initial commit. The given options are passed on to
+// CommitClient.Create, e.g. to set the author of the initial commit to a service identity.
+//
+// The author of the returned commit is the one recorded by the provider. Providers always record
+// the authenticated user as the committer, and might not report the e-mail address of the author,
+// hence callers relying on a specific author should check the returned commit.
+//
+// CreateOrgRepositoryWithContents is idempotent: if the repository already exists, it is used
+// as is, and the files are only committed if its default branch doesn't have any commits yet.
+// Otherwise, the latest commit on the default branch is returned.
+func CreateOrgRepositoryWithContents(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef, req RepositoryInfo, files []CommitFile, opts ...CommitCreateOption) (OrgRepository, Commit, error) {
+	// Validate the options before creating anything
+	if _, err := MakeCommitCreateOptions(opts...); err != nil {
+		return nil, nil, err
+	}
+	repo, err := c.Create(ctx, ref, req, &RepositoryCreateOptions{AutoInit: BoolVar(false)})
+	if errors.Is(err, ErrAlreadyExists) {
+		repo, err = c.Get(ctx, ref)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := commitInitialContents(ctx, repo, files, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, commit, nil
+}
+
+// CreateUserRepositoryWithContents creates an empty repository for the given user, and commits
+// the given files to its default branch, which creates the branch. It returns the repository
+// along with the initial commit. The given options are passed on to CommitClient.Create.
+//
+// CreateUserRepositoryWithContents reports the author and is idempotent in the same way as
+// CreateOrgRepositoryWithContents.
+func CreateUserRepositoryWithContents(ctx context.Context, c UserRepositoriesClient, ref UserRepositoryRef, req RepositoryInfo, files []CommitFile, opts ...CommitCreateOption) (UserRepository, Commit, error) {
+	// Validate the options before creating anything
+	if _, err := MakeCommitCreateOptions(opts...); err != nil {
+		return nil, nil, err
+	}
+	repo, err := c.Create(ctx, ref, req, &RepositoryCreateOptions{AutoInit: BoolVar(false)})
+	if errors.Is(err, ErrAlreadyExists) {
+		repo, err = c.Get(ctx, ref)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := commitInitialContents(ctx, repo, files, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, commit, nil
+}
+
+// CreateOrgRepositoryWithProtection creates a repository for the given organization, initialized
+// with a first commit, and protects its default branch with the given rule. If the Branch of
+// protection is empty, the default branch of the repository is protected.
+//
+// CreateOrgRepositoryWithProtection is idempotent: if the repository already exists, it is used
+// as is, and the protection rule is reconciled. As some providers create the default branch
+// asynchronously, it waits for the branch to exist before protecting it, using the PollInterval
+// of the CallOptions carried by ctx. If ctx has no deadline, the wait is bounded by two minutes.
+func CreateOrgRepositoryWithProtection(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef, req RepositoryInfo, protection BranchProtectionInfo) (OrgRepository, *RepositoryProtectionResult, error) {
+	if err := validateInitialProtection(req, protection); err != nil {
+		return nil, nil, err
+	}
+	created := true
+	repo, err := c.Create(ctx, ref, req, &RepositoryCreateOptions{AutoInit: BoolVar(true)})
+	if errors.Is(err, ErrAlreadyExists) {
+		created = false
+		repo, err = c.Get(ctx, ref)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := protectDefaultBranch(ctx, repo, protection)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.RepositoryCreated = created
+	return repo, result, nil
+}
+
+// CreateUserRepositoryWithProtection creates a repository for the given user, initialized with a
+// first commit, and protects its default branch with the given rule. If the Branch of protection
+// is empty, the default branch of the repository is protected.
+//
+// CreateUserRepositoryWithProtection waits for the branch and is idempotent in the same way as
+// CreateOrgRepositoryWithProtection.
+func CreateUserRepositoryWithProtection(ctx context.Context, c UserRepositoriesClient, ref UserRepositoryRef, req RepositoryInfo, protection BranchProtectionInfo) (UserRepository, *RepositoryProtectionResult, error) {
+	if err := validateInitialProtection(req, protection); err != nil {
+		return nil, nil, err
+	}
+	created := true
+	repo, err := c.Create(ctx, ref, req, &RepositoryCreateOptions{AutoInit: BoolVar(true)})
+	if errors.Is(err, ErrAlreadyExists) {
+		created = false
+		repo, err = c.Get(ctx, ref)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := protectDefaultBranch(ctx, repo, protection)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.RepositoryCreated = created
+	return repo, result, nil
+}
+
+// validateInitialProtection validates the protection rule before the repository is created. The
+// Branch of protection defaults to the requested default branch of the repository.
+func validateInitialProtection(req RepositoryInfo, protection BranchProtectionInfo) error {
+	if protection.Branch == "" {
+		protection.Branch = defaultBranchName
+		if req.DefaultBranch != nil {
+			protection.Branch = *req.DefaultBranch
+		}
+	}
+	return protection.ValidateInfo()
+}
+
+// protectDefaultBranch waits for the protected branch of repo to exist, and reconciles the
+// protection rule. The Branch of protection defaults to the default branch of repo.
+func protectDefaultBranch(ctx context.Context, repo UserRepository, protection BranchProtectionInfo) (*RepositoryProtectionResult, error) {
+	if protection.Branch == "" {
+		protection.Branch = defaultBranchName
+		if info := repo.Get(); info.DefaultBranch != nil {
+			protection.Branch = *info.DefaultBranch
+		}
+	}
+
+	waitCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, defaultBranchWaitTimeout)
+		defer cancel()
+	}
+	err := PollUntilDone(waitCtx, func(ctx context.Context) (bool, error) {
+		commits, err := repo.Commits().ListPage(ctx, protection.Branch, 1, 0)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return len(commits) != 0, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for branch %q: %w", protection.Branch, err)
+	}
+
+	bp, actionTaken, err := repo.BranchProtections().Reconcile(ctx, protection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to protect branch %q: %w", protection.Branch, err)
+	}
+	return &RepositoryProtectionResult{Protection: bp, ProtectionChanged: actionTaken}, nil
+}
+
+// commitInitialContents commits files to the default branch of repo, unless the branch already
+// has commits, and returns the latest commit on the branch.
+func commitInitialContents(ctx context.Context, repo UserRepository, files []CommitFile, opts ...CommitCreateOption) (Commit, error) {
+	branch := defaultBranchName
+	if info := repo.Get(); info.DefaultBranch != nil {
+		branch = *info.DefaultBranch
+	}
+
+	// An empty repository either has no commits, or no branch to list the commits of
+	commits, err := repo.Commits().ListPage(ctx, branch, 1, 0)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to list commits of branch %q: %w", branch, err)
+	}
+	if len(commits) != 0 {
+		return commits[0], nil
+	}
+
+	commit, err := repo.Commits().Create(ctx, branch, initialCommitMessage, files, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create initial commit on branch %q: %w", branch, err)
+	}
+	return commit, nil
+}
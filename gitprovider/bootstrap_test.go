@@ -0,0 +1,346 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+type fakeCommit struct {
+	Commit
+	sha    string
+	author CommitAuthor
+}
+
+func (c *fakeCommit) Get() CommitInfo {
+	return CommitInfo{Sha: c.sha, Author: c.author.Name, AuthorEmail: c.author.Email}
+}
+
+// fakeBootstrapRepo is a repository with a single branch, which records the commits created on it.
+type fakeBootstrapRepo struct {
+	OrgRepository
+	CommitClient
+	commits []string
+	created []string
+}
+
+func (r *fakeBootstrapRepo) Get() RepositoryInfo {
+	return RepositoryInfo{DefaultBranch: StringVar("main")}
+}
+
+func (r *fakeBootstrapRepo) Commits() CommitClient { return r }
+
+func (r *fakeBootstrapRepo) ListPage(_ context.Context, branch string, _, _ int) ([]Commit, error) {
+	if branch != "main" || len(r.commits) == 0 {
+		return nil, ErrNotFound
+	}
+	return []Commit{&fakeCommit{sha: r.commits[len(r.commits)-1]}}, nil
+}
+
+func (r *fakeBootstrapRepo) Create(_ context.Context, branch, _ string, _ []CommitFile, opts ...CommitCreateOption) (Commit, error) {
+	o, err := MakeCommitCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Like the providers, default to the authenticated user
+	author := CommitAuthor{Name: "user", Email: "user@example.com"}
+	if o.Author != nil {
+		author = *o.Author
+	}
+	sha := "initial-" + branch
+	r.commits = append(r.commits, sha)
+	r.created = append(r.created, sha)
+	return &fakeCommit{sha: sha, author: author}, nil
+}
+
+type fakeBootstrapOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	repo    *fakeBootstrapRepo
+	creates int
+}
+
+func (c *fakeBootstrapOrgRepositoriesClient) Get(_ context.Context, _ OrgRepositoryRef) (OrgRepository, error) {
+	if c.repo == nil {
+		return nil, ErrNotFound
+	}
+	return c.repo, nil
+}
+
+func (c *fakeBootstrapOrgRepositoriesClient) Create(_ context.Context, _ OrgRepositoryRef, _ RepositoryInfo, opts ...RepositoryCreateOption) (OrgRepository, error) {
+	if o, err := MakeRepositoryCreateOptions(opts...); err != nil || o.AutoInit == nil || *o.AutoInit {
+		panic("expected AutoInit to be disabled")
+	}
+	if c.repo != nil {
+		return nil, ErrAlreadyExists
+	}
+	c.creates++
+	c.repo = &fakeBootstrapRepo{}
+	return c.repo, nil
+}
+
+func TestCreateOrgRepositoryWithContents(t *testing.T) {
+	tests := []struct {
+		name        string
+		repo        *fakeBootstrapRepo
+		wantSHA     string
+		wantCreates int
+		wantCommits int
+	}{
+		{
+			name:        "new repository",
+			wantSHA:     "initial-main",
+			wantCreates: 1,
+			wantCommits: 1,
+		},
+		{
+			name:        "existing empty repository",
+			repo:        &fakeBootstrapRepo{},
+			wantSHA:     "initial-main",
+			wantCommits: 1,
+		},
+		{
+			name:    "existing repository with commits",
+			repo:    &fakeBootstrapRepo{commits: []string{"first", "second"}},
+			wantSHA: "second",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeBootstrapOrgRepositoriesClient{repo: tt.repo}
+			ref := OrgRepositoryRef{
+				OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "org"},
+				RepositoryName:  "repo",
+			}
+			files := []CommitFile{{Path: StringVar("README.md"), Content: StringVar("hello")}}
+
+			_, commit, err := CreateOrgRepositoryWithContents(context.Background(), c, ref, RepositoryInfo{}, files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sha := commit.Get().Sha; sha != tt.wantSHA {
+				t.Errorf("sha = %q, want %q", sha, tt.wantSHA)
+			}
+			if c.creates != tt.wantCreates {
+				t.Errorf("repository creates = %d, want %d", c.creates, tt.wantCreates)
+			}
+			if len(c.repo.created) != tt.wantCommits {
+				t.Errorf("commits created = %d, want %d", len(c.repo.created), tt.wantCommits)
+			}
+
+			// Running it again must not change anything
+			_, commit, err = CreateOrgRepositoryWithContents(context.Background(), c, ref, RepositoryInfo{}, files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sha := commit.Get().Sha; sha != tt.wantSHA {
+				t.Errorf("sha on re-run = %q, want %q", sha, tt.wantSHA)
+			}
+			if len(c.repo.created) != tt.wantCommits {
+				t.Errorf("commits created on re-run = %d, want %d", len(c.repo.created), tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestCreateOrgRepositoryWithContents_Author(t *testing.T) {
+	ref := OrgRepositoryRef{
+		OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	files := []CommitFile{{Path: StringVar("README.md"), Content: StringVar("hello")}}
+
+	t.Run("service identity", func(t *testing.T) {
+		c := &fakeBootstrapOrgRepositoriesClient{}
+		author := CommitAuthor{Name: "Flux", Email: "flux@example.com"}
+		_, commit, err := CreateOrgRepositoryWithContents(context.Background(), c, ref, RepositoryInfo{}, files, &CommitCreateOptions{Author: &author})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := commit.Get(); got.Author != author.Name || got.AuthorEmail != author.Email {
+			t.Errorf("author = %q <%s>, want %q <%s>", got.Author, got.AuthorEmail, author.Name, author.Email)
+		}
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		c := &fakeBootstrapOrgRepositoriesClient{}
+		author := CommitAuthor{Name: "Flux", Email: "Flux <flux@example.com>"}
+		_, _, err := CreateOrgRepositoryWithContents(context.Background(), c, ref, RepositoryInfo{}, files, &CommitCreateOptions{Author: &author})
+		if !errors.Is(err, validation.ErrFieldInvalid) {
+			t.Errorf("error = %v, want %v", err, validation.ErrFieldInvalid)
+		}
+		if c.creates != 0 {
+			t.Errorf("repository creates = %d, want 0", c.creates)
+		}
+	})
+}
+
+// fakeProtectedRepo is a repository whose default branch shows up after a number of polls, and
+// which records its branch protection rules.
+type fakeProtectedRepo struct {
+	OrgRepository
+	CommitClient
+	pollsUntilBranch int
+	rules            map[string]BranchProtectionInfo
+	reconciles       int
+}
+
+// fakeProtections is the BranchProtectionClient of a fakeProtectedRepo.
+type fakeProtections struct {
+	BranchProtectionClient
+	repo *fakeProtectedRepo
+}
+
+func (r *fakeProtectedRepo) Get() RepositoryInfo {
+	return RepositoryInfo{DefaultBranch: StringVar("trunk")}
+}
+
+func (r *fakeProtectedRepo) Commits() CommitClient { return r }
+
+func (r *fakeProtectedRepo) BranchProtections() BranchProtectionClient {
+	return fakeProtections{repo: r}
+}
+
+func (r *fakeProtectedRepo) ListPage(_ context.Context, branch string, _, _ int) ([]Commit, error) {
+	if r.pollsUntilBranch > 0 {
+		r.pollsUntilBranch--
+		return nil, ErrNotFound
+	}
+	if branch != "trunk" {
+		return nil, ErrNotFound
+	}
+	return []Commit{&fakeCommit{sha: "initial"}}, nil
+}
+
+func (p fakeProtections) Reconcile(_ context.Context, req BranchProtectionInfo) (BranchProtection, bool, error) {
+	if p.repo.pollsUntilBranch > 0 {
+		panic("expected the branch to exist before protecting it")
+	}
+	p.repo.reconciles++
+	if actual, ok := p.repo.rules[req.Branch]; ok && req.Equals(actual) {
+		return nil, false, nil
+	}
+	p.repo.rules[req.Branch] = req
+	return nil, true, nil
+}
+
+type fakeProtectedOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	repo    *fakeProtectedRepo
+	polls   int
+	creates int
+}
+
+func (c *fakeProtectedOrgRepositoriesClient) Get(_ context.Context, _ OrgRepositoryRef) (OrgRepository, error) {
+	if c.repo == nil {
+		return nil, ErrNotFound
+	}
+	return c.repo, nil
+}
+
+func (c *fakeProtectedOrgRepositoriesClient) Create(_ context.Context, _ OrgRepositoryRef, _ RepositoryInfo, opts ...RepositoryCreateOption) (OrgRepository, error) {
+	if o, err := MakeRepositoryCreateOptions(opts...); err != nil || o.AutoInit == nil || !*o.AutoInit {
+		panic("expected AutoInit to be enabled")
+	}
+	if c.repo != nil {
+		return nil, ErrAlreadyExists
+	}
+	c.creates++
+	c.repo = &fakeProtectedRepo{pollsUntilBranch: c.polls, rules: map[string]BranchProtectionInfo{}}
+	return c.repo, nil
+}
+
+func TestCreateOrgRepositoryWithProtection(t *testing.T) {
+	ref := OrgRepositoryRef{
+		OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	interval := time.Millisecond
+	ctx := WithOptions(context.Background(), CallOptions{PollInterval: &interval})
+	protection := BranchProtectionInfo{RequiredApprovals: IntVar(1)}
+
+	c := &fakeProtectedOrgRepositoriesClient{polls: 2}
+	_, result, err := CreateOrgRepositoryWithProtection(ctx, c, ref, RepositoryInfo{}, protection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.RepositoryCreated || !result.ProtectionChanged {
+		t.Errorf("result = %+v, want the repository and protection to be created", result)
+	}
+	if _, ok := c.repo.rules["trunk"]; !ok {
+		t.Errorf("rules = %v, want the default branch to be protected", c.repo.rules)
+	}
+
+	// Running it again must not change anything
+	_, result, err = CreateOrgRepositoryWithProtection(ctx, c, ref, RepositoryInfo{}, protection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RepositoryCreated || result.ProtectionChanged {
+		t.Errorf("result on re-run = %+v, want everything to be present", result)
+	}
+	if c.creates != 1 {
+		t.Errorf("repository creates = %d, want 1", c.creates)
+	}
+
+	// A changed rule is updated on the existing repository
+	protection.RequiredApprovals = IntVar(2)
+	_, result, err = CreateOrgRepositoryWithProtection(ctx, c, ref, RepositoryInfo{}, protection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RepositoryCreated || !result.ProtectionChanged {
+		t.Errorf("result on change = %+v, want the protection to be updated", result)
+	}
+}
+
+func TestCreateOrgRepositoryWithProtection_Errors(t *testing.T) {
+	ref := OrgRepositoryRef{
+		OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "org"},
+		RepositoryName:  "repo",
+	}
+	interval := time.Millisecond
+	ctx := WithOptions(context.Background(), CallOptions{PollInterval: &interval})
+
+	t.Run("invalid protection", func(t *testing.T) {
+		c := &fakeProtectedOrgRepositoriesClient{}
+		_, _, err := CreateOrgRepositoryWithProtection(ctx, c, ref, RepositoryInfo{}, BranchProtectionInfo{RequiredApprovals: IntVar(-1)})
+		if !errors.Is(err, validation.ErrFieldInvalid) {
+			t.Errorf("error = %v, want %v", err, validation.ErrFieldInvalid)
+		}
+		if c.creates != 0 {
+			t.Errorf("repository creates = %d, want 0", c.creates)
+		}
+	})
+
+	t.Run("branch never shows up", func(t *testing.T) {
+		c := &fakeProtectedOrgRepositoriesClient{}
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, _, err := CreateOrgRepositoryWithProtection(ctx, c, ref, RepositoryInfo{}, BranchProtectionInfo{Branch: "missing"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if c.repo.reconciles != 0 {
+			t.Errorf("reconciles = %d, want 0", c.repo.reconciles)
+		}
+	})
+}
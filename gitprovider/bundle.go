@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+)
+
+// RepositoryBundle describes the desired state of a repository together with its sub-resources,
+// so that it can be reconciled in one call using ReconcileOrgRepositoryBundle or
+// ReconcileUserRepositoryBundle.
+type RepositoryBundle struct {
+	// Repository is the desired state of the repository itself.
+	// +required
+	Repository RepositoryInfo
+
+	// TeamAccess lists the teams that should have access to the repository. Only supported for
+	// organization repositories.
+	// +optional
+	TeamAccess []TeamAccessInfo
+
+	// DeployKeys lists the deploy keys that should be able to access the repository.
+	// +optional
+	DeployKeys []DeployKeyInfo
+}
+
+// ValidateInfo validates the repository and all sub-resources of the bundle.
+func (b RepositoryBundle) ValidateInfo() error {
+	if err := b.Repository.ValidateInfo(); err != nil {
+		return err
+	}
+	for _, ta := range b.TeamAccess {
+		if err := ta.ValidateInfo(); err != nil {
+			return err
+		}
+	}
+	for _, dk := range b.DeployKeys {
+		if err := dk.ValidateInfo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BundleResourceKind is the kind of a resource reconciled as part of a RepositoryBundle.
+type BundleResourceKind string
+
+const (
+	// BundleResourceKindRepository is the repository itself.
+	BundleResourceKindRepository = BundleResourceKind("Repository")
+	// BundleResourceKindTeamAccess is the access of a team to the repository.
+	BundleResourceKindTeamAccess = BundleResourceKind("TeamAccess")
+	// BundleResourceKindDeployKey is a deploy key of the repository.
+	BundleResourceKindDeployKey = BundleResourceKind("DeployKey")
+)
+
+// BundleStatus summarizes the outcome of reconciling a RepositoryBundle.
+type BundleStatus string
+
+const (
+	// BundleStatusUnchanged means that all resources already were in the desired state.
+	BundleStatusUnchanged = BundleStatus("Unchanged")
+	// BundleStatusUpdated means that all resources were reconciled, and at least one was changed.
+	BundleStatusUpdated = BundleStatus("Updated")
+	// BundleStatusPartiallyFailed means that the repository was reconciled, but at least one of
+	// its sub-resources failed to reconcile.
+	BundleStatusPartiallyFailed = BundleStatus("PartiallyFailed")
+	// BundleStatusFailed means that the repository failed to reconcile, hence none of its
+	// sub-resources were reconciled.
+	BundleStatusFailed = BundleStatus("Failed")
+)
+
+// BundleResourceResult is the outcome of reconciling a single resource of a RepositoryBundle.
+type BundleResourceResult struct {
+	// Kind is the kind of the resource.
+	Kind BundleResourceKind
+
+	// Name identifies the resource among the resources of the same kind, e.g. the name of the
+	// team or deploy key.
+	Name string
+
+	// ActionTaken is true if the resource was created or updated.
+	ActionTaken bool
+
+	// Err is set if reconciling the resource failed.
+	Err error
+}
+
+// RepositoryBundleResult is the outcome of reconciling a RepositoryBundle.
+type RepositoryBundleResult struct {
+	// Resources holds the outcome of every reconciled resource, in the order they were
+	// reconciled. The repository always comes first.
+	Resources []BundleResourceResult
+}
+
+// Status summarizes the outcome of all resources.
+func (r *RepositoryBundleResult) Status() BundleStatus {
+	status := BundleStatusUnchanged
+	for i, res := range r.Resources {
+		switch {
+		case res.Err != nil && i == 0:
+			return BundleStatusFailed
+		case res.Err != nil:
+			status = BundleStatusPartiallyFailed
+		case res.ActionTaken && status == BundleStatusUnchanged:
+			status = BundleStatusUpdated
+		}
+	}
+	return status
+}
+
+// Failed returns the outcome of the resources which failed to reconcile.
+func (r *RepositoryBundleResult) Failed() []BundleResourceResult {
+	failed := []BundleResourceResult{}
+	for _, res := range r.Resources {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing the failed resources, wrapping the error of the first one, or
+// nil if all resources were reconciled.
+func (r *RepositoryBundleResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	first := failed[0]
+	return fmt.Errorf("%d of %d resources failed to reconcile, first %s %q: %w", len(failed), len(r.Resources), first.Kind, first.Name, first.Err)
+}
+
+// ReconcileOrgRepositoryBundle reconciles the repository of the given bundle, and then its
+// sub-resources in dependency order: the access of teams, followed by the deploy keys. The
+// given options are passed on to OrgRepositoriesClient.Reconcile.
+//
+// Failing to reconcile a sub-resource doesn't stop the others, instead the error is reported in
+// the result of the resource, see RepositoryBundleResult.Status. An error is only returned if
+// the bundle is invalid, or if reconciling the repository itself fails, in which case the result
+// holds the repository failure only.
+func ReconcileOrgRepositoryBundle(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef, bundle RepositoryBundle, opts ...RepositoryReconcileOption) (OrgRepository, *RepositoryBundleResult, error) {
+	if err := bundle.ValidateInfo(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, actionTaken, err := c.Reconcile(ctx, ref, bundle.Repository, opts...)
+	res := &RepositoryBundleResult{
+		Resources: []BundleResourceResult{{Kind: BundleResourceKindRepository, Name: ref.GetRepository(), ActionTaken: actionTaken, Err: err}},
+	}
+	if err != nil {
+		return nil, res, err
+	}
+
+	for _, ta := range bundle.TeamAccess {
+		_, actionTaken, err := repo.TeamAccess().Reconcile(ctx, ta)
+		res.Resources = append(res.Resources, BundleResourceResult{Kind: BundleResourceKindTeamAccess, Name: ta.Name, ActionTaken: actionTaken, Err: err})
+	}
+	reconcileBundleDeployKeys(ctx, repo, bundle.DeployKeys, res)
+	return repo, res, nil
+}
+
+// ReconcileUserRepositoryBundle is like ReconcileOrgRepositoryBundle, but for repositories
+// owned by a user. ErrInvalidArgument is returned if the bundle specifies team access, as user
+// repositories can't be accessed by teams.
+func ReconcileUserRepositoryBundle(ctx context.Context, c UserRepositoriesClient, ref UserRepositoryRef, bundle RepositoryBundle, opts ...RepositoryReconcileOption) (UserRepository, *RepositoryBundleResult, error) {
+	if len(bundle.TeamAccess) != 0 {
+		return nil, nil, fmt.Errorf("team access can't be given to user repositories: %w", ErrInvalidArgument)
+	}
+	if err := bundle.ValidateInfo(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, actionTaken, err := c.Reconcile(ctx, ref, bundle.Repository, opts...)
+	res := &RepositoryBundleResult{
+		Resources: []BundleResourceResult{{Kind: BundleResourceKindRepository, Name: ref.GetRepository(), ActionTaken: actionTaken, Err: err}},
+	}
+	if err != nil {
+		return nil, res, err
+	}
+
+	reconcileBundleDeployKeys(ctx, repo, bundle.DeployKeys, res)
+	return repo, res, nil
+}
+
+// reconcileBundleDeployKeys reconciles the given deploy keys of repo, recording the outcome in res.
+func reconcileBundleDeployKeys(ctx context.Context, repo UserRepository, keys []DeployKeyInfo, res *RepositoryBundleResult) {
+	for _, dk := range keys {
+		_, actionTaken, err := repo.DeployKeys().Reconcile(ctx, dk)
+		res.Resources = append(res.Resources, BundleResourceResult{Kind: BundleResourceKindDeployKey, Name: dk.Name, ActionTaken: actionTaken, Err: err})
+	}
+}
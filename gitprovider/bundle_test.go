@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeBundleOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	repo *fakeBundleRepo
+	err  error
+}
+
+func (c *fakeBundleOrgRepositoriesClient) Reconcile(_ context.Context, _ OrgRepositoryRef, _ RepositoryInfo, _ ...RepositoryReconcileOption) (OrgRepository, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	return c.repo, false, nil
+}
+
+// fakeBundleRepo records the order its sub-resources are reconciled in.
+type fakeBundleRepo struct {
+	OrgRepository
+	reconciled []string
+}
+
+func (r *fakeBundleRepo) TeamAccess() TeamAccessClient { return &fakeBundleTeamAccessClient{repo: r} }
+func (r *fakeBundleRepo) DeployKeys() DeployKeyClient  { return &fakeBundleDeployKeyClient{repo: r} }
+
+type fakeBundleTeamAccessClient struct {
+	TeamAccessClient
+	repo *fakeBundleRepo
+}
+
+func (c *fakeBundleTeamAccessClient) Reconcile(_ context.Context, req TeamAccessInfo) (TeamAccess, bool, error) {
+	c.repo.reconciled = append(c.repo.reconciled, "team:"+req.Name)
+	if req.Name == "missing" {
+		return nil, false, ErrNotFound
+	}
+	return nil, true, nil
+}
+
+type fakeBundleDeployKeyClient struct {
+	DeployKeyClient
+	repo *fakeBundleRepo
+}
+
+func (c *fakeBundleDeployKeyClient) Reconcile(_ context.Context, req DeployKeyInfo) (DeployKey, bool, error) {
+	c.repo.reconciled = append(c.repo.reconciled, "key:"+req.Name)
+	return nil, false, nil
+}
+
+func TestReconcileOrgRepositoryBundle(t *testing.T) {
+	bundle := RepositoryBundle{
+		TeamAccess: []TeamAccessInfo{{Name: "missing"}, {Name: "admins"}},
+		DeployKeys: []DeployKeyInfo{{Name: "flux", Key: []byte("ssh-ed25519 AAAA")}},
+	}
+	repo := &fakeBundleRepo{}
+	c := &fakeBundleOrgRepositoriesClient{repo: repo}
+
+	got, res, err := ReconcileOrgRepositoryBundle(context.Background(), c, OrgRepositoryRef{RepositoryName: "repo"}, bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != OrgRepository(repo) {
+		t.Errorf("expected the reconciled repository")
+	}
+	want := []string{"team:missing", "team:admins", "key:flux"}
+	if len(repo.reconciled) != len(want) {
+		t.Fatalf("expected %v to be reconciled, got %v", want, repo.reconciled)
+	}
+	for i := range want {
+		if repo.reconciled[i] != want[i] {
+			t.Fatalf("expected %v to be reconciled, got %v", want, repo.reconciled)
+		}
+	}
+	if len(res.Resources) != 4 || res.Resources[0].Kind != BundleResourceKindRepository {
+		t.Fatalf("unexpected results: %+v", res.Resources)
+	}
+	if status := res.Status(); status != BundleStatusPartiallyFailed {
+		t.Errorf("expected status %s, got %s", BundleStatusPartiallyFailed, status)
+	}
+	if failed := res.Failed(); len(failed) != 1 || failed[0].Name != "missing" {
+		t.Errorf("unexpected failed resources: %+v", failed)
+	}
+	if !errors.Is(res.Err(), ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", res.Err())
+	}
+}
+
+func TestReconcileOrgRepositoryBundle_repositoryFailed(t *testing.T) {
+	bundle := RepositoryBundle{DeployKeys: []DeployKeyInfo{{Name: "flux", Key: []byte("ssh-ed25519 AAAA")}}}
+	c := &fakeBundleOrgRepositoriesClient{err: ErrNoProviderSupport}
+
+	_, res, err := ReconcileOrgRepositoryBundle(context.Background(), c, OrgRepositoryRef{}, bundle)
+	if !errors.Is(err, ErrNoProviderSupport) {
+		t.Fatalf("expected ErrNoProviderSupport, got %v", err)
+	}
+	if len(res.Resources) != 1 || res.Status() != BundleStatusFailed {
+		t.Errorf("expected only the failed repository, got %+v", res.Resources)
+	}
+}
+
+func TestReconcileOrgRepositoryBundle_invalid(t *testing.T) {
+	bundle := RepositoryBundle{DeployKeys: []DeployKeyInfo{{Name: "flux"}}}
+	c := &fakeBundleOrgRepositoriesClient{repo: &fakeBundleRepo{}}
+
+	if _, _, err := ReconcileOrgRepositoryBundle(context.Background(), c, OrgRepositoryRef{}, bundle); err == nil {
+		t.Error("expected an error for a deploy key without key")
+	}
+	if len(c.repo.reconciled) != 0 {
+		t.Errorf("expected nothing to be reconciled, got %v", c.repo.reconciled)
+	}
+}
+
+func TestReconcileUserRepositoryBundle_teamAccess(t *testing.T) {
+	bundle := RepositoryBundle{TeamAccess: []TeamAccessInfo{{Name: "admins"}}}
+	_, _, err := ReconcileUserRepositoryBundle(context.Background(), nil, UserRepositoryRef{}, bundle)
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
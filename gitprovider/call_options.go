@@ -0,0 +1,323 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CallOptions specifies options applying to all API calls made with a given context. CallOptions
+// are attached to a context using WithOptions, which allows setting them once for a whole subtree
+// of calls instead of passing them to each call. Using CallOptions is opt-in; the clients behave
+// as before for contexts without CallOptions.
+//
+// The options are applied in the following order of precedence, from highest to lowest:
+//
+//  1. Explicit arguments given to the call, e.g. the perPage argument of CommitClient.ListPage.
+//  2. CallOptions set with the innermost WithOptions call for the context.
+//  3. CallOptions set with WithOptions for a parent context.
+//  4. The defaults of the client.
+type CallOptions struct {
+	// PerPage sets the page size to request when listing resources.
+	// Non-positive values are ignored.
+	// +optional
+	PerPage *int
+
+	// Timeout bounds the duration of each HTTP request made with the context, including reading
+	// the response body. A shorter deadline already set on the context still applies.
+	// Non-positive values are ignored.
+	// +optional
+	Timeout *time.Duration
+
+	// WaitForCompletion makes calls to operations that the provider completes asynchronously only
+	// return once the operation has completed, by polling the affected resource. Otherwise such
+	// calls return as soon as the provider has accepted the operation, and follow-up calls might
+	// still observe the previous state. The asynchronous operations are documented on the
+	// provider clients; one example is deleting a GitLab project.
+	// +optional
+	WaitForCompletion *bool
+
+	// PollInterval is the initial interval at which the affected resource is polled if
+	// WaitForCompletion is set. The interval is doubled after every poll, up to 30 seconds.
+	// Non-positive values are ignored, defaulting to one second.
+	// +optional
+	PollInterval *time.Duration
+
+	// CommitVerification makes listing commits also fetch the verification of each commit's
+	// signature from providers that need an extra request per commit for it, e.g. GitLab.
+	// Otherwise the Verification of commits listed from those providers is nil.
+	// +optional
+	CommitVerification *bool
+
+	// RunnerLabels makes listing runners also fetch the labels of each runner from providers that
+	// need an extra request per runner for them, e.g. GitLab. Otherwise the Labels of runners
+	// listed from those providers are nil.
+	// +optional
+	RunnerLabels *bool
+
+	// Retries is the number of times each HTTP request made with the context is retried after a
+	// transient failure, i.e. a network error, a timeout, or a 429, 502, 503 or 504 response.
+	// Only idempotent requests are retried, i.e. GET, HEAD, OPTIONS, PUT and DELETE requests
+	// whose body can be replayed. The retries wait as long as the Retry-After header of the
+	// response says, or else one second, doubled after every retry, up to 30 seconds. Retries
+	// made by the provider SDKs, e.g. of go-gitlab, happen in addition. Non-positive values are
+	// ignored, i.e. requests aren't retried by default.
+	// +optional
+	Retries *int
+}
+
+const (
+	// defaultPollInterval is the default initial interval of PollUntilDone.
+	defaultPollInterval = time.Second
+	// maxPollInterval bounds the interval of PollUntilDone.
+	maxPollInterval = 30 * time.Second
+	// defaultRetryInterval is the initial interval between retries, see CallOptions.Retries.
+	defaultRetryInterval = time.Second
+	// maxRetryInterval bounds the interval between retries.
+	maxRetryInterval = 30 * time.Second
+)
+
+// callOptionsKey is the context key for CallOptions.
+type callOptionsKey struct{}
+
+// WithOptions returns a copy of ctx carrying opts. Fields set in opts take precedence over the
+// CallOptions already carried by ctx, unset fields are inherited from ctx.
+func WithOptions(ctx context.Context, opts CallOptions) context.Context {
+	merged := CallOptionsFromContext(ctx)
+	if opts.PerPage != nil {
+		merged.PerPage = opts.PerPage
+	}
+	if opts.Timeout != nil {
+		merged.Timeout = opts.Timeout
+	}
+	if opts.WaitForCompletion != nil {
+		merged.WaitForCompletion = opts.WaitForCompletion
+	}
+	if opts.PollInterval != nil {
+		merged.PollInterval = opts.PollInterval
+	}
+	if opts.CommitVerification != nil {
+		merged.CommitVerification = opts.CommitVerification
+	}
+	if opts.Retries != nil {
+		merged.Retries = opts.Retries
+	}
+	if opts.RunnerLabels != nil {
+		merged.RunnerLabels = opts.RunnerLabels
+	}
+	return context.WithValue(ctx, callOptionsKey{}, merged)
+}
+
+// CallOptionsFromContext returns the CallOptions carried by ctx. The zero value is returned if
+// WithOptions hasn't been used for ctx.
+func CallOptionsFromContext(ctx context.Context) CallOptions {
+	opts, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return opts
+}
+
+// GetPerPage returns the page size to use for a call. perPage is the page size given explicitly
+// to the call, and is returned if positive. Otherwise PerPage is returned if set, or 0 to signal
+// that the client default should be used.
+func (opts CallOptions) GetPerPage(perPage int) int {
+	if perPage > 0 {
+		return perPage
+	}
+	if opts.PerPage != nil && *opts.PerPage > 0 {
+		return *opts.PerPage
+	}
+	return 0
+}
+
+// ShouldWaitForCompletion returns whether calls to asynchronous operations should wait for the
+// operation to complete, see WaitForCompletion.
+func (opts CallOptions) ShouldWaitForCompletion() bool {
+	return opts.WaitForCompletion != nil && *opts.WaitForCompletion
+}
+
+// ShouldFetchCommitVerification returns whether listing commits should fetch the verification
+// of each commit's signature, see CommitVerification.
+func (opts CallOptions) ShouldFetchCommitVerification() bool {
+	return opts.CommitVerification != nil && *opts.CommitVerification
+}
+
+// ShouldFetchRunnerLabels returns whether listing runners should fetch the labels of each
+// runner, see RunnerLabels.
+func (opts CallOptions) ShouldFetchRunnerLabels() bool {
+	return opts.RunnerLabels != nil && *opts.RunnerLabels
+}
+
+// PollUntilDone calls poll until it reports that the asynchronous operation it checks is done,
+// waiting PollInterval of the CallOptions carried by ctx in between, with exponential backoff.
+// The first error returned by poll is returned as-is, and the context error is returned if ctx
+// is done before the operation.
+func PollUntilDone(ctx context.Context, poll func(ctx context.Context) (done bool, err error)) error {
+	interval := defaultPollInterval
+	if opts := CallOptionsFromContext(ctx); opts.PollInterval != nil && *opts.PollInterval > 0 {
+		interval = *opts.PollInterval
+	}
+	for {
+		done, err := poll(ctx)
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
+
+// newCallOptionsTransport is a ChainableRoundTripperFunc applying the CallOptions carried by the
+// request context to the request.
+func newCallOptionsTransport(in http.RoundTripper) http.RoundTripper {
+	if in == nil {
+		in = http.DefaultTransport
+	}
+	return &callOptionsTransport{next: in}
+}
+
+// callOptionsTransport applies the Timeout and Retries of the CallOptions carried by the
+// request context.
+type callOptionsTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *callOptionsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts := CallOptionsFromContext(req.Context())
+	retries := 0
+	if opts.Retries != nil && isRetryable(req) {
+		retries = *opts.Retries
+	}
+
+	interval := defaultRetryInterval
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req, opts.Timeout)
+		if attempt >= retries || !isTransientFailure(req, resp, err) {
+			return resp, err
+		}
+
+		wait := retryAfter(resp, interval)
+		if resp != nil {
+			// Drain the body, so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// roundTrip sends req once, bounded by timeout if it's positive.
+func (t *callOptionsTransport) roundTrip(req *http.Request, timeout *time.Duration) (*http.Response, error) {
+	if timeout == nil || *timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), *timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The context must stay alive until the body has been read
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// isRetryable returns whether req is idempotent, and its body can be replayed.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isTransientFailure returns whether the result of sending req is worth retrying.
+func isTransientFailure(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Don't retry once the caller gave up
+		if req.Context().Err() != nil {
+			return false
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns how long to wait before retrying, as requested by the Retry-After header of
+// resp, or else interval.
+func retryAfter(resp *http.Response, interval time.Duration) time.Duration {
+	if resp == nil {
+		return interval
+	}
+	header := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return interval
+}
+
+// cancelOnCloseBody cancels the request context once the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithOptions(t *testing.T) {
+	ten, twenty := 10, 20
+	timeout := time.Minute
+
+	outer := WithOptions(context.Background(), CallOptions{PerPage: &ten, Timeout: &timeout})
+	inner := WithOptions(outer, CallOptions{PerPage: &twenty})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		perPage     int
+		wantPerPage int
+		wantTimeout *time.Duration
+	}{
+		{
+			name:        "no options",
+			ctx:         context.Background(),
+			wantPerPage: 0,
+		},
+		{
+			name:        "outer options",
+			ctx:         outer,
+			wantPerPage: 10,
+			wantTimeout: &timeout,
+		},
+		{
+			name:        "inner options override outer, unset fields are inherited",
+			ctx:         inner,
+			wantPerPage: 20,
+			wantTimeout: &timeout,
+		},
+		{
+			name:        "explicit argument takes precedence",
+			ctx:         inner,
+			perPage:     5,
+			wantPerPage: 5,
+			wantTimeout: &timeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := CallOptionsFromContext(tt.ctx)
+			if got := opts.GetPerPage(tt.perPage); got != tt.wantPerPage {
+				t.Errorf("CallOptions.GetPerPage() = %d, want %d", got, tt.wantPerPage)
+			}
+			if opts.Timeout != tt.wantTimeout {
+				t.Errorf("CallOptions.Timeout = %v, want %v", opts.Timeout, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func Test_callOptionsTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newCallOptionsTransport(nil)}
+	timeout := 10 * time.Millisecond
+	ctx := WithOptions(context.Background(), CallOptions{Timeout: &timeout})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected request to time out, got %v", err)
+	}
+}
+
+func Test_callOptionsTransport_retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		retries      *int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{
+			name:         "no retries by default",
+			method:       http.MethodGet,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "retried until success",
+			method:       http.MethodGet,
+			retries:      intVar(5),
+			wantStatus:   http.StatusOK,
+			wantRequests: 3,
+		},
+		{
+			name:         "retries exhausted",
+			method:       http.MethodGet,
+			retries:      intVar(1),
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 2,
+		},
+		{
+			name:         "body replayed",
+			method:       http.MethodPut,
+			body:         "content",
+			retries:      intVar(5),
+			wantStatus:   http.StatusOK,
+			wantRequests: 3,
+		},
+		{
+			name:         "not idempotent",
+			method:       http.MethodPost,
+			body:         "content",
+			retries:      intVar(5),
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != tt.body {
+					t.Errorf("got body %q, want %q", body, tt.body)
+				}
+				// Fail the first two requests
+				if atomic.AddInt32(&requests, 1) <= 2 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: newCallOptionsTransport(nil)}
+			ctx := WithOptions(context.Background(), CallOptions{Retries: tt.retries})
+			req, err := http.NewRequestWithContext(ctx, tt.method, srv.URL, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestPollUntilDone(t *testing.T) {
+	interval := time.Millisecond
+	ctx := WithOptions(context.Background(), CallOptions{PollInterval: &interval})
+
+	polls := 0
+	err := PollUntilDone(ctx, func(context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	if err != nil || polls != 3 {
+		t.Errorf("PollUntilDone() = %v after %d polls, want nil after 3", err, polls)
+	}
+
+	err = PollUntilDone(ctx, func(context.Context) (bool, error) {
+		return false, ErrNotFound
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("PollUntilDone() = %v, want %v", err, ErrNotFound)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = PollUntilDone(timeoutCtx, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PollUntilDone() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package gitprovider
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Client is an interface that allows talking to a Git provider.
 type Client interface {
@@ -37,6 +40,44 @@ type Client interface {
 	// permission. Permissions should be coarse-grained and applicable to *all* providers.
 	HasTokenPermission(ctx context.Context, permission TokenPermission) (bool, error)
 
+	// CheckScopes reports which of the required scopes the token of the client lacks. The scopes
+	// are provider-specific, e.g. "admin:org" on GitHub or "api" on GitLab. Broader scopes granted
+	// to the token count for the narrower ones they include.
+	// ErrNoProviderSupport is returned if the provider can't tell the scopes of the token.
+	CheckScopes(ctx context.Context, requiredScopes []string) (*ScopeCheckResult, error)
+
+	// TokenInfo returns the scopes and expiry of the token of the client, e.g. to fail fast with
+	// an actionable error if the token lacks scopes or is about to expire.
+	// ErrNoProviderSupport is returned if the provider can't tell anything about the token.
+	TokenInfo(ctx context.Context) (*TokenInfo, error)
+
+	// GetOwnerType returns whether the given owner (e.g. the owner part of a repository URL) is a
+	// user or an organization. Sub-organizations can be given as "org/sub-org".
+	// ErrNotFound is returned if the owner doesn't exist, and ErrUnknownOwnerType if the provider
+	// returns an owner type that isn't known. See NewOwnerResolver for a cached variant.
+	GetOwnerType(ctx context.Context, owner string) (OwnerType, error)
+
+	// ListStarred returns references to the repositories the given user has starred. Only public
+	// information is used, hence this works for any user. The references are either
+	// OrgRepositoryRefs or UserRepositoryRefs, depending on the owner of the repository.
+	// ErrNotFound is returned if the user doesn't exist, and an empty list if the user has no stars.
+	// ErrNoProviderSupport is returned if the provider has no notion of starring repositories.
+	//
+	// ListStarred returns all starred repositories, using multiple paginated requests if needed.
+	ListStarred(ctx context.Context, username string) ([]RepositoryRef, error)
+
+	// ListLicenseTemplates returns the license templates the provider offers when creating
+	// repositories. Unlike the LicenseTemplate constants, this is the full, live list of the
+	// provider, e.g. for offering all licenses in a UI. Note that only known LicenseTemplate
+	// values pass validation of RepositoryCreateOptions.
+	// ErrNoProviderSupport is returned if the provider has no license templates.
+	ListLicenseTemplates(ctx context.Context) ([]LicenseTemplateInfo, error)
+
+	// ListGitignoreTemplates returns the names of the .gitignore templates the provider offers,
+	// e.g. "Go". The list is fetched once and cached for the lifetime of the client.
+	// ErrNoProviderSupport is returned if the provider has no .gitignore templates.
+	ListGitignoreTemplates(ctx context.Context) ([]string, error)
+
 	// Raw returns the Go client used under the hood to access the Git provider.
 	Raw() interface{}
 }
@@ -78,6 +119,28 @@ type OrganizationsClient interface {
 	// Children returns all available organizations, using multiple paginated requests if needed.
 	Children(ctx context.Context, o OrganizationRef) ([]Organization, error)
 
+	// GetDefaultRepositoryPermission returns the permission all members of the organization
+	// have on its repositories, which is RepositoryPermissionNone if they only have access to
+	// repositories they are granted access to explicitly.
+	//
+	// ErrForbidden is returned if the caller isn't an administrator of the organization.
+	// ErrNoProviderSupport is returned if the provider has no default repository permission.
+	GetDefaultRepositoryPermission(ctx context.Context, o OrganizationRef) (RepositoryPermission, error)
+
+	// SetDefaultRepositoryPermission sets the permission all members of the organization have on
+	// its repositories. See ValidateDefaultRepositoryPermission for the allowed values.
+	//
+	// ErrForbidden is returned if the caller isn't an administrator of the organization.
+	// ErrNoProviderSupport is returned if the provider has no default repository permission.
+	SetDefaultRepositoryPermission(ctx context.Context, o OrganizationRef, permission RepositoryPermission) error
+
+	// ReconcileDefaultRepositoryPermission makes sure the default repository permission of the
+	// organization is the given one.
+	//
+	// If the permission differs from the actual one, it is set (actionTaken == true).
+	// If the permission is already the actual one, this is a no-op (actionTaken == false).
+	ReconcileDefaultRepositoryPermission(ctx context.Context, o OrganizationRef, permission RepositoryPermission) (actionTaken bool, err error)
+
 	// Possibly add Create/Update/Delete methods later
 }
 
@@ -93,6 +156,20 @@ type OrgRepositoriesClient interface {
 	// List returns all available repositories, using multiple paginated requests if needed.
 	List(ctx context.Context, o OrganizationRef) ([]OrgRepository, error)
 
+	// ListWithProgress lists all repositories in the given organization like List, and calls
+	// progress after each page, e.g. for rendering a progress bar while listing huge
+	// organizations. Listing stops with the error of ctx if it is cancelled between pages.
+	//
+	// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+	ListWithProgress(ctx context.Context, o OrganizationRef, progress ListProgressFunc) ([]OrgRepository, error)
+
+	// SearchPage returns the given page of repositories in the given organization, that match
+	// all filters set in opts. Pages are numbered from 1, and hold at most perPage repositories.
+	//
+	// validation.ErrFieldRequired is returned if a filter is set, but empty.
+	// ErrNoProviderSupport is returned if the provider can't search repositories.
+	SearchPage(ctx context.Context, o OrganizationRef, perPage, page int, opts ...RepositorySearchOption) ([]OrgRepository, error)
+
 	// Create creates a repository for the given organization, with the data and options.
 	//
 	// ErrAlreadyExists will be returned if the resource already exists.
@@ -104,6 +181,15 @@ type OrgRepositoriesClient interface {
 	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 	// If req is already the actual state, this is a no-op (actionTaken == false).
 	Reconcile(ctx context.Context, r OrgRepositoryRef, req RepositoryInfo, opts ...RepositoryReconcileOption) (resp OrgRepository, actionTaken bool, err error)
+
+	// Transfer transfers the repository to the organization newOwner, keeping its name. As the
+	// provider may transfer asynchronously, Transfer waits until the repository is visible in
+	// newOwner. Team access is lost when transferring, but can be re-applied in newOwner using
+	// RepositoryTransferOptions.TeamAccess, the outcome per team is part of the result.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	// ErrNoProviderSupport is returned if the provider can't transfer repositories.
+	Transfer(ctx context.Context, r OrgRepositoryRef, newOwner OrganizationRef, opts ...RepositoryTransferOption) (*RepositoryTransferResult, error)
 }
 
 // UserRepositoriesClient operates on repositories for users.
@@ -147,13 +233,157 @@ type TeamsClient interface {
 	Get(ctx context.Context, name string) (Team, error)
 
 	// List all teams (recursively, in terms of subgroups) within the specific organization.
+	// Only the top-level teams are returned if the TopLevelOnly option is set.
+	// Use WalkTeamHierarchy to traverse the returned teams from parent to child.
 	//
 	// List returns all available organizations, using multiple paginated requests if needed.
-	List(ctx context.Context) ([]Team, error)
+	List(ctx context.Context, opts ...TeamListOption) ([]Team, error)
+
+	// SetParent moves the team with the given name below the parent team with the given name.
+	// If parentName is an empty string, the team is moved to the top level of the organization.
+	// In GitLab, the subgroup is transferred to the parent subgroup.
+	// This requires admin permissions in the organization.
+	//
+	// ErrNotFound is returned if either team does not exist.
+	// ErrInvalidArgument is returned if the move would make the team its own ancestor.
+	// ErrForbidden is returned if the credentials lack the permissions to move the team.
+	// ErrNoProviderSupport is returned if the provider doesn't support nested teams.
+	SetParent(ctx context.Context, name, parentName string) error
 
 	// Possibly add Create/Update/Delete methods later
 }
 
+// RunnersClient operates on the self-hosted CI runners of a specific organization.
+// This client can be accessed through Organization.Runners().
+type RunnersClient interface {
+	// List all self-hosted runners registered for the specific organization.
+	// This requires admin permissions in the organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support self-hosted runners.
+	// ErrForbidden is returned if the credentials lack the permissions to list runners.
+	//
+	// List returns all available runners, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Runner, error)
+
+	// CreateRegistrationToken generates a token that can be used to register a new self-hosted
+	// runner in the specific organization. This requires admin permissions in the organization.
+	// The returned token is a secret, and must not be logged.
+	//
+	// Providers with a single registration token per organization (e.g. GitLab) reset it, which
+	// invalidates the previous token: runners registering with it afterwards fail, while already
+	// registered runners keep working. Providers issuing short-lived tokens (e.g. GitHub) leave
+	// the previously created tokens valid.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support self-hosted runners.
+	// ErrForbidden is returned if the credentials lack the permissions to create the token.
+	CreateRegistrationToken(ctx context.Context) (RunnerRegistrationToken, error)
+
+	// Delete removes the self-hosted runner with the given ID from the specific organization.
+	// This requires admin permissions in the organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support self-hosted runners.
+	// ErrNotFound is returned if the runner does not exist.
+	// ErrForbidden is returned if the credentials lack the permissions to delete the runner.
+	Delete(ctx context.Context, id int64) error
+}
+
+// AppAuthorizationsClient operates on the applications that have been authorized to access
+// a specific organization, e.g. installed GitHub Apps.
+// This client can be accessed through Organization.AppAuthorizations().
+type AppAuthorizationsClient interface {
+	// List all applications authorized to access the specific organization.
+	// This requires admin permissions in the organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support organization-wide app authorizations.
+	// ErrForbidden is returned if the credentials lack the permissions to list authorizations.
+	//
+	// List returns all available authorizations, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]AppAuthorization, error)
+
+	// Revoke removes the authorization with the given ID from the specific organization.
+	// This requires admin permissions in the organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support organization-wide app authorizations.
+	// ErrNotFound is returned if the authorization does not exist.
+	// ErrForbidden is returned if the credentials lack the permissions to revoke the authorization.
+	Revoke(ctx context.Context, id int64) error
+}
+
+// ProjectBoardsClient operates on the project boards of a specific organization.
+// This client can be accessed through Organization.ProjectBoards().
+type ProjectBoardsClient interface {
+	// List all project boards of the specific organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support project boards.
+	//
+	// List returns all available project boards, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]ProjectBoard, error)
+}
+
+// OrganizationWebhookClient operates on the webhooks of a specific organization.
+// This client can be accessed through Organization.Webhooks().
+type OrganizationWebhookClient interface {
+	// Get a webhook by its URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (OrganizationWebhook, error)
+
+	// List all webhooks of the given organization.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support organization webhooks.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]OrganizationWebhook, error)
+
+	// Create a webhook with the given specifications.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support the content type or one of
+	// the events.
+	Create(ctx context.Context, req WebhookInfo) (OrganizationWebhook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing webhook is matched by its URL.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	// The secret is part of the comparison only where the provider tells whether one is set
+	// (GitHub). WebhookReconcileOptions.ForceSecretRotation recreates the webhook with the
+	// desired secret instead (actionTaken == true).
+	Reconcile(ctx context.Context, req WebhookInfo, opts ...WebhookReconcileOption) (resp OrganizationWebhook, actionTaken bool, err error)
+}
+
+// SystemWebhookClient operates on the instance-wide webhooks of a Git provider, which are
+// triggered by the events in all repositories. Managing them requires administrator access.
+// This client can be accessed through SystemWebhooks().
+type SystemWebhookClient interface {
+	// Get returns the webhook with the given URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (SystemWebhook, error)
+
+	// List lists all instance-wide webhooks.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]SystemWebhook, error)
+
+	// Create a webhook with the given specifications.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support the content type or one of
+	// the events.
+	Create(ctx context.Context, req WebhookInfo) (SystemWebhook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing webhook is matched by its URL.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be recreated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	// WebhookReconcileOptions.ForceSecretRotation recreates the webhook with the desired secret
+	// (actionTaken == true).
+	Reconcile(ctx context.Context, req WebhookInfo, opts ...WebhookReconcileOption) (resp SystemWebhook, actionTaken bool, err error)
+}
+
 // TeamAccessClient operates on the teams list for a specific repository.
 // This client can be accessed through Repository.TeamAccess().
 type TeamAccessClient interface {
@@ -192,7 +422,8 @@ type DeployKeyClient interface {
 	//
 	// List returns all available deploy keys for the given type,
 	// using multiple paginated requests if needed.
-	List(ctx context.Context) ([]DeployKey, error)
+	// The keys can be sorted by the time they were last used, see DeployKeyListOptions.
+	List(ctx context.Context, opts ...DeployKeyListOption) ([]DeployKey, error)
 
 	// Create a deploy key with the given specifications.
 	//
@@ -207,6 +438,178 @@ type DeployKeyClient interface {
 	Reconcile(ctx context.Context, req DeployKeyInfo) (resp DeployKey, actionTaken bool, err error)
 }
 
+// DeployTokenClient operates on the deploy tokens for a specific repository.
+// This client can be accessed through Repository.DeployTokens().
+type DeployTokenClient interface {
+	// Get a DeployToken by its name.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, name string) (DeployToken, error)
+
+	// List all deploy tokens for the given repository.
+	//
+	// List returns all available deploy tokens, using multiple paginated requests if needed.
+	// The token values are not part of the result.
+	List(ctx context.Context) ([]DeployToken, error)
+
+	// Create a deploy token with the given specifications.
+	// This is the only time the token value is available, see DeployToken.Token().
+	Create(ctx context.Context, req DeployTokenInfo) (DeployToken, error)
+}
+
+// LabelClient operates on the labels of issues and pull requests for a specific repository.
+// This client can be accessed through Repository.Labels().
+type LabelClient interface {
+	// Get a label by its name.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, name string) (Label, error)
+
+	// List all labels of the given repository.
+	//
+	// List returns all available labels, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Label, error)
+
+	// Create a label with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req LabelInfo) (Label, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing label is found as described in LabelReconcileOptions, which allows renaming labels.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req LabelInfo, opts ...LabelReconcileOption) (resp Label, actionTaken bool, err error)
+}
+
+// WebhookClient operates on the webhooks for a specific repository.
+// This client can be accessed through Repository.Webhooks().
+type WebhookClient interface {
+	// Get a webhook by its URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (Webhook, error)
+
+	// List all webhooks of the given repository.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Webhook, error)
+
+	// Create a webhook with the given specifications.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support the content type or one of
+	// the events.
+	Create(ctx context.Context, req WebhookInfo) (Webhook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing webhook is matched by its URL.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	// The secret is part of the comparison only where the provider tells whether one is set
+	// (GitHub). WebhookReconcileOptions.ForceSecretRotation recreates the webhook with the
+	// desired secret instead (actionTaken == true).
+	Reconcile(ctx context.Context, req WebhookInfo, opts ...WebhookReconcileOption) (resp Webhook, actionTaken bool, err error)
+}
+
+// BranchProtectionClient operates on the branch protection rules for a specific repository.
+// This client can be accessed through Repository.BranchProtections().
+type BranchProtectionClient interface {
+	// Get the protection rule of the given branch. The rule is matched by the branch exactly, i.e.
+	// the rule of a wildcard pattern is only returned for the pattern itself.
+	//
+	// ErrNotFound is returned if the branch isn't protected.
+	Get(ctx context.Context, branch string) (BranchProtection, error)
+
+	// List the protection rules of all protected branches of the given repository.
+	//
+	// List returns all available protection rules, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]BranchProtection, error)
+
+	// Create a protection rule with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the branch is already protected.
+	// ErrNoProviderSupport is returned if the provider can't enforce one of the settings.
+	Create(ctx context.Context, req BranchProtectionInfo) (BranchProtection, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing protection rule is matched by its branch.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req BranchProtectionInfo) (resp BranchProtection, actionTaken bool, err error)
+
+	// ValidateCodeOwners returns the syntax errors of the CODEOWNERS file on the default branch,
+	// which is used to require the reviews of code owners. No errors are returned if the file
+	// is valid.
+	//
+	// ErrNotFound is returned if the repository has no CODEOWNERS file.
+	// ErrNoProviderSupport is returned if the provider can't validate the file.
+	ValidateCodeOwners(ctx context.Context) ([]CodeOwnersError, error)
+}
+
+// TagProtectionClient operates on the tag protection rules for a specific repository.
+// This client can be accessed through Repository.TagProtections().
+type TagProtectionClient interface {
+	// Get the rule with the given pattern.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, pattern string) (TagProtection, error)
+
+	// List all tag protection rules of the given repository.
+	//
+	// List returns all available rules, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]TagProtection, error)
+
+	// Create a rule with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req TagProtectionInfo) (TagProtection, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The existing rule is matched by its pattern.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req already exists, this is a no-op (actionTaken == false), as a rule consists of its
+	// pattern only.
+	Reconcile(ctx context.Context, req TagProtectionInfo) (resp TagProtection, actionTaken bool, err error)
+}
+
+// MilestoneClient operates on the milestones for a specific repository.
+// This client can be accessed through Repository.Milestones().
+type MilestoneClient interface {
+	// List lists the milestones of the repository, along with the number of open and closed
+	// issues in each milestone. The milestones can be filtered by state using MilestoneListOptions.
+	//
+	// List returns all available milestones, using multiple paginated requests if needed.
+	List(ctx context.Context, opts ...MilestoneListOption) ([]Milestone, error)
+}
+
+// ReleaseClient operates on the releases for a specific repository.
+// This client can be accessed through Repository.Releases().
+type ReleaseClient interface {
+	// Get returns the release created from the given tag, including its assets.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, tagName string) (Release, error)
+
+	// List lists all releases of the repository, including their assets, most recent first.
+	//
+	// List returns all available releases, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Release, error)
+
+	// DownloadAsset returns a reader streaming the contents of the given release asset.
+	// The caller must close the returned reader. The size of the asset isn't limited by
+	// WithMaxResponseSize, but by WithMaxDownloadSize, if given.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	DownloadAsset(ctx context.Context, asset ReleaseAsset) (io.ReadCloser, error)
+}
+
 // CommitClient operates on the commits list for a specific repository.
 // This client can be accessed through Repository.Commits().
 type CommitClient interface {
@@ -214,7 +617,11 @@ type CommitClient interface {
 	// ListPage lists repository commits of the given page and page size.
 	ListPage(ctx context.Context, branch string, perPage int, page int) ([]Commit, error)
 	// Create creates a commit with the given specifications.
-	Create(ctx context.Context, branch string, message string, files []CommitFile) (Commit, error)
+	Create(ctx context.Context, branch string, message string, files []CommitFile, opts ...CommitCreateOption) (Commit, error)
+	// ListComments lists all comments made on the commit with the given sha, including inline comments.
+	//
+	// ListComments returns all available comments, using multiple paginated requests if needed.
+	ListComments(ctx context.Context, sha string) ([]CommitComment, error)
 }
 
 // BranchClient operates on the branches for a specific repository.
@@ -222,22 +629,122 @@ type CommitClient interface {
 type BranchClient interface {
 	// Create creates a branch with the given specifications.
 	Create(ctx context.Context, branch, sha string) error
+
+	// List lists all branches of the repository.
+	//
+	// List returns all available branches, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]BranchInfo, error)
+
+	// Delete deletes the given branch.
+	//
+	// ErrNotFound is returned if the branch does not exist.
+	Delete(ctx context.Context, branch string) error
+
+	// Rename renames the given branch to newName. If the branch is the default branch of the
+	// repository, the default branch is moved to newName as well.
+	//
+	// ErrNotFound is returned if the branch does not exist.
+	Rename(ctx context.Context, branch, newName string) error
 }
 
 // PullRequestClient operates on the pull requests for a specific repository.
 // This client can be accessed through Repository.PullRequests().
 type PullRequestClient interface {
-	// List lists all pull requests in the repository
-	List(ctx context.Context) ([]PullRequest, error)
-	// Create creates a pull request with the given specifications.
-	Create(ctx context.Context, title, branch, baseBranch, description string) (PullRequest, error)
+	// List lists the pull requests in the repository, by default the open ones. Please refer to
+	// "PullRequestListOptions" for the filters, e.g. on the state or the base branch.
+	//
+	// ErrNoProviderSupport is returned if a filter is set, which the provider can't apply.
+	List(ctx context.Context, opts ...PullRequestListOption) ([]PullRequest, error)
+	// Create creates a pull request with the given specifications. Please refer to
+	// "PullRequestCreateOptions" for the optional settings, e.g. creating a draft.
+	//
+	// ErrNoProviderSupport is returned if a head repository is set, but the provider doesn't
+	// support pull requests across repositories.
+	Create(ctx context.Context, title, branch, baseBranch, description string, opts ...PullRequestCreateOption) (PullRequest, error)
 	// Edit allows for changing an existing pull request using the given options. Please refer to "EditOptions" for details on which data can be
 	// edited.
 	Edit(ctx context.Context, number int, opts EditOptions) (PullRequest, error)
 	// Get retrieves an existing pull request by number
 	Get(ctx context.Context, number int) (PullRequest, error)
-	// Merge merges a pull request with via either the "Squash" or "Merge" method
-	Merge(ctx context.Context, number int, mergeMethod MergeMethod, message string) error
+	// Merge merges a pull request with the given method, and message as the message of the
+	// merge or squash commit. Please refer to "PullRequestMergeOptions" for the optional
+	// settings, e.g. the commit title.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support the merge method, and
+	// ErrInvalidArgument if the merge method isn't allowed by the settings of the repository.
+	Merge(ctx context.Context, number int, mergeMethod MergeMethod, message string, opts ...PullRequestMergeOption) error
+	// Update changes an existing pull request using the given options, fields that aren't set
+	// are left as-is. Please refer to "PullRequestUpdateOptions" for details on which data can
+	// be changed.
+	//
+	// ErrNotFound is returned if the pull request doesn't exist.
+	Update(ctx context.Context, number int, opts PullRequestUpdateOptions) (PullRequest, error)
+	// Close closes an open pull request without merging it. Closing a closed pull request is a
+	// no-op.
+	//
+	// ErrNotFound is returned if the pull request doesn't exist, and ErrInvalidArgument if it
+	// has been merged already.
+	Close(ctx context.Context, number int) (PullRequest, error)
+	// Reopen reopens a closed pull request. Reopening an open pull request is a no-op.
+	//
+	// ErrNotFound is returned if the pull request doesn't exist, and ErrInvalidArgument if it
+	// has been merged already.
+	Reopen(ctx context.Context, number int) (PullRequest, error)
+	// MarkReady marks a draft pull request as ready for review. Marking a pull request that
+	// isn't a draft is a no-op.
+	//
+	// ErrNotFound is returned if the pull request doesn't exist.
+	MarkReady(ctx context.Context, number int) (PullRequest, error)
+}
+
+// ReviewClient operates on the reviews of a specific pull request.
+// This client can be accessed through PullRequest.Reviews().
+type ReviewClient interface {
+	// RequestReviewers requests reviews from the users with the given logins. Users that have
+	// been requested already are left as-is.
+	//
+	// ErrNotFound is returned if one of the users doesn't exist.
+	RequestReviewers(ctx context.Context, logins ...string) error
+
+	// List lists the reviews of the pull request. On providers without review objects
+	// (e.g. GitLab), these are the approvals of the pull request.
+	//
+	// List returns all available reviews, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Review, error)
+
+	// Approve approves the pull request as the authenticated user, with body as optional comment.
+	Approve(ctx context.Context, body string) (Review, error)
+
+	// Dismiss dismisses the review with the given ID, with message as the reason. Providers
+	// without review objects only allow to withdraw the approval of the authenticated user, and
+	// return ErrNoProviderSupport for reviews of other users.
+	//
+	// ErrNotFound is returned if the review doesn't exist.
+	Dismiss(ctx context.Context, id int64, message string) error
+}
+
+// PullRequestCommentClient operates on the comments of a specific pull request.
+// This client can be accessed through PullRequest.Comments().
+type PullRequestCommentClient interface {
+	// List lists the general and inline comments of the pull request, including replies,
+	// oldest first.
+	//
+	// List returns all available comments, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]PullRequestComment, error)
+
+	// Create creates a comment on the pull request. The comment is anchored to a line of the
+	// latest version of the pull request if Path and Line are set, and is a reply to a thread if
+	// InReplyTo is set. Otherwise, it is a general comment.
+	//
+	// ErrNotFound is returned if InReplyTo doesn't refer to an existing comment.
+	Create(ctx context.Context, req PullRequestCommentInfo) (PullRequestComment, error)
+
+	// Resolve marks the thread of the comment with the given ID as resolved. Resolving a
+	// resolved thread is a no-op.
+	//
+	// ErrNotFound is returned if the comment doesn't exist, and ErrInvalidArgument if its
+	// thread can't be resolved, e.g. general comments on GitHub.
+	Resolve(ctx context.Context, id int64) error
 }
 
 // EditOptions is provided to a PullRequestClient's "Edit" method for updating an existing pull request.
@@ -246,11 +753,35 @@ type EditOptions struct {
 	Title *string
 }
 
+// PullRequestUpdateOptions is provided to a PullRequestClient's "Update" method for changing an
+// existing pull request. Only the non-nil fields are changed.
+type PullRequestUpdateOptions struct {
+	// Title is set to change the title of the pull request.
+	// +optional
+	Title *string
+
+	// Description is set to change the description of the pull request.
+	// +optional
+	Description *string
+
+	// BaseBranch is set to change the branch the pull request is merged into.
+	// +optional
+	BaseBranch *string
+
+	// Draft is set to mark the pull request as draft (work in progress), or as ready for review.
+	// +optional
+	Draft *bool
+}
+
 // FileClient operates on the branches for a specific repository.
 // This client can be accessed through Repository.Branches().
 type FileClient interface {
 	// GetFiles fetch files content from specific path and branch
 	Get(ctx context.Context, path, branch string, optFns ...FilesGetOption) ([]*CommitFile, error)
+	// GetReader streams the raw contents of the file at path on the given ref without buffering it in memory.
+	// The caller is responsible for closing the returned reader. The size of the file isn't limited by
+	// WithMaxResponseSize, but by WithMaxDownloadSize, if given.
+	GetReader(ctx context.Context, path, ref string) (io.ReadCloser, error)
 }
 
 // TreeClient operates on the trees for a Git repository which describe the hierarchy between files in the repository
@@ -261,3 +792,23 @@ type TreeClient interface {
 	// List retrieves list of tree files (files/blob) from given tree sha/id or path+branch
 	List(ctx context.Context, sha string, path string, recursive bool) ([]*TreeEntry, error)
 }
+
+// ActionsPermissionsClient operates on the policy of which actions are allowed to run in a specific repository.
+// This client can be accessed through Repository.ActionsPermissions().
+type ActionsPermissionsClient interface {
+	// Get returns the actions permissions policy of the repository.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support this feature.
+	Get(ctx context.Context) (ActionsPermissionsInfo, error)
+
+	// Set applies the given actions permissions policy to the repository.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support this feature.
+	Set(ctx context.Context, req ActionsPermissionsInfo) error
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't equal the actual state, the policy will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req ActionsPermissionsInfo) (actionTaken bool, err error)
+}
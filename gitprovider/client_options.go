@@ -64,6 +64,34 @@ type CommonClientOptions struct {
 
 	// CABundle is a []byte containing the CA bundle to use for the client.
 	CABundle []byte
+
+	// ClientCertificate is the TLS client certificate the client authenticates with, see
+	// WithClientCertificate.
+	ClientCertificate *tls.Certificate
+
+	// MaxResponseSize is the maximum number of bytes read from the body of a single HTTP response.
+	// Reading more than that fails with ErrResponseTooLarge. Downloads aren't limited by it, see
+	// MaxDownloadSize. Default: DefaultMaxResponseSize
+	MaxResponseSize *int64
+
+	// MaxDownloadSize is the maximum number of bytes read from the body of a single download,
+	// i.e. the contents streamed by FileClient.GetReader and ReleaseClient.DownloadAsset.
+	// Reading more than that fails with ErrResponseTooLarge. Default: no limit
+	MaxDownloadSize *int64
+
+	// EmptyResponseCheck controls whether successful responses to GET requests with an empty body
+	// fail with an *EmptyResponseError, instead of being decoded into zero-value objects. Only
+	// requests expecting a JSON body are checked, raw files, release assets and diffs may be
+	// empty. Default: true
+	EmptyResponseCheck *bool
+
+	// MaxConcurrency is the maximum number of HTTP requests the client has in flight at the same
+	// time, regardless of how many goroutines use it. Default: unlimited
+	MaxConcurrency *int
+
+	// RedirectPolicy controls how HTTP redirects are followed.
+	// Default: DefaultMaxRedirects redirects, without changing the request method.
+	RedirectPolicy *RedirectPolicy
 }
 
 // ApplyToCommonClientOptions applies the currently set fields in opts to target. If both opts and
@@ -119,6 +147,54 @@ func (opts *CommonClientOptions) ApplyToCommonClientOptions(target *CommonClient
 		target.CABundle = opts.CABundle
 	}
 
+	if opts.ClientCertificate != nil {
+		// Make sure the user didn't specify the ClientCertificate twice
+		if target.ClientCertificate != nil {
+			return fmt.Errorf("option ClientCertificate already configured: %w", ErrInvalidClientOptions)
+		}
+		target.ClientCertificate = opts.ClientCertificate
+	}
+
+	if opts.MaxResponseSize != nil {
+		// Make sure the user didn't specify the MaxResponseSize twice
+		if target.MaxResponseSize != nil {
+			return fmt.Errorf("option MaxResponseSize already configured: %w", ErrInvalidClientOptions)
+		}
+		target.MaxResponseSize = opts.MaxResponseSize
+	}
+
+	if opts.MaxDownloadSize != nil {
+		// Make sure the user didn't specify the MaxDownloadSize twice
+		if target.MaxDownloadSize != nil {
+			return fmt.Errorf("option MaxDownloadSize already configured: %w", ErrInvalidClientOptions)
+		}
+		target.MaxDownloadSize = opts.MaxDownloadSize
+	}
+
+	if opts.EmptyResponseCheck != nil {
+		// Make sure the user didn't specify the EmptyResponseCheck twice
+		if target.EmptyResponseCheck != nil {
+			return fmt.Errorf("option EmptyResponseCheck already configured: %w", ErrInvalidClientOptions)
+		}
+		target.EmptyResponseCheck = opts.EmptyResponseCheck
+	}
+
+	if opts.MaxConcurrency != nil {
+		// Make sure the user didn't specify the MaxConcurrency twice
+		if target.MaxConcurrency != nil {
+			return fmt.Errorf("option MaxConcurrency already configured: %w", ErrInvalidClientOptions)
+		}
+		target.MaxConcurrency = opts.MaxConcurrency
+	}
+
+	if opts.RedirectPolicy != nil {
+		// Make sure the user didn't specify the RedirectPolicy twice
+		if target.RedirectPolicy != nil {
+			return fmt.Errorf("option RedirectPolicy already configured: %w", ErrInvalidClientOptions)
+		}
+		target.RedirectPolicy = opts.RedirectPolicy
+	}
+
 	return nil
 }
 
@@ -137,6 +213,21 @@ func BuildClientFromTransportChain(chain []ChainableRoundTripperFunc) (*http.Cli
 	return &http.Client{Transport: transport}, nil
 }
 
+// BuildHTTPClient builds a *http.Client from the transport chain returned by GetTransportChain,
+// which follows redirects according to the RedirectPolicy.
+func (opts *ClientOptions) BuildHTTPClient() (*http.Client, error) {
+	client, err := BuildClientFromTransportChain(opts.GetTransportChain())
+	if err != nil {
+		return nil, err
+	}
+	policy := RedirectPolicy{MaxRedirects: DefaultMaxRedirects}
+	if opts.RedirectPolicy != nil {
+		policy = *opts.RedirectPolicy
+	}
+	client.CheckRedirect = policy.checkRedirect()
+	return client, nil
+}
+
 // ClientOption is the interface to implement for passing options to NewClient.
 // The clientOptions struct is private to force usage of the With... functions.
 type ClientOption interface {
@@ -152,8 +243,19 @@ type ClientOptions struct {
 	// authTransport is a ChainableRoundTripperFunc adding authentication credentials to the transport chain.
 	authTransport ChainableRoundTripperFunc
 
+	// hostBoundAuth will be set if the credentials added by authTransport are bound to the
+	// requested host, and can hence be sent to other hosts, e.g. with SPNEGO.
+	hostBoundAuth bool
+
+	// concurrencyLimit enforces MaxConcurrency. It's created once, so that all HTTP clients built
+	// from the options share the limit.
+	concurrencyLimit ChainableRoundTripperFunc
+
 	// enableConditionalRequests will be set if conditional requests should be used.
 	enableConditionalRequests *bool
+
+	// anonymous will be set if the client must not send credentials, see WithAnonymous.
+	anonymous *bool
 }
 
 // ApplyToClientOptions implements ClientOption, and applies the set fields of opts
@@ -170,6 +272,7 @@ func (opts *ClientOptions) ApplyToClientOptions(target *ClientOptions) error {
 			return fmt.Errorf("option authTransport already configured: %w", ErrInvalidClientOptions)
 		}
 		target.authTransport = opts.authTransport
+		target.hostBoundAuth = opts.hostBoundAuth
 	}
 
 	if opts.enableConditionalRequests != nil {
@@ -179,26 +282,122 @@ func (opts *ClientOptions) ApplyToClientOptions(target *ClientOptions) error {
 		}
 		target.enableConditionalRequests = opts.enableConditionalRequests
 	}
+
+	if opts.anonymous != nil {
+		// Make sure the user didn't specify the anonymous twice
+		if target.anonymous != nil {
+			return fmt.Errorf("option anonymous already configured: %w", ErrInvalidClientOptions)
+		}
+		target.anonymous = opts.anonymous
+	}
+
+	// An anonymous client can't have credentials
+	if target.IsAnonymous() && (target.authTransport != nil || target.ClientCertificate != nil) {
+		return fmt.Errorf("an anonymous client can't be configured with credentials: %w", ErrInvalidClientOptions)
+	}
 	return nil
 }
 
+// HasAuthentication returns whether the transport chain authenticates the requests, i.e. whether
+// WithOAuth2Token, WithOAuth2TokenSource, WithTokenSource, WithCredentialChain or WithSPNEGO is
+// used.
+func (opts *ClientOptions) HasAuthentication() bool {
+	return opts.authTransport != nil
+}
+
+// IsAnonymous returns whether the client must not send credentials, i.e. whether WithAnonymous
+// is used.
+func (opts *ClientOptions) IsAnonymous() bool {
+	return opts.anonymous != nil && *opts.anonymous
+}
+
 // GetTransportChain builds the full chain of transports (from left to right,
 // as per gitprovider.BuildClientFromTransportChain) of the form described in NewClient.
 func (opts *ClientOptions) GetTransportChain() (chain []ChainableRoundTripperFunc) {
+	return opts.transportChain(true)
+}
+
+// BuildDownloadHTTPClient builds a *http.Client like BuildHTTPClient, which doesn't send the
+// credentials of the client. It's meant for following redirects to other hosts, e.g. to the
+// pre-signed URLs of the storage backend of release assets, which must be requested without
+// credentials. The rest of the transport chain applies as configured, including the transport
+// hooks, the CA bundle, the client certificate and SPNEGO, whose tokens are bound to the
+// requested host. Empty responses aren't checked, as downloads may be empty. All requests
+// are downloads, hence only MaxDownloadSize limits the size of the responses.
+func (opts *ClientOptions) BuildDownloadHTTPClient() (*http.Client, error) {
+	client, err := BuildClientFromTransportChain(opts.transportChain(false))
+	if err != nil {
+		return nil, err
+	}
+	policy := RedirectPolicy{MaxRedirects: DefaultMaxRedirects}
+	if opts.RedirectPolicy != nil {
+		policy = *opts.RedirectPolicy
+	}
+	client.CheckRedirect = policy.checkRedirect()
+	return client, nil
+}
+
+// transportChain builds the chain of transports, with the credentials of the client and the
+// empty response check if withCredentials is set.
+func (opts *ClientOptions) transportChain(withCredentials bool) (chain []ChainableRoundTripperFunc) {
 	if opts.PostChainTransportHook != nil {
 		chain = append(chain, opts.PostChainTransportHook)
 	}
-	if opts.authTransport != nil {
+	// The client certificate is added to the TLS configuration of the "final" transport
+	if opts.ClientCertificate != nil {
+		chain = append(chain, clientCertificateTransport(*opts.ClientCertificate))
+	}
+	// Only requests actually sent to the API count towards the concurrency limit, hence
+	// it's added before the cache. Retries by the provider SDKs acquire a new slot each time.
+	if opts.MaxConcurrency != nil {
+		if opts.concurrencyLimit == nil {
+			opts.concurrencyLimit = concurrencyLimitTransport(*opts.MaxConcurrency)
+		}
+		chain = append(chain, opts.concurrencyLimit)
+	}
+	if opts.authTransport != nil && (withCredentials || opts.hostBoundAuth) {
 		chain = append(chain, opts.authTransport)
 	}
-	if opts.enableConditionalRequests != nil && *opts.enableConditionalRequests {
+	if withCredentials && opts.enableConditionalRequests != nil && *opts.enableConditionalRequests {
 		// TODO: Provide some kind of debug logging if/when the httpcache is used
 		// One can see if the request hit the cache using: resp.Header[httpcache.XFromCache]
-		chain = append(chain, cache.NewHTTPCacheTransport)
+		chain = append(chain, bypassForContextCredentials(cache.NewHTTPCacheTransport))
 	}
 	if opts.PreChainTransportHook != nil {
 		chain = append(chain, opts.PreChainTransportHook)
 	}
+	// Anonymous clients strip the credentials set by the provider SDKs, and only send requests
+	// reading data, unless the request context carries credentials
+	if withCredentials && opts.IsAnonymous() {
+		chain = append(chain, anonymousTransport)
+	}
+	// The credentials carried by the request context replace the ones set by the provider SDKs,
+	// the authentication transports skip such requests
+	if withCredentials {
+		chain = append(chain, contextCredentialsTransport)
+	}
+	// Limit the size of the responses the client reads. Downloads are streamed to the caller,
+	// hence they're limited separately.
+	var maxDownloadSize int64
+	if opts.MaxDownloadSize != nil {
+		maxDownloadSize = *opts.MaxDownloadSize
+	}
+	if withCredentials {
+		maxResponseSize := int64(DefaultMaxResponseSize)
+		if opts.MaxResponseSize != nil {
+			maxResponseSize = *opts.MaxResponseSize
+		}
+		chain = append(chain, maxResponseSizeTransport(maxResponseSize, maxDownloadSize))
+	} else if maxDownloadSize > 0 {
+		chain = append(chain, maxResponseSizeTransport(maxDownloadSize, maxDownloadSize))
+	}
+	// Detect empty responses where a body is required, e.g. caused by misbehaving proxies
+	if withCredentials && (opts.EmptyResponseCheck == nil || *opts.EmptyResponseCheck) {
+		chain = append(chain, emptyResponseTransport)
+	}
+	// The CallOptions carried by the request context are applied outermost, so e.g. their
+	// timeout covers the whole chain.
+	chain = append(chain, newCallOptionsTransport)
 	return
 }
 
@@ -243,6 +442,68 @@ func WithDestructiveAPICalls(destructiveActions bool) ClientOption {
 	return buildCommonOption(CommonClientOptions{EnableDestructiveAPICalls: &destructiveActions})
 }
 
+// WithMaxResponseSize limits the number of bytes read from the body of a single HTTP response
+// to maxBytes, in order to protect against huge responses exhausting memory. Reading more than
+// maxBytes fails with ErrResponseTooLarge. maxBytes must be positive.
+// If this option isn't given, DefaultMaxResponseSize is used. Downloads are limited by
+// WithMaxDownloadSize instead.
+func WithMaxResponseSize(maxBytes int64) ClientOption {
+	// Don't allow disabling the limit
+	if maxBytes <= 0 {
+		return optionError(fmt.Errorf("maxBytes must be positive: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{MaxResponseSize: &maxBytes})
+}
+
+// WithMaxDownloadSize limits the number of bytes read from the body of a single download to
+// maxBytes, i.e. the contents streamed by FileClient.GetReader and ReleaseClient.DownloadAsset.
+// Reading more than maxBytes fails with ErrResponseTooLarge. maxBytes must be positive.
+// If this option isn't given, downloads aren't limited, as they're streamed to the caller
+// instead of being buffered in memory.
+func WithMaxDownloadSize(maxBytes int64) ClientOption {
+	if maxBytes <= 0 {
+		return optionError(fmt.Errorf("maxBytes must be positive: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{MaxDownloadSize: &maxBytes})
+}
+
+// WithEmptyResponseCheck controls whether successful responses to GET requests with an empty
+// body fail with an *EmptyResponseError (matching ErrEmptyResponse), which includes the URL of
+// the request. Otherwise, such responses are decoded into zero-value objects. Only requests
+// expecting a JSON body are checked, as e.g. raw files, release assets and diffs may be empty.
+// If this option isn't given, the check is enabled.
+func WithEmptyResponseCheck(enabled bool) ClientOption {
+	return buildCommonOption(CommonClientOptions{EmptyResponseCheck: &enabled})
+}
+
+// WithMaxConcurrency limits the number of HTTP requests the client has in flight at the same time
+// to n, which is shared between all goroutines using the client, and all HTTP clients it uses,
+// e.g. for API calls and downloads. Requests exceeding the limit wait
+// for a free slot, or until their context is done. n must be positive.
+// If this option isn't given, the number of concurrent requests is unlimited.
+func WithMaxConcurrency(n int) ClientOption {
+	// Don't allow a limit that blocks all requests
+	if n <= 0 {
+		return optionError(fmt.Errorf("n must be positive: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{MaxConcurrency: &n})
+}
+
+// WithRedirectPolicy controls how the client follows HTTP redirects, e.g. issued by proxies in
+// front of self-hosted instances. MaxRedirects must not be negative.
+// If this option isn't given, up to DefaultMaxRedirects redirects are followed, and redirects
+// that would change the method of a request fail with ErrRedirectMethodChanged.
+func WithRedirectPolicy(policy RedirectPolicy) ClientOption {
+	if policy.MaxRedirects < 0 {
+		return optionError(fmt.Errorf("MaxRedirects must not be negative: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{RedirectPolicy: &policy})
+}
+
 // WithPreChainTransportHook registers a ChainableRoundTripperFunc "before" the cache and authentication
 // transports in the chain. For more information, see NewClient, and gitprovider.CommonClientOptions.PreChainTransportHook.
 func WithPreChainTransportHook(preRoundTripperFunc ChainableRoundTripperFunc) ClientOption {
@@ -277,7 +538,8 @@ func WithOAuth2Token(oauth2Token string) ClientOption {
 }
 
 func oauth2Transport(oauth2Token string) ChainableRoundTripperFunc {
-	return func(in http.RoundTripper) http.RoundTripper {
+	// Requests with context credentials are sent as-is
+	return bypassForContextCredentials(func(in http.RoundTripper) http.RoundTripper {
 		// Create a TokenSource of the given access token
 		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: oauth2Token})
 		// Create a Transport, with "in" as the underlying transport, and the given TokenSource
@@ -285,7 +547,7 @@ func oauth2Transport(oauth2Token string) ChainableRoundTripperFunc {
 			Base:   in,
 			Source: oauth2.ReuseTokenSource(nil, ts),
 		}
-	}
+	})
 }
 
 // WithConditionalRequests instructs the client to use Conditional Requests to Stash.
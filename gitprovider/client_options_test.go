@@ -17,9 +17,14 @@ limitations under the License.
 package gitprovider
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/fluxcd/go-git-providers/validation"
@@ -272,6 +277,66 @@ func Test_makeCientOptions(t *testing.T) {
 			opts:         []ClientOption{WithCustomCAPostChainTransportHook(nil)},
 			expectedErrs: []error{ErrInvalidClientOptions},
 		},
+		{
+			name: "WithMaxResponseSize",
+			opts: []ClientOption{WithMaxResponseSize(1024)},
+			want: buildCommonOption(CommonClientOptions{MaxResponseSize: int64Var(1024)}),
+		},
+		{
+			name:         "WithMaxResponseSize, zero",
+			opts:         []ClientOption{WithMaxResponseSize(0)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithMaxResponseSize, exclusive",
+			opts:         []ClientOption{WithMaxResponseSize(1024), WithMaxResponseSize(2048)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithMaxDownloadSize",
+			opts: []ClientOption{WithMaxDownloadSize(1024)},
+			want: buildCommonOption(CommonClientOptions{MaxDownloadSize: int64Var(1024)}),
+		},
+		{
+			name:         "WithMaxDownloadSize, negative",
+			opts:         []ClientOption{WithMaxDownloadSize(-1)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithMaxDownloadSize, exclusive",
+			opts:         []ClientOption{WithMaxDownloadSize(1024), WithMaxDownloadSize(2048)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithMaxConcurrency",
+			opts: []ClientOption{WithMaxConcurrency(4)},
+			want: buildCommonOption(CommonClientOptions{MaxConcurrency: intVar(4)}),
+		},
+		{
+			name:         "WithMaxConcurrency, zero",
+			opts:         []ClientOption{WithMaxConcurrency(0)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithMaxConcurrency, exclusive",
+			opts:         []ClientOption{WithMaxConcurrency(4), WithMaxConcurrency(8)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithRedirectPolicy",
+			opts: []ClientOption{WithRedirectPolicy(RedirectPolicy{MaxRedirects: 3})},
+			want: buildCommonOption(CommonClientOptions{RedirectPolicy: &RedirectPolicy{MaxRedirects: 3}}),
+		},
+		{
+			name:         "WithRedirectPolicy, negative",
+			opts:         []ClientOption{WithRedirectPolicy(RedirectPolicy{MaxRedirects: -1})},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithRedirectPolicy, exclusive",
+			opts:         []ClientOption{WithRedirectPolicy(RedirectPolicy{}), WithRedirectPolicy(RedirectPolicy{})},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
 		{
 			name: "WithOAuth2Token",
 			opts: []ClientOption{WithOAuth2Token("foo")},
@@ -317,3 +382,78 @@ func Test_makeCientOptions(t *testing.T) {
 		})
 	}
 }
+
+// hookTransport marks the requests passing through it.
+type hookTransport struct {
+	next http.RoundTripper
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Hook", "applied")
+	if t.next == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
+
+func Test_clientOptions_BuildDownloadHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = io.WriteString(w, strings.Repeat("x", 10))
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s|%s", r.Header.Get("Authorization"), r.Header.Get("X-Hook"))
+	}))
+	defer srv.Close()
+
+	opts, err := MakeClientOptions(
+		WithOAuth2Token("secret"),
+		WithPreChainTransportHook(func(next http.RoundTripper) http.RoundTripper {
+			return &hookTransport{next: next}
+		}),
+		WithMaxResponseSize(8),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "|applied"; string(body) != want {
+		t.Errorf("got %q, want %q", body, want)
+	}
+
+	// MaxResponseSize doesn't apply to downloads
+	resp, err = client.Get(srv.URL + "/large")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Errorf("unexpected error reading a response larger than MaxResponseSize: %v", err)
+	}
+
+	opts, err = MakeClientOptions(WithMaxDownloadSize(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = opts.BuildDownloadHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(srv.URL + "/large"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import "time"
+
+// Clock tells the current time. It's used where behavior depends on the passing of time, e.g.
+// for expiring cache entries, so that tests can control time instead of sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by the system time, i.e. time.Now.
+var SystemClock Clock = systemClock{} //nolint:gochecknoglobals
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// concurrencyLimitTransport returns a ChainableRoundTripperFunc allowing at most n requests to
+// be in flight at the same time. A request occupies its slot until its response body is closed.
+// The limit is shared between all transports returned by the function.
+func concurrencyLimitTransport(n int) ChainableRoundTripperFunc {
+	sem := make(chan struct{}, n)
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &concurrencyLimitedTransport{next: in, sem: sem}
+	}
+}
+
+// concurrencyLimitedTransport acquires a slot of sem for each request.
+type concurrencyLimitedTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *concurrencyLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-t.sem }
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody calls release once when the body is closed.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close implements io.Closer.
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func intVar(i int) *int {
+	return &i
+}
+
+func Test_concurrencyLimitTransport(t *testing.T) {
+	const limit = 2
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	chainFunc := concurrencyLimitTransport(limit)
+	// Two transports built from the same function share the limit
+	clients := []*http.Client{
+		{Transport: chainFunc(nil)},
+		{Transport: chainFunc(nil)},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(client *http.Client) {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}(clients[i%len(clients)])
+	}
+	wg.Wait()
+
+	if maxInFlight > limit {
+		t.Errorf("had %d requests in flight, want at most %d", maxInFlight, limit)
+	}
+}
+
+func Test_concurrencyLimitTransport_contextDone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: concurrencyLimitTransport(1)(nil)}
+	// Occupy the only slot by not closing the body
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// Closing the body frees the slot
+	resp.Body.Close()
+	resp, err = client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestWithMaxConcurrency_sharedByDownloads(t *testing.T) {
+	const limit = 2
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	opts, err := MakeClientOptions(WithMaxConcurrency(limit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloadClient, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clients := []*http.Client{apiClient, downloadClient}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(client *http.Client) {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}(clients[i%len(clients)])
+	}
+	wg.Wait()
+
+	if maxInFlight > limit {
+		t.Errorf("had %d requests in flight, want at most %d", maxInFlight, limit)
+	}
+
+	// A download waits while API calls occupy all slots
+	var bodies []io.Closer
+	for i := 0; i < limit; i++ {
+		resp, err := apiClient.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, resp.Body)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := downloadClient.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+	for _, body := range bodies {
+		body.Close()
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"net/http"
+)
+
+// Credentials authenticate the API requests made with a given context, see
+// WithContextCredentials.
+type Credentials struct {
+	// Username is sent together with Token using HTTP basic authentication if set, as required by
+	// e.g. Bitbucket Cloud app passwords or Azure DevOps. Otherwise Token is sent as a bearer
+	// token in the Authorization header.
+	// +optional
+	Username string
+
+	// Token is the personal access token or OAuth2 access token to authenticate with. The
+	// requests are sent unauthenticated if empty.
+	Token string
+}
+
+// contextCredentialsKey is the context key for Credentials.
+type contextCredentialsKey struct{}
+
+// WithContextCredentials returns a copy of ctx carrying creds, which are used to authenticate
+// all API requests made with the context instead of the credentials of the Client. This allows
+// a single Client to act on behalf of different users, e.g. in multi-tenant controllers managing
+// many organizations using separate personal access tokens, while sharing its HTTP connections
+// and options.
+//
+// Responses to requests with context credentials are never served from, or stored in, the cache
+// of WithConditionalRequests. Git operations the clients of some providers perform (e.g. Stash
+// creating commits by pushing) still use the credentials of the Client.
+func WithContextCredentials(ctx context.Context, creds Credentials) context.Context {
+	return context.WithValue(ctx, contextCredentialsKey{}, creds)
+}
+
+// CredentialsFromContext returns the Credentials carried by ctx, and whether
+// WithContextCredentials has been used for ctx.
+func CredentialsFromContext(ctx context.Context) (Credentials, bool) {
+	creds, ok := ctx.Value(contextCredentialsKey{}).(Credentials)
+	return creds, ok
+}
+
+// authHeaders are the headers the provider SDKs and the authentication transports use to send
+// the credentials of the Client, which are replaced by the context credentials.
+var authHeaders = []string{"Authorization", "PRIVATE-TOKEN", "JOB-TOKEN"}
+
+// contextCredentialsTransport is a ChainableRoundTripperFunc replacing the credentials of
+// requests with the Credentials carried by the request context.
+func contextCredentialsTransport(in http.RoundTripper) http.RoundTripper {
+	if in == nil {
+		in = http.DefaultTransport
+	}
+	return &credentialsTransport{next: in}
+}
+
+// credentialsTransport applies the Credentials carried by the request context.
+type credentialsTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *credentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	creds, ok := CredentialsFromContext(req.Context())
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	for _, header := range authHeaders {
+		req.Header.Del(header)
+	}
+	switch {
+	case creds.Token == "":
+		// Send the request unauthenticated
+	case creds.Username != "":
+		req.SetBasicAuth(creds.Username, creds.Token)
+	default:
+		req.Header.Set("Authorization", "Bearer "+creds.Token)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// bypassForContextCredentials returns a ChainableRoundTripperFunc which sends requests with
+// context credentials directly to "in", skipping the transport built by chainFn. It's used for
+// the authentication transports, and for the cache, which doesn't tell the responses for
+// different credentials apart.
+func bypassForContextCredentials(chainFn ChainableRoundTripperFunc) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &bypassTransport{direct: in, next: chainFn(in)}
+	}
+}
+
+// bypassTransport sends requests with context credentials to direct, and others to next.
+type bypassTransport struct {
+	direct http.RoundTripper
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *bypassTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := CredentialsFromContext(req.Context()); ok {
+		return t.direct.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithContextCredentials(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("ETag", `"etag"`)
+		_, _ = fmt.Fprintf(w, "%s|%s", r.Header.Get("Authorization"), r.Header.Get("PRIVATE-TOKEN"))
+	}))
+	defer srv.Close()
+
+	opts, err := MakeClientOptions(WithOAuth2Token("client-token"), WithConditionalRequests(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		want         string
+		wantRequests int
+	}{
+		{
+			name:         "client credentials",
+			ctx:          context.Background(),
+			want:         "Bearer client-token|sdk-token",
+			wantRequests: 1,
+		},
+		{
+			name:         "client credentials, cached",
+			ctx:          context.Background(),
+			want:         "Bearer client-token|sdk-token",
+			wantRequests: 0,
+		},
+		{
+			name:         "bearer token",
+			ctx:          WithContextCredentials(context.Background(), Credentials{Token: "tenant-token"}),
+			want:         "Bearer tenant-token|",
+			wantRequests: 1,
+		},
+		{
+			name:         "bearer token, not cached",
+			ctx:          WithContextCredentials(context.Background(), Credentials{Token: "tenant-token"}),
+			want:         "Bearer tenant-token|",
+			wantRequests: 1,
+		},
+		{
+			name:         "basic auth",
+			ctx:          WithContextCredentials(context.Background(), Credentials{Username: "user", Token: "password"}),
+			want:         "Basic dXNlcjpwYXNzd29yZA==|",
+			wantRequests: 1,
+		},
+		{
+			name:         "unauthenticated",
+			ctx:          WithContextCredentials(context.Background(), Credentials{}),
+			want:         "|",
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Like e.g. the GitLab SDK, which sets the token itself
+			req.Header.Set("PRIVATE-TOKEN", "sdk-token")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(body); got != tt.want {
+				t.Errorf("got credentials %q, want %q", got, tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests to the server, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWithContextCredentials_tokenSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	calls := 0
+	opts, err := MakeClientOptions(WithTokenSource(func(context.Context) (string, time.Time, error) {
+		calls++
+		return "client-token", time.Time{}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithContextCredentials(context.Background(), Credentials{Token: "tenant-token"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(body); got != "Bearer tenant-token" {
+		t.Errorf("got credentials %q, want %q", got, "Bearer tenant-token")
+	}
+	// The token source isn't needed for requests with context credentials
+	if calls != 0 {
+		t.Errorf("token source called %d times, want 0", calls)
+	}
+}
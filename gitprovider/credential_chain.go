@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// fileCredentialsRefreshInterval is how long a token read from a file is used, before the file
+// is read again to pick up rotated tokens, e.g. of a mounted Kubernetes secret.
+const fileCredentialsRefreshInterval = time.Minute
+
+// CredentialProvider is a source of credentials for a CredentialChain.
+type CredentialProvider interface {
+	// Name describes the provider, e.g. "env:GITHUB_TOKEN".
+	Name() string
+	// Retrieve returns the token and its expiry, a zero expiry means the token doesn't expire.
+	// An error wrapping ErrNoCredentials is returned if the provider has no credentials, which
+	// makes the chain try the next provider.
+	Retrieve(ctx context.Context) (token string, expiry time.Time, err error)
+}
+
+// CredentialChain tries multiple CredentialProviders in order, and uses the credentials of the
+// first one having credentials, like the credential chains of the cloud SDKs. Use
+// WithCredentialChain to authenticate a Client with the chain, and Used to tell which provider
+// the credentials came from, e.g. for logging.
+//
+// The chain is evaluated again when the credentials expire, hence it can fall back to another
+// provider if e.g. the token file was removed.
+type CredentialChain struct {
+	providers []CredentialProvider
+
+	mu   sync.Mutex
+	used string
+}
+
+// NewCredentialChain creates a CredentialChain trying the providers in the given order.
+func NewCredentialChain(providers ...CredentialProvider) *CredentialChain {
+	return &CredentialChain{providers: providers}
+}
+
+// Token returns the credentials of the first provider having credentials, and implements
+// TokenSourceFunc. An error wrapping ErrNoCredentials is returned if no provider has
+// credentials; other errors of the providers are returned immediately.
+func (c *CredentialChain) Token(ctx context.Context) (string, time.Time, error) {
+	names := make([]string, 0, len(c.providers))
+	for _, provider := range c.providers {
+		token, expiry, err := provider.Retrieve(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			names = append(names, provider.Name())
+			continue
+		}
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("credential provider %s: %w", provider.Name(), err)
+		}
+		c.mu.Lock()
+		c.used = provider.Name()
+		c.mu.Unlock()
+		return token, expiry, nil
+	}
+	return "", time.Time{}, fmt.Errorf("tried %s: %w", strings.Join(names, ", "), ErrNoCredentials)
+}
+
+// Used returns the name of the provider the latest credentials came from, or an empty string if
+// the chain wasn't used yet.
+func (c *CredentialChain) Used() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.used
+}
+
+// WithCredentialChain initializes a Client which authenticates requests with the credentials of
+// chain, see WithTokenSource. chain must not be nil.
+func WithCredentialChain(chain *CredentialChain) ClientOption {
+	// Don't allow an empty value
+	if chain == nil {
+		return optionError(fmt.Errorf("chain cannot be nil: %w", ErrInvalidClientOptions))
+	}
+	return WithTokenSource(chain.Token)
+}
+
+// EnvCredentials returns a CredentialProvider reading the token from the first of the given
+// environment variables that is set, e.g. "GITHUB_TOKEN".
+func EnvCredentials(names ...string) CredentialProvider {
+	return &envCredentials{names: names}
+}
+
+type envCredentials struct {
+	names []string
+}
+
+func (p *envCredentials) Name() string {
+	return "env:" + strings.Join(p.names, ",")
+}
+
+func (p *envCredentials) Retrieve(_ context.Context) (string, time.Time, error) {
+	for _, name := range p.names {
+		if token := os.Getenv(name); token != "" {
+			return token, time.Time{}, nil
+		}
+	}
+	return "", time.Time{}, ErrNoCredentials
+}
+
+// FileCredentials returns a CredentialProvider reading the token from the file at path, e.g. a
+// mounted Kubernetes secret. Surrounding whitespace is trimmed, and the file is read again every
+// minute to pick up rotated tokens. A missing or empty file has no credentials.
+func FileCredentials(path string) CredentialProvider {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path string
+}
+
+func (p *fileCredentials) Name() string {
+	return "file:" + p.path
+}
+
+func (p *fileCredentials) Retrieve(_ context.Context) (string, time.Time, error) {
+	content, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", time.Time{}, ErrNoCredentials
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", time.Time{}, ErrNoCredentials
+	}
+	return token, time.Now().Add(fileCredentialsRefreshInterval), nil
+}
+
+// OAuth2Credentials returns a CredentialProvider getting access tokens from source, e.g. the
+// TokenSource of the client credentials of an OAuth app. The tokens are reused until they
+// expire. name describes the source, e.g. "oauth-app".
+func OAuth2Credentials(name string, source oauth2.TokenSource) CredentialProvider {
+	return &oauth2Credentials{name: name, source: oauth2.ReuseTokenSource(nil, source)}
+}
+
+type oauth2Credentials struct {
+	name   string
+	source oauth2.TokenSource
+}
+
+func (p *oauth2Credentials) Name() string {
+	return p.name
+}
+
+func (p *oauth2Credentials) Retrieve(_ context.Context) (string, time.Time, error) {
+	token, err := p.source.Token()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token.AccessToken, token.Expiry, nil
+}
+
+// AnonymousCredentials returns a CredentialProvider which always provides an empty token, i.e.
+// sends the requests unauthenticated. It's meant as last provider of a chain, for access to
+// public resources.
+func AnonymousCredentials() CredentialProvider {
+	return anonymousCredentials{}
+}
+
+type anonymousCredentials struct{}
+
+func (anonymousCredentials) Name() string {
+	return "anonymous"
+}
+
+func (anonymousCredentials) Retrieve(_ context.Context) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCredentialChain(t *testing.T) {
+	ctx := context.Background()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	chain := NewCredentialChain(
+		EnvCredentials("TEST_CHAIN_TOKEN"),
+		FileCredentials(tokenFile),
+		AnonymousCredentials(),
+	)
+	tests := []struct {
+		name      string
+		env       string
+		file      string
+		wantToken string
+		wantUsed  string
+	}{
+		{name: "environment variable first", env: "env-token", file: "file-token", wantToken: "env-token", wantUsed: "env:TEST_CHAIN_TOKEN"},
+		{name: "file without environment variable", file: " file-token\n", wantToken: "file-token", wantUsed: "file:" + tokenFile},
+		{name: "anonymous without credentials", wantUsed: "anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_CHAIN_TOKEN", tt.env)
+			_ = os.Remove(tokenFile)
+			if tt.file != "" {
+				if err := os.WriteFile(tokenFile, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			token, _, err := chain.Token(ctx)
+			if err != nil {
+				t.Fatalf("Token() error = %v", err)
+			}
+			if token != tt.wantToken {
+				t.Errorf("Token() = %q, want %q", token, tt.wantToken)
+			}
+			if got := chain.Used(); got != tt.wantUsed {
+				t.Errorf("Used() = %q, want %q", got, tt.wantUsed)
+			}
+		})
+	}
+
+	// Without a fallback, the chain has no credentials
+	if _, _, err := NewCredentialChain(EnvCredentials("TEST_CHAIN_UNSET")).Token(ctx); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Token() error = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestWithCredentialChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%q", r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		chain *CredentialChain
+		want  string
+	}{
+		{name: "token", chain: NewCredentialChain(staticCredentials("token")), want: `"Bearer token"`},
+		{name: "anonymous", chain: NewCredentialChain(AnonymousCredentials()), want: `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := MakeClientOptions(WithCredentialChain(tt.chain))
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := opts.BuildHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Authorization = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := MakeClientOptions(WithCredentialChain(nil)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions for a nil chain, got %v", err)
+	}
+}
+
+// staticCredentials is a CredentialProvider always returning the token.
+type staticCredentials string
+
+func (s staticCredentials) Name() string { return "static" }
+
+func (s staticCredentials) Retrieve(context.Context) (string, time.Time, error) {
+	return string(s), time.Time{}, nil
+}
@@ -14,9 +14,20 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package bitbucket
+package gitprovider
 
-import (
-	// TODO: Dummy import until we have the implementation ready.
-	_ "github.com/ktrysmt/go-bitbucket"
-)
+import "sort"
+
+// SortDeployKeys sorts keys in place as requested by opts, see DeployKeyListOptions.
+func SortDeployKeys(keys []DeployKey, opts DeployKeyListOptions) {
+	if opts.SortByLastUsed == nil || !*opts.SortByLastUsed {
+		return
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i].LastUsedAt(), keys[j].LastUsedAt()
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+}
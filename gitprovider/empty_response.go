@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// emptyResponseTransport is a ChainableRoundTripperFunc that fails successful responses to GET
+// requests expecting a JSON body with an *EmptyResponseError if their body is empty.
+func emptyResponseTransport(in http.RoundTripper) http.RoundTripper {
+	if in == nil {
+		in = http.DefaultTransport
+	}
+	return &emptyResponseCheckTransport{next: in}
+}
+
+// emptyResponseCheckTransport detects empty bodies in responses that require one.
+type emptyResponseCheckTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *emptyResponseCheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// Only a successful GET is guaranteed to carry a body, other requests may be answered with
+	// an empty one, e.g. DELETE calls.
+	if req.Method != http.MethodGet || resp.StatusCode != http.StatusOK || resp.ContentLength > 0 || !expectsJSONBody(req) {
+		return resp, nil
+	}
+	// The length isn't known up front for e.g. chunked responses, hence peek into the body
+	body := bufio.NewReader(resp.Body)
+	if resp.ContentLength < 0 {
+		if _, err := body.Peek(1); err != io.EOF {
+			resp.Body = &peekedBody{Reader: body, Closer: resp.Body}
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+	return nil, &EmptyResponseError{URL: req.URL.Redacted(), StatusCode: resp.StatusCode}
+}
+
+// expectsJSONBody returns whether the request is answered with a JSON document, which is never
+// empty. Raw files, release assets and diffs may legitimately be empty.
+func expectsJSONBody(req *http.Request) bool {
+	// GitLab serves raw files at .../raw, although go-gitlab always accepts JSON
+	if strings.HasSuffix(req.URL.Path, "/raw") {
+		return false
+	}
+	// Some SDKs, e.g. the Stash one, don't set the Accept header for JSON requests
+	accept := req.Header.Get("Accept")
+	return accept == "" || strings.Contains(accept, "json")
+}
+
+// peekedBody reads the rest of a response body that has already been peeked into.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
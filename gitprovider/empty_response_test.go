@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_emptyResponseTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		accept       string
+		status       int
+		body         string
+		chunked      bool
+		disabled     bool
+		expectedErr  error
+		expectedBody string
+	}{
+		{
+			name:        "empty 200",
+			method:      http.MethodGet,
+			status:      http.StatusOK,
+			expectedErr: ErrEmptyResponse,
+		},
+		{
+			name:        "empty chunked 200",
+			method:      http.MethodGet,
+			status:      http.StatusOK,
+			chunked:     true,
+			expectedErr: ErrEmptyResponse,
+		},
+		{
+			name:         "chunked body",
+			method:       http.MethodGet,
+			status:       http.StatusOK,
+			body:         `{"id":1}`,
+			chunked:      true,
+			expectedBody: `{"id":1}`,
+		},
+		{
+			name:   "empty 204",
+			method: http.MethodGet,
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "empty DELETE",
+			method: http.MethodDelete,
+			status: http.StatusOK,
+		},
+		{
+			name:   "empty raw file",
+			method: http.MethodGet,
+			accept: "application/vnd.github.raw",
+			status: http.StatusOK,
+		},
+		{
+			name:   "empty diff",
+			method: http.MethodGet,
+			accept: "application/vnd.github.v3.diff",
+			status: http.StatusOK,
+		},
+		{
+			name:   "empty asset",
+			method: http.MethodGet,
+			accept: "application/octet-stream",
+			status: http.StatusOK,
+		},
+		{
+			name:   "empty GitLab raw file",
+			method: http.MethodGet,
+			path:   "/projects/org%2Frepo/repository/files/empty.txt/raw",
+			accept: "application/json",
+			status: http.StatusOK,
+		},
+		{
+			name:        "empty JSON document",
+			method:      http.MethodGet,
+			accept:      "application/vnd.github.v3+json",
+			status:      http.StatusOK,
+			expectedErr: ErrEmptyResponse,
+		},
+		{
+			name:     "disabled",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			disabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				if tt.chunked {
+					// Flushing before writing the body prevents setting Content-Length
+					w.(http.Flusher).Flush()
+				}
+				_, _ = io.Copy(w, strings.NewReader(tt.body))
+			}))
+			defer srv.Close()
+
+			opts, err := MakeClientOptions(WithEmptyResponseCheck(!tt.disabled))
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := opts.BuildHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := tt.path
+			if path == "" {
+				path = "/repos/org/repo"
+			}
+			req, _ := http.NewRequest(tt.method, srv.URL+path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			var body []byte
+			resp, err := client.Do(req)
+			if err == nil {
+				body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if err != nil {
+				emptyErr := &EmptyResponseError{}
+				if !errors.As(err, &emptyErr) || emptyErr.URL != srv.URL+"/repos/org/repo" {
+					t.Errorf("expected *EmptyResponseError with the request URL, got %v", err)
+				}
+			}
+			if string(body) != tt.expectedBody {
+				t.Errorf("expected body %q, got %q", tt.expectedBody, body)
+			}
+		})
+	}
+}
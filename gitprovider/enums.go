@@ -16,7 +16,16 @@ limitations under the License.
 
 package gitprovider
 
-import "github.com/fluxcd/go-git-providers/validation"
+import (
+	"sync"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// enumsMu guards the maps of values registered per provider through the Register functions.
+//
+//nolint:gochecknoglobals
+var enumsMu sync.RWMutex
 
 // TransportType is an enum specifying the transport type used when cloning a repository.
 type TransportType string
@@ -57,14 +66,49 @@ var knownRepositoryVisibilityValues = map[RepositoryVisibility]struct{}{
 	RepositoryVisibilityPrivate:  {},
 }
 
-// ValidateRepositoryVisibility validates a given RepositoryVisibility.
+// registeredRepositoryVisibilityValues holds the RepositoryVisibility values registered per
+// provider through RegisterRepositoryVisibility.
+//
+//nolint:gochecknoglobals
+var registeredRepositoryVisibilityValues = map[ProviderID]map[RepositoryVisibility]struct{}{}
+
+// ValidateRepositoryVisibility validates a given RepositoryVisibility against the values defined
+// in this package. Use ValidateProviderRepositoryVisibility to also accept the values registered
+// for a provider.
 // Use as errs.Append(ValidateRepositoryVisibility(visibility), visibility, "FieldName").
 func ValidateRepositoryVisibility(r RepositoryVisibility) error {
-	_, ok := knownRepositoryVisibilityValues[r]
-	if !ok {
-		return validation.ErrFieldEnumInvalid
+	return ValidateProviderRepositoryVisibility("", r)
+}
+
+// ValidateProviderRepositoryVisibility validates a given RepositoryVisibility for the given
+// provider, accepting the values defined in this package and the ones registered for provider.
+// Use as errs.Append(ValidateProviderRepositoryVisibility(provider, visibility), visibility, "FieldName").
+func ValidateProviderRepositoryVisibility(provider ProviderID, r RepositoryVisibility) error {
+	if _, ok := knownRepositoryVisibilityValues[r]; ok {
+		return nil
+	}
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	if _, ok := registeredRepositoryVisibilityValues[provider][r]; ok {
+		return nil
+	}
+	return validation.ErrFieldEnumInvalid
+}
+
+// RegisterRepositoryVisibility registers additional RepositoryVisibility values that are accepted
+// by ValidateProviderRepositoryVisibility for the given provider, on top of the values defined in
+// this package. This allows provider packages to support visibilities specific to their provider,
+// without making them valid for other providers, and is meant to be called from the init
+// function of such a package.
+func RegisterRepositoryVisibility(provider ProviderID, values ...RepositoryVisibility) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	if registeredRepositoryVisibilityValues[provider] == nil {
+		registeredRepositoryVisibilityValues[provider] = map[RepositoryVisibility]struct{}{}
+	}
+	for _, v := range values {
+		registeredRepositoryVisibilityValues[provider][v] = struct{}{}
 	}
-	return nil
 }
 
 // RepositoryVisibilityVar returns a pointer to a RepositoryVisibility.
@@ -98,7 +142,7 @@ const (
 	RepositoryPermissionAdmin = RepositoryPermission("admin")
 )
 
-// knownRepositoryVisibilityValues is a map of known RepositoryPermission values, used for validation.
+// knownRepositoryPermissionValues is a map of known RepositoryPermission values, used for validation.
 //
 //nolint:gochecknoglobals
 var knownRepositoryPermissionValues = map[RepositoryPermission]struct{}{
@@ -109,14 +153,49 @@ var knownRepositoryPermissionValues = map[RepositoryPermission]struct{}{
 	RepositoryPermissionAdmin:    {},
 }
 
-// ValidateRepositoryPermission validates a given RepositoryPermission.
+// registeredRepositoryPermissionValues holds the RepositoryPermission values registered per
+// provider through RegisterRepositoryPermission.
+//
+//nolint:gochecknoglobals
+var registeredRepositoryPermissionValues = map[ProviderID]map[RepositoryPermission]struct{}{}
+
+// ValidateRepositoryPermission validates a given RepositoryPermission against the values defined
+// in this package. Use ValidateProviderRepositoryPermission to also accept the values registered
+// for a provider.
 // Use as errs.Append(ValidateRepositoryPermission(permission), permission, "FieldName").
 func ValidateRepositoryPermission(p RepositoryPermission) error {
-	_, ok := knownRepositoryPermissionValues[p]
-	if !ok {
-		return validation.ErrFieldEnumInvalid
+	return ValidateProviderRepositoryPermission("", p)
+}
+
+// ValidateProviderRepositoryPermission validates a given RepositoryPermission for the given
+// provider, accepting the values defined in this package and the ones registered for provider.
+// Use as errs.Append(ValidateProviderRepositoryPermission(provider, permission), permission, "FieldName").
+func ValidateProviderRepositoryPermission(provider ProviderID, p RepositoryPermission) error {
+	if _, ok := knownRepositoryPermissionValues[p]; ok {
+		return nil
+	}
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	if _, ok := registeredRepositoryPermissionValues[provider][p]; ok {
+		return nil
+	}
+	return validation.ErrFieldEnumInvalid
+}
+
+// RegisterRepositoryPermission registers additional RepositoryPermission values that are accepted
+// by ValidateProviderRepositoryPermission for the given provider, on top of the values defined in
+// this package. This allows provider packages to support access levels specific to their
+// provider, without making them valid for other providers, and is meant to be called from the
+// init function of such a package.
+func RegisterRepositoryPermission(provider ProviderID, values ...RepositoryPermission) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	if registeredRepositoryPermissionValues[provider] == nil {
+		registeredRepositoryPermissionValues[provider] = map[RepositoryPermission]struct{}{}
+	}
+	for _, v := range values {
+		registeredRepositoryPermissionValues[provider][v] = struct{}{}
 	}
-	return nil
 }
 
 // RepositoryPermissionVar returns a pointer to a RepositoryPermission.
@@ -124,6 +203,33 @@ func RepositoryPermissionVar(p RepositoryPermission) *RepositoryPermission {
 	return &p
 }
 
+// RepositoryPermissionNone ("none") - members only have access to repositories they are granted
+// access to explicitly. This is only valid as the default repository permission of an organization,
+// see ValidateDefaultRepositoryPermission.
+const RepositoryPermissionNone = RepositoryPermission("none")
+
+// knownDefaultRepositoryPermissionValues is a map of RepositoryPermission values that can be
+// used as the default repository permission of an organization, used for validation.
+//
+//nolint:gochecknoglobals
+var knownDefaultRepositoryPermissionValues = map[RepositoryPermission]struct{}{
+	RepositoryPermissionNone:  {},
+	RepositoryPermissionPull:  {},
+	RepositoryPermissionPush:  {},
+	RepositoryPermissionAdmin: {},
+}
+
+// ValidateDefaultRepositoryPermission validates a given RepositoryPermission used as the default
+// repository permission of an organization.
+// Use as errs.Append(ValidateDefaultRepositoryPermission(permission), permission, "FieldName").
+func ValidateDefaultRepositoryPermission(p RepositoryPermission) error {
+	_, ok := knownDefaultRepositoryPermissionValues[p]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
 // LicenseTemplate is an enum specifying a license template that can be used when creating a
 // repository. Examples of available licenses are here:
 // https://docs.github.com/en/github/creating-cloning-and-archiving-repositories/licensing-a-repository#searching-github-by-license-type
@@ -182,4 +288,477 @@ const (
 
 	// MergeMethodSquash causes a pull request merge to first squash commits
 	MergeMethodSquash = MergeMethod("squash")
+
+	// MergeMethodRebase causes a pull request merge to rebase the commits onto the base branch,
+	// without a merge commit
+	MergeMethodRebase = MergeMethod("rebase")
+
+	// MergeMethodFastForward causes a pull request merge to fast-forward the base branch, which
+	// fails if the pull request isn't up-to-date with the base branch
+	MergeMethodFastForward = MergeMethod("fast-forward")
+)
+
+// knownMergeMethodValues is a map of known MergeMethod values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownMergeMethodValues = map[MergeMethod]struct{}{
+	MergeMethodMerge:       {},
+	MergeMethodSquash:      {},
+	MergeMethodRebase:      {},
+	MergeMethodFastForward: {},
+}
+
+// ValidateMergeMethod validates a given MergeMethod.
+// Use as errs.Append(ValidateMergeMethod(method), method, "FieldName").
+func ValidateMergeMethod(m MergeMethod) error {
+	_, ok := knownMergeMethodValues[m]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// PullRequestState is an enum specifying the state of a pull request.
+type PullRequestState string
+
+const (
+	// PullRequestStateOpen specifies that the pull request is open, i.e. can be merged.
+	PullRequestStateOpen = PullRequestState("open")
+
+	// PullRequestStateClosed specifies that the pull request has been closed without merging it.
+	PullRequestStateClosed = PullRequestState("closed")
+
+	// PullRequestStateMerged specifies that the pull request has been merged.
+	PullRequestStateMerged = PullRequestState("merged")
+)
+
+// knownPullRequestStateValues is a map of known PullRequestState values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownPullRequestStateValues = map[PullRequestState]struct{}{
+	PullRequestStateOpen:   {},
+	PullRequestStateClosed: {},
+	PullRequestStateMerged: {},
+}
+
+// ValidatePullRequestState validates a given PullRequestState.
+// Use as errs.Append(ValidatePullRequestState(state), state, "FieldName").
+func ValidatePullRequestState(s PullRequestState) error {
+	_, ok := knownPullRequestStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// PullRequestStateVar returns a pointer to a PullRequestState.
+func PullRequestStateVar(s PullRequestState) *PullRequestState {
+	return &s
+}
+
+// ReviewState is an enum specifying the state of a pull request review.
+type ReviewState string
+
+const (
+	// ReviewStateApproved specifies that the reviewer approved the pull request.
+	ReviewStateApproved = ReviewState("approved")
+
+	// ReviewStateChangesRequested specifies that the reviewer requested changes to the pull request.
+	ReviewStateChangesRequested = ReviewState("changes_requested")
+
+	// ReviewStateCommented specifies that the reviewer commented without approving or requesting changes.
+	ReviewStateCommented = ReviewState("commented")
+
+	// ReviewStateDismissed specifies that the review has been dismissed.
+	ReviewStateDismissed = ReviewState("dismissed")
+
+	// ReviewStatePending specifies that the review has been requested or started, but not submitted yet.
+	ReviewStatePending = ReviewState("pending")
+)
+
+// FileChangeType is an enum specifying how a file has been changed, e.g. by a pull request.
+type FileChangeType string
+
+const (
+	// FileChangeTypeAdded specifies that the file has been added.
+	FileChangeTypeAdded = FileChangeType("added")
+
+	// FileChangeTypeModified specifies that the content or the mode of the file has been changed.
+	FileChangeTypeModified = FileChangeType("modified")
+
+	// FileChangeTypeRemoved specifies that the file has been removed.
+	FileChangeTypeRemoved = FileChangeType("removed")
+
+	// FileChangeTypeRenamed specifies that the file has been moved from another path, and possibly changed.
+	FileChangeTypeRenamed = FileChangeType("renamed")
+
+	// FileChangeTypeCopied specifies that the file has been copied from another path, and possibly changed.
+	FileChangeTypeCopied = FileChangeType("copied")
+)
+
+// RunnerStatus is an enum specifying the status of a self-hosted CI runner.
+type RunnerStatus string
+
+const (
+	// RunnerStatusOnline specifies that the runner is connected to the provider and can pick up jobs.
+	RunnerStatusOnline = RunnerStatus("online")
+	// RunnerStatusOffline specifies that the runner hasn't contacted the provider recently.
+	RunnerStatusOffline = RunnerStatus("offline")
+)
+
+// knownRunnerStatusValues is a map of known RunnerStatus values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownRunnerStatusValues = map[RunnerStatus]struct{}{
+	RunnerStatusOnline:  {},
+	RunnerStatusOffline: {},
+}
+
+// ValidateRunnerStatus validates a given RunnerStatus.
+// Use as errs.Append(ValidateRunnerStatus(status), status, "FieldName").
+func ValidateRunnerStatus(s RunnerStatus) error {
+	_, ok := knownRunnerStatusValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// RunnerStatusVar returns a pointer to a RunnerStatus.
+func RunnerStatusVar(s RunnerStatus) *RunnerStatus {
+	return &s
+}
+
+// AllowedActions is an enum specifying which (GitHub) actions are allowed to run in a repository.
+type AllowedActions string
+
+const (
+	// AllowedActionsAll specifies that all actions and reusable workflows are allowed to run.
+	AllowedActionsAll = AllowedActions("all")
+	// AllowedActionsLocalOnly specifies that only actions and reusable workflows defined in
+	// the same organization or user account are allowed to run.
+	AllowedActionsLocalOnly = AllowedActions("local_only")
+	// AllowedActionsSelected specifies that only actions and reusable workflows matching the
+	// configured patterns are allowed to run.
+	AllowedActionsSelected = AllowedActions("selected")
+)
+
+// knownAllowedActionsValues is a map of known AllowedActions values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownAllowedActionsValues = map[AllowedActions]struct{}{
+	AllowedActionsAll:       {},
+	AllowedActionsLocalOnly: {},
+	AllowedActionsSelected:  {},
+}
+
+// ValidateAllowedActions validates a given AllowedActions.
+// Use as errs.Append(ValidateAllowedActions(allowed), allowed, "FieldName").
+func ValidateAllowedActions(a AllowedActions) error {
+	_, ok := knownAllowedActionsValues[a]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// AllowedActionsVar returns a pointer to an AllowedActions.
+func AllowedActionsVar(a AllowedActions) *AllowedActions {
+	return &a
+}
+
+// ReactionContent is an enum specifying the content of a reaction (e.g. an emoji) to an issue,
+// pull request or comment.
+type ReactionContent string
+
+const (
+	// ReactionContentThumbsUp is the 👍 reaction. This is called "thumbsup" in GitLab.
+	ReactionContentThumbsUp = ReactionContent("+1")
+	// ReactionContentThumbsDown is the 👎 reaction. This is called "thumbsdown" in GitLab.
+	ReactionContentThumbsDown = ReactionContent("-1")
+	// ReactionContentLaugh is the 😄 reaction. This is called "laughing" in GitLab.
+	ReactionContentLaugh = ReactionContent("laugh")
+	// ReactionContentConfused is the 😕 reaction.
+	ReactionContentConfused = ReactionContent("confused")
+	// ReactionContentHeart is the ❤️ reaction.
+	ReactionContentHeart = ReactionContent("heart")
+	// ReactionContentHooray is the 🎉 reaction. This is called "tada" in GitLab.
+	ReactionContentHooray = ReactionContent("hooray")
+	// ReactionContentRocket is the 🚀 reaction.
+	ReactionContentRocket = ReactionContent("rocket")
+	// ReactionContentEyes is the 👀 reaction.
+	ReactionContentEyes = ReactionContent("eyes")
+)
+
+// knownReactionContentValues is a map of known ReactionContent values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownReactionContentValues = map[ReactionContent]struct{}{
+	ReactionContentThumbsUp:   {},
+	ReactionContentThumbsDown: {},
+	ReactionContentLaugh:      {},
+	ReactionContentConfused:   {},
+	ReactionContentHeart:      {},
+	ReactionContentHooray:     {},
+	ReactionContentRocket:     {},
+	ReactionContentEyes:       {},
+}
+
+// ValidateReactionContent validates a given ReactionContent.
+// Use as errs.Append(ValidateReactionContent(content), content, "FieldName").
+func ValidateReactionContent(c ReactionContent) error {
+	_, ok := knownReactionContentValues[c]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// ReactionContentVar returns a pointer to a ReactionContent.
+func ReactionContentVar(c ReactionContent) *ReactionContent {
+	return &c
+}
+
+// DeployTokenScope is an enum specifying what a (GitLab) deploy token grants access to.
+type DeployTokenScope string
+
+const (
+	// DeployTokenScopeReadRepository allows cloning the repository.
+	DeployTokenScopeReadRepository = DeployTokenScope("read_repository")
+	// DeployTokenScopeReadRegistry allows pulling images from the container registry.
+	DeployTokenScopeReadRegistry = DeployTokenScope("read_registry")
+	// DeployTokenScopeWriteRegistry allows pushing images to the container registry.
+	DeployTokenScopeWriteRegistry = DeployTokenScope("write_registry")
+	// DeployTokenScopeReadPackageRegistry allows pulling packages from the package registry.
+	DeployTokenScopeReadPackageRegistry = DeployTokenScope("read_package_registry")
+	// DeployTokenScopeWritePackageRegistry allows publishing packages to the package registry.
+	DeployTokenScopeWritePackageRegistry = DeployTokenScope("write_package_registry")
 )
+
+// knownDeployTokenScopeValues is a map of known DeployTokenScope values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownDeployTokenScopeValues = map[DeployTokenScope]struct{}{
+	DeployTokenScopeReadRepository:       {},
+	DeployTokenScopeReadRegistry:         {},
+	DeployTokenScopeWriteRegistry:        {},
+	DeployTokenScopeReadPackageRegistry:  {},
+	DeployTokenScopeWritePackageRegistry: {},
+}
+
+// ValidateDeployTokenScope validates a given DeployTokenScope.
+// Use as errs.Append(ValidateDeployTokenScope(scope), scope, "FieldName").
+func ValidateDeployTokenScope(s DeployTokenScope) error {
+	_, ok := knownDeployTokenScopeValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// DeployTokenScopeVar returns a pointer to a DeployTokenScope.
+func DeployTokenScopeVar(s DeployTokenScope) *DeployTokenScope {
+	return &s
+}
+
+// OwnerType is an enum specifying whether the owner of a repository is a user or an organization.
+type OwnerType string
+
+const (
+	// OwnerTypeUser specifies that the owner is a user.
+	OwnerTypeUser = OwnerType("user")
+	// OwnerTypeOrganization specifies that the owner is an organization (or sub-organization).
+	OwnerTypeOrganization = OwnerType("organization")
+)
+
+// knownOwnerTypeValues is a map of known OwnerType values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownOwnerTypeValues = map[OwnerType]struct{}{
+	OwnerTypeUser:         {},
+	OwnerTypeOrganization: {},
+}
+
+// ValidateOwnerType validates a given OwnerType.
+// Use as errs.Append(ValidateOwnerType(ownerType), ownerType, "FieldName").
+func ValidateOwnerType(t OwnerType) error {
+	_, ok := knownOwnerTypeValues[t]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// OwnerTypeVar returns a pointer to an OwnerType.
+func OwnerTypeVar(t OwnerType) *OwnerType {
+	return &t
+}
+
+// MilestoneState is an enum specifying the state of a milestone.
+type MilestoneState string
+
+const (
+	// MilestoneStateOpen specifies that the milestone is open (or active).
+	MilestoneStateOpen = MilestoneState("open")
+	// MilestoneStateClosed specifies that the milestone is closed.
+	MilestoneStateClosed = MilestoneState("closed")
+)
+
+// knownMilestoneStateValues is a map of known MilestoneState values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownMilestoneStateValues = map[MilestoneState]struct{}{
+	MilestoneStateOpen:   {},
+	MilestoneStateClosed: {},
+}
+
+// ValidateMilestoneState validates a given MilestoneState.
+// Use as errs.Append(ValidateMilestoneState(state), state, "FieldName").
+func ValidateMilestoneState(s MilestoneState) error {
+	_, ok := knownMilestoneStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// MilestoneStateVar returns a pointer to a MilestoneState.
+func MilestoneStateVar(s MilestoneState) *MilestoneState {
+	return &s
+}
+
+// RepositorySubscription is an enum specifying which notifications the authenticated user
+// receives for a repository.
+type RepositorySubscription string
+
+const (
+	// RepositorySubscriptionDefault specifies that there are no repository-specific settings,
+	// i.e. the user's default notification settings apply.
+	// This is called "global" in GitLab.
+	RepositorySubscriptionDefault = RepositorySubscription("default")
+	// RepositorySubscriptionParticipating specifies that the user is only notified about
+	// activity they participate in. GitHub can't set this explicitly, but only as the default.
+	RepositorySubscriptionParticipating = RepositorySubscription("participating")
+	// RepositorySubscriptionWatch specifies that the user is notified about all activity.
+	RepositorySubscriptionWatch = RepositorySubscription("watch")
+	// RepositorySubscriptionIgnore specifies that the user is never notified.
+	// This is called "disabled" in GitLab.
+	RepositorySubscriptionIgnore = RepositorySubscription("ignore")
+	// RepositorySubscriptionCustom specifies that the user is notified about a custom selection
+	// of events, which can only be configured through the provider. Only supported by GitLab.
+	RepositorySubscriptionCustom = RepositorySubscription("custom")
+)
+
+// knownRepositorySubscriptionValues is a map of known RepositorySubscription values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownRepositorySubscriptionValues = map[RepositorySubscription]struct{}{
+	RepositorySubscriptionDefault:       {},
+	RepositorySubscriptionParticipating: {},
+	RepositorySubscriptionWatch:         {},
+	RepositorySubscriptionIgnore:        {},
+	RepositorySubscriptionCustom:        {},
+}
+
+// ValidateRepositorySubscription validates a given RepositorySubscription.
+// Use as errs.Append(ValidateRepositorySubscription(subscription), subscription, "FieldName").
+func ValidateRepositorySubscription(s RepositorySubscription) error {
+	_, ok := knownRepositorySubscriptionValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// RepositorySubscriptionVar returns a pointer to a RepositorySubscription.
+func RepositorySubscriptionVar(s RepositorySubscription) *RepositorySubscription {
+	return &s
+}
+
+// WebhookEvent is an enum specifying an event that triggers a webhook. Each event is mapped to
+// the closest (set of) provider-specific events. The same enum is used as the type of the
+// events parsed by the webhooks package.
+type WebhookEvent string
+
+const (
+	// WebhookEventPush specifies that the webhook is triggered when commits are pushed to
+	// branches. GitHub and Stash trigger it for pushed tags too.
+	WebhookEventPush = WebhookEvent("push")
+	// WebhookEventPullRequest specifies that the webhook is triggered when pull requests are
+	// opened, updated, merged or closed.
+	WebhookEventPullRequest = WebhookEvent("pull_request")
+	// WebhookEventTag specifies that the webhook is triggered when tags are created or deleted.
+	// GitHub triggers it for created and deleted branches too. Not supported by Stash, which
+	// sends pushed tags as push events.
+	WebhookEventTag = WebhookEvent("tag")
+	// WebhookEventIssues specifies that the webhook is triggered when issues are opened, updated
+	// or closed. Not supported by Stash, which has no issue tracker.
+	WebhookEventIssues = WebhookEvent("issues")
+	// WebhookEventComment specifies that the webhook is triggered when comments are added to
+	// issues or pull requests.
+	WebhookEventComment = WebhookEvent("comment")
+	// WebhookEventRelease specifies that the webhook is triggered when releases are published.
+	// Not supported by Stash, which has no releases.
+	WebhookEventRelease = WebhookEvent("release")
+)
+
+// knownWebhookEventValues is a map of known WebhookEvent values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownWebhookEventValues = map[WebhookEvent]struct{}{
+	WebhookEventPush:        {},
+	WebhookEventPullRequest: {},
+	WebhookEventTag:         {},
+	WebhookEventIssues:      {},
+	WebhookEventComment:     {},
+	WebhookEventRelease:     {},
+}
+
+// ValidateWebhookEvent validates a given WebhookEvent.
+// Use as errs.Append(ValidateWebhookEvent(event), event, "FieldName").
+func ValidateWebhookEvent(e WebhookEvent) error {
+	_, ok := knownWebhookEventValues[e]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WebhookEventVar returns a pointer to a WebhookEvent.
+func WebhookEventVar(e WebhookEvent) *WebhookEvent {
+	return &e
+}
+
+// WebhookContentType is an enum specifying how the payload of a webhook is encoded.
+type WebhookContentType string
+
+const (
+	// WebhookContentTypeJSON specifies that the payload is sent as JSON request body.
+	WebhookContentTypeJSON = WebhookContentType("json")
+	// WebhookContentTypeForm specifies that the payload is sent as "payload" form parameter.
+	// Only supported by GitHub.
+	WebhookContentTypeForm = WebhookContentType("form")
+)
+
+// knownWebhookContentTypeValues is a map of known WebhookContentType values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownWebhookContentTypeValues = map[WebhookContentType]struct{}{
+	WebhookContentTypeJSON: {},
+	WebhookContentTypeForm: {},
+}
+
+// ValidateWebhookContentType validates a given WebhookContentType.
+// Use as errs.Append(ValidateWebhookContentType(contentType), contentType, "FieldName").
+func ValidateWebhookContentType(t WebhookContentType) error {
+	_, ok := knownWebhookContentTypeValues[t]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WebhookContentTypeVar returns a pointer to a WebhookContentType.
+func WebhookContentTypeVar(t WebhookContentType) *WebhookContentType {
+	return &t
+}
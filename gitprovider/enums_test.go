@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func TestRegisterRepositoryVisibility(t *testing.T) {
+	provider, other := ProviderID("test-provider"), ProviderID("test-other")
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		defer enumsMu.Unlock()
+		delete(registeredRepositoryVisibilityValues, provider)
+	})
+	custom := RepositoryVisibility("test-workspace")
+	if err := ValidateProviderRepositoryVisibility(provider, custom); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Fatalf("ValidateProviderRepositoryVisibility() before registration error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+
+	RegisterRepositoryVisibility(provider, custom)
+
+	for _, v := range []RepositoryVisibility{custom, RepositoryVisibilityPublic, RepositoryVisibilityInternal, RepositoryVisibilityPrivate} {
+		if err := ValidateProviderRepositoryVisibility(provider, v); err != nil {
+			t.Errorf("ValidateProviderRepositoryVisibility(%q) error = %v", v, err)
+		}
+	}
+	if err := ValidateProviderRepositoryVisibility(provider, "unknown"); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateProviderRepositoryVisibility() error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+	// The registered value is only valid for the provider it was registered for
+	if err := ValidateProviderRepositoryVisibility(other, custom); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateProviderRepositoryVisibility() for another provider error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+	info := RepositoryInfo{Visibility: &custom}
+	if err := info.ValidateInfo(); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateInfo() error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+	if err := info.ValidateInfoFor(provider); err != nil {
+		t.Errorf("ValidateInfoFor() error = %v", err)
+	}
+}
+
+func TestRegisterRepositoryPermission(t *testing.T) {
+	provider, other := ProviderID("test-provider"), ProviderID("test-other")
+	t.Cleanup(func() {
+		enumsMu.Lock()
+		defer enumsMu.Unlock()
+		delete(registeredRepositoryPermissionValues, provider)
+	})
+	custom := RepositoryPermission("test-write")
+	info := TeamAccessInfo{Name: "team", Permission: &custom}
+	if err := info.ValidateInfoFor(provider); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Fatalf("ValidateInfoFor() before registration error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+
+	RegisterRepositoryPermission(provider, custom)
+
+	if err := info.ValidateInfoFor(provider); err != nil {
+		t.Errorf("ValidateInfoFor() error = %v", err)
+	}
+	if err := info.ValidateInfoFor(other); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateInfoFor() for another provider error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+	if err := info.ValidateInfo(); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateInfo() error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+	if err := ValidateProviderRepositoryPermission(provider, RepositoryPermissionPush); err != nil {
+		t.Errorf("ValidateProviderRepositoryPermission(%q) error = %v", RepositoryPermissionPush, err)
+	}
+}
+
+func TestValidateWebhookEvent(t *testing.T) {
+	for _, e := range []WebhookEvent{WebhookEventPush, WebhookEventPullRequest, WebhookEventTag, WebhookEventIssues, WebhookEventComment, WebhookEventRelease} {
+		if err := ValidateWebhookEvent(e); err != nil {
+			t.Errorf("ValidateWebhookEvent(%q) error = %v", e, err)
+		}
+	}
+	if err := ValidateWebhookEvent("merge_request"); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateWebhookEvent() error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+}
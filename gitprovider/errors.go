@@ -18,6 +18,7 @@ package gitprovider
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -42,6 +43,22 @@ var (
 	ErrAlreadyExists = errors.New("resource already exists, cannot create object. Use Reconcile() to create it idempotently")
 	// ErrNotFound is returned by .Get() and .Update() calls if the given resource doesn't exist.
 	ErrNotFound = errors.New("the requested resource was not found")
+	// ErrForbidden is returned if the provider responded with 403 Forbidden, e.g. because the
+	// given credentials lack the (admin) scope required for the requested operation.
+	ErrForbidden = errors.New("the request was forbidden by the provider, check the token scopes")
+	// ErrSAMLAuthorizationRequired is returned if the organization enforces SAML single sign-on,
+	// and the credentials haven't been authorized for it. Use errors.As with a
+	// *SAMLAuthorizationRequiredError to get the URL at which the credentials can be authorized.
+	ErrSAMLAuthorizationRequired = errors.New("the credentials must be authorized for the organization's SAML single sign-on")
+	// ErrResponseTooLarge is returned when the body of an HTTP response exceeds the maximum size
+	// configured with WithMaxResponseSize.
+	ErrResponseTooLarge = errors.New("the response body exceeds the maximum size")
+	// ErrEmptyResponse is returned if the provider responded successfully with an empty body to a
+	// request that requires one, e.g. because of a misbehaving proxy. Use errors.As with a
+	// *EmptyResponseError to get the URL of the request.
+	ErrEmptyResponse = errors.New("the response body is empty, although one was expected")
+	// ErrUnknownOwnerType is returned if it can't be determined whether an owner is a user or an organization.
+	ErrUnknownOwnerType = errors.New("could not determine whether the owner is a user or an organization")
 	// ErrInvalidServerData is returned when the server returned invalid data, e.g. missing required fields in the response.
 	ErrInvalidServerData = errors.New("got invalid data from server, don't know how to handle")
 
@@ -60,6 +77,14 @@ var (
 	// ErrDestructiveCallDisallowed happens when the client isn't set up with WithDestructiveAPICalls()
 	// but a destructive action is called.
 	ErrDestructiveCallDisallowed = errors.New("destructive call was blocked, disallowed by client")
+	// ErrTooManyRedirects is returned if a request was redirected more often than allowed by the
+	// RedirectPolicy of the client.
+	ErrTooManyRedirects = errors.New("the request was redirected too many times")
+	// ErrRedirectLoop is returned if a request was redirected to a location it was already sent to.
+	ErrRedirectLoop = errors.New("the request was redirected in a loop")
+	// ErrRedirectMethodChanged is returned if following a redirect would change the method of the
+	// request, dropping its body, which isn't allowed by the RedirectPolicy of the client.
+	ErrRedirectMethodChanged = errors.New("following the redirect would change the request method")
 	// ErrInvalidTransportChainReturn is returned if a ChainableRoundTripperFunc returns nil, which is invalid.
 	ErrInvalidTransportChainReturn = errors.New("the return value of a ChainableRoundTripperFunc must not be nil")
 
@@ -68,8 +93,20 @@ var (
 	ErrInvalidPermissionLevel = errors.New("invalid permission level")
 	// ErrMissingHeader is returned when an expected header is missing from the HTTP response.
 	ErrMissingHeader = errors.New("header is missing")
+	// ErrMissingScopes is returned by ScopeCheckResult.Err if the token lacks required scopes.
+	ErrMissingScopes = errors.New("the token is missing required scopes")
 	// ErrGroupNotFound is returned when the gitlab group does not exist
 	ErrGroupNotFound = errors.New("404 Group Not Found")
+	// ErrNoCredentials is returned by a CredentialProvider which has no credentials, and by a
+	// CredentialChain if none of its providers has credentials.
+	ErrNoCredentials = errors.New("no credentials found")
+	// ErrCredentialsRequired is returned if an anonymous client, see WithAnonymous, is used for an
+	// operation modifying data. Use errors.As with *CredentialsRequiredError to get the request.
+	ErrCredentialsRequired = errors.New("the operation requires credentials, but the client is anonymous")
+	// ErrMissingTokenPermission is returned if the fine-grained permissions of the token, e.g. a
+	// GitHub fine-grained personal access token, don't allow an operation. Use errors.As with
+	// *MissingTokenPermissionError to get the permission.
+	ErrMissingTokenPermission = errors.New("the token lacks a permission required for the operation")
 )
 
 // HTTPError is an error that contains context about the HTTP request/response that failed.
@@ -89,6 +126,69 @@ func (e *HTTPError) Error() string {
 	return e.ErrorMessage
 }
 
+// EmptyResponseError is an error describing a request that was answered with an empty body,
+// although one was expected. It matches ErrEmptyResponse using errors.Is.
+type EmptyResponseError struct {
+	// URL of the request, with any password redacted.
+	URL string `json:"url"`
+	// StatusCode of the response.
+	StatusCode int `json:"statusCode"`
+}
+
+// Error implements the error interface.
+func (e *EmptyResponseError) Error() string {
+	return fmt.Sprintf("%s: got HTTP %d with an empty body: %v", e.URL, e.StatusCode, ErrEmptyResponse)
+}
+
+// Is makes the error match ErrEmptyResponse.
+func (e *EmptyResponseError) Is(target error) bool {
+	return target == ErrEmptyResponse
+}
+
+// CredentialsRequiredError is an error describing a request modifying data, which an anonymous
+// client didn't send. It matches ErrCredentialsRequired using errors.Is.
+type CredentialsRequiredError struct {
+	// Method of the request.
+	Method string `json:"method"`
+	// URL of the request, with any password redacted.
+	URL string `json:"url"`
+}
+
+// Error implements the error interface.
+func (e *CredentialsRequiredError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.URL, ErrCredentialsRequired)
+}
+
+// Is makes the error match ErrCredentialsRequired.
+func (e *CredentialsRequiredError) Is(target error) bool {
+	return target == ErrCredentialsRequired
+}
+
+// InsufficientMembershipError is an error describing that the authenticated user lacks the role
+// in an organization required for an operation. It matches ErrForbidden using errors.Is.
+type InsufficientMembershipError struct {
+	// Organization is the name of the organization.
+	Organization string `json:"organization"`
+	// Role is the provider-specific role of the user in the organization, e.g. "member" on GitHub
+	// or "reporter" on GitLab. It is empty if the user isn't a member of the organization.
+	Role string `json:"role"`
+	// RequiredRole is the provider-specific role the operation requires at least.
+	RequiredRole string `json:"requiredRole"`
+}
+
+// Error implements the error interface.
+func (e *InsufficientMembershipError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("not a member of organization %q, role %q is required", e.Organization, e.RequiredRole)
+	}
+	return fmt.Sprintf("role %q in organization %q is insufficient, role %q is required", e.Role, e.Organization, e.RequiredRole)
+}
+
+// Is makes the error match ErrForbidden.
+func (e *InsufficientMembershipError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
 // RateLimitError is an error, extending HTTPError, that contains context about rate limits.
 type RateLimitError struct {
 	// RateLimitError extends HTTPError.
@@ -131,3 +231,37 @@ type InvalidCredentialsError struct {
 	// InvalidCredentialsError extends HTTPError.
 	HTTPError `json:",inline"`
 }
+
+// MissingTokenPermissionError is an error, extending HTTPError, that describes a fine-grained
+// permission the token lacks for an operation. It matches ErrMissingTokenPermission using
+// errors.Is.
+type MissingTokenPermissionError struct {
+	// MissingTokenPermissionError extends HTTPError.
+	HTTPError `json:",inline"`
+
+	// Permission the token needs, in the provider-specific form "name=level", e.g.
+	// "administration=write" on GitHub. Multiple permissions that are all needed are separated
+	// by ",".
+	Permission string `json:"permission"`
+
+	// Alternatives are other permissions, of the same form as Permission, that allow the
+	// operation as well.
+	// +optional
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+// Is makes the error match ErrMissingTokenPermission.
+func (e *MissingTokenPermissionError) Is(target error) bool {
+	return target == ErrMissingTokenPermission
+}
+
+// SAMLAuthorizationRequiredError is an error, extending HTTPError, that describes that the
+// credentials must be authorized for the SAML single sign-on of an organization.
+type SAMLAuthorizationRequiredError struct {
+	// SAMLAuthorizationRequiredError extends HTTPError.
+	HTTPError `json:",inline"`
+
+	// AuthorizationURL is the URL at which the user can authorize the credentials, if the
+	// provider returned one.
+	AuthorizationURL string `json:"authorizationURL"`
+}
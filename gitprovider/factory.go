@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ClientFactory creates a Client for the given domain. The domain is the host (and port) of the
+// URL given to NewClientFromURL, prefixed with "http://" if that was the scheme used. The full URL
+// is passed as well, so that the factory can extract credentials from its user info, and any
+// provider-specific information (e.g. the Azure DevOps organization) from its path.
+type ClientFactory func(domain string, u *url.URL, opts ...ClientOption) (Client, error)
+
+// ProviderFactory describes how NewClientFromURL detects a provider and creates a client for it.
+type ProviderFactory struct {
+	// Domains are the hosts that are served by this provider, e.g. "github.com".
+	// +optional
+	Domains []string
+
+	// HostHints are labels that identify a self-hosted instance of this provider when they are
+	// part of the host name, e.g. "gitlab" matches "gitlab.example.com". The name the provider
+	// is registered with is always used as a hint.
+	// +optional
+	HostHints []string
+
+	// NewClient creates the client for this provider.
+	// +required
+	NewClient ClientFactory
+}
+
+// registeredProvider is a ProviderFactory registered under a name.
+type registeredProvider struct {
+	name    string
+	factory ProviderFactory
+}
+
+// providersMu guards providers.
+//
+//nolint:gochecknoglobals
+var providersMu sync.RWMutex
+
+// providers holds the providers registered through RegisterProvider, in registration order.
+//
+//nolint:gochecknoglobals
+var providers []registeredProvider
+
+// providerNameRegexp matches names that can be used as hint in the URL scheme.
+var providerNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9.-]*$`)
+
+// RegisterProvider registers a provider for NewClientFromURL under the given name, which is
+// usually the ProviderID of the provider, and acts as an explicit hint in the URL scheme, e.g.
+// "gitlab+https://git.example.com". The name must consist of lowercase letters, digits, dots
+// and dashes, and start with a letter.
+//
+// The providers of this module register themselves, but out-of-tree providers can be registered
+// the same way. This is meant to be called from the init function of a provider package, and
+// panics if the name is invalid or already registered, or the factory has no NewClient function.
+func RegisterProvider(name string, factory ProviderFactory) {
+	if !providerNameRegexp.MatchString(name) {
+		panic(fmt.Sprintf("gitprovider: invalid provider name %q", name))
+	}
+	if factory.NewClient == nil {
+		panic(fmt.Sprintf("gitprovider: provider %q has no NewClient function", name))
+	}
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	for _, p := range providers {
+		if p.name == name {
+			panic(fmt.Sprintf("gitprovider: provider %q registered twice", name))
+		}
+	}
+	providers = append(providers, registeredProvider{name: name, factory: factory})
+}
+
+// LookupProvider returns the ProviderFactory registered under the given name.
+func LookupProvider(name string) (ProviderFactory, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	for _, p := range providers {
+		if p.name == name {
+			return p.factory, true
+		}
+	}
+	return ProviderFactory{}, false
+}
+
+// RegisteredProviders returns the sorted names of all registered providers.
+func RegisteredProviders() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewClientFromURL creates a Client for the provider serving the given URL, e.g.
+// "https://github.com" or "https://TOKEN@gitlab.example.com/group". Only providers whose
+// packages have been imported (e.g. import _ "github.com/fluxcd/go-git-providers/github") are
+// detected. The provider is detected in the following order:
+//
+//   - an explicit hint in the URL scheme, e.g. "gitea+https://git.example.com",
+//   - the host matching one of the domains of a provider, e.g. "bitbucket.org",
+//   - a label of the host matching one of the host hints of a provider, e.g. "gitlab.example.com".
+//
+// Credentials can be given as the user info of the URL, where a password is used as the token
+// when set, and the username otherwise. Providers authenticating with a username and token use
+// both. The domain is set through WithDomain, hence opts must not contain it. Instances served
+// under a sub-path can't be detected, use the provider package directly for these.
+//
+// ErrDomainUnsupported is returned if no provider could be detected.
+func NewClientFromURL(rawURL string, opts ...ClientOption) (Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", rawURL, ErrInvalidArgument)
+	}
+
+	var hint string
+	scheme := u.Scheme
+	if i := strings.Index(scheme, "+"); i >= 0 {
+		hint, scheme = scheme[:i], scheme[i+1:]
+	}
+	if scheme != "https" && scheme != "http" {
+		return nil, fmt.Errorf("url %q must use the http or https scheme: %w", rawURL, ErrInvalidArgument)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("url %q has no host: %w", rawURL, ErrInvalidArgument)
+	}
+	u.Scheme = scheme
+
+	factory, ok := detectProvider(hint, strings.ToLower(u.Hostname()))
+	if !ok {
+		if hint != "" {
+			return nil, fmt.Errorf("provider %q isn't registered: %w", hint, ErrDomainUnsupported)
+		}
+		return nil, fmt.Errorf("couldn't detect the provider for %q: %w", u.Host, ErrDomainUnsupported)
+	}
+
+	domain := u.Host
+	if scheme == "http" {
+		domain = "http://" + domain
+	}
+	return factory.NewClient(domain, u, opts...)
+}
+
+// detectProvider returns the registered provider matching the given hint or host.
+func detectProvider(hint, host string) (ProviderFactory, bool) {
+	if hint != "" {
+		return LookupProvider(hint)
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	for _, p := range providers {
+		for _, d := range p.factory.Domains {
+			if host == d {
+				return p.factory, true
+			}
+		}
+	}
+	labels := strings.FieldsFunc(host, func(r rune) bool { return r == '.' || r == '-' })
+	for _, p := range providers {
+		hints := append([]string{p.name}, p.factory.HostHints...)
+		for _, h := range hints {
+			for _, l := range labels {
+				if l == h {
+					return p.factory, true
+				}
+			}
+		}
+	}
+	return ProviderFactory{}, false
+}
+
+// TokenFromURL returns the token given as user info of the URL, i.e. the password if set, and
+// the username otherwise.
+func TokenFromURL(u *url.URL) string {
+	if u.User == nil {
+		return ""
+	}
+	if password, ok := u.User.Password(); ok {
+		return password
+	}
+	return u.User.Username()
+}
@@ -86,6 +86,23 @@ type Reconcilable interface {
 	Reconcile(ctx context.Context) (actionTaken bool, err error)
 }
 
+// Reactable is an interface which all objects that can be reacted to (e.g. with an emoji)
+// using the Client implement.
+type Reactable interface {
+	// AddReaction adds a reaction with the given content to this object, as the authenticated user.
+	// If the user has already reacted with the given content, the existing reaction is returned.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support reactions for this object.
+	AddReaction(ctx context.Context, content ReactionContent) (Reaction, error)
+
+	// ListReactions lists all reactions to this object.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support reactions for this object.
+	//
+	// ListReactions returns all available reactions, using multiple paginated requests if needed.
+	ListReactions(ctx context.Context) ([]Reaction, error)
+}
+
 // Object is the interface all types should implement.
 type Object interface {
 	// APIObject returns the underlying value that was returned from the server.
@@ -105,6 +122,19 @@ type RepositoryBound interface {
 	Repository() RepositoryRef
 }
 
+// ListProgress describes how far a listing of resources has progressed.
+type ListProgress struct {
+	// Count is the number of resources listed so far.
+	Count int
+	// EstimatedTotal is the estimated total number of resources, derived from the pagination
+	// information of the provider. It is 0 if the provider doesn't report enough information
+	// to estimate it, and equals Count once the last page has been listed.
+	EstimatedTotal int
+}
+
+// ListProgressFunc is called with the progress of a listing after each page.
+type ListProgressFunc func(progress ListProgress)
+
 // ValidateAndDefaultInfo can be used in client Create() and Reconcile() functions, where the
 // request object, which implements InfoRequest, shall be first validated, and then defaulted.
 // Defaulting happens at Create(), because we want to consistently apply this library's defaults
@@ -120,3 +150,32 @@ func ValidateAndDefaultInfo(info DefaultedInfoRequest) error {
 	info.Default()
 	return nil
 }
+
+// InfoDefaulter is implemented by providers that have own defaults for some requests, which
+// differ from the provider-agnostic ones applied by DefaultedInfoRequest.Default(). For example,
+// the default branch of new repositories might be configured for the Git provider instance.
+//
+// In order to override the defaults of a provider, implement this interface, and pass the
+// implementation to ValidateAndDefaultInfoWith in the Create() and Reconcile() functions.
+type InfoDefaulter interface {
+	// DefaultInfo sets the unset optional fields of info the provider has own defaults for.
+	// Requests (or fields) without provider-specific defaults must be left as-is, as those
+	// are defaulted by info.Default() afterwards.
+	DefaultInfo(ctx context.Context, info DefaultedInfoRequest) error
+}
+
+// ValidateAndDefaultInfoWith is like ValidateAndDefaultInfo, but applies the provider-specific
+// defaults of d before the provider-agnostic info.Default(), which remains the fallback for all
+// fields d leaves unset. If d is nil, this is equal to ValidateAndDefaultInfo.
+func ValidateAndDefaultInfoWith(ctx context.Context, d InfoDefaulter, info DefaultedInfoRequest) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if d != nil {
+		if err := d.DefaultInfo(ctx, info); err != nil {
+			return err
+		}
+	}
+	info.Default()
+	return nil
+}
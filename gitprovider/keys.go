@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// WithClientCertificate initializes a Client which authenticates to the provider using the given
+// TLS client certificate (mutual TLS), e.g. the personal certificates supported by Bitbucket
+// Server. It can be combined with token authentication and with WithCustomCAPostChainTransportHook.
+// A custom PostChainTransportHook must return an *http.Transport for the certificate to be added
+// to it, otherwise requests fail with ErrInvalidClientOptions.
+//
+// Use LoadClientCertificate or LoadClientCertificateFiles to load the certificate.
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	// Don't allow an empty value
+	if len(cert.Certificate) == 0 || cert.PrivateKey == nil {
+		return optionError(fmt.Errorf("client certificate and private key are required: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{ClientCertificate: &cert})
+}
+
+// LoadClientCertificate parses a PEM encoded client certificate and its PEM encoded, unencrypted
+// private key, for use with WithClientCertificate.
+func LoadClientCertificate(certPEM, keyPEM []byte) (tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to parse client certificate: %v: %w", err, ErrInvalidClientOptions)
+	}
+	return cert, nil
+}
+
+// LoadClientCertificateFiles reads a client certificate and its private key from the given PEM
+// encoded files, for use with WithClientCertificate.
+func LoadClientCertificateFiles(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read client certificate key: %w", err)
+	}
+	return LoadClientCertificate(certPEM, keyPEM)
+}
+
+// LoadSSHPrivateKey parses a PEM encoded SSH private key (RSA, ECDSA or Ed25519, in the OpenSSH
+// or PKCS formats), which is used by providers performing Git operations over SSH. An empty
+// passphrase is only valid for unencrypted keys.
+func LoadSSHPrivateKey(keyPEM, passphrase []byte) (ssh.Signer, error) {
+	var signer ssh.Signer
+	var err error
+	if len(passphrase) == 0 {
+		signer, err = ssh.ParsePrivateKey(keyPEM)
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(keyPEM, passphrase)
+	}
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf("SSH private key is encrypted, but no passphrase given: %w", ErrInvalidClientOptions)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH private key: %v: %w", err, ErrInvalidClientOptions)
+	}
+	return signer, nil
+}
+
+// LoadSSHPrivateKeyFile reads the SSH private key from the given file, see LoadSSHPrivateKey.
+func LoadSSHPrivateKeyFile(path string, passphrase []byte) (ssh.Signer, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH private key: %w", err)
+	}
+	return LoadSSHPrivateKey(keyPEM, passphrase)
+}
+
+// clientCertificateTransport adds cert to the TLS configuration of "in", which has to be an
+// *http.Transport, e.g. the one built by WithCustomCAPostChainTransportHook.
+func clientCertificateTransport(cert tls.Certificate) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		t, ok := in.(*http.Transport)
+		if !ok {
+			return &errorTransport{err: fmt.Errorf("cannot add client certificate to transport of type %T: %w", in, ErrInvalidClientOptions)}
+		}
+
+		// Don't modify the shared transport, Clone also clones the TLS configuration
+		t = t.Clone()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		return t
+	}
+}
+
+// errorTransport fails every request with err.
+type errorTransport struct {
+	err error
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must always close the body
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return nil, t.err
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// newClientCertificate returns a PEM encoded, self-signed client certificate and its key.
+func newClientCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certPEM, keyPEM := newClientCertificate(t)
+	cert, err := LoadClientCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr bool
+		// wantInvalidOptions is whether the request fails with ErrInvalidClientOptions
+		wantInvalidOptions bool
+	}{
+		{
+			name: "with client certificate",
+			opts: []ClientOption{WithCustomCAPostChainTransportHook(caBundle), WithClientCertificate(cert)},
+		},
+		{
+			name:    "without client certificate",
+			opts:    []ClientOption{WithCustomCAPostChainTransportHook(caBundle)},
+			wantErr: true,
+		},
+		{
+			name: "custom post chain transport",
+			opts: []ClientOption{
+				WithPostChainTransportHook(func(in http.RoundTripper) http.RoundTripper { return &errorTransport{} }),
+				WithClientCertificate(cert),
+			},
+			wantErr:            true,
+			wantInvalidOptions: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := MakeClientOptions(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := opts.BuildHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Get(srv.URL)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				resp.Body.Close()
+				return
+			}
+			if err == nil {
+				resp.Body.Close()
+				t.Fatal("expected the request to fail")
+			}
+			if tt.wantInvalidOptions && !errors.Is(err, ErrInvalidClientOptions) {
+				t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithClientCertificate_invalid(t *testing.T) {
+	if _, err := MakeClientOptions(WithClientCertificate(tls.Certificate{})); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+	}
+
+	certPEM, keyPEM := newClientCertificate(t)
+	cert, err := LoadClientCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MakeClientOptions(WithClientCertificate(cert), WithClientCertificate(cert)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions for a duplicate option, got %v", err)
+	}
+}
+
+func TestLoadClientCertificateFiles(t *testing.T) {
+	certPEM, keyPEM := newClientCertificate(t)
+	otherCertPEM, _ := newClientCertificate(t)
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	certFile, keyFile, otherCertFile := write("cert.pem", certPEM), write("key.pem", keyPEM), write("other.pem", otherCertPEM)
+
+	if _, err := LoadClientCertificateFiles(certFile, keyFile); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// The key doesn't match the certificate
+	if _, err := LoadClientCertificateFiles(otherCertFile, keyFile); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+	}
+	if _, err := LoadClientCertificateFiles(filepath.Join(dir, "missing.pem"), keyFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestLoadSSHPrivateKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedBlock, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, encryptedPEM := pem.EncodeToMemory(block), pem.EncodeToMemory(encryptedBlock)
+
+	tests := []struct {
+		name       string
+		keyPEM     []byte
+		passphrase string
+		wantErr    bool
+	}{
+		{name: "unencrypted", keyPEM: keyPEM},
+		{name: "encrypted", keyPEM: encryptedPEM, passphrase: "secret"},
+		{name: "encrypted, missing passphrase", keyPEM: encryptedPEM, wantErr: true},
+		{name: "encrypted, wrong passphrase", keyPEM: encryptedPEM, passphrase: "wrong", wantErr: true},
+		{name: "invalid", keyPEM: []byte("not a key"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := LoadSSHPrivateKey(tt.keyPEM, []byte(tt.passphrase))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidClientOptions) {
+					t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := signer.PublicKey().Type(); got != ssh.KeyAlgoED25519 {
+				t.Errorf("got key type %q, want %q", got, ssh.KeyAlgoED25519)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+// MatchLabel returns the label in labels that a label reconcile of a label named name refers to,
+// as described in LabelReconcileOptions, or nil if there's no such label.
+func MatchLabel(labels []Label, name string, opts LabelReconcileOptions) Label {
+	if opts.ID != nil {
+		for _, l := range labels {
+			if l.ID() == *opts.ID {
+				return l
+			}
+		}
+	}
+	for _, l := range labels {
+		if l.Get().Name == name {
+			return l
+		}
+	}
+	if opts.PreviousName != nil {
+		for _, l := range labels {
+			if l.Get().Name == *opts.PreviousName {
+				return l
+			}
+		}
+	}
+	return nil
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package gitprovider
 
 import (
+	"strings"
+	"time"
+
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
@@ -55,6 +58,23 @@ type RepositoryCreateOptions struct {
 	// Default: nil.
 	// Available options: See the LicenseTemplate enum.
 	LicenseTemplate *LicenseTemplate
+
+	// VerifyVisibility can be set to read the repository again shortly after it was created, and
+	// report if its visibility differs from the requested one, e.g. because an organization policy
+	// forced it to be private. A mismatch is reported through VerifyVisibility.OnMismatch, and
+	// doesn't fail the creation. Only supported by GitHub, GitLab, Gitea, Gogs and sourcehut, the
+	// other providers return ErrNoProviderSupport.
+	// Default: nil (which means "don't verify")
+	VerifyVisibility *VisibilityVerification
+
+	// CheckOrgMembership can be set to true in order to verify that the authenticated user has a
+	// role in the organization that allows creating repositories, before creating one. If not,
+	// an *InsufficientMembershipError is returned, which matches ErrForbidden. This costs extra
+	// requests, hence callers that know they have access can leave it unset. Only supported by
+	// GitHub and GitLab, the other providers return ErrNoProviderSupport. It's ignored when
+	// creating user repositories.
+	// Default: nil (which means "false, don't check")
+	CheckOrgMembership *bool
 }
 
 // ApplyToRepositoryCreateOptions applies the options defined in the options struct to the
@@ -67,6 +87,12 @@ func (opts *RepositoryCreateOptions) ApplyToRepositoryCreateOptions(target *Repo
 	if opts.LicenseTemplate != nil {
 		target.LicenseTemplate = opts.LicenseTemplate
 	}
+	if opts.VerifyVisibility != nil {
+		target.VerifyVisibility = opts.VerifyVisibility
+	}
+	if opts.CheckOrgMembership != nil {
+		target.CheckOrgMembership = opts.CheckOrgMembership
+	}
 }
 
 // ValidateOptions validates that the options are valid.
@@ -75,6 +101,14 @@ func (opts *RepositoryCreateOptions) ValidateOptions() error {
 	if opts.LicenseTemplate != nil {
 		errs.Append(ValidateLicenseTemplate(*opts.LicenseTemplate), *opts.LicenseTemplate, "LicenseTemplate")
 	}
+	if opts.VerifyVisibility != nil {
+		if opts.VerifyVisibility.OnMismatch == nil {
+			errs.Required("VerifyVisibility.OnMismatch")
+		}
+		if opts.VerifyVisibility.Delay < 0 {
+			errs.Invalid(opts.VerifyVisibility.Delay, "VerifyVisibility.Delay")
+		}
+	}
 	return errs.Error()
 }
 
@@ -94,3 +128,559 @@ func (opts *FilesGetOptions) ApplyFilesGetOptions(target *FilesGetOptions) {
 	target.Recursive = opts.Recursive
 
 }
+
+// MakeCommitCreateOptions returns a CommitCreateOptions based off the mutator functions
+// given to e.g. CommitClient.Create(), and validates the result.
+func MakeCommitCreateOptions(opts ...CommitCreateOption) (CommitCreateOptions, error) {
+	o := &CommitCreateOptions{}
+	for _, opt := range opts {
+		opt.ApplyToCommitCreateOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// CommitCreateOption is an interface for applying options to when creating commits.
+type CommitCreateOption interface {
+	// ApplyToCommitCreateOptions should apply relevant options to the target.
+	ApplyToCommitCreateOptions(target *CommitCreateOptions)
+}
+
+// CommitCreateOptions specifies optional options when creating a commit.
+type CommitCreateOptions struct {
+	// ProviderSigned can be set to true in order to request the Git provider to sign the commit
+	// with its own key, so that it shows up as verified. Whether the commit actually got signed
+	// is reported in the Verification field of the returned commit.
+	// ErrNoProviderSupport is returned if the provider can't be asked to sign commits.
+	// Default: nil (which means "false, don't request signing")
+	ProviderSigned *bool
+
+	// Author can be set in order to record the given identity as the author of the commit,
+	// e.g. a service identity, instead of the user the client is authenticated as.
+	// Default: nil (which means "the authenticated user")
+	Author *CommitAuthor
+}
+
+// ApplyToCommitCreateOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *CommitCreateOptions) ApplyToCommitCreateOptions(target *CommitCreateOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.ProviderSigned != nil {
+		target.ProviderSigned = opts.ProviderSigned
+	}
+	if opts.Author != nil {
+		target.Author = opts.Author
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *CommitCreateOptions) ValidateOptions() error {
+	if opts.Author != nil {
+		return opts.Author.ValidateInfo()
+	}
+	return nil
+}
+
+// MakeTeamListOptions returns a TeamListOptions based off the mutator functions
+// given to e.g. TeamsClient.List().
+func MakeTeamListOptions(opts ...TeamListOption) TeamListOptions {
+	o := &TeamListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToTeamListOptions(o)
+	}
+	return *o
+}
+
+// TeamListOption is an interface for applying options to when listing teams.
+type TeamListOption interface {
+	// ApplyToTeamListOptions should apply relevant options to the target.
+	ApplyToTeamListOptions(target *TeamListOptions)
+}
+
+// TeamListOptions specifies optional options when listing teams.
+type TeamListOptions struct {
+	// TopLevelOnly can be set to true in order to only list teams without a parent team.
+	// Default: nil (which means "false, list teams at all levels")
+	TopLevelOnly *bool
+}
+
+// ApplyToTeamListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *TeamListOptions) ApplyToTeamListOptions(target *TeamListOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.TopLevelOnly != nil {
+		target.TopLevelOnly = opts.TopLevelOnly
+	}
+}
+
+// MakeRepositorySearchOptions returns a RepositorySearchOptions based off the mutator functions
+// given to e.g. OrgRepositoriesClient.SearchPage().
+// validation.ErrFieldRequired is returned if the language is set, but empty.
+func MakeRepositorySearchOptions(opts ...RepositorySearchOption) (RepositorySearchOptions, error) {
+	o := &RepositorySearchOptions{}
+	for _, opt := range opts {
+		opt.ApplyToRepositorySearchOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// RepositorySearchOption is an interface for applying options to when searching repositories.
+type RepositorySearchOption interface {
+	// ApplyToRepositorySearchOptions should apply relevant options to the target.
+	ApplyToRepositorySearchOptions(target *RepositorySearchOptions)
+}
+
+// RepositorySearchOptions specifies the filters to apply when searching repositories.
+type RepositorySearchOptions struct {
+	// Language filters the repositories by their primary language, e.g. "Go".
+	// The language name is passed to the provider as-is, and is matched case-insensitively.
+	// Default: nil (which means "don't filter by language")
+	Language *string
+}
+
+// ApplyToRepositorySearchOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *RepositorySearchOptions) ApplyToRepositorySearchOptions(target *RepositorySearchOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.Language != nil {
+		target.Language = opts.Language
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *RepositorySearchOptions) ValidateOptions() error {
+	errs := validation.New("RepositorySearchOptions")
+	if opts.Language != nil && strings.TrimSpace(*opts.Language) == "" {
+		errs.Required("Language")
+	}
+	return errs.Error()
+}
+
+// MakeLabelReconcileOptions returns a LabelReconcileOptions based off the mutator functions
+// given to e.g. LabelClient.Reconcile().
+func MakeLabelReconcileOptions(opts ...LabelReconcileOption) LabelReconcileOptions {
+	o := &LabelReconcileOptions{}
+	for _, opt := range opts {
+		opt.ApplyToLabelReconcileOptions(o)
+	}
+	return *o
+}
+
+// LabelReconcileOption is an interface for applying options to when reconciling labels.
+type LabelReconcileOption interface {
+	// ApplyToLabelReconcileOptions should apply relevant options to the target.
+	ApplyToLabelReconcileOptions(target *LabelReconcileOptions)
+}
+
+// LabelReconcileOptions specifies how to find the existing label when reconciling a label.
+// Matching an existing label that has a different name renames it in place, which keeps its
+// association with existing issues and pull requests, instead of deleting and recreating it.
+// The label is matched by ID first, then by the desired name, and then by PreviousName.
+type LabelReconcileOptions struct {
+	// ID matches the label with the given provider ID, as returned by Label.ID().
+	// Default: nil (which means "match by name")
+	ID *string
+
+	// PreviousName matches the label with the given name, if there's no label with the
+	// desired name yet.
+	// Default: nil (which means "match by the desired name only")
+	PreviousName *string
+}
+
+// ApplyToLabelReconcileOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *LabelReconcileOptions) ApplyToLabelReconcileOptions(target *LabelReconcileOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.ID != nil {
+		target.ID = opts.ID
+	}
+	if opts.PreviousName != nil {
+		target.PreviousName = opts.PreviousName
+	}
+}
+
+// MakeWebhookReconcileOptions returns a WebhookReconcileOptions based off the mutator functions
+// given to e.g. WebhookClient.Reconcile().
+func MakeWebhookReconcileOptions(opts ...WebhookReconcileOption) WebhookReconcileOptions {
+	o := &WebhookReconcileOptions{}
+	for _, opt := range opts {
+		opt.ApplyToWebhookReconcileOptions(o)
+	}
+	return *o
+}
+
+// WebhookReconcileOption is an interface for applying options to when reconciling webhooks.
+type WebhookReconcileOption interface {
+	// ApplyToWebhookReconcileOptions should apply relevant options to the target.
+	ApplyToWebhookReconcileOptions(target *WebhookReconcileOptions)
+}
+
+// WebhookReconcileOptions specifies optional options when reconciling webhooks.
+type WebhookReconcileOptions struct {
+	// ForceSecretRotation deletes the existing webhook, and recreates it with the secret in the
+	// desired state. As the Git providers don't return the secret, this is the only way to make
+	// sure the webhook uses the desired secret. Payloads sent in between are lost.
+	// ErrInvalidArgument is returned if the desired state has no secret.
+	// Default: nil (which means "only update the webhook if it differs from the desired state")
+	ForceSecretRotation *bool
+}
+
+// ApplyToWebhookReconcileOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *WebhookReconcileOptions) ApplyToWebhookReconcileOptions(target *WebhookReconcileOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.ForceSecretRotation != nil {
+		target.ForceSecretRotation = opts.ForceSecretRotation
+	}
+}
+
+// MakeDeployKeyListOptions returns a DeployKeyListOptions based off the mutator functions
+// given to e.g. DeployKeyClient.List().
+func MakeDeployKeyListOptions(opts ...DeployKeyListOption) DeployKeyListOptions {
+	o := &DeployKeyListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToDeployKeyListOptions(o)
+	}
+	return *o
+}
+
+// DeployKeyListOption is an interface for applying options to when listing deploy keys.
+type DeployKeyListOption interface {
+	// ApplyToDeployKeyListOptions should apply relevant options to the target.
+	ApplyToDeployKeyListOptions(target *DeployKeyListOptions)
+}
+
+// DeployKeyListOptions specifies optional options when listing deploy keys.
+type DeployKeyListOptions struct {
+	// SortByLastUsed sorts the deploy keys by DeployKey.LastUsedAt, least recently used first.
+	// Keys without a last used time come first, in the order returned by the provider.
+	// Default: nil (which means "in the order returned by the provider")
+	SortByLastUsed *bool
+}
+
+// ApplyToDeployKeyListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *DeployKeyListOptions) ApplyToDeployKeyListOptions(target *DeployKeyListOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.SortByLastUsed != nil {
+		target.SortByLastUsed = opts.SortByLastUsed
+	}
+}
+
+// MakePullRequestListOptions returns a PullRequestListOptions based off the mutator functions
+// given to e.g. PullRequestClient.List().
+// validation.ErrFieldEnumInvalid is returned if the state is set, but unknown.
+func MakePullRequestListOptions(opts ...PullRequestListOption) (PullRequestListOptions, error) {
+	o := &PullRequestListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestListOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// PullRequestListOption is an interface for applying options to when listing pull requests.
+type PullRequestListOption interface {
+	// ApplyToPullRequestListOptions should apply relevant options to the target.
+	ApplyToPullRequestListOptions(target *PullRequestListOptions)
+}
+
+// PullRequestListOptions specifies optional filters when listing pull requests. The filters are
+// applied by the provider where possible, and otherwise to the pull requests it returns.
+type PullRequestListOptions struct {
+	// State filters the pull requests by their state.
+	// Default: nil (which means "list open pull requests")
+	State *PullRequestState
+
+	// BaseBranch filters the pull requests by the branch they are merged into.
+	// Default: nil (which means "list pull requests for all base branches")
+	BaseBranch *string
+
+	// SourceBranch filters the pull requests by the branch they have been created from.
+	// Default: nil (which means "list pull requests from all source branches")
+	SourceBranch *string
+
+	// Author filters the pull requests by the username of the user who created them.
+	// Default: nil (which means "list pull requests of all authors")
+	Author *string
+
+	// Label filters the pull requests by the name of one of their labels.
+	// Default: nil (which means "list pull requests regardless of their labels")
+	Label *string
+
+	// UpdatedSince filters out the pull requests which haven't been updated since the given time.
+	// Default: nil (which means "list pull requests regardless of when they were updated")
+	UpdatedSince *time.Time
+}
+
+// ApplyToPullRequestListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestListOptions) ApplyToPullRequestListOptions(target *PullRequestListOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.State != nil {
+		target.State = opts.State
+	}
+	if opts.BaseBranch != nil {
+		target.BaseBranch = opts.BaseBranch
+	}
+	if opts.SourceBranch != nil {
+		target.SourceBranch = opts.SourceBranch
+	}
+	if opts.Author != nil {
+		target.Author = opts.Author
+	}
+	if opts.Label != nil {
+		target.Label = opts.Label
+	}
+	if opts.UpdatedSince != nil {
+		target.UpdatedSince = opts.UpdatedSince
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PullRequestListOptions) ValidateOptions() error {
+	errs := validation.New("PullRequestListOptions")
+	if opts.State != nil {
+		errs.Append(ValidatePullRequestState(*opts.State), *opts.State, "State")
+	}
+	return errs.Error()
+}
+
+// MakeMilestoneListOptions returns a MilestoneListOptions based off the mutator functions
+// given to e.g. MilestoneClient.List().
+// validation.ErrFieldEnumInvalid is returned if the state is set, but unknown.
+func MakeMilestoneListOptions(opts ...MilestoneListOption) (MilestoneListOptions, error) {
+	o := &MilestoneListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToMilestoneListOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// MilestoneListOption is an interface for applying options to when listing milestones.
+type MilestoneListOption interface {
+	// ApplyToMilestoneListOptions should apply relevant options to the target.
+	ApplyToMilestoneListOptions(target *MilestoneListOptions)
+}
+
+// MilestoneListOptions specifies optional options when listing milestones.
+type MilestoneListOptions struct {
+	// State filters the milestones by their state.
+	// Default: nil (which means "list both open and closed milestones")
+	State *MilestoneState
+}
+
+// ApplyToMilestoneListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *MilestoneListOptions) ApplyToMilestoneListOptions(target *MilestoneListOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.State != nil {
+		target.State = opts.State
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *MilestoneListOptions) ValidateOptions() error {
+	errs := validation.New("MilestoneListOptions")
+	if opts.State != nil {
+		errs.Append(ValidateMilestoneState(*opts.State), *opts.State, "State")
+	}
+	return errs.Error()
+}
+
+// MakeOwnerResolverOptions returns an OwnerResolverOptions based off the mutator functions
+// given to NewOwnerResolver(), with the defaults filled in.
+func MakeOwnerResolverOptions(opts ...OwnerResolverOption) OwnerResolverOptions {
+	o := &OwnerResolverOptions{}
+	for _, opt := range opts {
+		opt.ApplyToOwnerResolverOptions(o)
+	}
+	if o.CacheTTL == nil {
+		ttl := DefaultOwnerCacheTTL
+		o.CacheTTL = &ttl
+	}
+	if o.Clock == nil {
+		o.Clock = SystemClock
+	}
+	return *o
+}
+
+// OwnerResolverOption is an interface for applying options to an OwnerResolver.
+type OwnerResolverOption interface {
+	// ApplyToOwnerResolverOptions should apply relevant options to the target.
+	ApplyToOwnerResolverOptions(target *OwnerResolverOptions)
+}
+
+// OwnerResolverOptions specifies optional options for an OwnerResolver.
+type OwnerResolverOptions struct {
+	// CacheTTL is the duration for which resolved owner types are cached. Once it has passed,
+	// the owner type is looked up again, so that e.g. renamed users or groups don't stay stale
+	// forever. Zero or a negative duration disables caching.
+	// Default: nil (which means DefaultOwnerCacheTTL)
+	CacheTTL *time.Duration
+
+	// Clock is used to expire cached owner types.
+	// Default: nil (which means SystemClock)
+	Clock Clock
+}
+
+// ApplyToOwnerResolverOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *OwnerResolverOptions) ApplyToOwnerResolverOptions(target *OwnerResolverOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.CacheTTL != nil {
+		target.CacheTTL = opts.CacheTTL
+	}
+	if opts.Clock != nil {
+		target.Clock = opts.Clock
+	}
+}
+
+// MakeRepositoryTransferOptions returns a RepositoryTransferOptions based off the mutator
+// functions given to e.g. OrgRepositoriesClient.Transfer().
+// validation.ErrFieldRequired is returned if a team access spec has no team name.
+func MakeRepositoryTransferOptions(opts ...RepositoryTransferOption) (RepositoryTransferOptions, error) {
+	o := &RepositoryTransferOptions{}
+	for _, opt := range opts {
+		opt.ApplyToRepositoryTransferOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// RepositoryTransferOption is an interface for applying options to when transferring repositories.
+type RepositoryTransferOption interface {
+	// ApplyToRepositoryTransferOptions should apply relevant options to the target.
+	ApplyToRepositoryTransferOptions(target *RepositoryTransferOptions)
+}
+
+// RepositoryTransferOptions specifies optional options when transferring a repository.
+type RepositoryTransferOptions struct {
+	// TeamAccess is reconciled on the repository in the new organization once the transfer is
+	// done, as team access is lost when transferring. The teams must exist in the new organization.
+	// Default: nil (which means "don't give any teams access")
+	TeamAccess []TeamAccessInfo
+
+	// PollInterval is the initial interval at which the new organization is polled until the
+	// repository is visible there. It's doubled after each poll, up to 30 seconds.
+	// Default: nil (which means the PollInterval of the CallOptions carried by the context, or 1 second)
+	PollInterval *time.Duration
+
+	// Timeout bounds how long the new organization is polled until the repository is visible
+	// there. The deadline of the context applies too, if it's earlier.
+	// Default: nil (which means 5 minutes)
+	Timeout *time.Duration
+}
+
+// ApplyToRepositoryTransferOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *RepositoryTransferOptions) ApplyToRepositoryTransferOptions(target *RepositoryTransferOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.TeamAccess != nil {
+		target.TeamAccess = opts.TeamAccess
+	}
+	if opts.PollInterval != nil {
+		target.PollInterval = opts.PollInterval
+	}
+	if opts.Timeout != nil {
+		target.Timeout = opts.Timeout
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *RepositoryTransferOptions) ValidateOptions() error {
+	if opts.Timeout != nil && *opts.Timeout <= 0 {
+		errs := validation.New("RepositoryTransferOptions")
+		errs.Invalid(*opts.Timeout, "Timeout")
+		return errs.Error()
+	}
+	for _, ta := range opts.TeamAccess {
+		if err := ta.ValidateInfo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MakePullRequestCreateOptions returns a PullRequestCreateOptions based off the mutator functions
+// given to e.g. PullRequestClient.Create().
+func MakePullRequestCreateOptions(opts ...PullRequestCreateOption) PullRequestCreateOptions {
+	o := &PullRequestCreateOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestCreateOptions(o)
+	}
+	return *o
+}
+
+// PullRequestCreateOption is an interface for applying options to when creating pull requests.
+type PullRequestCreateOption interface {
+	// ApplyToPullRequestCreateOptions should apply relevant options to the target.
+	ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions)
+}
+
+// PullRequestCreateOptions specifies optional options when creating a pull request.
+type PullRequestCreateOptions struct {
+	// Draft can be set to true in order to create the pull request as draft, i.e. not ready for
+	// review (yet). GitLab marks draft merge requests through a "Draft:" prefix of the title.
+	// Default: nil (which means "false, ready for review")
+	Draft *bool
+
+	// HeadRepository can be set to the repository the head branch lives in, in order to create a
+	// pull request from a fork into the repository of the client. Not all providers support this.
+	// Default: nil (which means "the head branch lives in the repository of the client")
+	HeadRepository RepositoryRef
+}
+
+// ApplyToPullRequestCreateOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestCreateOptions) ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.Draft != nil {
+		target.Draft = opts.Draft
+	}
+	if opts.HeadRepository != nil {
+		target.HeadRepository = opts.HeadRepository
+	}
+}
+
+// MakePullRequestMergeOptions returns a PullRequestMergeOptions based off the mutator functions
+// given to e.g. PullRequestClient.Merge().
+func MakePullRequestMergeOptions(opts ...PullRequestMergeOption) PullRequestMergeOptions {
+	o := &PullRequestMergeOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestMergeOptions(o)
+	}
+	return *o
+}
+
+// PullRequestMergeOption is an interface for applying options to when merging pull requests.
+type PullRequestMergeOption interface {
+	// ApplyToPullRequestMergeOptions should apply relevant options to the target.
+	ApplyToPullRequestMergeOptions(target *PullRequestMergeOptions)
+}
+
+// PullRequestMergeOptions specifies optional options when merging a pull request.
+type PullRequestMergeOptions struct {
+	// CommitTitle overrides the title, i.e. the first line, of the merge or squash commit.
+	// Providers without a separate title prepend it to the commit message.
+	// Default: nil (which means "the default title of the provider")
+	CommitTitle *string
+}
+
+// ApplyToPullRequestMergeOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestMergeOptions) ApplyToPullRequestMergeOptions(target *PullRequestMergeOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.CommitTitle != nil {
+		target.CommitTitle = opts.CommitTitle
+	}
+}
+
+// CommitMessage returns message with CommitTitle as its first line, for providers that take
+// the full commit message only.
+func (opts *PullRequestMergeOptions) CommitMessage(message string) string {
+	if opts.CommitTitle == nil {
+		return message
+	}
+	if message == "" {
+		return *opts.CommitTitle
+	}
+	return *opts.CommitTitle + "\n\n" + message
+}
@@ -93,3 +93,220 @@ func TestMakeRepositoryCreateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeCommitCreateOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []CommitCreateOption
+		want    CommitCreateOptions
+		wantErr bool
+	}{
+		{
+			name: "default nil pointers",
+			want: CommitCreateOptions{},
+		},
+		{
+			name: "request signing",
+			opts: []CommitCreateOption{&CommitCreateOptions{ProviderSigned: BoolVar(true)}},
+			want: CommitCreateOptions{ProviderSigned: BoolVar(true)},
+		},
+		{
+			name: "latter overrides former",
+			opts: []CommitCreateOption{
+				&CommitCreateOptions{ProviderSigned: BoolVar(true)},
+				&CommitCreateOptions{ProviderSigned: BoolVar(false)},
+			},
+			want: CommitCreateOptions{ProviderSigned: BoolVar(false)},
+		},
+		{
+			name: "unset fields don't override",
+			opts: []CommitCreateOption{
+				&CommitCreateOptions{ProviderSigned: BoolVar(true)},
+				&CommitCreateOptions{},
+			},
+			want: CommitCreateOptions{ProviderSigned: BoolVar(true)},
+		},
+		{
+			name: "author",
+			opts: []CommitCreateOption{&CommitCreateOptions{Author: &CommitAuthor{Name: "Flux", Email: "flux@example.com"}}},
+			want: CommitCreateOptions{Author: &CommitAuthor{Name: "Flux", Email: "flux@example.com"}},
+		},
+		{
+			name:    "author with invalid email",
+			opts:    []CommitCreateOption{&CommitCreateOptions{Author: &CommitAuthor{Name: "Flux", Email: "flux"}}},
+			want:    CommitCreateOptions{Author: &CommitAuthor{Name: "Flux", Email: "flux"}},
+			wantErr: true,
+		},
+		{
+			name:    "author without name",
+			opts:    []CommitCreateOption{&CommitCreateOptions{Author: &CommitAuthor{Email: "flux@example.com"}}},
+			want:    CommitCreateOptions{Author: &CommitAuthor{Email: "flux@example.com"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakeCommitCreateOptions(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MakeCommitCreateOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakeCommitCreateOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeRepositorySearchOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []RepositorySearchOption
+		want         RepositorySearchOptions
+		expectedErrs []error
+	}{
+		{
+			name: "no filters",
+			want: RepositorySearchOptions{},
+		},
+		{
+			name: "language",
+			opts: []RepositorySearchOption{&RepositorySearchOptions{Language: StringVar("Go")}},
+			want: RepositorySearchOptions{Language: StringVar("Go")},
+		},
+		{
+			name:         "empty language",
+			opts:         []RepositorySearchOption{&RepositorySearchOptions{Language: StringVar(" ")}},
+			want:         RepositorySearchOptions{Language: StringVar(" ")},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakeRepositorySearchOptions(tt.opts...)
+			validation.TestExpectErrors(t, "MakeRepositorySearchOptions", err, tt.expectedErrs...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakeRepositorySearchOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakePullRequestCreateOptions(t *testing.T) {
+	fork := UserRepositoryRef{
+		UserRef:        UserRef{Domain: "github.com", UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	tests := []struct {
+		name string
+		opts []PullRequestCreateOption
+		want PullRequestCreateOptions
+	}{
+		{
+			name: "default nil pointers",
+			want: PullRequestCreateOptions{},
+		},
+		{
+			name: "draft",
+			opts: []PullRequestCreateOption{&PullRequestCreateOptions{Draft: BoolVar(true)}},
+			want: PullRequestCreateOptions{Draft: BoolVar(true)},
+		},
+		{
+			name: "head repository",
+			opts: []PullRequestCreateOption{&PullRequestCreateOptions{HeadRepository: fork}},
+			want: PullRequestCreateOptions{HeadRepository: fork},
+		},
+		{
+			name: "unset fields don't override",
+			opts: []PullRequestCreateOption{
+				&PullRequestCreateOptions{Draft: BoolVar(true)},
+				&PullRequestCreateOptions{},
+			},
+			want: PullRequestCreateOptions{Draft: BoolVar(true)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MakePullRequestCreateOptions(tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakePullRequestCreateOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestMergeOptions_CommitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []PullRequestMergeOption
+		message string
+		want    string
+	}{
+		{
+			name:    "no title",
+			message: "message",
+			want:    "message",
+		},
+		{
+			name:    "title and message",
+			opts:    []PullRequestMergeOption{&PullRequestMergeOptions{CommitTitle: StringVar("title")}},
+			message: "message",
+			want:    "title\n\nmessage",
+		},
+		{
+			name: "title only",
+			opts: []PullRequestMergeOption{&PullRequestMergeOptions{CommitTitle: StringVar("title")}},
+			want: "title",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := MakePullRequestMergeOptions(tt.opts...)
+			if got := o.CommitMessage(tt.message); got != tt.want {
+				t.Errorf("CommitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakePullRequestListOptions(t *testing.T) {
+	unknownState := PullRequestState("draft")
+	tests := []struct {
+		name    string
+		opts    []PullRequestListOption
+		want    PullRequestListOptions
+		wantErr error
+	}{
+		{
+			name: "default nil pointers",
+			want: PullRequestListOptions{},
+		},
+		{
+			name: "later options override earlier ones",
+			opts: []PullRequestListOption{
+				&PullRequestListOptions{State: PullRequestStateVar(PullRequestStateOpen), Author: StringVar("alice")},
+				&PullRequestListOptions{State: PullRequestStateVar(PullRequestStateMerged), BaseBranch: StringVar("main")},
+			},
+			want: PullRequestListOptions{
+				State:      PullRequestStateVar(PullRequestStateMerged),
+				Author:     StringVar("alice"),
+				BaseBranch: StringVar("main"),
+			},
+		},
+		{
+			name:    "unknown state",
+			opts:    []PullRequestListOption{&PullRequestListOptions{State: &unknownState}},
+			want:    PullRequestListOptions{State: &unknownState},
+			wantErr: validation.ErrFieldEnumInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakePullRequestListOptions(tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MakePullRequestListOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakePullRequestListOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OwnerRepositoriesClient operates on the repositories of an owner, regardless of whether the
+// owner is a user or an organization. It can be retrieved through OwnerResolver.Repositories().
+type OwnerRepositoriesClient interface {
+	// OwnerType returns whether the repositories are owned by a user or an organization.
+	OwnerType() OwnerType
+
+	// Get a specific repository of the owner. Repositories of organizations can be casted
+	// to OrgRepository.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, name string) (UserRepository, error)
+
+	// List all repositories of the owner.
+	//
+	// List returns all available repositories, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]UserRepository, error)
+
+	// Create a new repository for the owner.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryCreateOption) (UserRepository, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryReconcileOption) (resp UserRepository, actionTaken bool, err error)
+}
+
+// DefaultOwnerCacheTTL is the default duration for which OwnerResolver caches the type of an owner.
+const DefaultOwnerCacheTTL = 10 * time.Minute
+
+// OwnerResolver determines whether owners are users or organizations using Client.GetOwnerType,
+// and caches the results for OwnerResolverOptions.CacheTTL, DefaultOwnerCacheTTL by default.
+// It is safe for concurrent use.
+//
+// Only successful lookups are cached. If the type can't be determined, e.g. because the
+// provider returned an unknown owner type, or a self-hosted instance restricts the lookup,
+// the error is returned as is, and the caller needs to pick the repositories client itself.
+type OwnerResolver struct {
+	c    Client
+	opts OwnerResolverOptions
+
+	mu    sync.Mutex
+	cache map[string]ownerCacheEntry
+}
+
+type ownerCacheEntry struct {
+	ownerType OwnerType
+	expires   time.Time
+}
+
+// NewOwnerResolver returns a new OwnerResolver for the given client.
+func NewOwnerResolver(c Client, opts ...OwnerResolverOption) *OwnerResolver {
+	return &OwnerResolver{
+		c:     c,
+		opts:  MakeOwnerResolverOptions(opts...),
+		cache: map[string]ownerCacheEntry{},
+	}
+}
+
+// OwnerType returns whether the given owner is a user or an organization.
+func (r *OwnerResolver) OwnerType(ctx context.Context, owner string) (OwnerType, error) {
+	r.mu.Lock()
+	entry, ok := r.cache[owner]
+	if ok && !r.opts.Clock.Now().Before(entry.expires) {
+		// Expired, look it up again
+		delete(r.cache, owner)
+		ok = false
+	}
+	r.mu.Unlock()
+	if ok {
+		return entry.ownerType, nil
+	}
+
+	ownerType, err := r.c.GetOwnerType(ctx, owner)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the type of owner %q: %w", owner, err)
+	}
+	// Don't cache (and pass on) anything we don't know how to handle
+	if err := ValidateOwnerType(ownerType); err != nil {
+		return "", fmt.Errorf("owner %q has type %q: %w", owner, ownerType, ErrUnknownOwnerType)
+	}
+
+	if ttl := *r.opts.CacheTTL; ttl > 0 {
+		r.mu.Lock()
+		r.cache[owner] = ownerCacheEntry{ownerType: ownerType, expires: r.opts.Clock.Now().Add(ttl)}
+		r.mu.Unlock()
+	}
+	return ownerType, nil
+}
+
+// Repositories returns an OwnerRepositoriesClient operating on the repositories of the given
+// owner, backed by either the UserRepositoriesClient or the OrgRepositoriesClient of the client.
+// Sub-organizations can be given as "org/sub-org".
+func (r *OwnerResolver) Repositories(ctx context.Context, owner string) (OwnerRepositoriesClient, error) {
+	ownerType, err := r.OwnerType(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerType == OwnerTypeUser {
+		return &userOwnerRepositories{
+			c: r.c.UserRepositories(),
+			ref: UserRef{
+				Domain:    r.c.SupportedDomain(),
+				UserLogin: owner,
+			},
+		}, nil
+	}
+
+	orgs := strings.Split(owner, "/")
+	return &orgOwnerRepositories{
+		c: r.c.OrgRepositories(),
+		ref: OrganizationRef{
+			Domain:           r.c.SupportedDomain(),
+			Organization:     orgs[0],
+			SubOrganizations: orgs[1:],
+		},
+	}, nil
+}
+
+// userOwnerRepositories implements OwnerRepositoriesClient for users.
+type userOwnerRepositories struct {
+	c   UserRepositoriesClient
+	ref UserRef
+}
+
+func (u *userOwnerRepositories) OwnerType() OwnerType {
+	return OwnerTypeUser
+}
+
+func (u *userOwnerRepositories) repoRef(name string) UserRepositoryRef {
+	return UserRepositoryRef{UserRef: u.ref, RepositoryName: name}
+}
+
+func (u *userOwnerRepositories) Get(ctx context.Context, name string) (UserRepository, error) {
+	return u.c.Get(ctx, u.repoRef(name))
+}
+
+func (u *userOwnerRepositories) List(ctx context.Context) ([]UserRepository, error) {
+	return u.c.List(ctx, u.ref)
+}
+
+func (u *userOwnerRepositories) Create(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryCreateOption) (UserRepository, error) {
+	return u.c.Create(ctx, u.repoRef(name), req, opts...)
+}
+
+func (u *userOwnerRepositories) Reconcile(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryReconcileOption) (UserRepository, bool, error) {
+	return u.c.Reconcile(ctx, u.repoRef(name), req, opts...)
+}
+
+// orgOwnerRepositories implements OwnerRepositoriesClient for organizations.
+type orgOwnerRepositories struct {
+	c   OrgRepositoriesClient
+	ref OrganizationRef
+}
+
+func (o *orgOwnerRepositories) OwnerType() OwnerType {
+	return OwnerTypeOrganization
+}
+
+func (o *orgOwnerRepositories) repoRef(name string) OrgRepositoryRef {
+	return OrgRepositoryRef{OrganizationRef: o.ref, RepositoryName: name}
+}
+
+func (o *orgOwnerRepositories) Get(ctx context.Context, name string) (UserRepository, error) {
+	repo, err := o.c.Get(ctx, o.repoRef(name))
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (o *orgOwnerRepositories) List(ctx context.Context) ([]UserRepository, error) {
+	repos, err := o.c.List(ctx, o.ref)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]UserRepository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, repo)
+	}
+	return result, nil
+}
+
+func (o *orgOwnerRepositories) Create(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryCreateOption) (UserRepository, error) {
+	repo, err := o.c.Create(ctx, o.repoRef(name), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (o *orgOwnerRepositories) Reconcile(ctx context.Context, name string, req RepositoryInfo, opts ...RepositoryReconcileOption) (UserRepository, bool, error) {
+	repo, actionTaken, err := o.c.Reconcile(ctx, o.repoRef(name), req, opts...)
+	if err != nil {
+		return nil, actionTaken, err
+	}
+	return repo, actionTaken, nil
+}
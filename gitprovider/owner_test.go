@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeOwnerClient struct {
+	Client
+	types     map[string]OwnerType
+	calls     int
+	userRepos *fakeRefRecorder
+	orgRepos  *fakeRefRecorder
+}
+
+func (c *fakeOwnerClient) SupportedDomain() string { return "example.com" }
+
+func (c *fakeOwnerClient) GetOwnerType(_ context.Context, owner string) (OwnerType, error) {
+	c.calls++
+	t, ok := c.types[owner]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return t, nil
+}
+
+func (c *fakeOwnerClient) UserRepositories() UserRepositoriesClient {
+	return &fakeRefUserRepositoriesClient{rec: c.userRepos}
+}
+
+func (c *fakeOwnerClient) OrgRepositories() OrgRepositoriesClient {
+	return &fakeRefOrgRepositoriesClient{rec: c.orgRepos}
+}
+
+// fakeRefRecorder records the last repository ref passed to a repositories client.
+type fakeRefRecorder struct {
+	ref RepositoryRef
+}
+
+type fakeRefUserRepositoriesClient struct {
+	UserRepositoriesClient
+	rec *fakeRefRecorder
+}
+
+func (c *fakeRefUserRepositoriesClient) Get(_ context.Context, r UserRepositoryRef) (UserRepository, error) {
+	c.rec.ref = r
+	return nil, ErrNotFound
+}
+
+type fakeRefOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	rec *fakeRefRecorder
+}
+
+func (c *fakeRefOrgRepositoriesClient) Get(_ context.Context, r OrgRepositoryRef) (OrgRepository, error) {
+	c.rec.ref = r
+	return nil, ErrNotFound
+}
+
+func TestOwnerResolver_Repositories(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		expectedType  OwnerType
+		expectedRef   RepositoryRef
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name:         "user",
+			owner:        "alice",
+			expectedType: OwnerTypeUser,
+			expectedRef: UserRepositoryRef{
+				UserRef:        UserRef{Domain: "example.com", UserLogin: "alice"},
+				RepositoryName: "repo",
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:         "sub-organization",
+			owner:        "org/sub",
+			expectedType: OwnerTypeOrganization,
+			expectedRef: OrgRepositoryRef{
+				OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "org", SubOrganizations: []string{"sub"}},
+				RepositoryName:  "repo",
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:          "unknown type",
+			owner:         "bot",
+			expectedErr:   ErrUnknownOwnerType,
+			expectedCalls: 2,
+		},
+		{
+			name:          "not found",
+			owner:         "nobody",
+			expectedErr:   ErrNotFound,
+			expectedCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeOwnerClient{
+				types: map[string]OwnerType{
+					"alice":   OwnerTypeUser,
+					"org/sub": OwnerTypeOrganization,
+					"bot":     OwnerType("bot"),
+				},
+				userRepos: &fakeRefRecorder{},
+				orgRepos:  &fakeRefRecorder{},
+			}
+			r := NewOwnerResolver(c)
+			ctx := context.Background()
+
+			// Resolve twice, to make sure only successful lookups are cached
+			for i := 0; i < 2; i++ {
+				repos, err := r.Repositories(ctx, tt.owner)
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				if err != nil {
+					continue
+				}
+				if repos.OwnerType() != tt.expectedType {
+					t.Errorf("expected owner type %q, got %q", tt.expectedType, repos.OwnerType())
+				}
+				_, _ = repos.Get(ctx, "repo")
+			}
+			if c.calls != tt.expectedCalls {
+				t.Errorf("expected %d lookups, got %d", tt.expectedCalls, c.calls)
+			}
+
+			if tt.expectedRef == nil {
+				return
+			}
+			rec := c.userRepos
+			if tt.expectedType == OwnerTypeOrganization {
+				rec = c.orgRepos
+			}
+			if !reflect.DeepEqual(rec.ref, tt.expectedRef) {
+				t.Errorf("expected ref %v, got %v", tt.expectedRef, rec.ref)
+			}
+		})
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestOwnerResolver_CacheTTL(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []OwnerResolverOption
+		advance       time.Duration
+		expectedCalls int
+	}{
+		{
+			name:          "cached within the default TTL",
+			advance:       DefaultOwnerCacheTTL - time.Second,
+			expectedCalls: 1,
+		},
+		{
+			name:          "refetched after the default TTL",
+			advance:       DefaultOwnerCacheTTL,
+			expectedCalls: 2,
+		},
+		{
+			name:          "refetched after a custom TTL",
+			opts:          []OwnerResolverOption{&OwnerResolverOptions{CacheTTL: durationVar(time.Minute)}},
+			advance:       time.Minute,
+			expectedCalls: 2,
+		},
+		{
+			name:          "caching disabled",
+			opts:          []OwnerResolverOption{&OwnerResolverOptions{CacheTTL: durationVar(0)}},
+			expectedCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeOwnerClient{types: map[string]OwnerType{"alice": OwnerTypeUser}}
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			r := NewOwnerResolver(c, append(tt.opts, &OwnerResolverOptions{Clock: clock})...)
+			ctx := context.Background()
+
+			if _, err := r.OwnerType(ctx, "alice"); err != nil {
+				t.Fatal(err)
+			}
+			clock.now = clock.now.Add(tt.advance)
+			if _, err := r.OwnerType(ctx, "alice"); err != nil {
+				t.Fatal(err)
+			}
+			if c.calls != tt.expectedCalls {
+				t.Errorf("expected %d lookups, got %d", tt.expectedCalls, c.calls)
+			}
+		})
+	}
+}
+
+func durationVar(d time.Duration) *time.Duration {
+	return &d
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultMaxRedirects is the default maximum number of redirects followed for a single
+// request, see WithRedirectPolicy.
+const DefaultMaxRedirects = 10
+
+// RedirectPolicy controls how the client follows HTTP redirects, see WithRedirectPolicy.
+//
+// Requests using an idempotent method (i.e. PUT or DELETE) are always redirected using the
+// same method, and their body is sent again. Redirects of other requests, e.g. POST, that would
+// change the method to GET and drop the body fail with ErrRedirectMethodChanged, unless
+// AllowMethodChange is set.
+type RedirectPolicy struct {
+	// MaxRedirects is the maximum number of redirects followed for a single request. Following
+	// more redirects fails with ErrTooManyRedirects. If zero, no redirects are followed, and
+	// the redirect response is returned as-is.
+	MaxRedirects int
+
+	// AllowMethodChange allows following "301 Moved Permanently", "302 Found" and "303 See Other"
+	// redirects of non-idempotent requests with a GET request without body.
+	AllowMethodChange bool
+}
+
+// checkRedirect returns a function to be used as http.Client.CheckRedirect enforcing policy.
+func (policy RedirectPolicy) checkRedirect() func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if policy.MaxRedirects == 0 {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > policy.MaxRedirects {
+			return fmt.Errorf("stopped after %d redirects: %w", policy.MaxRedirects, ErrTooManyRedirects)
+		}
+
+		orig := via[0]
+		if req.Method != orig.Method {
+			if err := policy.restoreMethod(req, orig); err != nil {
+				return err
+			}
+		}
+
+		// A request that has already been sent to the same location can't succeed this time
+		for _, prev := range via {
+			if prev.Method == req.Method && prev.URL.String() == req.URL.String() {
+				return fmt.Errorf("%s %s redirected back to itself: %w", req.Method, req.URL, ErrRedirectLoop)
+			}
+		}
+		return nil
+	}
+}
+
+// restoreMethod restores the method, body and content headers of orig in the redirect request
+// req, if orig used an idempotent method. net/http changes the method of all requests but GET
+// and HEAD to GET for "301 Moved Permanently", "302 Found" and "303 See Other" redirects.
+func (policy RedirectPolicy) restoreMethod(req, orig *http.Request) error {
+	// "303 See Other" explicitly asks for a GET request
+	seeOther := req.Response != nil && req.Response.StatusCode == http.StatusSeeOther
+	if seeOther || !isIdempotentMethod(orig.Method) {
+		if policy.AllowMethodChange {
+			return nil
+		}
+		return fmt.Errorf("redirect of %s %s would change the method to %s: %w", orig.Method, orig.URL, req.Method, ErrRedirectMethodChanged)
+	}
+
+	if orig.GetBody != nil {
+		body, err := orig.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+		req.GetBody = orig.GetBody
+		req.ContentLength = orig.ContentLength
+	} else if orig.ContentLength != 0 {
+		// The body has already been consumed, and can't be sent again
+		return fmt.Errorf("redirect of %s %s can't replay the request body: %w", orig.Method, orig.URL, ErrRedirectMethodChanged)
+	}
+	req.Method = orig.Method
+	for _, header := range []string{"Content-Type", "Content-Encoding", "Content-Language"} {
+		if value := orig.Header.Get(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+	return nil
+}
+
+// isIdempotentMethod returns whether a request with the given method, which has a body,
+// can be sent again safely.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedirectPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/found", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusFound)
+	})
+	mux.HandleFunc("/see-other", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusSeeOther)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/twice", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/found", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop-back", http.StatusFound)
+	})
+	mux.HandleFunc("/loop-back", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/target", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, r.Method+" "+r.Header.Get("Content-Type")+" "+string(body))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		policy     *RedirectPolicy
+		method     string
+		path       string
+		want       string
+		wantStatus int
+		wantErr    error
+	}{
+		{name: "GET is redirected", method: http.MethodGet, path: "/found", want: "GET  "},
+		{name: "PUT is replayed", method: http.MethodPut, path: "/found", want: "PUT application/json {}"},
+		{name: "DELETE is replayed", method: http.MethodDelete, path: "/found", want: "DELETE application/json {}"},
+		{name: "POST is replayed for 307", method: http.MethodPost, path: "/temporary", want: "POST application/json {}"},
+		{name: "POST isn't downgraded", method: http.MethodPost, path: "/found", wantErr: ErrRedirectMethodChanged},
+		{name: "PUT isn't downgraded for 303", method: http.MethodPut, path: "/see-other", wantErr: ErrRedirectMethodChanged},
+		{
+			name:   "POST is downgraded if allowed",
+			policy: &RedirectPolicy{MaxRedirects: DefaultMaxRedirects, AllowMethodChange: true},
+			method: http.MethodPost,
+			path:   "/found",
+			want:   "GET  ",
+		},
+		{name: "loop", method: http.MethodGet, path: "/loop", wantErr: ErrRedirectLoop},
+		{
+			name:    "too many redirects",
+			policy:  &RedirectPolicy{MaxRedirects: 1},
+			method:  http.MethodGet,
+			path:    "/twice",
+			wantErr: ErrTooManyRedirects,
+		},
+		{
+			name:       "redirects disabled",
+			policy:     &RedirectPolicy{},
+			method:     http.MethodGet,
+			path:       "/found",
+			wantStatus: http.StatusFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &ClientOptions{}
+			opts.RedirectPolicy = tt.policy
+			client, err := opts.BuildHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body io.Reader
+			if tt.method != http.MethodGet {
+				body = strings.NewReader("{}")
+			}
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			resp, err := client.Do(req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			if tt.wantStatus == 0 {
+				tt.wantStatus = http.StatusOK
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.want == "" {
+				return
+			}
+			got, _ := io.ReadAll(resp.Body)
+			if string(got) != tt.want {
+				t.Errorf("response = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
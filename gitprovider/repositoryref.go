@@ -325,15 +325,23 @@ func ParseTypeHTTPS(url string) string {
 
 // ParseTypeGit returns the URL to clone a repository using the Git protocol.
 func ParseTypeGit(domain, identity, repository string) string {
-	return fmt.Sprintf("git@%s:%s/%s.git", domain, identity, repository)
+	return fmt.Sprintf("git@%s:%s/%s.git", sshHost(domain), identity, repository)
 }
 
 // ParseTypeSSH returns the URL to clone a repository using the SSH protocol.
 func ParseTypeSSH(domain, identity, repository string) string {
-	trimmedDomain := domain
-	trimmedDomain = strings.Replace(trimmedDomain, "https://", "", -1)
-	trimmedDomain = strings.Replace(trimmedDomain, "http://", "", -1)
-	return fmt.Sprintf("ssh://git@%s/%s/%s", trimmedDomain, identity, repository)
+	return fmt.Sprintf("ssh://git@%s/%s/%s", sshHost(domain), identity, repository)
+}
+
+// sshHost strips the scheme and any path from domain, as SSH clone URLs never include the
+// relative URL root an instance might be served under over HTTP(S).
+func sshHost(domain string) string {
+	host := strings.TrimPrefix(domain, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	return host
 }
 
 // ParseOrganizationURL parses an URL to an organization into a OrganizationRef object.
@@ -525,6 +525,24 @@ func TestGetCloneURL(t *testing.T) {
 			transport: TransportTypeSSH,
 			want:      "ssh://git@my-gitlab.com:6443/luxas/test-org/other/foo-bar",
 		},
+		{
+			name:      "org: https with subpath",
+			repoinfo:  newOrgRepoRef("https://my-gitlab.com/gitlab", "luxas", []string{"test-org"}, "foo-bar"),
+			transport: TransportTypeHTTPS,
+			want:      "https://my-gitlab.com/gitlab/luxas/test-org/foo-bar.git",
+		},
+		{
+			name:      "org: git with subpath",
+			repoinfo:  newOrgRepoRef("https://my-gitlab.com/gitlab", "luxas", []string{"test-org"}, "foo-bar"),
+			transport: TransportTypeGit,
+			want:      "git@my-gitlab.com:luxas/test-org/foo-bar.git",
+		},
+		{
+			name:      "org: ssh with subpath",
+			repoinfo:  newOrgRepoRef("my-gitlab.com:6443/gitlab", "luxas", []string{"test-org"}, "foo-bar"),
+			transport: TransportTypeSSH,
+			want:      "ssh://git@my-gitlab.com:6443/luxas/test-org/foo-bar",
+		},
 		{
 			name:      "user: git",
 			repoinfo:  newUserRepoRef("gitlab.com", "luxas", "foo-bar"),
@@ -16,6 +16,12 @@ limitations under the License.
 
 package gitprovider
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 // Organization represents an organization in a Git provider.
 // For now, the organization is read-only, i.e. there aren't set/update methods.
 type Organization interface {
@@ -30,6 +36,18 @@ type Organization interface {
 
 	// Teams gives access to the TeamsClient for this specific organization
 	Teams() TeamsClient
+
+	// Runners gives access to the RunnersClient for this specific organization
+	Runners() RunnersClient
+
+	// AppAuthorizations gives access to the AppAuthorizationsClient for this specific organization
+	AppAuthorizations() AppAuthorizationsClient
+
+	// ProjectBoards gives access to the ProjectBoardsClient for this specific organization
+	ProjectBoards() ProjectBoardsClient
+
+	// Webhooks gives access to the OrganizationWebhookClient for this specific organization
+	Webhooks() OrganizationWebhookClient
 }
 
 // Team represents a team in an organization in a Git provider.
@@ -45,6 +63,46 @@ type Team interface {
 	Get() TeamInfo
 }
 
+// Runner represents a self-hosted CI runner registered for an organization.
+// For now, the runner is read-only, i.e. there aren't set/update methods.
+type Runner interface {
+	// Runner implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// OrganizationBound returns organization reference details.
+	OrganizationBound
+
+	// Get returns high-level information about this runner.
+	Get() RunnerInfo
+}
+
+// AppAuthorization represents an application that has been authorized to access an organization.
+// For now, the authorization is read-only, i.e. there aren't set/update methods.
+type AppAuthorization interface {
+	// AppAuthorization implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// OrganizationBound returns organization reference details.
+	OrganizationBound
+
+	// Get returns high-level information about this authorization.
+	Get() AppAuthorizationInfo
+}
+
+// ProjectBoard represents a project board for planning work in an organization, i.e. a
+// Projects (v2) project on GitHub, or a group issue board on GitLab.
+// For now, the project board is read-only, i.e. there aren't set/update methods.
+type ProjectBoard interface {
+	// ProjectBoard implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// OrganizationBound returns organization reference details.
+	OrganizationBound
+
+	// Get returns high-level information about this project board.
+	Get() ProjectBoardInfo
+}
+
 // UserRepository describes a repository owned by an user.
 type UserRepository interface {
 	// UserRepository and OrgRepository implement the Object interface,
@@ -65,9 +123,80 @@ type UserRepository interface {
 	// the Git provider, run .Update() or .Reconcile().
 	Set(RepositoryInfo) error
 
+	// InitialCommitSHA returns the SHA of the initial commit on the default branch, if this
+	// repository was returned by Create (or Reconcile creating it) with the AutoInit option set.
+	// This allows creating branches and tags off the initial commit without an extra lookup.
+	// nil is returned for repositories that were fetched, or created without AutoInit. nil is
+	// also returned if the initial commit couldn't be looked up after creating the repository,
+	// in which case callers can look it up from the default branch themselves.
+	InitialCommitSHA() *string
+
+	// SetDefaultBranch makes the given, existing branch the default branch of the repository
+	// right away. Other pending changes made using Set aren't applied. The internal API object
+	// is updated accordingly.
+	//
+	// ErrNotFound is returned if the repository doesn't exist.
+	// ErrNoProviderSupport is returned if the provider can't change the default branch.
+	SetDefaultBranch(ctx context.Context, branch string) error
+
+	// Star stars the repository as the authenticated user. Starring an already starred
+	// repository is a no-op.
+	//
+	// ErrNotFound is returned if the repository doesn't exist.
+	// ErrNoProviderSupport is returned if the provider has no notion of starring repositories.
+	Star(ctx context.Context) error
+	// Unstar removes the star of the authenticated user from the repository. Unstarring a
+	// repository that isn't starred is a no-op.
+	//
+	// ErrNotFound is returned if the repository doesn't exist.
+	// ErrNoProviderSupport is returned if the provider has no notion of starring repositories.
+	Unstar(ctx context.Context) error
+	// IsStarred returns whether the authenticated user has starred the repository.
+	//
+	// ErrNoProviderSupport is returned if the provider has no notion of starring repositories.
+	IsStarred(ctx context.Context) (bool, error)
+
+	// GetSubscription returns which notifications the authenticated user receives for the repository.
+	//
+	// ErrNoProviderSupport is returned if the provider's setting can't be represented.
+	GetSubscription(ctx context.Context) (RepositorySubscription, error)
+	// SetSubscription sets which notifications the authenticated user receives for the repository.
+	//
+	// ErrNotFound is returned if the repository doesn't exist.
+	// ErrNoProviderSupport is returned if the provider can't set the given subscription.
+	SetSubscription(ctx context.Context, subscription RepositorySubscription) error
+
+	// IssueCounts returns the number of open and closed issues of the repository, without
+	// listing the issues. Pull requests aren't counted as issues.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't track issues.
+	IssueCounts(ctx context.Context) (IssueCounts, error)
+
 	// DeployKeys gives access to manipulating deploy keys to access this specific repository.
 	DeployKeys() DeployKeyClient
 
+	// DeployTokens gives access to manipulating deploy tokens to access this specific repository.
+	// Deploy tokens are only supported by GitLab, other providers return ErrNoProviderSupport.
+	DeployTokens() DeployTokenClient
+
+	// Labels gives access to the labels of issues and pull requests in this specific repository.
+	Labels() LabelClient
+
+	// Milestones gives access to the milestones of this specific repository.
+	Milestones() MilestoneClient
+
+	// Releases gives access to the releases of this specific repository.
+	Releases() ReleaseClient
+
+	// Webhooks gives access to the webhooks of this specific repository.
+	Webhooks() WebhookClient
+
+	// BranchProtections gives access to the protection rules of the branches of this specific repository.
+	BranchProtections() BranchProtectionClient
+
+	// TagProtections gives access to the rules protecting the tags of this specific repository.
+	TagProtections() TagProtectionClient
+
 	// Commits gives access to this specific repository commits
 	Commits() CommitClient
 
@@ -82,6 +211,9 @@ type UserRepository interface {
 
 	// Trees gives access to this specific repository trees.
 	Trees() TreeClient
+
+	// ActionsPermissions gives access to the policy of which actions are allowed to run in this specific repository.
+	ActionsPermissions() ActionsPermissionsClient
 }
 
 // OrgRepository describes a repository owned by an organization.
@@ -117,6 +249,192 @@ type DeployKey interface {
 	// Set sets high-level desired state for this deploy key. In order to apply these changes in
 	// the Git provider, run .Update() or .Reconcile().
 	Set(DeployKeyInfo) error
+
+	// LastUsedAt returns the time the deploy key was last used to access the repository, which
+	// helps finding unused keys. nil is returned if the key was never used, or if the provider
+	// doesn't expose it: only GitLab and Bitbucket Cloud do, GitHub and Stash always return nil.
+	LastUsedAt() *time.Time
+}
+
+// DeployToken represents a credential generated by the Git provider, scoped to a repository.
+type DeployToken interface {
+	// DeployToken implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The deploy token can be deleted, which revokes it.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this deploy token.
+	Get() DeployTokenInfo
+	// Token returns the secret token value. The value is only returned by the Git provider when
+	// creating the token, hence this is empty for deploy tokens that were fetched.
+	Token() string
+}
+
+// Label represents a label of issues and pull requests in a repository.
+type Label interface {
+	// Label implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The label can be updated, including its name.
+	Updatable
+	// The label can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// ID returns the stable identifier of the label, which doesn't change when it's renamed.
+	ID() string
+	// Get returns high-level information about this label.
+	Get() LabelInfo
+	// Set sets high-level desired state for this label. In order to apply these changes in
+	// the Git provider, run .Update().
+	Set(LabelInfo) error
+}
+
+// Webhook represents a webhook of a repository, which sends the payloads of events to a URL.
+type Webhook interface {
+	// Webhook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be updated, including its URL.
+	Updatable
+	// The webhook can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// ID returns the identifier of the webhook, which doesn't change when its URL is changed.
+	ID() string
+	// Get returns high-level information about this webhook.
+	Get() WebhookInfo
+	// Set sets high-level desired state for this webhook. In order to apply these changes in
+	// the Git provider, run .Update().
+	Set(WebhookInfo) error
+
+	// Deliveries lists the recent deliveries of this webhook, most recent first. The providers
+	// only keep the deliveries for a limited time.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't expose the deliveries.
+	//
+	// Deliveries returns all available deliveries, using multiple paginated requests if needed.
+	Deliveries(ctx context.Context) ([]WebhookDelivery, error)
+	// Redeliver sends the payload of the given delivery again.
+	//
+	// ErrNotFound is returned if the delivery does not exist.
+	// ErrNoProviderSupport is returned if the provider doesn't support redeliveries.
+	Redeliver(ctx context.Context, deliveryID string) error
+	// Ping makes the provider send a test payload to the webhook, and reports how the receiver
+	// responded, e.g. to verify the connectivity after setting up the webhook. A receiver that
+	// responds with an error, or doesn't respond at all, is reported in the result, not as error.
+	//
+	// ErrNoProviderSupport is returned if the provider can't send test payloads.
+	Ping(ctx context.Context) (WebhookPingResult, error)
+}
+
+// BranchProtection represents the protection rule of a branch in a repository.
+type BranchProtection interface {
+	// BranchProtection implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The protection rule can be updated, except for the branch it applies to.
+	Updatable
+	// The protection rule can be deleted, which unprotects the branch.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this protection rule.
+	Get() BranchProtectionInfo
+	// Set sets high-level desired state for this protection rule. In order to apply these
+	// changes in the Git provider, run .Update().
+	//
+	// ErrInvalidArgument is returned if the branch differs from the one of the rule.
+	Set(BranchProtectionInfo) error
+}
+
+// TagProtection represents a rule protecting the matching tags of a repository.
+type TagProtection interface {
+	// TagProtection implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The rule can be deleted, which unprotects the matching tags.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this rule.
+	Get() TagProtectionInfo
+}
+
+// SystemWebhook represents an instance-wide webhook, which sends the payloads of events in all
+// repositories of the Git provider to a URL. System webhooks can't be updated, hence they're
+// recreated when reconciling changes.
+type SystemWebhook interface {
+	// SystemWebhook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be deleted.
+	Deletable
+
+	// ID returns the identifier of the webhook.
+	ID() string
+	// Get returns high-level information about this webhook.
+	Get() WebhookInfo
+}
+
+// OrganizationWebhook represents a webhook of an organization, which sends the payloads of events
+// in all of the organization's repositories to a URL.
+type OrganizationWebhook interface {
+	// OrganizationWebhook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be updated, including its URL.
+	Updatable
+	// The webhook can be deleted.
+	Deletable
+	// OrganizationBound returns organization reference details.
+	OrganizationBound
+
+	// ID returns the identifier of the webhook, which doesn't change when its URL is changed.
+	ID() string
+	// Get returns high-level information about this webhook.
+	Get() WebhookInfo
+	// Set sets high-level desired state for this webhook. In order to apply these changes in
+	// the Git provider, run .Update().
+	Set(WebhookInfo) error
+}
+
+// Milestone represents a milestone of issues and pull requests in a repository.
+type Milestone interface {
+	// Milestone implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this milestone, including its progress.
+	Get() MilestoneInfo
+}
+
+// Release represents a release of a repository.
+type Release interface {
+	// Release implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this release, including its assets.
+	Get() ReleaseInfo
+
+	// UploadAsset uploads content as a new asset of the release, and returns the created
+	// asset including its download URL. contentType may be empty if it's unknown.
+	//
+	// ErrAlreadyExists is returned if the release already has an asset with the given name.
+	UploadAsset(ctx context.Context, name, contentType string, content io.Reader) (ReleaseAsset, error)
 }
 
 // TeamAccess describes a binding between a repository and a team.
@@ -150,14 +468,94 @@ type Commit interface {
 	Get() CommitInfo
 }
 
+// CommitComment represents a comment on a git commit.
+type CommitComment interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The commit comment can be reacted to.
+	Reactable
+
+	// Get returns high-level information about this commit comment.
+	Get() CommitCommentInfo
+}
+
 // PullRequest represents a pull request.
 type PullRequest interface {
 	// Object implements the Object interface,
 	// allowing access to the underlying object returned from the API.
 	Object
+	// The pull request can be reacted to.
+	Reactable
 
 	// Get returns high-level information about this pull request.
 	Get() PullRequestInfo
+
+	// Reviews gives access to the reviews of this pull request.
+	Reviews() ReviewClient
+
+	// Comments gives access to the general and inline comments of this pull request.
+	Comments() PullRequestCommentClient
+
+	// EnableAutoMerge queues this pull request to be merged with the given merge method
+	// as soon as all required checks have passed, without the caller having to poll them.
+	//
+	// ErrNoProviderSupport is returned if the provider can't merge pull requests automatically.
+	EnableAutoMerge(ctx context.Context, mergeMethod MergeMethod) error
+
+	// Files lists the files changed by this pull request.
+	//
+	// Files returns all changed files, using multiple paginated requests if needed.
+	Files(ctx context.Context) ([]PullRequestFile, error)
+
+	// Diff returns the changes of this pull request as unified diff, like "git diff".
+	Diff(ctx context.Context) (string, error)
+
+	// SetLabels replaces the labels of this pull request with the labels with the given names.
+	//
+	// ErrNoProviderSupport is returned if the provider has no labels on pull requests.
+	SetLabels(ctx context.Context, labels []string) error
+
+	// SetAssignees replaces the assignees of this pull request with the users with the given usernames.
+	//
+	// ErrNoProviderSupport is returned if pull requests can't be assigned in the provider.
+	SetAssignees(ctx context.Context, assignees []string) error
+
+	// SetMilestone adds this pull request to the milestone with the given number, or removes
+	// it from its milestone if number is 0.
+	//
+	// ErrNoProviderSupport is returned if the provider has no milestones.
+	SetMilestone(ctx context.Context, number int) error
+}
+
+// PullRequestComment represents a general or inline comment on a pull request.
+type PullRequestComment interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this comment.
+	Get() PullRequestCommentInfo
+}
+
+// Review represents a review of a pull request.
+type Review interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this review.
+	Get() ReviewInfo
+}
+
+// Reaction represents a reaction (e.g. an emoji) to an issue, pull request or comment.
+type Reaction interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this reaction.
+	Get() ReactionInfo
 }
 
 // Tree represents a git tree which is the hierarchical structure of your git data.
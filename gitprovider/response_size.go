@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseSize is the default maximum number of bytes read from the body of a single
+// HTTP response, see WithMaxResponseSize.
+const DefaultMaxResponseSize = 100 << 20 // 100 MiB
+
+// downloadKey is the context key marking downloads, see WithDownload.
+type downloadKey struct{}
+
+// WithDownload returns a copy of ctx marking the requests made with it as downloads, whose
+// responses are streamed to the caller instead of being buffered in memory. Downloads are
+// limited by WithMaxDownloadSize instead of WithMaxResponseSize. It's meant for provider
+// implementations, e.g. of FileClient.GetReader and ReleaseClient.DownloadAsset.
+func WithDownload(ctx context.Context) context.Context {
+	return context.WithValue(ctx, downloadKey{}, true)
+}
+
+// isDownload returns whether ctx is marked with WithDownload.
+func isDownload(ctx context.Context) bool {
+	download, _ := ctx.Value(downloadKey{}).(bool)
+	return download
+}
+
+// maxResponseSizeTransport returns a ChainableRoundTripperFunc limiting the number of bytes
+// read from each response body to maxBytes, and from the body of each download to
+// maxDownloadBytes. Downloads aren't limited if maxDownloadBytes is zero.
+func maxResponseSizeTransport(maxBytes, maxDownloadBytes int64) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &limitedResponseTransport{next: in, maxBytes: maxBytes, maxDownloadBytes: maxDownloadBytes}
+	}
+}
+
+// limitedResponseTransport limits the number of bytes read from each response body.
+type limitedResponseTransport struct {
+	next             http.RoundTripper
+	maxBytes         int64
+	maxDownloadBytes int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitedResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxBytes := t.maxBytes
+	if isDownload(req.Context()) {
+		maxBytes = t.maxDownloadBytes
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || maxBytes <= 0 {
+		return resp, err
+	}
+	// Fail early if the server announces a too large body
+	if resp.ContentLength > maxBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("response of %d bytes exceeds the limit of %d bytes: %w", resp.ContentLength, maxBytes, ErrResponseTooLarge)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: maxBytes, maxBytes: maxBytes}
+	return resp, nil
+}
+
+// limitedBody fails with ErrResponseTooLarge once more than maxBytes have been read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	maxBytes  int64
+}
+
+// Read implements io.Reader.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("response exceeds the limit of %d bytes: %w", b.maxBytes, ErrResponseTooLarge)
+	}
+	// Read one byte more than allowed, to tell a body of exactly maxBytes from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		// Only hand out the allowed bytes
+		return n + int(b.remaining), fmt.Errorf("response exceeds the limit of %d bytes: %w", b.maxBytes, ErrResponseTooLarge)
+	}
+	return n, err
+}
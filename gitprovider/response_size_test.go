@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func int64Var(i int64) *int64 {
+	return &i
+}
+
+func Test_maxResponseSizeTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		chunked       bool
+		maxBytes      int64
+		expectedErr   error
+		expectedBytes int
+	}{
+		{
+			name:          "within limit",
+			body:          "hello",
+			maxBytes:      5,
+			expectedBytes: 5,
+		},
+		{
+			name:        "content length exceeds limit",
+			body:        "hello world",
+			maxBytes:    5,
+			expectedErr: ErrResponseTooLarge,
+		},
+		{
+			name:          "chunked body exceeds limit",
+			body:          "hello world",
+			chunked:       true,
+			maxBytes:      5,
+			expectedErr:   ErrResponseTooLarge,
+			expectedBytes: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.chunked {
+					// Flushing before writing the body prevents setting Content-Length
+					w.(http.Flusher).Flush()
+				}
+				_, _ = io.Copy(w, strings.NewReader(tt.body))
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: maxResponseSizeTransport(tt.maxBytes, 0)(nil)}
+			var body []byte
+			resp, err := client.Get(srv.URL)
+			if err == nil {
+				body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if len(body) != tt.expectedBytes {
+				t.Errorf("read %d bytes, want %d", len(body), tt.expectedBytes)
+			}
+		})
+	}
+}
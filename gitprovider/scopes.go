@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TokenInfo describes the token the client is authenticated with, see Client.TokenInfo.
+type TokenInfo struct {
+	// Name of the token, if the provider reports one.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Scopes granted to the token, e.g. "admin:org" on GitHub or "api" on GitLab, as reported by
+	// the provider. nil if the provider doesn't report scopes for this kind of token, e.g. for
+	// GitHub fine-grained personal access tokens, and empty if the token has no scopes.
+	Scopes []string `json:"scopes"`
+
+	// ExpiresAt is when the token expires. nil if the token doesn't expire, or the provider
+	// doesn't report the expiry.
+	// +optional
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ScopeCheckResult is the result of Client.CheckScopes.
+type ScopeCheckResult struct {
+	// Granted are the scopes granted to the token, as reported by the provider.
+	Granted []string `json:"granted"`
+
+	// Missing are the required scopes that the token lacks, neither directly nor implied by
+	// a broader scope. Empty if the token has all required scopes.
+	Missing []string `json:"missing"`
+}
+
+// NewScopeCheckResult compares the granted scopes to the required ones. implied maps broader
+// scopes to the narrower ones they include, e.g. "repo" to "repo:status" on GitHub.
+func NewScopeCheckResult(granted, required []string, implied map[string][]string) *ScopeCheckResult {
+	has := map[string]bool{}
+	for _, scope := range granted {
+		has[scope] = true
+		for _, narrower := range implied[scope] {
+			has[narrower] = true
+		}
+	}
+
+	res := &ScopeCheckResult{Granted: granted, Missing: []string{}}
+	for _, scope := range required {
+		if !has[scope] {
+			res.Missing = append(res.Missing, scope)
+		}
+	}
+	sort.Strings(res.Missing)
+	return res
+}
+
+// Err returns an error wrapping ErrMissingScopes and listing the missing scopes, so it can be
+// shown to the user. nil is returned if no scopes are missing.
+func (r *ScopeCheckResult) Err() error {
+	if len(r.Missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrMissingScopes, strings.Join(r.Missing, ", "))
+}
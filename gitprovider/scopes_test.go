@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewScopeCheckResult(t *testing.T) {
+	implied := map[string][]string{"admin:org": {"write:org", "read:org"}}
+	tests := []struct {
+		name        string
+		granted     []string
+		required    []string
+		wantMissing []string
+	}{
+		{
+			name:        "all granted",
+			granted:     []string{"repo", "read:org"},
+			required:    []string{"repo", "read:org"},
+			wantMissing: []string{},
+		},
+		{
+			name:        "implied by a broader scope",
+			granted:     []string{"admin:org"},
+			required:    []string{"read:org", "write:org"},
+			wantMissing: []string{},
+		},
+		{
+			name:        "missing scopes are sorted",
+			granted:     []string{"read:org"},
+			required:    []string{"write:org", "repo", "read:org"},
+			wantMissing: []string{"repo", "write:org"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := NewScopeCheckResult(tt.granted, tt.required, implied)
+			if !reflect.DeepEqual(res.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %v, want %v", res.Missing, tt.wantMissing)
+			}
+			if err := res.Err(); (len(tt.wantMissing) > 0) != errors.Is(err, ErrMissingScopes) {
+				t.Errorf("Err() = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// NegotiateTokenFunc returns the initial SPNEGO token (RFC 4559) for the given Kerberos service
+// principal name, e.g. "HTTP/git.example.com". It's usually implemented using a Kerberos
+// library like gokrb5, by initializing a security context for spn using the credentials of a
+// keytab or credentials cache.
+type NegotiateTokenFunc func(ctx context.Context, spn string) ([]byte, error)
+
+// WithSPNEGO initializes a Client which authenticates requests using Kerberos through SPNEGO
+// ("Authorization: Negotiate"), as used by on-premise instances behind Kerberos, e.g. Bitbucket
+// Server or GitLab. A new token is requested from tokenFn for every request, using the service
+// principal name "HTTP/<host>" of the requested host. tokenFn must not be nil, and can't be
+// combined with other authentication options like WithOAuth2Token; pass an empty token to
+// NewClient.
+//
+// Requests fail with an *InvalidCredentialsError if tokenFn returns an error.
+func WithSPNEGO(tokenFn NegotiateTokenFunc) ClientOption {
+	// Don't allow an empty value
+	if tokenFn == nil {
+		return optionError(fmt.Errorf("tokenFn cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	// The tokens are requested for the service principal of the requested host
+	return &ClientOptions{authTransport: spnegoTransport(tokenFn), hostBoundAuth: true}
+}
+
+func spnegoTransport(tokenFn NegotiateTokenFunc) ChainableRoundTripperFunc {
+	// Requests with context credentials are sent as-is
+	return bypassForContextCredentials(func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &negotiateTransport{next: in, tokenFn: tokenFn}
+	})
+}
+
+// negotiateTransport sets the Authorization header of requests to a SPNEGO token.
+type negotiateTransport struct {
+	next    http.RoundTripper
+	tokenFn NegotiateTokenFunc
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *negotiateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	spn := "HTTP/" + req.URL.Hostname()
+	token, err := t.tokenFn(req.Context(), spn)
+	if err != nil {
+		// RoundTrippers must always close the body
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		msg := fmt.Sprintf("failed to get a SPNEGO token for %s: %v", spn, err)
+		return nil, &InvalidCredentialsError{HTTPError: HTTPError{ErrorMessage: msg, Message: msg}}
+	}
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	// The provider SDKs might have set their own credentials, which would confuse the server
+	req.Header.Del("PRIVATE-TOKEN")
+	req.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(token))
+	return t.next.RoundTrip(req)
+}
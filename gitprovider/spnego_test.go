@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithSPNEGO(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	var tokenErr error
+	opts, err := MakeClientOptions(WithSPNEGO(func(_ context.Context, spn string) ([]byte, error) {
+		return []byte("token for " + spn), tokenErr
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Negotiate " + base64.StdEncoding.EncodeToString([]byte("token for HTTP/127.0.0.1")); string(body) != want {
+		t.Errorf("got Authorization %q, want %q", body, want)
+	}
+
+	// The tokens are bound to the requested host, and hence sent by download clients too
+	downloadClient, err := opts.BuildDownloadHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = downloadClient.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Negotiate " + base64.StdEncoding.EncodeToString([]byte("token for HTTP/127.0.0.1")); string(body) != want {
+		t.Errorf("got Authorization %q from the download client, want %q", body, want)
+	}
+
+	tokenErr = errors.New("no Kerberos ticket")
+	_, err = client.Get(srv.URL)
+	var credsErr *InvalidCredentialsError
+	if !errors.As(err, &credsErr) {
+		t.Errorf("expected an *InvalidCredentialsError, got %v", err)
+	}
+}
+
+func TestWithSPNEGO_invalid(t *testing.T) {
+	tokenFn := func(context.Context, string) ([]byte, error) { return nil, nil }
+	tests := []struct {
+		name string
+		opts []ClientOption
+	}{
+		{
+			name: "nil",
+			opts: []ClientOption{WithSPNEGO(nil)},
+		},
+		{
+			name: "with token",
+			opts: []ClientOption{WithSPNEGO(tokenFn), WithOAuth2Token("token")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MakeClientOptions(tt.opts...); !errors.Is(err, ErrInvalidClientOptions) {
+				t.Errorf("expected ErrInvalidClientOptions, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WalkTeamHierarchy walks the hierarchy formed by the given teams depth-first, calling fn for
+// every team after its parent. ancestors holds the parent chain of the team, ordered from the
+// top-level team down to the direct parent, which is useful for e.g. resolving inherited
+// permissions. Teams whose parent isn't part of teams are treated as top-level teams. Siblings
+// are visited in the order of their names.
+//
+// The walk stops at the first error returned by fn, which is returned as-is.
+// ErrInvalidArgument is returned if the parent references of teams form a cycle.
+func WalkTeamHierarchy(teams []Team, fn func(team Team, ancestors []Team) error) error {
+	byName := make(map[string]Team, len(teams))
+	for _, team := range teams {
+		byName[team.Get().Name] = team
+	}
+
+	// Group the teams by parent, top-level teams are grouped under the empty string
+	children := make(map[string][]Team, len(teams))
+	for _, team := range teams {
+		parent := ""
+		if p := team.Get().Parent; p != nil {
+			if _, ok := byName[*p]; ok {
+				parent = *p
+			}
+		}
+		children[parent] = append(children[parent], team)
+	}
+	for _, siblings := range children {
+		sort.Slice(siblings, func(i, j int) bool {
+			return siblings[i].Get().Name < siblings[j].Get().Name
+		})
+	}
+
+	visited := make(map[string]bool, len(teams))
+	var walk func(team Team, ancestors []Team) error
+	walk = func(team Team, ancestors []Team) error {
+		name := team.Get().Name
+		visited[name] = true
+		if err := fn(team, ancestors); err != nil {
+			return err
+		}
+		// Use a full slice expression, so that siblings don't share the backing array
+		ancestors = append(ancestors[:len(ancestors):len(ancestors)], team)
+		for _, child := range children[name] {
+			if err := walk(child, ancestors); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, team := range children[""] {
+		if err := walk(team, nil); err != nil {
+			return err
+		}
+	}
+
+	// Teams that haven't been reached from any top-level team must be part of a cycle
+	for _, team := range teams {
+		if name := team.Get().Name; !visited[name] {
+			return fmt.Errorf("team %q is part of a parent cycle: %w", name, ErrInvalidArgument)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeTeam struct {
+	info TeamInfo
+}
+
+func (t *fakeTeam) Get() TeamInfo                 { return t.info }
+func (t *fakeTeam) APIObject() interface{}        { return nil }
+func (t *fakeTeam) Organization() OrganizationRef { return OrganizationRef{} }
+
+func newFakeTeam(name string, parent *string) Team {
+	return &fakeTeam{info: TeamInfo{Name: name, Parent: parent}}
+}
+
+func TestWalkTeamHierarchy(t *testing.T) {
+	tests := []struct {
+		name        string
+		teams       []Team
+		want        []string
+		expectedErr error
+	}{
+		{
+			name: "nested teams",
+			teams: []Team{
+				newFakeTeam("frontend", StringVar("engineering")),
+				newFakeTeam("web", StringVar("frontend")),
+				newFakeTeam("engineering", nil),
+				newFakeTeam("backend", StringVar("engineering")),
+				newFakeTeam("sales", nil),
+			},
+			want: []string{
+				"engineering",
+				"engineering/backend",
+				"engineering/frontend",
+				"engineering/frontend/web",
+				"sales",
+			},
+		},
+		{
+			name: "unknown parent is treated as top-level",
+			teams: []Team{
+				newFakeTeam("web", StringVar("frontend")),
+			},
+			want: []string{"web"},
+		},
+		{
+			name: "cycle",
+			teams: []Team{
+				newFakeTeam("sales", nil),
+				newFakeTeam("a", StringVar("b")),
+				newFakeTeam("b", StringVar("a")),
+			},
+			want:        []string{"sales"},
+			expectedErr: ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := WalkTeamHierarchy(tt.teams, func(team Team, ancestors []Team) error {
+				path := make([]string, 0, len(ancestors)+1)
+				for _, ancestor := range ancestors {
+					path = append(path, ancestor.Get().Name)
+				}
+				got = append(got, strings.Join(append(path, team.Get().Name), "/"))
+				return nil
+			})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("WalkTeamHierarchy() error = %v, want %v", err, tt.expectedErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WalkTeamHierarchy() visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
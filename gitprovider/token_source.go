@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenExpiryDelta is how long before its expiry a cached token is refreshed, so that it doesn't
+// expire while a request is in flight.
+const tokenExpiryDelta = 10 * time.Second
+
+// TokenSourceFunc returns the token to authenticate a request with, e.g. from an external secret
+// manager rotating it, or by exchanging the credentials of a GitHub App. The token is cached
+// until shortly before expiry, a zero expiry means the token isn't cached, i.e. the function is
+// called for every request. An empty token sends the request unauthenticated.
+type TokenSourceFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// WithTokenSource initializes a Client which authenticates requests with the token returned by
+// source, which allows rotating the credentials without creating a new Client. The token is sent
+// as a bearer token in the Authorization header; when using GitLab, pass an empty token to
+// NewClient. source must not be nil, and can't be combined with WithOAuth2Token.
+//
+// Requests fail with an *InvalidCredentialsError if source returns an error.
+func WithTokenSource(source TokenSourceFunc) ClientOption {
+	// Don't allow an empty value
+	if source == nil {
+		return optionError(fmt.Errorf("source cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	return &ClientOptions{authTransport: tokenSourceTransport(source, SystemClock)}
+}
+
+// WithOAuth2TokenSource initializes a Client which authenticates requests with the access tokens
+// of source, e.g. the TokenSource of an oauth2.Config, which refreshes expiring access tokens
+// using the refresh token. The tokens are reused until shortly before they expire, and sent as
+// bearer token in the Authorization header; when using GitLab, pass an empty token to NewClient.
+// source must not be nil, and can't be combined with WithOAuth2Token or WithTokenSource.
+//
+// Requests fail with an *InvalidCredentialsError if source returns an error.
+func WithOAuth2TokenSource(source oauth2.TokenSource) ClientOption {
+	// Don't allow an empty value
+	if source == nil {
+		return optionError(fmt.Errorf("source cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	// The token source only calls source once the current token expired
+	reuse := oauth2.ReuseTokenSource(nil, source)
+	return &ClientOptions{authTransport: tokenSourceTransport(func(context.Context) (string, time.Time, error) {
+		token, err := reuse.Token()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return token.AccessToken, token.Expiry, nil
+	}, SystemClock)}
+}
+
+func tokenSourceTransport(source TokenSourceFunc, clock Clock) ChainableRoundTripperFunc {
+	// The token is cached in the closure, shared between all transports built from it
+	cache := &tokenCache{source: source, clock: clock}
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &tokenTransport{next: in, cache: cache}
+	}
+}
+
+// tokenCache caches the token returned by a TokenSourceFunc until shortly before it expires.
+type tokenCache struct {
+	source TokenSourceFunc
+	clock  Clock
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// get returns the cached token if it's still valid, or a new token from the source otherwise.
+func (c *tokenCache) get(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.expiry.IsZero() && c.clock.Now().Add(tokenExpiryDelta).Before(c.expiry) {
+		return c.token, nil
+	}
+	token, expiry, err := c.source(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expiry = token, expiry
+	return token, nil
+}
+
+// tokenTransport sets the Authorization header of requests to the token of a tokenCache.
+type tokenTransport struct {
+	next  http.RoundTripper
+	cache *tokenCache
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests with context credentials are sent as-is
+	if _, ok := CredentialsFromContext(req.Context()); ok {
+		return t.next.RoundTrip(req)
+	}
+	token, err := t.cache.get(req.Context())
+	if err != nil {
+		msg := fmt.Sprintf("failed to get a token from the token source: %v", err)
+		return nil, &InvalidCredentialsError{HTTPError: HTTPError{ErrorMessage: msg, Message: msg}}
+	}
+	// An empty token means the request is sent unauthenticated
+	if token == "" {
+		return t.next.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the given request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func Test_tokenSourceTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	calls := 0
+	var sourceErr error
+	source := func(context.Context) (string, time.Time, error) {
+		if sourceErr != nil {
+			return "", time.Time{}, sourceErr
+		}
+		calls++
+		return fmt.Sprintf("token-%d", calls), clock.now.Add(time.Hour), nil
+	}
+	client := &http.Client{Transport: tokenSourceTransport(source, clock)(nil)}
+
+	get := func() (string, error) {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		var body string
+		_, _ = fmt.Fscan(resp.Body, &body, &body)
+		return body, nil
+	}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    string
+	}{
+		{name: "first request gets a token", want: "token-1"},
+		{name: "token is cached until expiry", advance: 30 * time.Minute, want: "token-1"},
+		{name: "token is rotated shortly before expiry", advance: 29*time.Minute + 55*time.Second, want: "token-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.now = clock.now.Add(tt.advance)
+			got, err := get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization = Bearer %q, want Bearer %q", got, tt.want)
+			}
+		})
+	}
+
+	// Errors of the source surface as invalid credentials once the token expired
+	clock.now = clock.now.Add(2 * time.Hour)
+	sourceErr = errors.New("secret manager unavailable")
+	_, err := get()
+	var credsErr *InvalidCredentialsError
+	if !errors.As(err, &credsErr) {
+		t.Errorf("expected an InvalidCredentialsError, got %v", err)
+	}
+}
+
+func TestWithTokenSource_invalid(t *testing.T) {
+	source := func(context.Context) (string, time.Time, error) { return "", time.Time{}, nil }
+
+	if _, err := MakeClientOptions(WithTokenSource(nil)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions for a nil source, got %v", err)
+	}
+	if _, err := MakeClientOptions(WithOAuth2Token("token"), WithTokenSource(source)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions when combined with WithOAuth2Token, got %v", err)
+	}
+}
+
+// fakeOAuth2Source returns the tokens in order, each valid for an hour from now.
+type fakeOAuth2Source struct {
+	calls int
+}
+
+func (s *fakeOAuth2Source) Token() (*oauth2.Token, error) {
+	s.calls++
+	return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", s.calls), Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestWithOAuth2TokenSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	source := &fakeOAuth2Source{}
+	opts, err := MakeClientOptions(WithOAuth2TokenSource(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.HasAuthentication() {
+		t.Error("HasAuthentication() = false, want true")
+	}
+	client, err := opts.BuildHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		_, _ = fmt.Fscan(resp.Body, &got, &got)
+		resp.Body.Close()
+		if got != "token-1" {
+			t.Errorf("Authorization = Bearer %q, want Bearer %q", got, "token-1")
+		}
+	}
+	// The valid token is reused
+	if source.calls != 1 {
+		t.Errorf("source called %d times, want 1", source.calls)
+	}
+
+	if _, err := MakeClientOptions(WithOAuth2TokenSource(nil)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions for a nil source, got %v", err)
+	}
+	if _, err := MakeClientOptions(WithOAuth2Token("token"), WithOAuth2TokenSource(source)); !errors.Is(err, ErrInvalidClientOptions) {
+		t.Errorf("expected ErrInvalidClientOptions when combined with WithOAuth2Token, got %v", err)
+	}
+}
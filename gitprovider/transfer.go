@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultTransferTimeout is how long CompleteRepositoryTransfer waits for a transferred
+// repository to be visible in its new organization, unless RepositoryTransferOptions.Timeout is set.
+const defaultTransferTimeout = 5 * time.Minute
+
+// RepositoryTransferResult is the outcome of OrgRepositoriesClient.Transfer.
+type RepositoryTransferResult struct {
+	// Repository is the repository in the new organization.
+	Repository OrgRepository
+
+	// TeamAccess holds the outcome of reconciling RepositoryTransferOptions.TeamAccess, one entry
+	// per team in the same order.
+	TeamAccess []TeamAccessReconcileResult
+}
+
+// TeamAccessReconcileResult is the outcome of reconciling the access of a single team.
+type TeamAccessReconcileResult struct {
+	// Name is the name of the team.
+	Name string
+
+	// ActionTaken is true if the access of the team was created or updated.
+	ActionTaken bool
+
+	// Err is set if reconciling the access of the team failed.
+	Err error
+}
+
+// CompleteRepositoryTransfer waits until the repository transferred to ref is visible in its new
+// organization, as providers may transfer repositories asynchronously, and then reconciles
+// opts.TeamAccess on it. It's meant to be used by implementations of OrgRepositoriesClient.Transfer.
+//
+// Failing to reconcile the access of a team doesn't stop the others, instead the error is
+// reported in the result of the team. The context error is returned if ctx is done, or
+// opts.Timeout expires, before the repository is visible.
+func CompleteRepositoryTransfer(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef, opts RepositoryTransferOptions) (*RepositoryTransferResult, error) {
+	timeout := defaultTransferTimeout
+	if opts.Timeout != nil {
+		timeout = *opts.Timeout
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if opts.PollInterval != nil {
+		waitCtx = WithOptions(waitCtx, CallOptions{PollInterval: opts.PollInterval})
+	}
+
+	var repo OrgRepository
+	err := PollUntilDone(waitCtx, func(ctx context.Context) (bool, error) {
+		var err error
+		repo, err = c.Get(ctx, ref)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository %s isn't visible after the transfer: %w", ref, err)
+	}
+
+	res := &RepositoryTransferResult{
+		Repository: repo,
+		TeamAccess: make([]TeamAccessReconcileResult, 0, len(opts.TeamAccess)),
+	}
+	for _, ta := range opts.TeamAccess {
+		_, actionTaken, err := repo.TeamAccess().Reconcile(ctx, ta)
+		res.TeamAccess = append(res.TeamAccess, TeamAccessReconcileResult{Name: ta.Name, ActionTaken: actionTaken, Err: err})
+	}
+	return res, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeTransferOrgRepositoriesClient returns ErrNotFound for the first notFound lookups, as
+// repositories may only show up in the new organization some time after the transfer.
+type fakeTransferOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	repo     *fakeTransferRepo
+	notFound int
+	gets     int
+}
+
+func (c *fakeTransferOrgRepositoriesClient) Get(_ context.Context, _ OrgRepositoryRef) (OrgRepository, error) {
+	c.gets++
+	if c.gets <= c.notFound {
+		return nil, ErrNotFound
+	}
+	return c.repo, nil
+}
+
+type fakeTransferRepo struct {
+	OrgRepository
+	teamAccess fakeTransferTeamAccessClient
+}
+
+func (r *fakeTransferRepo) TeamAccess() TeamAccessClient { return &r.teamAccess }
+
+type fakeTransferTeamAccessClient struct {
+	TeamAccessClient
+	reconciled []TeamAccessInfo
+}
+
+func (c *fakeTransferTeamAccessClient) Reconcile(_ context.Context, req TeamAccessInfo) (TeamAccess, bool, error) {
+	c.reconciled = append(c.reconciled, req)
+	if req.Name == "missing" {
+		return nil, false, ErrNotFound
+	}
+	return nil, true, nil
+}
+
+func TestCompleteRepositoryTransfer(t *testing.T) {
+	repo := &fakeTransferRepo{}
+	c := &fakeTransferOrgRepositoriesClient{repo: repo, notFound: 2}
+	teams := []TeamAccessInfo{
+		{Name: "admins", Permission: RepositoryPermissionVar(RepositoryPermissionAdmin)},
+		{Name: "missing"},
+	}
+	interval := time.Millisecond
+	opts, err := MakeRepositoryTransferOptions(&RepositoryTransferOptions{TeamAccess: teams, PollInterval: &interval})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := CompleteRepositoryTransfer(context.Background(), c, OrgRepositoryRef{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.gets != 3 {
+		t.Errorf("expected 3 lookups, got %d", c.gets)
+	}
+	if res.Repository != OrgRepository(repo) {
+		t.Errorf("expected the transferred repository")
+	}
+	if !reflect.DeepEqual(repo.teamAccess.reconciled, teams) {
+		t.Errorf("expected %v to be reconciled, got %v", teams, repo.teamAccess.reconciled)
+	}
+	if len(res.TeamAccess) != 2 || !res.TeamAccess[0].ActionTaken || res.TeamAccess[0].Err != nil || !errors.Is(res.TeamAccess[1].Err, ErrNotFound) {
+		t.Errorf("unexpected team access results: %+v", res.TeamAccess)
+	}
+}
+
+func TestCompleteRepositoryTransfer_timeout(t *testing.T) {
+	c := &fakeTransferOrgRepositoriesClient{notFound: 1 << 30}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	interval := time.Millisecond
+
+	_, err := CompleteRepositoryTransfer(ctx, c, OrgRepositoryRef{}, RepositoryTransferOptions{PollInterval: &interval})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestCompleteRepositoryTransfer_optionTimeout(t *testing.T) {
+	c := &fakeTransferOrgRepositoriesClient{notFound: 1 << 30}
+	interval, timeout := time.Millisecond, 10*time.Millisecond
+	opts, err := MakeRepositoryTransferOptions(&RepositoryTransferOptions{PollInterval: &interval, Timeout: &timeout})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a deadline of the context, the timeout of the options still bounds the polling
+	_, err = CompleteRepositoryTransfer(context.Background(), c, OrgRepositoryRef{}, opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMakeRepositoryTransferOptions_invalidTimeout(t *testing.T) {
+	timeout := time.Duration(0)
+	if _, err := MakeRepositoryTransferOptions(&RepositoryTransferOptions{Timeout: &timeout}); err == nil {
+		t.Error("expected an error for a non-positive timeout")
+	}
+}
+
+func TestMakeRepositoryTransferOptions_invalidTeamAccess(t *testing.T) {
+	_, err := MakeRepositoryTransferOptions(&RepositoryTransferOptions{TeamAccess: []TeamAccessInfo{{}}})
+	if err == nil {
+		t.Error("expected an error for a team access without name")
+	}
+}
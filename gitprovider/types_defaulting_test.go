@@ -17,14 +17,26 @@ limitations under the License.
 package gitprovider
 
 import (
+	"context"
 	"reflect"
 	"testing"
 )
 
+// branchDefaulter is an InfoDefaulter defaulting the default branch of repositories.
+type branchDefaulter string
+
+func (d branchDefaulter) DefaultInfo(_ context.Context, info DefaultedInfoRequest) error {
+	if repo, ok := info.(*RepositoryInfo); ok && repo.DefaultBranch == nil {
+		repo.DefaultBranch = StringVar(string(d))
+	}
+	return nil
+}
+
 func TestDefaulting(t *testing.T) {
 	tests := []struct {
 		name       string
 		structName string
+		defaulter  InfoDefaulter
 		object     DefaultedInfoRequest
 		expected   DefaultedInfoRequest
 	}{
@@ -89,6 +101,28 @@ func TestDefaulting(t *testing.T) {
 				DefaultBranch: StringVar("main"),
 			},
 		},
+		{
+			name:       "Repository: provider default",
+			structName: "Repository",
+			defaulter:  branchDefaulter("trunk"),
+			object:     &RepositoryInfo{},
+			expected: &RepositoryInfo{
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityPrivate),
+				DefaultBranch: StringVar("trunk"),
+			},
+		},
+		{
+			name:       "Repository: provider default doesn't override non-nil",
+			structName: "Repository",
+			defaulter:  branchDefaulter("trunk"),
+			object: &RepositoryInfo{
+				DefaultBranch: StringVar("main"),
+			},
+			expected: &RepositoryInfo{
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityPrivate),
+				DefaultBranch: StringVar("main"),
+			},
+		},
 		{
 			name:       "TeamAccess: empty",
 			structName: "TeamAccess",
@@ -120,6 +154,12 @@ func TestDefaulting(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// Same order as ValidateAndDefaultInfoWith, the generic defaults are the fallback
+			if tt.defaulter != nil {
+				if err := tt.defaulter.DefaultInfo(context.Background(), tt.object); err != nil {
+					t.Fatal(err)
+				}
+			}
 			tt.object.Default()
 
 			if !reflect.DeepEqual(tt.object, tt.expected) {
@@ -16,6 +16,8 @@ limitations under the License.
 
 package gitprovider
 
+import "time"
+
 // OrganizationInfo represents an (top-level- or sub-) organization.
 type OrganizationInfo struct {
 	// Name is the human-friendly name of this organization, e.g. "Flux" or "Kubernetes SIGs".
@@ -32,4 +34,89 @@ type TeamInfo struct {
 
 	// Members points to a set of user names (logins) of the members of this team.
 	Members []string `json:"members"`
+
+	// Parent is the name of the parent team, in the same format as Name. Parent is nil for
+	// top-level teams. Teams can be nested in GitHub, in GitLab the subgroups form the hierarchy.
+	// GitLab only populates Parent for teams returned by TeamsClient.List.
+	// +optional
+	Parent *string `json:"parent,omitempty"`
+}
+
+// RunnerInfo is a representation of a self-hosted CI runner registered for an organization.
+type RunnerInfo struct {
+	// ID is the provider-specific identifier of the runner.
+	ID int64 `json:"id"`
+
+	// Name is the human-friendly name of the runner.
+	Name string `json:"name"`
+
+	// Status describes whether the runner is currently online or offline.
+	Status RunnerStatus `json:"status"`
+
+	// Labels contains the labels (GitHub) or tags (GitLab) assigned to the runner. Listing the
+	// runners of GitLab only fetches them if CallOptions.RunnerLabels is set.
+	Labels []string `json:"labels"`
+}
+
+// AppAuthorizationInfo is a representation of an application authorized to access an organization.
+type AppAuthorizationInfo struct {
+	// ID is the provider-specific identifier of the authorization, e.g. the installation ID on GitHub.
+	ID int64 `json:"id"`
+
+	// AppName is the name of the authorized application, e.g. the app slug on GitHub.
+	AppName string `json:"appName"`
+
+	// Permissions maps the names of the resources the application may access to the level of
+	// access it has been granted, e.g. "contents" -> "read".
+	Permissions map[string]string `json:"permissions"`
+
+	// Installer is the login of the user that authorized the application.
+	// It is empty if the provider doesn't report it, as is the case for GitHub.
+	// +optional
+	Installer string `json:"installer,omitempty"`
+}
+
+// ProjectBoardInfo is a representation of a project board in an organization.
+type ProjectBoardInfo struct {
+	// ID is the provider-specific identifier of the project board, e.g. the GraphQL node ID on
+	// GitHub, or the numeric board ID on GitLab.
+	ID string `json:"id"`
+
+	// Title is the title of the project board.
+	Title string `json:"title"`
+
+	// URL is the address of the project board in the web UI.
+	URL string `json:"url"`
+}
+
+// NewRunnerRegistrationToken creates a RunnerRegistrationToken holding the given secret token,
+// valid until expiresAt (nil if it doesn't expire).
+func NewRunnerRegistrationToken(token string, expiresAt *time.Time) RunnerRegistrationToken {
+	return RunnerRegistrationToken{token: token, ExpiresAt: expiresAt}
+}
+
+// RunnerRegistrationToken is a short-lived secret used to register a new self-hosted runner.
+// The token itself is kept unexported and redacted when formatted, so that it never ends up
+// in logs by accident. Use Token() to access the secret value.
+type RunnerRegistrationToken struct {
+	// token is the secret registration token.
+	token string
+
+	// ExpiresAt is the point in time after which the token can't be used anymore, if any.
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// Token returns the secret registration token.
+func (t RunnerRegistrationToken) Token() string {
+	return t.token
+}
+
+// String implements fmt.Stringer, redacting the secret token.
+func (t RunnerRegistrationToken) String() string {
+	return "RunnerRegistrationToken{<redacted>}"
+}
+
+// GoString implements fmt.GoStringer, redacting the secret token.
+func (t RunnerRegistrationToken) GoString() string {
+	return t.String()
 }
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunnerRegistrationToken_redacted(t *testing.T) {
+	const secret = "AABBCCDDEEFF"
+	token := NewRunnerRegistrationToken(secret, nil)
+	if token.Token() != secret {
+		t.Fatalf("Token() = %q, want %q", token.Token(), secret)
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"%s", "%v", "%+v", "%#v"} {
+		if out := fmt.Sprintf(format, token); strings.Contains(out, secret) {
+			t.Errorf("fmt.Sprintf(%q) leaked the token: %s", format, out)
+		}
+	}
+	if strings.Contains(string(b), secret) {
+		t.Errorf("json.Marshal() leaked the token: %s", b)
+	}
+}
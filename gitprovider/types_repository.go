@@ -17,7 +17,12 @@ limitations under the License.
 package gitprovider
 
 import (
+	"fmt"
+	"net/mail"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/fluxcd/go-git-providers/validation"
@@ -34,6 +39,8 @@ const (
 	defaultBranchName = "main"
 	// by default, deploy keys are read-only.
 	defaultDeployKeyReadOnly = true
+	// by default, webhook payloads are sent as JSON.
+	defaultWebhookContentType = WebhookContentTypeJSON
 )
 
 // RepositoryInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
@@ -58,6 +65,20 @@ type RepositoryInfo struct {
 	// Default value at POST-time: RepositoryVisibilityPrivate.
 	// +optional
 	Visibility *RepositoryVisibility `json:"visibility"`
+
+	// AllowAutoMerge describes whether pull requests can be set to merge automatically once all
+	// requirements are met. It's only reconciled if set. ErrNoProviderSupport is returned if the
+	// provider doesn't have this setting.
+	// No default value at POST-time.
+	// +optional
+	AllowAutoMerge *bool `json:"allowAutoMerge"`
+
+	// DeleteBranchOnMerge describes whether head branches are deleted automatically after pull
+	// requests are merged. It's only reconciled if set. ErrNoProviderSupport is returned if the
+	// provider doesn't have this setting.
+	// No default value at POST-time.
+	// +optional
+	DeleteBranchOnMerge *bool `json:"deleteBranchOnMerge"`
 }
 
 // Default defaults the Repository, implementing the InfoRequest interface.
@@ -72,10 +93,16 @@ func (r *RepositoryInfo) Default() {
 
 // ValidateInfo validates the object at {Object}.Set() and POST-time.
 func (r RepositoryInfo) ValidateInfo() error {
+	return r.ValidateInfoFor("")
+}
+
+// ValidateInfoFor validates the object like ValidateInfo, additionally accepting the visibilities
+// registered for provider through RegisterRepositoryVisibility.
+func (r RepositoryInfo) ValidateInfoFor(provider ProviderID) error {
 	validator := validation.New("Repository")
 	// Validate the Visibility enum
 	if r.Visibility != nil {
-		validator.Append(ValidateRepositoryVisibility(*r.Visibility), *r.Visibility, "Visibility")
+		validator.Append(ValidateProviderRepositoryVisibility(provider, *r.Visibility), *r.Visibility, "Visibility")
 	}
 	return validator.Error()
 }
@@ -83,6 +110,16 @@ func (r RepositoryInfo) ValidateInfo() error {
 // Equals can be used to check if this *Info request (the desired state) matches the actual
 // passed in as the argument.
 func (r RepositoryInfo) Equals(actual InfoRequest) bool {
+	// The merge settings are only compared if they're set in the desired state
+	if a, ok := actual.(RepositoryInfo); ok {
+		if r.AllowAutoMerge == nil {
+			a.AllowAutoMerge = nil
+		}
+		if r.DeleteBranchOnMerge == nil {
+			a.DeleteBranchOnMerge = nil
+		}
+		actual = a
+	}
 	return reflect.DeepEqual(r, actual)
 }
 
@@ -112,6 +149,12 @@ func (ta *TeamAccessInfo) Default() {
 
 // ValidateInfo validates the object at {Object}.Set() and POST-time.
 func (ta TeamAccessInfo) ValidateInfo() error {
+	return ta.ValidateInfoFor("")
+}
+
+// ValidateInfoFor validates the object like ValidateInfo, additionally accepting the permissions
+// registered for provider through RegisterRepositoryPermission.
+func (ta TeamAccessInfo) ValidateInfoFor(provider ProviderID) error {
 	validator := validation.New("TeamAccess")
 	// Make sure we've set the name of the team
 	if len(ta.Name) == 0 {
@@ -119,7 +162,7 @@ func (ta TeamAccessInfo) ValidateInfo() error {
 	}
 	// Validate the Permission enum
 	if ta.Permission != nil {
-		validator.Append(ValidateRepositoryPermission(*ta.Permission), *ta.Permission, "Permission")
+		validator.Append(ValidateProviderRepositoryPermission(provider, *ta.Permission), *ta.Permission, "Permission")
 	}
 	return validator.Error()
 }
@@ -179,6 +222,573 @@ func (dk DeployKeyInfo) Equals(actual InfoRequest) bool {
 	return reflect.DeepEqual(dk, actual)
 }
 
+// DeployTokenInfo implements InfoRequest.
+var _ InfoRequest = DeployTokenInfo{}
+
+// DeployTokenInfo contains high-level information about a deploy token. Contrary to a deploy key,
+// the credential is generated by the Git provider, and only returned once when creating the token.
+type DeployTokenInfo struct {
+	// Name is the human-friendly interpretation of what the token is for.
+	// +required
+	Name string `json:"name"`
+
+	// Username is the username to authenticate with together with the token.
+	// Default value at POST-time: generated by the Git provider.
+	// +optional
+	Username *string `json:"username,omitempty"`
+
+	// Scopes specifies what the token grants access to. The order of the scopes is not significant.
+	// Available options: See the DeployTokenScope enum.
+	// +required
+	Scopes []DeployTokenScope `json:"scopes"`
+
+	// ExpiresAt specifies when the token expires. If nil, the token never expires.
+	// +optional
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (dt DeployTokenInfo) ValidateInfo() error {
+	validator := validation.New("DeployToken")
+	// Make sure we've set the name of the deploy token
+	if len(dt.Name) == 0 {
+		validator.Required("Name")
+	}
+	// At least one scope is needed for the token to be of any use
+	if len(dt.Scopes) == 0 {
+		validator.Required("Scopes")
+	}
+	seen := make(map[DeployTokenScope]struct{}, len(dt.Scopes))
+	for _, scope := range dt.Scopes {
+		validator.Append(ValidateDeployTokenScope(scope), scope, "Scopes")
+		// Don't allow the same scope twice
+		if _, ok := seen[scope]; ok {
+			validator.Invalid(scope, "Scopes")
+		}
+		seen[scope] = struct{}{}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The scopes are compared regardless of their order.
+func (dt DeployTokenInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(DeployTokenInfo)
+	if !ok {
+		return false
+	}
+	sortedScopes := func(scopes []DeployTokenScope) []DeployTokenScope {
+		sorted := append([]DeployTokenScope{}, scopes...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		return sorted
+	}
+	return dt.Name == other.Name &&
+		reflect.DeepEqual(dt.Username, other.Username) &&
+		reflect.DeepEqual(sortedScopes(dt.Scopes), sortedScopes(other.Scopes)) &&
+		timesEqual(dt.ExpiresAt, other.ExpiresAt)
+}
+
+// timesEqual returns whether a and b are both nil, or point to the same instant.
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// LabelInfo implements InfoRequest.
+var _ InfoRequest = LabelInfo{}
+
+// labelColorRegexp matches a color in hex notation, without a leading "#".
+var labelColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// LabelInfo contains high-level information about a label of issues and pull requests.
+type LabelInfo struct {
+	// Name is the name of the label.
+	// +required
+	Name string `json:"name"`
+
+	// Color is the color of the label in hex notation without a leading "#", e.g. "ff0000".
+	// +optional
+	Color *string `json:"color,omitempty"`
+
+	// Description describes what the label is for.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (l LabelInfo) ValidateInfo() error {
+	validator := validation.New("Label")
+	// Make sure we've set the name of the label
+	if len(l.Name) == 0 {
+		validator.Required("Name")
+	}
+	if l.Color != nil && !labelColorRegexp.MatchString(*l.Color) {
+		validator.Invalid(*l.Color, "Color")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. Colors are compared case-insensitively, and unset fields in the
+// desired state match any actual value.
+func (l LabelInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(LabelInfo)
+	if !ok {
+		return false
+	}
+	if l.Name != other.Name {
+		return false
+	}
+	if l.Color != nil && (other.Color == nil || !strings.EqualFold(*l.Color, *other.Color)) {
+		return false
+	}
+	if l.Description != nil && (other.Description == nil || *l.Description != *other.Description) {
+		return false
+	}
+	return true
+}
+
+// WebhookInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = WebhookInfo{}
+var _ DefaultedInfoRequest = &WebhookInfo{}
+
+// WebhookInfo contains high-level information about a webhook of a repository.
+type WebhookInfo struct {
+	// URL is the URL the payloads are sent to. It identifies the webhook within the repository.
+	// +required
+	URL string `json:"url"`
+
+	// Secret is used to sign the payloads (GitHub, Stash), or sent as token header (GitLab), for
+	// the receiver to verify them. The secret is never returned by the Git providers.
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// ContentType specifies how the payload is encoded.
+	// Available options: See the WebhookContentType enum.
+	// Default value at POST-time: WebhookContentTypeJSON.
+	// +optional
+	ContentType *WebhookContentType `json:"contentType,omitempty"`
+
+	// Events specifies which events trigger the webhook. The order of the events is not significant.
+	// Available options: See the WebhookEvent enum.
+	// +required
+	Events []WebhookEvent `json:"events"`
+}
+
+// Default defaults the Webhook fields.
+func (wh *WebhookInfo) Default() {
+	if wh.ContentType == nil {
+		wh.ContentType = WebhookContentTypeVar(defaultWebhookContentType)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (wh WebhookInfo) ValidateInfo() error {
+	validator := validation.New("Webhook")
+	// Make sure we've set the URL of the webhook
+	if len(wh.URL) == 0 {
+		validator.Required("URL")
+	}
+	if wh.ContentType != nil {
+		validator.Append(ValidateWebhookContentType(*wh.ContentType), *wh.ContentType, "ContentType")
+	}
+	// At least one event is needed for the webhook to be of any use
+	if len(wh.Events) == 0 {
+		validator.Required("Events")
+	}
+	seen := make(map[WebhookEvent]struct{}, len(wh.Events))
+	for _, event := range wh.Events {
+		validator.Append(ValidateWebhookEvent(event), event, "Events")
+		// Don't allow the same event twice
+		if _, ok := seen[event]; ok {
+			validator.Invalid(event, "Events")
+		}
+		seen[event] = struct{}{}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The events are compared regardless of their order. The secret
+// isn't compared, as the Git providers don't return it.
+func (wh WebhookInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(WebhookInfo)
+	if !ok {
+		return false
+	}
+	sortedEvents := func(events []WebhookEvent) []WebhookEvent {
+		sorted := append([]WebhookEvent{}, events...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		return sorted
+	}
+	return wh.URL == other.URL &&
+		reflect.DeepEqual(wh.ContentType, other.ContentType) &&
+		reflect.DeepEqual(sortedEvents(wh.Events), sortedEvents(other.Events))
+}
+
+// WebhookDelivery describes a single delivery of a webhook payload, e.g. for debugging failed
+// deliveries.
+type WebhookDelivery struct {
+	// ID identifies the delivery within the webhook, and is used to redeliver it.
+	ID string `json:"id"`
+
+	// Event is the provider-specific name of the event that triggered the delivery, e.g.
+	// "push" for GitHub or "push_hooks" for GitLab.
+	Event string `json:"event"`
+
+	// DeliveredAt is the time the payload was sent, or the zero time if the provider doesn't
+	// return it.
+	DeliveredAt time.Time `json:"deliveredAt"`
+
+	// StatusCode is the HTTP status code of the response, or 0 if no response was received.
+	StatusCode int `json:"statusCode"`
+
+	// Duration is how long the delivery took.
+	Duration time.Duration `json:"duration"`
+
+	// Redelivery specifies whether the delivery was triggered by a redelivery. Only set by GitHub.
+	Redelivery bool `json:"redelivery"`
+}
+
+// Successful returns whether the receiver responded with a 2xx status code.
+func (d WebhookDelivery) Successful() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// WebhookPingResult describes how the receiver of a webhook responded to a test payload.
+type WebhookPingResult struct {
+	// Successful specifies whether the receiver responded with a 2xx status code.
+	Successful bool `json:"successful"`
+
+	// StatusCode is the HTTP status code of the response, or 0 if no response was received, or
+	// the provider doesn't tell it (GitLab).
+	StatusCode int `json:"statusCode"`
+
+	// Message describes the outcome as reported by the provider, e.g. why the delivery failed.
+	Message string `json:"message,omitempty"`
+}
+
+// BranchProtectionInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = BranchProtectionInfo{}
+var _ DefaultedInfoRequest = &BranchProtectionInfo{}
+
+// BranchProtectionInfo contains high-level information about the protection rule of a branch.
+// Protected branches can't be deleted or force-pushed to.
+type BranchProtectionInfo struct {
+	// Branch is the name of the protected branch. It identifies the rule within the repository.
+	// GitLab also supports wildcard patterns like "release/*", protecting all matching branches
+	// with one rule, which is identified by the pattern. Other providers return
+	// ErrNoProviderSupport for patterns.
+	// +required
+	Branch string `json:"branch"`
+
+	// RequiredApprovals is the number of approving reviews a pull request needs before it can be
+	// merged into the branch. Only supported by GitHub, other providers return
+	// ErrNoProviderSupport if this is not zero.
+	// Default value at POST-time: 0.
+	// +optional
+	RequiredApprovals *int `json:"requiredApprovals,omitempty"`
+
+	// RequireCodeOwnerReviews specifies whether a pull request needs an approving review of the
+	// code owners of the changed files, as listed in the CODEOWNERS file. Only supported by
+	// GitHub and GitLab Premium, other providers return ErrNoProviderSupport if this is true.
+	// Default value at POST-time: false.
+	// +optional
+	RequireCodeOwnerReviews *bool `json:"requireCodeOwnerReviews,omitempty"`
+
+	// RequiredStatusChecks lists the status checks that must pass before a pull request can be
+	// merged into the branch. The order of the checks is not significant. GitLab only supports
+	// the StatusCheckPipeline check, which requires the pipelines of the project to succeed, for
+	// all its branches. Other providers return ErrNoProviderSupport for unsupported checks.
+	// +optional
+	RequiredStatusChecks []StatusCheck `json:"requiredStatusChecks,omitempty"`
+
+	// EnforceAdmins specifies whether the rule applies to administrators too. Only supported by
+	// GitHub, other providers return ErrNoProviderSupport if this is true.
+	// Default value at POST-time: false.
+	// +optional
+	EnforceAdmins *bool `json:"enforceAdmins,omitempty"`
+
+	// AllowedPushers lists the usernames of the users allowed to push to the branch. The order
+	// of the users is not significant. If empty, everyone with write access can push.
+	// +optional
+	AllowedPushers []string `json:"allowedPushers,omitempty"`
+}
+
+// Default defaults the BranchProtection fields.
+func (bp *BranchProtectionInfo) Default() {
+	if bp.RequiredApprovals == nil {
+		bp.RequiredApprovals = IntVar(0)
+	}
+	if bp.RequireCodeOwnerReviews == nil {
+		bp.RequireCodeOwnerReviews = BoolVar(false)
+	}
+	if bp.EnforceAdmins == nil {
+		bp.EnforceAdmins = BoolVar(false)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (bp BranchProtectionInfo) ValidateInfo() error {
+	validator := validation.New("BranchProtection")
+	// Make sure we've set the name of the branch
+	if len(bp.Branch) == 0 {
+		validator.Required("Branch")
+	}
+	if bp.RequiredApprovals != nil && *bp.RequiredApprovals < 0 {
+		validator.Invalid(*bp.RequiredApprovals, "RequiredApprovals")
+	}
+	contexts := make(map[string]struct{}, len(bp.RequiredStatusChecks))
+	for _, check := range bp.RequiredStatusChecks {
+		if len(check.Context) == 0 {
+			validator.Invalid(check.Context, "RequiredStatusChecks", "Context")
+		}
+		// A check can only be required once
+		if _, ok := contexts[check.Context]; ok {
+			validator.Invalid(check.Context, "RequiredStatusChecks", "Context")
+		}
+		contexts[check.Context] = struct{}{}
+		if check.AppID != nil && *check.AppID <= 0 {
+			validator.Invalid(*check.AppID, "RequiredStatusChecks", "AppID")
+		}
+	}
+	for _, pusher := range bp.AllowedPushers {
+		if len(pusher) == 0 {
+			validator.Invalid(pusher, "AllowedPushers")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The status checks and pushers are compared regardless of their
+// order, and the usernames case-insensitively. The app of a status check is only compared if
+// it's set in the desired state.
+func (bp BranchProtectionInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(BranchProtectionInfo)
+	if !ok {
+		return false
+	}
+	sorted := func(values []string, fold bool) []string {
+		out := make([]string, 0, len(values))
+		for _, v := range values {
+			if fold {
+				v = strings.ToLower(v)
+			}
+			out = append(out, v)
+		}
+		sort.Strings(out)
+		return out
+	}
+	return bp.Branch == other.Branch &&
+		reflect.DeepEqual(bp.RequiredApprovals, other.RequiredApprovals) &&
+		reflect.DeepEqual(bp.RequireCodeOwnerReviews, other.RequireCodeOwnerReviews) &&
+		reflect.DeepEqual(bp.EnforceAdmins, other.EnforceAdmins) &&
+		statusChecksEqual(bp.RequiredStatusChecks, other.RequiredStatusChecks) &&
+		reflect.DeepEqual(sorted(bp.AllowedPushers, true), sorted(other.AllowedPushers, true))
+}
+
+// IsBranchPattern returns whether the branch of a protection rule is a wildcard pattern like
+// "release/*", rather than the name of a single branch.
+func IsBranchPattern(branch string) bool {
+	return strings.Contains(branch, "*")
+}
+
+// statusChecksEqual returns whether the actual checks are the desired ones, in any order. The
+// app of a check is only compared if it's set in the desired check.
+func statusChecksEqual(desired, actual []StatusCheck) bool {
+	if len(desired) != len(actual) {
+		return false
+	}
+	apps := make(map[string]*int64, len(actual))
+	for _, check := range actual {
+		apps[check.Context] = check.AppID
+	}
+	for _, check := range desired {
+		app, ok := apps[check.Context]
+		if !ok {
+			return false
+		}
+		if check.AppID != nil && !reflect.DeepEqual(check.AppID, app) {
+			return false
+		}
+	}
+	return true
+}
+
+// StatusCheckPipeline is the context of the status check standing for the CI pipeline of the
+// repository as a whole. Providers like GitLab, which can't require individual status checks,
+// only support requiring this one.
+const StatusCheckPipeline = "pipeline"
+
+// StatusCheck is a status check, which must pass before a pull request can be merged into a
+// protected branch.
+type StatusCheck struct {
+	// Context is the name of the check, as reported by the CI system, e.g. "ci/build".
+	// +required
+	Context string `json:"context"`
+
+	// AppID is the ID of the app which must report the check. If nil, the check may be
+	// reported by any source. Only supported by GitHub.
+	// +optional
+	AppID *int64 `json:"appID,omitempty"`
+}
+
+// CodeOwnersError is a syntax error in the CODEOWNERS file of a repository.
+type CodeOwnersError struct {
+	// Path is the path of the CODEOWNERS file in the repository, e.g. ".github/CODEOWNERS".
+	Path string `json:"path"`
+
+	// Line is the line of the error, starting at 1.
+	Line int `json:"line"`
+
+	// Column is the column of the error, starting at 1.
+	Column int `json:"column"`
+
+	// Kind is the kind of the error, e.g. "Unknown owner".
+	Kind string `json:"kind"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+}
+
+// StatusChecks returns the status checks with the given contexts, which may be reported by
+// any source.
+func StatusChecks(contexts ...string) []StatusCheck {
+	checks := make([]StatusCheck, 0, len(contexts))
+	for _, context := range contexts {
+		checks = append(checks, StatusCheck{Context: context})
+	}
+	return checks
+}
+
+// TagProtectionInfo implements InfoRequest.
+var _ InfoRequest = TagProtectionInfo{}
+
+// TagProtectionInfo contains high-level information about a tag protection rule. Only the
+// maintainers and administrators of the repository can create, update or delete the tags
+// matching a rule.
+type TagProtectionInfo struct {
+	// Pattern matches the names of the protected tags, where "*" matches any characters, e.g.
+	// "v*". It identifies the rule within the repository.
+	// +required
+	Pattern string `json:"pattern"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (tp TagProtectionInfo) ValidateInfo() error {
+	validator := validation.New("TagProtection")
+	// Make sure we've set the pattern of the rule
+	if len(tp.Pattern) == 0 {
+		validator.Required("Pattern")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (tp TagProtectionInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(TagProtectionInfo)
+	if !ok {
+		return false
+	}
+	return tp.Pattern == other.Pattern
+}
+
+// ActionsPermissionsInfo implements InfoRequest.
+var _ InfoRequest = ActionsPermissionsInfo{}
+
+// ActionsPermissionsInfo describes which actions are allowed to run in a repository.
+type ActionsPermissionsInfo struct {
+	// AllowedActions describes the policy for which actions are allowed to run.
+	// Available options: See the AllowedActions enum.
+	// +required
+	AllowedActions AllowedActions `json:"allowedActions"`
+
+	// Patterns lists the allowed actions (e.g. "actions/checkout@*" or "my-org/*"), in
+	// case AllowedActions is AllowedActionsSelected. The order of the patterns is not significant.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (ap ActionsPermissionsInfo) ValidateInfo() error {
+	validator := validation.New("ActionsPermissions")
+	// Validate the AllowedActions enum
+	if len(ap.AllowedActions) == 0 {
+		validator.Required("AllowedActions")
+	} else {
+		validator.Append(ValidateAllowedActions(ap.AllowedActions), ap.AllowedActions, "AllowedActions")
+	}
+	// Patterns only make sense for the "selected" policy
+	if len(ap.Patterns) != 0 && ap.AllowedActions != AllowedActionsSelected {
+		validator.Invalid(ap.Patterns, "Patterns")
+	}
+	for _, pattern := range ap.Patterns {
+		if len(strings.TrimSpace(pattern)) == 0 {
+			validator.Invalid(pattern, "Patterns")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The patterns are compared regardless of their order.
+func (ap ActionsPermissionsInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(ActionsPermissionsInfo)
+	if !ok {
+		return false
+	}
+	return ap.AllowedActions == other.AllowedActions &&
+		reflect.DeepEqual(sortedPatterns(ap.Patterns), sortedPatterns(other.Patterns))
+}
+
+// sortedPatterns returns a sorted copy of patterns, treating nil and empty lists the same.
+func sortedPatterns(patterns []string) []string {
+	sorted := append([]string{}, patterns...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// CommitAuthor identifies the author of a commit that is being created.
+type CommitAuthor struct {
+	// Name is the name of the author.
+	// +required
+	Name string `json:"name"`
+
+	// Email is the e-mail address of the author, e.g. "bot@example.com".
+	// +required
+	Email string `json:"email"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (a CommitAuthor) ValidateInfo() error {
+	validator := validation.New("CommitAuthor")
+	if len(a.Name) == 0 {
+		validator.Required("Name")
+	}
+	if len(a.Email) == 0 {
+		validator.Required("Email")
+	} else if addr, err := mail.ParseAddress(a.Email); err != nil || addr.Address != a.Email {
+		// Only plain addresses are allowed, e.g. not "Bot <bot@example.com>"
+		validator.Invalid(a.Email, "Email")
+	}
+	return validator.Error()
+}
+
+// BranchInfo contains high-level information about a branch.
+type BranchInfo struct {
+	// Name is the name of the branch, e.g. "main".
+	// +required
+	Name string `json:"name"`
+
+	// Sha is the git sha of the commit the branch points to.
+	// +required
+	Sha string `json:"sha"`
+}
+
 // CommitInfo contains high-level information about a deploy key.
 type CommitInfo struct {
 	// Sha is the git sha for this commit.
@@ -192,6 +802,10 @@ type CommitInfo struct {
 	// Author is the author of the commit
 	Author string `json:"author"`
 
+	// AuthorEmail is the e-mail address of the author of the commit, if reported by the provider.
+	// +optional
+	AuthorEmail string `json:"authorEmail,omitempty"`
+
 	// Message is the commit message
 	Message string `json:"message"`
 
@@ -200,6 +814,276 @@ type CommitInfo struct {
 
 	// URL is the link for the commit
 	URL string `json:"url"`
+
+	// Verification describes whether the commit signature was verified by the provider.
+	// Verification is nil if the provider didn't return any verification information.
+	// +optional
+	Verification *CommitVerification `json:"verification,omitempty"`
+}
+
+// CommitVerification contains information about the verification of a commit signature.
+type CommitVerification struct {
+	// Verified is true if the provider could verify the signature of the commit.
+	Verified bool `json:"verified"`
+
+	// Reason is the provider-specific verification status, e.g. "valid" or "unsigned".
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Signer identifies whoever signed the commit, typically by e-mail address.
+	// Signer is empty if the provider couldn't tell who signed the commit.
+	// +optional
+	Signer string `json:"signer,omitempty"`
+}
+
+// MilestoneInfo contains high-level information about a milestone, including its progress.
+type MilestoneInfo struct {
+	// Number is the number of the milestone, unique within the repository.
+	// +required
+	Number int `json:"number"`
+
+	// Title is the title of the milestone.
+	// +required
+	Title string `json:"title"`
+
+	// Description describes the goal of the milestone.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// State is the state of the milestone.
+	// +required
+	State MilestoneState `json:"state"`
+
+	// DueDate is the date the milestone is due, if any.
+	// +optional
+	DueDate *time.Time `json:"dueDate,omitempty"`
+
+	// OpenIssues is the number of open issues in the milestone.
+	OpenIssues int `json:"openIssues"`
+
+	// ClosedIssues is the number of closed issues in the milestone.
+	ClosedIssues int `json:"closedIssues"`
+
+	// Progress is the percentage (0-100) of closed issues in the milestone, as computed
+	// by MilestoneProgress. It is 0 for milestones without issues.
+	Progress float64 `json:"progress"`
+}
+
+// LicenseTemplateInfo describes a license template offered by the provider.
+type LicenseTemplateInfo struct {
+	// Key identifies the license template, e.g. "apache-2.0".
+	Key LicenseTemplate `json:"key"`
+
+	// Name is the human-friendly name of the license, e.g. "Apache License 2.0".
+	Name string `json:"name"`
+}
+
+// IssueCounts holds the number of issues of a repository per state.
+type IssueCounts struct {
+	// Open is the number of open issues.
+	Open int `json:"open"`
+
+	// Closed is the number of closed issues.
+	Closed int `json:"closed"`
+}
+
+// ReleaseInfo contains high-level information about a release.
+type ReleaseInfo struct {
+	// TagName is the name of the tag the release is created from.
+	// +required
+	TagName string `json:"tagName"`
+
+	// Name is the title of the release.
+	Name string `json:"name"`
+
+	// Description describes the release, e.g. the release notes.
+	Description string `json:"description"`
+
+	// CreatedAt is the time the release was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Assets are the files attached to the release, e.g. binaries.
+	Assets []ReleaseAsset `json:"assets"`
+}
+
+// ValidateNewAsset validates that an asset named name can be added to the release, i.e. that
+// the name is set and isn't used by another asset of the release yet.
+func (r ReleaseInfo) ValidateNewAsset(name string) error {
+	validator := validation.New("ReleaseAsset")
+	if len(name) == 0 {
+		validator.Required("Name")
+	}
+	if err := validator.Error(); err != nil {
+		return err
+	}
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return fmt.Errorf("release %q already has an asset named %q: %w", r.TagName, name, ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// ReleaseAsset describes a file attached to a release.
+type ReleaseAsset struct {
+	// ID is the provider-specific identifier of the asset.
+	ID int64 `json:"id"`
+
+	// Name is the name of the asset.
+	Name string `json:"name"`
+
+	// Size is the size of the asset in bytes, or 0 if the provider doesn't know it.
+	Size int64 `json:"size"`
+
+	// DownloadURL is the URL at which the asset can be downloaded in a browser.
+	DownloadURL string `json:"downloadURL"`
+}
+
+// MilestoneProgress returns the percentage (0-100) of closed issues among all issues of
+// a milestone, or 0 if the milestone has no issues.
+func MilestoneProgress(openIssues, closedIssues int) float64 {
+	total := openIssues + closedIssues
+	if total == 0 {
+		return 0
+	}
+	return float64(closedIssues) * 100 / float64(total)
+}
+
+// CommitCommentInfo contains high-level information about a comment on a commit.
+type CommitCommentInfo struct {
+	// ID is the provider-specific identifier of the comment.
+	ID int64 `json:"id"`
+
+	// Author is the login of the user that wrote the comment.
+	Author string `json:"author"`
+
+	// Body is the content of the comment.
+	Body string `json:"body"`
+
+	// Path is the path of the file the comment was made on, if it is an inline comment.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Line is the line in the file the comment was made on, if it is an inline comment.
+	// +optional
+	Line int `json:"line,omitempty"`
+
+	// CreatedAt is the time the comment was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PullRequestFile is a file changed by a pull request.
+type PullRequestFile struct {
+	// Path is the path of the file in the source branch, or in the base branch if it has been removed.
+	Path string `json:"path"`
+
+	// PreviousPath is the path the file has been renamed or copied from.
+	// +optional
+	PreviousPath string `json:"previousPath,omitempty"`
+
+	// ChangeType specifies how the file has been changed.
+	ChangeType FileChangeType `json:"changeType"`
+
+	// Additions is the number of added lines, if reported by the provider.
+	Additions int `json:"additions"`
+
+	// Deletions is the number of deleted lines, if reported by the provider.
+	Deletions int `json:"deletions"`
+
+	// Patch is the unified diff of the changes to the file, without the file header. It is
+	// empty for binary files, and if the provider doesn't report it.
+	// +optional
+	Patch string `json:"patch,omitempty"`
+}
+
+// PullRequestCommentInfo contains high-level information about a comment on a pull request.
+type PullRequestCommentInfo struct {
+	// ID is the provider-specific identifier of the comment.
+	ID int64 `json:"id"`
+
+	// Author is the login of the user that wrote the comment.
+	Author string `json:"author"`
+
+	// Body is the content of the comment.
+	// +required
+	Body string `json:"body"`
+
+	// Path is the path of the file the comment was made on, if it is an inline comment.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Line is the line in the new version of the file the comment was made on, if it is an
+	// inline comment.
+	// +optional
+	Line int `json:"line,omitempty"`
+
+	// InReplyTo is the ID of a comment of the thread this comment replies to, if any. Replies
+	// are anchored to the same file and line as the thread, hence Path and Line must not be
+	// set for them.
+	// +optional
+	InReplyTo int64 `json:"inReplyTo,omitempty"`
+
+	// Resolved specifies whether the thread of the comment has been resolved.
+	Resolved bool `json:"resolved"`
+
+	// CreatedAt is the time the comment was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time, before the comment is created.
+func (c PullRequestCommentInfo) ValidateInfo() error {
+	validator := validation.New("PullRequestComment")
+	if len(c.Body) == 0 {
+		validator.Required("Body")
+	}
+	// Inline comments need both the file and the line, replies inherit them from their thread
+	if c.Path == "" && c.Line != 0 {
+		validator.Required("Path")
+	}
+	if c.Path != "" && c.Line == 0 {
+		validator.Required("Line")
+	}
+	if c.Line < 0 {
+		validator.Invalid(c.Line, "Line")
+	}
+	if c.InReplyTo != 0 && c.Path != "" {
+		validator.Invalid(c.Path, "Path")
+	}
+	return validator.Error()
+}
+
+// ReactionInfo contains high-level information about a reaction to an issue, pull request or comment.
+type ReactionInfo struct {
+	// ID is the provider-specific identifier of the reaction.
+	ID int64 `json:"id"`
+
+	// Content is the content of the reaction. Reactions the ReactionContent enum doesn't know
+	// about (e.g. arbitrary GitLab emojis) are passed through with their provider-specific name.
+	Content ReactionContent `json:"content"`
+
+	// User is the login of the user that reacted.
+	User string `json:"user"`
+}
+
+// ReviewInfo contains high-level information about a review of a pull request.
+type ReviewInfo struct {
+	// ID is the provider-specific identifier of the review. Providers without review objects
+	// (e.g. GitLab and Bitbucket Server) identify a review by the user ID of the reviewer.
+	ID int64 `json:"id"`
+
+	// Reviewer is the login of the user that reviewed the pull request.
+	Reviewer string `json:"reviewer"`
+
+	// State is the state of the review.
+	State ReviewState `json:"state"`
+
+	// Body is the comment left with the review, if any.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// SubmittedAt is the time the review has been submitted, if known.
+	// +optional
+	SubmittedAt *time.Time `json:"submittedAt,omitempty"`
 }
 
 // CommitFile contains high-level information about a file added to a commit.
@@ -233,6 +1117,24 @@ type PullRequestInfo struct {
 
 	// SourceBranch is the branch from which the pull request has been created.
 	SourceBranch string `json:"source_branch"`
+
+	// BaseBranch is the branch the pull request is merged into.
+	BaseBranch string `json:"base_branch"`
+
+	// State is the state of the pull request, i.e. whether it's open, closed or merged.
+	State PullRequestState `json:"state"`
+
+	// Draft specifies whether the pull request is a draft (work in progress).
+	Draft bool `json:"draft"`
+
+	// Labels are the names of the labels of the pull request.
+	Labels []string `json:"labels,omitempty"`
+
+	// Assignees are the usernames of the users the pull request is assigned to.
+	Assignees []string `json:"assignees,omitempty"`
+
+	// Milestone is the number of the milestone the pull request belongs to, if any.
+	Milestone *int `json:"milestone,omitempty"`
 }
 
 // TreeEntry contains info about each tree object's structure in TreeInfo whether it is a file or tree
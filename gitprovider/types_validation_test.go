@@ -79,6 +79,295 @@ func TestDeployKey_Validate(t *testing.T) {
 	}
 }
 
+func TestDeployToken_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        DeployTokenInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid create",
+			token: DeployTokenInfo{
+				Name:   "foo-deploytoken",
+				Scopes: []DeployTokenScope{DeployTokenScopeReadRepository, DeployTokenScopeReadRegistry},
+			},
+		},
+		{
+			name: "invalid create, missing name",
+			token: DeployTokenInfo{
+				Scopes: []DeployTokenScope{DeployTokenScopeReadRepository},
+			},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "invalid create, missing scopes",
+			token: DeployTokenInfo{
+				Name: "foo-deploytoken",
+			},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "invalid create, unknown scope",
+			token: DeployTokenInfo{
+				Name:   "foo-deploytoken",
+				Scopes: []DeployTokenScope{"api"},
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name: "invalid create, duplicate scope",
+			token: DeployTokenInfo{
+				Name:   "foo-deploytoken",
+				Scopes: []DeployTokenScope{DeployTokenScopeReadRepository, DeployTokenScopeReadRepository},
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "DeployToken", tt.token.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestLabel_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		label        LabelInfo
+		expectedErrs []error
+	}{
+		{
+			name:  "valid, with color",
+			label: LabelInfo{Name: "bug", Color: StringVar("FF00aa")},
+		},
+		{
+			name:         "invalid, missing name",
+			label:        LabelInfo{Color: StringVar("ff0000")},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, color with leading #",
+			label:        LabelInfo{Name: "bug", Color: StringVar("#ff0000")},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "Label", tt.label.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		webhook      WebhookInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid",
+			webhook: WebhookInfo{
+				URL:         "https://example.com/hook",
+				ContentType: WebhookContentTypeVar(WebhookContentTypeForm),
+				Events:      []WebhookEvent{WebhookEventPush, WebhookEventPullRequest},
+			},
+		},
+		{
+			name:         "invalid, missing URL",
+			webhook:      WebhookInfo{Events: []WebhookEvent{WebhookEventPush}},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, missing events",
+			webhook:      WebhookInfo{URL: "https://example.com/hook"},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, unknown event",
+			webhook:      WebhookInfo{URL: "https://example.com/hook", Events: []WebhookEvent{"tag_push"}},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name:         "invalid, duplicate event",
+			webhook:      WebhookInfo{URL: "https://example.com/hook", Events: []WebhookEvent{WebhookEventPush, WebhookEventPush}},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid, unknown content type",
+			webhook: WebhookInfo{
+				URL:         "https://example.com/hook",
+				ContentType: WebhookContentTypeVar("xml"),
+				Events:      []WebhookEvent{WebhookEventPush},
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "Webhook", tt.webhook.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestWebhook_Equals(t *testing.T) {
+	json := WebhookContentTypeVar(WebhookContentTypeJSON)
+	tests := []struct {
+		name    string
+		desired WebhookInfo
+		actual  WebhookInfo
+		want    bool
+	}{
+		{
+			name:    "events in different order, secret not returned",
+			desired: WebhookInfo{URL: "https://example.com/hook", Secret: StringVar("s3cr3t"), ContentType: json, Events: []WebhookEvent{WebhookEventPush, WebhookEventIssues}},
+			actual:  WebhookInfo{URL: "https://example.com/hook", ContentType: json, Events: []WebhookEvent{WebhookEventIssues, WebhookEventPush}},
+			want:    true,
+		},
+		{
+			name:    "different events",
+			desired: WebhookInfo{URL: "https://example.com/hook", ContentType: json, Events: []WebhookEvent{WebhookEventPush}},
+			actual:  WebhookInfo{URL: "https://example.com/hook", ContentType: json, Events: []WebhookEvent{WebhookEventPush, WebhookEventIssues}},
+		},
+		{
+			name:    "different content type",
+			desired: WebhookInfo{URL: "https://example.com/hook", ContentType: json, Events: []WebhookEvent{WebhookEventPush}},
+			actual:  WebhookInfo{URL: "https://example.com/hook", ContentType: WebhookContentTypeVar(WebhookContentTypeForm), Events: []WebhookEvent{WebhookEventPush}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.desired.Equals(tt.actual); got != tt.want {
+				t.Errorf("WebhookInfo.Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchProtection_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		protection   BranchProtectionInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid",
+			protection: BranchProtectionInfo{
+				Branch:               "main",
+				RequiredApprovals:    IntVar(2),
+				RequiredStatusChecks: []StatusCheck{{Context: "ci"}, {Context: "lint", AppID: Int64Var(15368)}},
+				AllowedPushers:       []string{"alice"},
+			},
+		},
+		{
+			name:         "invalid, missing branch",
+			protection:   BranchProtectionInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, negative approvals",
+			protection:   BranchProtectionInfo{Branch: "main", RequiredApprovals: IntVar(-1)},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid, empty status check",
+			protection:   BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("")},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid, duplicate status check",
+			protection:   BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("ci", "ci")},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid, status check app",
+			protection:   BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []StatusCheck{{Context: "ci", AppID: Int64Var(0)}}},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid, empty pusher",
+			protection:   BranchProtectionInfo{Branch: "main", AllowedPushers: []string{""}},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "BranchProtection", tt.protection.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestBranchProtection_Equals(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired BranchProtectionInfo
+		actual  BranchProtectionInfo
+		want    bool
+	}{
+		{
+			name:    "checks and pushers in different order and case",
+			desired: BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("ci", "lint"), AllowedPushers: []string{"Alice", "bob"}},
+			actual:  BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("lint", "ci"), AllowedPushers: []string{"bob", "alice"}},
+			want:    true,
+		},
+		{
+			name:    "different approvals",
+			desired: BranchProtectionInfo{Branch: "main", RequiredApprovals: IntVar(2)},
+			actual:  BranchProtectionInfo{Branch: "main", RequiredApprovals: IntVar(1)},
+		},
+		{
+			name:    "different code owner reviews",
+			desired: BranchProtectionInfo{Branch: "main", RequireCodeOwnerReviews: BoolVar(true)},
+			actual:  BranchProtectionInfo{Branch: "main", RequireCodeOwnerReviews: BoolVar(false)},
+		},
+		{
+			name:    "status checks differ in case",
+			desired: BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("CI")},
+			actual:  BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("ci")},
+		},
+		{
+			name:    "status check app not desired",
+			desired: BranchProtectionInfo{Branch: "main", RequiredStatusChecks: StatusChecks("ci")},
+			actual:  BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []StatusCheck{{Context: "ci", AppID: Int64Var(15368)}}},
+			want:    true,
+		},
+		{
+			name:    "status check apps differ",
+			desired: BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []StatusCheck{{Context: "ci", AppID: Int64Var(1)}}},
+			actual:  BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []StatusCheck{{Context: "ci", AppID: Int64Var(15368)}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.desired.Equals(tt.actual); got != tt.want {
+				t.Errorf("BranchProtectionInfo.Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProtection_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		protection   TagProtectionInfo
+		expectedErrs []error
+	}{
+		{
+			name:       "valid",
+			protection: TagProtectionInfo{Pattern: "v*"},
+		},
+		{
+			name:         "invalid, missing pattern",
+			protection:   TagProtectionInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "TagProtection", tt.protection.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
 func TestRepository_Validate(t *testing.T) {
 	unknownRepositoryVisibility := RepositoryVisibility("unknown")
 	tests := []struct {
@@ -154,3 +443,155 @@ func TestTeamAccess_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestActionsPermissions_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		info         ActionsPermissionsInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid, all",
+			info: ActionsPermissionsInfo{AllowedActions: AllowedActionsAll},
+		},
+		{
+			name: "valid, selected with patterns",
+			info: ActionsPermissionsInfo{
+				AllowedActions: AllowedActionsSelected,
+				Patterns:       []string{"actions/checkout@*", "fluxcd/*"},
+			},
+		},
+		{
+			name:         "invalid, missing mode",
+			info:         ActionsPermissionsInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, unknown mode",
+			info:         ActionsPermissionsInfo{AllowedActions: AllowedActions("some")},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name: "invalid, patterns without selected mode",
+			info: ActionsPermissionsInfo{
+				AllowedActions: AllowedActionsLocalOnly,
+				Patterns:       []string{"fluxcd/*"},
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid, empty pattern",
+			info: ActionsPermissionsInfo{
+				AllowedActions: AllowedActionsSelected,
+				Patterns:       []string{" "},
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "ActionsPermissions", tt.info.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestActionsPermissions_Equals(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired ActionsPermissionsInfo
+		actual  ActionsPermissionsInfo
+		want    bool
+	}{
+		{
+			name:    "same mode",
+			desired: ActionsPermissionsInfo{AllowedActions: AllowedActionsAll},
+			actual:  ActionsPermissionsInfo{AllowedActions: AllowedActionsAll},
+			want:    true,
+		},
+		{
+			name:    "different mode",
+			desired: ActionsPermissionsInfo{AllowedActions: AllowedActionsAll},
+			actual:  ActionsPermissionsInfo{AllowedActions: AllowedActionsLocalOnly},
+		},
+		{
+			name:    "patterns in different order",
+			desired: ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected, Patterns: []string{"b/*", "a/*"}},
+			actual:  ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected, Patterns: []string{"a/*", "b/*"}},
+			want:    true,
+		},
+		{
+			name:    "nil and empty patterns",
+			desired: ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected},
+			actual:  ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected, Patterns: []string{}},
+			want:    true,
+		},
+		{
+			name:    "different patterns",
+			desired: ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected, Patterns: []string{"a/*"}},
+			actual:  ActionsPermissionsInfo{AllowedActions: AllowedActionsSelected, Patterns: []string{"a/*", "b/*"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.desired.Equals(tt.actual); got != tt.want {
+				t.Errorf("ActionsPermissionsInfo.Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_Equals(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired RepositoryInfo
+		actual  RepositoryInfo
+		want    bool
+	}{
+		{
+			name:    "auto-merge unset",
+			desired: RepositoryInfo{},
+			actual:  RepositoryInfo{AllowAutoMerge: BoolVar(true)},
+			want:    true,
+		},
+		{
+			name:    "auto-merge same",
+			desired: RepositoryInfo{AllowAutoMerge: BoolVar(true)},
+			actual:  RepositoryInfo{AllowAutoMerge: BoolVar(true)},
+			want:    true,
+		},
+		{
+			name:    "auto-merge different",
+			desired: RepositoryInfo{AllowAutoMerge: BoolVar(true)},
+			actual:  RepositoryInfo{AllowAutoMerge: BoolVar(false)},
+		},
+		{
+			name:    "auto-merge unknown",
+			desired: RepositoryInfo{AllowAutoMerge: BoolVar(false)},
+			actual:  RepositoryInfo{},
+		},
+		{
+			name:    "branch deletion unset",
+			desired: RepositoryInfo{},
+			actual:  RepositoryInfo{DeleteBranchOnMerge: BoolVar(true)},
+			want:    true,
+		},
+		{
+			name:    "branch deletion same",
+			desired: RepositoryInfo{DeleteBranchOnMerge: BoolVar(false)},
+			actual:  RepositoryInfo{DeleteBranchOnMerge: BoolVar(false)},
+			want:    true,
+		},
+		{
+			name:    "branch deletion different",
+			desired: RepositoryInfo{DeleteBranchOnMerge: BoolVar(true)},
+			actual:  RepositoryInfo{DeleteBranchOnMerge: BoolVar(false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.desired.Equals(tt.actual); got != tt.want {
+				t.Errorf("RepositoryInfo.Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,16 @@ func BoolVar(b bool) *bool {
 	return &b
 }
 
+// IntVar returns a pointer to the given int.
+func IntVar(i int) *int {
+	return &i
+}
+
+// Int64Var returns a pointer to the given int64.
+func Int64Var(i int64) *int64 {
+	return &i
+}
+
 // StringVar returns a pointer to the given string.
 func StringVar(s string) *string {
 	return &s
@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBulkConcurrency is the default number of repositories updated in parallel.
+	defaultBulkConcurrency = 4
+	// defaultBulkMaxRetries is the default number of retries after hitting the rate limit.
+	defaultBulkMaxRetries = 3
+	// defaultBulkBackoff is the initial backoff if the provider doesn't tell when to retry.
+	defaultBulkBackoff = time.Second
+)
+
+// BulkVisibilityOptions specifies options for SetOrgRepositoriesVisibility.
+type BulkVisibilityOptions struct {
+	// ConfirmName must be set to the name of the organization, in order to confirm that the
+	// visibility of the repositories in exactly this organization is meant to be changed.
+	// +required
+	ConfirmName string
+
+	// Filter selects the repositories to change the visibility of.
+	// Default: nil (which means "all repositories in the organization")
+	Filter func(repo OrgRepository) bool
+
+	// Concurrency bounds the number of repositories that are updated in parallel.
+	// Default: 4
+	Concurrency int
+
+	// MaxRetries bounds how many times the update of a repository is retried after hitting the
+	// rate limit. Other errors aren't retried. Set it to 0 to turn the retries off, negative
+	// values are invalid.
+	// Default: nil (which means 3)
+	MaxRetries *int
+}
+
+// RepositoryVisibilityResult is the outcome of changing the visibility of a single repository.
+type RepositoryVisibilityResult struct {
+	// Repository points to the repository.
+	Repository RepositoryRef
+
+	// Skipped is true if the repository already had the target visibility, and wasn't updated.
+	Skipped bool
+
+	// Err is set if updating the repository failed.
+	Err error
+}
+
+// SetOrgRepositoriesVisibility sets the visibility of all repositories in the given organization
+// that match opts.Filter. Repositories already at the target visibility are skipped. The
+// repositories are updated in parallel with bounded concurrency, and updates hitting the rate
+// limit are retried after backing off.
+//
+// The returned results contain one entry per matching repository, in the order returned by
+// OrgRepositoriesClient.List. Failing to update a repository doesn't stop the other updates,
+// instead the error is reported in the result of the repository.
+//
+// ErrInvalidArgument is returned if opts.ConfirmName doesn't match the organization name, or if
+// opts.MaxRetries is negative.
+// validation.ErrFieldEnumInvalid is returned if the visibility isn't a known value.
+func SetOrgRepositoriesVisibility(ctx context.Context, c OrgRepositoriesClient, o OrganizationRef, visibility RepositoryVisibility, opts BulkVisibilityOptions) ([]RepositoryVisibilityResult, error) {
+	// This is a mass-change of the organization, hence require explicit confirmation
+	if opts.ConfirmName != o.Organization {
+		return nil, fmt.Errorf("confirmation %q doesn't match organization %q: %w", opts.ConfirmName, o.Organization, ErrInvalidArgument)
+	}
+	if err := ValidateRepositoryVisibility(visibility); err != nil {
+		return nil, err
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	maxRetries := defaultBulkMaxRetries
+	if opts.MaxRetries != nil {
+		if *opts.MaxRetries < 0 {
+			return nil, fmt.Errorf("MaxRetries must not be negative: %w", ErrInvalidArgument)
+		}
+		maxRetries = *opts.MaxRetries
+	}
+
+	repos, err := c.List(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the matching repositories, updates holds nil for the skipped ones
+	results := make([]RepositoryVisibilityResult, 0, len(repos))
+	updates := make([]OrgRepository, 0, len(repos))
+	for _, repo := range repos {
+		if opts.Filter != nil && !opts.Filter(repo) {
+			continue
+		}
+		info := repo.Get()
+		skip := info.Visibility != nil && *info.Visibility == visibility
+		results = append(results, RepositoryVisibilityResult{Repository: repo.Repository(), Skipped: skip})
+		if skip {
+			repo = nil
+		}
+		updates = append(updates, repo)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, repo := range updates {
+		if repo == nil {
+			continue
+		}
+		repo, result := repo, &results[i]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.Err = setRepositoryVisibility(ctx, repo, visibility, maxRetries)
+		}()
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// setRepositoryVisibility updates the visibility of repo, retrying up to maxRetries times if
+// the rate limit was hit.
+func setRepositoryVisibility(ctx context.Context, repo OrgRepository, visibility RepositoryVisibility, maxRetries int) error {
+	info := repo.Get()
+	info.Visibility = &visibility
+	if err := repo.Set(info); err != nil {
+		return err
+	}
+
+	backoff := defaultBulkBackoff
+	for attempt := 0; ; attempt++ {
+		err := repo.Update(ctx)
+		wait, limited := rateLimitBackoff(err, backoff)
+		if !limited || attempt >= maxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// rateLimitBackoff returns how long to wait before retrying, if err is caused by hitting the
+// rate limit. The reset time of the rate limit or the Retry-After header is used if available,
+// otherwise fallback is returned.
+func rateLimitBackoff(err error, fallback time.Duration) (time.Duration, bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if wait := time.Until(rateLimitErr.Reset); wait > 0 {
+			return wait, true
+		}
+		return fallback, true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.Response != nil && httpErr.Response.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(httpErr.Response.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return fallback, true
+	}
+	return 0, false
+}
+
+// VisibilityVerification specifies how the visibility of a newly created repository is verified.
+type VisibilityVerification struct {
+	// Delay is how long to wait after the creation before reading the repository again, to give
+	// the provider time to apply organization policies.
+	// Default: 0 (which means "read it again right away")
+	Delay time.Duration
+
+	// OnMismatch is called if the visibility of the repository differs from the requested one,
+	// or if it couldn't be read again.
+	// +required
+	OnMismatch func(mismatch VisibilityMismatch)
+}
+
+// VisibilityMismatch describes a newly created repository whose visibility differs from the
+// requested one.
+type VisibilityMismatch struct {
+	// Repository is the repository that was created.
+	Repository RepositoryRef
+	// Requested is the visibility that was asked for.
+	Requested RepositoryVisibility
+	// Actual is the visibility that was read back, nil if it couldn't be read.
+	Actual *RepositoryVisibility
+	// Err is set if the repository couldn't be read again.
+	Err error
+}
+
+// VerifyCreatedRepositoryVisibility waits for v.Delay, reads the visibility of the newly created
+// repository using get, and calls v.OnMismatch if it differs from requested. It is meant to be
+// used by provider implementations when the VerifyVisibility create option is set. Nothing is
+// verified if v or requested is nil, or if ctx is done before the delay has passed.
+func VerifyCreatedRepositoryVisibility(ctx context.Context, v *VisibilityVerification, ref RepositoryRef, requested *RepositoryVisibility, get func(ctx context.Context) (*RepositoryVisibility, error)) {
+	if v == nil || v.OnMismatch == nil || requested == nil {
+		return
+	}
+	if v.Delay > 0 {
+		timer := time.NewTimer(v.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+
+	actual, err := get(ctx)
+	if err != nil {
+		v.OnMismatch(VisibilityMismatch{Repository: ref, Requested: *requested, Err: err})
+		return
+	}
+	if actual == nil || *actual != *requested {
+		v.OnMismatch(VisibilityMismatch{Repository: ref, Requested: *requested, Actual: actual})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeOrgRepositoriesClient struct {
+	OrgRepositoriesClient
+	repos []OrgRepository
+}
+
+func (c *fakeOrgRepositoriesClient) List(_ context.Context, _ OrganizationRef) ([]OrgRepository, error) {
+	return c.repos, nil
+}
+
+type fakeOrgRepository struct {
+	OrgRepository
+	name    string
+	info    RepositoryInfo
+	updates int32
+	errs    []error
+}
+
+func (r *fakeOrgRepository) Get() RepositoryInfo { return r.info }
+
+func (r *fakeOrgRepository) Set(info RepositoryInfo) error {
+	r.info = info
+	return nil
+}
+
+func (r *fakeOrgRepository) Update(_ context.Context) error {
+	i := atomic.AddInt32(&r.updates, 1) - 1
+	if int(i) < len(r.errs) {
+		return r.errs[i]
+	}
+	return nil
+}
+
+func (r *fakeOrgRepository) Repository() RepositoryRef {
+	return OrgRepositoryRef{OrganizationRef: OrganizationRef{Organization: "org"}, RepositoryName: r.name}
+}
+
+func TestSetOrgRepositoriesVisibility(t *testing.T) {
+	org := OrganizationRef{Domain: "github.com", Organization: "org"}
+	tooManyRequests := &HTTPError{Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}}
+	rateLimited := &RateLimitError{Reset: time.Now().Add(-time.Second)}
+	boom := errors.New("boom")
+
+	public := &fakeOrgRepository{name: "public", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}}
+	private := &fakeOrgRepository{name: "private", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPrivate)}}
+	limited := &fakeOrgRepository{name: "limited", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}, errs: []error{rateLimited}}
+	failing := &fakeOrgRepository{name: "failing", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}, errs: []error{boom}}
+	filtered := &fakeOrgRepository{name: "filtered", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}}
+	exhausted := &fakeOrgRepository{name: "exhausted", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}, errs: []error{tooManyRequests, tooManyRequests}}
+	c := &fakeOrgRepositoriesClient{repos: []OrgRepository{public, private, limited, failing, filtered, exhausted}}
+
+	if _, err := SetOrgRepositoriesVisibility(context.Background(), c, org, RepositoryVisibilityPrivate, BulkVisibilityOptions{ConfirmName: "other"}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument for mismatching confirmation, got %v", err)
+	}
+
+	results, err := SetOrgRepositoriesVisibility(context.Background(), c, org, RepositoryVisibilityPrivate, BulkVisibilityOptions{
+		ConfirmName: "org",
+		Filter: func(repo OrgRepository) bool {
+			return repo != filtered
+		},
+		Concurrency: 2,
+		MaxRetries:  IntVar(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name    string
+		skipped bool
+		err     error
+		updates int32
+	}{
+		{name: "public", updates: 1},
+		{name: "private", skipped: true, updates: 0},
+		{name: "limited", updates: 2},
+		{name: "failing", err: boom, updates: 1},
+		{name: "exhausted", err: tooManyRequests, updates: 2},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	repos := map[string]*fakeOrgRepository{"public": public, "private": private, "limited": limited, "failing": failing, "exhausted": exhausted}
+	for i, w := range want {
+		got := results[i]
+		if got.Repository.GetRepository() != w.name || got.Skipped != w.skipped || !errors.Is(got.Err, w.err) {
+			t.Errorf("result %d = %+v, want %+v", i, got, w)
+		}
+		if updates := atomic.LoadInt32(&repos[w.name].updates); updates != w.updates {
+			t.Errorf("repository %q was updated %d times, want %d", w.name, updates, w.updates)
+		}
+	}
+	if filtered.updates != 0 {
+		t.Errorf("filtered repository was updated")
+	}
+}
+
+func TestSetOrgRepositoriesVisibility_maxRetries(t *testing.T) {
+	org := OrganizationRef{Domain: "github.com", Organization: "org"}
+	tooManyRequests := &HTTPError{Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}}
+
+	if _, err := SetOrgRepositoriesVisibility(context.Background(), &fakeOrgRepositoriesClient{}, org, RepositoryVisibilityPrivate, BulkVisibilityOptions{
+		ConfirmName: "org",
+		MaxRetries:  IntVar(-1),
+	}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument for negative MaxRetries, got %v", err)
+	}
+
+	// Zero turns the retries off
+	limited := &fakeOrgRepository{name: "limited", info: RepositoryInfo{Visibility: RepositoryVisibilityVar(RepositoryVisibilityPublic)}, errs: []error{tooManyRequests}}
+	results, err := SetOrgRepositoriesVisibility(context.Background(), &fakeOrgRepositoriesClient{repos: []OrgRepository{limited}}, org, RepositoryVisibilityPrivate, BulkVisibilityOptions{
+		ConfirmName: "org",
+		MaxRetries:  IntVar(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Err, tooManyRequests) {
+		t.Errorf("results = %+v, want the rate limit error", results)
+	}
+	if limited.updates != 1 {
+		t.Errorf("repository was updated %d times, want 1", limited.updates)
+	}
+}
+
+func TestVerifyCreatedRepositoryVisibility(t *testing.T) {
+	ref := OrgRepositoryRef{OrganizationRef: OrganizationRef{Organization: "org"}, RepositoryName: "repo"}
+	public := RepositoryVisibilityPublic
+	private := RepositoryVisibilityPrivate
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name       string
+		actual     *RepositoryVisibility
+		getErr     error
+		ctx        func() context.Context
+		delay      time.Duration
+		wantCalled bool
+		wantGet    bool
+	}{
+		{name: "matching", actual: &public, wantGet: true},
+		{name: "overridden by policy", actual: &private, wantCalled: true, wantGet: true},
+		{name: "read failed", getErr: boom, wantCalled: true, wantGet: true},
+		{
+			name:  "context done during delay",
+			delay: time.Hour,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+			var got *VisibilityMismatch
+			v := &VisibilityVerification{
+				Delay:      tt.delay,
+				OnMismatch: func(m VisibilityMismatch) { got = &m },
+			}
+			gotGet := false
+			VerifyCreatedRepositoryVisibility(ctx, v, ref, &public, func(context.Context) (*RepositoryVisibility, error) {
+				gotGet = true
+				return tt.actual, tt.getErr
+			})
+			if gotGet != tt.wantGet {
+				t.Errorf("get called = %v, want %v", gotGet, tt.wantGet)
+			}
+			if (got != nil) != tt.wantCalled {
+				t.Fatalf("OnMismatch called = %v, want %v", got != nil, tt.wantCalled)
+			}
+			if got == nil {
+				return
+			}
+			if got.Repository.GetRepository() != "repo" || got.Requested != public || got.Actual != tt.actual || !errors.Is(got.Err, tt.getErr) {
+				t.Errorf("mismatch = %+v", *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// RotatesWebhookSecret returns whether reconciling req recreates the webhook to rotate its
+// secret, as requested using WebhookReconcileOptions.ForceSecretRotation.
+// ErrInvalidArgument is returned if the rotation is requested, but req has no secret.
+func RotatesWebhookSecret(req WebhookInfo, opts WebhookReconcileOptions) (bool, error) {
+	if opts.ForceSecretRotation == nil || !*opts.ForceSecretRotation {
+		return false, nil
+	}
+	if req.Secret == nil || *req.Secret == "" {
+		return false, fmt.Errorf("rotating the webhook secret requires a secret: %w", ErrInvalidArgument)
+	}
+	return true, nil
+}
+
+// SystemWebhookProvider is implemented by the clients of Git providers with instance-wide
+// webhooks, i.e. GitLab system hooks. Use SystemWebhooks to access them.
+type SystemWebhookProvider interface {
+	// SystemWebhooks returns the SystemWebhookClient operating on the instance-wide webhooks.
+	SystemWebhooks() SystemWebhookClient
+}
+
+// SystemWebhooks returns the SystemWebhookClient of c, if its provider has instance-wide webhooks.
+// ErrNoProviderSupport is returned otherwise.
+func SystemWebhooks(c Client) (SystemWebhookClient, error) {
+	p, ok := c.(SystemWebhookProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s has no system webhooks: %w", c.ProviderID(), ErrNoProviderSupport)
+	}
+	return p.SystemWebhooks(), nil
+}
+
+// WebhookEventMap maps the webhook events a Git provider supports to the names the provider uses
+// for them, e.g. WebhookEventComment to "issue_comment" and "pull_request_review_comment" on
+// GitHub. The provider packages expose their map through WebhookEvents().
+type WebhookEventMap map[WebhookEvent][]string
+
+// Supports returns whether the provider supports the given event.
+func (m WebhookEventMap) Supports(event WebhookEvent) bool {
+	_, ok := m[event]
+	return ok
+}
+
+// WebhookEventsBuilder builds the events of a webhook, i.e. WebhookInfo.Events, for example:
+//
+//	events, err := gitprovider.NewWebhookEventsBuilder(github.WebhookEvents()).Push().PullRequest().Build()
+type WebhookEventsBuilder struct {
+	supported WebhookEventMap
+	events    []WebhookEvent
+}
+
+// NewWebhookEventsBuilder returns a WebhookEventsBuilder, which rejects the events that aren't in
+// supported. With a nil map, all known events are accepted.
+func NewWebhookEventsBuilder(supported WebhookEventMap) *WebhookEventsBuilder {
+	return &WebhookEventsBuilder{supported: supported}
+}
+
+// Push adds WebhookEventPush.
+func (b *WebhookEventsBuilder) Push() *WebhookEventsBuilder {
+	return b.Add(WebhookEventPush)
+}
+
+// PullRequest adds WebhookEventPullRequest.
+func (b *WebhookEventsBuilder) PullRequest() *WebhookEventsBuilder {
+	return b.Add(WebhookEventPullRequest)
+}
+
+// Tag adds WebhookEventTag.
+func (b *WebhookEventsBuilder) Tag() *WebhookEventsBuilder {
+	return b.Add(WebhookEventTag)
+}
+
+// Issues adds WebhookEventIssues.
+func (b *WebhookEventsBuilder) Issues() *WebhookEventsBuilder {
+	return b.Add(WebhookEventIssues)
+}
+
+// Comment adds WebhookEventComment.
+func (b *WebhookEventsBuilder) Comment() *WebhookEventsBuilder {
+	return b.Add(WebhookEventComment)
+}
+
+// Release adds WebhookEventRelease.
+func (b *WebhookEventsBuilder) Release() *WebhookEventsBuilder {
+	return b.Add(WebhookEventRelease)
+}
+
+// Add adds the given events, e.g. read from a configuration file. They're validated by Build.
+func (b *WebhookEventsBuilder) Add(events ...WebhookEvent) *WebhookEventsBuilder {
+	b.events = append(b.events, events...)
+	return b
+}
+
+// Build returns the added events in the order they were added, without duplicates.
+// validation.ErrFieldRequired is returned if no events were added, validation.ErrFieldEnumInvalid
+// for unknown events, and ErrNoProviderSupport for events the provider doesn't support.
+func (b *WebhookEventsBuilder) Build() ([]WebhookEvent, error) {
+	validator := validation.New("WebhookEvents")
+	if len(b.events) == 0 {
+		validator.Required("Events")
+	}
+	events := make([]WebhookEvent, 0, len(b.events))
+	seen := make(map[WebhookEvent]struct{}, len(b.events))
+	for _, event := range b.events {
+		if _, ok := seen[event]; ok {
+			continue
+		}
+		seen[event] = struct{}{}
+		if err := ValidateWebhookEvent(event); err != nil {
+			validator.Append(err, event, "Events")
+			continue
+		}
+		if b.supported != nil && !b.supported.Supports(event) {
+			validator.Append(ErrNoProviderSupport, event, "Events")
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := validator.Error(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func TestRotatesWebhookSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     WebhookInfo
+		opts    []WebhookReconcileOption
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "not requested",
+			req:  WebhookInfo{Secret: StringVar("s3cr3t")},
+		},
+		{
+			name: "disabled",
+			req:  WebhookInfo{Secret: StringVar("s3cr3t")},
+			opts: []WebhookReconcileOption{&WebhookReconcileOptions{ForceSecretRotation: BoolVar(false)}},
+		},
+		{
+			name: "requested",
+			req:  WebhookInfo{Secret: StringVar("s3cr3t")},
+			opts: []WebhookReconcileOption{&WebhookReconcileOptions{ForceSecretRotation: BoolVar(true)}},
+			want: true,
+		},
+		{
+			name:    "without a secret",
+			req:     WebhookInfo{},
+			opts:    []WebhookReconcileOption{&WebhookReconcileOptions{ForceSecretRotation: BoolVar(true)}},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:    "with an empty secret",
+			req:     WebhookInfo{Secret: StringVar("")},
+			opts:    []WebhookReconcileOption{&WebhookReconcileOptions{ForceSecretRotation: BoolVar(true)}},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RotatesWebhookSecret(tt.req, MakeWebhookReconcileOptions(tt.opts...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RotatesWebhookSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RotatesWebhookSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSystemWebhookBaseClient struct {
+	Client
+}
+
+func (c *fakeSystemWebhookBaseClient) ProviderID() ProviderID { return ProviderID("fake") }
+
+type fakeSystemWebhookProvider struct {
+	fakeSystemWebhookBaseClient
+	systemWebhooks SystemWebhookClient
+}
+
+func (c *fakeSystemWebhookProvider) SystemWebhooks() SystemWebhookClient {
+	return c.systemWebhooks
+}
+
+func TestSystemWebhooks(t *testing.T) {
+	if _, err := SystemWebhooks(&fakeSystemWebhookBaseClient{}); !errors.Is(err, ErrNoProviderSupport) {
+		t.Errorf("SystemWebhooks() error = %v, want %v", err, ErrNoProviderSupport)
+	}
+
+	want := struct{ SystemWebhookClient }{}
+	got, err := SystemWebhooks(&fakeSystemWebhookProvider{systemWebhooks: want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("SystemWebhooks() = %v, want %v", got, want)
+	}
+}
+
+func TestWebhookEventsBuilder(t *testing.T) {
+	supported := WebhookEventMap{
+		WebhookEventPush:        {"repo:refs_changed"},
+		WebhookEventPullRequest: {"pr:opened"},
+	}
+	tests := []struct {
+		name    string
+		builder *WebhookEventsBuilder
+		want    []WebhookEvent
+		wantErr error
+	}{
+		{
+			name:    "all known events",
+			builder: NewWebhookEventsBuilder(nil).Push().PullRequest().Tag().Issues().Comment().Release(),
+			want: []WebhookEvent{
+				WebhookEventPush, WebhookEventPullRequest, WebhookEventTag,
+				WebhookEventIssues, WebhookEventComment, WebhookEventRelease,
+			},
+		},
+		{
+			name:    "duplicates",
+			builder: NewWebhookEventsBuilder(supported).PullRequest().Add(WebhookEventPush, WebhookEventPullRequest),
+			want:    []WebhookEvent{WebhookEventPullRequest, WebhookEventPush},
+		},
+		{
+			name:    "no events",
+			builder: NewWebhookEventsBuilder(nil),
+			wantErr: validation.ErrFieldRequired,
+		},
+		{
+			name:    "unknown event",
+			builder: NewWebhookEventsBuilder(nil).Push().Add("pushed"),
+			wantErr: validation.ErrFieldEnumInvalid,
+		},
+		{
+			name:    "unsupported event",
+			builder: NewWebhookEventsBuilder(supported).Push().Release(),
+			wantErr: ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Build() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogs
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/giteaapi"
+)
+
+// TokenVariable is the common name for the environment variable containing a Gogs
+// authentication token.
+const TokenVariable = "GOGS_TOKEN" // #nosec G101
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	return NewClient(gitprovider.TokenFromURL(u), append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for Gogs API endpoints.
+//
+// The token is an access token of a Gogs user, passing an empty token will allow public read
+// access only.
+//
+// Gogs is always self-hosted, hence the domain must be given using WithDomain, e.g.
+// "gogs.example.com" or "https://example.com/gogs" for instances served under a sub-path.
+//
+// The client talks to Gogs using the Gitea clients, as the Gitea API grew out of the Gogs one, and
+// still serves most endpoints in the same way. Editing repositories, managing the teams of
+// repositories and deploy keys with write access aren't supported by Gogs, ErrNoProviderSupport
+// is returned for them.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Domain == nil {
+		return nil, fmt.Errorf("the domain of the gogs server must be given: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	domain, err := giteaapi.NormalizeDomain(*opts.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := giteaapi.NewClientFromOptions(flavor, token, domain, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogs
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/giteaapi"
+)
+
+// ProviderID is the provider ID for Gogs.
+const ProviderID = gitprovider.ProviderID("gogs")
+
+// flavor describes the Gogs API. Gogs has no API for editing repositories and the teams of
+// repositories, and its deploy keys are always read-only.
+//
+//nolint:gochecknoglobals
+var flavor = &giteaapi.Flavor{
+	ProviderID: ProviderID,
+	APIDocURL:  "https://github.com/gogs/docs-api",
+	LicenseTemplates: map[gitprovider.LicenseTemplate]string{
+		gitprovider.LicenseTemplateApache2: "Apache License 2.0",
+		gitprovider.LicenseTemplateMIT:     "MIT License",
+		gitprovider.LicenseTemplateGPL3:    "GNU General Public License v3.0",
+	},
+}
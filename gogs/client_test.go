@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gogs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+// newTestClient returns a client for a fake Gogs API served by mux, together with the domain
+// of the fake server.
+func newTestClient(t *testing.T, mux *http.ServeMux) (gitprovider.Client, string) {
+	t.Helper()
+	srv := testutil.NewServer(t, mux)
+	c, err := NewClient("token", gitprovider.WithDomain(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, srv.URL
+}
+
+func TestNewClient_domain(t *testing.T) {
+	if _, err := NewClient("token"); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("NewClient() without domain error = %v, want ErrInvalidClientOptions", err)
+	}
+	c, err := NewClient("token", gitprovider.WithDomain("gogs.example.com/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.SupportedDomain(); got != "gogs.example.com" {
+		t.Errorf("SupportedDomain() = %q, want %q", got, "gogs.example.com")
+	}
+	if got := c.ProviderID(); got != ProviderID {
+		t.Errorf("ProviderID() = %q, want %q", got, ProviderID)
+	}
+}
+
+func TestClient_unsupportedEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/flux/repo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s, Gogs has no API for editing repositories", r.Method, r.URL.Path)
+		}
+		testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"name": "repo", "default_branch": "master"})
+	})
+	mux.HandleFunc("/api/v1/repos/flux/repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s, Gogs deploy keys are always read-only", r.Method, r.URL.Path)
+		}
+		testutil.WriteJSON(w, http.StatusOK, []interface{}{})
+	})
+	c, domain := newTestClient(t, mux)
+	ctx := context.Background()
+
+	repo, err := c.OrgRepositories().Get(ctx, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "flux"},
+		RepositoryName:  "repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "Update",
+			call: func() error {
+				return repo.Update(ctx)
+			},
+		},
+		{
+			name: "SetDefaultBranch",
+			call: func() error {
+				return repo.SetDefaultBranch(ctx, "main")
+			},
+		},
+		{
+			name: "TeamAccess List",
+			call: func() error {
+				_, err := repo.TeamAccess().List(ctx)
+				return err
+			},
+		},
+		{
+			name: "TeamAccess Create",
+			call: func() error {
+				_, err := repo.TeamAccess().Create(ctx, gitprovider.TeamAccessInfo{Name: "maintainers"})
+				return err
+			},
+		},
+		{
+			name: "DeployKeys Create with write access",
+			call: func() error {
+				_, err := repo.DeployKeys().Create(ctx, gitprovider.DeployKeyInfo{
+					Name:     "flux",
+					Key:      []byte("ssh-ed25519 AAAA"),
+					ReadOnly: gitprovider.BoolVar(false),
+				})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+				t.Errorf("error = %v, want ErrNoProviderSupport", err)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_license(t *testing.T) {
+	var license string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/org/flux/repos", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Name    string `json:"name"`
+			License string `json:"license"`
+		}
+		testutil.ReadJSON(t, r, &req)
+		license = req.License
+		testutil.WriteJSON(w, http.StatusCreated, map[string]interface{}{"name": req.Name, "default_branch": "master"})
+	})
+	c, domain := newTestClient(t, mux)
+
+	_, err := c.OrgRepositories().Create(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "flux"},
+		RepositoryName:  "repo",
+	}, gitprovider.RepositoryInfo{}, &gitprovider.RepositoryCreateOptions{
+		LicenseTemplate: gitprovider.LicenseTemplateVar(gitprovider.LicenseTemplateMIT),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Gogs names the licenses it ships with differently than Gitea
+	if license != "MIT License" {
+		t.Errorf("Create() sent license %q, want %q", license, "MIT License")
+	}
+}
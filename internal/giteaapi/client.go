@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// NewClientFromOptions creates a client of the given flavor for the server at domain, using the
+// HTTP client, token and destructive actions setting of opts. An empty token allows public read
+// access only.
+func NewClientFromOptions(flavor *Flavor, token, domain string, opts *gitprovider.ClientOptions) (*Client, error) {
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts := []gitea.ClientOption{gitea.SetHTTPClient(httpClient)}
+	if token != "" {
+		clientOpts = append(clientOpts, gitea.SetToken(token))
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return NewClient(flavor, gitprovider.GetDomainURL(domain), clientOpts, domain, destructiveActions)
+}
+
+// NewClient creates a client of the given flavor for the server at baseURL, using the Gitea SDK
+// with opts.
+func NewClient(flavor *Flavor, baseURL string, opts []gitea.ClientOption, domain string, destructiveActions bool) (*Client, error) {
+	// Don't request the server version when creating clients, all calls made by this package
+	// are supported by the Gitea versions the SDK supports, and Gogs doesn't report it
+	opts = append(opts, gitea.SetGiteaVersion(""))
+	gc, err := gitea.NewClient(baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	giteaClient := &giteaClientImpl{c: gc, baseURL: baseURL, opts: opts, destructiveActions: destructiveActions, apiDocURL: flavor.APIDocURL}
+	ctx := &clientContext{c: giteaClient, domain: domain, destructiveActions: destructiveActions, flavor: flavor}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+	}, nil
+}
+
+type clientContext struct {
+	c                  giteaClient
+	domain             string
+	destructiveActions bool
+	flavor             *Flavor
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "gitea.com" or
+// "gogs.example.com". This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID of the flavor, e.g. "gitea".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return c.flavor.ProviderID
+}
+
+// Raw returns the Gitea client (code.gitea.io/sdk/gitea *Client)
+// used under the hood for accessing the server.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeOrganization if an organization with the given name exists,
+// and OwnerTypeUser if a user with the given name exists.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	// GET /orgs/{org}
+	_, err := c.c.GetOrg(ctx, owner)
+	if err == nil {
+		return gitprovider.OwnerTypeOrganization, nil
+	}
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		return "", err
+	}
+	// GET /users/{username}
+	if _, err := c.c.GetUser(ctx, owner); err != nil {
+		return "", err
+	}
+	return gitprovider.OwnerTypeUser, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as listing starred repositories isn't implemented yet.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, errNotImplemented("listing starred repositories")
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as listing license templates isn't implemented yet.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, errNotImplemented("listing license templates")
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as listing .gitignore templates isn't implemented yet.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, errNotImplemented("listing .gitignore templates")
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as the scopes of Gitea access tokens
+// can't be inspected using the token itself.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as the scopes of Gitea access tokens
+// can't be inspected using the token itself.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as Gitea access tokens can't be inspected
+// using the token itself.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
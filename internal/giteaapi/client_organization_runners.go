@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// RunnersClient implements the gitprovider.RunnersClient interface.
+var _ gitprovider.RunnersClient = &RunnersClient{}
+
+// RunnersClient handles self-hosted runners, which the Gitea API doesn't expose.
+type RunnersClient struct{}
+
+// List always returns ErrNoProviderSupport, as Gitea has no API for managing Actions runners.
+func (c *RunnersClient) List(_ context.Context) ([]gitprovider.Runner, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// CreateRegistrationToken always returns ErrNoProviderSupport, as Gitea has no API for managing Actions runners.
+func (c *RunnersClient) CreateRegistrationToken(_ context.Context) (gitprovider.RunnerRegistrationToken, error) {
+	return gitprovider.RunnerRegistrationToken{}, gitprovider.ErrNoProviderSupport
+}
+
+// Delete always returns ErrNoProviderSupport, as Gitea has no API for managing Actions runners.
+func (c *RunnersClient) Delete(_ context.Context, _ int64) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+func TestDeployKeyClient_Reconcile(t *testing.T) {
+	keys := []*gitea.DeployKey{{ID: 1, Title: "flux", Key: "ssh-ed25519 AAAA", ReadOnly: true}}
+	deleted := []int64{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/flux/repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			testutil.WriteJSON(w, http.StatusOK, keys)
+		case http.MethodPost:
+			req := &gitea.CreateKeyOption{}
+			testutil.ReadJSON(t, r, req)
+			for _, key := range keys {
+				if key.Title == req.Title {
+					testutil.WriteJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "A key with the same name already exists"})
+					return
+				}
+			}
+			key := &gitea.DeployKey{ID: int64(len(keys) + 1), Title: req.Title, Key: req.Key, ReadOnly: req.ReadOnly}
+			keys = append(keys, key)
+			testutil.WriteJSON(w, http.StatusCreated, key)
+		}
+	})
+	mux.HandleFunc("/api/v1/repos/flux/repo/keys/", func(w http.ResponseWriter, r *http.Request) {
+		var id int64
+		if _, err := fmt.Sscanf(r.URL.Path, "/api/v1/repos/flux/repo/keys/%d", &id); err != nil || r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		deleted = append(deleted, id)
+		for i, key := range keys {
+			if key.ID == id {
+				keys = append(keys[:i], keys[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux)
+	dks := &DeployKeyClient{
+		clientContext: c.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+
+	_, actionTaken, err := dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 AAAA")})
+	if err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	// Gitea deploy keys can't be edited, hence giving write access recreates the key
+	dk, actionTaken, err := dks.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 AAAA"), ReadOnly: gitprovider.BoolVar(false)})
+	if err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want the key to be recreated", actionTaken, err)
+	}
+	if !reflect.DeepEqual(deleted, []int64{1}) {
+		t.Errorf("deleted keys = %v, want [1]", deleted)
+	}
+	if got := dk.Get().ReadOnly; got == nil || *got {
+		t.Errorf("Reconcile() read-only = %v, want false", got)
+	}
+
+	_, err = dks.Create(ctx, gitprovider.DeployKeyInfo{Name: "flux", Key: []byte("ssh-ed25519 BBBB")})
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want ErrAlreadyExists", err)
+	}
+}
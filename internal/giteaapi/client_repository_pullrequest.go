@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests of a specific repository.
+type PullRequestClient struct{}
+
+// List always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) List(_ context.Context, _ ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("listing pull requests")
+}
+
+// Create always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Create(_ context.Context, _, _, _, _ string, _ ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("creating pull requests")
+}
+
+// Edit always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Edit(_ context.Context, _ int, _ gitprovider.EditOptions) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("editing pull requests")
+}
+
+// Get always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Get(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("getting pull requests")
+}
+
+// Merge always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Merge(_ context.Context, _ int, _ gitprovider.MergeMethod, _ string, _ ...gitprovider.PullRequestMergeOption) error {
+	return errNotImplemented("merging pull requests")
+}
+
+// Update always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Update(_ context.Context, _ int, _ gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("updating pull requests")
+}
+
+// Close always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Close(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("closing pull requests")
+}
+
+// Reopen always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) Reopen(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("reopening pull requests")
+}
+
+// MarkReady always returns ErrNoProviderSupport, as pull requests aren't implemented yet.
+func (c *PullRequestClient) MarkReady(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, errNotImplemented("marking pull requests as ready")
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient operates on the teams list for a specific repository.
+// Gitea teams have the same permission on all of their repositories, hence the permission of
+// the team in the organization settings is the permission on the repository.
+//
+// ErrNoProviderSupport is returned by all methods if the API doesn't serve the teams of
+// repositories, like the Gogs one, which only lets site administrators manage them.
+type TeamAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a team's permission level of this given repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamAccessClient) Get(ctx context.Context, name string) (gitprovider.TeamAccess, error) {
+	if !c.flavor.TeamAccess {
+		return nil, errNoTeamAccess(c.flavor)
+	}
+	// GET /repos/{owner}/{repo}/teams/{team}
+	apiObj, err := c.c.GetRepoTeam(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return nil, err
+	}
+	return newTeamAccess(c, teamAccessFromAPI(apiObj)), nil
+}
+
+// List the team access control list for this repository.
+func (c *TeamAccessClient) List(ctx context.Context) ([]gitprovider.TeamAccess, error) {
+	if !c.flavor.TeamAccess {
+		return nil, errNoTeamAccess(c.flavor)
+	}
+	// GET /repos/{owner}/{repo}/teams
+	apiObjs, err := c.c.ListRepoTeams(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	teamAccess := make([]gitprovider.TeamAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListRepoTeams
+		teamAccess = append(teamAccess, newTeamAccess(c, teamAccessFromAPI(apiObj)))
+	}
+
+	return teamAccess, nil
+}
+
+// Create adds a given team to the repository's team access control list.
+// As the permission of Gitea teams is set for the whole organization, ErrNoProviderSupport is
+// returned if the requested permission differs from the permission of the team.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamAccessClient) Create(ctx context.Context, req gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	if !c.flavor.TeamAccess {
+		return nil, errNoTeamAccess(c.flavor)
+	}
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// GET /orgs/{org}/teams
+	team, err := c.getOrgTeam(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	actual := teamAccessFromAPI(team)
+	if *actual.Permission != *req.Permission {
+		return nil, fmt.Errorf("team %q has the %q permission on all repositories, can't grant %q: %w",
+			req.Name, *actual.Permission, *req.Permission, gitprovider.ErrNoProviderSupport)
+	}
+
+	// PUT /repos/{owner}/{repo}/teams/{team}
+	if err := c.c.AddRepoTeam(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Name); err != nil {
+		return nil, err
+	}
+
+	return newTeamAccess(c, actual), nil
+}
+
+// getOrgTeam returns the team with the given name in the organization owning the repository.
+func (c *TeamAccessClient) getOrgTeam(ctx context.Context, name string) (*gitea.Team, error) {
+	// GET /orgs/{org}/teams
+	apiObjs, err := c.c.ListOrgTeams(ctx, c.ref.GetIdentity())
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Name == name {
+			return apiObj, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q in organization %q: %w", name, c.ref.GetIdentity(), gitprovider.ErrNotFound)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.TeamAccessInfo,
+) (gitprovider.TeamAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+func errNoTeamAccess(flavor *Flavor) error {
+	return fmt.Errorf("%s has no API for the teams of a repository: %w", flavor.ProviderID, gitprovider.ErrNoProviderSupport)
+}
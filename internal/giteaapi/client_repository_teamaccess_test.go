@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+)
+
+func TestTeamAccessClient_Reconcile(t *testing.T) {
+	orgTeams := []*gitea.Team{
+		{ID: 1, Name: "devs", Permission: gitea.AccessModeRead},
+		{ID: 2, Name: "ops", Permission: gitea.AccessModeWrite},
+	}
+	repoTeams := map[string]bool{"devs": true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/orgs/flux/teams", func(w http.ResponseWriter, r *http.Request) {
+		testutil.WriteJSON(w, http.StatusOK, orgTeams)
+	})
+	mux.HandleFunc("/api/v1/repos/flux/repo/teams/", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path[len("/api/v1/repos/flux/repo/teams/"):]
+		switch r.Method {
+		case http.MethodGet:
+			for _, team := range orgTeams {
+				if team.Name == name && repoTeams[name] {
+					testutil.WriteJSON(w, http.StatusOK, team)
+					return
+				}
+			}
+			testutil.WriteJSON(w, http.StatusNotFound, map[string]string{"message": "The target couldn't be found."})
+		case http.MethodPut:
+			repoTeams[name] = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	c := newTestClient(t, mux)
+	tas := &TeamAccessClient{
+		clientContext: c.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		req             gitprovider.TeamAccessInfo
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name: "unchanged",
+			req:  gitprovider.TeamAccessInfo{Name: "devs"},
+		},
+		{
+			name:            "created",
+			req:             gitprovider.TeamAccessInfo{Name: "ops", Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush)},
+			wantActionTaken: true,
+		},
+		{
+			name:            "permission differs from the team",
+			req:             gitprovider.TeamAccessInfo{Name: "devs", Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionAdmin)},
+			wantActionTaken: true,
+			wantErr:         gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:            "unknown team",
+			req:             gitprovider.TeamAccessInfo{Name: "qa"},
+			wantActionTaken: true,
+			wantErr:         gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, actionTaken, err := tas.Reconcile(ctx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+		})
+	}
+	want := map[string]bool{"devs": true, "ops": true}
+	if !reflect.DeepEqual(repoTeams, want) {
+		t.Errorf("repository teams = %v, want %v", repoTeams, want)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Flavor describes a server speaking the Gitea API, and which parts of it the server serves. The
+// clients of this package are shared by Gitea and Gogs, whose API Gitea grew out of.
+type Flavor struct {
+	// ProviderID is the provider ID reported by the clients.
+	ProviderID gitprovider.ProviderID
+
+	// APIDocURL points to the documentation of the API, and is part of the HTTP errors.
+	APIDocURL string
+
+	// EditRepositories is true if the settings of repositories can be changed using the API, i.e.
+	// if "PATCH /repos/{owner}/{repo}" is served.
+	EditRepositories bool
+
+	// TeamAccess is true if the teams of repositories can be managed using the API, i.e. if the
+	// "/repos/{owner}/{repo}/teams" endpoints are served.
+	TeamAccess bool
+
+	// WritableDeployKeys is true if deploy keys can be given write access.
+	WritableDeployKeys bool
+
+	// LicenseTemplates maps the license templates to the names of the licenses the server ships
+	// with.
+	LicenseTemplates map[gitprovider.LicenseTemplate]string
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// giteaClient is a wrapper around *gitea.Client, which implements higher-level methods,
+// operating on the gitea structs. Pagination is implemented for all List* methods, all returned
+// objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+// This interface is also fakeable, in order to unit-test the client.
+type giteaClient interface {
+	// Client returns the underlying *gitea.Client
+	Client() *gitea.Client
+
+	// GetOrg is a wrapper for "GET /orgs/{org}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetOrg(ctx context.Context, orgName string) (*gitea.Organization, error)
+	// ListOrgs is a wrapper for "GET /user/orgs".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgs(ctx context.Context) ([]*gitea.Organization, error)
+
+	// GetUser is a wrapper for "GET /users/{username}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUser(ctx context.Context, username string) (*gitea.User, error)
+
+	// GetRepo is a wrapper for "GET /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, owner, repo string) (*gitea.Repository, error)
+	// ListOrgRepos is a wrapper for "GET /orgs/{org}/repos".
+	// progress is called after each page, if non-nil.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgRepos(ctx context.Context, org string, progress gitprovider.ListProgressFunc) ([]*gitea.Repository, error)
+	// ListUserRepos is a wrapper for "GET /users/{username}/repos".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListUserRepos(ctx context.Context, username string) ([]*gitea.Repository, error)
+	// CreateRepo is a wrapper for "POST /user/repos" (if orgName == "")
+	// or "POST /org/{org}/repos" (if orgName != "").
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, orgName string, req *gitea.CreateRepoOption) (*gitea.Repository, error)
+	// UpdateRepo is a wrapper for "PATCH /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, owner, repo string, req *gitea.EditRepoOption) (*gitea.Repository, error)
+	// DeleteRepo is a wrapper for "DELETE /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, owner, repo string) error
+
+	// GetBranch is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, owner, repo, branch string) (*gitea.Branch, error)
+
+	// ListKeys is a wrapper for "GET /repos/{owner}/{repo}/keys".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListKeys(ctx context.Context, owner, repo string) ([]*gitea.DeployKey, error)
+	// CreateKey is a wrapper for "POST /repos/{owner}/{repo}/keys".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateKey(ctx context.Context, owner, repo string, req *gitea.CreateKeyOption) (*gitea.DeployKey, error)
+	// DeleteKey is a wrapper for "DELETE /repos/{owner}/{repo}/keys/{id}".
+	// This function handles HTTP error wrapping.
+	DeleteKey(ctx context.Context, owner, repo string, id int64) error
+
+	// ListOrgTeams is a wrapper for "GET /orgs/{org}/teams".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgTeams(ctx context.Context, orgName string) ([]*gitea.Team, error)
+	// ListRepoTeams is a wrapper for "GET /repos/{owner}/{repo}/teams".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListRepoTeams(ctx context.Context, owner, repo string) ([]*gitea.Team, error)
+	// GetRepoTeam is a wrapper for "GET /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepoTeam(ctx context.Context, owner, repo, teamName string) (*gitea.Team, error)
+	// AddRepoTeam is a wrapper for "PUT /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping.
+	AddRepoTeam(ctx context.Context, owner, repo, teamName string) error
+	// RemoveRepoTeam is a wrapper for "DELETE /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping.
+	RemoveRepoTeam(ctx context.Context, owner, repo, teamName string) error
+}
+
+// giteaClientImpl is a wrapper around *gitea.Client, which implements higher-level methods,
+// operating on the gitea structs. As the gitea SDK sets the context client-wide, a client is
+// created from baseURL and opts for each call, instead of sharing one between goroutines.
+type giteaClientImpl struct {
+	c                  *gitea.Client
+	baseURL            string
+	opts               []gitea.ClientOption
+	destructiveActions bool
+	// apiDocURL is part of the HTTP errors, see Flavor.APIDocURL
+	apiDocURL string
+}
+
+// giteaClientImpl implements giteaClient.
+var _ giteaClient = &giteaClientImpl{}
+
+func (c *giteaClientImpl) Client() *gitea.Client {
+	return c.c
+}
+
+// client returns a *gitea.Client sending its requests with ctx.
+func (c *giteaClientImpl) client(ctx context.Context) (*gitea.Client, error) {
+	opts := make([]gitea.ClientOption, 0, len(c.opts)+1)
+	opts = append(opts, c.opts...)
+	opts = append(opts, gitea.SetContext(ctx))
+	return gitea.NewClient(c.baseURL, opts...)
+}
+
+func (c *giteaClientImpl) GetOrg(ctx context.Context, orgName string) (*gitea.Organization, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /orgs/{org}
+	apiObj, resp, err := gc.GetOrg(orgName)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateOrganizationAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListOrgs(ctx context.Context) ([]*gitea.Organization, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*gitea.Organization{}
+	opts := gitea.ListOrgsOptions{}
+	err = c.allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /user/orgs
+		pageObjs, resp, listErr := gc.ListMyOrgs(opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateOrganizationAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetUser(ctx context.Context, username string) (*gitea.User, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /users/{username}
+	apiObj, resp, err := gc.GetUserInfo(username)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) GetRepo(ctx context.Context, owner, repo string) (*gitea.Repository, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}
+	apiObj, resp, err := gc.GetRepo(owner, repo)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListOrgRepos(ctx context.Context, org string, progress gitprovider.ListProgressFunc) ([]*gitea.Repository, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*gitea.Repository{}
+	opts := gitea.ListOrgReposOptions{}
+	err = c.allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /orgs/{org}/repos
+		pageObjs, resp, listErr := gc.ListOrgRepos(org, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		if listErr == nil && progress != nil {
+			progress(listProgress(resp, len(apiObjs)))
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListUserRepos(ctx context.Context, username string) ([]*gitea.Repository, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*gitea.Repository{}
+	opts := gitea.ListReposOptions{}
+	err = c.allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /users/{username}/repos
+		pageObjs, resp, listErr := gc.ListUserRepos(username, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) CreateRepo(ctx context.Context, orgName string, req *gitea.CreateRepoOption) (*gitea.Repository, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var apiObj *gitea.Repository
+	var resp *gitea.Response
+	if orgName == "" {
+		// POST /user/repos
+		apiObj, resp, err = gc.CreateRepo(*req)
+	} else {
+		// POST /org/{org}/repos
+		apiObj, resp, err = gc.CreateOrgRepo(orgName, *req)
+	}
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) UpdateRepo(ctx context.Context, owner, repo string, req *gitea.EditRepoOption) (*gitea.Repository, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// PATCH /repos/{owner}/{repo}
+	apiObj, resp, err := gc.EditRepo(owner, repo, *req)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) DeleteRepo(ctx context.Context, owner, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	gc, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	// DELETE /repos/{owner}/{repo}
+	resp, err := gc.DeleteRepo(owner, repo)
+	return c.handleHTTPError(resp, err)
+}
+
+func (c *giteaClientImpl) GetBranch(ctx context.Context, owner, repo, branch string) (*gitea.Branch, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, resp, err := gc.GetRepoBranch(owner, repo, branch)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]*gitea.DeployKey, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*gitea.DeployKey{}
+	opts := gitea.ListDeployKeysOptions{}
+	err = c.allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/keys
+		pageObjs, resp, listErr := gc.ListDeployKeys(owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) CreateKey(ctx context.Context, owner, repo string, req *gitea.CreateKeyOption) (*gitea.DeployKey, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/keys
+	apiObj, resp, err := gc.CreateDeployKey(owner, repo, *req)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// Validate the API object
+	if err := validateDeployKeyAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) DeleteKey(ctx context.Context, owner, repo string, id int64) error {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	// DELETE /repos/{owner}/{repo}/keys/{id}
+	resp, err := gc.DeleteDeployKey(owner, repo, id)
+	return c.handleHTTPError(resp, err)
+}
+
+func (c *giteaClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]*gitea.Team, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*gitea.Team{}
+	opts := gitea.ListTeamsOptions{}
+	err = c.allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /orgs/{org}/teams
+		pageObjs, resp, listErr := gc.ListOrgTeams(orgName, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateTeamAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListRepoTeams(ctx context.Context, owner, repo string) ([]*gitea.Team, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}/teams
+	apiObjs, resp, err := gc.GetRepoTeams(owner, repo)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateTeamAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetRepoTeam(ctx context.Context, owner, repo, teamName string) (*gitea.Team, error) {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}/teams/{team}
+	apiObj, resp, err := gc.CheckRepoTeam(owner, repo, teamName)
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err)
+	}
+	// The SDK doesn't treat teams without access as an error
+	if apiObj == nil {
+		return nil, fmt.Errorf("team %q has no access to %s/%s: %w", teamName, owner, repo, gitprovider.ErrNotFound)
+	}
+	// Validate the API object
+	if err := validateTeamAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) AddRepoTeam(ctx context.Context, owner, repo, teamName string) error {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	// PUT /repos/{owner}/{repo}/teams/{team}
+	resp, err := gc.AddRepoTeam(owner, repo, teamName)
+	return c.handleHTTPError(resp, err)
+}
+
+func (c *giteaClientImpl) RemoveRepoTeam(ctx context.Context, owner, repo, teamName string) error {
+	gc, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	// DELETE /repos/{owner}/{repo}/teams/{team}
+	resp, err := gc.RemoveRepoTeam(owner, repo, teamName)
+	return c.handleHTTPError(resp, err)
+}
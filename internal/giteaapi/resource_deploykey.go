@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newDeployKey(c *DeployKeyClient, key *gitea.DeployKey) *deployKey {
+	return &deployKey{
+		k: *key,
+		c: c,
+	}
+}
+
+var _ gitprovider.DeployKey = &deployKey{}
+
+type deployKey struct {
+	k gitea.DeployKey
+	c *DeployKeyClient
+}
+
+func (dk *deployKey) Get() gitprovider.DeployKeyInfo {
+	return deployKeyFromAPI(&dk.k)
+}
+
+func (dk *deployKey) Set(info gitprovider.DeployKeyInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployKeyInfoToAPIObj(dk.c.flavor, &info, &dk.k)
+}
+
+func (dk *deployKey) APIObject() interface{} {
+	return &dk.k
+}
+
+// LastUsedAt always returns nil, as Gitea doesn't report when a deploy key was last used.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return nil
+}
+
+func (dk *deployKey) Repository() gitprovider.RepositoryRef {
+	return dk.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dk *deployKey) Update(ctx context.Context) error {
+	// Delete the old key and recreate, as Gitea deploy keys can't be edited
+	if err := dk.Delete(ctx); err != nil {
+		return err
+	}
+	return dk.createIntoSelf(ctx)
+}
+
+// Delete deletes a deploy key from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dk *deployKey) Delete(ctx context.Context) error {
+	// We can use the same DeployKey ID that we got from the GET calls. Make sure it's set.
+	// This _should never_ happen, but just check for it anyways to avoid deleting the wrong key.
+	if dk.k.ID == 0 {
+		return fmt.Errorf("didn't expect ID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	// DELETE /repos/{owner}/{repo}/keys/{id}
+	return dk.c.c.DeleteKey(ctx, dk.c.ref.GetIdentity(), dk.c.ref.GetRepository(), dk.k.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dk *deployKey) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dk.c.get(ctx, dk.k.Title)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, dk.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if dk.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, dk.Update(ctx)
+}
+
+func (dk *deployKey) createIntoSelf(ctx context.Context) error {
+	// POST /repos/{owner}/{repo}/keys
+	apiObj, err := dk.c.c.CreateKey(ctx, dk.c.ref.GetIdentity(), dk.c.ref.GetRepository(), &gitea.CreateKeyOption{
+		Title:    dk.k.Title,
+		Key:      dk.k.Key,
+		ReadOnly: dk.k.ReadOnly,
+	})
+	if err != nil {
+		return err
+	}
+	dk.k = *apiObj
+	return nil
+}
+
+func validateDeployKeyAPI(apiObj *gitea.DeployKey) error {
+	return validateAPIObject("Gitea.DeployKey", func(validator validation.Validator) {
+		// Make sure ID, title and key fields are populated
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Title == "" {
+			validator.Required("Title")
+		}
+		if apiObj.Key == "" {
+			validator.Required("Key")
+		}
+	})
+}
+
+func deployKeyFromAPI(apiObj *gitea.DeployKey) gitprovider.DeployKeyInfo {
+	return gitprovider.DeployKeyInfo{
+		Name:     apiObj.Title,
+		Key:      []byte(apiObj.Key),
+		ReadOnly: gitprovider.BoolVar(apiObj.ReadOnly),
+	}
+}
+
+func deployKeyToAPI(flavor *Flavor, info *gitprovider.DeployKeyInfo) (*gitea.CreateKeyOption, error) {
+	k := &gitea.DeployKey{}
+	if err := deployKeyInfoToAPIObj(flavor, info, k); err != nil {
+		return nil, err
+	}
+	return &gitea.CreateKeyOption{
+		Title:    k.Title,
+		Key:      k.Key,
+		ReadOnly: k.ReadOnly,
+	}, nil
+}
+
+// deployKeyInfoToAPIObj applies info to apiObj. ErrNoProviderSupport is returned for deploy keys
+// with write access if the deploy keys of the server are always read-only, like the Gogs ones.
+func deployKeyInfoToAPIObj(flavor *Flavor, info *gitprovider.DeployKeyInfo, apiObj *gitea.DeployKey) error {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Title = info.Name
+	apiObj.Key = string(info.Key)
+	// optional fields
+	if info.ReadOnly != nil {
+		if !*info.ReadOnly && !flavor.WritableDeployKeys {
+			return fmt.Errorf("%s deploy keys are always read-only: %w", flavor.ProviderID, gitprovider.ErrNoProviderSupport)
+		}
+		apiObj.ReadOnly = *info.ReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// alreadyExistsMagicStrings are part of the messages Gitea returns when creating a repository
+// or deploy key that already exists.
+//
+//nolint:gochecknoglobals
+var alreadyExistsMagicStrings = []string{"already exists", "already been added", "has been used"}
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for Gitea's usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Gitea's usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Gitea's usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for Gitea's usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser:
+		return nil
+	case gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("the gitea api doesn't support sub-organizations: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// handleHTTPError checks the response of a failed call, and returns typed variants of err.
+// The gitea SDK only returns the message of error responses, hence resp is needed for the status.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func (c *giteaClientImpl) handleHTTPError(resp *gitea.Response, err error) error {
+	// Short-circuit quickly if possible, allow always piping through this function
+	if err == nil {
+		return nil
+	}
+	// Errors before a response was received, e.g. connection errors, are returned as-is
+	if resp == nil || resp.Response == nil {
+		return err
+	}
+	httpErr := gitprovider.HTTPError{
+		Response:         resp.Response,
+		ErrorMessage:     fmt.Sprintf("%d %s", resp.StatusCode, err.Error()),
+		Message:          err.Error(),
+		DocumentationURL: c.apiDocURL,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		// Check for invalid credentials, and return a typed error in that case
+		return &gitprovider.InvalidCredentialsError{HTTPError: httpErr}
+	case http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case http.StatusNotFound:
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		// Gitea doesn't tell the limits, only that they have been exceeded
+		return &gitprovider.RateLimitError{HTTPError: httpErr}
+	case http.StatusConflict, http.StatusUnprocessableEntity:
+		// Check for already exists errors
+		for _, s := range alreadyExistsMagicStrings {
+			if strings.Contains(httpErr.Message, s) {
+				return validation.NewMultiError(&httpErr, gitprovider.ErrAlreadyExists)
+			}
+		}
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// allPages runs fn for each page, expecting a HTTP request to be made and returned during that call.
+// allPages expects that the data is saved in fn to an outer variable.
+// allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *giteaClientImpl) allPages(ctx context.Context, opts *gitea.ListOptions, fn func() (*gitea.Response, error)) error {
+	opts.PageSize = gitprovider.CallOptionsFromContext(ctx).GetPerPage(opts.PageSize)
+	for {
+		resp, err := fn()
+		if err != nil {
+			return c.handleHTTPError(resp, err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		// Stop early if the caller gave up in the meantime
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listProgress returns the progress after count items have been listed, with resp being the
+// response to the latest page. The total is taken from the X-Total-Count header Gitea sets.
+func listProgress(resp *gitea.Response, count int) gitprovider.ListProgress {
+	progress := gitprovider.ListProgress{Count: count}
+	if resp.NextPage == 0 {
+		progress.EstimatedTotal = count
+	} else if total, err := strconv.Atoi(resp.Header.Get("X-Total-Count")); err == nil {
+		progress.EstimatedTotal = total
+	}
+	return progress
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that aren't implemented by this package yet, or
+// that the Gitea API doesn't serve.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't supported: %w", feature, gitprovider.ErrNoProviderSupport)
+}
+
+// NormalizeDomain validates the given domain and strips any trailing slashes from it.
+func NormalizeDomain(domain string) (string, error) {
+	u, err := url.Parse(gitprovider.GetDomainURL(domain))
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("domain %q has no host: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("domain %q must not contain a query or fragment: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	return strings.TrimRight(domain, "/"), nil
+}
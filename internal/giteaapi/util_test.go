@@ -0,0 +1,221 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package giteaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const testDomain = "gitea.com"
+
+// testFlavor is a flavor serving the whole API, like Gitea.
+var testFlavor = &Flavor{
+	ProviderID:         "gitea",
+	APIDocURL:          "https://docs.example.com/api",
+	EditRepositories:   true,
+	TeamAccess:         true,
+	WritableDeployKeys: true,
+}
+
+// newTestClient returns a client for a fake Gitea API served by mux.
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	srv := testutil.NewServer(t, mux)
+	c, err := NewClient(testFlavor, srv.URL, []gitea.ClientOption{gitea.SetHTTPClient(srv.Client())}, testDomain, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func Test_handleHTTPError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		message      string
+		expectedErrs []error
+		// unexpectedErr mustn't be part of the error
+		unexpectedErr error
+	}{
+		{
+			name:         "401 => InvalidCredentialsError",
+			status:       http.StatusUnauthorized,
+			message:      "token is required",
+			expectedErrs: []error{&gitprovider.InvalidCredentialsError{}},
+		},
+		{
+			name:         "403 => InvalidCredentialsError & ErrForbidden",
+			status:       http.StatusForbidden,
+			message:      "token does not have at least one of required scope(s): [read:repository]",
+			expectedErrs: []error{&validation.MultiError{}, &gitprovider.InvalidCredentialsError{}, gitprovider.ErrForbidden},
+		},
+		{
+			name:         "404 => ErrNotFound",
+			status:       http.StatusNotFound,
+			message:      "The target couldn't be found.",
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrNotFound},
+		},
+		{
+			name:         "429 => RateLimitError",
+			status:       http.StatusTooManyRequests,
+			expectedErrs: []error{&gitprovider.RateLimitError{}},
+		},
+		{
+			name:         "409 for existing repository => ErrAlreadyExists",
+			status:       http.StatusConflict,
+			message:      "The repository with the same name already exists.",
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+		},
+		{
+			name:         "422 for used deploy key => ErrAlreadyExists",
+			status:       http.StatusUnprocessableEntity,
+			message:      "Key content has been used as non-deploy key",
+			expectedErrs: []error{&validation.MultiError{}, gitprovider.ErrAlreadyExists},
+		},
+		{
+			name:          "409 for other conflicts => HTTPError",
+			status:        http.StatusConflict,
+			message:       "repository is archived",
+			expectedErrs:  []error{&gitprovider.HTTPError{}},
+			unexpectedErr: gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/repos/flux/repo", func(w http.ResponseWriter, r *http.Request) {
+				testutil.WriteJSON(w, tt.status, map[string]string{"message": tt.message})
+			})
+			c := newTestClient(t, mux)
+
+			_, err := c.c.GetRepo(context.Background(), "flux", "repo")
+			validation.TestExpectErrors(t, "handleHTTPError", err, tt.expectedErrs...)
+			if tt.unexpectedErr != nil && errors.Is(err, tt.unexpectedErr) {
+				t.Errorf("handleHTTPError() error = %v, didn't want %v", err, tt.unexpectedErr)
+			}
+		})
+	}
+}
+
+func Test_handleHTTPError_documentationURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/flux/missing", func(w http.ResponseWriter, r *http.Request) {
+		testutil.WriteJSON(w, http.StatusNotFound, map[string]string{"message": "The target couldn't be found."})
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.c.GetRepo(context.Background(), "flux", "missing")
+	httpErr := &gitprovider.HTTPError{}
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("GetRepo() error = %v, want an HTTPError", err)
+	}
+	// The errors point to the API documentation of the flavor, which differs between Gitea and Gogs
+	if httpErr.DocumentationURL != testFlavor.APIDocURL {
+		t.Errorf("DocumentationURL = %q, want %q", httpErr.DocumentationURL, testFlavor.APIDocURL)
+	}
+}
+
+func Test_allPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		totalCount   bool
+		wantProgress []gitprovider.ListProgress
+	}{
+		{
+			name:         "total reported, like Gitea",
+			totalCount:   true,
+			wantProgress: []gitprovider.ListProgress{{Count: 2, EstimatedTotal: 3}, {Count: 3, EstimatedTotal: 3}},
+		},
+		{
+			name:         "total not reported, like Gogs",
+			wantProgress: []gitprovider.ListProgress{{Count: 2}, {Count: 3, EstimatedTotal: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := []string{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/orgs/flux/repos", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("limit"); got != "2" {
+					t.Errorf("limit = %q, want 2", got)
+				}
+				page := r.URL.Query().Get("page")
+				pages = append(pages, page)
+				if tt.totalCount {
+					w.Header().Set("X-Total-Count", "3")
+				}
+				if page == "2" {
+					testutil.WriteJSON(w, http.StatusOK, []map[string]string{{"name": "c"}})
+					return
+				}
+				// Gitea links to the next page, which is followed using the page number
+				w.Header().Set("Link", `<http://`+r.Host+`/api/v1/orgs/flux/repos?limit=2&page=2>; rel="next"`)
+				testutil.WriteJSON(w, http.StatusOK, []map[string]string{{"name": "a"}, {"name": "b"}})
+			})
+			c := newTestClient(t, mux)
+
+			perPage := 2
+			ctx := gitprovider.WithOptions(context.Background(), gitprovider.CallOptions{PerPage: &perPage})
+			progress := []gitprovider.ListProgress{}
+			repos, err := c.OrgRepositories().ListWithProgress(ctx,
+				gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"},
+				func(p gitprovider.ListProgress) { progress = append(progress, p) })
+			if err != nil {
+				t.Fatalf("ListWithProgress() error = %v", err)
+			}
+			if len(repos) != 3 {
+				t.Errorf("ListWithProgress() returned %d repositories, want 3", len(repos))
+			}
+			if want := []string{"1", "2"}; !reflect.DeepEqual(pages, want) {
+				t.Errorf("requested pages %v, want %v", pages, want)
+			}
+			if !reflect.DeepEqual(progress, tt.wantProgress) {
+				t.Errorf("progress = %v, want %v", progress, tt.wantProgress)
+			}
+		})
+	}
+}
+
+func Test_allPages_error(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/orgs/flux/repos", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("page") == "2" {
+			testutil.WriteJSON(w, http.StatusNotFound, map[string]string{"message": "The target couldn't be found."})
+			return
+		}
+		w.Header().Set("Link", `<http://`+r.Host+`/api/v1/orgs/flux/repos?page=2>; rel="next"`)
+		testutil.WriteJSON(w, http.StatusOK, []map[string]string{{"name": "a"}})
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.OrgRepositories().List(context.Background(), gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"})
+	validation.TestExpectErrors(t, "List", err, &validation.MultiError{}, gitprovider.ErrNotFound)
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
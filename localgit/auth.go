@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// DefaultDomain specifies the default domain used in the references of the repositories.
+const DefaultDomain = "localhost"
+
+// NewClient creates a new gitprovider.Client instance operating on the bare Git repositories
+// below the given root directory, which must exist. No network access is needed, which makes
+// this client useful for air-gapped environments and integration tests.
+//
+// The repositories are stored as "<root>/<owner>/<repo>.git", where the owner is the path of
+// an organization including its sub-organizations, e.g. "org/sub", or the login of a user.
+// The directories don't tell organizations and users apart, hence both can be used to access
+// the same repositories. The path of a repository can be used as clone URL, e.g. by the
+// go-git library or the git command line.
+//
+// The domain of the references defaults to "localhost", and can be customized using
+// WithDomain. Options related to HTTP, e.g. the transport hooks, are ignored.
+func NewClient(root string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root directory: %v: %w", err, gitprovider.ErrInvalidClientOptions)
+	}
+	if fi, err := os.Stat(root); err != nil || !fi.IsDir() {
+		return nil, fmt.Errorf("root %q must be an existing directory: %w", root, gitprovider.ErrInvalidClientOptions)
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = *opts.Domain
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(root, domain, destructiveActions), nil
+}
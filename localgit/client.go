@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for local Git repositories.
+const ProviderID = gitprovider.ProviderID("localgit")
+
+func newClient(root, domain string, destructiveActions bool) *Client {
+	ctx := &clientContext{root, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	root               string
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain used in the references of the repositories, e.g. "localhost".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "localgit".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the absolute path of the root directory holding the repositories.
+func (c *Client) Raw() interface{} {
+	return c.root
+}
+
+// RepositoryPath returns the absolute path of the given repository, which can be used as its
+// clone URL.
+func (c *Client) RepositoryPath(ref gitprovider.RepositoryRef) (string, error) {
+	return repositoryPath(c.root, ref)
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeOrganization if a directory for the given owner exists, as the
+// directories don't tell organizations and users apart.
+func (c *Client) GetOwnerType(_ context.Context, owner string) (gitprovider.OwnerType, error) {
+	if _, err := getDirectory(c.root, owner); err != nil {
+		return "", err
+	}
+	return gitprovider.OwnerTypeOrganization, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as local repositories can't be starred.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as there are no license templates
+// for local repositories.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as there are no .gitignore
+// templates for local repositories.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as local repositories are accessed
+// without a token.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as local repositories are accessed without
+// a token.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as local repositories are accessed without
+// a token.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"path"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the directories holding repositories, which are exposed as
+// organizations.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get returns the directory of the given organization, e.g. "<root>/org/sub" for a
+// sub-organization.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationsClient) Get(_ context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := getDirectory(c.root, ref.GetIdentity())
+	if err != nil {
+		return nil, err
+	}
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// List returns the top-level directories of the root directory.
+func (c *OrganizationsClient) List(_ context.Context) ([]gitprovider.Organization, error) {
+	apiObjs, err := listDirectories(c.root, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		orgs = append(orgs, newOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: apiObj.Name,
+		}))
+	}
+	return orgs, nil
+}
+
+// Children returns the directories in the directory of the given organization, which aren't
+// repositories.
+//
+// ErrNotFound is returned if the organization does not exist.
+func (c *OrganizationsClient) Children(_ context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObjs, err := listDirectories(c.root, ref.GetIdentity())
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		subOrgs := append(append([]string{}, ref.SubOrganizations...), path.Base(apiObj.Name))
+		orgs = append(orgs, newOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:           c.domain,
+			Organization:     ref.Organization,
+			SubOrganizations: subOrgs,
+		}))
+	}
+	return orgs, nil
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as directories have no
+// default repository permission.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as directories have no
+// default repository permission.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as directories
+// have no default repository permission.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
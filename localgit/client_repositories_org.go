@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient operates on the repositories in the directory of an organization.
+type OrgRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Get(_ context.Context, ref gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	// Make sure the OrgRepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	apiObj, err := getRepository(c.root, ref)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, ref), nil
+}
+
+// List all repositories in the directory of the given organization.
+//
+// ErrNotFound is returned if the organization does not exist.
+func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given organization like List, and calls
+// progress once with the total, as the repositories are listed at once.
+func (c *OrgRepositoriesClient) ListWithProgress(_ context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	dir, err := getDirectory(c.root, ref.GetIdentity())
+	if err != nil {
+		return nil, err
+	}
+	apiObjs, err := listRepositories(dir.Path)
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		progress(gitprovider.ListProgress{Count: len(apiObjs), EstimatedTotal: len(apiObjs)})
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  repositoryName(apiObj),
+		}))
+	}
+	return repos, nil
+}
+
+// SearchPage always returns ErrNoProviderSupport, as searching repositories isn't implemented yet.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, errNotImplemented("searching repositories")
+}
+
+// Create creates a bare repository in the directory of the given organization, with the data
+// and options. The directory is created if it doesn't exist yet.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrgRepositoriesClient) Create(_ context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, initialCommitSHA, err := createRepository(c.clientContext, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repo := newOrgRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+// Transfer always returns ErrNoProviderSupport, as transferring repositories isn't implemented yet.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, errNotImplemented("transferring repositories")
+}
+
+// getRepository opens the repository of the given ref.
+//
+// ErrNotFound is returned if the repository does not exist.
+func getRepository(root string, ref gitprovider.RepositoryRef) (*Repository, error) {
+	p, err := repositoryPath(root, ref)
+	if err != nil {
+		return nil, err
+	}
+	return openRepository(p)
+}
+
+// createRepository initializes the bare repository, and returns it together with the SHA of
+// the initial commit if the AutoInit option is set. The initial commit adds a README.md file
+// to the default branch.
+func createRepository(c *clientContext, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, *string, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.LicenseTemplate != nil {
+		return nil, nil, fmt.Errorf("local repositories have no license templates: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.VerifyVisibility != nil {
+		return nil, nil, fmt.Errorf("option VerifyVisibility isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if _, isOrg := ref.(gitprovider.OrgRepositoryRef); isOrg && o.CheckOrgMembership != nil && *o.CheckOrgMembership {
+		return nil, nil, fmt.Errorf("option CheckOrgMembership isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	p, err := repositoryPath(c.root, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiObj := &Repository{Path: p}
+	if err := repositoryInfoToAPIObj(&req, apiObj); err != nil {
+		return nil, nil, err
+	}
+	if err := initRepository(apiObj); err != nil {
+		return nil, nil, err
+	}
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil, nil
+	}
+
+	readme := gitprovider.CommitFile{
+		Path:    gitprovider.StringVar("README.md"),
+		Content: gitprovider.StringVar(fmt.Sprintf("# %s\n", ref.GetRepository())),
+	}
+	commit, err := commitFiles(apiObj.Git, apiObj.DefaultBranch, "Initial commit", []gitprovider.CommitFile{readme}, c.signature(nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiObj, gitprovider.StringVar(commit.Hash.String()), nil
+}
+
+// signature returns the signature of commits created by the client, for the given author. The
+// default author is "localgit", with an e-mail address in the domain of the client.
+func (c *clientContext) signature(author *gitprovider.CommitAuthor) object.Signature {
+	if author == nil {
+		author = &gitprovider.CommitAuthor{Name: string(ProviderID), Email: string(ProviderID) + "@" + c.domain}
+	}
+	return object.Signature{Name: author.Name, Email: author.Email, When: time.Now()}
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
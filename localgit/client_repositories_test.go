@@ -0,0 +1,315 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newTestClient returns a client for a fresh root directory.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	c, err := NewClient(t.TempDir(), gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.(*Client)
+}
+
+func TestOrgRepositoriesClient_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux", SubOrganizations: []string{"team"}},
+		RepositoryName:  "repo",
+	}
+	req := gitprovider.RepositoryInfo{Description: gitprovider.StringVar("desc")}
+
+	repo, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req, &gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() didn't create the repository")
+	}
+	if !isRepository(filepath.Join(c.root, "flux", "team", "repo.git")) {
+		t.Error("Reconcile() didn't create the bare repository")
+	}
+	if repo.(*orgRepository).InitialCommitSHA() == nil {
+		t.Error("Reconcile() didn't set the initial commit")
+	}
+
+	// Reading the repository back yields the defaulted request
+	repo, err = c.OrgRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar("desc"),
+		DefaultBranch: gitprovider.StringVar("main"),
+		Visibility:    gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+	if got := repo.Get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	// A second reconcile is a no-op, a changed description is updated
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	req.Description = gitprovider.StringVar("other")
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want update", actionTaken, err)
+	}
+	repo, err = c.OrgRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := *repo.Get().Description; got != "other" {
+		t.Errorf("Description = %q, want %q", got, "other")
+	}
+
+	// The repository is listed in its directory, which is a child of the organization
+	repos, err := c.OrgRepositories().List(ctx, ref.OrganizationRef)
+	if err != nil || len(repos) != 1 || repos[0].Repository().GetRepository() != "repo" {
+		t.Errorf("List() = %v, %v, want [repo]", repos, err)
+	}
+	children, err := c.Organizations().Children(ctx, gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux"})
+	if err != nil || len(children) != 1 || children[0].Organization().GetIdentity() != "flux/team" {
+		t.Errorf("Children() = %v, %v, want [flux/team]", children, err)
+	}
+
+	if err := repo.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := c.OrgRepositories().Get(ctx, ref); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUserRepositoriesClient_Create(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	ref := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+
+	if _, err := c.UserRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := c.UserRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want ErrAlreadyExists", err)
+	}
+	public := gitprovider.RepositoryInfo{Visibility: gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)}
+	ref.RepositoryName = "public"
+	if _, err := c.UserRepositories().Create(ctx, ref, public); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+	if _, err := os.Stat(filepath.Join(c.root, "user", "public.git")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Create() created the unsupported repository, stat error = %v", err)
+	}
+}
+
+func TestRepositoryPath(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name    string
+		ref     gitprovider.RepositoryRef
+		want    string
+		wantErr error
+	}{
+		{
+			name: "sub-organization",
+			ref: gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "flux", SubOrganizations: []string{"team"}},
+				RepositoryName:  "repo",
+			},
+			want: filepath.Join(c.root, "flux", "team", "repo.git"),
+		},
+		{
+			name: "parent directory as organization",
+			ref: gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: ".."},
+				RepositoryName:  "repo",
+			},
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+		{
+			name: "parent directory as repository",
+			ref: gitprovider.UserRepositoryRef{
+				UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "user"},
+				RepositoryName: "..",
+			},
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.RepositoryPath(tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RepositoryPath() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	ref := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	repo, err := c.UserRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The first commit creates the branch of the empty repository
+	first, err := repo.Commits().Create(ctx, "main", "first", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("readme")},
+		{Path: gitprovider.StringVar("deploy/app.yaml"), Content: gitprovider.StringVar("app")},
+		{Path: gitprovider.StringVar("deploy/base/kustomization.yaml"), Content: gitprovider.StringVar("base")},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	author := &gitprovider.CommitAuthor{Name: "Flux", Email: "flux@example.com"}
+	second, err := repo.Commits().Create(ctx, "main", "second", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("README.md")},
+	}, &gitprovider.CommitCreateOptions{Author: author})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := second.Get().Author; got != "Flux" {
+		t.Errorf("Author = %q, want %q", got, "Flux")
+	}
+	if _, err := repo.Commits().Create(ctx, "other", "third", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("readme")},
+	}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Create() error = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.Commits().Create(ctx, "main", "escape", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("../outside"), Content: gitprovider.StringVar("")},
+	}); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Create() error = %v, want ErrInvalidArgument", err)
+	}
+
+	commits, err := repo.Commits().ListPage(ctx, "main", 10, 1)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Get().Sha != second.Get().Sha || commits[1].Get().Sha != first.Get().Sha {
+		t.Errorf("ListPage() = %v, want [second first]", commits)
+	}
+
+	// The deleted file is gone, the files in the sub-directory are kept
+	if _, err := repo.Files().Get(ctx, "README.md", "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	files, err := repo.Files().Get(ctx, "deploy", "main")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(files) != 1 || *files[0].Path != "deploy/app.yaml" || *files[0].Content != "app" {
+		t.Errorf("Get() = %v, want [deploy/app.yaml]", files)
+	}
+	files, err = repo.Files().Get(ctx, "deploy", "main", &gitprovider.FilesGetOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("Get() returned %d files, want 2", len(files))
+	}
+
+	// The first commit still has the README
+	r, err := repo.Files().GetReader(ctx, "README.md", first.Get().Sha)
+	if err != nil {
+		t.Fatalf("GetReader() error = %v", err)
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil || string(content) != "readme" {
+		t.Errorf("GetReader() = %q, %v, want %q", content, err, "readme")
+	}
+
+	if err := repo.Branches().Create(ctx, "feature", first.Get().Sha); err != nil {
+		t.Fatalf("Branches().Create() error = %v", err)
+	}
+	if err := repo.Branches().Create(ctx, "feature", first.Get().Sha); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Branches().Create() error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestBranchClient_Rename(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	ref := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	repo, err := c.UserRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{}, &gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	sha := *repo.(*userRepository).InitialCommitSHA()
+	if err := repo.Branches().Create(ctx, "dev", sha); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Renaming the default branch moves HEAD along with it
+	if err := repo.Branches().Rename(ctx, "main", "trunk"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if err := repo.Branches().Rename(ctx, "main", "other"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Rename() error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Branches().Rename(ctx, "dev", "trunk"); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Rename() error = %v, want ErrAlreadyExists", err)
+	}
+	if err := repo.Branches().Delete(ctx, "dev"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	branches, err := repo.Branches().List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []gitprovider.BranchInfo{{Name: "trunk", Sha: sha}}
+	if !reflect.DeepEqual(branches, want) {
+		t.Errorf("List() = %v, want %v", branches, want)
+	}
+	repo, err = c.UserRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := *repo.Get().DefaultBranch; got != "trunk" {
+		t.Errorf("DefaultBranch = %q, want %q", got, "trunk")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchClient implements the gitprovider.BranchClient interface.
+var _ gitprovider.BranchClient = &BranchClient{}
+
+// BranchClient operates on the branches of a specific repository.
+type BranchClient struct {
+	path string
+}
+
+// Create creates a branch pointing to the commit with the given SHA.
+//
+// ErrAlreadyExists is returned if the branch already exists, and ErrNotFound if the commit
+// does not exist.
+func (c *BranchClient) Create(_ context.Context, branch, sha string) error {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	if _, err := repo.Git.Reference(name, false); err == nil {
+		return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrAlreadyExists)
+	}
+	commit, err := repo.Git.CommitObject(plumbing.NewHash(sha))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return fmt.Errorf("commit %q: %w", sha, gitprovider.ErrNotFound)
+		}
+		return err
+	}
+	return repo.Git.Storer.SetReference(plumbing.NewHashReference(name, commit.Hash))
+}
+
+// List lists all branches of the repository.
+func (c *BranchClient) List(_ context.Context) ([]gitprovider.BranchInfo, error) {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := repo.Git.Branches()
+	if err != nil {
+		return nil, err
+	}
+	branches := []gitprovider.BranchInfo{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		branches = append(branches, gitprovider.BranchInfo{
+			Name: ref.Name().Short(),
+			Sha:  ref.Hash().String(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// Delete deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Delete(_ context.Context, branch string) error {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	if _, err := repo.Git.Reference(name, false); err != nil {
+		return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	return repo.Git.Storer.RemoveReference(name)
+}
+
+// Rename renames the given branch to newName, and points HEAD to newName if it pointed to the
+// old branch.
+//
+// ErrNotFound is returned if the branch does not exist, and ErrAlreadyExists if newName does.
+func (c *BranchClient) Rename(_ context.Context, branch, newName string) error {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	ref, err := repo.Git.Reference(name, false)
+	if err != nil {
+		return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	newRefName := plumbing.NewBranchReferenceName(newName)
+	if _, err := repo.Git.Reference(newRefName, false); err == nil {
+		return fmt.Errorf("branch %q: %w", newName, gitprovider.ErrAlreadyExists)
+	}
+	if err := repo.Git.Storer.SetReference(plumbing.NewHashReference(newRefName, ref.Hash())); err != nil {
+		return err
+	}
+	if repo.DefaultBranch == branch {
+		if err := repo.Git.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, newRefName)); err != nil {
+			return err
+		}
+	}
+	return repo.Git.Storer.RemoveReference(name)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits of a specific repository.
+type CommitClient struct {
+	*clientContext
+	path string
+}
+
+// ListPage lists the commits of the given page and page size reachable from the branch, newest
+// first. The pages start at 1.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := repo.Git.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+		}
+		return nil, err
+	}
+	iter, err := repo.Git.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	skip := 0
+	if page > 1 {
+		skip = (page - 1) * perPage
+	}
+	commits := make([]gitprovider.Commit, 0, perPage)
+	for len(commits) < perPage {
+		apiObj, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		commits = append(commits, newCommit(apiObj))
+	}
+	return commits, nil
+}
+
+// Create creates a commit on the branch, which applies the given files to the tree of the
+// latest commit. Files with nil content are deleted. The branch is created if the repository
+// is empty. The commit is authored and committed by the Author option, or "localgit" by default.
+//
+// ErrNotFound is returned if the branch does not exist in a repository with commits.
+func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	o, err := gitprovider.MakeCommitCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.ProviderSigned != nil && *o.ProviderSigned {
+		return nil, fmt.Errorf("local repositories can't sign commits: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return nil, err
+	}
+	apiObj, err := commitFiles(repo.Git, branch, message, files, c.signature(o.Author))
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(apiObj), nil
+}
+
+// ListComments always returns ErrNoProviderSupport, as local repositories have no commit comments.
+func (c *CommitClient) ListComments(_ context.Context, _ string) ([]gitprovider.CommitComment, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func newCommit(apiObj *object.Commit) *commitType {
+	return &commitType{
+		k: *apiObj,
+	}
+}
+
+var _ gitprovider.Commit = &commitType{}
+
+type commitType struct {
+	k object.Commit
+}
+
+func (c *commitType) Get() gitprovider.CommitInfo {
+	return commitFromAPI(&c.k)
+}
+
+func (c *commitType) APIObject() interface{} {
+	return &c.k
+}
+
+// commitFromAPI maps the commit. Local commits have no URL, and their signatures aren't verified.
+func commitFromAPI(apiObj *object.Commit) gitprovider.CommitInfo {
+	return gitprovider.CommitInfo{
+		Sha:         apiObj.Hash.String(),
+		TreeSha:     apiObj.TreeHash.String(),
+		Author:      apiObj.Author.Name,
+		AuthorEmail: apiObj.Author.Email,
+		Message:     apiObj.Message,
+		CreatedAt:   apiObj.Author.When,
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the files of a specific repository.
+type FileClient struct {
+	path string
+}
+
+// Get returns the file at the given path on the branch, or the files in the directory at the
+// path. The files in sub-directories are only returned with the Recursive option.
+//
+// ErrNotFound is returned if the branch or path does not exist.
+func (c *FileClient) Get(_ context.Context, filePath, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+
+	tree, err := c.tree(branch)
+	if err != nil {
+		return nil, err
+	}
+	dirPath := strings.Trim(filePath, "/")
+	if dirPath != "" {
+		file, err := tree.File(dirPath)
+		if err == nil {
+			commitFile, err := fileFromAPI(dirPath, file)
+			if err != nil {
+				return nil, err
+			}
+			return []*gitprovider.CommitFile{commitFile}, nil
+		}
+		if !errors.Is(err, object.ErrFileNotFound) {
+			return nil, err
+		}
+		if tree, err = tree.Tree(dirPath); err != nil {
+			if errors.Is(err, object.ErrDirectoryNotFound) {
+				return nil, fmt.Errorf("path %q: %w", filePath, gitprovider.ErrNotFound)
+			}
+			return nil, err
+		}
+	}
+
+	files := make([]*gitprovider.CommitFile, 0)
+	add := func(file *object.File) error {
+		commitFile, err := fileFromAPI(path.Join(dirPath, file.Name), file)
+		if err != nil {
+			return err
+		}
+		files = append(files, commitFile)
+		return nil
+	}
+	if fileOpts.Recursive {
+		if err := tree.Files().ForEach(add); err != nil {
+			return nil, err
+		}
+		return files, nil
+	}
+	for i := range tree.Entries {
+		if !tree.Entries[i].Mode.IsFile() {
+			continue
+		}
+		file, err := tree.TreeEntryFile(&tree.Entries[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := add(file); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// GetReader streams the contents of the file at path on the given ref, which can be a branch,
+// tag or commit SHA. The caller is responsible for closing the returned reader.
+//
+// ErrNotFound is returned if the ref or file does not exist.
+func (c *FileClient) GetReader(_ context.Context, filePath, ref string) (io.ReadCloser, error) {
+	tree, err := c.tree(ref)
+	if err != nil {
+		return nil, err
+	}
+	file, err := tree.File(strings.Trim(filePath, "/"))
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, fmt.Errorf("file %q: %w", filePath, gitprovider.ErrNotFound)
+		}
+		return nil, err
+	}
+	return file.Reader()
+}
+
+// tree returns the tree of the commit the given revision resolves to.
+func (c *FileClient) tree(rev string) (*object.Tree, error) {
+	repo, err := openRepository(c.path)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := repo.Git.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, fmt.Errorf("revision %q: %w", rev, gitprovider.ErrNotFound)
+		}
+		return nil, err
+	}
+	commit, err := repo.Git.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// fileFromAPI reads the contents of the file, which is located at filePath.
+func fileFromAPI(filePath string, file *object.File) (*gitprovider.CommitFile, error) {
+	content, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return &gitprovider.CommitFile{
+		Path:    gitprovider.StringVar(filePath),
+		Content: gitprovider.StringVar(content),
+	}, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// LabelClient implements the gitprovider.LabelClient interface.
+var _ gitprovider.LabelClient = &LabelClient{}
+
+// LabelClient handles issue labels, which are not available for local repositories.
+type LabelClient struct{}
+
+// Get always returns ErrNoProviderSupport, as local repositories have no issue tracker.
+func (c *LabelClient) Get(_ context.Context, _ string) (gitprovider.Label, error) {
+	return nil, errNotImplemented("reading labels")
+}
+
+// List always returns ErrNoProviderSupport, as local repositories have no issue tracker.
+func (c *LabelClient) List(_ context.Context) ([]gitprovider.Label, error) {
+	return nil, errNotImplemented("listing labels")
+}
+
+// Create always returns ErrNoProviderSupport, as local repositories have no issue tracker.
+func (c *LabelClient) Create(_ context.Context, _ gitprovider.LabelInfo) (gitprovider.Label, error) {
+	return nil, errNotImplemented("creating labels")
+}
+
+// Reconcile always returns ErrNoProviderSupport, as local repositories have no issue tracker.
+func (c *LabelClient) Reconcile(_ context.Context, _ gitprovider.LabelInfo, _ ...gitprovider.LabelReconcileOption) (gitprovider.Label, bool, error) {
+	return nil, false, errNotImplemented("reconciling labels")
+}
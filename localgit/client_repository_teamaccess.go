@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient handles the permissions of teams for a specific repository, which are not
+// available for local repositories.
+type TeamAccessClient struct{}
+
+// Get always returns ErrNoProviderSupport, as directories have no teams.
+func (c *TeamAccessClient) Get(_ context.Context, _ string) (gitprovider.TeamAccess, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as directories have no teams.
+func (c *TeamAccessClient) List(_ context.Context) ([]gitprovider.TeamAccess, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as directories have no teams.
+func (c *TeamAccessClient) Create(_ context.Context, _ gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as directories have no teams.
+func (c *TeamAccessClient) Reconcile(_ context.Context, _ gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.RepositoryRef) *userRepository {
+	return &userRepository{
+		clientContext:      ctx,
+		r:                  *apiObj,
+		ref:                ref,
+		deployKeys:         &DeployKeyClient{},
+		deployTokens:       &DeployTokenClient{},
+		labels:             &LabelClient{},
+		milestones:         &MilestoneClient{},
+		releases:           &ReleaseClient{},
+		webhooks:           &WebhookClient{},
+		branchProtections:  &BranchProtectionClient{},
+		tagProtections:     &TagProtectionClient{},
+		commits:            &CommitClient{clientContext: ctx, path: apiObj.Path},
+		branches:           &BranchClient{path: apiObj.Path},
+		pullRequests:       &PullRequestClient{},
+		files:              &FileClient{path: apiObj.Path},
+		trees:              &TreeClient{},
+		actionsPermissions: &ActionsPermissionsClient{},
+	}
+}
+
+var _ gitprovider.UserRepository = &userRepository{}
+
+type userRepository struct {
+	*clientContext
+
+	r   Repository
+	ref gitprovider.RepositoryRef
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
+}
+
+func (r *userRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return repositoryInfoToAPIObj(&info, &r.r)
+}
+
+func (r *userRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *userRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// InitialCommitSHA returns the SHA of the initial commit, if the repository was created with AutoInit.
+func (r *userRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
+}
+
+func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *userRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *userRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *userRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
+func (r *userRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *userRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *userRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *userRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *userRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+// Update writes the description and default branch of the repository.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *userRepository) Update(_ context.Context) error {
+	if _, err := openRepository(r.r.Path); err != nil {
+		return err
+	}
+	return writeRepository(&r.r)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := openRepository(r.r.Path)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, initRepository(&r.r)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if r.r.Description == actual.Description && r.r.DefaultBranch == actual.DefaultBranch {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	r.r.Git = actual.Git
+	return true, r.Update(ctx)
+}
+
+// SetDefaultBranch points HEAD of the repository to the given branch.
+//
+// ErrNotFound is returned if the repository or the branch doesn't exist.
+func (r *userRepository) SetDefaultBranch(_ context.Context, branch string) error {
+	actual, err := openRepository(r.r.Path)
+	if err != nil {
+		return err
+	}
+	if _, err := actual.Git.Reference(plumbing.NewBranchReferenceName(branch), false); err != nil {
+		return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	actual.DefaultBranch = branch
+	if err := writeRepository(actual); err != nil {
+		return err
+	}
+	r.r.DefaultBranch = branch
+	return nil
+}
+
+// Delete removes the directory of the repository irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *userRepository) Delete(_ context.Context) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !r.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if !isRepository(r.r.Path) {
+		return fmt.Errorf("repository %q: %w", r.r.Path, gitprovider.ErrNotFound)
+	}
+	return os.RemoveAll(r.r.Path)
+}
+
+// Star always returns ErrNoProviderSupport, as local repositories can't be starred.
+func (r *userRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as local repositories can't be starred.
+func (r *userRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as local repositories can't be starred.
+func (r *userRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as local repositories can't be watched.
+func (r *userRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as local repositories can't be watched.
+func (r *userRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as local repositories have no issue tracker.
+func (r *userRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, gitprovider.ErrNoProviderSupport
+}
+
+func newOrgRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.RepositoryRef) *orgRepository {
+	return &orgRepository{
+		userRepository: *newUserRepository(ctx, apiObj, ref),
+		teamAccess:     &TeamAccessClient{},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	userRepository
+
+	teamAccess *TeamAccessClient
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// repositoryFromAPI maps the repository, which is always private, as it can only be accessed
+// through the file system.
+func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
+	return gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar(apiObj.Description),
+		DefaultBranch: gitprovider.StringVar(apiObj.DefaultBranch),
+		Visibility:    gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+}
+
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings local repositories don't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) error {
+	if repo.Description != nil {
+		apiObj.Description = *repo.Description
+	}
+	if repo.DefaultBranch != nil {
+		apiObj.DefaultBranch = *repo.DefaultBranch
+	}
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityPrivate {
+		return fmt.Errorf("local repositories are always private, %q isn't supported: %w", *repo.Visibility, gitprovider.ErrNoProviderSupport)
+	}
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("local repositories don't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("local repositories don't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
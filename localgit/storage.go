@@ -0,0 +1,329 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// defaultDescription is the content of the "description" file written by "git init", which
+// means that the repository has no description.
+const defaultDescription = "Unnamed repository; edit this file 'description' to name the repository."
+
+// isRepository returns whether the directory at path is a bare repository.
+func isRepository(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// getDirectory returns the directory with the given name, e.g. "org/sub".
+// ErrNotFound is returned if it doesn't exist, or is a repository.
+func getDirectory(root, name string) (*Directory, error) {
+	dir, err := directoryPath(root, name)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() || isRepository(dir) {
+		return nil, fmt.Errorf("directory %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return &Directory{Name: name, Path: dir}, nil
+}
+
+// listDirectories returns the directories directly below the directory with the given name,
+// which aren't repositories. The top-level directories are returned for an empty name.
+func listDirectories(root, name string) ([]*Directory, error) {
+	parent := root
+	if name != "" {
+		d, err := getDirectory(root, name)
+		if err != nil {
+			return nil, err
+		}
+		parent = d.Path
+	}
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		return nil, err
+	}
+	dirs := []*Directory{}
+	for _, entry := range entries {
+		p := filepath.Join(parent, entry.Name())
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || isRepository(p) {
+			continue
+		}
+		dirs = append(dirs, &Directory{Name: path.Join(name, entry.Name()), Path: p})
+	}
+	return dirs, nil
+}
+
+// openRepository opens the bare repository at path.
+// ErrNotFound is returned if it doesn't exist.
+func openRepository(path string) (*Repository, error) {
+	if !isRepository(path) {
+		return nil, fmt.Errorf("repository %q: %w", path, gitprovider.ErrNotFound)
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+	apiObj := &Repository{Path: path, Git: repo}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return nil, err
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		apiObj.DefaultBranch = head.Target().Short()
+	}
+	description, err := os.ReadFile(filepath.Join(path, "description"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if d := strings.TrimSpace(string(description)); d != defaultDescription {
+		apiObj.Description = d
+	}
+	return apiObj, nil
+}
+
+// listRepositories returns the repositories directly in the directory at dir.
+func listRepositories(dir string) ([]*Repository, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	repos := []*Repository{}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), repositorySuffix) || !isRepository(p) {
+			continue
+		}
+		apiObj, err := openRepository(p)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, apiObj)
+	}
+	return repos, nil
+}
+
+// repositoryName returns the name of the repository, i.e. its directory without the ".git" suffix.
+func repositoryName(apiObj *Repository) string {
+	return strings.TrimSuffix(filepath.Base(apiObj.Path), repositorySuffix)
+}
+
+// initRepository creates the bare repository at apiObj.Path, with the description and default
+// branch of apiObj. ErrAlreadyExists is returned if the directory already exists.
+func initRepository(apiObj *Repository) error {
+	if _, err := os.Stat(apiObj.Path); err == nil {
+		return fmt.Errorf("repository %q: %w", apiObj.Path, gitprovider.ErrAlreadyExists)
+	}
+	repo, err := git.PlainInit(apiObj.Path, true)
+	if err != nil {
+		return err
+	}
+	apiObj.Git = repo
+	return writeRepository(apiObj)
+}
+
+// writeRepository writes the description and default branch of apiObj to the repository.
+func writeRepository(apiObj *Repository) error {
+	if err := os.WriteFile(filepath.Join(apiObj.Path, "description"), []byte(apiObj.Description+"\n"), 0o644); err != nil {
+		return err
+	}
+	head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(apiObj.DefaultBranch))
+	return apiObj.Git.Storer.SetReference(head)
+}
+
+// fileEntry is a file of a tree, i.e. a blob with its mode.
+type fileEntry struct {
+	mode filemode.FileMode
+	hash plumbing.Hash
+}
+
+// commitFiles creates a commit on top of branch, which applies the given files to its tree. Files
+// with nil content are deleted. The branch is created if the repository has no commits yet.
+// ErrNotFound is returned if the branch doesn't exist in a repository with commits.
+func commitFiles(repo *git.Repository, branch, message string, files []gitprovider.CommitFile, author object.Signature) (*object.Commit, error) {
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	tree := map[string]fileEntry{}
+	var parents []plumbing.Hash
+
+	ref, err := repo.Storer.Reference(branchRef)
+	switch {
+	case err == nil:
+		parent, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil, err
+		}
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return nil, err
+		}
+		if err := parentTree.Files().ForEach(func(f *object.File) error {
+			tree[f.Name] = fileEntry{f.Mode, f.Hash}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent.Hash)
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// Only empty repositories may get a new branch through a commit
+		empty, err := isEmpty(repo)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+		}
+	default:
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.Path == nil {
+			return nil, fmt.Errorf("file without path: %w", gitprovider.ErrInvalidArgument)
+		}
+		p := path.Clean(strings.TrimPrefix(*file.Path, "/"))
+		if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf("invalid file path %q: %w", *file.Path, gitprovider.ErrInvalidArgument)
+		}
+		if file.Content == nil {
+			delete(tree, p)
+			continue
+		}
+		hash, err := writeBlob(repo.Storer, *file.Content)
+		if err != nil {
+			return nil, err
+		}
+		tree[p] = fileEntry{filemode.Regular, hash}
+	}
+	treeHash, err := writeTree(repo.Storer, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := &object.Commit{
+		Author:       author,
+		Committer:    author,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return nil, err
+	}
+	commitHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, commitHash)); err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(commitHash)
+}
+
+// isEmpty returns whether the repository has no branches.
+func isEmpty(repo *git.Repository) (bool, error) {
+	branches, err := repo.Branches()
+	if err != nil {
+		return false, err
+	}
+	defer branches.Close()
+	if _, err := branches.Next(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+// writeBlob stores content as blob, and returns its hash.
+func writeBlob(s storer.EncodedObjectStorer, content string) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// writeTree stores the tree holding the given files keyed by their path, including its
+// sub-trees, and returns its hash.
+func writeTree(s storer.EncodedObjectStorer, files map[string]fileEntry) (plumbing.Hash, error) {
+	entries := []object.TreeEntry{}
+	subTrees := map[string]map[string]fileEntry{}
+	for p, f := range files {
+		name, rest, ok := strings.Cut(p, "/")
+		if !ok {
+			entries = append(entries, object.TreeEntry{Name: name, Mode: f.mode, Hash: f.hash})
+			continue
+		}
+		if subTrees[name] == nil {
+			subTrees[name] = map[string]fileEntry{}
+		}
+		subTrees[name][rest] = f
+	}
+	for name, subTree := range subTrees {
+		hash, err := writeTree(s, subTree)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git sorts the entries by name, as if the names of trees had a trailing slash
+	sortKey := func(e object.TreeEntry) string {
+		if e.Mode == filemode.Dir {
+			return e.Name + "/"
+		}
+		return e.Name
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortKey(entries[i]) < sortKey(entries[j])
+	})
+
+	obj := s.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
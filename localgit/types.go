@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"github.com/go-git/go-git/v5"
+)
+
+// Directory is a directory below the root which holds repositories, i.e. an organization or
+// the account of a user.
+type Directory struct {
+	// Name is the path of the directory relative to the root, e.g. "org/sub".
+	Name string
+	// Path is the absolute path of the directory.
+	Path string
+}
+
+// Repository is a bare Git repository below the root.
+type Repository struct {
+	// Path is the absolute path of the repository, e.g. "/srv/git/org/repo.git".
+	Path string
+	// Description is the content of the "description" file of the repository.
+	Description string
+	// DefaultBranch is the branch HEAD points to.
+	DefaultBranch string
+
+	// Git is the go-git repository used to access the repository.
+	Git *git.Repository
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localgit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// repositorySuffix is the suffix of the directories of bare repositories.
+const repositorySuffix = ".git"
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for local usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for local usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for local usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for local usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeSuborganization, gitprovider.IdentityTypeUser:
+		return nil
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// directoryPath returns the absolute path of the directory with the given name, e.g. "org/sub".
+// ErrInvalidArgument is returned for names that would escape the root, e.g. "org/..".
+func directoryPath(root, name string) (string, error) {
+	segments := strings.Split(name, "/")
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, `\`+string(filepath.Separator)) {
+			return "", fmt.Errorf("invalid path segment %q in %q: %w", segment, name, gitprovider.ErrInvalidArgument)
+		}
+	}
+	return filepath.Join(append([]string{root}, segments...)...), nil
+}
+
+// repositoryPath returns the absolute path of the bare repository, e.g. "<root>/org/repo.git".
+func repositoryPath(root string, ref gitprovider.RepositoryRef) (string, error) {
+	dir, err := directoryPath(root, ref.GetIdentity())
+	if err != nil {
+		return "", err
+	}
+	name := ref.GetRepository()
+	if strings.Contains(name, "/") {
+		return "", fmt.Errorf("invalid repository name %q: %w", name, gitprovider.ErrInvalidArgument)
+	}
+	if _, err := directoryPath(dir, name); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+repositorySuffix), nil
+}
+
+// errNotImplemented is returned for features that local repositories could support, but which
+// aren't implemented by this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for localgit yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
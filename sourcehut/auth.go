@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend, i.e. the git service of
+	// the hosted sr.ht instance.
+	DefaultDomain = "git.sr.ht"
+	// TokenVariable is the common name for the environment variable
+	// containing a sr.ht personal access token.
+	TokenVariable = "SRHT_TOKEN" // #nosec G101
+)
+
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		Domains:   []string{DefaultDomain},
+		NewClient: newClientFromURL,
+	})
+	gitprovider.RegisterRepositoryVisibility(ProviderID, RepositoryVisibilityUnlisted)
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as credentials.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	return NewClient(gitprovider.TokenFromURL(u), append(opts, gitprovider.WithDomain(domain))...)
+}
+
+// NewClient creates a new gitprovider.Client instance for the sr.ht GraphQL API.
+//
+// The token is a personal access token, which needs read-write access to the git.sr.ht
+// "REPOSITORIES" and the meta.sr.ht "SSH_KEYS" grants. The sr.ht API can't be used without
+// authentication, hence the token is required.
+//
+// A self-hosted sr.ht instance can be used if you specify the domain of its git service using
+// WithDomain, e.g. "git.example.com". SSH keys are managed through the meta service of the
+// instance, whose domain is derived from the one of the git service, e.g. "meta.example.com".
+//
+// sr.ht has no organizations, all repositories are owned by users, e.g. "~user/repo" whose
+// UserLogin is given with or without the "~" prefix. As sr.ht has no deploy keys, the
+// DeployKeyClient of a repository operates on the SSH keys of the authenticated user.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("the sr.ht API requires a token: %w", gitprovider.ErrInvalidClientOptions)
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := opts.BuildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = strings.TrimRight(*opts.Domain, "/")
+	}
+	gitURL, metaURL, err := serviceURLs(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(httpClient, gitURL, metaURL, token, domain, destructiveActions), nil
+}
+
+// serviceURLs returns the URLs of the GraphQL endpoints of the git and meta services, given the
+// domain of the git service, e.g. "https://git.sr.ht/query" and "https://meta.sr.ht/query".
+func serviceURLs(domain string) (*url.URL, *url.URL, error) {
+	gitURL, err := url.Parse(gitprovider.GetDomainURL(domain) + "/query")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if gitURL.RawQuery != "" || gitURL.Fragment != "" || gitURL.Path != "/query" {
+		return nil, nil, fmt.Errorf("domain %q must not contain a path, query or fragment: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	if !strings.HasPrefix(gitURL.Host, "git.") {
+		return nil, nil, fmt.Errorf("domain %q isn't the one of a git.sr.ht service, e.g. %q: %w", domain, DefaultDomain, gitprovider.ErrInvalidClientOptions)
+	}
+	metaURL := *gitURL
+	metaURL.Host = "meta." + strings.TrimPrefix(gitURL.Host, "git.")
+	return gitURL, &metaURL, nil
+}
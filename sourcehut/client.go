@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for SourceHut.
+const ProviderID = gitprovider.ProviderID("sourcehut")
+
+// RepositoryVisibilityUnlisted is the visibility of sr.ht repositories that can be accessed by
+// anyone who knows their URL, but aren't listed on the profile of their owner.
+const RepositoryVisibilityUnlisted = gitprovider.RepositoryVisibility("unlisted")
+
+func newClient(httpClient *http.Client, gitURL, metaURL *url.URL, token, domain string, destructiveActions bool) *Client {
+	srhtClient := &srhtClientImpl{httpClient, gitURL, metaURL, token, destructiveActions}
+	ctx := &clientContext{srhtClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs:          &OrganizationsClient{},
+		orgRepos:      &OrgRepositoriesClient{},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  srhtClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "git.sr.ht".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "sourcehut".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the *http.Client used under the hood for accessing the sr.ht GraphQL API.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// GetOwnerType returns OwnerTypeUser if a user with the given name exists, as sr.ht has no
+// organizations. The name can be given with or without the "~" prefix.
+func (c *Client) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	if _, err := c.c.GetUser(ctx, username(owner)); err != nil {
+		return "", err
+	}
+	return gitprovider.OwnerTypeUser, nil
+}
+
+// ListStarred always returns ErrNoProviderSupport, as sr.ht has no notion of starring repositories.
+func (c *Client) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as sr.ht has no license templates.
+func (c *Client) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as sr.ht has no .gitignore templates.
+func (c *Client) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// HasTokenPermission always returns ErrNoProviderSupport, as sr.ht doesn't expose the grants
+// of the token used.
+func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CheckScopes always returns ErrNoProviderSupport, as sr.ht doesn't expose the grants of the
+// token used.
+func (c *Client) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as sr.ht doesn't expose information about
+// the token used.
+func (c *Client) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
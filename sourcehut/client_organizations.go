@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient handles organizations, which are not available in sr.ht.
+// All repositories are owned by users, use UserRepositoriesClient instead.
+type OrganizationsClient struct{}
+
+// Get always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) Get(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) List(_ context.Context) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Children always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDefaultRepositoryPermission always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetDefaultRepositoryPermission always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileDefaultRepositoryPermission always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.RepositoryPermission) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
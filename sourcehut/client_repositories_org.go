@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient handles repositories owned by organizations, which are not available
+// in sr.ht. All repositories are owned by users, use UserRepositoriesClient instead.
+type OrgRepositoriesClient struct{}
+
+// Get always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) Get(_ context.Context, _ gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) List(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListWithProgress always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) ListWithProgress(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SearchPage always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) Create(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.RepositoryInfo, _ ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) Reconcile(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.RepositoryInfo, _ ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
+
+// Transfer always returns ErrNoProviderSupport, as sr.ht has no organizations.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserRepositoriesClient implements the gitprovider.UserRepositoriesClient interface.
+var _ gitprovider.UserRepositoriesClient = &UserRepositoriesClient{}
+
+// UserRepositoriesClient operates on repositories the user has access to.
+type UserRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *UserRepositoriesClient) Get(ctx context.Context, ref gitprovider.UserRepositoryRef) (gitprovider.UserRepository, error) {
+	// Make sure the UserRepositoryRef is valid
+	if err := validateUserRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	apiObj, err := c.c.GetRepository(ctx, username(ref.UserLogin), ref.RepositoryName)
+	if err != nil {
+		return nil, err
+	}
+	return newUserRepository(c.clientContext, apiObj, ref), nil
+}
+
+// List all repositories owned by the given user, which are visible to the authenticated user.
+//
+// List returns all available repositories, using multiple paginated requests if needed.
+func (c *UserRepositoriesClient) List(ctx context.Context, ref gitprovider.UserRef) ([]gitprovider.UserRepository, error) {
+	// Make sure the UserRef is valid
+	if err := validateUserRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObjs, err := c.c.ListRepositories(ctx, username(ref.UserLogin))
+	if err != nil {
+		return nil, err
+	}
+
+	// Traverse the list, and return a list of UserRepository objects
+	repos := make([]gitprovider.UserRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListRepositories
+		repos = append(repos, newUserRepository(c.clientContext, apiObj, gitprovider.UserRepositoryRef{
+			UserRef:        ref,
+			RepositoryName: apiObj.Name,
+		}))
+	}
+	return repos, nil
+}
+
+// Create creates a repository for the given user, with the data and options.
+// sr.ht only allows creating repositories for the authenticated user. The repositories are
+// created empty, hence the AutoInit and LicenseTemplate options aren't supported, and the
+// default branch is the first branch pushed.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *UserRepositoriesClient) Create(ctx context.Context,
+	ref gitprovider.UserRepositoryRef,
+	req gitprovider.RepositoryInfo,
+	opts ...gitprovider.RepositoryCreateOption,
+) (gitprovider.UserRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateUserRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := createRepository(ctx, c.c, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newUserRepository(c.clientContext, apiObj, ref), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.UserRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.UserRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := validateAndDefaultRepositoryInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+func createRepository(ctx context.Context, c srhtClient, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := validateAndDefaultRepositoryInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.AutoInit != nil && *o.AutoInit {
+		return nil, fmt.Errorf("sr.ht can't initialize repositories: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if o.LicenseTemplate != nil {
+		return nil, fmt.Errorf("sr.ht has no license templates: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	// Convert to the API object, the default branch is left to the first push
+	data := &Repository{}
+	req.DefaultBranch = nil
+	if err := repositoryInfoToAPIObj(&req, data); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := c.CreateRepository(ctx, ref.GetRepository(), data.Visibility, data.Description)
+	if err != nil {
+		return nil, err
+	}
+	gitprovider.VerifyCreatedRepositoryVisibility(ctx, o.VerifyVisibility, ref, req.Visibility, func(ctx context.Context) (*gitprovider.RepositoryVisibility, error) {
+		actual, err := c.GetRepository(ctx, username(ref.GetIdentity()), ref.GetRepository())
+		if err != nil {
+			return nil, err
+		}
+		return repositoryFromAPI(actual).Visibility, nil
+	})
+	return apiObj, nil
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// The default branch of empty repositories is the first branch pushed, and can't be set
+	if actual.Get().DefaultBranch == nil {
+		req.DefaultBranch = nil
+	}
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+// validateAndDefaultRepositoryInfo validates req like gitprovider.ValidateAndDefaultInfo, also
+// accepting the visibilities registered for sr.ht, and defaults it.
+func validateAndDefaultRepositoryInfo(req *gitprovider.RepositoryInfo) error {
+	if err := req.ValidateInfoFor(ProviderID); err != nil {
+		return err
+	}
+	req.Default()
+	return nil
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/testutil"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const testDomain = "git.example.com"
+
+// newTestClient returns a client for a fake sr.ht instance, whose git and meta services are
+// served by git and meta, if non-nil.
+func newTestClient(t *testing.T, git, meta http.HandlerFunc) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	if git != nil {
+		mux.HandleFunc("/git/query", git)
+	}
+	if meta != nil {
+		mux.HandleFunc("/meta/query", meta)
+	}
+	srv := testutil.NewServer(t, mux)
+	gitURL, _ := url.Parse(srv.URL + "/git/query")
+	metaURL, _ := url.Parse(srv.URL + "/meta/query")
+	return newClient(srv.Client(), gitURL, metaURL, "token", testDomain, false)
+}
+
+// decodeRequest decodes the GraphQL request of r.
+func decodeRequest(t *testing.T, r *http.Request) *graphQLRequest {
+	t.Helper()
+	if got := r.Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization header = %q", got)
+	}
+	req := &graphQLRequest{}
+	testutil.ReadJSON(t, r, req)
+	return req
+}
+
+// writeData writes a successful GraphQL response with the given data.
+func writeData(w http.ResponseWriter, data interface{}) {
+	testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// writeErrors writes a GraphQL response with the given error messages.
+func writeErrors(w http.ResponseWriter, messages ...string) {
+	errs := []graphQLError{}
+	for _, m := range messages {
+		errs = append(errs, graphQLError{Message: m})
+	}
+	testutil.WriteJSON(w, http.StatusOK, map[string]interface{}{"data": nil, "errors": errs})
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		domain   string
+		wantMeta string
+		wantErr  error
+	}{
+		{name: "default", wantMeta: "https://meta.sr.ht/query"},
+		{name: "self-hosted", domain: "git.example.com", wantMeta: "https://meta.example.com/query"},
+		{name: "http", domain: "http://git.example.com:8080/", wantMeta: "http://meta.example.com:8080/query"},
+		{name: "not the git service", domain: "example.com", wantErr: gitprovider.ErrInvalidClientOptions},
+		{name: "sub-path", domain: "https://example.com/git", wantErr: gitprovider.ErrInvalidClientOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []gitprovider.ClientOption{}
+			if tt.domain != "" {
+				opts = append(opts, gitprovider.WithDomain(tt.domain))
+			}
+			c, err := NewClient("token", opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewClient() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.(*Client).c.(*srhtClientImpl).metaURL.String(); got != tt.wantMeta {
+				t.Errorf("meta URL = %q, want %q", got, tt.wantMeta)
+			}
+		})
+	}
+
+	if _, err := NewClient(""); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("NewClient() without token error = %v, want %v", err, gitprovider.ErrInvalidClientOptions)
+	}
+}
+
+func TestUserRepositoriesClient_Get(t *testing.T) {
+	git := func(w http.ResponseWriter, r *http.Request) {
+		req := decodeRequest(t, r)
+		if req.Variables["username"] != "flux" {
+			t.Errorf("username = %v, want flux", req.Variables["username"])
+		}
+		if req.Variables["name"] != "repo" {
+			writeData(w, map[string]interface{}{"user": map[string]interface{}{"repository": nil}})
+			return
+		}
+		writeData(w, map[string]interface{}{"user": map[string]interface{}{"repository": map[string]interface{}{
+			"id":          1,
+			"name":        "repo",
+			"description": "desc",
+			"visibility":  "UNLISTED",
+			"HEAD":        map[string]string{"name": "refs/heads/main"},
+		}}})
+	}
+	c := newTestClient(t, git, nil)
+	userRef := gitprovider.UserRef{Domain: testDomain, UserLogin: "~flux"}
+
+	repo, err := c.UserRepositories().Get(context.Background(), gitprovider.UserRepositoryRef{UserRef: userRef, RepositoryName: "repo"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar("desc"),
+		DefaultBranch: gitprovider.StringVar("main"),
+		Visibility:    gitprovider.RepositoryVisibilityVar(RepositoryVisibilityUnlisted),
+	}
+	if got := repo.Get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	_, err = c.UserRepositories().Get(context.Background(), gitprovider.UserRepositoryRef{UserRef: userRef, RepositoryName: "missing"})
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	_, err = c.OrgRepositories().Get(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: testDomain, Organization: "flux"},
+		RepositoryName:  "repo",
+	})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("OrgRepositories().Get() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestUserRepositoriesClient_Reconcile(t *testing.T) {
+	var repo map[string]interface{}
+	updates := 0
+	git := func(w http.ResponseWriter, r *http.Request) {
+		req := decodeRequest(t, r)
+		switch {
+		case strings.Contains(req.Query, "createRepository"):
+			if repo != nil {
+				writeErrors(w, "A repository with this name already exists.")
+				return
+			}
+			repo = map[string]interface{}{
+				"id":          1,
+				"name":        req.Variables["name"],
+				"description": req.Variables["description"],
+				"visibility":  req.Variables["visibility"],
+			}
+			writeData(w, map[string]interface{}{"createRepository": repo})
+		case strings.Contains(req.Query, "updateRepository"):
+			updates++
+			input := req.Variables["input"].(map[string]interface{})
+			if _, ok := input["HEAD"]; ok {
+				t.Errorf("the default branch of an empty repository was updated")
+			}
+			repo["description"] = input["description"]
+			repo["visibility"] = input["visibility"]
+			writeData(w, map[string]interface{}{"updateRepository": repo})
+		default:
+			writeData(w, map[string]interface{}{"user": map[string]interface{}{"repository": repo}})
+		}
+	}
+	c := newTestClient(t, git, nil)
+	ref := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: testDomain, UserLogin: "flux"},
+		RepositoryName: "repo",
+	}
+	info := gitprovider.RepositoryInfo{Description: gitprovider.StringVar("desc")}
+
+	_, actionTaken, err := c.UserRepositories().Reconcile(context.Background(), ref, info)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want created", actionTaken, err)
+	}
+	if repo["visibility"] != "PRIVATE" {
+		t.Errorf("created visibility = %v, want PRIVATE", repo["visibility"])
+	}
+
+	// The repository is empty, hence the default branch must not cause an update
+	_, actionTaken, err = c.UserRepositories().Reconcile(context.Background(), ref, info)
+	if err != nil || actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	info.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)
+	_, actionTaken, err = c.UserRepositories().Reconcile(context.Background(), ref, info)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want updated", actionTaken, err)
+	}
+	if updates != 1 || repo["visibility"] != "PUBLIC" {
+		t.Errorf("updates = %d, visibility = %v, want 1, PUBLIC", updates, repo["visibility"])
+	}
+
+	// The sr.ht specific visibility is only valid for sr.ht
+	info.Visibility = gitprovider.RepositoryVisibilityVar(RepositoryVisibilityUnlisted)
+	_, actionTaken, err = c.UserRepositories().Reconcile(context.Background(), ref, info)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want updated", actionTaken, err)
+	}
+	if updates != 2 || repo["visibility"] != "UNLISTED" {
+		t.Errorf("updates = %d, visibility = %v, want 2, UNLISTED", updates, repo["visibility"])
+	}
+	if err := info.ValidateInfo(); !errors.Is(err, validation.ErrFieldEnumInvalid) {
+		t.Errorf("ValidateInfo() error = %v, want %v", err, validation.ErrFieldEnumInvalid)
+	}
+
+	_, err = c.UserRepositories().Create(context.Background(), ref, info)
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	_, err = c.UserRepositories().Create(context.Background(), ref, info, &gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() with AutoInit error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
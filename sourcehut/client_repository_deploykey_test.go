@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestDeployKeyClient_Reconcile(t *testing.T) {
+	keys := []map[string]interface{}{{"id": 1, "key": "ssh-ed25519 AAAA other", "fingerprint": "SHA256:a", "comment": "other"}}
+	created := []string{}
+	deleted := []float64{}
+	meta := func(w http.ResponseWriter, r *http.Request) {
+		req := decodeRequest(t, r)
+		switch {
+		case strings.Contains(req.Query, "createSSHKey"):
+			key := req.Variables["key"].(string)
+			created = append(created, key)
+			fields := strings.Fields(key)
+			apiObj := map[string]interface{}{"id": len(keys) + 1, "key": key, "fingerprint": "SHA256:b", "comment": strings.Join(fields[2:], " ")}
+			keys = append(keys, apiObj)
+			writeData(w, map[string]interface{}{"createSSHKey": apiObj})
+		case strings.Contains(req.Query, "deleteSSHKey"):
+			id := req.Variables["id"].(float64)
+			deleted = append(deleted, id)
+			for i, key := range keys {
+				if key["id"] == int(id) {
+					keys = append(keys[:i], keys[i+1:]...)
+					break
+				}
+			}
+			writeData(w, map[string]interface{}{"deleteSSHKey": map[string]interface{}{"id": id}})
+		default:
+			writeData(w, map[string]interface{}{"me": map[string]interface{}{"sshKeys": map[string]interface{}{"results": keys, "cursor": nil}}})
+		}
+	}
+	c := newTestClient(t, nil, meta)
+	repo := newUserRepository(c.clientContext, &Repository{ID: 1, Name: "repo"}, gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: testDomain, UserLogin: "flux"},
+		RepositoryName: "repo",
+	})
+	info := gitprovider.DeployKeyInfo{Name: "flux system", Key: []byte("ssh-ed25519 BBBB user@host\n"), ReadOnly: gitprovider.BoolVar(false)}
+
+	_, actionTaken, err := repo.DeployKeys().Reconcile(context.Background(), info)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want created", actionTaken, err)
+	}
+	if len(created) != 1 || created[0] != "ssh-ed25519 BBBB flux system" {
+		t.Errorf("created keys = %q, want the key commented with its name", created)
+	}
+
+	// The comment of the key is replaced by the name, hence it doesn't cause an update
+	_, actionTaken, err = repo.DeployKeys().Reconcile(context.Background(), info)
+	if err != nil || actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	info.Key = []byte("ssh-ed25519 CCCC")
+	_, actionTaken, err = repo.DeployKeys().Reconcile(context.Background(), info)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want recreated", actionTaken, err)
+	}
+	if len(deleted) != 1 || deleted[0] != 2 || len(created) != 2 {
+		t.Errorf("deleted = %v, created = %q, want the key to be recreated", deleted, created)
+	}
+
+	// Keys are read-only by default, which sr.ht doesn't support
+	_, err = repo.DeployKeys().Create(context.Background(), gitprovider.DeployKeyInfo{Name: "read-only", Key: []byte("ssh-ed25519 DDDD")})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
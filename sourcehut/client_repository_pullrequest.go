@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests of a specific repository.
+type PullRequestClient struct{}
+
+// List always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) List(_ context.Context, _ ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Create(_ context.Context, _, _, _, _ string, _ ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Edit always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Edit(_ context.Context, _ int, _ gitprovider.EditOptions) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Get always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Get(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Merge always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Merge(_ context.Context, _ int, _ gitprovider.MergeMethod, _ string, _ ...gitprovider.PullRequestMergeOption) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Update always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Update(_ context.Context, _ int, _ gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Close always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Close(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reopen always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) Reopen(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// MarkReady always returns ErrNoProviderSupport, as sr.ht has no pull requests, patches are sent by email.
+func (c *PullRequestClient) MarkReady(_ context.Context, _ int) (gitprovider.PullRequest, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
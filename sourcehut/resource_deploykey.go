@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newDeployKey(c *DeployKeyClient, key *SSHKey) *deployKey {
+	return &deployKey{
+		k: *key,
+		c: c,
+	}
+}
+
+var _ gitprovider.DeployKey = &deployKey{}
+
+type deployKey struct {
+	k SSHKey
+	c *DeployKeyClient
+}
+
+func (dk *deployKey) Get() gitprovider.DeployKeyInfo {
+	return deployKeyFromAPI(&dk.k)
+}
+
+func (dk *deployKey) Set(info gitprovider.DeployKeyInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployKeyInfoToAPIObj(&info, &dk.k)
+}
+
+func (dk *deployKey) APIObject() interface{} {
+	return &dk.k
+}
+
+// LastUsedAt returns the time the SSH key was last used, which is nil if it was never used.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return dk.k.LastUsed
+}
+
+func (dk *deployKey) Repository() gitprovider.RepositoryRef {
+	return dk.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dk *deployKey) Update(ctx context.Context) error {
+	// Delete the old key and recreate, as sr.ht SSH keys can't be edited
+	if err := dk.Delete(ctx); err != nil {
+		return err
+	}
+	return dk.createIntoSelf(ctx)
+}
+
+// Delete deletes the SSH key from the account of the user.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dk *deployKey) Delete(ctx context.Context) error {
+	// We can use the same SSH key ID that we got from the GET calls. Make sure it's set.
+	// This _should never_ happen, but just check for it anyways to avoid deleting the wrong key.
+	if dk.k.ID == 0 {
+		return fmt.Errorf("didn't expect ID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	return dk.c.c.DeleteSSHKey(ctx, dk.k.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dk *deployKey) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dk.c.get(ctx, dk.Get().Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, dk.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if dk.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, dk.Update(ctx)
+}
+
+func (dk *deployKey) createIntoSelf(ctx context.Context) error {
+	apiObj, err := createDeployKey(ctx, dk.c.c, dk.Get())
+	if err != nil {
+		return err
+	}
+	dk.k = *apiObj
+	return nil
+}
+
+func validateSSHKeyAPI(apiObj *SSHKey) error {
+	return validateAPIObject("SourceHut.SSHKey", func(validator validation.Validator) {
+		// Make sure ID and key fields are populated
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Key == "" {
+			validator.Required("Key")
+		}
+	})
+}
+
+// authorizedKey returns the type and base64-encoded data of the public key in the
+// authorized_keys format, i.e. without its comment, e.g. "ssh-ed25519 AAAA...".
+func authorizedKey(key []byte) string {
+	fields := strings.Fields(string(key))
+	if len(fields) < 2 {
+		return strings.TrimSpace(string(key))
+	}
+	return fields[0] + " " + fields[1]
+}
+
+func deployKeyFromAPI(apiObj *SSHKey) gitprovider.DeployKeyInfo {
+	info := gitprovider.DeployKeyInfo{
+		Key:      []byte(authorizedKey([]byte(apiObj.Key))),
+		ReadOnly: gitprovider.BoolVar(false),
+	}
+	if apiObj.Comment != nil {
+		info.Name = *apiObj.Comment
+	}
+	return info
+}
+
+// deployKeyInfoToAPIObj applies info to apiObj. ErrNoProviderSupport is returned for read-only
+// keys, as sr.ht SSH keys always grant read-write access.
+func deployKeyInfoToAPIObj(info *gitprovider.DeployKeyInfo, apiObj *SSHKey) error {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Comment = gitprovider.StringVar(info.Name)
+	apiObj.Key = authorizedKey(info.Key)
+	// optional fields
+	if info.ReadOnly == nil || *info.ReadOnly {
+		return fmt.Errorf("sr.ht SSH keys always grant read-write access, set ReadOnly to false: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
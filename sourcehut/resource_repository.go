@@ -0,0 +1,374 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// branchRefPrefix is the prefix of the references of branches.
+const branchRefPrefix = "refs/heads/"
+
+func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.RepositoryRef) *userRepository {
+	return &userRepository{
+		clientContext: ctx,
+		r:             *apiObj,
+		ref:           ref,
+		deployKeys: &DeployKeyClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		deployTokens:       &DeployTokenClient{},
+		labels:             &LabelClient{},
+		milestones:         &MilestoneClient{},
+		releases:           &ReleaseClient{},
+		webhooks:           &WebhookClient{},
+		branchProtections:  &BranchProtectionClient{},
+		tagProtections:     &TagProtectionClient{},
+		commits:            &CommitClient{},
+		branches:           &BranchClient{},
+		pullRequests:       &PullRequestClient{},
+		files:              &FileClient{},
+		trees:              &TreeClient{},
+		actionsPermissions: &ActionsPermissionsClient{},
+	}
+}
+
+var _ gitprovider.UserRepository = &userRepository{}
+
+type userRepository struct {
+	*clientContext
+
+	r   Repository
+	ref gitprovider.RepositoryRef
+
+	deployKeys         *DeployKeyClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+	commits            *CommitClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+}
+
+func (r *userRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfoFor(ProviderID); err != nil {
+		return err
+	}
+	return repositoryInfoToAPIObj(&info, &r.r)
+}
+
+func (r *userRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *userRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// InitialCommitSHA always returns nil, as sr.ht repositories are always created empty.
+func (r *userRepository) InitialCommitSHA() *string {
+	return nil
+}
+
+func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *userRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *userRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *userRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
+func (r *userRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *userRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *userRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *userRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *userRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// The default branch of empty repositories can't be set, it is the first branch pushed.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *userRepository) Update(ctx context.Context) error {
+	// The ID is needed to address the repository, make sure it's set.
+	if r.r.ID == 0 {
+		return fmt.Errorf("didn't expect ID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	apiObj, err := r.c.UpdateRepository(ctx, r.r.ID, newRepositorySpec(&r.r))
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
+	apiObj, err := r.c.GetRepository(ctx, username(r.ref.GetIdentity()), r.ref.GetRepository())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			repo, err := r.c.CreateRepository(ctx, r.ref.GetRepository(), r.r.Visibility, r.r.Description)
+			if err != nil {
+				return true, err
+			}
+			r.r = *repo
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+	r.r.ID = apiObj.ID
+	// The default branch of empty repositories is the first branch pushed, and can't be set
+	if apiObj.HEAD == nil {
+		r.r.HEAD = nil
+	}
+
+	// If the desired matches the actual state, do nothing
+	if reflect.DeepEqual(newRepositorySpec(&r.r), newRepositorySpec(apiObj)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, r.Update(ctx)
+}
+
+// SetDefaultBranch points HEAD of the repository to the given branch.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *userRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	// mutation updateRepository
+	apiObj, err := r.c.UpdateRepository(ctx, r.r.ID, &RepositoryInput{HEAD: &branch})
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Delete deletes the current resource irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *userRepository) Delete(ctx context.Context) error {
+	// The ID is needed to address the repository, make sure it's set.
+	// This _should never_ happen, but just check for it anyways to avoid deleting the wrong repository.
+	if r.r.ID == 0 {
+		return fmt.Errorf("didn't expect ID to be unset: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	return r.c.DeleteRepository(ctx, r.r.ID)
+}
+
+// Star always returns ErrNoProviderSupport, as sr.ht has no notion of starring repositories.
+func (r *userRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as sr.ht has no notion of starring repositories.
+func (r *userRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as sr.ht has no notion of starring repositories.
+func (r *userRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as sr.ht has no notion of watching repositories.
+func (r *userRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as sr.ht has no notion of watching repositories.
+func (r *userRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as the sr.ht ticket trackers aren't bound
+// to repositories.
+func (r *userRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, gitprovider.ErrNoProviderSupport
+}
+
+// validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRepositoryAPI(apiObj *Repository) error {
+	return validateAPIObject("SourceHut.Repository", func(validator validation.Validator) {
+		// Make sure ID and name are set, as they're used to reference the repository
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+		if apiObj.Visibility == "" {
+			validator.Required("Visibility")
+		}
+	})
+}
+
+// validateUserAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateUserAPI(apiObj *User) error {
+	return validateAPIObject("SourceHut.User", func(validator validation.Validator) {
+		if apiObj.Username == "" {
+			validator.Required("Username")
+		}
+	})
+}
+
+// visibilities maps the repository visibilities to the ones of sr.ht.
+//
+//nolint:gochecknoglobals
+var visibilities = map[gitprovider.RepositoryVisibility]Visibility{
+	gitprovider.RepositoryVisibilityPublic:  VisibilityPublic,
+	RepositoryVisibilityUnlisted:            VisibilityUnlisted,
+	gitprovider.RepositoryVisibilityPrivate: VisibilityPrivate,
+}
+
+func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{}
+	if apiObj.Description != nil {
+		repo.Description = gitprovider.StringVar(*apiObj.Description)
+	} else {
+		repo.Description = gitprovider.StringVar("")
+	}
+	if apiObj.HEAD != nil {
+		repo.DefaultBranch = gitprovider.StringVar(strings.TrimPrefix(apiObj.HEAD.Name, branchRefPrefix))
+	}
+	for visibility, apiVisibility := range visibilities {
+		if apiObj.Visibility == apiVisibility {
+			repo.Visibility = gitprovider.RepositoryVisibilityVar(visibility)
+		}
+	}
+	return repo
+}
+
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings sr.ht doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) error {
+	if repo.Description != nil {
+		apiObj.Description = gitprovider.StringVar(*repo.Description)
+	}
+	if repo.DefaultBranch != nil {
+		apiObj.HEAD = &Reference{Name: branchRefPrefix + *repo.DefaultBranch}
+	}
+	if repo.Visibility != nil {
+		visibility, ok := visibilities[*repo.Visibility]
+		if !ok {
+			return fmt.Errorf("sr.ht doesn't support the %q visibility for repositories: %w", *repo.Visibility, gitprovider.ErrNoProviderSupport)
+		}
+		apiObj.Visibility = visibility
+	}
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("sr.ht doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("sr.ht doesn't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// newRepositorySpec copies over the fields of the repository that can be set, i.e. the desired
+// spec of the repository. This allows us to separate "spec" from "status" fields.
+func newRepositorySpec(apiObj *Repository) *RepositoryInput {
+	visibility := apiObj.Visibility
+	spec := &RepositoryInput{
+		Description: gitprovider.StringVar(""),
+		Visibility:  &visibility,
+	}
+	if apiObj.Description != nil {
+		spec.Description = gitprovider.StringVar(*apiObj.Description)
+	}
+	if apiObj.HEAD != nil {
+		spec.HEAD = gitprovider.StringVar(strings.TrimPrefix(apiObj.HEAD.Name, branchRefPrefix))
+	}
+	return spec
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// repositoryFields are the fields of the Repository type requested from the API.
+const repositoryFields = `id name description visibility owner { canonicalName } HEAD { name }`
+
+// sshKeyFields are the fields of the SSHKey type requested from the API.
+const sshKeyFields = `id key fingerprint comment created lastUsed`
+
+// srhtClient is a wrapper around the GraphQL APIs of the git.sr.ht and meta.sr.ht services,
+// which implements higher-level methods, operating on the structs in types.go. Users are given
+// by their username, without the "~" prefix. Pagination is implemented for all List* methods,
+// all returned objects are validated, and HTTP and GraphQL errors are handled/wrapped using
+// handleHTTPError and handleGraphQLErrors. This interface is also fakeable, in order to
+// unit-test the client.
+type srhtClient interface {
+	// Client returns the underlying *http.Client
+	Client() *http.Client
+
+	// GetUser is a wrapper for the git.sr.ht "user" query.
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUser(ctx context.Context, username string) (*User, error)
+
+	// GetRepository is a wrapper for the git.sr.ht "user { repository }" query.
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepository(ctx context.Context, username, name string) (*Repository, error)
+	// ListRepositories is a wrapper for the git.sr.ht "user { repositories }" query.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepositories(ctx context.Context, username string) ([]*Repository, error)
+	// CreateRepository is a wrapper for the git.sr.ht "createRepository" mutation, which creates
+	// a repository for the authenticated user.
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepository(ctx context.Context, name string, visibility Visibility, description *string) (*Repository, error)
+	// UpdateRepository is a wrapper for the git.sr.ht "updateRepository" mutation.
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepository(ctx context.Context, id int, req *RepositoryInput) (*Repository, error)
+	// DeleteRepository is a wrapper for the git.sr.ht "deleteRepository" mutation.
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepository(ctx context.Context, id int) error
+
+	// ListSSHKeys is a wrapper for the meta.sr.ht "me { sshKeys }" query, listing the SSH keys
+	// of the authenticated user.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListSSHKeys(ctx context.Context) ([]*SSHKey, error)
+	// CreateSSHKey is a wrapper for the meta.sr.ht "createSSHKey" mutation.
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateSSHKey(ctx context.Context, key string) (*SSHKey, error)
+	// DeleteSSHKey is a wrapper for the meta.sr.ht "deleteSSHKey" mutation.
+	// This function handles HTTP error wrapping.
+	DeleteSSHKey(ctx context.Context, id int) error
+}
+
+// srhtClientImpl is a wrapper around *http.Client, which implements higher-level methods,
+// operating on the structs in types.go.
+type srhtClientImpl struct {
+	c                  *http.Client
+	gitURL             *url.URL
+	metaURL            *url.URL
+	token              string
+	destructiveActions bool
+}
+
+// srhtClientImpl implements srhtClient.
+var _ srhtClient = &srhtClientImpl{}
+
+func (c *srhtClientImpl) Client() *http.Client {
+	return c.c
+}
+
+func (c *srhtClientImpl) GetUser(ctx context.Context, username string) (*User, error) {
+	var data struct {
+		User *User `json:"user"`
+	}
+	if err := c.query(ctx, c.gitURL, `query($username: String!) {
+		user(username: $username) { id canonicalName username }
+	}`, map[string]interface{}{"username": username}, &data); err != nil {
+		return nil, err
+	}
+	if data.User == nil {
+		return nil, fmt.Errorf("user %q: %w", username, gitprovider.ErrNotFound)
+	}
+	if err := validateUserAPI(data.User); err != nil {
+		return nil, err
+	}
+	return data.User, nil
+}
+
+func (c *srhtClientImpl) GetRepository(ctx context.Context, username, name string) (*Repository, error) {
+	var data struct {
+		User *struct {
+			Repository *Repository `json:"repository"`
+		} `json:"user"`
+	}
+	if err := c.query(ctx, c.gitURL, `query($username: String!, $name: String!) {
+		user(username: $username) { repository(name: $name) { `+repositoryFields+` } }
+	}`, map[string]interface{}{"username": username, "name": name}, &data); err != nil {
+		return nil, err
+	}
+	if data.User == nil || data.User.Repository == nil {
+		return nil, fmt.Errorf("repository ~%s/%s: %w", username, name, gitprovider.ErrNotFound)
+	}
+	if err := validateRepositoryAPI(data.User.Repository); err != nil {
+		return nil, err
+	}
+	return data.User.Repository, nil
+}
+
+func (c *srhtClientImpl) ListRepositories(ctx context.Context, username string) ([]*Repository, error) {
+	vars := map[string]interface{}{"username": username}
+	if perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0); perPage > 0 {
+		vars["filter"] = map[string]interface{}{"count": perPage}
+	}
+	apiObjs := []*Repository{}
+	for {
+		var data struct {
+			User *struct {
+				Repositories struct {
+					Results []*Repository `json:"results"`
+					Cursor  *string       `json:"cursor"`
+				} `json:"repositories"`
+			} `json:"user"`
+		}
+		if err := c.query(ctx, c.gitURL, `query($username: String!, $cursor: Cursor, $filter: Filter) {
+			user(username: $username) { repositories(cursor: $cursor, filter: $filter) { results { `+repositoryFields+` } cursor } }
+		}`, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.User == nil {
+			return nil, fmt.Errorf("user %q: %w", username, gitprovider.ErrNotFound)
+		}
+		apiObjs = append(apiObjs, data.User.Repositories.Results...)
+		if data.User.Repositories.Cursor == nil {
+			break
+		}
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vars["cursor"] = *data.User.Repositories.Cursor
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *srhtClientImpl) CreateRepository(ctx context.Context, name string, visibility Visibility, description *string) (*Repository, error) {
+	var data struct {
+		CreateRepository *Repository `json:"createRepository"`
+	}
+	if err := c.query(ctx, c.gitURL, `mutation($name: String!, $visibility: Visibility!, $description: String) {
+		createRepository(name: $name, visibility: $visibility, description: $description) { `+repositoryFields+` }
+	}`, map[string]interface{}{"name": name, "visibility": visibility, "description": description}, &data); err != nil {
+		return nil, err
+	}
+	if data.CreateRepository == nil {
+		return nil, fmt.Errorf("no repository returned when creating %q: %w", name, gitprovider.ErrInvalidServerData)
+	}
+	if err := validateRepositoryAPI(data.CreateRepository); err != nil {
+		return nil, err
+	}
+	return data.CreateRepository, nil
+}
+
+func (c *srhtClientImpl) UpdateRepository(ctx context.Context, id int, req *RepositoryInput) (*Repository, error) {
+	var data struct {
+		UpdateRepository *Repository `json:"updateRepository"`
+	}
+	if err := c.query(ctx, c.gitURL, `mutation($id: Int!, $input: RepoInput!) {
+		updateRepository(id: $id, input: $input) { `+repositoryFields+` }
+	}`, map[string]interface{}{"id": id, "input": req}, &data); err != nil {
+		return nil, err
+	}
+	if data.UpdateRepository == nil {
+		return nil, fmt.Errorf("repository %d: %w", id, gitprovider.ErrNotFound)
+	}
+	if err := validateRepositoryAPI(data.UpdateRepository); err != nil {
+		return nil, err
+	}
+	return data.UpdateRepository, nil
+}
+
+func (c *srhtClientImpl) DeleteRepository(ctx context.Context, id int) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	var data struct {
+		DeleteRepository *struct {
+			ID int `json:"id"`
+		} `json:"deleteRepository"`
+	}
+	if err := c.query(ctx, c.gitURL, `mutation($id: Int!) {
+		deleteRepository(id: $id) { id }
+	}`, map[string]interface{}{"id": id}, &data); err != nil {
+		return err
+	}
+	if data.DeleteRepository == nil {
+		return fmt.Errorf("repository %d: %w", id, gitprovider.ErrNotFound)
+	}
+	return nil
+}
+
+func (c *srhtClientImpl) ListSSHKeys(ctx context.Context) ([]*SSHKey, error) {
+	vars := map[string]interface{}{}
+	apiObjs := []*SSHKey{}
+	for {
+		var data struct {
+			Me struct {
+				SSHKeys struct {
+					Results []*SSHKey `json:"results"`
+					Cursor  *string   `json:"cursor"`
+				} `json:"sshKeys"`
+			} `json:"me"`
+		}
+		if err := c.query(ctx, c.metaURL, `query($cursor: Cursor) {
+			me { sshKeys(cursor: $cursor) { results { `+sshKeyFields+` } cursor } }
+		}`, vars, &data); err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, data.Me.SSHKeys.Results...)
+		if data.Me.SSHKeys.Cursor == nil {
+			break
+		}
+		// Stop early if the caller gave up, instead of requesting the remaining pages
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vars["cursor"] = *data.Me.SSHKeys.Cursor
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateSSHKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *srhtClientImpl) CreateSSHKey(ctx context.Context, key string) (*SSHKey, error) {
+	var data struct {
+		CreateSSHKey *SSHKey `json:"createSSHKey"`
+	}
+	if err := c.query(ctx, c.metaURL, `mutation($key: String!) {
+		createSSHKey(key: $key) { `+sshKeyFields+` }
+	}`, map[string]interface{}{"key": key}, &data); err != nil {
+		return nil, err
+	}
+	if data.CreateSSHKey == nil {
+		return nil, fmt.Errorf("no SSH key returned when creating it: %w", gitprovider.ErrInvalidServerData)
+	}
+	if err := validateSSHKeyAPI(data.CreateSSHKey); err != nil {
+		return nil, err
+	}
+	return data.CreateSSHKey, nil
+}
+
+func (c *srhtClientImpl) DeleteSSHKey(ctx context.Context, id int) error {
+	var data struct {
+		DeleteSSHKey *struct {
+			ID int `json:"id"`
+		} `json:"deleteSSHKey"`
+	}
+	if err := c.query(ctx, c.metaURL, `mutation($id: Int!) {
+		deleteSSHKey(id: $id) { id }
+	}`, map[string]interface{}{"id": id}, &data); err != nil {
+		return err
+	}
+	if data.DeleteSSHKey == nil {
+		return fmt.Errorf("SSH key %d: %w", id, gitprovider.ErrNotFound)
+	}
+	return nil
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of a GraphQL response. Errors are returned with a 200 status.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// graphQLError is an error returned in the body of a GraphQL response.
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// query sends the GraphQL document with the given variables to endpoint, and decodes the data
+// of the response into out. There is no need to wrap the resulting error in handleHTTPError(err)
+// or handleGraphQLErrors(err), as that's already done.
+func (c *srhtClientImpl) query(ctx context.Context, endpoint *url.URL, document string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(&graphQLRequest{Query: document, Variables: variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return handleHTTPError(resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	gqlResp := &graphQLResponse{}
+	if err := json.Unmarshal(data, gqlResp); err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", req.URL.Redacted(), err)
+	}
+	if len(gqlResp.Errors) != 0 {
+		return handleGraphQLErrors(resp, gqlResp.Errors)
+	}
+	if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+		return fmt.Errorf("failed to decode the data of %s: %w", req.URL.Redacted(), err)
+	}
+	return nil
+}
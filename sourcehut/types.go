@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"time"
+)
+
+// Visibility is the visibility of a sr.ht repository.
+type Visibility string
+
+const (
+	// VisibilityPublic is the visibility of repositories that are listed on the profile of
+	// their owner, and can be accessed by anyone.
+	VisibilityPublic = Visibility("PUBLIC")
+	// VisibilityUnlisted is the visibility of repositories that can be accessed by anyone who
+	// knows their URL, but aren't listed on the profile of their owner.
+	VisibilityUnlisted = Visibility("UNLISTED")
+	// VisibilityPrivate is the visibility of repositories that can only be accessed by their
+	// owner and the users they were shared with.
+	VisibilityPrivate = Visibility("PRIVATE")
+)
+
+// User is a user of the git.sr.ht service.
+type User struct {
+	ID            int    `json:"id"`
+	CanonicalName string `json:"canonicalName"`
+	Username      string `json:"username"`
+}
+
+// Entity is the owner of a repository, given by its canonical name, e.g. "~user".
+type Entity struct {
+	CanonicalName string `json:"canonicalName"`
+}
+
+// Reference is a Git reference of a repository.
+type Reference struct {
+	// Name is the full name of the reference, e.g. "refs/heads/main".
+	Name string `json:"name"`
+}
+
+// Repository is a repository of the git.sr.ht service.
+type Repository struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	Visibility  Visibility `json:"visibility"`
+	Owner       *Entity    `json:"owner"`
+	// HEAD is the reference HEAD points to, which is nil for empty repositories.
+	HEAD *Reference `json:"HEAD"`
+}
+
+// RepositoryInput holds the settings of a repository to update, only set fields are changed.
+type RepositoryInput struct {
+	Name        *string     `json:"name,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	Visibility  *Visibility `json:"visibility,omitempty"`
+	// HEAD is the name of the branch HEAD should point to, e.g. "main".
+	HEAD *string `json:"HEAD,omitempty"`
+}
+
+// SSHKey is a SSH key of a user of the meta.sr.ht service, which grants access to all
+// repositories the user has access to.
+type SSHKey struct {
+	ID          int        `json:"id"`
+	Key         string     `json:"key"`
+	Fingerprint string     `json:"fingerprint"`
+	Comment     *string    `json:"comment"`
+	Created     time.Time  `json:"created"`
+	LastUsed    *time.Time `json:"lastUsed"`
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcehut
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const apiDocURL = "https://man.sr.ht/graphql.md"
+
+// alreadyExistsMagicStrings are part of the messages sr.ht returns when creating a repository
+// or SSH key that already exists.
+//
+//nolint:gochecknoglobals
+var alreadyExistsMagicStrings = []string{"already exists", "already registered", "already in use"}
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for sr.ht's usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for sr.ht's usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeUser:
+		return nil
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("sr.ht has no organizations: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// username returns the sr.ht username of the login, which can be given with the "~" prefix of
+// the canonical name, e.g. "~user".
+func username(login string) string {
+	return strings.TrimPrefix(login, "~")
+}
+
+// handleHTTPError reads the error response resp, and returns typed variants of it.
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(resp *http.Response) error {
+	// The error responses of sr.ht are either GraphQL responses or plain text, which is only
+	// used for the message, hence reading a prefix is enough
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	httpErr := gitprovider.HTTPError{
+		Response:         resp,
+		ErrorMessage:     fmt.Sprintf("%s %s: %d %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode, message),
+		Message:          message,
+		DocumentationURL: apiDocURL,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		// Check for invalid credentials, and return a typed error in that case
+		return &gitprovider.InvalidCredentialsError{HTTPError: httpErr}
+	case http.StatusForbidden:
+		// Check for insufficient permissions, and also mark the error as ErrForbidden in that case
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	case http.StatusNotFound:
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		// sr.ht doesn't tell the limits, only that they have been exceeded
+		return &gitprovider.RateLimitError{HTTPError: httpErr}
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// handleGraphQLErrors returns typed variants of the errors of a GraphQL response, which is
+// successful in terms of HTTP. The consumer must use errors.Is and errors.As to check for
+// equality and get data out of it.
+func handleGraphQLErrors(resp *http.Response, errs []graphQLError) error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	message := strings.Join(messages, "; ")
+	httpErr := gitprovider.HTTPError{
+		Response:         resp,
+		ErrorMessage:     fmt.Sprintf("%s %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), message),
+		Message:          message,
+		DocumentationURL: apiDocURL,
+	}
+
+	lower := strings.ToLower(message)
+	// Check for already exists errors
+	for _, s := range alreadyExistsMagicStrings {
+		if strings.Contains(lower, s) {
+			return validation.NewMultiError(&httpErr, gitprovider.ErrAlreadyExists)
+		}
+	}
+	// Objects that don't exist or aren't visible to the user are reported as missing rows
+	if strings.Contains(lower, "no rows") || strings.Contains(lower, "not found") {
+		return validation.NewMultiError(&httpErr, gitprovider.ErrNotFound)
+	}
+	if strings.Contains(lower, "access denied") {
+		return validation.NewMultiError(&gitprovider.InvalidCredentialsError{HTTPError: httpErr}, gitprovider.ErrForbidden)
+	}
+	// Otherwise, return a generic *HTTPError
+	return &httpErr
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// errNotImplemented is returned for features that sr.ht has, but which aren't implemented by
+// this package yet.
+func errNotImplemented(feature string) error {
+	return fmt.Errorf("%s isn't implemented for sourcehut yet: %w", feature, gitprovider.ErrNoProviderSupport)
+}
@@ -24,8 +24,30 @@ import (
 	"github.com/go-logr/logr"
 )
 
+func init() {
+	gitprovider.RegisterProvider(string(ProviderID), gitprovider.ProviderFactory{
+		HostHints: []string{"bitbucket"},
+		NewClient: newClientFromURL,
+	})
+}
+
+// newClientFromURL creates a client for gitprovider.NewClientFromURL, using the user info of
+// the URL as username and token.
+func newClientFromURL(domain string, u *url.URL, opts ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	var username, token string
+	if u.User != nil {
+		username = u.User.Username()
+		token, _ = u.User.Password()
+	}
+	return NewStashClient(username, token, append(opts, gitprovider.WithDomain(gitprovider.GetDomainURL(domain)))...)
+}
+
 // NewStashClient creates a new Client instance for Stash API endpoints.
 // The client accepts a username+token as an argument, which is used to authenticate.
+// When using a client certificate (see gitprovider.WithClientCertificate), the token can be
+// empty, as well as for anonymous clients (see gitprovider.WithAnonymous). Instances behind
+// Kerberos can be used with gitprovider.WithSPNEGO and an empty token too. Git operations can be
+// authenticated using an SSH key instead, see WithSSHKey, which is required for Kerberos.
 // The host name is used to construct the base URL for the Stash API.
 // Variadic parameters gitprovider.ClientOption are used to pass additional options to the gitprovider.Client.
 func NewStashClient(username, token string, optFns ...gitprovider.ClientOption) (*ProviderClient, error) {
@@ -37,7 +59,7 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 	}
 
 	// Create a *http.Client using the transport chain
-	client, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	client, err := opts.BuildHTTPClient()
 	if err != nil {
 		return nil, err
 	}
@@ -58,13 +80,21 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 		return nil, err
 	}
 
-	var stashClient *Client
+	var clientOpts []ClientOptionsFunc
+	// With a client certificate or authentication configured in the transport chain (e.g.
+	// Kerberos), the API requests are authenticated without the token, and it's optional.
+	// Anonymous clients don't need a token either.
+	if token != "" || (opts.ClientCertificate == nil && !opts.HasAuthentication() && !opts.IsAnonymous()) {
+		clientOpts = append(clientOpts, WithAuth(username, token))
+	}
 	if len(opts.CABundle) != 0 {
-		stashClient, err = NewClient(client, host, nil, logger, WithAuth(username, token), WithCABundle(opts.CABundle))
-	} else {
-		stashClient, err = NewClient(client, host, nil, logger, WithAuth(username, token))
+		clientOpts = append(clientOpts, WithCABundle(opts.CABundle))
+	}
+	if key := sshKeyFromOptions(optFns); key != nil {
+		clientOpts = append(clientOpts, WithSSHAuth(key.signer, key.hostKeyCallback))
 	}
 
+	stashClient, err := NewClient(client, host, nil, logger, clientOpts...)
 	if err != nil {
 		return nil, err
 	}
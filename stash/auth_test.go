@@ -17,6 +17,7 @@ limitations under the License.
 package stash
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -60,3 +61,14 @@ func Test_DomainVariations(t *testing.T) {
 		})
 	}
 }
+
+func Test_SPNEGOWithoutToken(t *testing.T) {
+	tokenFn := func(context.Context, string) ([]byte, error) { return []byte("token"), nil }
+	c, err := NewStashClient("", "", gitprovider.WithDomain("stash.example.com"), gitprovider.WithSPNEGO(tokenFn))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.client.HeaderFields.Get("Authorization"); got != "" {
+		t.Errorf("expected no Authorization header, got %q", got)
+	}
+}
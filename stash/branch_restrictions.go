@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	restrictionsURI           = "restrictions"
+	stashURIbranchPermissions = "/rest/branch-permissions/2.0"
+
+	// branchMatcherType matches a single branch by its ref.
+	branchMatcherType = "BRANCH"
+
+	// The restriction types, see the API docs.
+	restrictionReadOnly        = "read-only"
+	restrictionNoDeletes       = "no-deletes"
+	restrictionFastForwardOnly = "fast-forward-only"
+)
+
+// BranchRestrictions interface defines the methods for working with the branch permissions of
+// a repository.
+type BranchRestrictions interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*BranchRestrictionList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*BranchRestriction, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, restriction *BranchRestrictionRequest) (*BranchRestriction, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, restrictionID int) error
+}
+
+// BranchRestrictionsService is a client for communicating with stash branch permissions endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-ref-restriction-rest.html
+type BranchRestrictionsService service
+
+// BranchRestrictionMatcherType is the kind of refs a matcher matches.
+type BranchRestrictionMatcherType struct {
+	// ID is the kind of the matcher, e.g. "BRANCH" or "PATTERN"
+	ID string `json:"id"`
+	// Name is the display name of the kind
+	Name string `json:"name,omitempty"`
+}
+
+// BranchRestrictionMatcher selects the refs a restriction applies to.
+type BranchRestrictionMatcher struct {
+	// ID identifies the refs, e.g. "refs/heads/main" for branch matchers
+	ID string `json:"id"`
+	// DisplayID is the display name of the refs, e.g. "main"
+	DisplayID string `json:"displayId,omitempty"`
+	// Type is the kind of the matcher
+	Type BranchRestrictionMatcherType `json:"type"`
+	// Active specifies whether the matcher is used
+	Active bool `json:"active"`
+}
+
+// BranchRestriction restricts the changes to the matching refs.
+type BranchRestriction struct {
+	// Session is the session object
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the restriction id
+	ID int `json:"id,omitempty"`
+	// Type is the kind of restriction, e.g. "read-only" or "no-deletes"
+	Type string `json:"type"`
+	// Matcher selects the refs the restriction applies to
+	Matcher BranchRestrictionMatcher `json:"matcher"`
+	// Users are exempted from the restriction
+	Users []User `json:"users,omitempty"`
+}
+
+// BranchRestrictionRequest is the request creating a restriction, which takes the names of
+// the exempted users instead of the users.
+type BranchRestrictionRequest struct {
+	// Type is the kind of restriction, e.g. "read-only" or "no-deletes"
+	Type string `json:"type"`
+	// Matcher selects the refs the restriction applies to
+	Matcher BranchRestrictionMatcher `json:"matcher"`
+	// Users are the names of the users exempted from the restriction
+	Users []string `json:"users,omitempty"`
+}
+
+// BranchRestrictionList is a list of branch restrictions
+type BranchRestrictionList struct {
+	Paging
+	BranchRestrictions []*BranchRestriction `json:"values,omitempty"`
+}
+
+// GetBranchRestrictions returns the list of branch restrictions
+func (b *BranchRestrictionList) GetBranchRestrictions() []*BranchRestriction {
+	return b.BranchRestrictions
+}
+
+// List returns the list of branch restrictions of the repository.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a BranchRestrictionList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions".
+func (s *BranchRestrictionsService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*BranchRestrictionList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list branch restrictions request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list branch restrictions failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	restrictions := &BranchRestrictionList{}
+	if err := json.Unmarshal(res, restrictions); err != nil {
+		return nil, fmt.Errorf("list branch restrictions failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, r := range restrictions.GetBranchRestrictions() {
+		r.Session.set(resp)
+	}
+
+	return restrictions, nil
+}
+
+// All retrieves all branch restrictions of the repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *BranchRestrictionsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*BranchRestriction, error) {
+	r := []*BranchRestriction{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, list.GetBranchRestrictions()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Create creates a branch restriction.
+// Create uses the endpoint "POST /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions".
+func (s *BranchRestrictionsService) Create(ctx context.Context, projectKey, repositorySlug string, restriction *BranchRestrictionRequest) (*BranchRestriction, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(restriction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall branch restriction: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create branch restriction request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create branch restriction failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("create branch restriction failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	r := &BranchRestriction{}
+	if err := json.Unmarshal(res, r); err != nil {
+		return nil, fmt.Errorf("create branch restriction failed, unable to unmarshall json: %w", err)
+	}
+
+	r.Session.set(resp)
+
+	return r, nil
+}
+
+// Delete deletes the branch restriction with the given ID.
+// Delete uses the endpoint "DELETE /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions/{id}".
+func (s *BranchRestrictionsService) Delete(ctx context.Context, projectKey, repositorySlug string, restrictionID int) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI, strconv.Itoa(restrictionID)))
+	if err != nil {
+		return fmt.Errorf("delete branch restriction request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete branch restriction failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// newBranchPermissionsURI builds stash branch permissions URI
+func newBranchPermissionsURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIbranchPermissions}, elements...), "/")
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchProtectionClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.BranchProtectionInfo
+		wantRequests    []string
+		wantBody        *BranchRestrictionRequest
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name:         "up to date",
+			req:          gitprovider.BranchProtectionInfo{Branch: "main", AllowedPushers: []string{"Alice"}},
+			wantRequests: []string{"GET"},
+		},
+		{
+			name:            "remove the pushers",
+			req:             gitprovider.BranchProtectionInfo{Branch: "main"},
+			wantRequests:    []string{"GET", "DELETE /3"},
+			wantActionTaken: true,
+		},
+		{
+			name:         "protect an unprotected branch",
+			req:          gitprovider.BranchProtectionInfo{Branch: "dev", AllowedPushers: []string{"bob"}},
+			wantRequests: []string{"GET", "POST", "POST", "POST"},
+			wantBody: &BranchRestrictionRequest{
+				Type: restrictionReadOnly,
+				Matcher: BranchRestrictionMatcher{
+					ID:        "refs/heads/dev",
+					DisplayID: "dev",
+					Type:      BranchRestrictionMatcherType{ID: branchMatcherType},
+					Active:    true,
+				},
+				Users: []string{"bob"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name:         "branch pattern",
+			req:          gitprovider.BranchProtectionInfo{Branch: "release/*"},
+			wantRequests: []string{"GET"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:         "required code owner reviews",
+			req:          gitprovider.BranchProtectionInfo{Branch: "main", RequireCodeOwnerReviews: gitprovider.BoolVar(true)},
+			wantRequests: []string{"GET"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:         "required status checks",
+			req:          gitprovider.BranchProtectionInfo{Branch: "main", RequiredStatusChecks: gitprovider.StatusChecks("ci")},
+			wantRequests: []string{"GET"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var requests []string
+			var body *BranchRestrictionRequest
+			path := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s", stashURIbranchPermissions, projectsURI, RepositoriesURI, restrictionsURI)
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.Path, path)))
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, `{"isLastPage":true,"values":[
+						{"id":1,"type":"no-deletes","matcher":{"id":"refs/heads/main","type":{"id":"BRANCH"},"active":true}},
+						{"id":2,"type":"fast-forward-only","matcher":{"id":"refs/heads/main","type":{"id":"BRANCH"},"active":true}},
+						{"id":3,"type":"read-only","matcher":{"id":"refs/heads/main","type":{"id":"BRANCH"},"active":true},"users":[{"name":"alice"}]},
+						{"id":4,"type":"read-only","matcher":{"id":"release/*","type":{"id":"PATTERN"},"active":true}}
+					]}`)
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					body = &BranchRestrictionRequest{}
+					if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+						t.Error(err)
+					}
+					resp := &BranchRestriction{ID: 5, Type: body.Type, Matcher: body.Matcher}
+					for _, name := range body.Users {
+						resp.Users = append(resp.Users, User{Name: name})
+					}
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(resp)
+				}
+			}
+			mux.HandleFunc(path, handler)
+			mux.HandleFunc(path+"/", handler)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+				RepositoryName:  "my-repo",
+			}
+			ref.SetKey("prj")
+			ref.SetSlug("my-repo")
+			c := &BranchProtectionClient{clientContext: &clientContext{client: client}, ref: ref}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if d := cmp.Diff(tt.wantRequests, requests); d != "" {
+				t.Errorf("Reconcile() requests returned diff (want -> got):\n%s", d)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Reconcile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if d := cmp.Diff(tt.wantBody, body); d != "" {
+				t.Errorf("Reconcile() body returned diff (want -> got):\n%s", d)
+			}
+		})
+	}
+}
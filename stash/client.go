@@ -29,6 +29,7 @@ import (
 	"sync"
 	"time"
 
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-cleanhttp"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
@@ -102,6 +103,8 @@ type Client struct {
 	token string
 	// caBundle is the CA bundle used to authenticate the server.
 	caBundle []byte
+	// sshAuth authenticates Git operations over SSH, see WithSSHAuth.
+	sshAuth *gitssh.PublicKeys
 
 	// Services are used to communicate with the different stash endpoints.
 	Users        Users
@@ -113,6 +116,9 @@ type Client struct {
 	Commits      Commits
 	PullRequests PullRequests
 	DeployKeys   DeployKeys
+	Webhooks     Webhooks
+
+	BranchRestrictions BranchRestrictions
 }
 
 // RateLimiter is the interface that wraps the basic Wait method.
@@ -230,6 +236,8 @@ func NewClient(httpClient *http.Client, host string, header *http.Header, logger
 	c.Commits = &CommitsService{Client: c}
 	c.PullRequests = &PullRequestsService{Client: c}
 	c.DeployKeys = &DeployKeysService{Client: c}
+	c.Webhooks = &WebhooksService{Client: c}
+	c.BranchRestrictions = &BranchRestrictionsService{Client: c}
 
 	return c, nil
 }
@@ -501,7 +509,7 @@ func (c *Client) Do(request *http.Request) ([]byte, *http.Response, error) {
 		return nil, resp, err
 	}
 
-	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusCreated && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodDelete) ||
+	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusCreated && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodDelete) ||
 		(resp.StatusCode == http.StatusAccepted && request.Method == http.MethodDelete) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodPut) || resp.StatusCode == http.StatusBadRequest {
 		return resBytes, resp, nil
 	}
@@ -79,8 +79,9 @@ func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Tea
 }
 
 // List teams (stash groups).
+// Stash groups can't be nested, hence all teams are top-level and the options are ignored.
 // ErrNotFound is returned if the resource does not exist.
-func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
+func (c *TeamsClient) List(ctx context.Context, _ ...gitprovider.TeamListOption) ([]gitprovider.Team, error) {
 	// Retrieve all groups for a given project
 	// pagination happens in ListProjectGroups
 	apiObjs, err := c.client.Projects.AllGroupsPermission(ctx, c.ref.Key())
@@ -126,3 +127,8 @@ func validateProjectGroupPermissionAPI(apiObj *ProjectGroupPermission) error {
 		}
 	})
 }
+
+// SetParent is not supported, as Stash groups can't be nested.
+func (c *TeamsClient) SetParent(_ context.Context, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationWebhookClient implements the gitprovider.OrganizationWebhookClient interface.
+var _ gitprovider.OrganizationWebhookClient = &OrganizationWebhookClient{}
+
+// OrganizationWebhookClient operates on the webhooks of a specific project. Project webhooks
+// are triggered by the events in all repositories of the project.
+type OrganizationWebhookClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationWebhookClient) Get(ctx context.Context, url string) (gitprovider.OrganizationWebhook, error) {
+	webhooks, err := c.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook %q: %w", url, err)
+	}
+	for _, wh := range webhooks {
+		if wh.h.URL == url {
+			return wh, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the project.
+//
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *OrganizationWebhookClient) List(ctx context.Context) ([]gitprovider.OrganizationWebhook, error) {
+	whs, err := c.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	// Cast to the generic []gitprovider.OrganizationWebhook
+	webhooks := make([]gitprovider.OrganizationWebhook, 0, len(whs))
+	for _, wh := range whs {
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+func (c *OrganizationWebhookClient) list(ctx context.Context) ([]*projectWebhook, error) {
+	apiObjs, err := c.client.Webhooks.AllProjectWebhooks(ctx, c.ref.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*projectWebhook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, newProjectWebhook(c, apiObj))
+	}
+	return webhooks, nil
+}
+
+// Create creates a webhook with the given specifications. The webhook is named after its URL.
+// Stash only sends JSON payloads, has neither issues nor releases, and sends pushed tags as push
+// events, hence these return ErrNoProviderSupport.
+func (c *OrganizationWebhookClient) Create(ctx context.Context, req gitprovider.WebhookInfo) (gitprovider.OrganizationWebhook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookSupport(req); err != nil {
+		return nil, err
+	}
+
+	apiObj := &Webhook{
+		Name:   req.URL,
+		Active: true,
+	}
+	webhookInfoToAPIObj(&req, apiObj)
+
+	created, err := c.client.Webhooks.CreateProjectWebhook(ctx, c.ref.Key(), apiObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return newProjectWebhook(c, created), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing webhook is matched by its URL.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated in place (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+// WebhookReconcileOptions.ForceSecretRotation deletes and recreates the webhook (actionTaken == true).
+func (c *OrganizationWebhookClient) Reconcile(ctx context.Context, req gitprovider.WebhookInfo, opts ...gitprovider.WebhookReconcileOption) (gitprovider.OrganizationWebhook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+	rotateSecret, err := gitprovider.RotatesWebhookSecret(req, gitprovider.MakeWebhookReconcileOptions(opts...))
+	if err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// The secret can't be compared, hence recreate the webhook to make sure it's used
+	if rotateSecret {
+		if err := actual.Delete(ctx); err != nil {
+			return nil, false, err
+		}
+		resp, err := c.Create(ctx, req)
+		return resp, true, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
@@ -136,3 +136,107 @@ func validateProjectAPI(apiObj *Project) error {
 		}
 	})
 }
+
+// defaultProjectPermissions maps the default repository permissions of organizations to the
+// project permissions granted to all users by Stash, in the order they are looked up. Stash
+// doesn't grant PROJECT_ADMIN to all users.
+//
+//nolint:gochecknoglobals
+var defaultProjectPermissions = []struct {
+	permission gitprovider.RepositoryPermission
+	value      string
+}{
+	{gitprovider.RepositoryPermissionPush, stashPermissionProjectWrite},
+	{gitprovider.RepositoryPermissionPull, stashPermissionProjectRead},
+}
+
+// projectKey returns the key of the project ref points to, looking it up if it isn't set.
+func (c *OrganizationsClient) projectKey(ctx context.Context, ref gitprovider.OrganizationRef) (string, error) {
+	if ref.Key() != "" {
+		return ref.Key(), nil
+	}
+	apiObj, err := c.client.Projects.Get(ctx, ref.Organization)
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization %q: %w", ref.Organization, err)
+	}
+	return apiObj.Key, nil
+}
+
+// GetDefaultRepositoryPermission returns the permission granted to all users for the project,
+// which is RepositoryPermissionNone if there is none.
+//
+// ErrForbidden is returned if the caller isn't an administrator of the project.
+func (c *OrganizationsClient) GetDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.RepositoryPermission, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.host); err != nil {
+		return "", err
+	}
+	key, err := c.projectKey(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range defaultProjectPermissions {
+		permitted, err := c.client.Projects.GetProjectDefaultPermission(ctx, key, p.value)
+		if err != nil {
+			return "", fmt.Errorf("failed to get default permission of organization %q: %w", ref.Organization, err)
+		}
+		if permitted {
+			return p.permission, nil
+		}
+	}
+	return gitprovider.RepositoryPermissionNone, nil
+}
+
+// SetDefaultRepositoryPermission sets the permission granted to all users for the project.
+// RepositoryPermissionAdmin isn't supported, as Stash doesn't grant PROJECT_ADMIN to all users.
+//
+// ErrForbidden is returned if the caller isn't an administrator of the project.
+func (c *OrganizationsClient) SetDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef, permission gitprovider.RepositoryPermission) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.host); err != nil {
+		return err
+	}
+	if err := gitprovider.ValidateDefaultRepositoryPermission(permission); err != nil {
+		return fmt.Errorf("invalid default repository permission %q: %w", permission, err)
+	}
+	if permission == gitprovider.RepositoryPermissionAdmin {
+		return fmt.Errorf("stash doesn't support %q as default repository permission: %w", permission, gitprovider.ErrNoProviderSupport)
+	}
+	key, err := c.projectKey(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	// Revoke the higher permissions first, as a project has a single default permission
+	for _, p := range defaultProjectPermissions {
+		allow := p.permission == permission
+		if err := c.client.Projects.SetProjectDefaultPermission(ctx, key, p.value, allow); err != nil {
+			return fmt.Errorf("failed to set default permission of organization %q: %w", ref.Organization, err)
+		}
+		if allow {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ReconcileDefaultRepositoryPermission makes sure the permission granted to all users for the
+// project is the given one.
+//
+// If the permission differs from the actual one, it is set (actionTaken == true).
+// If the permission is already the actual one, this is a no-op (actionTaken == false).
+func (c *OrganizationsClient) ReconcileDefaultRepositoryPermission(ctx context.Context, ref gitprovider.OrganizationRef, permission gitprovider.RepositoryPermission) (bool, error) {
+	if err := gitprovider.ValidateDefaultRepositoryPermission(permission); err != nil {
+		return false, fmt.Errorf("invalid default repository permission %q: %w", permission, err)
+	}
+
+	actual, err := c.GetDefaultRepositoryPermission(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+	if actual == permission {
+		return false, nil
+	}
+	return true, c.SetDefaultRepositoryPermission(ctx, ref, permission)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationsClient_DefaultRepositoryPermission(t *testing.T) {
+	mux, client := setup(t)
+
+	// The default permission of the project, PROJECT_WRITE implies PROJECT_READ
+	state := stashPermissionProjectWrite
+	forbidden := false
+	prefix := fmt.Sprintf("%s/%s/PRJ/%s/", stashURIprefix, projectsURI, permissionsURI)
+	mux.HandleFunc(prefix, func(w http.ResponseWriter, r *http.Request) {
+		if forbidden {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		permission := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), "/"+allUsersURI)
+		switch r.Method {
+		case http.MethodGet:
+			permitted := state == permission || (state == stashPermissionProjectWrite && permission == stashPermissionProjectRead)
+			fmt.Fprintf(w, `{"permitted":%t}`, permitted)
+		case http.MethodPost:
+			switch allow := r.URL.Query().Get("allow"); {
+			case allow == "true":
+				state = permission
+			case state == permission:
+				state = ""
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	c := &OrganizationsClient{clientContext: &clientContext{client: client, host: client.BaseURL.Host}}
+	ref := gitprovider.OrganizationRef{Domain: client.BaseURL.Host, Organization: "prj"}
+	ref.SetKey("PRJ")
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		state string
+		want  gitprovider.RepositoryPermission
+	}{
+		{state: "", want: gitprovider.RepositoryPermissionNone},
+		{state: stashPermissionProjectRead, want: gitprovider.RepositoryPermissionPull},
+		{state: stashPermissionProjectWrite, want: gitprovider.RepositoryPermissionPush},
+	} {
+		state = tt.state
+		got, err := c.GetDefaultRepositoryPermission(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("GetDefaultRepositoryPermission() with %q = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		permission      gitprovider.RepositoryPermission
+		wantActionTaken bool
+		wantState       string
+	}{
+		{permission: gitprovider.RepositoryPermissionPull, wantActionTaken: true, wantState: stashPermissionProjectRead},
+		{permission: gitprovider.RepositoryPermissionPull, wantActionTaken: false, wantState: stashPermissionProjectRead},
+		{permission: gitprovider.RepositoryPermissionPush, wantActionTaken: true, wantState: stashPermissionProjectWrite},
+		{permission: gitprovider.RepositoryPermissionNone, wantActionTaken: true, wantState: ""},
+	} {
+		actionTaken, err := c.ReconcileDefaultRepositoryPermission(ctx, ref, tt.permission)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actionTaken != tt.wantActionTaken {
+			t.Errorf("ReconcileDefaultRepositoryPermission(%q) actionTaken = %t, want %t", tt.permission, actionTaken, tt.wantActionTaken)
+		}
+		if state != tt.wantState {
+			t.Errorf("ReconcileDefaultRepositoryPermission(%q) set %q, want %q", tt.permission, state, tt.wantState)
+		}
+	}
+
+	if err := c.SetDefaultRepositoryPermission(ctx, ref, gitprovider.RepositoryPermissionAdmin); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("expected ErrNoProviderSupport for %q, got %v", gitprovider.RepositoryPermissionAdmin, err)
+	}
+
+	forbidden = true
+	if _, err := c.GetDefaultRepositoryPermission(ctx, ref); !errors.Is(err, gitprovider.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
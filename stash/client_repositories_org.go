@@ -79,12 +79,20 @@ func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepo
 // List all repositories in the given organization.
 // List returns all available repositories, using multiple paginated requests if needed.
 func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	return c.ListWithProgress(ctx, ref, nil)
+}
+
+// ListWithProgress lists all repositories in the given organization like List, and calls
+// progress after each page. Stash doesn't report the total, hence it isn't estimated.
+//
+// ListWithProgress returns all available repositories, using multiple paginated requests if needed.
+func (c *OrgRepositoriesClient) ListWithProgress(ctx context.Context, ref gitprovider.OrganizationRef, progress gitprovider.ListProgressFunc) ([]gitprovider.OrgRepository, error) {
 	// Make sure the OrganizationRef is valid
 	if err := validateOrganizationRef(ref, c.host); err != nil {
 		return nil, err
 	}
 
-	apiObjs, err := c.client.Repositories.All(ctx, ref.Key())
+	apiObjs, err := c.client.Repositories.AllWithProgress(ctx, ref.Key(), progress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list repositories: %w", err)
 	}
@@ -114,8 +122,15 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// SearchPage is not supported, as Stash doesn't keep track of the languages of repositories.
+func (c *OrgRepositoriesClient) SearchPage(_ context.Context, _ gitprovider.OrganizationRef, _, _ int, _ ...gitprovider.RepositorySearchOption) ([]gitprovider.OrgRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
 // Create creates a repository for the given organization, with the data and options.
-// ErrAlreadyExists will be returned if the resource already exists.
+// ErrAlreadyExists will be returned if the resource already exists. If req has settings Stash
+// doesn't support, the repository is created with the supported ones, and returned along with
+// an error wrapping ErrNoProviderSupport.
 func (c *OrgRepositoriesClient) Create(ctx context.Context,
 	ref gitprovider.OrgRepositoryRef,
 	req gitprovider.RepositoryInfo,
@@ -125,8 +140,9 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context,
 		return nil, err
 	}
 
-	apiObj, err := createRepository(ctx, c.client, ref.Key(), ref, req, opts...)
-	if err != nil {
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.client, ref.Key(), ref, req, opts...)
+	// The repository is only returned along with ErrNoProviderSupport if it has been created
+	if err != nil && (apiObj == nil || !errors.Is(err, gitprovider.ErrNoProviderSupport)) {
 		if errors.Is(err, ErrAlreadyExists) {
 			return nil, gitprovider.ErrAlreadyExists
 		}
@@ -135,7 +151,14 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context,
 
 	ref.SetSlug(apiObj.Slug)
 
-	return newOrgRepository(c.clientContext, apiObj, ref), nil
+	repo := newOrgRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, err
+}
+
+// Transfer always returns ErrNoProviderSupport, as moving repositories between projects isn't supported yet.
+func (c *OrgRepositoriesClient) Transfer(_ context.Context, _ gitprovider.OrgRepositoryRef, _ gitprovider.OrganizationRef, _ ...gitprovider.RepositoryTransferOption) (*gitprovider.RepositoryTransferResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -191,36 +214,47 @@ func deleteRepository(ctx context.Context, c *Client, orgKey, repoSlug string) e
 	return nil
 }
 
-func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, error) {
+// createRepository creates the repository, and returns it together with the SHA of the initial
+// commit if the AutoInit option is set. If req has unsupported settings, the created repository
+// is returned along with an error wrapping ErrNoProviderSupport.
+func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, *string, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
 	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Assemble the options struct based on the given options
 	opt, err := gitprovider.MakeRepositoryCreateOptions(opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if opt.VerifyVisibility != nil {
+		return nil, nil, fmt.Errorf("option VerifyVisibility isn't supported: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if _, isOrg := ref.(gitprovider.OrgRepositoryRef); isOrg && opt.CheckOrgMembership != nil && *opt.CheckOrgMembership {
+		return nil, nil, fmt.Errorf("option CheckOrgMembership isn't supported: %w", gitprovider.ErrNoProviderSupport)
 	}
 
-	// Convert to the API object and apply the options
-	data := repositoryToAPI(&req, ref)
-	if err != nil {
-		return nil, err
+	// Convert to the API object and apply the options. Unsupported settings are reported once
+	// the repository has been created with the supported ones.
+	data, unsupportedErr := repositoryToAPI(&req, ref)
+	if unsupportedErr != nil && !errors.Is(unsupportedErr, gitprovider.ErrNoProviderSupport) {
+		return nil, nil, unsupportedErr
 	}
 
 	repo, err := c.Repositories.Create(ctx, orgKey, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create repository: %w", err)
+		return nil, nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
 	user, err := c.Users.Get(ctx, repo.Session.UserName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	var initCommit *CreateCommit
+	var initialCommitSHA *string
 
 	if opt.AutoInit != nil && *(opt.AutoInit) {
 		readmeContents := fmt.Sprintf("# %s\n%s", repo.Name, repo.Description)
@@ -249,11 +283,11 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 				Email: user.EmailAddress,
 			}),
 			WithMessage("initial commit"),
-			WithURL(getRepoHTTPref(repo.Links.Clone)),
+			WithURL(c.cloneURL(repo.Links.Clone)),
 			WithFiles(files))
 
 		if err != nil {
-			return nil, fmt.Errorf("failed to create initial commit: %w", err)
+			return nil, nil, fmt.Errorf("failed to create initial commit: %w", err)
 		}
 
 		err = initRepo(ctx, c, initCommit, repo)
@@ -262,11 +296,18 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 			//create default branch
 			br, err := setDefaultBranch(ctx, c, orgKey, data.DefaultBranch, repo)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create default branch: %w", err)
+				return nil, nil, fmt.Errorf("failed to create default branch: %w", err)
 			}
 			// save the default branch after setting it
 			repo.DefaultBranch = br.DisplayID
 		}
+
+		// The initial commit is pushed using git, hence look it up from the default branch
+		defaultBranch, err := c.Branches.Default(ctx, orgKey, repo.Slug)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get default branch: %w", err)
+		}
+		initialCommitSHA = &defaultBranch.LatestCommit
 	} else if data.DefaultBranch != "" && data.DefaultBranch != legacyBranch {
 		// Init repo anyway because we need to set the default branch and for now we have an empty repo.
 		// Stash set it by default to master so we use that to branch from.
@@ -276,23 +317,23 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 				Email: user.EmailAddress,
 			}),
 			WithMessage("initial commit"),
-			WithURL(getRepoHTTPref(repo.Links.Clone)))
+			WithURL(c.cloneURL(repo.Links.Clone)))
 
 		if err != nil {
-			return nil, fmt.Errorf("failed to create initial commit: %w", err)
+			return nil, nil, fmt.Errorf("failed to create initial commit: %w", err)
 		}
 
 		err = initRepo(ctx, c, initCommit, repo)
 		br, err := setDefaultBranch(ctx, c, orgKey, data.DefaultBranch, repo)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create default branch: %w", err)
+			return nil, nil, fmt.Errorf("failed to create default branch: %w", err)
 		}
 
 		// save the default branch after setting it
 		repo.DefaultBranch = br.DisplayID
 	}
 
-	return repo, nil
+	return repo, initialCommitSHA, unsupportedErr
 }
 
 func setDefaultBranch(ctx context.Context, c *Client, orgKey, branch string, repo *Repository) (*Branch, error) {
@@ -343,10 +384,14 @@ func getRepoHTTPref(clones []Clone) string {
 func (c *OrgRepositoriesClient) reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
 	actionTaken := false
 
+	// Unsupported settings can't be reconciled, they're reported once the supported ones are
+	unsupportedErr := unsupportedRepositorySettings(req)
+	req.AllowAutoMerge, req.DeleteBranchOnMerge = nil, nil
+
 	// If the desired matches the actual state, just return the actual state
 	new := actual.Get()
 	if req.Equals(new) {
-		return actionTaken, nil
+		return actionTaken, unsupportedErr
 	}
 	// Populate the desired state to the current-actual object
 	err := actual.Set(req)
@@ -368,7 +413,7 @@ func (c *OrgRepositoriesClient) reconcileRepository(ctx context.Context, actual
 	}
 
 	actionTaken = true
-	return actionTaken, nil
+	return actionTaken, unsupportedErr
 }
 
 func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_Create_unsupportedOptions(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	c := &OrgRepositoriesClient{clientContext: &clientContext{client: client, host: client.BaseURL.Host}}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: client.BaseURL.Host, Organization: "prj"},
+		RepositoryName:  "repo",
+	}
+	ref.SetKey("PRJ")
+
+	tests := []struct {
+		name string
+		opts *gitprovider.RepositoryCreateOptions
+	}{
+		{
+			name: "VerifyVisibility",
+			opts: &gitprovider.RepositoryCreateOptions{
+				VerifyVisibility: &gitprovider.VisibilityVerification{
+					OnMismatch: func(gitprovider.VisibilityMismatch) {},
+				},
+			},
+		},
+		{
+			name: "CheckOrgMembership",
+			opts: &gitprovider.RepositoryCreateOptions{CheckOrgMembership: gitprovider.BoolVar(true)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Create(context.Background(), ref, gitprovider.RepositoryInfo{}, tt.opts); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+				t.Errorf("expected ErrNoProviderSupport, got %v", err)
+			}
+		})
+	}
+}
@@ -118,7 +118,9 @@ func (c *UserRepositoriesClient) List(ctx context.Context, ref gitprovider.UserR
 }
 
 // Create creates a repository for the given organization, with the data and options
-// ErrAlreadyExists will be returned if the resource already exists.
+// ErrAlreadyExists will be returned if the resource already exists. If req has settings Stash
+// doesn't support, the repository is created with the supported ones, and returned along with
+// an error wrapping ErrNoProviderSupport.
 func (c *UserRepositoriesClient) Create(ctx context.Context,
 	ref gitprovider.UserRepositoryRef,
 	req gitprovider.RepositoryInfo,
@@ -128,8 +130,9 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 		return nil, err
 	}
 
-	apiObj, err := createRepository(ctx, c.client, addTilde(ref.UserLogin), ref, req, opts...)
-	if err != nil {
+	apiObj, initialCommitSHA, err := createRepository(ctx, c.client, addTilde(ref.UserLogin), ref, req, opts...)
+	// The repository is only returned along with ErrNoProviderSupport if it has been created
+	if err != nil && (apiObj == nil || !errors.Is(err, gitprovider.ErrNoProviderSupport)) {
 		if errors.Is(err, ErrAlreadyExists) {
 			return nil, gitprovider.ErrAlreadyExists
 		}
@@ -138,7 +141,9 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 
 	ref.SetSlug(apiObj.Slug)
 
-	return newUserRepository(c.clientContext, apiObj, ref), nil
+	repo := newUserRepository(c.clientContext, apiObj, ref)
+	repo.initialCommitSHA = initialCommitSHA
+	return repo, err
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -167,10 +172,14 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 func (c *UserRepositoriesClient) reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
 	actionTaken := false
 
+	// Unsupported settings can't be reconciled, they're reported once the supported ones are
+	unsupportedErr := unsupportedRepositorySettings(req)
+	req.AllowAutoMerge, req.DeleteBranchOnMerge = nil, nil
+
 	// If the desired matches the actual state, just return the actual state
 	new := actual.Get()
 	if req.Equals(new) {
-		return actionTaken, nil
+		return actionTaken, unsupportedErr
 	}
 	// Populate the desired state to the current-actual object
 	err := actual.Set(req)
@@ -193,7 +202,7 @@ func (c *UserRepositoriesClient) reconcileRepository(ctx context.Context, actual
 
 	actionTaken = true
 
-	return actionTaken, nil
+	return actionTaken, unsupportedErr
 }
 
 func validateUserAPI(apiObj *User) error {
@@ -18,6 +18,7 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -52,7 +53,7 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 		return fmt.Errorf("failed to get user %s: %w", repo.Session.UserName, err)
 	}
 
-	url := getRepoHTTPref(repo.Links.Clone)
+	url := c.client.cloneURL(repo.Links.Clone)
 
 	r, dir, err := c.client.Git.CloneRepository(ctx, url)
 	if err != nil {
@@ -90,6 +91,81 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 	return nil
 }
 
+// List lists all branches of the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.BranchInfo, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+	apiObjs, err := c.client.Branches.All(ctx, projectKey, repoSlug)
+	if errors.Is(err, ErrNotFound) {
+		return nil, gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	branches := make([]gitprovider.BranchInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, gitprovider.BranchInfo{
+			Name: apiObj.DisplayID,
+			Sha:  apiObj.LatestCommit,
+		})
+	}
+	return branches, nil
+}
+
+// Delete deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+	err := c.client.Branches.Delete(ctx, projectKey, repoSlug, fmt.Sprintf("refs/heads/%s", branch))
+	if errors.Is(err, ErrNotFound) {
+		return gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// Rename renames the given branch to newName. Stash can't rename branches, hence the branch
+// is created under newName, made the default branch if the old one was, and then deleted.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) Rename(ctx context.Context, branch, newName string) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+	apiObjs, err := c.client.Branches.All(ctx, projectKey, repoSlug)
+	if errors.Is(err, ErrNotFound) {
+		return gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var old *Branch
+	for _, apiObj := range apiObjs {
+		if apiObj.DisplayID == branch {
+			old = apiObj
+			break
+		}
+	}
+	if old == nil {
+		return fmt.Errorf("branch %s: %w", branch, gitprovider.ErrNotFound)
+	}
+
+	if _, err := c.client.Branches.Create(ctx, projectKey, repoSlug, newName, old.LatestCommit); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", newName, err)
+	}
+	// Stash refuses to delete the default branch, hence move it first
+	if old.IsDefault {
+		if err := c.client.Branches.SetDefault(ctx, projectKey, repoSlug, fmt.Sprintf("refs/heads/%s", newName)); err != nil {
+			return fmt.Errorf("failed to set default branch: %w", err)
+		}
+	}
+	return c.Delete(ctx, branch)
+}
+
 func (c *BranchClient) getDefault(ctx context.Context) (string, error) {
 	projectKey, repoSlug := getStashRefs(c.ref)
 
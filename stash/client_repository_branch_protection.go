@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchProtectionClient implements the gitprovider.BranchProtectionClient interface.
+var _ gitprovider.BranchProtectionClient = &BranchProtectionClient{}
+
+// BranchProtectionClient operates on the branch protection rules of a specific repository.
+// A rule consists of the branch permissions preventing deletions and rewriting history of the
+// branch, and a read-only permission exempting the allowed pushers, if any.
+type BranchProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protection rule of the given branch.
+//
+// ErrNotFound is returned if the branch isn't protected.
+func (c *BranchProtectionClient) Get(ctx context.Context, branch string) (gitprovider.BranchProtection, error) {
+	bp, err := c.get(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+func (c *BranchProtectionClient) get(ctx context.Context, branch string) (*branchProtection, error) {
+	protections, err := c.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the protection of branch %q: %w", branch, err)
+	}
+	for _, bp := range protections {
+		if bp.branch == branch {
+			return bp, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists the protection rules of all protected branches of the repository.
+//
+// List returns all available protection rules, using multiple paginated requests if needed.
+func (c *BranchProtectionClient) List(ctx context.Context) ([]gitprovider.BranchProtection, error) {
+	bps, err := c.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch protections: %w", err)
+	}
+	// Cast to the generic []gitprovider.BranchProtection
+	protections := make([]gitprovider.BranchProtection, 0, len(bps))
+	for _, bp := range bps {
+		protections = append(protections, bp)
+	}
+	return protections, nil
+}
+
+// list groups the restrictions of single branches by branch, in the order they are returned.
+func (c *BranchProtectionClient) list(ctx context.Context) ([]*branchProtection, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+	apiObjs, err := c.client.BranchRestrictions.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	protections := []*branchProtection{}
+	byBranch := map[string]*branchProtection{}
+	for _, apiObj := range apiObjs {
+		branch, ok := restrictedBranch(apiObj)
+		if !ok {
+			continue
+		}
+		bp, ok := byBranch[branch]
+		if !ok {
+			bp = newBranchProtection(c, branch)
+			byBranch[branch] = bp
+			protections = append(protections, bp)
+		}
+		bp.addRestriction(apiObj)
+	}
+	return protections, nil
+}
+
+// Create protects a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch is already protected.
+// ErrNoProviderSupport is returned if approvals, the reviews of code owners, status checks or
+// enforcing the rule for administrators are required, as Stash branch permissions can't
+// enforce these.
+func (c *BranchProtectionClient) Create(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateBranchProtectionSupport(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.get(ctx, req.Branch); err == nil {
+		return nil, fmt.Errorf("branch %q: %w", req.Branch, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	return c.create(ctx, req)
+}
+
+func (c *BranchProtectionClient) create(ctx context.Context, req gitprovider.BranchProtectionInfo) (*branchProtection, error) {
+	bp := newBranchProtection(c, req.Branch)
+	if err := bp.Set(req); err != nil {
+		return nil, err
+	}
+	if err := bp.Update(ctx); err != nil {
+		return nil, fmt.Errorf("failed to protect branch %q: %w", req.Branch, err)
+	}
+	return bp, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// The existing protection rule is matched by its branch.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *BranchProtectionClient) Reconcile(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.get(ctx, req.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.create(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return resp, true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state. A rule missing
+	// some of its restrictions is completed as well.
+	if req.Equals(actual.Get()) && actual.complete() {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// ValidateCodeOwners always returns ErrNoProviderSupport, as Stash has no API validating the
+// CODEOWNERS file.
+func (c *BranchProtectionClient) ValidateCodeOwners(_ context.Context) ([]gitprovider.CodeOwnersError, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
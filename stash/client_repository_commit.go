@@ -69,7 +69,18 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+//
+// Stash can't be asked to sign the commit, hence ErrNoProviderSupport is returned if the
+// ProviderSigned option is set. If the Author option is set, it is recorded as the author of
+// the commit, otherwise the authenticated user is.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	o, err := gitprovider.MakeCommitCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.ProviderSigned != nil && *o.ProviderSigned {
+		return nil, fmt.Errorf("stash doesn't support provider-signed commits: %w", gitprovider.ErrNoProviderSupport)
+	}
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -88,7 +99,7 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		return nil, fmt.Errorf("failed to get user %s: %w", repo.Session.UserName, err)
 	}
 
-	url := getRepoHTTPref(repo.Links.Clone)
+	url := c.client.cloneURL(repo.Links.Clone)
 	r, dir, err := c.client.Git.CloneRepository(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to clone repository %s: %w", url, err)
@@ -98,11 +109,18 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 	for _, file := range files {
 		f = append(f, CommitFile{Path: file.Path, Content: file.Content})
 	}
+	author := &CommitAuthor{
+		Name:  user.Name,
+		Email: user.EmailAddress,
+	}
+	if o.Author != nil {
+		author = &CommitAuthor{
+			Name:  o.Author.Name,
+			Email: o.Author.Email,
+		}
+	}
 	commit, err := NewCommit(
-		WithAuthor(&CommitAuthor{
-			Name:  user.Name,
-			Email: user.EmailAddress,
-		}),
+		WithAuthor(author),
 		WithMessage(message),
 		WithURL(url),
 		WithFiles(f))
@@ -129,3 +147,8 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 
 	return newCommit(sha), nil
 }
+
+// ListComments is not supported by Stash, as commit comments can only be listed per file.
+func (c *CommitClient) ListComments(_ context.Context, _ string) ([]gitprovider.CommitComment, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -63,7 +63,7 @@ func (c *DeployKeyClient) get(ctx context.Context, name string) (*DeployKey, err
 // List lists all repository deploy keys.
 // List returns all available repository deploy keys for the given type,
 // using multiple paginated requests if needed.
-func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, error) {
+func (c *DeployKeyClient) List(ctx context.Context, opts ...gitprovider.DeployKeyListOption) ([]gitprovider.DeployKey, error) {
 	apiObjs, err := c.list(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deploy keys: %w", err)
@@ -73,6 +73,7 @@ func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, er
 	for _, apiObj := range apiObjs {
 		keys = append(keys, newDeployKey(c, apiObj))
 	}
+	gitprovider.SortDeployKeys(keys, gitprovider.MakeDeployKeyListOptions(opts...))
 	return keys, nil
 }
 
@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -36,3 +37,8 @@ type FileClient struct {
 func (c *FileClient) Get(_ context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
 	return nil, fmt.Errorf("error getting file %s@%s. not implemented in stash yet", path, branch)
 }
+
+// GetReader is not supported by Stash yet.
+func (c *FileClient) GetReader(_ context.Context, _, _ string) (io.ReadCloser, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
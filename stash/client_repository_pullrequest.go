@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -47,12 +48,35 @@ func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.Pu
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
-	return newPullRequest(pr), nil
+	return newPullRequest(c.clientContext, c.ref, pr), nil
 
 }
 
-// List returns all pull requests for the given repository.
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
+// List returns the pull requests for the given repository, by default the open ones.
+//
+// The state and base branch are filtered by Stash, or the source branch if there is no base branch
+// filter. The other filters are applied to the returned pull requests. Stash has no labels on pull
+// requests, hence filtering by label returns ErrNoProviderSupport.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.Label != nil {
+		return nil, fmt.Errorf("stash can't filter pull requests by label: %w", gitprovider.ErrNoProviderSupport)
+	}
+	filter := &PullRequestFilter{}
+	if o.State != nil {
+		filter.State = pullRequestStateToAPI(*o.State)
+	}
+	switch {
+	case o.BaseBranch != nil:
+		filter.At = fmt.Sprintf("refs/heads/%s", *o.BaseBranch)
+	case o.SourceBranch != nil:
+		filter.At = fmt.Sprintf("refs/heads/%s", *o.SourceBranch)
+		filter.Direction = "OUTGOING"
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -61,7 +85,7 @@ func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest
 		projectKey = addTilde(r.UserLogin)
 	}
 
-	apiObjs, err := c.client.PullRequests.All(ctx, projectKey, repoSlug)
+	apiObjs, err := c.client.PullRequests.All(ctx, projectKey, repoSlug, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests: %w", err)
 	}
@@ -69,16 +93,45 @@ func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest
 	// Traverse the list, and return a list of OrgRepository objects
 	prs := make([]gitprovider.PullRequest, 0, len(apiObjs))
 	for _, apiObj := range apiObjs {
-		prs = append(prs, newPullRequest(apiObj))
+		if matchesListOptions(apiObj, o) {
+			prs = append(prs, newPullRequest(c.clientContext, c.ref, apiObj))
+		}
 	}
 
 	return prs, nil
 
 }
 
-// Merge merges the pull request.
-// Stash does not support message and merge strategy options for pull requests automatic merges.
-func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider.MergeMethod, _ string) error {
+// matchesListOptions applies the filters of opts, which Stash can't apply when listing pull requests.
+func matchesListOptions(apiObj *PullRequest, opts gitprovider.PullRequestListOptions) bool {
+	if opts.SourceBranch != nil && apiObj.FromRef.DisplayID != *opts.SourceBranch {
+		return false
+	}
+	if opts.Author != nil && (apiObj.Author == nil || apiObj.Author.User.Name != *opts.Author) {
+		return false
+	}
+	if opts.UpdatedSince != nil && time.UnixMilli(apiObj.UpdatedDate).Before(*opts.UpdatedSince) {
+		return false
+	}
+	return true
+}
+
+// mergeStrategies maps merge methods to the ids of the Stash merge strategies.
+var mergeStrategies = map[gitprovider.MergeMethod]string{
+	gitprovider.MergeMethodMerge:       "no-ff",
+	gitprovider.MergeMethodSquash:      "squash",
+	gitprovider.MergeMethodRebase:      "rebase-ff-only",
+	gitprovider.MergeMethodFastForward: "ff-only",
+}
+
+// Merge merges the pull request, using the merge strategy corresponding to mergeMethod, which must be
+// enabled for the repository.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o := gitprovider.MakePullRequestMergeOptions(opts...)
+	strategyID, ok := mergeStrategies[mergeMethod]
+	if !ok {
+		return fmt.Errorf("unsupported merge method %q: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -87,6 +140,14 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider
 		projectKey = addTilde(r.UserLogin)
 	}
 
+	config, err := c.client.PullRequests.GetMergeConfig(ctx, projectKey, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get merge config: %w", err)
+	}
+	if !mergeStrategyEnabled(config, strategyID) {
+		return fmt.Errorf("merge strategy %q isn't enabled for repository %s: %w", strategyID, repoSlug, gitprovider.ErrInvalidArgument)
+	}
+
 	// Get the pull request first
 	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
 	if err != nil {
@@ -94,7 +155,10 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider
 	}
 
 	// Merge the pull request
-	_, err = c.client.PullRequests.Merge(ctx, projectKey, repoSlug, pr.ID, pr.Version)
+	_, err = c.client.PullRequests.MergeWithOptions(ctx, projectKey, repoSlug, pr.ID, pr.Version, &MergeOptions{
+		Message:    o.CommitMessage(message),
+		StrategyID: strategyID,
+	})
 	if err != nil {
 		return err
 	}
@@ -103,8 +167,24 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider
 
 }
 
+// mergeStrategyEnabled returns whether the strategy with the given id is enabled in config.
+func mergeStrategyEnabled(config *MergeConfig, id string) bool {
+	for _, strategy := range config.Strategies {
+		if strategy.ID == id {
+			return strategy.Enabled
+		}
+	}
+	return false
+}
+
 // Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Draft pull requests are supported by Bitbucket Server 8.18 and later only. Pull requests from
+// forks are not supported, hence setting a head repository returns ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
+	if o.HeadRepository != nil {
+		return nil, fmt.Errorf("stash can't create pull requests from forks: %w", gitprovider.ErrNoProviderSupport)
+	}
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -120,6 +200,7 @@ func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranc
 		Open:        true,
 		Closed:      false,
 		Locked:      false,
+		Draft:       o.Draft,
 		ToRef: Ref{
 			ID: fmt.Sprintf("refs/heads/%s", baseBranch),
 			Repository: Repository{
@@ -140,32 +221,39 @@ func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranc
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request: %w", err)
 	}
-	return newPullRequest(created), nil
+	return newPullRequest(c.clientContext, c.ref, created), nil
 }
 
 // Edit modifies an existing PR. Please refer to "EditOptions" for details on which data can be edited.
 func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovider.EditOptions) (gitprovider.PullRequest, error) {
-	projectKey, repoSlug := getStashRefs(c.ref)
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Title: opts.Title})
+}
 
-	// check if it is a user repository
-	// if yes, we need to add a tilde to the user login and use it as the project key
-	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
-		projectKey = addTilde(r.UserLogin)
-	}
+// Update changes an existing PR. Please refer to "PullRequestUpdateOptions" for details on which data can be changed.
+// Draft pull requests are supported by Bitbucket Server 8.18 and later only.
+func (c *PullRequestClient) Update(ctx context.Context, number int, opts gitprovider.PullRequestUpdateOptions) (gitprovider.PullRequest, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
 
 	// need to fetch the PR first to get the right version number
-	pr, err := c.Get(ctx, number)
+	apiObject, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PR %d: %w", number, err)
 	}
-	apiObject, ok := pr.APIObject().(*PullRequest)
-	if !ok {
-		return nil, fmt.Errorf("API object is of unexpected type") // this should never happen!
-	}
 
 	if opts.Title != nil {
 		apiObject.Title = *opts.Title
 	}
+	if opts.Description != nil {
+		apiObject.Description = *opts.Description
+	}
+	if opts.BaseBranch != nil {
+		apiObject.ToRef.ID = fmt.Sprintf("refs/heads/%s", *opts.BaseBranch)
+		apiObject.ToRef.DisplayID = ""
+		apiObject.ToRef.LatestCommit = ""
+	}
+	if opts.Draft != nil {
+		apiObject.Draft = opts.Draft
+	}
 	// the REST API doesn't accept the following fields to be set for update requests
 	apiObject.Author = nil
 	apiObject.Participants = nil
@@ -174,7 +262,57 @@ func (c *PullRequestClient) Edit(ctx context.Context, number int, opts gitprovid
 		return nil, fmt.Errorf("failed to edit pull request: %w", err)
 	}
 
-	return newPullRequest(edited), nil
+	return newPullRequest(c.clientContext, c.ref, edited), nil
+}
+
+// Close declines an open pull request.
+func (c *PullRequestClient) Close(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, declinedState, c.client.PullRequests.Decline)
+}
+
+// Reopen reopens a declined pull request.
+func (c *PullRequestClient) Reopen(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.setState(ctx, number, openState, c.client.PullRequests.Reopen)
+}
+
+// MarkReady marks a draft pull request as ready for review.
+func (c *PullRequestClient) MarkReady(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	return c.Update(ctx, number, gitprovider.PullRequestUpdateOptions{Draft: gitprovider.BoolVar(false)})
+}
+
+// setState calls transition to move the pull request into the given state, unless it is in that
+// state already. Merged pull requests can't change state.
+func (c *PullRequestClient) setState(ctx context.Context, number int, state string,
+	transition func(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)) (gitprovider.PullRequest, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	// the transitions require the current version of the PR
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR %d: %w", number, err)
+	}
+	if pr.State == mergedState {
+		return nil, fmt.Errorf("pull request %d has been merged already: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if pr.State == state {
+		return newPullRequest(c.clientContext, c.ref, pr), nil
+	}
+
+	updated, err := transition(ctx, projectKey, repoSlug, pr.ID, pr.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change state of pull request %d: %w", number, err)
+	}
+	return newPullRequest(c.clientContext, c.ref, updated), nil
+}
+
+// getProjectKeyAndSlug returns the project key and repository slug of the repository, using the
+// tilde-prefixed user login as project key for user repositories.
+func getProjectKeyAndSlug(ref gitprovider.RepositoryRef) (string, string) {
+	projectKey, repoSlug := getStashRefs(ref)
+	if r, ok := ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+	return projectKey, repoSlug
 }
 
 func validatePullRequestsAPI(apiObj *PullRequest) error {
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the comments of a specific pull request.
+type PullRequestCommentClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// List lists the comments of the pull request and their replies, oldest first.
+//
+// List returns all available comments, using multiple paginated requests if needed.
+func (c *PullRequestCommentClient) List(ctx context.Context) ([]gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	roots, err := c.client.PullRequests.AllComments(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments of PR %d: %w", c.number, err)
+	}
+	comments := flattenComments(roots)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Get().CreatedAt.Before(comments[j].Get().CreatedAt)
+	})
+	return comments, nil
+}
+
+// Create adds a general comment, an inline comment on an added line of the effective diff, or a
+// reply to the pull request.
+func (c *PullRequestCommentClient) Create(ctx context.Context, req gitprovider.PullRequestCommentInfo) (gitprovider.PullRequestComment, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	comment := &Comment{Text: req.Body}
+	if req.InReplyTo != 0 {
+		comment.Parent = &CommentParent{ID: req.InReplyTo}
+	} else if req.Path != "" {
+		comment.Anchor = &CommentAnchor{
+			DiffType: "EFFECTIVE",
+			FileType: "TO",
+			Line:     req.Line,
+			LineType: "ADDED",
+			Path:     req.Path,
+		}
+	}
+	apiObj, err := c.client.PullRequests.CreateComment(ctx, projectKey, repoSlug, c.number, comment)
+	if errors.Is(err, ErrNotFound) {
+		return nil, gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to comment on PR %d: %w", c.number, err)
+	}
+	if req.InReplyTo != 0 {
+		return newPullRequestComment(apiObj, &Comment{ID: req.InReplyTo}, apiObj), nil
+	}
+	return newPullRequestComment(apiObj, nil, apiObj), nil
+}
+
+// Resolve resolves the thread the comment with the given ID belongs to. This requires
+// Bitbucket Server 7.7 or later.
+func (c *PullRequestCommentClient) Resolve(ctx context.Context, id int64) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	roots, err := c.client.PullRequests.AllComments(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return fmt.Errorf("failed to list comments of PR %d: %w", c.number, err)
+	}
+	root := findThread(roots, id)
+	if root == nil {
+		return fmt.Errorf("comment %d: %w", id, gitprovider.ErrNotFound)
+	}
+	if root.ThreadResolved {
+		return nil
+	}
+	update := &Comment{ID: root.ID, Text: root.Text, ThreadResolved: true, Version: root.Version}
+	if _, err := c.client.PullRequests.UpdateComment(ctx, projectKey, repoSlug, c.number, update); err != nil {
+		return fmt.Errorf("failed to resolve comment %d: %w", root.ID, err)
+	}
+	return nil
+}
+
+// findThread returns the root comment of the thread containing the comment with the given ID, or nil.
+func findThread(roots []*Comment, id int64) *Comment {
+	var contains func(apiObj *Comment) bool
+	contains = func(apiObj *Comment) bool {
+		if apiObj.ID == id {
+			return true
+		}
+		for _, reply := range apiObj.Comments {
+			if contains(reply) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, root := range roots {
+		if contains(root) {
+			return root
+		}
+	}
+	return nil
+}
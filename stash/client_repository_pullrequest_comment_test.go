@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestPullRequestCommentClient(t *testing.T) {
+	activities := &ActivityList{
+		Paging: Paging{IsLastPage: true},
+		Activities: []*Activity{
+			{Action: "COMMENTED", CommentAction: "ADDED", Comment: &Comment{
+				ID: 2, Version: 1, Text: "nit", Author: &User{Name: "bob"}, CreatedDate: 2000,
+				Comments: []*Comment{{ID: 3, Text: "done", Author: &User{Name: "alice"}, CreatedDate: 3000}},
+			}, CommentAnchor: &CommentAnchor{Path: "main.go", Line: 3}},
+			{Action: "APPROVED"},
+			{Action: "COMMENTED", CommentAction: "ADDED", Comment: &Comment{ID: 1, Text: "general", CreatedDate: 1000}},
+		},
+	}
+	var created []*Comment
+	var updated *Comment
+	mux, client := setup(t)
+	prPath := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(prPath+"/activities", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(activities)
+	})
+	mux.HandleFunc(prPath+"/comments", func(w http.ResponseWriter, r *http.Request) {
+		req := &Comment{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Error(err)
+		}
+		created = append(created, req)
+		req.ID = int64(4 + len(created))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(req)
+	})
+	mux.HandleFunc(prPath+"/comments/2", func(w http.ResponseWriter, r *http.Request) {
+		updated = &Comment{}
+		if err := json.NewDecoder(r.Body).Decode(updated); err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(updated)
+	})
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+		RepositoryName:  "my-repo",
+	}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	c := &PullRequestCommentClient{
+		clientContext: &clientContext{client: client},
+		ref:           ref,
+		number:        1,
+	}
+	ctx := context.Background()
+
+	comments, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("List() returned %d comments, want 3", len(comments))
+	}
+	if got := comments[0].Get(); got.ID != 1 || got.Path != "" {
+		t.Errorf("List()[0] = %+v, want the general comment", got)
+	}
+	if got := comments[2].Get(); got.ID != 3 || got.Author != "alice" || got.Path != "main.go" || got.Line != 3 || got.InReplyTo != 2 {
+		t.Errorf("List()[2] = %+v, want the reply to comment 2", got)
+	}
+
+	if _, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "typo", Path: "main.go", Line: 7}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if a := created[0].Anchor; a == nil || a.Path != "main.go" || a.Line != 7 || a.LineType != "ADDED" || a.FileType != "TO" {
+		t.Errorf("Create() sent anchor %+v, want an anchor on the added line", created[0].Anchor)
+	}
+	reply, err := c.Create(ctx, gitprovider.PullRequestCommentInfo{Body: "fixed", InReplyTo: 2})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created[1].Parent == nil || created[1].Parent.ID != 2 || reply.Get().InReplyTo != 2 {
+		t.Errorf("Create() sent %+v, want a reply to comment 2", created[1])
+	}
+
+	if err := c.Resolve(ctx, 3); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if updated == nil || !updated.ThreadResolved || updated.Version != 1 {
+		t.Errorf("Resolve() sent %+v, want the thread of comment 2 to be resolved", updated)
+	}
+	if err := c.Resolve(ctx, 9); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Resolve() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReviewClient implements the gitprovider.ReviewClient interface.
+var _ gitprovider.ReviewClient = &ReviewClient{}
+
+// ReviewClient operates on the reviewers of a specific pull request.
+type ReviewClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// RequestReviewers adds the users with the given names to the reviewers of the pull request.
+func (c *ReviewClient) RequestReviewers(ctx context.Context, logins ...string) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	// need to fetch the PR first to get the right version number
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return fmt.Errorf("failed to get PR %d: %w", c.number, err)
+	}
+
+	requested := make(map[string]bool, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		requested[reviewer.User.Name] = true
+	}
+	reviewers := len(pr.Reviewers)
+	for _, login := range logins {
+		if !requested[login] {
+			pr.Reviewers = append(pr.Reviewers, Participant{User: User{Name: login}})
+			requested[login] = true
+		}
+	}
+	if len(pr.Reviewers) == reviewers {
+		return nil
+	}
+
+	// the REST API doesn't accept the following fields to be set for update requests
+	pr.Author = nil
+	pr.Participants = nil
+	if _, err := c.client.PullRequests.Update(ctx, projectKey, repoSlug, pr); err != nil {
+		return fmt.Errorf("failed to request reviewers: %w", err)
+	}
+	return nil
+}
+
+// List lists the reviewers of the pull request, along with their review status.
+func (c *ReviewClient) List(ctx context.Context) ([]gitprovider.Review, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR %d: %w", c.number, err)
+	}
+
+	reviews := make([]gitprovider.Review, 0, len(pr.Reviewers))
+	for i := range pr.Reviewers {
+		reviews = append(reviews, newReview(&pr.Reviewers[i]))
+	}
+	return reviews, nil
+}
+
+// Approve approves the pull request as the user the client has been created with. A non-empty
+// body is added as a comment to the pull request.
+func (c *ReviewClient) Approve(ctx context.Context, body string) (gitprovider.Review, error) {
+	if c.client.username == "" {
+		return nil, fmt.Errorf("approving requires a client with a username: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	participant, err := c.client.PullRequests.SetParticipantStatus(ctx, projectKey, repoSlug, c.number, c.client.username, approvedStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve PR %d: %w", c.number, err)
+	}
+	if body != "" {
+		if err := c.client.PullRequests.AddComment(ctx, projectKey, repoSlug, c.number, body); err != nil {
+			return nil, fmt.Errorf("failed to comment on PR %d: %w", c.number, err)
+		}
+	}
+	return newReview(participant), nil
+}
+
+// Dismiss withdraws the approval of the user the client has been created with, whose user ID
+// must be given as id. message is ignored, as Stash doesn't record a reason.
+func (c *ReviewClient) Dismiss(ctx context.Context, id int64, _ string) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(c.ref)
+
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return fmt.Errorf("failed to get PR %d: %w", c.number, err)
+	}
+	for _, reviewer := range pr.Reviewers {
+		if reviewer.User.ID != id {
+			continue
+		}
+		if reviewer.User.Name != c.client.username {
+			return fmt.Errorf("stash can only withdraw the approval of the authenticated user: %w", gitprovider.ErrNoProviderSupport)
+		}
+		_, err := c.client.PullRequests.SetParticipantStatus(ctx, projectKey, repoSlug, c.number, c.client.username, unapprovedStatus)
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return err
+	}
+	return fmt.Errorf("no review with ID %d: %w", id, gitprovider.ErrNotFound)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestReviewClient(t *testing.T) {
+	pr := &PullRequest{
+		IDVersion: IDVersion{ID: 1, Version: 2},
+		Title:     "PR service",
+		Reviewers: []Participant{
+			{User: User{ID: 1, Name: "alice"}, Status: needsWorkStatus},
+			{User: User{ID: 3, Name: "carol"}, Status: approvedStatus},
+		},
+	}
+	var statuses []string
+	comment := ""
+	mux, client := setup(t)
+	client.username = "carol"
+	prPath := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(prPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			req := &PullRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Error(err)
+			}
+			pr.Reviewers = req.Reviewers
+		}
+		json.NewEncoder(w).Encode(pr)
+	})
+	mux.HandleFunc(prPath+"/participants/carol", func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		statuses = append(statuses, req["status"])
+		json.NewEncoder(w).Encode(&Participant{User: User{ID: 3, Name: "carol"}, Status: req["status"]})
+	})
+	mux.HandleFunc(prPath+"/comments", func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		comment = req["text"]
+		w.WriteHeader(http.StatusCreated)
+	})
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+		RepositoryName:  "my-repo",
+	}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	c := &ReviewClient{
+		clientContext: &clientContext{client: client},
+		ref:           ref,
+		number:        1,
+	}
+	ctx := context.Background()
+
+	if err := c.RequestReviewers(ctx, "alice", "bob"); err != nil {
+		t.Fatalf("RequestReviewers() error = %v", err)
+	}
+	// alice is a reviewer already
+	if len(pr.Reviewers) != 3 || pr.Reviewers[2].User.Name != "bob" {
+		t.Errorf("RequestReviewers() set reviewers %+v, want bob to be added", pr.Reviewers)
+	}
+
+	reviews, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	wantStates := []gitprovider.ReviewState{gitprovider.ReviewStateChangesRequested, gitprovider.ReviewStateApproved, gitprovider.ReviewStatePending}
+	if len(reviews) != len(wantStates) {
+		t.Fatalf("List() returned %d reviews, want %d", len(reviews), len(wantStates))
+	}
+	for i, want := range wantStates {
+		if got := reviews[i].Get().State; got != want {
+			t.Errorf("List()[%d] state = %q, want %q", i, got, want)
+		}
+	}
+
+	review, err := c.Approve(ctx, "LGTM")
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if got := review.Get(); got.ID != 3 || got.State != gitprovider.ReviewStateApproved || comment != "LGTM" {
+		t.Errorf("Approve() = %+v with comment %q, want the approval of carol and the comment", got, comment)
+	}
+
+	if err := c.Dismiss(ctx, 1, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Dismiss() error = %v, want ErrNoProviderSupport for the review of another user", err)
+	}
+	if err := c.Dismiss(ctx, 42, ""); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Dismiss() error = %v, want ErrNotFound", err)
+	}
+	if err := c.Dismiss(ctx, 3, ""); err != nil {
+		t.Fatalf("Dismiss() error = %v", err)
+	}
+	if want := []string{approvedStatus, unapprovedStatus}; fmt.Sprint(statuses) != fmt.Sprint(want) {
+		t.Errorf("participant statuses = %v, want %v", statuses, want)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 const (
@@ -125,6 +127,7 @@ func (s *CommitsService) List(ctx context.Context, projectKey, repositorySlug, b
 // ListPage retrieves all commits for a given page.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *CommitsService) ListPage(ctx context.Context, projectKey, repositorySlug, branch string, perPage, page int) ([]*CommitObject, error) {
+	perPage = gitprovider.CallOptionsFromContext(ctx).GetPerPage(perPage)
 	start := 0
 	if page > 0 {
 		start = (perPage * page) + 1
@@ -34,7 +34,6 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/filesystem"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -318,7 +317,7 @@ func (s *GitService) CloneRepository(ctx context.Context, URL string) (r *git.Re
 
 	r, err = git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
 		URL:      URL,
-		Auth:     &githttp.BasicAuth{Username: s.Client.username, Password: s.Client.token},
+		Auth:     s.Client.gitAuth(),
 		CABundle: s.Client.caBundle,
 	})
 	if err != nil {
@@ -327,7 +326,7 @@ func (s *GitService) CloneRepository(ctx context.Context, URL string) (r *git.Re
 
 	err = r.Fetch(&git.FetchOptions{
 		RefSpecs: []config.RefSpec{"refs/*:refs/*", "HEAD:refs/heads/HEAD"},
-		Auth:     &githttp.BasicAuth{Username: s.Client.username, Password: s.Client.token},
+		Auth:     s.Client.gitAuth(),
 		CABundle: s.Client.caBundle,
 	})
 
@@ -539,7 +538,7 @@ func (s *GitService) Push(ctx context.Context, r *git.Repository) error {
 
 	options := &git.PushOptions{
 		RemoteName: "origin",
-		Auth:       &githttp.BasicAuth{Username: s.Client.username, Password: s.Client.token},
+		Auth:       s.Client.gitAuth(),
 		CABundle:   s.Client.caBundle,
 	}
 
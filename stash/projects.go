@@ -22,12 +22,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 const (
 	projectsURI        = "projects"
 	groupPermisionsURI = "permissions/groups"
 	userPermisionsURI  = "permissions/users"
+	permissionsURI     = "permissions"
+	allUsersURI        = "all"
 )
 
 // Projects interface defines the methods that can be used to
@@ -40,6 +45,8 @@ type Projects interface {
 	ListProjectGroupsPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectGroups, error)
 	AllGroupsPermission(ctx context.Context, projectKey string) ([]*ProjectGroupPermission, error)
 	ListProjectUsersPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectUsers, error)
+	GetProjectDefaultPermission(ctx context.Context, projectKey, permission string) (bool, error)
+	SetProjectDefaultPermission(ctx context.Context, projectKey, permission string, allow bool) error
 }
 
 // ProjectsService is a client for communicating with stash projects endpoint
@@ -125,7 +132,7 @@ func (s *ProjectsService) List(ctx context.Context, opts *PagingOptions) (*Proje
 func (s *ProjectsService) All(ctx context.Context) ([]*Project, error) {
 	p := []*Project{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func() (*Paging, error) {
 		list, err := s.List(ctx, opts)
 		if err != nil {
 			return nil, err
@@ -292,7 +299,7 @@ func (s *ProjectsService) ListProjectGroupsPermission(ctx context.Context, proje
 func (s *ProjectsService) AllGroupsPermission(ctx context.Context, projectKey string) ([]*ProjectGroupPermission, error) {
 	p := []*ProjectGroupPermission{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func() (*Paging, error) {
 		list, err := s.ListProjectGroupsPermission(ctx, projectKey, opts)
 		if err != nil {
 			return nil, err
@@ -373,3 +380,72 @@ func (s *ProjectsService) ListProjectUsersPermission(ctx context.Context, projec
 
 	return up, nil
 }
+
+// projectDefaultPermission reports whether a permission is granted to all users for a project.
+type projectDefaultPermission struct {
+	Permitted bool `json:"permitted"`
+}
+
+// GetProjectDefaultPermission reports whether the given permission is granted to all users for the
+// specified project, i.e. whether it's the default permission of the project.
+// GetProjectDefaultPermission uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/permissions/{permission}/all".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project
+// or a higher global permission to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) GetProjectDefaultPermission(ctx context.Context, projectKey, permission string) (bool, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, permissionsURI, permission, allUsersURI))
+	if err != nil {
+		return false, fmt.Errorf("get project default permission request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return false, fmt.Errorf("get project default permission failed: %s: %w", resp.Status, gitprovider.ErrForbidden)
+	}
+	if err != nil {
+		return false, fmt.Errorf("get project default permission failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, ErrNotFound
+	}
+
+	p := &projectDefaultPermission{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return false, fmt.Errorf("get project default permission failed, unable to unmarshal permission json: %w", err)
+	}
+
+	return p.Permitted, nil
+}
+
+// SetProjectDefaultPermission grants or revokes the given permission to all users for the
+// specified project, i.e. sets or unsets it as the default permission of the project.
+// SetProjectDefaultPermission uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/permissions/{permission}/all?allow".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project
+// or a higher global permission to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) SetProjectDefaultPermission(ctx context.Context, projectKey, permission string, allow bool) error {
+	query := url.Values{
+		"allow": []string{strconv.FormatBool(allow)},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, permissionsURI, permission, allUsersURI), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("set project default permission request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("set project default permission failed: %s: %w", resp.Status, gitprovider.ErrForbidden)
+	}
+	if err != nil {
+		return fmt.Errorf("set project default permission failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("set project default permission failed: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -28,18 +28,39 @@ import (
 const (
 	pullRequestsURI = "pull-requests"
 	mergeURI        = "merge"
+	declineURI      = "decline"
+	reopenURI       = "reopen"
+	participantsURI = "participants"
+	commentsURI     = "comments"
+	activitiesURI   = "activities"
+	settingsURI     = "settings"
+	changesURI      = "changes"
+	diffSuffix      = ".diff"
 )
 
 // PullRequests interface defines the methods that can be used to
 // retrieve pull requests of a repository.
 type PullRequests interface {
 	Get(ctx context.Context, projectKey, repositorySlug string, prID int) (*PullRequest, error)
-	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*PullRequestList, error)
-	All(ctx context.Context, projectKey, repositorySlug string) ([]*PullRequest, error)
+	List(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter, opts *PagingOptions) (*PullRequestList, error)
+	All(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter) ([]*PullRequest, error)
 	Create(ctx context.Context, projectKey, repositorySlug string, pr *CreatePullRequest) (*PullRequest, error)
 	Update(ctx context.Context, projectKey, repositorySlug string, pr *PullRequest) (*PullRequest, error)
 	Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
+	MergeWithOptions(ctx context.Context, projectKey, repositorySlug string, prID int, version int, opts *MergeOptions) (*PullRequest, error)
+	GetMergeConfig(ctx context.Context, projectKey, repositorySlug string) (*MergeConfig, error)
+	Decline(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
+	Reopen(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
+	SetParticipantStatus(ctx context.Context, projectKey, repositorySlug string, prID int, userSlug, status string) (*Participant, error)
+	AddComment(ctx context.Context, projectKey, repositorySlug string, prID int, text string) error
+	ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error)
+	AllComments(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Comment, error)
+	CreateComment(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error)
+	UpdateComment(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error)
 	Delete(ctx context.Context, projectKey, repositorySlug string, IDVersion IDVersion) error
+	ListChanges(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ChangeList, error)
+	AllChanges(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Change, error)
+	GetDiff(ctx context.Context, projectKey, repositorySlug string, prID int) (string, error)
 }
 
 // PullRequestsService is a client for communicating with stash pull requests endpoint
@@ -79,6 +100,8 @@ type CreatePullRequest struct {
 	Closed bool `json:"closed,omitempty"`
 	// Description is the description of the pull request
 	Description string `json:"description,omitempty"`
+	// Draft indicates if the pull request is created as draft, it requires Bitbucket Server 8.18 or later
+	Draft *bool `json:"draft,omitempty"`
 	// FromRef is the source branch or tag
 	FromRef Ref `json:"fromRef,omitempty"`
 	// Locked indicates if the pull request is locked
@@ -115,6 +138,8 @@ type PullRequest struct {
 	CreatedDate int64 `json:"createdDate,omitempty"`
 	// Description is the description of the pull request
 	Description string `json:"description,omitempty"`
+	// Draft indicates if the pull request is a draft, it is only reported by Bitbucket Server 8.18 and later
+	Draft *bool `json:"draft,omitempty"`
 	// FromRef is the source branch or tag
 	FromRef Ref `json:"fromRef,omitempty"`
 	IDVersion
@@ -158,6 +183,126 @@ type MergeResult struct {
 	Outcome string `json:"outcome,omitempty"`
 }
 
+// Comment is a comment on a pull request
+type Comment struct {
+	// Anchor is the position of an inline comment in the diff, it is nil for general comments
+	Anchor *CommentAnchor `json:"anchor,omitempty"`
+	// Author is the author of the comment
+	Author *User `json:"author,omitempty"`
+	// Comments are the replies to the comment
+	Comments []*Comment `json:"comments,omitempty"`
+	// CreatedDate is the creation date of the comment
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// ID is the id of the comment
+	ID int64 `json:"id,omitempty"`
+	// Parent is the comment replied to, it is only used when creating a reply
+	Parent *CommentParent `json:"parent,omitempty"`
+	// Text is the text of the comment
+	Text string `json:"text,omitempty"`
+	// ThreadResolved indicates if the thread of the comment is resolved, it is only reported by Bitbucket Server 7.7 and later
+	ThreadResolved bool `json:"threadResolved,omitempty"`
+	// Version is the version of the comment, which has to be given when updating it
+	Version int `json:"version"`
+}
+
+// CommentAnchor is the position of an inline comment
+type CommentAnchor struct {
+	// DiffType is the type of diff the comment was added to, e.g. "EFFECTIVE"
+	DiffType string `json:"diffType,omitempty"`
+	// FileType is the side of the diff, i.e. "FROM" or "TO"
+	FileType string `json:"fileType,omitempty"`
+	// Line is the line number of the comment
+	Line int `json:"line,omitempty"`
+	// LineType is the type of line, i.e. "ADDED", "REMOVED" or "CONTEXT"
+	LineType string `json:"lineType,omitempty"`
+	// Path is the path of the file
+	Path string `json:"path,omitempty"`
+}
+
+// CommentParent references the comment a reply is added to
+type CommentParent struct {
+	// ID is the id of the comment replied to
+	ID int64 `json:"id"`
+}
+
+// Activity is an event on a pull request, e.g. a comment being added
+type Activity struct {
+	// Action is the action of the activity, e.g. "COMMENTED"
+	Action string `json:"action,omitempty"`
+	// Comment is the comment of "COMMENTED" activities
+	Comment *Comment `json:"comment,omitempty"`
+	// CommentAction is the action on the comment, e.g. "ADDED"
+	CommentAction string `json:"commentAction,omitempty"`
+	// CommentAnchor is the position of an inline comment, as reported by older versions
+	CommentAnchor *CommentAnchor `json:"commentAnchor,omitempty"`
+	// CreatedDate is the creation date of the activity
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// ID is the id of the activity
+	ID int64 `json:"id,omitempty"`
+}
+
+// ActivityList is a list of pull request activities
+type ActivityList struct {
+	// Paging is the paging information
+	Paging
+	// Activities are the activities
+	Activities []*Activity `json:"values,omitempty"`
+}
+
+// Change is a file changed by a pull request
+type Change struct {
+	// Path is the path of the file
+	Path ChangePath `json:"path"`
+	// SrcPath is the path the file has been moved or copied from
+	SrcPath *ChangePath `json:"srcPath,omitempty"`
+	// Type is the type of the change, i.e. ADD, MODIFY, DELETE, MOVE or COPY
+	Type string `json:"type,omitempty"`
+	// NodeType is the type of the changed node, i.e. FILE or SUBMODULE
+	NodeType string `json:"nodeType,omitempty"`
+}
+
+// ChangePath is the path of a changed file
+type ChangePath struct {
+	// ToString is the full path of the file
+	ToString string `json:"toString"`
+}
+
+// ChangeList is a list of the files changed by a pull request
+type ChangeList struct {
+	// Paging is the paging information
+	Paging
+	// Changes are the changed files
+	Changes []*Change `json:"values,omitempty"`
+}
+
+// MergeOptions are the optional parameters of merging a pull request
+type MergeOptions struct {
+	// Message is the message of the merge or squash commit
+	Message string `json:"message,omitempty"`
+	// StrategyID is the merge strategy, e.g. "no-ff" or "squash", it must be enabled for the repository
+	StrategyID string `json:"strategyId,omitempty"`
+}
+
+// MergeConfig is the merge configuration of a repository
+type MergeConfig struct {
+	// DefaultStrategy is the strategy used if none is given when merging
+	DefaultStrategy MergeStrategy `json:"defaultStrategy,omitempty"`
+	// Strategies are the available merge strategies
+	Strategies []MergeStrategy `json:"strategies,omitempty"`
+	// Type is the level the configuration is inherited from, e.g. "REPOSITORY" or "PROJECT"
+	Type string `json:"type,omitempty"`
+}
+
+// MergeStrategy is a strategy for merging pull requests
+type MergeStrategy struct {
+	// Enabled indicates if the strategy may be used for the repository
+	Enabled bool `json:"enabled,omitempty"`
+	// ID is the id of the strategy, e.g. "no-ff", "ff-only", "rebase-ff-only" or "squash"
+	ID string `json:"id,omitempty"`
+	// Name is the display name of the strategy
+	Name string `json:"name,omitempty"`
+}
+
 // PullRequestList is a list of pull requests
 type PullRequestList struct {
 	// Paging is the paging information
@@ -171,13 +316,36 @@ func (p *PullRequestList) GetPullRequests() []*PullRequest {
 	return p.PullRequests
 }
 
+// PullRequestFilter filters the pull requests returned by List and All.
+type PullRequestFilter struct {
+	// State is one of OPEN, DECLINED, MERGED or ALL, Stash returns the open pull requests by default
+	State string
+	// At is the fully qualified name of the branch the pull requests are filtered by, e.g. refs/heads/main
+	At string
+	// Direction is INCOMING to filter At by the target branch (default), or OUTGOING to filter it by the source branch
+	Direction string
+}
+
 // List returns the list of pull requests.
+// Filtering is optional and is enabled by providing a PullRequestFilter struct.
 // Paging is optional and is enabled by providing a PagingOptions struct.
 // A pointer to a PullRequestsList struct is returned to retrieve the next page of results.
 // List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests".
 // https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
-func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*PullRequestList, error) {
-	query := addPaging(url.Values{}, opts)
+func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter, opts *PagingOptions) (*PullRequestList, error) {
+	values := url.Values{}
+	if filter != nil {
+		if filter.State != "" {
+			values.Add("state", filter.State)
+		}
+		if filter.At != "" {
+			values.Add("at", filter.At)
+		}
+		if filter.Direction != "" {
+			values.Add("direction", filter.Direction)
+		}
+	}
+	query := addPaging(values, opts)
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI), WithQuery(query))
 	if err != nil {
 		return nil, fmt.Errorf("list pull requests request creation failed: %w", err)
@@ -205,11 +373,11 @@ func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySl
 
 // All retrieves all pull requests for a given repository.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
-func (s *PullRequestsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*PullRequest, error) {
+func (s *PullRequestsService) All(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter) ([]*PullRequest, error) {
 	pr := []*PullRequest{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
-		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+	err := allPages(ctx, opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, filter, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -315,13 +483,29 @@ func (s *PullRequestsService) Update(ctx context.Context, projectKey, repository
 // Merge the pull request with the given ID and version.
 // Merge uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/merge?version".
 func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error) {
+	return s.MergeWithOptions(ctx, projectKey, repositorySlug, prID, version, nil)
+}
+
+// MergeWithOptions merges the pull request with the given ID and version, using the commit message and
+// merge strategy of opts if set.
+// MergeWithOptions uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/merge?version".
+func (s *PullRequestsService) MergeWithOptions(ctx context.Context, projectKey, repositorySlug string, prID int, version int, opts *MergeOptions) (*PullRequest, error) {
 	query := url.Values{
 		"version": []string{strconv.Itoa(version)},
 	}
 
 	header := http.Header{"X-Atlassian-Token": []string{"no-check"}}
+	reqOpts := []RequestOptionFunc{WithQuery(query), WithHeader(header)}
+	if opts != nil {
+		header.Set("Content-Type", "application/json")
+		body, err := marshallBody(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshall merge options: %v", err)
+		}
+		reqOpts = append(reqOpts, WithBody(body))
+	}
 
-	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), mergeURI), WithQuery(query), WithHeader(header))
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), mergeURI), reqOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("merge pull request request creation failed: %w", err)
 	}
@@ -348,6 +532,268 @@ func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositoryS
 	return p, nil
 }
 
+// GetMergeConfig retrieves the merge configuration of the repository, i.e. which merge strategies are enabled.
+// GetMergeConfig uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/settings/pull-requests".
+func (s *PullRequestsService) GetMergeConfig(ctx context.Context, projectKey, repositorySlug string) (*MergeConfig, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, settingsURI, pullRequestsURI))
+	if err != nil {
+		return nil, fmt.Errorf("get merge config request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get merge config failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	settings := &struct {
+		MergeConfig MergeConfig `json:"mergeConfig"`
+	}{}
+	if err := json.Unmarshal(res, settings); err != nil {
+		return nil, fmt.Errorf("get merge config failed, unable to unmarshal pull request settings json: %w", err)
+	}
+
+	return &settings.MergeConfig, nil
+}
+
+// Decline declines the pull request with the given ID and version, i.e. closes it without merging.
+// Decline uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/decline?version".
+func (s *PullRequestsService) Decline(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error) {
+	return s.transition(ctx, projectKey, repositorySlug, prID, version, declineURI)
+}
+
+// Reopen reopens the declined pull request with the given ID and version.
+// Reopen uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/reopen?version".
+func (s *PullRequestsService) Reopen(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error) {
+	return s.transition(ctx, projectKey, repositorySlug, prID, version, reopenURI)
+}
+
+// transition posts to the given state transition endpoint of a pull request, e.g. "decline".
+func (s *PullRequestsService) transition(ctx context.Context, projectKey, repositorySlug string, prID int, version int, action string) (*PullRequest, error) {
+	query := url.Values{
+		"version": []string{strconv.Itoa(version)},
+	}
+
+	header := http.Header{"X-Atlassian-Token": []string{"no-check"}}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), action), WithQuery(query), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("%s pull request request creation failed: %w", action, err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s pull request failed: %w", action, err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s pull request failed with status code %d, error: %s", action, resp.StatusCode, res)
+	}
+
+	p := &PullRequest{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("%s pull request failed, unable to unmarshal pull request json: %w", action, err)
+	}
+
+	p.Session.set(resp)
+
+	return p, nil
+}
+
+// SetParticipantStatus sets the review status of the user with the given slug, i.e. "APPROVED",
+// "NEEDS_WORK" or "UNAPPROVED". The user is added as participant if needed.
+// SetParticipantStatus uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/participants/{userSlug}".
+func (s *PullRequestsService) SetParticipantStatus(ctx context.Context, projectKey, repositorySlug string, prID int, userSlug, status string) (*Participant, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(map[string]string{"status": status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall participant: %v", err)
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), participantsURI, userSlug), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("set participant status request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("set participant status failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("set participant status failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	p := &Participant{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("set participant status failed, unable to unmarshal participant json: %w", err)
+	}
+
+	return p, nil
+}
+
+// AddComment adds a general comment with the given text to the pull request.
+// AddComment uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments".
+func (s *PullRequestsService) AddComment(ctx context.Context, projectKey, repositorySlug string, prID int, text string) error {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshall comment: %v", err)
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return fmt.Errorf("add comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("add comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("add comment failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	return nil
+}
+
+// ListActivities returns the activities of the pull request, most recent first.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// ListActivities uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/activities".
+func (s *PullRequestsService) ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), activitiesURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list activities request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list activities failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	a := &ActivityList{}
+	if err := json.Unmarshal(res, a); err != nil {
+		return nil, fmt.Errorf("list activities failed, unable to unmarshal activity list json: %w", err)
+	}
+
+	return a, nil
+}
+
+// AllComments retrieves the top-level comments of the pull request, with their replies nested.
+// Comments are only listed as part of the activities of a pull request, the anchor of inline
+// comments is filled in from the activity if the server doesn't report it on the comment.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *PullRequestsService) AllComments(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Comment, error) {
+	comments := []*Comment{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func() (*Paging, error) {
+		list, err := s.ListActivities(ctx, projectKey, repositorySlug, prID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range list.Activities {
+			if a.Action != "COMMENTED" || a.CommentAction != "ADDED" || a.Comment == nil {
+				continue
+			}
+			if a.Comment.Anchor == nil {
+				a.Comment.Anchor = a.CommentAnchor
+			}
+			comments = append(comments, a.Comment)
+		}
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// CreateComment adds a comment to the pull request. Inline comments must have an anchor,
+// replies must have a parent.
+// CreateComment uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments".
+func (s *PullRequestsService) CreateComment(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall comment: %v", err)
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("create comment failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("create comment failed, unable to unmarshal comment json: %w", err)
+	}
+
+	return c, nil
+}
+
+// UpdateComment updates the comment with the given ID, the version of the comment must be the current one.
+// UpdateComment uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments/{commentId}".
+func (s *PullRequestsService) UpdateComment(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall comment: %v", err)
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI, strconv.FormatInt(comment.ID, 10)), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update comment failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("update comment failed, unable to unmarshal comment json: %w", err)
+	}
+
+	return c, nil
+}
+
 // Delete deletes the pull request with the given ID
 // Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}".
 // To call this resource, users must:
@@ -377,3 +823,69 @@ func (s *PullRequestsService) Delete(ctx context.Context, projectKey, repository
 
 	return nil
 }
+
+// ListChanges returns the files changed by the pull request.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// ListChanges uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/changes".
+func (s *PullRequestsService) ListChanges(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ChangeList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), changesURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list changes request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list changes failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &ChangeList{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("list changes failed, unable to unmarshal change list json: %w", err)
+	}
+
+	return c, nil
+}
+
+// AllChanges retrieves all files changed by the pull request.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *PullRequestsService) AllChanges(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Change, error) {
+	changes := []*Change{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func() (*Paging, error) {
+		list, err := s.ListChanges(ctx, projectKey, repositorySlug, prID, opts)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, list.Changes...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// GetDiff returns the raw unified diff of the pull request.
+// GetDiff uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}.diff".
+func (s *PullRequestsService) GetDiff(ctx context.Context, projectKey, repositorySlug string, prID int) (string, error) {
+	header := http.Header{"Accept": []string{"text/plain"}}
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID)+diffSuffix), WithHeader(header))
+	if err != nil {
+		return "", fmt.Errorf("get diff request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get diff failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+
+	return string(res), nil
+}
@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -28,6 +29,8 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestGetPR(t *testing.T) {
@@ -134,7 +137,7 @@ func TestListPRs(t *testing.T) {
 
 	})
 	ctx := context.Background()
-	list, err := client.PullRequests.List(ctx, "prj1", "repo1", nil)
+	list, err := client.PullRequests.List(ctx, "prj1", "repo1", nil, nil)
 	if err != nil {
 		t.Fatalf("PullRequests.List returned error: %v", err)
 	}
@@ -490,3 +493,189 @@ func TestDeletePR(t *testing.T) {
 		})
 	}
 }
+
+func TestDeclineReopenPR(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    string
+		wantState string
+	}{
+		{
+			name:      "decline",
+			action:    declineURI,
+			wantState: declinedState,
+		},
+		{
+			name:      "reopen",
+			action:    reopenURI,
+			wantState: openState,
+		},
+	}
+
+	mux, client := setup(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, tt.action)
+			mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Query().Get("version") != "2" {
+					http.Error(w, "The pull request is out of date", http.StatusConflict)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 3}, Title: "PR service", State: tt.wantState})
+			})
+			ctx := context.Background()
+			var pr *PullRequest
+			var err error
+			if tt.action == declineURI {
+				pr, err = client.PullRequests.Decline(ctx, "prj", "my-repo", 1, 2)
+			} else {
+				pr, err = client.PullRequests.Reopen(ctx, "prj", "my-repo", 1, 2)
+			}
+			if err != nil {
+				t.Fatalf("PullRequests.%s returned error: %v", tt.name, err)
+			}
+			if pr.State != tt.wantState || pr.Version != 3 {
+				t.Errorf("PullRequests.%s returned state %q and version %d, want %q and 3", tt.name, pr.State, pr.Version, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestMergePR(t *testing.T) {
+	var merge *MergeOptions
+	mux, client := setup(t)
+	repoPath := fmt.Sprintf("%s/%s/prj/%s/my-repo", stashURIprefix, projectsURI, RepositoriesURI)
+	mux.HandleFunc(repoPath+"/settings/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"mergeConfig":{"strategies":[{"id":"no-ff","enabled":true},{"id":"squash","enabled":true},{"id":"ff-only","enabled":false}]}}`))
+	})
+	mux.HandleFunc(repoPath+"/pull-requests/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 2}, Title: "PR service"})
+	})
+	mux.HandleFunc(repoPath+"/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("version") != "2" {
+			http.Error(w, "The pull request is out of date", http.StatusConflict)
+			return
+		}
+		merge = &MergeOptions{}
+		if err := json.NewDecoder(r.Body).Decode(merge); err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 3}, Title: "PR service", State: mergedState})
+	})
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+		RepositoryName:  "my-repo",
+	}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	c := &PullRequestClient{
+		clientContext: &clientContext{client: client},
+		ref:           ref,
+	}
+	ctx := context.Background()
+
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodSquash, "message", &gitprovider.PullRequestMergeOptions{CommitTitle: gitprovider.StringVar("title")}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if want := (MergeOptions{Message: "title\n\nmessage", StrategyID: "squash"}); merge == nil || *merge != want {
+		t.Errorf("Merge() sent %+v, want %+v", merge, want)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodFastForward, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() with a disabled strategy error = %v, want ErrInvalidArgument", err)
+	}
+	if err := c.Merge(ctx, 1, gitprovider.MergeMethodRebase, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Merge() with an unlisted strategy error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestListFilteredPRs(t *testing.T) {
+	mux, client := setup(t)
+	path := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != mergedState || query.Get("at") != "refs/heads/main" || query.Get("direction") != "" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		alice := &Participant{User: User{Name: "alice"}}
+		bob := &Participant{User: User{Name: "bob"}}
+		json.NewEncoder(w).Encode(&PullRequestList{
+			Paging: Paging{IsLastPage: true},
+			PullRequests: []*PullRequest{
+				{IDVersion: IDVersion{ID: 1}, Author: alice, FromRef: Ref{DisplayID: "feature"}, State: mergedState},
+				{IDVersion: IDVersion{ID: 2}, Author: bob, FromRef: Ref{DisplayID: "feature"}, State: mergedState},
+				{IDVersion: IDVersion{ID: 3}, Author: alice, FromRef: Ref{DisplayID: "fix"}, State: mergedState},
+			},
+		})
+	})
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+		RepositoryName:  "my-repo",
+	}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	c := &PullRequestClient{
+		clientContext: &clientContext{client: client},
+		ref:           ref,
+	}
+	ctx := context.Background()
+
+	prs, err := c.List(ctx, &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateMerged),
+		BaseBranch:   gitprovider.StringVar("main"),
+		SourceBranch: gitprovider.StringVar("feature"),
+		Author:       gitprovider.StringVar("alice"),
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().Number != 1 {
+		t.Errorf("List() = %v, want only pull request 1", prs)
+	}
+	if _, err := c.List(ctx, &gitprovider.PullRequestListOptions{Label: gitprovider.StringVar("release")}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("List() by label error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestPRFilesAndDiff(t *testing.T) {
+	const diff = "diff --git a/README.md b/README.md\n"
+	mux, client := setup(t)
+	prPath := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(prPath+"/changes", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"isLastPage":true,"values":[
+			{"path":{"toString":"README.md"},"type":"MODIFY","nodeType":"FILE"},
+			{"path":{"toString":"docs/new.md"},"srcPath":{"toString":"docs/old.md"},"type":"MOVE","nodeType":"FILE"}
+		]}`))
+	})
+	mux.HandleFunc(prPath+".diff", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(diff))
+	})
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+		RepositoryName:  "my-repo",
+	}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	pr := newPullRequest(&clientContext{client: client}, ref, &PullRequest{IDVersion: IDVersion{ID: 1}})
+	ctx := context.Background()
+
+	files, err := pr.Files(ctx)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	want := []gitprovider.PullRequestFile{
+		{Path: "README.md", ChangeType: gitprovider.FileChangeTypeModified},
+		{Path: "docs/new.md", PreviousPath: "docs/old.md", ChangeType: gitprovider.FileChangeTypeRenamed},
+	}
+	if d := cmp.Diff(want, files); d != "" {
+		t.Errorf("Files() returned diff (want -> got):\n%s", d)
+	}
+	got, err := pr.Diff(ctx)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if got != diff {
+		t.Errorf("Diff() = %q, want %q", got, diff)
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 var (
@@ -49,6 +51,7 @@ type Repositories interface {
 type RepositoryManager interface {
 	List(ctx context.Context, projectKey string, opts *PagingOptions) (*RepositoryList, error)
 	All(ctx context.Context, projectKey string) ([]*Repository, error)
+	AllWithProgress(ctx context.Context, projectKey string, progress gitprovider.ListProgressFunc) ([]*Repository, error)
 	Get(ctx context.Context, projectKey, repoSlug string) (*Repository, error)
 	Create(ctx context.Context, projectKey string, repository *Repository) (*Repository, error)
 	Update(ctx context.Context, projectKey, repositorySlug string, repository *Repository) (*Repository, error)
@@ -153,14 +156,28 @@ func (s *RepositoriesService) List(ctx context.Context, projectKey string, opts
 // All retrieves all repositories for a given project.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *RepositoriesService) All(ctx context.Context, projectKey string) ([]*Repository, error) {
+	return s.AllWithProgress(ctx, projectKey, nil)
+}
+
+// AllWithProgress retrieves all repositories for a given project like All, and calls progress
+// after each page. Stash doesn't report the total number of repositories, hence it is only
+// known once the last page has been retrieved.
+func (s *RepositoriesService) AllWithProgress(ctx context.Context, projectKey string, progress gitprovider.ListProgressFunc) ([]*Repository, error) {
 	r := []*Repository{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func() (*Paging, error) {
 		list, err := s.List(ctx, projectKey, opts)
 		if err != nil {
 			return nil, err
 		}
 		r = append(r, list.GetRepositories()...)
+		if progress != nil {
+			p := gitprovider.ListProgress{Count: len(r)}
+			if list.IsLast() {
+				p.EstimatedTotal = len(r)
+			}
+			progress(p)
+		}
 		return &list.Paging, nil
 	})
 	if err != nil {
@@ -398,7 +415,7 @@ func (s *RepositoriesService) ListRepositoryGroupsPermission(ctx context.Context
 func (s *RepositoriesService) AllGroupsPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryGroupPermission, error) {
 	p := []*RepositoryGroupPermission{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func() (*Paging, error) {
 		list, err := s.ListRepositoryGroupsPermission(ctx, projectKey, repositorySlug, opts)
 		if err != nil {
 			return nil, err
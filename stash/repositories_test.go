@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -26,6 +27,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestGetRepository(t *testing.T) {
@@ -660,3 +663,52 @@ func TestListRepositoryUsersPermission(t *testing.T) {
 	}
 
 }
+
+func TestOrgRepositoriesClient_Reconcile_unsupportedSettings(t *testing.T) {
+	mux, client := setup(t)
+	repo := &Repository{ID: 1, Name: "repo1", Slug: "repo1", Description: "old"}
+	var updates int
+	mux.HandleFunc(fmt.Sprintf("%s/%s/prj1/%s/repo1", stashURIprefix, projectsURI, RepositoriesURI), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(repo); err != nil {
+				t.Error(err)
+			}
+			updates++
+		}
+		json.NewEncoder(w).Encode(repo)
+	})
+	mux.HandleFunc(fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/%s", stashURIprefix, projectsURI, RepositoriesURI, branchesURI, defaultBranchURI), func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Branch{ID: "refs/heads/main", DisplayID: "main"})
+	})
+
+	c := &OrgRepositoriesClient{clientContext: &clientContext{client: client, host: client.BaseURL.Host}}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: client.BaseURL.Host, Organization: "prj1"},
+		RepositoryName:  "repo1",
+	}
+	ref.SetKey("prj1")
+	req := gitprovider.RepositoryInfo{
+		Description:         gitprovider.StringVar("new"),
+		DefaultBranch:       gitprovider.StringVar("main"),
+		Visibility:          gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+		DeleteBranchOnMerge: gitprovider.BoolVar(true),
+	}
+
+	// The supported settings are reconciled
+	_, actionTaken, err := c.Reconcile(context.Background(), ref, req)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Fatalf("Reconcile() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if !actionTaken || updates != 1 || repo.Description != "new" {
+		t.Errorf("Reconcile() actionTaken = %v with %d updates and description %q, want the description to be updated", actionTaken, updates, repo.Description)
+	}
+
+	// Once they match, nothing is updated, but the unsupported setting is still reported
+	_, actionTaken, err = c.Reconcile(context.Background(), ref, req)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Fatalf("Reconcile() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if actionTaken || updates != 1 {
+		t.Errorf("Reconcile() actionTaken = %v with %d updates, want no update", actionTaken, updates)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// branchRefPrefix is the prefix of the refs of branches.
+const branchRefPrefix = "refs/heads/"
+
+// protectingRestrictions are the restriction types every protected branch has, preventing the
+// deletion of the branch and rewriting its history.
+//
+//nolint:gochecknoglobals
+var protectingRestrictions = []string{restrictionNoDeletes, restrictionFastForwardOnly}
+
+func newBranchProtection(c *BranchProtectionClient, branch string) *branchProtection {
+	return &branchProtection{
+		branch: branch,
+		c:      c,
+	}
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	branch string
+	// restrictions are the restrictions of the branch that make up the rule.
+	restrictions []*BranchRestriction
+	// allowedPushers is the desired state set using Set, which is applied by Update.
+	allowedPushers []string
+	c              *BranchProtectionClient
+}
+
+// addRestriction adds a restriction of the branch to the rule.
+func (bp *branchProtection) addRestriction(apiObj *BranchRestriction) {
+	bp.restrictions = append(bp.restrictions, apiObj)
+	if apiObj.Type == restrictionReadOnly {
+		bp.allowedPushers = restrictionUsernames(apiObj)
+	}
+}
+
+// restriction returns the restriction of the given type, or nil if there is none.
+func (bp *branchProtection) restriction(restrictionType string) *BranchRestriction {
+	for _, r := range bp.restrictions {
+		if r.Type == restrictionType {
+			return r
+		}
+	}
+	return nil
+}
+
+// complete returns whether the branch has all restrictions of a protected branch.
+func (bp *branchProtection) complete() bool {
+	for _, restrictionType := range protectingRestrictions {
+		if bp.restriction(restrictionType) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	info := gitprovider.BranchProtectionInfo{
+		Branch:                  bp.branch,
+		RequiredApprovals:       gitprovider.IntVar(0),
+		RequireCodeOwnerReviews: gitprovider.BoolVar(false),
+		EnforceAdmins:           gitprovider.BoolVar(false),
+	}
+	if r := bp.restriction(restrictionReadOnly); r != nil {
+		info.AllowedPushers = restrictionUsernames(r)
+	}
+	return info
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Branch != bp.branch {
+		return fmt.Errorf("can't move the protection rule of branch %q to %q: %w", bp.branch, info.Branch, gitprovider.ErrInvalidArgument)
+	}
+	if err := validateBranchProtectionSupport(info); err != nil {
+		return err
+	}
+	bp.allowedPushers = info.AllowedPushers
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return bp.restrictions
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The missing restrictions
+// are created, and the read-only restriction is replaced if the allowed pushers changed.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(bp.c.ref)
+	restrictions := []*BranchRestriction{}
+	for _, restrictionType := range protectingRestrictions {
+		r := bp.restriction(restrictionType)
+		if r == nil {
+			var err error
+			r, err = bp.c.client.BranchRestrictions.Create(ctx, projectKey, repoSlug, branchRestrictionRequest(restrictionType, bp.branch, nil))
+			if err != nil {
+				return err
+			}
+		}
+		restrictions = append(restrictions, r)
+	}
+
+	readOnly := bp.restriction(restrictionReadOnly)
+	if readOnly != nil && !sameUsernames(restrictionUsernames(readOnly), bp.allowedPushers) {
+		if err := bp.c.client.BranchRestrictions.Delete(ctx, projectKey, repoSlug, readOnly.ID); err != nil {
+			return err
+		}
+		readOnly = nil
+	}
+	if readOnly == nil && len(bp.allowedPushers) != 0 {
+		var err error
+		readOnly, err = bp.c.client.BranchRestrictions.Create(ctx, projectKey, repoSlug, branchRestrictionRequest(restrictionReadOnly, bp.branch, bp.allowedPushers))
+		if err != nil {
+			return err
+		}
+	}
+	if readOnly != nil {
+		restrictions = append(restrictions, readOnly)
+	}
+	bp.restrictions = restrictions
+	return nil
+}
+
+// Delete unprotects the branch by deleting all its restrictions.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(bp.c.ref)
+	for _, r := range bp.restrictions {
+		if err := bp.c.client.BranchRestrictions.Delete(ctx, projectKey, repoSlug, r.ID); err != nil {
+			return fmt.Errorf("failed to delete the %s restriction of branch %q: %w", r.Type, bp.branch, err)
+		}
+	}
+	return nil
+}
+
+// restrictedBranch returns the branch a restriction applies to, if it's one of the
+// restrictions of a protected branch.
+func restrictedBranch(apiObj *BranchRestriction) (string, bool) {
+	if apiObj.Matcher.Type.ID != branchMatcherType || !strings.HasPrefix(apiObj.Matcher.ID, branchRefPrefix) {
+		return "", false
+	}
+	switch apiObj.Type {
+	case restrictionReadOnly, restrictionNoDeletes, restrictionFastForwardOnly:
+		return strings.TrimPrefix(apiObj.Matcher.ID, branchRefPrefix), true
+	}
+	return "", false
+}
+
+// restrictionUsernames returns the names of the users exempted from the restriction.
+func restrictionUsernames(apiObj *BranchRestriction) []string {
+	var names []string
+	for _, user := range apiObj.Users {
+		names = append(names, user.Name)
+	}
+	return names
+}
+
+// sameUsernames returns whether both lists contain the same usernames, regardless of their
+// order and case.
+func sameUsernames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	names := make(map[string]int, len(a))
+	for _, name := range a {
+		names[strings.ToLower(name)]++
+	}
+	for _, name := range b {
+		name = strings.ToLower(name)
+		if names[name] == 0 {
+			return false
+		}
+		names[name]--
+	}
+	return true
+}
+
+func branchRestrictionRequest(restrictionType, branch string, users []string) *BranchRestrictionRequest {
+	return &BranchRestrictionRequest{
+		Type: restrictionType,
+		Matcher: BranchRestrictionMatcher{
+			ID:        branchRefPrefix + branch,
+			DisplayID: branch,
+			Type:      BranchRestrictionMatcherType{ID: branchMatcherType},
+			Active:    true,
+		},
+		Users: users,
+	}
+}
+
+// validateBranchProtectionSupport returns ErrNoProviderSupport for the settings that Stash
+// branch permissions can't enforce. Required approvals and builds are merge checks of pull
+// requests instead.
+func validateBranchProtectionSupport(info gitprovider.BranchProtectionInfo) error {
+	if gitprovider.IsBranchPattern(info.Branch) {
+		return fmt.Errorf("protecting the branch pattern %q isn't implemented for stash: %w", info.Branch, gitprovider.ErrNoProviderSupport)
+	}
+	if info.RequiredApprovals != nil && *info.RequiredApprovals != 0 {
+		return fmt.Errorf("stash branch permissions can't require approvals: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.RequireCodeOwnerReviews != nil && *info.RequireCodeOwnerReviews {
+		return fmt.Errorf("stash branch permissions can't require the reviews of code owners: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if len(info.RequiredStatusChecks) != 0 {
+		return fmt.Errorf("stash branch permissions can't require status checks: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.EnforceAdmins != nil && *info.EnforceAdmins {
+		return fmt.Errorf("stash branch permissions can't be enforced for administrators separately: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
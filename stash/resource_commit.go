@@ -45,9 +45,10 @@ func (c *commitType) APIObject() interface{} {
 func commitFromAPI(commit CommitObject) gitprovider.CommitInfo {
 	t := time.Unix(commit.AuthorTimestamp, 0)
 	return gitprovider.CommitInfo{
-		Sha:       commit.ID,
-		Author:    commit.Author.Name,
-		Message:   commit.Message,
-		CreatedAt: t,
+		Sha:         commit.ID,
+		Author:      commit.Author.Name,
+		AuthorEmail: commit.Author.EmailAddress,
+		Message:     commit.Message,
+		CreatedAt:   t,
 	}
 }
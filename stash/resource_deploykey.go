@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 
@@ -59,6 +60,11 @@ func (dk *deployKey) APIObject() interface{} {
 	return &dk.k
 }
 
+// LastUsedAt always returns nil, as Stash doesn't expose when an access key was last used.
+func (dk *deployKey) LastUsedAt() *time.Time {
+	return nil
+}
+
 func (dk *deployKey) Repository() gitprovider.RepositoryRef {
 	return dk.c.ref
 }
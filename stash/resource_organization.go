@@ -25,9 +25,13 @@ var _ gitprovider.Organization = &Organization{}
 
 // Organization represents a project in the Stash provider.
 type Organization struct {
-	p     Project
-	ref   gitprovider.OrganizationRef
-	teams *TeamsClient
+	p                 Project
+	ref               gitprovider.OrganizationRef
+	teams             *TeamsClient
+	runners           *RunnersClient
+	appAuthorizations *AppAuthorizationsClient
+	projectBoards     *ProjectBoardsClient
+	webhooks          *OrganizationWebhookClient
 }
 
 // Get returns the organization's information, Name and description.
@@ -50,6 +54,26 @@ func (o *Organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+// Runners gives access to the RunnersClient for this specific organization
+func (o *Organization) Runners() gitprovider.RunnersClient {
+	return o.runners
+}
+
+// AppAuthorizations gives access to the AppAuthorizationsClient for this specific organization
+func (o *Organization) AppAuthorizations() gitprovider.AppAuthorizationsClient {
+	return o.appAuthorizations
+}
+
+// ProjectBoards gives access to the ProjectBoardsClient for this specific organization
+func (o *Organization) ProjectBoards() gitprovider.ProjectBoardsClient {
+	return o.projectBoards
+}
+
+// Webhooks gives access to the OrganizationWebhookClient for this specific organization
+func (o *Organization) Webhooks() gitprovider.OrganizationWebhookClient {
+	return o.webhooks
+}
+
 func organizationFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        &apiObj.Name,
@@ -65,5 +89,12 @@ func newOrganization(ctx *clientContext, apiObj *Project, ref gitprovider.Organi
 			clientContext: ctx,
 			ref:           ref,
 		},
+		runners:           &RunnersClient{},
+		appAuthorizations: &AppAuthorizationsClient{},
+		projectBoards:     &ProjectBoardsClient{},
+		webhooks: &OrganizationWebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
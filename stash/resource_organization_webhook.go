@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newProjectWebhook(c *OrganizationWebhookClient, apiObj *Webhook) *projectWebhook {
+	return &projectWebhook{
+		h: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.OrganizationWebhook = &projectWebhook{}
+
+type projectWebhook struct {
+	h Webhook
+	c *OrganizationWebhookClient
+}
+
+func (wh *projectWebhook) ID() string {
+	return strconv.Itoa(wh.h.ID)
+}
+
+func (wh *projectWebhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *projectWebhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookSupport(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	return nil
+}
+
+func (wh *projectWebhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *projectWebhook) Organization() gitprovider.OrganizationRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *projectWebhook) Update(ctx context.Context) error {
+	apiObj, err := wh.c.client.Webhooks.UpdateProjectWebhook(ctx, wh.c.ref.Key(), &wh.h)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook %d: %w", wh.h.ID, err)
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the project.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *projectWebhook) Delete(ctx context.Context) error {
+	if err := wh.c.client.Webhooks.DeleteProjectWebhook(ctx, wh.c.ref.Key(), wh.h.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook %d: %w", wh.h.ID, err)
+	}
+	return nil
+}
@@ -17,22 +17,37 @@ limitations under the License.
 package stash
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
-// The value of the "State" field of a Stash pull request after it has been merged"
-const mergedState = "MERGED"
+// The values of the "State" field of a Stash pull request.
+const (
+	// mergedState is the state of a pull request after it has been merged.
+	mergedState = "MERGED"
+	// declinedState is the state of a pull request that has been declined, i.e. closed without merging.
+	declinedState = "DECLINED"
+	// openState is the state of an open pull request.
+	openState = "OPEN"
+)
 
-func newPullRequest(apiObj *PullRequest) *pullrequest {
+func newPullRequest(ctx *clientContext, ref gitprovider.RepositoryRef, apiObj *PullRequest) *pullrequest {
 	return &pullrequest{
-		pr: *apiObj,
+		clientContext: ctx,
+		ref:           ref,
+		pr:            *apiObj,
 	}
 }
 
 var _ gitprovider.PullRequest = &pullrequest{}
 
 type pullrequest struct {
-	pr PullRequest
+	*clientContext
+
+	ref gitprovider.RepositoryRef
+	pr  PullRequest
 }
 
 func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
@@ -43,6 +58,114 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Reviews gives access to the reviewers of the pull request.
+func (pr *pullrequest) Reviews() gitprovider.ReviewClient {
+	return &ReviewClient{
+		clientContext: pr.clientContext,
+		ref:           pr.ref,
+		number:        pr.pr.ID,
+	}
+}
+
+// Comments gives access to the comments of the pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{
+		clientContext: pr.clientContext,
+		ref:           pr.ref,
+		number:        pr.pr.ID,
+	}
+}
+
+// Files lists the files changed by the pull request. Stash doesn't report the number of added
+// and deleted lines, nor the patches of the files, see Diff.
+func (pr *pullrequest) Files(ctx context.Context) ([]gitprovider.PullRequestFile, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(pr.ref)
+
+	apiObjs, err := pr.client.PullRequests.AllChanges(ctx, projectKey, repoSlug, pr.pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changes of PR %d: %w", pr.pr.ID, err)
+	}
+	files := make([]gitprovider.PullRequestFile, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		file := gitprovider.PullRequestFile{
+			Path:       apiObj.Path.ToString,
+			ChangeType: fileChangeTypeFromAPI(apiObj.Type),
+		}
+		if apiObj.SrcPath != nil && apiObj.SrcPath.ToString != apiObj.Path.ToString {
+			file.PreviousPath = apiObj.SrcPath.ToString
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Diff returns the unified diff of the pull request.
+func (pr *pullrequest) Diff(ctx context.Context) (string, error) {
+	projectKey, repoSlug := getProjectKeyAndSlug(pr.ref)
+
+	diff, err := pr.client.PullRequests.GetDiff(ctx, projectKey, repoSlug, pr.pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff of PR %d: %w", pr.pr.ID, err)
+	}
+	return diff, nil
+}
+
+// EnableAutoMerge is not supported for Stash pull requests.
+func (pr *pullrequest) EnableAutoMerge(_ context.Context, _ gitprovider.MergeMethod) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetLabels is not supported, as Stash has no labels on pull requests.
+func (pr *pullrequest) SetLabels(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetAssignees is not supported, as Stash pull requests have reviewers, but no assignees.
+func (pr *pullrequest) SetAssignees(_ context.Context, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// SetMilestone is not supported, as Stash has no milestones.
+func (pr *pullrequest) SetMilestone(_ context.Context, _ int) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// AddReaction is not supported, as Stash doesn't have reactions on pull requests.
+func (pr *pullrequest) AddReaction(_ context.Context, _ gitprovider.ReactionContent) (gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListReactions is not supported, as Stash doesn't have reactions on pull requests.
+func (pr *pullrequest) ListReactions(_ context.Context) ([]gitprovider.Reaction, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// fileChangeTypeFromAPI maps the type of a change to a gitprovider.FileChangeType.
+func fileChangeTypeFromAPI(changeType string) gitprovider.FileChangeType {
+	switch changeType {
+	case "ADD":
+		return gitprovider.FileChangeTypeAdded
+	case "DELETE":
+		return gitprovider.FileChangeTypeRemoved
+	case "MOVE":
+		return gitprovider.FileChangeTypeRenamed
+	case "COPY":
+		return gitprovider.FileChangeTypeCopied
+	}
+	return gitprovider.FileChangeTypeModified
+}
+
+// pullRequestStateToAPI maps a gitprovider.PullRequestState to the state of a Stash pull request.
+func pullRequestStateToAPI(state gitprovider.PullRequestState) string {
+	switch state {
+	case gitprovider.PullRequestStateMerged:
+		return mergedState
+	case gitprovider.PullRequestStateClosed:
+		return declinedState
+	}
+	return openState
+}
+
 func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
 	return gitprovider.PullRequestInfo{
 		Title:        apiObj.Title,
@@ -51,7 +174,21 @@ func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
 		Number:       apiObj.ID,
 		Merged:       apiObj.State == mergedState,
 		SourceBranch: apiObj.FromRef.DisplayID,
+		BaseBranch:   apiObj.ToRef.DisplayID,
+		State:        pullRequestStateFromAPI(apiObj.State),
+		Draft:        apiObj.Draft != nil && *apiObj.Draft,
+	}
+}
+
+// pullRequestStateFromAPI maps the state of a pull request to a gitprovider.PullRequestState.
+func pullRequestStateFromAPI(state string) gitprovider.PullRequestState {
+	switch state {
+	case mergedState:
+		return gitprovider.PullRequestStateMerged
+	case declinedState:
+		return gitprovider.PullRequestStateClosed
 	}
+	return gitprovider.PullRequestStateOpen
 }
 
 func getSelfref(selves []Self) string {
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newPullRequestComment wraps a comment of the thread with the given root comment. parent is
+// the comment replied to, or nil for the root comment itself.
+func newPullRequestComment(root, parent, apiObj *Comment) *pullRequestComment {
+	c := &pullRequestComment{
+		c:        *apiObj,
+		anchor:   root.Anchor,
+		resolved: root.ThreadResolved,
+	}
+	if parent != nil {
+		c.inReplyTo = parent.ID
+	}
+	return c
+}
+
+// flattenComments returns the comments of the given threads, along with all of their replies.
+func flattenComments(roots []*Comment) []gitprovider.PullRequestComment {
+	var comments []gitprovider.PullRequestComment
+	var walk func(root, parent, apiObj *Comment)
+	walk = func(root, parent, apiObj *Comment) {
+		comments = append(comments, newPullRequestComment(root, parent, apiObj))
+		for _, reply := range apiObj.Comments {
+			walk(root, apiObj, reply)
+		}
+	}
+	for _, root := range roots {
+		walk(root, nil, root)
+	}
+	return comments
+}
+
+var _ gitprovider.PullRequestComment = &pullRequestComment{}
+
+// pullRequestComment is a comment of a pull request. Replies are nested in Stash, and share
+// the anchor and resolved state of the thread they belong to.
+type pullRequestComment struct {
+	c         Comment
+	anchor    *CommentAnchor
+	inReplyTo int64
+	resolved  bool
+}
+
+func (c *pullRequestComment) Get() gitprovider.PullRequestCommentInfo {
+	info := gitprovider.PullRequestCommentInfo{
+		ID:        c.c.ID,
+		Body:      c.c.Text,
+		InReplyTo: c.inReplyTo,
+		Resolved:  c.resolved,
+		CreatedAt: time.UnixMilli(c.c.CreatedDate),
+	}
+	if c.c.Author != nil {
+		info.Author = c.c.Author.Name
+	}
+	if c.anchor != nil {
+		info.Path = c.anchor.Path
+		info.Line = c.anchor.Line
+	}
+	return info
+}
+
+func (c *pullRequestComment) APIObject() interface{} {
+	return &c.c
+}
@@ -18,6 +18,7 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -56,21 +57,46 @@ func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.R
 			clientContext: ctx,
 			ref:           ref,
 		},
+		actionsPermissions: &ActionsPermissionsClient{},
+		deployTokens:       &DeployTokenClient{},
+		labels:             &LabelClient{},
+		milestones:         &MilestoneClient{},
+		releases:           &ReleaseClient{},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		tagProtections: &TagProtectionClient{},
 	}
 }
 
 var _ gitprovider.UserRepository = &userRepository{}
 
 type userRepository struct {
-	repository   Repository
-	ref          gitprovider.RepositoryRef
-	c            *UserRepositoriesClient
-	deployKeys   *DeployKeyClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	commits      *CommitClient
-	files        *FileClient
-	trees        *TreeClient
+	repository         Repository
+	ref                gitprovider.RepositoryRef
+	c                  *UserRepositoriesClient
+	deployKeys         *DeployKeyClient
+	branches           *BranchClient
+	pullRequests       *PullRequestClient
+	commits            *CommitClient
+	files              *FileClient
+	trees              *TreeClient
+	actionsPermissions *ActionsPermissionsClient
+	deployTokens       *DeployTokenClient
+	labels             *LabelClient
+	milestones         *MilestoneClient
+	releases           *ReleaseClient
+	webhooks           *WebhookClient
+	branchProtections  *BranchProtectionClient
+	tagProtections     *TagProtectionClient
+
+	// initialCommitSHA is only set if the repository was created with AutoInit.
+	initialCommitSHA *string
 }
 
 func (r *userRepository) Branches() gitprovider.BranchClient {
@@ -93,6 +119,38 @@ func (r *userRepository) Trees() gitprovider.TreeClient {
 	return r.trees
 }
 
+func (r *userRepository) ActionsPermissions() gitprovider.ActionsPermissionsClient {
+	return r.actionsPermissions
+}
+
+func (r *userRepository) DeployTokens() gitprovider.DeployTokenClient {
+	return r.deployTokens
+}
+
+func (r *userRepository) Labels() gitprovider.LabelClient {
+	return r.labels
+}
+
+func (r *userRepository) Milestones() gitprovider.MilestoneClient {
+	return r.milestones
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) TagProtections() gitprovider.TagProtectionClient {
+	return r.tagProtections
+}
+
 func (r *userRepository) Get() gitprovider.RepositoryInfo {
 	return repositoryFromAPI(&r.repository)
 }
@@ -101,8 +159,12 @@ func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
 	if err := info.ValidateInfo(); err != nil {
 		return err
 	}
-	repositoryInfoToAPIObj(&info, &r.repository)
-	return nil
+	return repositoryInfoToAPIObj(&info, &r.repository)
+}
+
+// InitialCommitSHA returns the SHA of the initial commit, if the repository was created with AutoInit.
+func (r *userRepository) InitialCommitSHA() *string {
+	return r.initialCommitSHA
 }
 
 func (r *userRepository) APIObject() interface{} {
@@ -158,6 +220,22 @@ func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
 	return actionTaken, nil
 }
 
+// SetDefaultBranch makes the given branch the default branch of the repository.
+//
+// ErrNotFound is returned if the resource doesn't exist.
+func (r *userRepository) SetDefaultBranch(ctx context.Context, branch string) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(r.ref)
+	err := r.c.client.Branches.SetDefault(ctx, projectKey, repoSlug, fmt.Sprintf("refs/heads/%s", branch))
+	if errors.Is(err, ErrNotFound) {
+		return gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update default branch: %w", err)
+	}
+	r.repository.DefaultBranch = branch
+	return nil
+}
+
 // Delete deletes the current resource irreversibly.
 // ErrNotFound is returned if the resource doesn't exist anymore.
 func (r *userRepository) Delete(ctx context.Context) error {
@@ -165,6 +243,36 @@ func (r *userRepository) Delete(ctx context.Context) error {
 	return deleteRepository(ctx, r.c.client, addTilde(ref.UserLogin), ref.Slug())
 }
 
+// Star always returns ErrNoProviderSupport, as Stash doesn't support starring repositories.
+func (r *userRepository) Star(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Unstar always returns ErrNoProviderSupport, as Stash doesn't support starring repositories.
+func (r *userRepository) Unstar(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IsStarred always returns ErrNoProviderSupport, as Stash doesn't support starring repositories.
+func (r *userRepository) IsStarred(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetSubscription always returns ErrNoProviderSupport, as Stash doesn't expose repository notification settings.
+func (r *userRepository) GetSubscription(_ context.Context) (gitprovider.RepositorySubscription, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription always returns ErrNoProviderSupport, as Stash doesn't expose repository notification settings.
+func (r *userRepository) SetSubscription(_ context.Context, _ gitprovider.RepositorySubscription) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// IssueCounts always returns ErrNoProviderSupport, as Stash doesn't track issues.
+func (r *userRepository) IssueCounts(_ context.Context) (gitprovider.IssueCounts, error) {
+	return gitprovider.IssueCounts{}, gitprovider.ErrNoProviderSupport
+}
+
 // GetCloneURL returns a formatted string that can be used for cloning
 // from a remote Git provider.
 func (r *userRepository) GetCloneURL(prefix string, transport gitprovider.TransportType) string {
@@ -271,16 +379,18 @@ func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
 	return repo
 }
 
-func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) *Repository {
+func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) (*Repository, error) {
 	apiObj := &Repository{
 		Name:  *gitprovider.StringVar(ref.GetRepository()),
 		ScmID: "git",
 	}
-	repositoryInfoToAPIObj(repo, apiObj)
-	return apiObj
+	err := repositoryInfoToAPIObj(repo, apiObj)
+	return apiObj, err
 }
 
-func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) {
+// repositoryInfoToAPIObj applies the set fields of repo to apiObj. ErrNoProviderSupport is
+// returned for settings Stash doesn't have, after the supported ones have been applied.
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) error {
 	if repo.Description != nil {
 		apiObj.Description = *repo.Description
 	}
@@ -291,6 +401,19 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository
 	if repo.DefaultBranch != nil {
 		apiObj.DefaultBranch = *gitprovider.StringVar(*repo.DefaultBranch)
 	}
+	return unsupportedRepositorySettings(*repo)
+}
+
+// unsupportedRepositorySettings returns an error wrapping ErrNoProviderSupport if repo has
+// settings Stash doesn't have. The merge settings are chosen per pull request.
+func unsupportedRepositorySettings(repo gitprovider.RepositoryInfo) error {
+	if repo.AllowAutoMerge != nil {
+		return fmt.Errorf("stash doesn't support the AllowAutoMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if repo.DeleteBranchOnMerge != nil {
+		return fmt.Errorf("stash doesn't support the DeleteBranchOnMerge setting: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
 }
 
 // GetCloneURL returns a formatted string that can be used for cloning
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// The values of the "Status" field of a Stash pull request participant.
+const (
+	// approvedStatus is the status of a reviewer that approved the pull request.
+	approvedStatus = "APPROVED"
+	// needsWorkStatus is the status of a reviewer that requested changes.
+	needsWorkStatus = "NEEDS_WORK"
+	// unapprovedStatus is the status of a reviewer that hasn't approved the pull request (yet).
+	unapprovedStatus = "UNAPPROVED"
+)
+
+// newReview returns the reviewer participant as a review, Stash has no review objects.
+func newReview(apiObj *Participant) *review {
+	return &review{
+		p: *apiObj,
+	}
+}
+
+var _ gitprovider.Review = &review{}
+
+type review struct {
+	p Participant
+}
+
+func (r *review) Get() gitprovider.ReviewInfo {
+	return reviewFromAPI(&r.p)
+}
+
+func (r *review) APIObject() interface{} {
+	return &r.p
+}
+
+func reviewFromAPI(apiObj *Participant) gitprovider.ReviewInfo {
+	state := gitprovider.ReviewStatePending
+	switch apiObj.Status {
+	case approvedStatus:
+		state = gitprovider.ReviewStateApproved
+	case needsWorkStatus:
+		state = gitprovider.ReviewStateChangesRequested
+	}
+	return gitprovider.ReviewInfo{
+		ID:       apiObj.User.ID,
+		Reviewer: apiObj.User.Name,
+		State:    state,
+	}
+}
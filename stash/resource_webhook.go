@@ -0,0 +1,208 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const webhookConfigSecret = "secret"
+
+// webhookEvents maps the webhook events to the Stash events, in the order of the enum. Stash
+// has neither issues nor releases, and sends pushed tags as push events.
+//
+//nolint:gochecknoglobals
+var webhookEvents = []struct {
+	event     gitprovider.WebhookEvent
+	apiEvents []string
+}{
+	{gitprovider.WebhookEventPush, []string{"repo:refs_changed"}},
+	{gitprovider.WebhookEventPullRequest, []string{"pr:opened", "pr:from_ref_updated", "pr:modified", "pr:merged", "pr:declined", "pr:deleted"}},
+	{gitprovider.WebhookEventComment, []string{"pr:comment:added", "pr:comment:edited", "pr:comment:deleted"}},
+}
+
+// WebhookEvents returns the webhook events Stash supports for repository and project webhooks, mapped to the
+// Stash events they subscribe to.
+func WebhookEvents() gitprovider.WebhookEventMap {
+	events := make(gitprovider.WebhookEventMap, len(webhookEvents))
+	for _, e := range webhookEvents {
+		events[e.event] = append([]string{}, e.apiEvents...)
+	}
+	return events
+}
+
+func newWebhook(c *WebhookClient, apiObj *Webhook) *webhook {
+	return &webhook{
+		h: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+type webhook struct {
+	h Webhook
+	c *WebhookClient
+}
+
+func (wh *webhook) ID() string {
+	return strconv.Itoa(wh.h.ID)
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookSupport(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server. The secret is only sent if
+// it was set using Set.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(wh.c.ref)
+	apiObj, err := wh.c.client.Webhooks.Update(ctx, projectKey, repoSlug, &wh.h)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook %d: %w", wh.h.ID, err)
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	projectKey, repoSlug := getProjectKeyAndSlug(wh.c.ref)
+	if err := wh.c.client.Webhooks.Delete(ctx, projectKey, repoSlug, wh.h.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook %d: %w", wh.h.ID, err)
+	}
+	return nil
+}
+
+// Deliveries always returns ErrNoProviderSupport, as Stash only exposes statistics about the
+// deliveries.
+func (wh *webhook) Deliveries(_ context.Context) ([]gitprovider.WebhookDelivery, error) {
+	return nil, fmt.Errorf("stash can't list webhook deliveries: %w", gitprovider.ErrNoProviderSupport)
+}
+
+// Redeliver always returns ErrNoProviderSupport, as Stash can't redeliver payloads.
+func (wh *webhook) Redeliver(_ context.Context, _ string) error {
+	return fmt.Errorf("stash can't redeliver webhook payloads: %w", gitprovider.ErrNoProviderSupport)
+}
+
+// Ping always returns ErrNoProviderSupport, as Stash can't send test payloads to existing
+// webhooks.
+func (wh *webhook) Ping(_ context.Context) (gitprovider.WebhookPingResult, error) {
+	return gitprovider.WebhookPingResult{}, fmt.Errorf("stash can't ping webhooks: %w", gitprovider.ErrNoProviderSupport)
+}
+
+func validateWebhookAPI(apiObj *Webhook) error {
+	return validateAPIObject("Stash.Webhook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// validateWebhookSupport returns ErrNoProviderSupport for content types and events Stash
+// doesn't support.
+func validateWebhookSupport(info gitprovider.WebhookInfo) error {
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("stash only sends webhook payloads as JSON: %w", gitprovider.ErrNoProviderSupport)
+	}
+	for _, event := range info.Events {
+		switch event {
+		case gitprovider.WebhookEventTag:
+			return fmt.Errorf("stash sends pushed tags as push webhook events: %w", gitprovider.ErrNoProviderSupport)
+		}
+		if !WebhookEvents().Supports(event) {
+			return fmt.Errorf("stash has no %s webhook events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	return nil
+}
+
+func webhookFromAPI(apiObj *Webhook) gitprovider.WebhookInfo {
+	events := []gitprovider.WebhookEvent{}
+	for _, e := range webhookEvents {
+		if containsAny(apiObj.Events, e.apiEvents) {
+			events = append(events, e.event)
+		}
+	}
+	return gitprovider.WebhookInfo{
+		URL:         apiObj.URL,
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Events:      events,
+	}
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *Webhook) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.URL = info.URL
+	apiObj.Events = []string{}
+	for _, e := range webhookEvents {
+		for _, event := range info.Events {
+			if event == e.event {
+				apiObj.Events = append(apiObj.Events, e.apiEvents...)
+			}
+		}
+	}
+	// optional fields
+	if info.Secret != nil {
+		apiObj.Configuration = map[string]string{webhookConfigSecret: *info.Secret}
+	}
+}
+
+// containsAny returns whether any of values is in list.
+func containsAny(list, values []string) bool {
+	for _, l := range list {
+		for _, v := range values {
+			if l == v {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package stash
 
 import (
+	"context"
 	"net/http"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 const (
@@ -104,7 +107,11 @@ type Links struct {
 	Clone []Clone `json:"clone,omitempty"`
 }
 
-func allPages(opts *PagingOptions, fn func() (*Paging, error)) error {
+func allPages(ctx context.Context, opts *PagingOptions, fn func() (*Paging, error)) error {
+	// The page size set on the context overrides the default of the caller
+	if perPage := gitprovider.CallOptionsFromContext(ctx).GetPerPage(0); perPage > 0 {
+		opts.Limit = int64(perPage)
+	}
 	for {
 		resp, err := fn()
 		if err != nil {
@@ -113,6 +120,10 @@ func allPages(opts *PagingOptions, fn func() (*Paging, error)) error {
 		if resp.IsLast() {
 			return nil
 		}
+		// Stop early if the caller gave up in the meantime
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// get Next start
 		opts.Start = resp.NextPageStart
 	}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// sshUser is the user Bitbucket Server expects for Git operations over SSH, the account is
+// identified by the key.
+const sshUser = "git"
+
+// WithSSHKey configures the client to clone and push repositories over SSH, authenticating with
+// the given key instead of the token, e.g. loaded using gitprovider.LoadSSHPrivateKey. The key
+// has to be added to the account of the user in Bitbucket Server. hostKeyCallback verifies the
+// server's host key; if nil, the known_hosts files are used, see the go-git ssh package.
+//
+// API requests are still authenticated by the token, or by a client certificate configured with
+// gitprovider.WithClientCertificate.
+func WithSSHKey(signer ssh.Signer, hostKeyCallback ssh.HostKeyCallback) gitprovider.ClientOption {
+	// Don't allow an empty value
+	if signer == nil {
+		return &sshKeyOption{err: fmt.Errorf("signer cannot be nil: %w", gitprovider.ErrInvalidClientOptions)}
+	}
+	return &sshKeyOption{signer: signer, hostKeyCallback: hostKeyCallback}
+}
+
+// sshKeyOption carries the SSH key to NewStashClient, the gitprovider.ClientOptions only cover
+// the HTTP transport.
+type sshKeyOption struct {
+	signer          ssh.Signer
+	hostKeyCallback ssh.HostKeyCallback
+	err             error
+}
+
+// ApplyToClientOptions only reports the errors of WithSSHKey, the Git transport is configured
+// by NewStashClient.
+func (o *sshKeyOption) ApplyToClientOptions(_ *gitprovider.ClientOptions) error {
+	return o.err
+}
+
+// sshKeyFromOptions returns the SSH key given using WithSSHKey, if any.
+func sshKeyFromOptions(optFns []gitprovider.ClientOption) *sshKeyOption {
+	for _, opt := range optFns {
+		if o, ok := opt.(*sshKeyOption); ok && o.signer != nil {
+			return o
+		}
+	}
+	return nil
+}
+
+// WithSSHAuth is used to authenticate Git operations over SSH using the given key.
+// If hostKeyCallback is nil, the known_hosts files are used to verify the host key.
+func WithSSHAuth(signer ssh.Signer, hostKeyCallback ssh.HostKeyCallback) ClientOptionsFunc {
+	return func(c *Client) error {
+		if signer == nil {
+			return errors.New("signer is required")
+		}
+
+		auth := &gitssh.PublicKeys{User: sshUser, Signer: signer}
+		auth.HostKeyCallback = hostKeyCallback
+		c.sshAuth = auth
+		return nil
+	}
+}
+
+// gitAuth returns the authentication used to clone and push repositories.
+func (c *Client) gitAuth() transport.AuthMethod {
+	if c.sshAuth != nil {
+		return c.sshAuth
+	}
+	return &githttp.BasicAuth{Username: c.username, Password: c.token}
+}
+
+// cloneURL returns the URL used to clone the repository with the given clone links, which is
+// the SSH one if Git operations are authenticated using an SSH key.
+func (c *Client) cloneURL(clones []Clone) string {
+	if c.sshAuth != nil {
+		return getRepoSSHref(clones)
+	}
+	return getRepoHTTPref(clones)
+}
+
+func getRepoSSHref(clones []Clone) string {
+	for _, clone := range clones {
+		if clone.Name == "ssh" {
+			return clone.Href
+		}
+	}
+	return "no ssh ref found"
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+)
+
+func Test_WithSSHKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clones := []Clone{
+		{Name: "http", Href: "https://stash.example.com/scm/prj/repo.git"},
+		{Name: "ssh", Href: "ssh://git@stash.example.com:7999/prj/repo.git"},
+	}
+	domain := gitprovider.WithDomain("stash.example.com")
+
+	c, err := NewStashClient("user1", "token", domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.client.gitAuth().(*githttp.BasicAuth); !ok {
+		t.Errorf("expected basic auth for Git operations, got %T", c.client.gitAuth())
+	}
+	if got := c.client.cloneURL(clones); got != clones[0].Href {
+		t.Errorf("got clone URL %q, want %q", got, clones[0].Href)
+	}
+
+	c, err = NewStashClient("user1", "token", domain, WithSSHKey(signer, ssh.InsecureIgnoreHostKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, ok := c.client.gitAuth().(*gitssh.PublicKeys)
+	if !ok {
+		t.Fatalf("expected SSH public key auth for Git operations, got %T", c.client.gitAuth())
+	}
+	if auth.User != sshUser || auth.Signer != signer {
+		t.Errorf("unexpected SSH auth: %v", auth)
+	}
+	if got := c.client.cloneURL(clones); got != clones[1].Href {
+		t.Errorf("got clone URL %q, want %q", got, clones[1].Href)
+	}
+
+	if _, err := NewStashClient("user1", "token", domain, WithSSHKey(nil, nil)); err == nil {
+		t.Error("expected an error for a nil signer")
+	}
+}
+
+func Test_ClientCertificateWithoutToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	domain := gitprovider.WithDomain("stash.example.com")
+
+	if _, err := NewStashClient("", "", domain); err == nil {
+		t.Error("expected an error without token")
+	}
+	c, err := NewStashClient("", "", domain, gitprovider.WithClientCertificate(cert))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.client.HeaderFields.Get("Authorization"); got != "" {
+		t.Errorf("expected no Authorization header, got %q", got)
+	}
+}
@@ -18,6 +18,9 @@ package stash
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -112,6 +115,58 @@ func (p *ProviderClient) HasTokenPermission(_ context.Context, _ gitprovider.Tok
 	return false, gitprovider.ErrNoProviderSupport
 }
 
+// CheckScopes always returns ErrNoProviderSupport, as Stash doesn't expose the permissions of a token.
+func (p *ProviderClient) CheckScopes(_ context.Context, _ []string) (*gitprovider.ScopeCheckResult, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// TokenInfo always returns ErrNoProviderSupport, as Stash doesn't expose information about
+// the token used.
+func (p *ProviderClient) TokenInfo(_ context.Context) (*gitprovider.TokenInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListStarred always returns ErrNoProviderSupport, as Stash doesn't support starring repositories.
+func (p *ProviderClient) ListStarred(_ context.Context, _ string) ([]gitprovider.RepositoryRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListGitignoreTemplates always returns ErrNoProviderSupport, as Stash doesn't offer .gitignore templates.
+func (p *ProviderClient) ListGitignoreTemplates(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ListLicenseTemplates always returns ErrNoProviderSupport, as Stash doesn't offer license templates.
+func (p *ProviderClient) ListLicenseTemplates(_ context.Context) ([]gitprovider.LicenseTemplateInfo, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetOwnerType returns whether the given owner is a user or an organization (i.e. a project).
+// Owners prefixed with "~" refer to personal projects, and hence users. Otherwise, projects are
+// looked up first, so if a project key and a user slug are equal, the project wins.
+func (p *ProviderClient) GetOwnerType(ctx context.Context, owner string) (gitprovider.OwnerType, error) {
+	if strings.HasPrefix(owner, "~") {
+		return gitprovider.OwnerTypeUser, nil
+	}
+
+	_, err := p.client.Projects.Get(ctx, owner)
+	if err == nil {
+		return gitprovider.OwnerTypeOrganization, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("failed to get project %s: %w", owner, err)
+	}
+
+	_, err = p.client.Users.Get(ctx, owner)
+	if err == nil {
+		return gitprovider.OwnerTypeUser, nil
+	}
+	if errors.Is(err, ErrNotFound) {
+		return "", gitprovider.ErrNotFound
+	}
+	return "", fmt.Errorf("failed to get user %s: %w", owner, err)
+}
+
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
 // depending on if any error was registered with it; either returns nil, or a MultiError
 // with both the validation error and ErrInvalidServerData, to mark that the server data
@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	webhooksURI = "webhooks"
+)
+
+// Webhooks interface defines the methods for working with repository and project webhooks.
+type Webhooks interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error)
+	Update(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error
+	ListProjectWebhooks(ctx context.Context, projectKey string, opts *PagingOptions) (*WebhookList, error)
+	AllProjectWebhooks(ctx context.Context, projectKey string) ([]*Webhook, error)
+	CreateProjectWebhook(ctx context.Context, projectKey string, webhook *Webhook) (*Webhook, error)
+	UpdateProjectWebhook(ctx context.Context, projectKey string, webhook *Webhook) (*Webhook, error)
+	DeleteProjectWebhook(ctx context.Context, projectKey string, webhookID int) error
+}
+
+// WebhooksService is a client for communicating with stash repository webhooks endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-rest.html
+type WebhooksService service
+
+// Webhook is a webhook of a repository or a project.
+type Webhook struct {
+	// Session is the session object
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the webhook id
+	ID int `json:"id,omitempty"`
+	// Name is the webhook name
+	Name string `json:"name,omitempty"`
+	// URL is the URL the payloads are sent to
+	URL string `json:"url,omitempty"`
+	// Events are the events triggering the webhook, e.g. "repo:refs_changed" or "pr:opened"
+	Events []string `json:"events"`
+	// Active specifies whether the payloads are sent
+	Active bool `json:"active"`
+	// Configuration contains the secret used to sign the payloads, which isn't returned
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// WebhookList is a list of webhooks
+type WebhookList struct {
+	Paging
+	Webhooks []*Webhook `json:"values,omitempty"`
+}
+
+// GetWebhooks returns the list of webhooks
+func (w *WebhookList) GetWebhooks() []*Webhook {
+	return w.Webhooks
+}
+
+// List returns the list of webhooks of the repository.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a WebhookList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error) {
+	return s.list(ctx, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), opts)
+}
+
+// ListProjectWebhooks returns the list of webhooks of the project, which are triggered by the
+// events in all repositories of the project.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a WebhookList struct is returned to retrieve the next page of results.
+// ListProjectWebhooks uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/webhooks".
+func (s *WebhooksService) ListProjectWebhooks(ctx context.Context, projectKey string, opts *PagingOptions) (*WebhookList, error) {
+	return s.list(ctx, newURI(projectsURI, projectKey, webhooksURI), opts)
+}
+
+func (s *WebhooksService) list(ctx context.Context, uri string, opts *PagingOptions) (*WebhookList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, uri, WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	webhooks := &WebhookList{}
+	if err := json.Unmarshal(res, webhooks); err != nil {
+		return nil, fmt.Errorf("list webhooks failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, w := range webhooks.GetWebhooks() {
+		w.Session.set(resp)
+	}
+
+	return webhooks, nil
+}
+
+// All retrieves all webhooks of the repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *WebhooksService) All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error) {
+	return s.all(ctx, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI))
+}
+
+// AllProjectWebhooks retrieves all webhooks of the project.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *WebhooksService) AllProjectWebhooks(ctx context.Context, projectKey string) ([]*Webhook, error) {
+	return s.all(ctx, newURI(projectsURI, projectKey, webhooksURI))
+}
+
+func (s *WebhooksService) all(ctx context.Context, uri string) ([]*Webhook, error) {
+	w := []*Webhook{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func() (*Paging, error) {
+		list, err := s.list(ctx, uri, opts)
+		if err != nil {
+			return nil, err
+		}
+		w = append(w, list.GetWebhooks()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Create creates a webhook.
+// Create uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error) {
+	return s.create(ctx, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), webhook)
+}
+
+// CreateProjectWebhook creates a webhook for all repositories of the project.
+// CreateProjectWebhook uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/webhooks".
+func (s *WebhooksService) CreateProjectWebhook(ctx context.Context, projectKey string, webhook *Webhook) (*Webhook, error) {
+	return s.create(ctx, newURI(projectsURI, projectKey, webhooksURI), webhook)
+}
+
+func (s *WebhooksService) create(ctx context.Context, uri string, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, uri, WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("create webhook failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	w := &Webhook{}
+	if err := json.Unmarshal(res, w); err != nil {
+		return nil, fmt.Errorf("create webhook failed, unable to unmarshall json: %w", err)
+	}
+
+	w.Session.set(resp)
+
+	return w, nil
+}
+
+// Update updates the webhook with the ID of the given webhook.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Update(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error) {
+	return s.update(ctx, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhook.ID)), webhook)
+}
+
+// UpdateProjectWebhook updates the project webhook with the ID of the given webhook.
+// UpdateProjectWebhook uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/webhooks/{webhookId}".
+func (s *WebhooksService) UpdateProjectWebhook(ctx context.Context, projectKey string, webhook *Webhook) (*Webhook, error) {
+	return s.update(ctx, newURI(projectsURI, projectKey, webhooksURI, strconv.Itoa(webhook.ID)), webhook)
+}
+
+func (s *WebhooksService) update(ctx context.Context, uri string, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, uri, WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("update webhook failed with status code %d, error: %s", resp.StatusCode, res)
+	}
+
+	w := &Webhook{}
+	if err := json.Unmarshal(res, w); err != nil {
+		return nil, fmt.Errorf("update webhook failed, unable to unmarshall json: %w", err)
+	}
+
+	w.Session.set(resp)
+
+	return w, nil
+}
+
+// Delete deletes the webhook with the given ID.
+// Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error {
+	return s.delete(ctx, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhookID)))
+}
+
+// DeleteProjectWebhook deletes the project webhook with the given ID.
+// DeleteProjectWebhook uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/webhooks/{webhookId}".
+func (s *WebhooksService) DeleteProjectWebhook(ctx context.Context, projectKey string, webhookID int) error {
+	return s.delete(ctx, newURI(projectsURI, projectKey, webhooksURI, strconv.Itoa(webhookID)))
+}
+
+func (s *WebhooksService) delete(ctx context.Context, uri string) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, uri)
+	if err != nil {
+		return fmt.Errorf("delete webhook request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
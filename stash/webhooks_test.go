@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		wantRequests    []string
+		wantBody        *Webhook
+		wantActionTaken bool
+		wantErr         error
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			wantRequests: []string{"GET"},
+		},
+		{
+			name: "change events",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventComment},
+			},
+			wantRequests: []string{"GET", "PUT /1"},
+			wantBody: &Webhook{
+				ID:     1,
+				Name:   "ci",
+				URL:    hookURL,
+				Events: []string{"repo:refs_changed", "pr:comment:added", "pr:comment:edited", "pr:comment:deleted"},
+				Active: true,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET", "POST"},
+			wantBody: &Webhook{
+				Name:          "https://example.com/other",
+				URL:           "https://example.com/other",
+				Events:        []string{"repo:refs_changed"},
+				Active:        true,
+				Configuration: map[string]string{"secret": "s3cr3t"},
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "release events",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventRelease},
+			},
+			wantRequests: []string{"GET"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name: "tag events",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTag},
+			},
+			wantRequests: []string{"GET"},
+			wantErr:      gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var requests []string
+			var body *Webhook
+			path := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s", stashURIprefix, projectsURI, RepositoriesURI, webhooksURI)
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.Path, path)))
+				if r.Method == http.MethodGet {
+					fmt.Fprintf(w, `{"isLastPage":true,"values":[{"id":1,"name":"ci","url":%q,"events":["repo:refs_changed","pr:opened","pr:merged"],"active":true}]}`, hookURL)
+					return
+				}
+				body = &Webhook{}
+				if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+					t.Error(err)
+				}
+				resp := *body
+				resp.ID = 1
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusCreated)
+				}
+				json.NewEncoder(w).Encode(resp)
+			}
+			mux.HandleFunc(path, handler)
+			mux.HandleFunc(path+"/", handler)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"},
+				RepositoryName:  "my-repo",
+			}
+			ref.SetKey("prj")
+			ref.SetSlug("my-repo")
+			c := &WebhookClient{clientContext: &clientContext{client: client}, ref: ref}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if d := cmp.Diff(tt.wantRequests, requests); d != "" {
+				t.Errorf("Reconcile() requests returned diff (want -> got):\n%s", d)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Reconcile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if d := cmp.Diff(tt.wantBody, body); d != "" {
+				t.Errorf("Reconcile() body returned diff (want -> got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile(t *testing.T) {
+	const hookURL = "https://example.com/hook"
+	tests := []struct {
+		name            string
+		req             gitprovider.WebhookInfo
+		wantRequests    []string
+		wantBody        *Webhook
+		wantActionTaken bool
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET"},
+		},
+		{
+			name: "change events",
+			req: gitprovider.WebhookInfo{
+				URL:    hookURL,
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventComment},
+			},
+			wantRequests: []string{"GET", "PUT /1"},
+			wantBody: &Webhook{
+				ID:     1,
+				Name:   "ci",
+				URL:    hookURL,
+				Events: []string{"repo:refs_changed", "pr:comment:added", "pr:comment:edited", "pr:comment:deleted"},
+				Active: true,
+			},
+			wantActionTaken: true,
+		},
+		{
+			name: "create without a match",
+			req: gitprovider.WebhookInfo{
+				URL:    "https://example.com/other",
+				Secret: gitprovider.StringVar("s3cr3t"),
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+			},
+			wantRequests: []string{"GET", "POST"},
+			wantBody: &Webhook{
+				Name:          "https://example.com/other",
+				URL:           "https://example.com/other",
+				Events:        []string{"repo:refs_changed"},
+				Active:        true,
+				Configuration: map[string]string{"secret": "s3cr3t"},
+			},
+			wantActionTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var requests []string
+			var body *Webhook
+			path := fmt.Sprintf("%s/%s/prj/%s", stashURIprefix, projectsURI, webhooksURI)
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.Path, path)))
+				if r.Method == http.MethodGet {
+					fmt.Fprintf(w, `{"isLastPage":true,"values":[{"id":1,"name":"ci","url":%q,"events":["repo:refs_changed"],"active":true}]}`, hookURL)
+					return
+				}
+				body = &Webhook{}
+				if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+					t.Error(err)
+				}
+				resp := *body
+				resp.ID = 1
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusCreated)
+				}
+				json.NewEncoder(w).Encode(resp)
+			}
+			mux.HandleFunc(path, handler)
+			mux.HandleFunc(path+"/", handler)
+
+			ref := gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj"}
+			ref.SetKey("prj")
+			c := &OrganizationWebhookClient{clientContext: &clientContext{client: client}, ref: ref}
+
+			resp, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			want := tt.req
+			want.Default()
+			if got := resp.Get(); !want.Equals(got) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, want)
+			}
+			if d := cmp.Diff(tt.wantRequests, requests); d != "" {
+				t.Errorf("Reconcile() requests returned diff (want -> got):\n%s", d)
+			}
+			if d := cmp.Diff(tt.wantBody, body); d != "" {
+				t.Errorf("Reconcile() body returned diff (want -> got):\n%s", d)
+			}
+		})
+	}
+}
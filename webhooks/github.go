@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const githubEventHeader = "X-GitHub-Event"
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type githubSender struct {
+	Login string `json:"login"`
+}
+
+type githubPushPayload struct {
+	Ref        string           `json:"ref"`
+	Before     string           `json:"before"`
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubSender     `json:"sender"`
+	Commits    []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+type githubPullRequestPayload struct {
+	Action      string           `json:"action"`
+	Number      int              `json:"number"`
+	Repository  githubRepository `json:"repository"`
+	Sender      githubSender     `json:"sender"`
+	PullRequest struct {
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		Merged  bool   `json:"merged"`
+		Head    struct {
+			Ref string `json:"ref"`
+			SHA string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+}
+
+type githubReleasePayload struct {
+	Action     string           `json:"action"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubSender     `json:"sender"`
+	Release    struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		HTMLURL string `json:"html_url"`
+	} `json:"release"`
+}
+
+// parseGitHub parses the payload of the given GitHub event. Pushed tags are sent as push events.
+func parseGitHub(eventName string, body []byte) (*Event, error) {
+	switch eventName {
+	case "push":
+		payload := &githubPushPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitHub push payload: %w", err)
+		}
+		event := &Event{
+			Repository: payload.Repository.toEvent(),
+			Sender:     payload.Sender.Login,
+		}
+		if strings.HasPrefix(payload.Ref, tagRefPrefix) {
+			event.Type = gitprovider.WebhookEventTag
+			event.Tag = &Tag{
+				Name:    strings.TrimPrefix(payload.Ref, tagRefPrefix),
+				Deleted: payload.Deleted,
+			}
+			if !payload.Deleted {
+				event.Tag.SHA = payload.After
+			}
+			return event, nil
+		}
+		event.Type = gitprovider.WebhookEventPush
+		event.Push = &Push{
+			Ref:     payload.Ref,
+			Branch:  strings.TrimPrefix(payload.Ref, branchRefPrefix),
+			Before:  payload.Before,
+			After:   payload.After,
+			Deleted: payload.Deleted,
+			Commits: make([]Commit, 0, len(payload.Commits)),
+		}
+		for _, c := range payload.Commits {
+			event.Push.Commits = append(event.Push.Commits, Commit{
+				SHA:     c.ID,
+				Message: c.Message,
+				Author:  c.Author.Name,
+				URL:     c.URL,
+			})
+		}
+		return event, nil
+	case "pull_request":
+		payload := &githubPullRequestPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitHub pull request payload: %w", err)
+		}
+		return &Event{
+			Type:       gitprovider.WebhookEventPullRequest,
+			Repository: payload.Repository.toEvent(),
+			Sender:     payload.Sender.Login,
+			PullRequest: &PullRequest{
+				Action:       githubPullRequestAction(payload.Action, payload.PullRequest.Merged),
+				Number:       payload.Number,
+				Title:        payload.PullRequest.Title,
+				URL:          payload.PullRequest.HTMLURL,
+				SourceBranch: payload.PullRequest.Head.Ref,
+				TargetBranch: payload.PullRequest.Base.Ref,
+				SHA:          payload.PullRequest.Head.SHA,
+			},
+		}, nil
+	case "release":
+		payload := &githubReleasePayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitHub release payload: %w", err)
+		}
+		return &Event{
+			Type:       gitprovider.WebhookEventRelease,
+			Repository: payload.Repository.toEvent(),
+			Sender:     payload.Sender.Login,
+			Release: &Release{
+				Action:  payload.Action,
+				TagName: payload.Release.TagName,
+				Name:    payload.Release.Name,
+				URL:     payload.Release.HTMLURL,
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: GitHub event %q", ErrUnsupportedEvent, eventName)
+}
+
+func (r githubRepository) toEvent() Repository {
+	return Repository{
+		FullName: r.FullName,
+		CloneURL: r.CloneURL,
+		HTMLURL:  r.HTMLURL,
+	}
+}
+
+// githubPullRequestAction normalizes the action, as GitHub sends "closed" for merged pull requests.
+func githubPullRequestAction(action string, merged bool) PullRequestAction {
+	switch action {
+	case "opened":
+		return PullRequestActionOpened
+	case "synchronize":
+		return PullRequestActionUpdated
+	case "reopened":
+		return PullRequestActionReopened
+	case "closed":
+		if merged {
+			return PullRequestActionMerged
+		}
+		return PullRequestActionClosed
+	}
+	return PullRequestAction(action)
+}
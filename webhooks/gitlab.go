@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	gitlabEventHeader = "X-Gitlab-Event"
+
+	// gitlabZeroSHA is sent as "after" SHA if a branch is deleted.
+	gitlabZeroSHA = "0000000000000000000000000000000000000000"
+)
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	GitHTTPURL        string `json:"git_http_url"`
+	WebURL            string `json:"web_url"`
+}
+
+type gitlabPushPayload struct {
+	Ref          string        `json:"ref"`
+	Before       string        `json:"before"`
+	After        string        `json:"after"`
+	CheckoutSHA  *string       `json:"checkout_sha"`
+	UserUsername string        `json:"user_username"`
+	Project      gitlabProject `json:"project"`
+	Commits      []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+type gitlabMergeRequestPayload struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		URL          string `json:"url"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		Action       string `json:"action"`
+		OldRev       string `json:"oldrev"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+}
+
+type gitlabReleasePayload struct {
+	Action  string        `json:"action"`
+	Tag     string        `json:"tag"`
+	Name    string        `json:"name"`
+	URL     string        `json:"url"`
+	Project gitlabProject `json:"project"`
+}
+
+// parseGitLab parses the payload of the given GitLab event. Release events don't contain the
+// user who triggered them.
+func parseGitLab(eventName string, body []byte) (*Event, error) {
+	switch eventName {
+	case "Push Hook":
+		payload := &gitlabPushPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab push payload: %w", err)
+		}
+		event := &Event{
+			Type:       gitprovider.WebhookEventPush,
+			Repository: payload.Project.toEvent(),
+			Sender:     payload.UserUsername,
+			Push: &Push{
+				Ref:     payload.Ref,
+				Branch:  strings.TrimPrefix(payload.Ref, branchRefPrefix),
+				Before:  payload.Before,
+				After:   payload.After,
+				Deleted: payload.After == gitlabZeroSHA,
+				Commits: make([]Commit, 0, len(payload.Commits)),
+			},
+		}
+		for _, c := range payload.Commits {
+			event.Push.Commits = append(event.Push.Commits, Commit{
+				SHA:     c.ID,
+				Message: c.Message,
+				Author:  c.Author.Name,
+				URL:     c.URL,
+			})
+		}
+		return event, nil
+	case "Tag Push Hook":
+		payload := &gitlabPushPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab tag push payload: %w", err)
+		}
+		event := &Event{
+			Type:       gitprovider.WebhookEventTag,
+			Repository: payload.Project.toEvent(),
+			Sender:     payload.UserUsername,
+			Tag: &Tag{
+				Name: strings.TrimPrefix(payload.Ref, tagRefPrefix),
+				// GitLab sends no checkout SHA for deleted tags
+				Deleted: payload.CheckoutSHA == nil,
+			},
+		}
+		if payload.CheckoutSHA != nil {
+			event.Tag.SHA = *payload.CheckoutSHA
+		}
+		return event, nil
+	case "Merge Request Hook":
+		payload := &gitlabMergeRequestPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab merge request payload: %w", err)
+		}
+		attrs := payload.ObjectAttributes
+		return &Event{
+			Type:       gitprovider.WebhookEventPullRequest,
+			Repository: payload.Project.toEvent(),
+			Sender:     payload.User.Username,
+			PullRequest: &PullRequest{
+				Action:       gitlabMergeRequestAction(attrs.Action, attrs.OldRev),
+				Number:       attrs.IID,
+				Title:        attrs.Title,
+				URL:          attrs.URL,
+				SourceBranch: attrs.SourceBranch,
+				TargetBranch: attrs.TargetBranch,
+				SHA:          attrs.LastCommit.ID,
+			},
+		}, nil
+	case "Release Hook":
+		payload := &gitlabReleasePayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab release payload: %w", err)
+		}
+		return &Event{
+			Type:       gitprovider.WebhookEventRelease,
+			Repository: payload.Project.toEvent(),
+			Release: &Release{
+				Action:  payload.Action,
+				TagName: payload.Tag,
+				Name:    payload.Name,
+				URL:     payload.URL,
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: GitLab event %q", ErrUnsupportedEvent, eventName)
+}
+
+func (p gitlabProject) toEvent() Repository {
+	return Repository{
+		FullName: p.PathWithNamespace,
+		CloneURL: p.GitHTTPURL,
+		HTMLURL:  p.WebURL,
+	}
+}
+
+// gitlabMergeRequestAction normalizes the action. GitLab sends "update" for changes of e.g. the
+// title too, but only sets the old revision if commits were pushed.
+func gitlabMergeRequestAction(action, oldRev string) PullRequestAction {
+	switch action {
+	case "open":
+		return PullRequestActionOpened
+	case "update":
+		if oldRev != "" {
+			return PullRequestActionUpdated
+		}
+	case "close":
+		return PullRequestActionClosed
+	case "reopen":
+		return PullRequestActionReopened
+	case "merge":
+		return PullRequestActionMerged
+	}
+	return PullRequestAction(action)
+}
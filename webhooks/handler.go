@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// EventHandlerFunc handles a normalized webhook event. If it returns an error, the Handler
+// responds with 500 Internal Server Error, for the provider to show the delivery as failed.
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler receiving the webhook requests of all supported providers. It
+// detects the provider of each request from its headers, verifies the signature, parses the
+// payload, and calls the callbacks registered for the type of the event.
+//
+// Valid requests with events that aren't normalized (e.g. pings) or without registered callbacks
+// are acknowledged with 204 No Content, in order not to show the delivery as failed.
+type Handler struct {
+	secret string
+
+	mu        sync.RWMutex
+	callbacks map[gitprovider.WebhookEvent][]EventHandlerFunc
+}
+
+// NewHandler returns a Handler verifying the signatures of all providers with secret. The
+// signatures aren't verified if secret is empty.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		secret:    secret,
+		callbacks: map[gitprovider.WebhookEvent][]EventHandlerFunc{},
+	}
+}
+
+// On registers fn to be called for events of the given type. The callbacks of a type are called
+// in the order they're registered, until one of them returns an error.
+func (h *Handler) On(eventType gitprovider.WebhookEvent, fn EventHandlerFunc) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[eventType] = append(h.callbacks[eventType], fn)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	event, err := Parse(r, h.secret)
+	if err != nil {
+		if errors.Is(err, ErrUnsupportedEvent) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, err.Error(), statusCodeForError(err))
+		return
+	}
+
+	h.mu.RLock()
+	callbacks := h.callbacks[event.Type]
+	h.mu.RUnlock()
+	for _, fn := range callbacks {
+		if err := fn(r.Context(), event); err != nil {
+			// Don't expose the error of the callback to the sender
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// statusCodeForError returns the status code to respond with if parsing a request fails.
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrPayloadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		// Unknown providers, or malformed payloads
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestHandler(t *testing.T) {
+	const secret = "s3cr3t"
+	pushBody := `{"object_kind":"push","ref":"refs/heads/main","before":"a","after":"b",` + gitlabProj + `}`
+	tests := []struct {
+		name           string
+		method         string
+		header         map[string]string
+		body           string
+		failCallback   bool
+		wantStatusCode int
+		wantEvents     []gitprovider.WebhookEvent
+	}{
+		{
+			name:           "dispatch to the callbacks of the event",
+			header:         map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret},
+			body:           pushBody,
+			wantStatusCode: http.StatusNoContent,
+			wantEvents:     []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPush},
+		},
+		{
+			name:           "failing callback",
+			header:         map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret},
+			body:           pushBody,
+			failCallback:   true,
+			wantStatusCode: http.StatusInternalServerError,
+			wantEvents:     []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+		},
+		{
+			name:           "no callbacks for the event",
+			header:         map[string]string{"X-Gitlab-Event": "Tag Push Hook", "X-Gitlab-Token": secret},
+			body:           `{"object_kind":"tag_push","ref":"refs/tags/v1.0.0","before":"0000","after":"b",` + gitlabProj + `}`,
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "unsupported event",
+			header:         map[string]string{"X-Gitlab-Event": "Issue Hook", "X-Gitlab-Token": secret},
+			body:           `{"object_kind":"issue",` + gitlabProj + `}`,
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "invalid token",
+			header:         map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "other"},
+			body:           pushBody,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown provider",
+			header:         map[string]string{"X-Gitea-Event": "push"},
+			body:           pushBody,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed payload",
+			header:         map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret},
+			body:           "{",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []gitprovider.WebhookEvent
+			callback := func(_ context.Context, event *Event) error {
+				events = append(events, event.Type)
+				if tt.failCallback {
+					return errors.New("failed")
+				}
+				return nil
+			}
+			h := NewHandler(secret).
+				On(gitprovider.WebhookEventPush, callback).
+				On(gitprovider.WebhookEventPush, callback)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			r := httptest.NewRequest(method, "/hook", strings.NewReader(tt.body))
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatusCode {
+				t.Errorf("ServeHTTP() status = %d, want %d", w.Code, tt.wantStatusCode)
+			}
+			if !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Errorf("ServeHTTP() dispatched %v, want %v", events, tt.wantEvents)
+			}
+		})
+	}
+}
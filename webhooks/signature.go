@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+const (
+	githubSignature256Header = "X-Hub-Signature-256"
+	githubSignatureHeader    = "X-Hub-Signature"
+	gitlabTokenHeader        = "X-Gitlab-Token"
+	stashSignatureHeader     = "X-Hub-Signature"
+)
+
+// verifyGitHubSignature verifies the HMAC of the payload, preferring SHA-256 over the legacy
+// SHA-1 signature.
+func verifyGitHubSignature(header http.Header, body []byte, secret string) error {
+	if secret == "" {
+		return nil
+	}
+	if signature := header.Get(githubSignature256Header); signature != "" {
+		return verifyHMAC(signature, "sha256=", sha256.New, body, secret)
+	}
+	if signature := header.Get(githubSignatureHeader); signature != "" {
+		return verifyHMAC(signature, "sha1=", sha1.New, body, secret)
+	}
+	return ErrMissingSignature
+}
+
+// verifyGitLabToken compares the token GitLab sends in plain text with the secret.
+func verifyGitLabToken(header http.Header, secret string) error {
+	if secret == "" {
+		return nil
+	}
+	token := header.Get(gitlabTokenHeader)
+	if token == "" {
+		return ErrMissingSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyStashSignature verifies the SHA-256 HMAC of the payload.
+func verifyStashSignature(header http.Header, body []byte, secret string) error {
+	if secret == "" {
+		return nil
+	}
+	signature := header.Get(stashSignatureHeader)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	return verifyHMAC(signature, "sha256=", sha256.New, body, secret)
+}
+
+// verifyHMAC verifies a signature of the form "{prefix}{hex encoded HMAC}".
+func verifyHMAC(signature, prefix string, hashFn func() hash.Hash, body []byte, secret string) error {
+	if !strings.HasPrefix(signature, prefix) {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(hashFn, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
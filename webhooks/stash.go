@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	stashEventHeader = "X-Event-Key"
+
+	stashRefTypeTag       = "TAG"
+	stashChangeTypeDelete = "DELETE"
+)
+
+type stashLink struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+type stashRepository struct {
+	Slug    string `json:"slug"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Clone []stashLink `json:"clone"`
+		Self  []stashLink `json:"self"`
+	} `json:"links"`
+}
+
+type stashActor struct {
+	Name string `json:"name"`
+}
+
+type stashRefsChangedPayload struct {
+	Actor      stashActor      `json:"actor"`
+	Repository stashRepository `json:"repository"`
+	Changes    []struct {
+		Ref struct {
+			ID        string `json:"id"`
+			DisplayID string `json:"displayId"`
+			Type      string `json:"type"`
+		} `json:"ref"`
+		FromHash string `json:"fromHash"`
+		ToHash   string `json:"toHash"`
+		Type     string `json:"type"`
+	} `json:"changes"`
+}
+
+type stashPullRequestPayload struct {
+	Actor       stashActor `json:"actor"`
+	PullRequest struct {
+		ID      int    `json:"id"`
+		Title   string `json:"title"`
+		FromRef struct {
+			DisplayID    string `json:"displayId"`
+			LatestCommit string `json:"latestCommit"`
+		} `json:"fromRef"`
+		ToRef struct {
+			DisplayID  string          `json:"displayId"`
+			Repository stashRepository `json:"repository"`
+		} `json:"toRef"`
+		Links struct {
+			Self []stashLink `json:"self"`
+		} `json:"links"`
+	} `json:"pullRequest"`
+}
+
+// parseStash parses the payload of the given Bitbucket Server event. Bitbucket Server has no
+// releases, and doesn't send the pushed commits. If multiple refs changed in one push, only the
+// first one is returned.
+func parseStash(eventName string, body []byte) (*Event, error) {
+	switch eventName {
+	case "repo:refs_changed":
+		payload := &stashRefsChangedPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Bitbucket Server push payload: %w", err)
+		}
+		if len(payload.Changes) == 0 {
+			return nil, fmt.Errorf("%w: Bitbucket Server push without changes", ErrUnsupportedEvent)
+		}
+		change := payload.Changes[0]
+		event := &Event{
+			Repository: payload.Repository.toEvent(),
+			Sender:     payload.Actor.Name,
+		}
+		deleted := change.Type == stashChangeTypeDelete
+		if change.Ref.Type == stashRefTypeTag {
+			event.Type = gitprovider.WebhookEventTag
+			event.Tag = &Tag{
+				Name:    change.Ref.DisplayID,
+				Deleted: deleted,
+			}
+			if !deleted {
+				event.Tag.SHA = change.ToHash
+			}
+			return event, nil
+		}
+		event.Type = gitprovider.WebhookEventPush
+		event.Push = &Push{
+			Ref:     change.Ref.ID,
+			Branch:  change.Ref.DisplayID,
+			Before:  change.FromHash,
+			After:   change.ToHash,
+			Deleted: deleted,
+			Commits: []Commit{},
+		}
+		return event, nil
+	case "pr:opened", "pr:from_ref_updated", "pr:modified", "pr:merged", "pr:declined", "pr:deleted":
+		payload := &stashPullRequestPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Bitbucket Server pull request payload: %w", err)
+		}
+		pr := payload.PullRequest
+		event := &Event{
+			Type:       gitprovider.WebhookEventPullRequest,
+			Repository: pr.ToRef.Repository.toEvent(),
+			Sender:     payload.Actor.Name,
+			PullRequest: &PullRequest{
+				Action:       stashPullRequestAction(strings.TrimPrefix(eventName, "pr:")),
+				Number:       pr.ID,
+				Title:        pr.Title,
+				SourceBranch: pr.FromRef.DisplayID,
+				TargetBranch: pr.ToRef.DisplayID,
+				SHA:          pr.FromRef.LatestCommit,
+			},
+		}
+		if len(pr.Links.Self) > 0 {
+			event.PullRequest.URL = pr.Links.Self[0].Href
+		}
+		return event, nil
+	}
+	return nil, fmt.Errorf("%w: Bitbucket Server event %q", ErrUnsupportedEvent, eventName)
+}
+
+func (r stashRepository) toEvent() Repository {
+	repo := Repository{
+		FullName: r.Project.Key + "/" + r.Slug,
+	}
+	for _, link := range r.Links.Clone {
+		if link.Name == "http" {
+			repo.CloneURL = link.Href
+		}
+	}
+	if len(r.Links.Self) > 0 {
+		repo.HTMLURL = r.Links.Self[0].Href
+	}
+	return repo
+}
+
+// stashPullRequestAction normalizes the action, i.e. the event key without the "pr:" prefix.
+func stashPullRequestAction(action string) PullRequestAction {
+	switch action {
+	case "opened":
+		return PullRequestActionOpened
+	case "from_ref_updated":
+		return PullRequestActionUpdated
+	case "declined", "deleted":
+		return PullRequestActionClosed
+	case "merged":
+		return PullRequestActionMerged
+	}
+	return PullRequestAction(action)
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// ProviderGitHub is the provider ID of GitHub, as in the github package.
+	ProviderGitHub = gitprovider.ProviderID("github")
+	// ProviderGitLab is the provider ID of GitLab, as in the gitlab package.
+	ProviderGitLab = gitprovider.ProviderID("gitlab")
+	// ProviderStash is the provider ID of Bitbucket Server, as in the stash package.
+	ProviderStash = gitprovider.ProviderID("stash")
+
+	// maxPayloadSize is the maximum size of a payload, which is the limit of GitHub.
+	maxPayloadSize = 25 << 20
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+var (
+	// ErrUnknownProvider is returned if the provider of a webhook request can't be detected, or
+	// payloads of the provider can't be parsed.
+	ErrUnknownProvider = errors.New("unknown webhook provider")
+	// ErrUnsupportedEvent is returned for events that aren't normalized, e.g. pings.
+	ErrUnsupportedEvent = errors.New("unsupported webhook event")
+	// ErrMissingSignature is returned if a secret is given, but the request isn't signed.
+	ErrMissingSignature = errors.New("webhook request isn't signed")
+	// ErrInvalidSignature is returned if the signature of the request doesn't match the secret.
+	ErrInvalidSignature = errors.New("webhook signature doesn't match")
+	// ErrPayloadTooLarge is returned if the payload exceeds the maximum size of 25 MB.
+	ErrPayloadTooLarge = errors.New("webhook payload exceeds the maximum size")
+)
+
+// PullRequestAction is the normalized action of a pull request event. Actions without
+// counterpart across providers are passed on unchanged.
+type PullRequestAction string
+
+const (
+	// PullRequestActionOpened means the pull request was opened.
+	PullRequestActionOpened = PullRequestAction("opened")
+	// PullRequestActionUpdated means new commits were pushed to the source branch.
+	PullRequestActionUpdated = PullRequestAction("updated")
+	// PullRequestActionClosed means the pull request was closed without being merged.
+	PullRequestActionClosed = PullRequestAction("closed")
+	// PullRequestActionReopened means the pull request was reopened.
+	PullRequestActionReopened = PullRequestAction("reopened")
+	// PullRequestActionMerged means the pull request was merged.
+	PullRequestActionMerged = PullRequestAction("merged")
+)
+
+// Event is a normalized webhook event. Depending on Type, exactly one of Push, PullRequest,
+// Tag and Release is set. Issue and comment events aren't parsed.
+type Event struct {
+	// Provider is the ID of the provider which sent the event.
+	Provider gitprovider.ProviderID
+	// Type is the type of the event, i.e. push, pull_request, tag or release.
+	Type gitprovider.WebhookEvent
+	// Repository is the repository the event happened in.
+	Repository Repository
+	// Sender is the username of the user who triggered the event, if the provider sends it.
+	Sender string
+
+	// Push is set for gitprovider.WebhookEventPush.
+	Push *Push
+	// PullRequest is set for gitprovider.WebhookEventPullRequest.
+	PullRequest *PullRequest
+	// Tag is set for gitprovider.WebhookEventTag.
+	Tag *Tag
+	// Release is set for gitprovider.WebhookEventRelease.
+	Release *Release
+}
+
+// Repository identifies the repository of an event.
+type Repository struct {
+	// FullName is the path of the repository including its owner, e.g. "fluxcd/flux2".
+	FullName string
+	// CloneURL is the HTTPS URL to clone the repository.
+	CloneURL string
+	// HTMLURL is the URL of the repository in the web UI.
+	HTMLURL string
+}
+
+// Push describes commits being pushed to a branch.
+type Push struct {
+	// Ref is the full reference that was pushed, e.g. "refs/heads/main".
+	Ref string
+	// Branch is the name of the branch that was pushed, e.g. "main".
+	Branch string
+	// Before is the SHA of the branch before the push.
+	Before string
+	// After is the SHA of the branch after the push.
+	After string
+	// Deleted is true if the branch was deleted.
+	Deleted bool
+	// Commits are the pushed commits, if the provider sends them.
+	Commits []Commit
+}
+
+// Commit describes a pushed commit.
+type Commit struct {
+	// SHA is the SHA of the commit.
+	SHA string
+	// Message is the commit message.
+	Message string
+	// Author is the name of the commit author.
+	Author string
+	// URL is the URL of the commit in the web UI.
+	URL string
+}
+
+// PullRequest describes a change of a pull request.
+type PullRequest struct {
+	// Action is what happened to the pull request.
+	Action PullRequestAction
+	// Number is the number of the pull request in the repository.
+	Number int
+	// Title is the title of the pull request.
+	Title string
+	// URL is the URL of the pull request in the web UI.
+	URL string
+	// SourceBranch is the branch to merge.
+	SourceBranch string
+	// TargetBranch is the branch to merge into.
+	TargetBranch string
+	// SHA is the SHA of the latest commit of the source branch.
+	SHA string
+}
+
+// Tag describes a tag being pushed or deleted.
+type Tag struct {
+	// Name is the name of the tag, e.g. "v1.0.0".
+	Name string
+	// SHA is the SHA the tag points to, which is empty if the tag was deleted.
+	SHA string
+	// Deleted is true if the tag was deleted.
+	Deleted bool
+}
+
+// Release describes a change of a release.
+type Release struct {
+	// Action is the provider-specific action, e.g. "published" for GitHub or "create" for GitLab.
+	Action string
+	// TagName is the name of the tag the release is created from.
+	TagName string
+	// Name is the title of the release.
+	Name string
+	// URL is the URL of the release in the web UI.
+	URL string
+}
+
+// Parse reads the payload of a webhook request, detects its provider, verifies its signature,
+// and returns the normalized event. The signature isn't verified if secret is empty.
+//
+// ErrUnknownProvider is returned if the provider can't be detected from the headers.
+// ErrMissingSignature or ErrInvalidSignature is returned if the signature can't be verified.
+// ErrUnsupportedEvent is returned for events that aren't normalized.
+func Parse(r *http.Request, secret string) (*Event, error) {
+	provider, err := DetectProvider(r.Header)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook payload: %w", err)
+	}
+	if len(body) > maxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	return ParsePayload(provider, r.Header, body, secret)
+}
+
+// DetectProvider returns the provider which sent a webhook request with the given headers.
+//
+// ErrUnknownProvider is returned if the headers don't match any of the supported providers.
+func DetectProvider(header http.Header) (gitprovider.ProviderID, error) {
+	switch {
+	case header.Get(githubEventHeader) != "":
+		return ProviderGitHub, nil
+	case header.Get(gitlabEventHeader) != "":
+		return ProviderGitLab, nil
+	// Bitbucket Cloud uses the same event header, but sends a hook UUID as well
+	case header.Get(stashEventHeader) != "" && header.Get("X-Hook-UUID") == "":
+		return ProviderStash, nil
+	}
+	return "", ErrUnknownProvider
+}
+
+// ParsePayload verifies the signature of the payload sent by the given provider, and returns
+// the normalized event. The signature isn't verified if secret is empty.
+//
+// ErrUnknownProvider is returned if the payloads of the provider can't be parsed.
+// ErrMissingSignature or ErrInvalidSignature is returned if the signature can't be verified.
+// ErrUnsupportedEvent is returned for events that aren't normalized.
+func ParsePayload(provider gitprovider.ProviderID, header http.Header, body []byte, secret string) (*Event, error) {
+	var event *Event
+	var err error
+	switch provider {
+	case ProviderGitHub:
+		if err := verifyGitHubSignature(header, body, secret); err != nil {
+			return nil, err
+		}
+		event, err = parseGitHub(header.Get(githubEventHeader), body)
+	case ProviderGitLab:
+		if err := verifyGitLabToken(header, secret); err != nil {
+			return nil, err
+		}
+		event, err = parseGitLab(header.Get(gitlabEventHeader), body)
+	case ProviderStash:
+		if err := verifyStashSignature(header, body, secret); err != nil {
+			return nil, err
+		}
+		event, err = parseStash(header.Get(stashEventHeader), body)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	event.Provider = provider
+	return event, nil
+}
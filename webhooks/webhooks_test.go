@@ -0,0 +1,327 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	githubRepo = `"repository":{"full_name":"org/repo","clone_url":"https://github.com/org/repo.git","html_url":"https://github.com/org/repo"},"sender":{"login":"octocat"}`
+	gitlabProj = `"project":{"path_with_namespace":"group/repo","git_http_url":"https://gitlab.com/group/repo.git","web_url":"https://gitlab.com/group/repo"}`
+	stashRepo  = `{"slug":"repo","project":{"key":"PRJ"},"links":{"clone":[{"href":"ssh://git@stash.example.com/prj/repo.git","name":"ssh"},{"href":"https://stash.example.com/scm/prj/repo.git","name":"http"}],"self":[{"href":"https://stash.example.com/projects/PRJ/repos/repo/browse"}]}}`
+)
+
+var (
+	wantGitHubRepo = Repository{FullName: "org/repo", CloneURL: "https://github.com/org/repo.git", HTMLURL: "https://github.com/org/repo"}
+	wantGitLabRepo = Repository{FullName: "group/repo", CloneURL: "https://gitlab.com/group/repo.git", HTMLURL: "https://gitlab.com/group/repo"}
+	wantStashRepo  = Repository{FullName: "PRJ/repo", CloneURL: "https://stash.example.com/scm/prj/repo.git", HTMLURL: "https://stash.example.com/projects/PRJ/repos/repo/browse"}
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  map[string]string
+		body    string
+		want    *Event
+		wantErr error
+	}{
+		{
+			name:   "GitHub push",
+			header: map[string]string{"X-GitHub-Event": "push"},
+			body:   `{"ref":"refs/heads/main","before":"a","after":"b",` + githubRepo + `,"commits":[{"id":"b","message":"fix","url":"https://github.com/org/repo/commit/b","author":{"name":"Octo Cat"}}]}`,
+			want: &Event{
+				Provider:   ProviderGitHub,
+				Type:       gitprovider.WebhookEventPush,
+				Repository: wantGitHubRepo,
+				Sender:     "octocat",
+				Push: &Push{
+					Ref:     "refs/heads/main",
+					Branch:  "main",
+					Before:  "a",
+					After:   "b",
+					Commits: []Commit{{SHA: "b", Message: "fix", Author: "Octo Cat", URL: "https://github.com/org/repo/commit/b"}},
+				},
+			},
+		},
+		{
+			name:   "GitHub tag",
+			header: map[string]string{"X-GitHub-Event": "push"},
+			body:   `{"ref":"refs/tags/v1.0.0","before":"0000","after":"b",` + githubRepo + `,"commits":[]}`,
+			want: &Event{
+				Provider:   ProviderGitHub,
+				Type:       gitprovider.WebhookEventTag,
+				Repository: wantGitHubRepo,
+				Sender:     "octocat",
+				Tag:        &Tag{Name: "v1.0.0", SHA: "b"},
+			},
+		},
+		{
+			name:   "GitHub merged pull request",
+			header: map[string]string{"X-GitHub-Event": "pull_request"},
+			body:   `{"action":"closed","number":3,` + githubRepo + `,"pull_request":{"title":"Feature","html_url":"https://github.com/org/repo/pull/3","merged":true,"head":{"ref":"feature","sha":"c"},"base":{"ref":"main"}}}`,
+			want: &Event{
+				Provider:   ProviderGitHub,
+				Type:       gitprovider.WebhookEventPullRequest,
+				Repository: wantGitHubRepo,
+				Sender:     "octocat",
+				PullRequest: &PullRequest{
+					Action:       PullRequestActionMerged,
+					Number:       3,
+					Title:        "Feature",
+					URL:          "https://github.com/org/repo/pull/3",
+					SourceBranch: "feature",
+					TargetBranch: "main",
+					SHA:          "c",
+				},
+			},
+		},
+		{
+			name:   "GitHub release",
+			header: map[string]string{"X-GitHub-Event": "release"},
+			body:   `{"action":"published",` + githubRepo + `,"release":{"tag_name":"v1.0.0","name":"First","html_url":"https://github.com/org/repo/releases/tag/v1.0.0"}}`,
+			want: &Event{
+				Provider:   ProviderGitHub,
+				Type:       gitprovider.WebhookEventRelease,
+				Repository: wantGitHubRepo,
+				Sender:     "octocat",
+				Release:    &Release{Action: "published", TagName: "v1.0.0", Name: "First", URL: "https://github.com/org/repo/releases/tag/v1.0.0"},
+			},
+		},
+		{
+			name:    "GitHub ping",
+			header:  map[string]string{"X-GitHub-Event": "ping"},
+			body:    `{"zen":"Keep it simple."}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+		{
+			name:   "GitLab push of a deleted branch",
+			header: map[string]string{"X-Gitlab-Event": "Push Hook"},
+			body:   `{"ref":"refs/heads/feature","before":"a","after":"0000000000000000000000000000000000000000","checkout_sha":null,"user_username":"jdoe",` + gitlabProj + `,"commits":[]}`,
+			want: &Event{
+				Provider:   ProviderGitLab,
+				Type:       gitprovider.WebhookEventPush,
+				Repository: wantGitLabRepo,
+				Sender:     "jdoe",
+				Push: &Push{
+					Ref:     "refs/heads/feature",
+					Branch:  "feature",
+					Before:  "a",
+					After:   "0000000000000000000000000000000000000000",
+					Deleted: true,
+					Commits: []Commit{},
+				},
+			},
+		},
+		{
+			name:   "GitLab deleted tag",
+			header: map[string]string{"X-Gitlab-Event": "Tag Push Hook"},
+			body:   `{"ref":"refs/tags/v1.0.0","before":"b","after":"0000000000000000000000000000000000000000","checkout_sha":null,"user_username":"jdoe",` + gitlabProj + `}`,
+			want: &Event{
+				Provider:   ProviderGitLab,
+				Type:       gitprovider.WebhookEventTag,
+				Repository: wantGitLabRepo,
+				Sender:     "jdoe",
+				Tag:        &Tag{Name: "v1.0.0", Deleted: true},
+			},
+		},
+		{
+			name:   "GitLab merge request update with new commits",
+			header: map[string]string{"X-Gitlab-Event": "Merge Request Hook"},
+			body:   `{"user":{"username":"jdoe"},` + gitlabProj + `,"object_attributes":{"iid":5,"title":"Feature","url":"https://gitlab.com/group/repo/-/merge_requests/5","source_branch":"feature","target_branch":"main","action":"update","oldrev":"a","last_commit":{"id":"c"}}}`,
+			want: &Event{
+				Provider:   ProviderGitLab,
+				Type:       gitprovider.WebhookEventPullRequest,
+				Repository: wantGitLabRepo,
+				Sender:     "jdoe",
+				PullRequest: &PullRequest{
+					Action:       PullRequestActionUpdated,
+					Number:       5,
+					Title:        "Feature",
+					URL:          "https://gitlab.com/group/repo/-/merge_requests/5",
+					SourceBranch: "feature",
+					TargetBranch: "main",
+					SHA:          "c",
+				},
+			},
+		},
+		{
+			name:   "GitLab release",
+			header: map[string]string{"X-Gitlab-Event": "Release Hook"},
+			body:   `{"action":"create","tag":"v1.0.0","name":"First","url":"https://gitlab.com/group/repo/-/releases/v1.0.0",` + gitlabProj + `}`,
+			want: &Event{
+				Provider:   ProviderGitLab,
+				Type:       gitprovider.WebhookEventRelease,
+				Repository: wantGitLabRepo,
+				Release:    &Release{Action: "create", TagName: "v1.0.0", Name: "First", URL: "https://gitlab.com/group/repo/-/releases/v1.0.0"},
+			},
+		},
+		{
+			name:   "Bitbucket Server push",
+			header: map[string]string{"X-Event-Key": "repo:refs_changed"},
+			body:   `{"actor":{"name":"admin"},"repository":` + stashRepo + `,"changes":[{"ref":{"id":"refs/heads/main","displayId":"main","type":"BRANCH"},"fromHash":"a","toHash":"b","type":"UPDATE"}]}`,
+			want: &Event{
+				Provider:   ProviderStash,
+				Type:       gitprovider.WebhookEventPush,
+				Repository: wantStashRepo,
+				Sender:     "admin",
+				Push:       &Push{Ref: "refs/heads/main", Branch: "main", Before: "a", After: "b", Commits: []Commit{}},
+			},
+		},
+		{
+			name:   "Bitbucket Server tag",
+			header: map[string]string{"X-Event-Key": "repo:refs_changed"},
+			body:   `{"actor":{"name":"admin"},"repository":` + stashRepo + `,"changes":[{"ref":{"id":"refs/tags/v1.0.0","displayId":"v1.0.0","type":"TAG"},"fromHash":"0000","toHash":"b","type":"ADD"}]}`,
+			want: &Event{
+				Provider:   ProviderStash,
+				Type:       gitprovider.WebhookEventTag,
+				Repository: wantStashRepo,
+				Sender:     "admin",
+				Tag:        &Tag{Name: "v1.0.0", SHA: "b"},
+			},
+		},
+		{
+			name:   "Bitbucket Server declined pull request",
+			header: map[string]string{"X-Event-Key": "pr:declined"},
+			body:   `{"actor":{"name":"admin"},"pullRequest":{"id":7,"title":"Feature","fromRef":{"displayId":"feature","latestCommit":"c"},"toRef":{"displayId":"main","repository":` + stashRepo + `},"links":{"self":[{"href":"https://stash.example.com/projects/PRJ/repos/repo/pull-requests/7"}]}}}`,
+			want: &Event{
+				Provider:   ProviderStash,
+				Type:       gitprovider.WebhookEventPullRequest,
+				Repository: wantStashRepo,
+				Sender:     "admin",
+				PullRequest: &PullRequest{
+					Action:       PullRequestActionClosed,
+					Number:       7,
+					Title:        "Feature",
+					URL:          "https://stash.example.com/projects/PRJ/repos/repo/pull-requests/7",
+					SourceBranch: "feature",
+					TargetBranch: "main",
+					SHA:          "c",
+				},
+			},
+		},
+		{
+			name:    "Bitbucket Cloud",
+			header:  map[string]string{"X-Event-Key": "repo:push", "X-Hook-UUID": "uuid"},
+			body:    `{}`,
+			wantErr: ErrUnknownProvider,
+		},
+		{
+			name:    "no provider headers",
+			body:    `{}`,
+			wantErr: ErrUnknownProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(tt.body))
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			got, err := Parse(r, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("Parse() returned diff (want -> got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestParsePayload_Signature(t *testing.T) {
+	const secret = "s3cr3t"
+	body := []byte(`{"ref":"refs/heads/main",` + githubRepo + `}`)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name     string
+		provider gitprovider.ProviderID
+		header   map[string]string
+		wantErr  error
+	}{
+		{
+			name:     "GitHub valid signature",
+			provider: ProviderGitHub,
+			header:   map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": signature},
+		},
+		{
+			name:     "GitHub invalid signature",
+			provider: ProviderGitHub,
+			header:   map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=00"},
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "GitHub missing signature",
+			provider: ProviderGitHub,
+			header:   map[string]string{"X-GitHub-Event": "push"},
+			wantErr:  ErrMissingSignature,
+		},
+		{
+			name:     "GitLab valid token",
+			provider: ProviderGitLab,
+			header:   map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret},
+		},
+		{
+			name:     "GitLab invalid token",
+			provider: ProviderGitLab,
+			header:   map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "other"},
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "Bitbucket Server valid signature",
+			provider: ProviderStash,
+			header:   map[string]string{"X-Event-Key": "pr:opened", "X-Hub-Signature": signature},
+		},
+		{
+			name:     "Bitbucket Server signature with the wrong algorithm",
+			provider: ProviderStash,
+			header:   map[string]string{"X-Event-Key": "pr:opened", "X-Hub-Signature": strings.Replace(signature, "sha256", "sha1", 1)},
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "unknown provider",
+			provider: gitprovider.ProviderID("gitea"),
+			wantErr:  ErrUnknownProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.header {
+				header.Set(key, value)
+			}
+			_, err := ParsePayload(tt.provider, header, body, secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePayload() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}